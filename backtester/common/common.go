@@ -5,6 +5,7 @@ import (
 	"regexp"
 	"strings"
 
+	"github.com/shopspring/decimal"
 	gctorder "github.com/thrasher-corp/gocryptotrader/exchanges/order"
 	"github.com/thrasher-corp/gocryptotrader/log"
 )
@@ -15,6 +16,19 @@ func CanTransact(side gctorder.Side) bool {
 	return side.IsLong() || side.IsShort() || side == gctorder.ClosePosition
 }
 
+// Round rounds the value to the decimal places using the rounding mode.
+// An empty or unrecognised mode uses the default RoundHalfEven
+func Round(d decimal.Decimal, places int32, mode string) decimal.Decimal {
+	switch mode {
+	case RoundHalfUp:
+		return d.Round(places)
+	case RoundTowardZero:
+		return d.Truncate(places)
+	default:
+		return d.RoundBank(places)
+	}
+}
+
 // DataTypeToInt converts the config string value into an int
 func DataTypeToInt(dataType string) (int64, error) {
 	switch dataType {
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/shopspring/decimal"
 	gctorder "github.com/thrasher-corp/gocryptotrader/exchanges/order"
 )
 
@@ -97,6 +98,55 @@ func TestCanTransact(t *testing.T) {
 	}
 }
 
+func TestRound(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		mode     string
+		value    string
+		expected string
+	}{
+		{"", "0.125", "0.12"},
+		{RoundHalfEven, "0.125", "0.12"},
+		{RoundHalfEven, "0.135", "0.14"},
+		{RoundHalfEven, "-0.125", "-0.12"},
+		{RoundHalfEven, "0.1251", "0.13"},
+		{RoundHalfUp, "0.125", "0.13"},
+		{RoundHalfUp, "-0.125", "-0.13"},
+		{RoundTowardZero, "0.129", "0.12"},
+		{RoundTowardZero, "-0.129", "-0.12"},
+	} {
+		received := Round(decimal.RequireFromString(tc.value), 2, tc.mode)
+		if !received.Equal(decimal.RequireFromString(tc.expected)) {
+			t.Errorf("mode '%v' value '%v' received '%v' expected '%v'", tc.mode, tc.value, received, tc.expected)
+		}
+	}
+}
+
+func TestRoundIsSymmetric(t *testing.T) {
+	t.Parallel()
+	// rounding a buy's positive cash flow and a sell's negative cash flow of
+	// the same size must differ only in sign so neither side is favoured
+	for _, mode := range []string{RoundHalfEven, RoundHalfUp, RoundTowardZero} {
+		for _, value := range []string{"1.005", "1.015", "2.5", "0.0049"} {
+			d := decimal.RequireFromString(value)
+			buy, sell := Round(d, 2, mode), Round(d.Neg(), 2, mode)
+			if !buy.Equal(sell.Neg()) {
+				t.Errorf("mode '%v' value '%v' received buy '%v' sell '%v' expected symmetry", mode, value, buy, sell)
+			}
+		}
+	}
+	// half even rounding of consecutive halves has no cumulative bias
+	var total, rounded decimal.Decimal
+	for i := int64(0); i < 100; i++ {
+		d := decimal.New(2*i+1, -2).Mul(decimal.NewFromInt(5))
+		total = total.Add(d)
+		rounded = rounded.Add(Round(d, 1, RoundHalfEven))
+	}
+	if !total.Equal(rounded) {
+		t.Errorf("received '%v' expected '%v'", rounded, total)
+	}
+}
+
 func TestDataTypeConversion(t *testing.T) {
 	t.Parallel()
 	for _, ti := range []struct {
@@ -25,6 +25,20 @@ const (
 	DataTrade
 )
 
+// Rounding modes applied when conforming prices and fees to a precision.
+// RoundHalfEven, also known as banker's rounding, is the default as rounding
+// halves to the nearest even digit does not bias results in either direction
+// over the many values rounded during a long backtest
+const (
+	// RoundHalfEven rounds to the nearest value, with halves rounded to the
+	// nearest even digit
+	RoundHalfEven = "half-even"
+	// RoundHalfUp rounds to the nearest value, with halves rounded away from zero
+	RoundHalfUp = "half-up"
+	// RoundTowardZero truncates digits beyond the precision
+	RoundTowardZero = "toward-zero"
+)
+
 var (
 	// ErrNilArguments is a common error response to highlight that nils were passed in
 	// when they should not have been
@@ -83,6 +97,7 @@ type DataEventHandler interface {
 	GetHighPrice() decimal.Decimal
 	GetLowPrice() decimal.Decimal
 	GetOpenPrice() decimal.Decimal
+	GetVolume() decimal.Decimal
 }
 
 // Directioner dictates the side of an order
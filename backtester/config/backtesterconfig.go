@@ -69,3 +69,15 @@ func GenerateDefaultConfig() (*BacktesterConfig, error) {
 		},
 	}, nil
 }
+
+// Validate ensures the checkpoint interval is not negative
+// and that a path is set to save checkpoints to
+func (c *Checkpoint) Validate() error {
+	if c.Interval < 0 {
+		return fmt.Errorf("%w interval %v must not be negative", errInvalidCheckpoint, c.Interval)
+	}
+	if c.Interval > 0 && c.Path == "" {
+		return fmt.Errorf("%w a path is required to save checkpoints to", errInvalidCheckpoint)
+	}
+	return nil
+}
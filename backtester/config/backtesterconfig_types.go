@@ -1,6 +1,7 @@
 package config
 
 import (
+	"errors"
 	"path/filepath"
 	"runtime"
 
@@ -16,6 +17,8 @@ var (
 	DefaultBTConfigDir = filepath.Join(DefaultBTDir, "config.json")
 )
 
+var errInvalidCheckpoint = errors.New("invalid checkpoint settings, please check your config")
+
 // BacktesterConfig contains the configuration for the backtester
 type BacktesterConfig struct {
 	PluginPath    string         `json:"plugin-path"`
@@ -23,6 +26,7 @@ type BacktesterConfig struct {
 	Verbose       bool           `json:"verbose"`
 	LogSubheaders bool           `json:"log-subheaders"`
 	Report        Report         `json:"report"`
+	Checkpoint    Checkpoint     `json:"checkpoint"`
 	GRPC          GRPC           `json:"grpc"`
 	UseCMDColours bool           `json:"use-cmd-colours"`
 	Colours       common.Colours `json:"cmd-colours"`
@@ -36,6 +40,13 @@ type Report struct {
 	DarkMode       bool   `json:"dark-mode"`
 }
 
+// Checkpoint contains the settings for saving checkpoints of single
+// strategy runs which can be resumed after an interruption
+type Checkpoint struct {
+	Interval int64  `json:"interval"`
+	Path     string `json:"path"`
+}
+
 // GRPC holds the GRPC configuration
 type GRPC struct {
 	Username string `json:"username"`
@@ -47,3 +47,27 @@ func TestGenerateDefaultConfig(t *testing.T) {
 		t.Errorf("received '%v' expected '%v'", cfg.PrintLogo, true)
 	}
 }
+
+func TestValidateCheckpoint(t *testing.T) {
+	t.Parallel()
+	c := &Checkpoint{}
+	err := c.Validate()
+	if !errors.Is(err, nil) {
+		t.Errorf("received '%v' expected '%v'", err, nil)
+	}
+	c.Interval = -1
+	err = c.Validate()
+	if !errors.Is(err, errInvalidCheckpoint) {
+		t.Errorf("received '%v' expected '%v'", err, errInvalidCheckpoint)
+	}
+	c.Interval = 5
+	err = c.Validate()
+	if !errors.Is(err, errInvalidCheckpoint) {
+		t.Errorf("received '%v' expected '%v'", err, errInvalidCheckpoint)
+	}
+	c.Path = "checkpoint"
+	err = c.Validate()
+	if !errors.Is(err, nil) {
+		t.Errorf("received '%v' expected '%v'", err, nil)
+	}
+}
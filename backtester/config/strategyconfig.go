@@ -1,13 +1,21 @@
 package config
 
 import (
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"os"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/shopspring/decimal"
 	"github.com/thrasher-corp/gocryptotrader/backtester/common"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventhandlers/exchange"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventhandlers/statistics"
 	"github.com/thrasher-corp/gocryptotrader/backtester/eventhandlers/strategies"
 	"github.com/thrasher-corp/gocryptotrader/backtester/eventhandlers/strategies/base"
 	gctcommon "github.com/thrasher-corp/gocryptotrader/common"
@@ -32,6 +40,20 @@ func ReadStrategyConfigFromFile(path string) (*Config, error) {
 	return resp, err
 }
 
+// Hash returns the hex encoded SHA-256 hash of the config's JSON,
+// identifying runs which used an identical strategy config
+func (c *Config) Hash() (string, error) {
+	if c == nil {
+		return "", fmt.Errorf("%w nil config", common.ErrNilArguments)
+	}
+	data, err := json.Marshal(c)
+	if err != nil {
+		return "", err
+	}
+	hash := sha256.Sum256(data)
+	return hex.EncodeToString(hash[:]), nil
+}
+
 // Validate checks all config settings
 func (c *Config) Validate() error {
 	if c == nil {
@@ -49,9 +71,65 @@ func (c *Config) Validate() error {
 	if err != nil {
 		return err
 	}
+	err = c.validateStatisticSettings()
+	if err != nil {
+		return err
+	}
 	return c.validateMinMaxes()
 }
 
+// validateStatisticSettings ensures the cost basis method, when set,
+// is one which the statistics package can match tax lots with and that
+// the trading calendar is one which returns can be annualised with.
+// A rolling beta window, when set, must hold at least two returns and
+// value at risk confidence levels must be percentages between 0 and 100,
+// as must the performance fee, which cannot crystallise at a negative interval.
+// Wash trade detection cannot use a negative window or price tolerance
+// and the capacity participation target must be a percentage
+func (c *Config) validateStatisticSettings() error {
+	c.StatisticSettings.CostBasisMethod = strings.ToLower(c.StatisticSettings.CostBasisMethod)
+	switch c.StatisticSettings.CostBasisMethod {
+	case "", statistics.CostBasisFIFO, statistics.CostBasisLIFO, statistics.CostBasisAverage:
+	default:
+		return fmt.Errorf("%w '%v', must be fifo, lifo or average", errInvalidCostBasisMethod, c.StatisticSettings.CostBasisMethod)
+	}
+	c.StatisticSettings.TradingCalendar = strings.ToLower(c.StatisticSettings.TradingCalendar)
+	switch c.StatisticSettings.TradingCalendar {
+	case "":
+		c.StatisticSettings.TradingCalendar = statistics.TradingCalendarContinuous
+	case statistics.TradingCalendarContinuous, statistics.TradingCalendarBusinessDays:
+	default:
+		return fmt.Errorf("%w '%v', must be continuous or business-days", errInvalidTradingCalendar, c.StatisticSettings.TradingCalendar)
+	}
+	if c.StatisticSettings.RollingBetaWindow < 0 || c.StatisticSettings.RollingBetaWindow == 1 {
+		return fmt.Errorf("%w %v, must be at least 2", errInvalidRollingBetaWindow, c.StatisticSettings.RollingBetaWindow)
+	}
+	for i := range c.StatisticSettings.ValueAtRiskConfidenceLevels {
+		if !c.StatisticSettings.ValueAtRiskConfidenceLevels[i].IsPositive() || c.StatisticSettings.ValueAtRiskConfidenceLevels[i].GreaterThanOrEqual(decimal.NewFromInt(100)) {
+			return fmt.Errorf("%w %v, must be between 0 and 100", errInvalidConfidenceLevel, c.StatisticSettings.ValueAtRiskConfidenceLevels[i])
+		}
+	}
+	if c.StatisticSettings.AutocorrelationLags < 0 {
+		return fmt.Errorf("%w %v, cannot be negative", errInvalidAutocorrelationLags, c.StatisticSettings.AutocorrelationLags)
+	}
+	if c.StatisticSettings.PerformanceFeePercent.IsNegative() || c.StatisticSettings.PerformanceFeePercent.GreaterThanOrEqual(decimal.NewFromInt(100)) {
+		return fmt.Errorf("%w %v%%, must be between 0 and 100", errInvalidPerformanceFee, c.StatisticSettings.PerformanceFeePercent)
+	}
+	if c.StatisticSettings.PerformanceFeeIntervalDays < 0 {
+		return fmt.Errorf("%w interval of %v days cannot be negative", errInvalidPerformanceFee, c.StatisticSettings.PerformanceFeeIntervalDays)
+	}
+	if c.StatisticSettings.WashTradeWindowMinutes < 0 || c.StatisticSettings.WashTradePriceTolerancePercent.IsNegative() {
+		return fmt.Errorf("%w window of %v minutes and price tolerance of %v%% cannot be negative", errInvalidWashTradeSettings, c.StatisticSettings.WashTradeWindowMinutes, c.StatisticSettings.WashTradePriceTolerancePercent)
+	}
+	if c.StatisticSettings.WashTradeWindowMinutes == 0 && c.StatisticSettings.WashTradePriceTolerancePercent.IsPositive() {
+		return fmt.Errorf("%w price tolerance of %v%% requires a window", errInvalidWashTradeSettings, c.StatisticSettings.WashTradePriceTolerancePercent)
+	}
+	if c.StatisticSettings.CapacityParticipationPercent.IsNegative() || c.StatisticSettings.CapacityParticipationPercent.GreaterThan(decimal.NewFromInt(100)) {
+		return fmt.Errorf("%w %v%%, must be between 0 and 100", errInvalidCapacityParticipation, c.StatisticSettings.CapacityParticipationPercent)
+	}
+	return nil
+}
+
 // validate ensures no one sets bad config values on purpose
 func (m *MinMax) validate() error {
 	if m.MaximumSize.IsNegative() {
@@ -104,6 +182,46 @@ func (c *Config) validateStrategySettings() error {
 	if c.FundingSettings.UseExchangeLevelFunding && !c.StrategySettings.SimultaneousSignalProcessing {
 		return errSimultaneousProcessingRequired
 	}
+	c.StrategySettings.OpposingSignalPolicy = strings.ToLower(c.StrategySettings.OpposingSignalPolicy)
+	switch c.StrategySettings.OpposingSignalPolicy {
+	case "":
+		c.StrategySettings.OpposingSignalPolicy = "hedge"
+	case "hedge", "net", "reject":
+	default:
+		return fmt.Errorf("%w '%v', must be hedge, net or reject", errInvalidOpposingSignalPolicy, c.StrategySettings.OpposingSignalPolicy)
+	}
+	c.StrategySettings.SimultaneousSignalPriority = strings.ToLower(c.StrategySettings.SimultaneousSignalPriority)
+	switch c.StrategySettings.SimultaneousSignalPriority {
+	case "":
+		c.StrategySettings.SimultaneousSignalPriority = "currency"
+	case "currency", "signal-strength", "allocated-amount":
+	default:
+		return fmt.Errorf("%w '%v', must be currency, signal-strength or allocated-amount", errInvalidSignalPriority, c.StrategySettings.SimultaneousSignalPriority)
+	}
+	c.StrategySettings.FirstCandlePolicy = strings.ToLower(c.StrategySettings.FirstCandlePolicy)
+	switch c.StrategySettings.FirstCandlePolicy {
+	case "":
+		c.StrategySettings.FirstCandlePolicy = "skip"
+	case "skip", "open", "trade":
+	default:
+		return fmt.Errorf("%w '%v', must be skip, open or trade", errInvalidFirstCandlePolicy, c.StrategySettings.FirstCandlePolicy)
+	}
+	c.StrategySettings.EndOfDataPolicy = strings.ToLower(c.StrategySettings.EndOfDataPolicy)
+	switch c.StrategySettings.EndOfDataPolicy {
+	case "":
+		c.StrategySettings.EndOfDataPolicy = "mark-to-market"
+	case "mark-to-market", "force-close", "error":
+	default:
+		return fmt.Errorf("%w '%v', must be mark-to-market, force-close or error", errInvalidEndOfDataPolicy, c.StrategySettings.EndOfDataPolicy)
+	}
+	c.StrategySettings.RoundingMode = strings.ToLower(c.StrategySettings.RoundingMode)
+	switch c.StrategySettings.RoundingMode {
+	case "":
+		c.StrategySettings.RoundingMode = common.RoundHalfEven
+	case common.RoundHalfEven, common.RoundHalfUp, common.RoundTowardZero:
+	default:
+		return fmt.Errorf("%w '%v', must be %v, %v or %v", errInvalidRoundingMode, c.StrategySettings.RoundingMode, common.RoundHalfEven, common.RoundHalfUp, common.RoundTowardZero)
+	}
 	if len(c.FundingSettings.ExchangeLevelFunding) > 0 && !c.FundingSettings.UseExchangeLevelFunding {
 		return errExchangeLevelFundingRequired
 	}
@@ -122,6 +240,30 @@ func (c *Config) validateStrategySettings() error {
 			}
 		}
 	}
+	if c.FundingSettings.UseQuoteFundingBasket && !c.FundingSettings.UseExchangeLevelFunding {
+		return fmt.Errorf("%w, exchange level funding must be enabled", errInvalidQuoteFundingBasket)
+	}
+	if len(c.FundingSettings.QuoteFundingPriority) > 0 && !c.FundingSettings.UseQuoteFundingBasket {
+		return fmt.Errorf("%w, quote funding priority set while the quote funding basket is disabled", errInvalidQuoteFundingBasket)
+	}
+	for i := range c.FundingSettings.QuoteFundingPriority {
+		if !c.FundingSettings.QuoteFundingPriority[i].IsStableCurrency() {
+			return fmt.Errorf("%w '%v', quote funding priority must only contain stablecoins", errInvalidQuoteFundingBasket, c.FundingSettings.QuoteFundingPriority[i])
+		}
+	}
+	for i := range c.FundingSettings.CapitalFlows {
+		flow := c.FundingSettings.CapitalFlows[i]
+		switch {
+		case flow.ExchangeName == "", flow.Currency.IsEmpty():
+			return fmt.Errorf("%w, exchange name and currency must be set", errInvalidCapitalFlow)
+		case flow.Time.IsZero():
+			return fmt.Errorf("%w for %v %v %v, time must be set", errInvalidCapitalFlow, flow.ExchangeName, flow.Asset, flow.Currency)
+		case flow.Amount.IsZero():
+			return fmt.Errorf("%w for %v %v %v, amount cannot be zero", errInvalidCapitalFlow, flow.ExchangeName, flow.Asset, flow.Currency)
+		case flow.Asset.IsFutures():
+			return fmt.Errorf("%w for %v %v %v, only spot funding is supported", errInvalidCapitalFlow, flow.ExchangeName, flow.Asset, flow.Currency)
+		}
+	}
 	strats := strategies.GetStrategies()
 	for i := range strats {
 		if strings.EqualFold(strats[i].Name(), c.StrategySettings.Name) {
@@ -193,6 +335,16 @@ func (c *Config) validateCurrencySettings() error {
 					c.CurrencySettings[i].SpotDetails.InitialBaseFunds = &decimal.Zero
 				}
 			}
+			if c.CurrencySettings[i].SpotDetails.InitialBaseEntryPrice != nil {
+				if !c.CurrencySettings[i].SpotDetails.InitialBaseEntryPrice.IsPositive() {
+					return fmt.Errorf("%w '%v', must be greater than zero", errInvalidInitialBaseEntryPrice, c.CurrencySettings[i].SpotDetails.InitialBaseEntryPrice)
+				}
+				if c.FundingSettings.UseExchangeLevelFunding ||
+					c.CurrencySettings[i].SpotDetails.InitialBaseFunds == nil ||
+					!c.CurrencySettings[i].SpotDetails.InitialBaseFunds.IsPositive() {
+					return fmt.Errorf("%w, requires initial base funds without exchange level funding", errInvalidInitialBaseEntryPrice)
+				}
+			}
 		}
 		if c.CurrencySettings[i].Base.IsEmpty() {
 			return errUnsetCurrency
@@ -212,6 +364,236 @@ func (c *Config) validateCurrencySettings() error {
 			c.CurrencySettings[i].MinimumSlippagePercent.GreaterThan(c.CurrencySettings[i].MaximumSlippagePercent) {
 			return errBadSlippageRates
 		}
+		if len(c.CurrencySettings[i].SlippageTiers) > 0 {
+			hasSlippage = true
+			err := validateSlippageTiers(c.CurrencySettings[i].SlippageTiers)
+			if err != nil {
+				return err
+			}
+		}
+		if c.CurrencySettings[i].SlippageCapPercent.IsNegative() {
+			return fmt.Errorf("%w slippage cap percent %v", errBadSlippageRates, c.CurrencySettings[i].SlippageCapPercent)
+		}
+		if c.CurrencySettings[i].ExecutionInterval != 0 || c.CurrencySettings[i].ExecutionCSVPath != "" {
+			err := c.validateExecutionInterval(&c.CurrencySettings[i])
+			if err != nil {
+				return err
+			}
+		}
+		if c.CurrencySettings[i].SlippageAsymmetry.IsNegative() || c.CurrencySettings[i].SlippageAsymmetry.GreaterThan(decimal.NewFromInt(1)) {
+			return fmt.Errorf("%w slippage asymmetry %v must be between 0 and 1", errBadSlippageRates, c.CurrencySettings[i].SlippageAsymmetry)
+		}
+		if c.CurrencySettings[i].LiquidationSlippagePercent.IsNegative() || c.CurrencySettings[i].LiquidationSlippagePercent.GreaterThanOrEqual(decimal.NewFromInt(100)) {
+			return fmt.Errorf("%w slippage percent %v must be at least 0 and below 100", errInvalidLiquidationPenalty, c.CurrencySettings[i].LiquidationSlippagePercent)
+		}
+		if c.CurrencySettings[i].LiquidationFeeRate.IsNegative() {
+			return fmt.Errorf("%w fee rate %v cannot be negative", errInvalidLiquidationPenalty, c.CurrencySettings[i].LiquidationFeeRate)
+		}
+		if c.CurrencySettings[i].BorrowRatePercent.IsNegative() {
+			return fmt.Errorf("%w %v cannot be negative", errInvalidBorrowRate, c.CurrencySettings[i].BorrowRatePercent)
+		}
+		if c.CurrencySettings[i].BorrowRatePercent.IsPositive() && !c.CurrencySettings[i].Asset.IsFutures() {
+			return fmt.Errorf("%w %v %v cannot hold short positions, only futures can be shorted", errInvalidBorrowRate, c.CurrencySettings[i].ExchangeName, c.CurrencySettings[i].Asset)
+		}
+		if len(c.CurrencySettings[i].FundingRates) > 0 || c.CurrencySettings[i].FundingRateCSVPath != "" {
+			if !c.CurrencySettings[i].Asset.IsFutures() {
+				return fmt.Errorf("%w %v %v does not pay funding", errInvalidFundingRates, c.CurrencySettings[i].ExchangeName, c.CurrencySettings[i].Asset)
+			}
+			if _, err := c.CurrencySettings[i].GetFundingRates(); err != nil {
+				return err
+			}
+		}
+		if c.CurrencySettings[i].TradingSession != nil {
+			_, _, _, err := c.CurrencySettings[i].TradingSession.Parse()
+			if err != nil {
+				return err
+			}
+		}
+		if len(c.CurrencySettings[i].FeeTiers) > 0 {
+			err := validateFeeTiers(c.CurrencySettings[i].FeeTiers)
+			if err != nil {
+				return err
+			}
+		}
+		if c.CurrencySettings[i].LatencyDistribution != nil {
+			err := c.CurrencySettings[i].LatencyDistribution.Validate()
+			if err != nil {
+				return err
+			}
+		}
+		if c.CurrencySettings[i].DrawdownStopOut != nil {
+			err := c.CurrencySettings[i].DrawdownStopOut.Validate()
+			if err != nil {
+				return err
+			}
+		}
+		if c.CurrencySettings[i].LosingStreakBreaker != nil {
+			err := c.CurrencySettings[i].LosingStreakBreaker.Validate()
+			if err != nil {
+				return err
+			}
+		}
+		if c.CurrencySettings[i].OrderbookImbalanceDepth < 0 {
+			return fmt.Errorf("%w %v", errInvalidOrderbookImbalanceDepth, c.CurrencySettings[i].OrderbookImbalanceDepth)
+		}
+		if c.CurrencySettings[i].DowntimeGapCandles < 0 {
+			return fmt.Errorf("%w %v", errInvalidDowntimeGapCandles, c.CurrencySettings[i].DowntimeGapCandles)
+		}
+		if c.CurrencySettings[i].VolumeFitWindow < 0 {
+			return fmt.Errorf("%w %v", errInvalidVolumeFitWindow, c.CurrencySettings[i].VolumeFitWindow)
+		}
+		c.CurrencySettings[i].VolumeExceedBehaviour = strings.ToLower(c.CurrencySettings[i].VolumeExceedBehaviour)
+		switch c.CurrencySettings[i].VolumeExceedBehaviour {
+		case "":
+			c.CurrencySettings[i].VolumeExceedBehaviour = exchange.VolumeExceedShrink
+		case exchange.VolumeExceedShrink, exchange.VolumeExceedReject, exchange.VolumeExceedAllow:
+		default:
+			return fmt.Errorf("%w '%v', must be shrink, reject or allow", errInvalidVolumeExceedBehaviour, c.CurrencySettings[i].VolumeExceedBehaviour)
+		}
+		if c.CurrencySettings[i].AllowPartialFills &&
+			(c.CurrencySettings[i].SkipCandleVolumeFitting || c.CurrencySettings[i].Asset != asset.Spot) {
+			return fmt.Errorf("%w, partial fills require candle volume fitting of spot orders", errInvalidPartialFills)
+		}
+		if c.CurrencySettings[i].AllowPartialFills && c.CurrencySettings[i].VolumeExceedBehaviour != exchange.VolumeExceedShrink {
+			return fmt.Errorf("%w, partial fills require orders exceeding candle volume to be shrunk", errInvalidPartialFills)
+		}
+		if c.CurrencySettings[i].QuotePrecision < 0 {
+			return fmt.Errorf("%w %v", errInvalidQuotePrecision, c.CurrencySettings[i].QuotePrecision)
+		}
+		if c.CurrencySettings[i].MinimumHoldingPeriodMinutes < 0 {
+			return fmt.Errorf("%w %v", errInvalidMinimumHoldingPeriod, c.CurrencySettings[i].MinimumHoldingPeriodMinutes)
+		}
+		if c.CurrencySettings[i].MaxPositionAgeMinutes < 0 {
+			return fmt.Errorf("%w %v", errInvalidMaxPositionAge, c.CurrencySettings[i].MaxPositionAgeMinutes)
+		}
+		if c.CurrencySettings[i].ExecutionDelayCandles < 0 {
+			return fmt.Errorf("%w %v", errInvalidExecutionDelay, c.CurrencySettings[i].ExecutionDelayCandles)
+		}
+		if c.CurrencySettings[i].MaximumCapitalAllocation.IsNegative() {
+			return fmt.Errorf("%w %v", errInvalidMaximumCapitalAllocation, c.CurrencySettings[i].MaximumCapitalAllocation)
+		}
+		var totalTagAllocation decimal.Decimal
+		for tag, percent := range c.CurrencySettings[i].TagAllocations {
+			if tag == "" || !percent.IsPositive() {
+				return fmt.Errorf("%w tag '%v' percent %v, tags must be named with a positive percent", errInvalidTagAllocations, tag, percent)
+			}
+			totalTagAllocation = totalTagAllocation.Add(percent)
+		}
+		if totalTagAllocation.GreaterThan(decimal.NewFromInt(100)) {
+			return fmt.Errorf("%w total %v%% exceeds 100%%", errInvalidTagAllocations, totalTagAllocation)
+		}
+		if len(c.CurrencySettings[i].Accounts) > 0 {
+			if c.CurrencySettings[i].Asset != asset.Spot {
+				return fmt.Errorf("%w accounts are only supported for spot, received %v", errInvalidAccounts, c.CurrencySettings[i].Asset)
+			}
+			if c.FundingSettings.UseExchangeLevelFunding {
+				return fmt.Errorf("%w accounts cannot be used with exchange level funding", errInvalidAccounts)
+			}
+		}
+		var totalAccountAllocation decimal.Decimal
+		for account, percent := range c.CurrencySettings[i].Accounts {
+			if account == "" || !percent.IsPositive() {
+				return fmt.Errorf("%w account '%v' percent %v, accounts must be named with a positive percent", errInvalidAccounts, account, percent)
+			}
+			totalAccountAllocation = totalAccountAllocation.Add(percent)
+		}
+		if totalAccountAllocation.GreaterThan(decimal.NewFromInt(100)) {
+			return fmt.Errorf("%w total %v%% exceeds 100%%", errInvalidAccounts, totalAccountAllocation)
+		}
+		c.CurrencySettings[i].CrossedOrderbookBehaviour = strings.ToLower(c.CurrencySettings[i].CrossedOrderbookBehaviour)
+		switch c.CurrencySettings[i].CrossedOrderbookBehaviour {
+		case "":
+			c.CurrencySettings[i].CrossedOrderbookBehaviour = exchange.CrossedOrderbookReject
+		case exchange.CrossedOrderbookReject, exchange.CrossedOrderbookWait:
+		default:
+			return fmt.Errorf("%w '%v', must be reject or wait", errInvalidCrossedOrderbookBehaviour, c.CurrencySettings[i].CrossedOrderbookBehaviour)
+		}
+		if c.CurrencySettings[i].MaximumOrderbookAgeSeconds < 0 {
+			return fmt.Errorf("%w %v", errInvalidMaximumOrderbookAge, c.CurrencySettings[i].MaximumOrderbookAgeSeconds)
+		}
+		c.CurrencySettings[i].DepthExhaustionBehaviour = strings.ToLower(c.CurrencySettings[i].DepthExhaustionBehaviour)
+		switch c.CurrencySettings[i].DepthExhaustionBehaviour {
+		case "":
+			c.CurrencySettings[i].DepthExhaustionBehaviour = exchange.DepthExhaustionPartialFill
+		case exchange.DepthExhaustionPartialFill, exchange.DepthExhaustionReject:
+		default:
+			return fmt.Errorf("%w '%v', must be partial-fill or reject", errInvalidDepthExhaustion, c.CurrencySettings[i].DepthExhaustionBehaviour)
+		}
+		if c.CurrencySettings[i].SubmissionRetries < 0 || c.CurrencySettings[i].SubmissionRetryBackoffMilliseconds < 0 {
+			return fmt.Errorf("%w retries %v backoff %v milliseconds", errInvalidSubmissionRetries, c.CurrencySettings[i].SubmissionRetries, c.CurrencySettings[i].SubmissionRetryBackoffMilliseconds)
+		}
+		c.CurrencySettings[i].FeeShortfallBehaviour = strings.ToLower(c.CurrencySettings[i].FeeShortfallBehaviour)
+		switch c.CurrencySettings[i].FeeShortfallBehaviour {
+		case "":
+			c.CurrencySettings[i].FeeShortfallBehaviour = exchange.FeeShortfallShrink
+		case exchange.FeeShortfallShrink, exchange.FeeShortfallReject:
+		default:
+			return fmt.Errorf("%w '%v', must be shrink or reject", errInvalidFeeShortfallBehaviour, c.CurrencySettings[i].FeeShortfallBehaviour)
+		}
+		c.CurrencySettings[i].SlippageOverfillBehaviour = strings.ToLower(c.CurrencySettings[i].SlippageOverfillBehaviour)
+		switch c.CurrencySettings[i].SlippageOverfillBehaviour {
+		case "":
+			c.CurrencySettings[i].SlippageOverfillBehaviour = exchange.SlippageOverfillShrink
+		case exchange.SlippageOverfillShrink, exchange.SlippageOverfillReject:
+		default:
+			return fmt.Errorf("%w '%v', must be shrink or reject", errInvalidSlippageOverfill, c.CurrencySettings[i].SlippageOverfillBehaviour)
+		}
+		if c.CurrencySettings[i].PriceTickSize.IsNegative() {
+			return fmt.Errorf("%w %v cannot be negative", errInvalidPriceTickSize, c.CurrencySettings[i].PriceTickSize)
+		}
+		if c.CurrencySettings[i].PriceTickSize.IsPositive() && !c.CurrencySettings[i].CanUseExchangeLimits {
+			return fmt.Errorf("%w %v requires use-exchange-order-limits", errInvalidPriceTickSize, c.CurrencySettings[i].PriceTickSize)
+		}
+		if c.CurrencySettings[i].ExtremeVolatilityThreshold.IsNegative() {
+			return fmt.Errorf("%w threshold %v cannot be negative", errInvalidExtremeVolatility, c.CurrencySettings[i].ExtremeVolatilityThreshold)
+		}
+		c.CurrencySettings[i].ExtremeVolatilityBehaviour = strings.ToLower(c.CurrencySettings[i].ExtremeVolatilityBehaviour)
+		switch c.CurrencySettings[i].ExtremeVolatilityBehaviour {
+		case "":
+			c.CurrencySettings[i].ExtremeVolatilityBehaviour = exchange.ExtremeVolatilityReject
+		case exchange.ExtremeVolatilityReject:
+		case exchange.ExtremeVolatilityAmplifySlippage:
+			if c.CurrencySettings[i].ExtremeVolatilitySlippageMultiplier.IsZero() {
+				c.CurrencySettings[i].ExtremeVolatilitySlippageMultiplier = decimal.NewFromInt(2)
+			}
+			if c.CurrencySettings[i].ExtremeVolatilitySlippageMultiplier.LessThan(decimal.NewFromInt(1)) {
+				return fmt.Errorf("%w slippage multiplier %v must be at least 1", errInvalidExtremeVolatility, c.CurrencySettings[i].ExtremeVolatilitySlippageMultiplier)
+			}
+		default:
+			return fmt.Errorf("%w '%v', must be reject or amplify-slippage", errInvalidExtremeVolatility, c.CurrencySettings[i].ExtremeVolatilityBehaviour)
+		}
+		c.CurrencySettings[i].RebatePolicy = strings.ToLower(c.CurrencySettings[i].RebatePolicy)
+		switch c.CurrencySettings[i].RebatePolicy {
+		case "":
+			c.CurrencySettings[i].RebatePolicy = exchange.RebateReinvest
+		case exchange.RebateReinvest, exchange.RebateAccumulate:
+		default:
+			return fmt.Errorf("%w '%v', must be reinvest or accumulate", errInvalidRebatePolicy, c.CurrencySettings[i].RebatePolicy)
+		}
+		c.CurrencySettings[i].FillTimeAssignment = strings.ToLower(c.CurrencySettings[i].FillTimeAssignment)
+		switch c.CurrencySettings[i].FillTimeAssignment {
+		case "":
+			c.CurrencySettings[i].FillTimeAssignment = exchange.FillTimeClose
+		case exchange.FillTimeClose, exchange.FillTimeOpen, exchange.FillTimeProportional:
+		default:
+			return fmt.Errorf("%w '%v', must be close, open or proportional", errInvalidFillTimeAssignment, c.CurrencySettings[i].FillTimeAssignment)
+		}
+		c.CurrencySettings[i].SignalToFillConvention = strings.ToLower(c.CurrencySettings[i].SignalToFillConvention)
+		switch c.CurrencySettings[i].SignalToFillConvention {
+		case "":
+			c.CurrencySettings[i].SignalToFillConvention = exchange.NextCandleOpen
+		case exchange.SameCandleClose, exchange.NextCandleOpen:
+		default:
+			return fmt.Errorf("%w '%v', must be same-candle-close or next-candle-open", errInvalidSignalToFillConvention, c.CurrencySettings[i].SignalToFillConvention)
+		}
+		c.CurrencySettings[i].FlipPolicy = strings.ToLower(c.CurrencySettings[i].FlipPolicy)
+		switch c.CurrencySettings[i].FlipPolicy {
+		case "":
+			c.CurrencySettings[i].FlipPolicy = exchange.ClampToFlat
+		case exchange.AllowFlip, exchange.ClampToFlat, exchange.RejectExcess:
+		default:
+			return fmt.Errorf("%w '%v', must be allow-flip, clamp-to-flat or reject-excess", errInvalidFlipPolicy, c.CurrencySettings[i].FlipPolicy)
+		}
 		c.CurrencySettings[i].ExchangeName = strings.ToLower(c.CurrencySettings[i].ExchangeName)
 	}
 	if hasSlippage && hasFutures {
@@ -234,6 +616,13 @@ func (c *Config) PrintSetting() {
 		log.Info(common.Config, "Custom strategy variables: unset")
 	}
 	log.Infof(common.Config, "Simultaneous Signal Processing: %v", c.StrategySettings.SimultaneousSignalProcessing)
+	if c.StrategySettings.SimultaneousSignalProcessing {
+		log.Infof(common.Config, "Opposing signal policy: %v", c.StrategySettings.OpposingSignalPolicy)
+		log.Infof(common.Config, "Simultaneous signal priority: %v", c.StrategySettings.SimultaneousSignalPriority)
+	}
+	log.Infof(common.Config, "First candle policy: %v", c.StrategySettings.FirstCandlePolicy)
+	log.Infof(common.Config, "End of data policy: %v", c.StrategySettings.EndOfDataPolicy)
+	log.Infof(common.Config, "Rounding mode: %v", c.StrategySettings.RoundingMode)
 	log.Infof(common.Config, "USD value tracking: %v", !c.StrategySettings.DisableUSDTracking)
 
 	if c.FundingSettings.UseExchangeLevelFunding && c.StrategySettings.SimultaneousSignalProcessing {
@@ -246,6 +635,17 @@ func (c *Config) PrintSetting() {
 				c.FundingSettings.ExchangeLevelFunding[i].Currency,
 				c.FundingSettings.ExchangeLevelFunding[i].InitialFunds.Round(8))
 		}
+		if c.FundingSettings.UseQuoteFundingBasket {
+			log.Infof(common.Config, "Quote funding basket priority: %v", c.FundingSettings.QuoteFundingPriority)
+		}
+	}
+	for i := range c.FundingSettings.CapitalFlows {
+		log.Infof(common.Config, "Capital flow for %v %v %v at %v: %v",
+			c.FundingSettings.CapitalFlows[i].ExchangeName,
+			c.FundingSettings.CapitalFlows[i].Asset,
+			c.FundingSettings.CapitalFlows[i].Currency,
+			c.FundingSettings.CapitalFlows[i].Time,
+			c.FundingSettings.CapitalFlows[i].Amount.Round(8))
 	}
 
 	for i := range c.CurrencySettings {
@@ -261,6 +661,11 @@ func (c *Config) PrintSetting() {
 					c.CurrencySettings[i].SpotDetails.InitialBaseFunds.Round(8),
 					c.CurrencySettings[i].Base)
 			}
+			if c.CurrencySettings[i].SpotDetails.InitialBaseEntryPrice != nil {
+				log.Infof(common.Config, "Initial base entry price: %v %v",
+					c.CurrencySettings[i].SpotDetails.InitialBaseEntryPrice.Round(8),
+					c.CurrencySettings[i].Quote)
+			}
 			if c.CurrencySettings[i].SpotDetails.InitialQuoteFunds != nil {
 				log.Infof(common.Config, "Initial quote funds: %v %v",
 					c.CurrencySettings[i].SpotDetails.InitialQuoteFunds.Round(8),
@@ -283,12 +688,121 @@ func (c *Config) PrintSetting() {
 		}
 		log.Infof(common.Config, "Minimum slippage percent: %v", c.CurrencySettings[i].MinimumSlippagePercent.Round(8))
 		log.Infof(common.Config, "Maximum slippage percent: %v", c.CurrencySettings[i].MaximumSlippagePercent.Round(8))
+		if c.CurrencySettings[i].SlippageCapPercent.IsPositive() {
+			log.Infof(common.Config, "Slippage cap percent: %v", c.CurrencySettings[i].SlippageCapPercent.Round(8))
+		}
+		if c.CurrencySettings[i].SlippageAsymmetry.IsPositive() {
+			log.Infof(common.Config, "Slippage asymmetry: %v", c.CurrencySettings[i].SlippageAsymmetry.Round(8))
+		}
+		if c.CurrencySettings[i].AllowSlippagePriceImprovement {
+			log.Info(common.Config, "Slippage can improve fill prices")
+		}
+		if c.CurrencySettings[i].LiquidationSlippagePercent.IsPositive() || c.CurrencySettings[i].LiquidationFeeRate.IsPositive() {
+			log.Infof(common.Config, "Liquidation penalty slippage percent: %v fee rate: %v", c.CurrencySettings[i].LiquidationSlippagePercent, c.CurrencySettings[i].LiquidationFeeRate)
+		}
+		if c.CurrencySettings[i].BorrowRatePercent.IsPositive() {
+			log.Infof(common.Config, "Annual short borrow rate percent: %v", c.CurrencySettings[i].BorrowRatePercent)
+		}
+		for j := range c.CurrencySettings[i].FeeTiers {
+			log.Infof(common.Config, "Fee tier: maker %v taker %v basis points from traded volume %v", c.CurrencySettings[i].FeeTiers[j].MakerBasisPoints, c.CurrencySettings[i].FeeTiers[j].TakerBasisPoints, c.CurrencySettings[i].FeeTiers[j].VolumeThreshold)
+		}
+		for j := range c.CurrencySettings[i].SlippageTiers {
+			log.Infof(common.Config, "Slippage tier: %v basis points from notional %v", c.CurrencySettings[i].SlippageTiers[j].BasisPoints, c.CurrencySettings[i].SlippageTiers[j].MinimumNotional)
+		}
 		log.Infof(common.Config, "Buy rules: %+v", c.CurrencySettings[i].BuySide)
 		log.Infof(common.Config, "Sell rules: %+v", c.CurrencySettings[i].SellSide)
 		if c.CurrencySettings[i].FuturesDetails != nil && c.CurrencySettings[i].Asset == asset.Futures {
 			log.Infof(common.Config, "Leverage rules: %+v", c.CurrencySettings[i].FuturesDetails.Leverage)
 		}
 		log.Infof(common.Config, "Can use exchange defined order execution limits: %+v", c.CurrencySettings[i].CanUseExchangeLimits)
+		if c.CurrencySettings[i].PriceTickSize.IsPositive() {
+			log.Infof(common.Config, "Price tick size: %v", c.CurrencySettings[i].PriceTickSize)
+		}
+		if c.CurrencySettings[i].NetOfCostTargets {
+			log.Info(common.Config, "Targets are calculated net of fees and spread")
+		}
+		if c.CurrencySettings[i].ExecutionInterval > 0 {
+			log.Infof(common.Config, "Execution interval: %v", c.CurrencySettings[i].ExecutionInterval)
+		}
+		if c.CurrencySettings[i].RecordFundingLedger {
+			log.Info(common.Config, "Recording funding ledger for each order")
+		}
+		if len(c.CurrencySettings[i].FundingRates) > 0 || c.CurrencySettings[i].FundingRateCSVPath != "" {
+			log.Infof(common.Config, "Funding rates: %v, funding rate csv file: %v", len(c.CurrencySettings[i].FundingRates), c.CurrencySettings[i].FundingRateCSVPath)
+		}
+		if c.CurrencySettings[i].TradingSession != nil {
+			log.Infof(common.Config, "Trading session: %+v", *c.CurrencySettings[i].TradingSession)
+		}
+		if c.CurrencySettings[i].LatencyDistribution != nil {
+			log.Infof(common.Config, "Latency distribution: %+v", *c.CurrencySettings[i].LatencyDistribution)
+		}
+		if c.CurrencySettings[i].ExecutionDelayCandles > 0 {
+			log.Infof(common.Config, "Execution delay: %v candles", c.CurrencySettings[i].ExecutionDelayCandles)
+		}
+		if c.CurrencySettings[i].OrderbookImbalanceDepth > 0 {
+			log.Infof(common.Config, "Orderbook imbalance depth: %v levels", c.CurrencySettings[i].OrderbookImbalanceDepth)
+		}
+		if c.CurrencySettings[i].DowntimeGapCandles > 0 {
+			log.Infof(common.Config, "Downtime from data gaps of at least: %v candles", c.CurrencySettings[i].DowntimeGapCandles)
+		}
+		if c.CurrencySettings[i].VolumeFitWindow > 1 {
+			log.Infof(common.Config, "Volume fit window: %v candles", c.CurrencySettings[i].VolumeFitWindow)
+		}
+		if !c.CurrencySettings[i].SkipCandleVolumeFitting {
+			log.Infof(common.Config, "Volume exceed behaviour: %v", c.CurrencySettings[i].VolumeExceedBehaviour)
+		}
+		if c.CurrencySettings[i].AllowPartialFills {
+			log.Infof(common.Config, "Allow partial fills: %v", c.CurrencySettings[i].AllowPartialFills)
+		}
+		if c.CurrencySettings[i].RoundQuoteFunding {
+			log.Infof(common.Config, "Round quote funding: %v, quote precision: %v", c.CurrencySettings[i].RoundQuoteFunding, c.CurrencySettings[i].QuotePrecision)
+		}
+		if c.CurrencySettings[i].MinimumHoldingPeriodMinutes > 0 {
+			log.Infof(common.Config, "Minimum holding period: %v minutes", c.CurrencySettings[i].MinimumHoldingPeriodMinutes)
+		}
+		if c.CurrencySettings[i].MaxPositionAgeMinutes > 0 {
+			log.Infof(common.Config, "Max position age: %v minutes", c.CurrencySettings[i].MaxPositionAgeMinutes)
+		}
+		if c.CurrencySettings[i].MaximumCapitalAllocation.IsPositive() {
+			log.Infof(common.Config, "Maximum capital allocation: %v", c.CurrencySettings[i].MaximumCapitalAllocation)
+		}
+		if len(c.CurrencySettings[i].TagAllocations) > 0 {
+			log.Infof(common.Config, "Tag allocations: %v", c.CurrencySettings[i].TagAllocations)
+		}
+		if len(c.CurrencySettings[i].Accounts) > 0 {
+			log.Infof(common.Config, "Accounts: %v", c.CurrencySettings[i].Accounts)
+		}
+		if c.DataSettings.LiveData != nil && c.DataSettings.LiveData.RealOrders {
+			log.Infof(common.Config, "Crossed orderbook behaviour: %v", c.CurrencySettings[i].CrossedOrderbookBehaviour)
+			if c.CurrencySettings[i].MaximumOrderbookAgeSeconds > 0 {
+				log.Infof(common.Config, "Maximum orderbook age: %v seconds", c.CurrencySettings[i].MaximumOrderbookAgeSeconds)
+			}
+			log.Infof(common.Config, "Depth exhaustion behaviour: %v", c.CurrencySettings[i].DepthExhaustionBehaviour)
+			if c.CurrencySettings[i].SubmissionRetries > 0 {
+				log.Infof(common.Config, "Submission retries: %v with %v milliseconds backoff", c.CurrencySettings[i].SubmissionRetries, c.CurrencySettings[i].SubmissionRetryBackoffMilliseconds)
+			}
+		}
+		log.Infof(common.Config, "Signal to fill convention: %v", c.CurrencySettings[i].SignalToFillConvention)
+		if c.CurrencySettings[i].Asset.IsFutures() {
+			log.Infof(common.Config, "Flip policy: %v", c.CurrencySettings[i].FlipPolicy)
+		}
+		log.Infof(common.Config, "Fill time assignment: %v", c.CurrencySettings[i].FillTimeAssignment)
+		log.Infof(common.Config, "Fee shortfall behaviour: %v", c.CurrencySettings[i].FeeShortfallBehaviour)
+		log.Infof(common.Config, "Slippage overfill behaviour: %v", c.CurrencySettings[i].SlippageOverfillBehaviour)
+		if c.CurrencySettings[i].ExtremeVolatilityThreshold.IsPositive() {
+			if c.CurrencySettings[i].ExtremeVolatilityBehaviour == exchange.ExtremeVolatilityAmplifySlippage {
+				log.Infof(common.Config, "Extreme volatility: amplify slippage %vx on candles ranging %v%% or more", c.CurrencySettings[i].ExtremeVolatilitySlippageMultiplier, c.CurrencySettings[i].ExtremeVolatilityThreshold)
+			} else {
+				log.Infof(common.Config, "Extreme volatility: reject orders on candles ranging %v%% or more", c.CurrencySettings[i].ExtremeVolatilityThreshold)
+			}
+		}
+		log.Infof(common.Config, "Rebate policy: %v", c.CurrencySettings[i].RebatePolicy)
+		if c.CurrencySettings[i].DrawdownStopOut != nil {
+			log.Infof(common.Config, "Drawdown stop-out: %v%% %v drawdown", c.CurrencySettings[i].DrawdownStopOut.MaximumDrawdownPercent, c.CurrencySettings[i].DrawdownStopOut.Scope)
+		}
+		if c.CurrencySettings[i].LosingStreakBreaker != nil {
+			log.Infof(common.Config, "Losing streak breaker: %v consecutive losses, %v minute cooldown", c.CurrencySettings[i].LosingStreakBreaker.ConsecutiveLosses, c.CurrencySettings[i].LosingStreakBreaker.CooldownMinutes)
+		}
 	}
 
 	log.Info(common.Config, common.CMDColours.H2+"------------------Portfolio Settings-------------------------"+common.CMDColours.Default)
@@ -323,3 +837,238 @@ func (c *Config) PrintSetting() {
 		log.Infof(common.Config, "End date: %v", c.DataSettings.DatabaseData.EndDate.Format(gctcommon.SimpleTimeFormat))
 	}
 }
+
+// validateExecutionInterval ensures a currency's execution interval is finer
+// than the data settings interval and divides evenly into it, so that each
+// signal candle closes with an execution candle. CSV data requires the
+// execution interval's candles to be loaded from their own file
+func (c *Config) validateExecutionInterval(cs *CurrencySettings) error {
+	switch {
+	case cs.ExecutionInterval <= 0:
+		return fmt.Errorf("%w %v %v %v must be set to load execution data", errInvalidExecutionInterval, cs.ExchangeName, cs.Asset, cs.ExecutionInterval)
+	case c.DataSettings.LiveData != nil:
+		return fmt.Errorf("%w, execution data cannot be loaded with live data", errInvalidExecutionInterval)
+	case cs.ExecutionInterval >= c.DataSettings.Interval ||
+		c.DataSettings.Interval.Duration()%cs.ExecutionInterval.Duration() != 0:
+		return fmt.Errorf("%w %v must be smaller than and divide evenly into the data interval %v", errInvalidExecutionInterval, cs.ExecutionInterval, c.DataSettings.Interval)
+	case c.DataSettings.CSVData != nil && cs.ExecutionCSVPath == "":
+		return fmt.Errorf("%w, csv data requires an execution csv path", errInvalidExecutionInterval)
+	case c.DataSettings.CSVData == nil && cs.ExecutionCSVPath != "":
+		return fmt.Errorf("%w, an execution csv path requires csv data", errInvalidExecutionInterval)
+	}
+	return nil
+}
+
+// GetFundingRates returns the currency's configured funding rates along with
+// those loaded from its funding rate csv file, ordered by time
+func (c *CurrencySettings) GetFundingRates() ([]FundingRate, error) {
+	rates := make([]FundingRate, 0, len(c.FundingRates))
+	rates = append(rates, c.FundingRates...)
+	if c.FundingRateCSVPath != "" {
+		csvRates, err := loadFundingRateCSV(c.FundingRateCSVPath)
+		if err != nil {
+			return nil, err
+		}
+		rates = append(rates, csvRates...)
+	}
+	sort.Slice(rates, func(i, j int) bool {
+		return rates[i].Time.Before(rates[j].Time)
+	})
+	for i := range rates {
+		if rates[i].Time.IsZero() {
+			return nil, fmt.Errorf("%w rate %v time must be set", errInvalidFundingRates, rates[i].Rate)
+		}
+		if rates[i].Rate.Abs().GreaterThanOrEqual(decimal.NewFromInt(1)) {
+			return nil, fmt.Errorf("%w rate %v at %v must be between -1 and 1", errInvalidFundingRates, rates[i].Rate, rates[i].Time)
+		}
+		if i > 0 && rates[i].Time.Equal(rates[i-1].Time) {
+			return nil, fmt.Errorf("%w multiple rates at %v", errInvalidFundingRates, rates[i].Time)
+		}
+	}
+	return rates, nil
+}
+
+// loadFundingRateCSV reads funding rates from a csv file of
+// unix timestamp and rate rows
+func loadFundingRateCSV(path string) ([]FundingRate, error) {
+	if !file.Exists(path) {
+		return nil, fmt.Errorf("%w %v", common.ErrFileNotFound, path)
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err = f.Close(); err != nil {
+			log.Errorln(common.Config, err)
+		}
+	}()
+	rows, err := csv.NewReader(f).ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("%w could not read %v %v", errInvalidFundingRates, path, err)
+	}
+	rates := make([]FundingRate, len(rows))
+	for i := range rows {
+		if len(rows[i]) < 2 {
+			return nil, fmt.Errorf("%w %v row %v requires a timestamp and rate", errInvalidFundingRates, path, i+1)
+		}
+		unix, parseErr := strconv.ParseInt(strings.TrimSpace(rows[i][0]), 10, 64)
+		if parseErr != nil {
+			return nil, fmt.Errorf("%w %v row %v timestamp %v", errInvalidFundingRates, path, i+1, parseErr)
+		}
+		rate, parseErr := decimal.NewFromString(strings.TrimSpace(rows[i][1]))
+		if parseErr != nil {
+			return nil, fmt.Errorf("%w %v row %v rate %v", errInvalidFundingRates, path, i+1, parseErr)
+		}
+		rates[i] = FundingRate{
+			Time: time.Unix(unix, 0).UTC(),
+			Rate: rate,
+		}
+	}
+	return rates, nil
+}
+
+// Parse converts the trading session open and close times into durations
+// since midnight in the session's timezone
+func (t *TradingSession) Parse() (openTime, closeTime time.Duration, loc *time.Location, err error) {
+	if t == nil {
+		return 0, 0, nil, fmt.Errorf("%w trading session", common.ErrNilArguments)
+	}
+	for i := range t.Days {
+		if t.Days[i] < time.Sunday || t.Days[i] > time.Saturday {
+			return 0, 0, nil, fmt.Errorf("%w day %v", errInvalidTradingSession, t.Days[i])
+		}
+	}
+	loc = time.UTC
+	if t.Timezone != "" {
+		loc, err = time.LoadLocation(t.Timezone)
+		if err != nil {
+			return 0, 0, nil, fmt.Errorf("%w timezone %v %v", errInvalidTradingSession, t.Timezone, err)
+		}
+	}
+	openTime, err = parseTimeOfDay(t.OpenTime)
+	if err != nil {
+		return 0, 0, nil, err
+	}
+	closeTime, err = parseTimeOfDay(t.CloseTime)
+	if err != nil {
+		return 0, 0, nil, err
+	}
+	return openTime, closeTime, loc, nil
+}
+
+func parseTimeOfDay(s string) (time.Duration, error) {
+	tt, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, fmt.Errorf("%w time %q %v", errInvalidTradingSession, s, err)
+	}
+	return time.Duration(tt.Hour())*time.Hour + time.Duration(tt.Minute())*time.Minute, nil
+}
+
+// validateFeeTiers ensures fee tiers are sorted by ascending volume
+// threshold and that trading more volume never raises the fees charged
+func validateFeeTiers(tiers []FeeTier) error {
+	for i := range tiers {
+		if tiers[i].VolumeThreshold.IsNegative() {
+			return fmt.Errorf("%w tier %v volume threshold %v cannot be negative", errInvalidFeeTiers, i, tiers[i].VolumeThreshold)
+		}
+		if tiers[i].MakerBasisPoints.LessThanOrEqual(decimal.NewFromInt(-10000)) || tiers[i].MakerBasisPoints.GreaterThanOrEqual(decimal.NewFromInt(10000)) ||
+			tiers[i].TakerBasisPoints.IsNegative() || tiers[i].TakerBasisPoints.GreaterThanOrEqual(decimal.NewFromInt(10000)) {
+			return fmt.Errorf("%w tier %v maker %v basis points must be above -10000 and taker %v basis points at least 0, both below 10000", errInvalidFeeTiers, i, tiers[i].MakerBasisPoints, tiers[i].TakerBasisPoints)
+		}
+		if i == 0 {
+			continue
+		}
+		if !tiers[i].VolumeThreshold.GreaterThan(tiers[i-1].VolumeThreshold) {
+			return fmt.Errorf("%w tier %v volume threshold %v must be above the previous tier's %v", errInvalidFeeTiers, i, tiers[i].VolumeThreshold, tiers[i-1].VolumeThreshold)
+		}
+		if tiers[i].MakerBasisPoints.GreaterThan(tiers[i-1].MakerBasisPoints) ||
+			tiers[i].TakerBasisPoints.GreaterThan(tiers[i-1].TakerBasisPoints) {
+			return fmt.Errorf("%w tier %v maker %v and taker %v basis points cannot be above the previous tier's %v and %v", errInvalidFeeTiers, i, tiers[i].MakerBasisPoints, tiers[i].TakerBasisPoints, tiers[i-1].MakerBasisPoints, tiers[i-1].TakerBasisPoints)
+		}
+	}
+	return nil
+}
+
+// validateSlippageTiers ensures slippage tiers are sorted by ascending minimum
+// notional and that larger orders never slip less than smaller ones
+func validateSlippageTiers(tiers []SlippageTier) error {
+	for i := range tiers {
+		if tiers[i].MinimumNotional.IsNegative() {
+			return fmt.Errorf("%w tier %v minimum notional %v cannot be negative", errInvalidSlippageTiers, i, tiers[i].MinimumNotional)
+		}
+		if tiers[i].BasisPoints.IsNegative() || tiers[i].BasisPoints.GreaterThanOrEqual(decimal.NewFromInt(10000)) {
+			return fmt.Errorf("%w tier %v basis points %v must be at least 0 and below 10000", errInvalidSlippageTiers, i, tiers[i].BasisPoints)
+		}
+		if i == 0 {
+			continue
+		}
+		if !tiers[i].MinimumNotional.GreaterThan(tiers[i-1].MinimumNotional) {
+			return fmt.Errorf("%w tier %v minimum notional %v must be above the previous tier's %v", errInvalidSlippageTiers, i, tiers[i].MinimumNotional, tiers[i-1].MinimumNotional)
+		}
+		if tiers[i].BasisPoints.LessThan(tiers[i-1].BasisPoints) {
+			return fmt.Errorf("%w tier %v basis points %v cannot be below the previous tier's %v", errInvalidSlippageTiers, i, tiers[i].BasisPoints, tiers[i-1].BasisPoints)
+		}
+	}
+	return nil
+}
+
+// Validate ensures the latency distribution can be sampled
+func (l *LatencyDistribution) Validate() error {
+	if l == nil {
+		return fmt.Errorf("%w latency distribution", common.ErrNilArguments)
+	}
+	if l.MeanMilliseconds < 0 || l.StandardDeviationMilliseconds < 0 {
+		return fmt.Errorf("%w mean %v standard deviation %v cannot be negative", errInvalidLatencyDistribution, l.MeanMilliseconds, l.StandardDeviationMilliseconds)
+	}
+	if len(l.Histogram) == 0 {
+		if l.MeanMilliseconds == 0 && l.StandardDeviationMilliseconds == 0 {
+			return fmt.Errorf("%w no histogram or parametric distribution set", errInvalidLatencyDistribution)
+		}
+		return nil
+	}
+	var totalWeight int64
+	for i := range l.Histogram {
+		if l.Histogram[i].LatencyMilliseconds < 0 || l.Histogram[i].Weight < 0 {
+			return fmt.Errorf("%w histogram bucket %v cannot be negative", errInvalidLatencyDistribution, i)
+		}
+		totalWeight += l.Histogram[i].Weight
+	}
+	if totalWeight == 0 {
+		return fmt.Errorf("%w histogram has no weight", errInvalidLatencyDistribution)
+	}
+	return nil
+}
+
+// Validate ensures the drawdown stop-out has a usable threshold and scope
+func (d *DrawdownStopOut) Validate() error {
+	if d == nil {
+		return fmt.Errorf("%w drawdown stop-out", common.ErrNilArguments)
+	}
+	if !d.MaximumDrawdownPercent.IsPositive() || d.MaximumDrawdownPercent.GreaterThan(decimal.NewFromInt(100)) {
+		return fmt.Errorf("%w maximum drawdown percent %v must be above 0 and at most 100", errInvalidDrawdownStopOut, d.MaximumDrawdownPercent)
+	}
+	d.Scope = strings.ToLower(d.Scope)
+	switch d.Scope {
+	case "":
+		d.Scope = exchange.DrawdownScopePosition
+	case exchange.DrawdownScopePosition, exchange.DrawdownScopePortfolio:
+	default:
+		return fmt.Errorf("%w scope %q, must be position or portfolio", errInvalidDrawdownStopOut, d.Scope)
+	}
+	return nil
+}
+
+// Validate ensures the losing streak breaker has a usable threshold and cooldown
+func (l *LosingStreakBreaker) Validate() error {
+	if l == nil {
+		return fmt.Errorf("%w losing streak breaker", common.ErrNilArguments)
+	}
+	if l.ConsecutiveLosses <= 0 {
+		return fmt.Errorf("%w consecutive losses %v must be above 0", errInvalidLosingStreakBreaker, l.ConsecutiveLosses)
+	}
+	if l.CooldownMinutes < 0 {
+		return fmt.Errorf("%w cooldown minutes %v cannot be negative", errInvalidLosingStreakBreaker, l.CooldownMinutes)
+	}
+	return nil
+}
@@ -130,6 +130,38 @@ func TestValidateCurrencySettings(t *testing.T) {
 	if err != nil {
 		t.Error(err)
 	}
+	c.CurrencySettings[0].SlippageCapPercent = decimal.NewFromInt(-1)
+	err = c.validateCurrencySettings()
+	if !errors.Is(err, errBadSlippageRates) {
+		t.Errorf("received: %v, expected: %v", err, errBadSlippageRates)
+	}
+	c.CurrencySettings[0].SlippageCapPercent = decimal.Zero
+	c.CurrencySettings[0].SlippageAsymmetry = decimal.NewFromFloat(1.5)
+	err = c.validateCurrencySettings()
+	if !errors.Is(err, errBadSlippageRates) {
+		t.Errorf("received: %v, expected: %v", err, errBadSlippageRates)
+	}
+	c.CurrencySettings[0].SlippageAsymmetry = decimal.NewFromFloat(0.5)
+	err = c.validateCurrencySettings()
+	if !errors.Is(err, nil) {
+		t.Errorf("received: %v, expected: %v", err, nil)
+	}
+	c.CurrencySettings[0].LiquidationSlippagePercent = decimal.NewFromInt(100)
+	err = c.validateCurrencySettings()
+	if !errors.Is(err, errInvalidLiquidationPenalty) {
+		t.Errorf("received: %v, expected: %v", err, errInvalidLiquidationPenalty)
+	}
+	c.CurrencySettings[0].LiquidationSlippagePercent = decimal.NewFromInt(5)
+	c.CurrencySettings[0].LiquidationFeeRate = decimal.NewFromInt(-1)
+	err = c.validateCurrencySettings()
+	if !errors.Is(err, errInvalidLiquidationPenalty) {
+		t.Errorf("received: %v, expected: %v", err, errInvalidLiquidationPenalty)
+	}
+	c.CurrencySettings[0].LiquidationFeeRate = decimal.NewFromFloat(0.01)
+	err = c.validateCurrencySettings()
+	if !errors.Is(err, nil) {
+		t.Errorf("received: %v, expected: %v", err, nil)
+	}
 	c.CurrencySettings = []CurrencySettings{
 		{
 			SellSide: MinMax{
@@ -267,6 +299,187 @@ func TestValidateStrategySettings(t *testing.T) {
 	}
 }
 
+func TestValidateOpposingSignalPolicy(t *testing.T) {
+	t.Parallel()
+	c := &Config{StrategySettings: StrategySettings{Name: dca}}
+	err := c.validateStrategySettings()
+	if !errors.Is(err, nil) {
+		t.Errorf("received: %v, expected: %v", err, nil)
+	}
+	if c.StrategySettings.OpposingSignalPolicy != "hedge" {
+		t.Errorf("received: %v, expected: %v", c.StrategySettings.OpposingSignalPolicy, "hedge")
+	}
+
+	c.StrategySettings.OpposingSignalPolicy = "ignore"
+	err = c.validateStrategySettings()
+	if !errors.Is(err, errInvalidOpposingSignalPolicy) {
+		t.Errorf("received: %v, expected: %v", err, errInvalidOpposingSignalPolicy)
+	}
+
+	c.StrategySettings.OpposingSignalPolicy = "NET"
+	err = c.validateStrategySettings()
+	if !errors.Is(err, nil) {
+		t.Errorf("received: %v, expected: %v", err, nil)
+	}
+	if c.StrategySettings.OpposingSignalPolicy != "net" {
+		t.Errorf("received: %v, expected: %v", c.StrategySettings.OpposingSignalPolicy, "net")
+	}
+}
+
+func TestValidateSimultaneousSignalPriority(t *testing.T) {
+	t.Parallel()
+	c := &Config{StrategySettings: StrategySettings{Name: dca}}
+	err := c.validateStrategySettings()
+	if !errors.Is(err, nil) {
+		t.Errorf("received: %v, expected: %v", err, nil)
+	}
+	if c.StrategySettings.SimultaneousSignalPriority != "currency" {
+		t.Errorf("received: %v, expected: %v", c.StrategySettings.SimultaneousSignalPriority, "currency")
+	}
+
+	c.StrategySettings.SimultaneousSignalPriority = "random"
+	err = c.validateStrategySettings()
+	if !errors.Is(err, errInvalidSignalPriority) {
+		t.Errorf("received: %v, expected: %v", err, errInvalidSignalPriority)
+	}
+
+	c.StrategySettings.SimultaneousSignalPriority = "Signal-Strength"
+	err = c.validateStrategySettings()
+	if !errors.Is(err, nil) {
+		t.Errorf("received: %v, expected: %v", err, nil)
+	}
+	if c.StrategySettings.SimultaneousSignalPriority != "signal-strength" {
+		t.Errorf("received: %v, expected: %v", c.StrategySettings.SimultaneousSignalPriority, "signal-strength")
+	}
+}
+
+func TestValidateFirstCandlePolicy(t *testing.T) {
+	t.Parallel()
+	c := &Config{StrategySettings: StrategySettings{Name: dca}}
+	err := c.validateStrategySettings()
+	if !errors.Is(err, nil) {
+		t.Errorf("received: %v, expected: %v", err, nil)
+	}
+	if c.StrategySettings.FirstCandlePolicy != "skip" {
+		t.Errorf("received: %v, expected: %v", c.StrategySettings.FirstCandlePolicy, "skip")
+	}
+
+	c.StrategySettings.FirstCandlePolicy = "ignore"
+	err = c.validateStrategySettings()
+	if !errors.Is(err, errInvalidFirstCandlePolicy) {
+		t.Errorf("received: %v, expected: %v", err, errInvalidFirstCandlePolicy)
+	}
+
+	c.StrategySettings.FirstCandlePolicy = "OPEN"
+	err = c.validateStrategySettings()
+	if !errors.Is(err, nil) {
+		t.Errorf("received: %v, expected: %v", err, nil)
+	}
+	if c.StrategySettings.FirstCandlePolicy != "open" {
+		t.Errorf("received: %v, expected: %v", c.StrategySettings.FirstCandlePolicy, "open")
+	}
+}
+
+func TestValidateEndOfDataPolicy(t *testing.T) {
+	t.Parallel()
+	c := &Config{StrategySettings: StrategySettings{Name: dca}}
+	err := c.validateStrategySettings()
+	if !errors.Is(err, nil) {
+		t.Errorf("received: %v, expected: %v", err, nil)
+	}
+	if c.StrategySettings.EndOfDataPolicy != "mark-to-market" {
+		t.Errorf("received: %v, expected: %v", c.StrategySettings.EndOfDataPolicy, "mark-to-market")
+	}
+
+	c.StrategySettings.EndOfDataPolicy = "ignore"
+	err = c.validateStrategySettings()
+	if !errors.Is(err, errInvalidEndOfDataPolicy) {
+		t.Errorf("received: %v, expected: %v", err, errInvalidEndOfDataPolicy)
+	}
+
+	c.StrategySettings.EndOfDataPolicy = "Force-Close"
+	err = c.validateStrategySettings()
+	if !errors.Is(err, nil) {
+		t.Errorf("received: %v, expected: %v", err, nil)
+	}
+	if c.StrategySettings.EndOfDataPolicy != "force-close" {
+		t.Errorf("received: %v, expected: %v", c.StrategySettings.EndOfDataPolicy, "force-close")
+	}
+}
+
+func TestValidateRoundingMode(t *testing.T) {
+	t.Parallel()
+	c := &Config{StrategySettings: StrategySettings{Name: dca}}
+	err := c.validateStrategySettings()
+	if !errors.Is(err, nil) {
+		t.Errorf("received: %v, expected: %v", err, nil)
+	}
+	if c.StrategySettings.RoundingMode != common.RoundHalfEven {
+		t.Errorf("received: %v, expected: %v", c.StrategySettings.RoundingMode, common.RoundHalfEven)
+	}
+
+	c.StrategySettings.RoundingMode = "half-down"
+	err = c.validateStrategySettings()
+	if !errors.Is(err, errInvalidRoundingMode) {
+		t.Errorf("received: %v, expected: %v", err, errInvalidRoundingMode)
+	}
+
+	c.StrategySettings.RoundingMode = "Toward-Zero"
+	err = c.validateStrategySettings()
+	if !errors.Is(err, nil) {
+		t.Errorf("received: %v, expected: %v", err, nil)
+	}
+	if c.StrategySettings.RoundingMode != common.RoundTowardZero {
+		t.Errorf("received: %v, expected: %v", c.StrategySettings.RoundingMode, common.RoundTowardZero)
+	}
+}
+
+func TestValidateCapitalFlows(t *testing.T) {
+	t.Parallel()
+	flow := CapitalFlow{
+		Time:         time.Now(),
+		ExchangeName: testExchange,
+		Asset:        asset.Spot,
+		Currency:     currency.USDT,
+		Amount:       decimal.NewFromInt(-1337),
+	}
+	c := &Config{
+		StrategySettings: StrategySettings{Name: dca},
+		FundingSettings:  FundingSettings{CapitalFlows: []CapitalFlow{flow}},
+	}
+	err := c.validateStrategySettings()
+	if !errors.Is(err, nil) {
+		t.Errorf("received: %v, expected: %v", err, nil)
+	}
+
+	c.FundingSettings.CapitalFlows[0].Amount = decimal.Zero
+	err = c.validateStrategySettings()
+	if !errors.Is(err, errInvalidCapitalFlow) {
+		t.Errorf("received: %v, expected: %v", err, errInvalidCapitalFlow)
+	}
+
+	c.FundingSettings.CapitalFlows[0] = flow
+	c.FundingSettings.CapitalFlows[0].Time = time.Time{}
+	err = c.validateStrategySettings()
+	if !errors.Is(err, errInvalidCapitalFlow) {
+		t.Errorf("received: %v, expected: %v", err, errInvalidCapitalFlow)
+	}
+
+	c.FundingSettings.CapitalFlows[0] = flow
+	c.FundingSettings.CapitalFlows[0].Asset = asset.Futures
+	err = c.validateStrategySettings()
+	if !errors.Is(err, errInvalidCapitalFlow) {
+		t.Errorf("received: %v, expected: %v", err, errInvalidCapitalFlow)
+	}
+
+	c.FundingSettings.CapitalFlows[0] = flow
+	c.FundingSettings.CapitalFlows[0].Currency = currency.EMPTYCODE
+	err = c.validateStrategySettings()
+	if !errors.Is(err, errInvalidCapitalFlow) {
+		t.Errorf("received: %v, expected: %v", err, errInvalidCapitalFlow)
+	}
+}
+
 func TestPrintSettings(t *testing.T) {
 	t.Parallel()
 	cfg := Config{
@@ -1344,3 +1557,1395 @@ func TestGenerateFTXCashAndCarryStrategy(t *testing.T) {
 		}
 	}
 }
+
+func TestValidateExecutionInterval(t *testing.T) {
+	t.Parallel()
+	c := &Config{
+		CurrencySettings: []CurrencySettings{
+			{
+				ExchangeName:     testExchange,
+				Base:             currency.BTC,
+				Quote:            currency.USDT,
+				Asset:            asset.Spot,
+				ExecutionCSVPath: "execution.csv",
+			},
+		},
+		DataSettings: DataSettings{
+			Interval: kline.OneHour,
+			CSVData:  &CSVData{FullPath: "signal.csv"},
+		},
+	}
+	err := c.validateCurrencySettings()
+	if !errors.Is(err, errInvalidExecutionInterval) {
+		t.Errorf("received: %v, expected: %v", err, errInvalidExecutionInterval)
+	}
+	c.CurrencySettings[0].ExecutionInterval = kline.OneHour
+	err = c.validateCurrencySettings()
+	if !errors.Is(err, errInvalidExecutionInterval) {
+		t.Errorf("received: %v, expected: %v", err, errInvalidExecutionInterval)
+	}
+	// seven minutes does not divide evenly into an hour
+	c.CurrencySettings[0].ExecutionInterval = kline.Interval(time.Minute * 7)
+	err = c.validateCurrencySettings()
+	if !errors.Is(err, errInvalidExecutionInterval) {
+		t.Errorf("received: %v, expected: %v", err, errInvalidExecutionInterval)
+	}
+	c.CurrencySettings[0].ExecutionInterval = kline.FifteenMin
+	err = c.validateCurrencySettings()
+	if !errors.Is(err, nil) {
+		t.Errorf("received: %v, expected: %v", err, nil)
+	}
+	c.CurrencySettings[0].ExecutionCSVPath = ""
+	err = c.validateCurrencySettings()
+	if !errors.Is(err, errInvalidExecutionInterval) {
+		t.Errorf("received: %v, expected: %v", err, errInvalidExecutionInterval)
+	}
+	c.DataSettings.CSVData = nil
+	c.DataSettings.APIData = &APIData{}
+	err = c.validateCurrencySettings()
+	if !errors.Is(err, nil) {
+		t.Errorf("received: %v, expected: %v", err, nil)
+	}
+	c.CurrencySettings[0].ExecutionCSVPath = "execution.csv"
+	err = c.validateCurrencySettings()
+	if !errors.Is(err, errInvalidExecutionInterval) {
+		t.Errorf("received: %v, expected: %v", err, errInvalidExecutionInterval)
+	}
+	c.CurrencySettings[0].ExecutionCSVPath = ""
+	c.DataSettings.APIData = nil
+	c.DataSettings.LiveData = &LiveData{}
+	err = c.validateCurrencySettings()
+	if !errors.Is(err, errInvalidExecutionInterval) {
+		t.Errorf("received: %v, expected: %v", err, errInvalidExecutionInterval)
+	}
+}
+
+func TestTradingSessionParse(t *testing.T) {
+	t.Parallel()
+	var ts *TradingSession
+	_, _, _, err := ts.Parse()
+	if !errors.Is(err, common.ErrNilArguments) {
+		t.Errorf("received: %v, expected: %v", err, common.ErrNilArguments)
+	}
+
+	ts = &TradingSession{OpenTime: "9:30am", CloseTime: "16:00"}
+	_, _, _, err = ts.Parse()
+	if !errors.Is(err, errInvalidTradingSession) {
+		t.Errorf("received: %v, expected: %v", err, errInvalidTradingSession)
+	}
+
+	ts.OpenTime = "09:30"
+	ts.Days = []time.Weekday{7}
+	_, _, _, err = ts.Parse()
+	if !errors.Is(err, errInvalidTradingSession) {
+		t.Errorf("received: %v, expected: %v", err, errInvalidTradingSession)
+	}
+
+	ts.Days = []time.Weekday{time.Monday}
+	ts.Timezone = "Not/AZone"
+	_, _, _, err = ts.Parse()
+	if !errors.Is(err, errInvalidTradingSession) {
+		t.Errorf("received: %v, expected: %v", err, errInvalidTradingSession)
+	}
+
+	ts.Timezone = ""
+	openTime, closeTime, loc, err := ts.Parse()
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: %v, expected: %v", err, nil)
+	}
+	if openTime != time.Hour*9+time.Minute*30 {
+		t.Errorf("received: %v, expected: %v", openTime, time.Hour*9+time.Minute*30)
+	}
+	if closeTime != time.Hour*16 {
+		t.Errorf("received: %v, expected: %v", closeTime, time.Hour*16)
+	}
+	if loc != time.UTC {
+		t.Errorf("received: %v, expected: %v", loc, time.UTC)
+	}
+}
+
+func TestLatencyDistributionValidate(t *testing.T) {
+	t.Parallel()
+	var l *LatencyDistribution
+	err := l.Validate()
+	if !errors.Is(err, common.ErrNilArguments) {
+		t.Errorf("received: %v, expected: %v", err, common.ErrNilArguments)
+	}
+
+	l = &LatencyDistribution{}
+	err = l.Validate()
+	if !errors.Is(err, errInvalidLatencyDistribution) {
+		t.Errorf("received: %v, expected: %v", err, errInvalidLatencyDistribution)
+	}
+
+	l.MeanMilliseconds = -1
+	err = l.Validate()
+	if !errors.Is(err, errInvalidLatencyDistribution) {
+		t.Errorf("received: %v, expected: %v", err, errInvalidLatencyDistribution)
+	}
+
+	l.MeanMilliseconds = 500
+	err = l.Validate()
+	if !errors.Is(err, nil) {
+		t.Errorf("received: %v, expected: %v", err, nil)
+	}
+
+	l.Histogram = []LatencyBucket{{LatencyMilliseconds: 100}}
+	err = l.Validate()
+	if !errors.Is(err, errInvalidLatencyDistribution) {
+		t.Errorf("received: %v, expected: %v", err, errInvalidLatencyDistribution)
+	}
+
+	l.Histogram[0].Weight = -1
+	err = l.Validate()
+	if !errors.Is(err, errInvalidLatencyDistribution) {
+		t.Errorf("received: %v, expected: %v", err, errInvalidLatencyDistribution)
+	}
+
+	l.Histogram[0].Weight = 1
+	err = l.Validate()
+	if !errors.Is(err, nil) {
+		t.Errorf("received: %v, expected: %v", err, nil)
+	}
+}
+
+func TestDrawdownStopOutValidate(t *testing.T) {
+	t.Parallel()
+	var d *DrawdownStopOut
+	err := d.Validate()
+	if !errors.Is(err, common.ErrNilArguments) {
+		t.Errorf("received: %v, expected: %v", err, common.ErrNilArguments)
+	}
+
+	d = &DrawdownStopOut{}
+	err = d.Validate()
+	if !errors.Is(err, errInvalidDrawdownStopOut) {
+		t.Errorf("received: %v, expected: %v", err, errInvalidDrawdownStopOut)
+	}
+
+	d.MaximumDrawdownPercent = decimal.NewFromInt(101)
+	err = d.Validate()
+	if !errors.Is(err, errInvalidDrawdownStopOut) {
+		t.Errorf("received: %v, expected: %v", err, errInvalidDrawdownStopOut)
+	}
+
+	d.MaximumDrawdownPercent = decimal.NewFromInt(20)
+	d.Scope = "everything"
+	err = d.Validate()
+	if !errors.Is(err, errInvalidDrawdownStopOut) {
+		t.Errorf("received: %v, expected: %v", err, errInvalidDrawdownStopOut)
+	}
+
+	d.Scope = ""
+	err = d.Validate()
+	if !errors.Is(err, nil) {
+		t.Errorf("received: %v, expected: %v", err, nil)
+	}
+	if d.Scope != "position" {
+		t.Errorf("received: %v, expected: %v", d.Scope, "position")
+	}
+
+	d.Scope = "Portfolio"
+	err = d.Validate()
+	if !errors.Is(err, nil) {
+		t.Errorf("received: %v, expected: %v", err, nil)
+	}
+	if d.Scope != "portfolio" {
+		t.Errorf("received: %v, expected: %v", d.Scope, "portfolio")
+	}
+}
+
+func TestValidateOrderbookImbalanceDepth(t *testing.T) {
+	t.Parallel()
+	c := &Config{
+		CurrencySettings: []CurrencySettings{
+			{
+				ExchangeName:            testExchange,
+				Base:                    currency.BTC,
+				Quote:                   currency.USDT,
+				Asset:                   asset.Spot,
+				OrderbookImbalanceDepth: -1,
+			},
+		},
+	}
+	err := c.validateCurrencySettings()
+	if !errors.Is(err, errInvalidOrderbookImbalanceDepth) {
+		t.Errorf("received: %v, expected: %v", err, errInvalidOrderbookImbalanceDepth)
+	}
+	c.CurrencySettings[0].OrderbookImbalanceDepth = 5
+	err = c.validateCurrencySettings()
+	if !errors.Is(err, nil) {
+		t.Errorf("received: %v, expected: %v", err, nil)
+	}
+}
+
+func TestValidateStatisticSettings(t *testing.T) {
+	t.Parallel()
+	c := &Config{StatisticSettings: StatisticSettings{CostBasisMethod: "hifo"}}
+	err := c.validateStatisticSettings()
+	if !errors.Is(err, errInvalidCostBasisMethod) {
+		t.Errorf("received: %v, expected: %v", err, errInvalidCostBasisMethod)
+	}
+	c.StatisticSettings.CostBasisMethod = "LIFO"
+	err = c.validateStatisticSettings()
+	if !errors.Is(err, nil) {
+		t.Errorf("received: %v, expected: %v", err, nil)
+	}
+	if c.StatisticSettings.CostBasisMethod != "lifo" {
+		t.Errorf("received: %v, expected: %v", c.StatisticSettings.CostBasisMethod, "lifo")
+	}
+	if c.StatisticSettings.TradingCalendar != "continuous" {
+		t.Errorf("received: %v, expected: %v", c.StatisticSettings.TradingCalendar, "continuous")
+	}
+
+	c.StatisticSettings.TradingCalendar = "lunar"
+	err = c.validateStatisticSettings()
+	if !errors.Is(err, errInvalidTradingCalendar) {
+		t.Errorf("received: %v, expected: %v", err, errInvalidTradingCalendar)
+	}
+	c.StatisticSettings.TradingCalendar = "Business-Days"
+	err = c.validateStatisticSettings()
+	if !errors.Is(err, nil) {
+		t.Errorf("received: %v, expected: %v", err, nil)
+	}
+	if c.StatisticSettings.TradingCalendar != "business-days" {
+		t.Errorf("received: %v, expected: %v", c.StatisticSettings.TradingCalendar, "business-days")
+	}
+
+	c.StatisticSettings.RollingBetaWindow = 1
+	err = c.validateStatisticSettings()
+	if !errors.Is(err, errInvalidRollingBetaWindow) {
+		t.Errorf("received: %v, expected: %v", err, errInvalidRollingBetaWindow)
+	}
+	c.StatisticSettings.RollingBetaWindow = 30
+	err = c.validateStatisticSettings()
+	if !errors.Is(err, nil) {
+		t.Errorf("received: %v, expected: %v", err, nil)
+	}
+
+	c.StatisticSettings.ValueAtRiskConfidenceLevels = []decimal.Decimal{decimal.NewFromInt(95), decimal.NewFromInt(100)}
+	err = c.validateStatisticSettings()
+	if !errors.Is(err, errInvalidConfidenceLevel) {
+		t.Errorf("received: %v, expected: %v", err, errInvalidConfidenceLevel)
+	}
+	c.StatisticSettings.ValueAtRiskConfidenceLevels = []decimal.Decimal{decimal.NewFromInt(95), decimal.NewFromInt(99)}
+	err = c.validateStatisticSettings()
+	if !errors.Is(err, nil) {
+		t.Errorf("received: %v, expected: %v", err, nil)
+	}
+
+	c.StatisticSettings.AutocorrelationLags = -1
+	err = c.validateStatisticSettings()
+	if !errors.Is(err, errInvalidAutocorrelationLags) {
+		t.Errorf("received: %v, expected: %v", err, errInvalidAutocorrelationLags)
+	}
+	c.StatisticSettings.AutocorrelationLags = 5
+	err = c.validateStatisticSettings()
+	if !errors.Is(err, nil) {
+		t.Errorf("received: %v, expected: %v", err, nil)
+	}
+
+	c.StatisticSettings.PerformanceFeePercent = decimal.NewFromInt(100)
+	err = c.validateStatisticSettings()
+	if !errors.Is(err, errInvalidPerformanceFee) {
+		t.Errorf("received: %v, expected: %v", err, errInvalidPerformanceFee)
+	}
+	c.StatisticSettings.PerformanceFeePercent = decimal.NewFromInt(20)
+	c.StatisticSettings.PerformanceFeeIntervalDays = -1
+	err = c.validateStatisticSettings()
+	if !errors.Is(err, errInvalidPerformanceFee) {
+		t.Errorf("received: %v, expected: %v", err, errInvalidPerformanceFee)
+	}
+	c.StatisticSettings.PerformanceFeeIntervalDays = 90
+	err = c.validateStatisticSettings()
+	if !errors.Is(err, nil) {
+		t.Errorf("received: %v, expected: %v", err, nil)
+	}
+
+	c.StatisticSettings.WashTradeWindowMinutes = -1
+	err = c.validateStatisticSettings()
+	if !errors.Is(err, errInvalidWashTradeSettings) {
+		t.Errorf("received: %v, expected: %v", err, errInvalidWashTradeSettings)
+	}
+	c.StatisticSettings.WashTradeWindowMinutes = 0
+	c.StatisticSettings.WashTradePriceTolerancePercent = decimal.NewFromFloat(0.1)
+	err = c.validateStatisticSettings()
+	if !errors.Is(err, errInvalidWashTradeSettings) {
+		t.Errorf("received: %v, expected: %v", err, errInvalidWashTradeSettings)
+	}
+	c.StatisticSettings.WashTradeWindowMinutes = 5
+	c.StatisticSettings.WashTradePriceTolerancePercent = decimal.NewFromInt(-1)
+	err = c.validateStatisticSettings()
+	if !errors.Is(err, errInvalidWashTradeSettings) {
+		t.Errorf("received: %v, expected: %v", err, errInvalidWashTradeSettings)
+	}
+	c.StatisticSettings.WashTradePriceTolerancePercent = decimal.NewFromFloat(0.1)
+	err = c.validateStatisticSettings()
+	if !errors.Is(err, nil) {
+		t.Errorf("received: %v, expected: %v", err, nil)
+	}
+
+	c.StatisticSettings.CapacityParticipationPercent = decimal.NewFromInt(101)
+	err = c.validateStatisticSettings()
+	if !errors.Is(err, errInvalidCapacityParticipation) {
+		t.Errorf("received: %v, expected: %v", err, errInvalidCapacityParticipation)
+	}
+	c.StatisticSettings.CapacityParticipationPercent = decimal.NewFromInt(1)
+	err = c.validateStatisticSettings()
+	if !errors.Is(err, nil) {
+		t.Errorf("received: %v, expected: %v", err, nil)
+	}
+}
+
+func TestValidateDowntimeGapCandles(t *testing.T) {
+	t.Parallel()
+	c := &Config{
+		CurrencySettings: []CurrencySettings{
+			{
+				ExchangeName:       testExchange,
+				Base:               currency.BTC,
+				Quote:              currency.USDT,
+				Asset:              asset.Spot,
+				DowntimeGapCandles: -1,
+			},
+		},
+	}
+	err := c.validateCurrencySettings()
+	if !errors.Is(err, errInvalidDowntimeGapCandles) {
+		t.Errorf("received: %v, expected: %v", err, errInvalidDowntimeGapCandles)
+	}
+	c.CurrencySettings[0].DowntimeGapCandles = 3
+	err = c.validateCurrencySettings()
+	if !errors.Is(err, nil) {
+		t.Errorf("received: %v, expected: %v", err, nil)
+	}
+}
+
+func TestValidateCrossedOrderbookBehaviour(t *testing.T) {
+	t.Parallel()
+	c := &Config{
+		CurrencySettings: []CurrencySettings{
+			{
+				ExchangeName:              testExchange,
+				Base:                      currency.BTC,
+				Quote:                     currency.USDT,
+				Asset:                     asset.Spot,
+				CrossedOrderbookBehaviour: "trade-through",
+			},
+		},
+	}
+	err := c.validateCurrencySettings()
+	if !errors.Is(err, errInvalidCrossedOrderbookBehaviour) {
+		t.Errorf("received: %v, expected: %v", err, errInvalidCrossedOrderbookBehaviour)
+	}
+	c.CurrencySettings[0].CrossedOrderbookBehaviour = ""
+	err = c.validateCurrencySettings()
+	if !errors.Is(err, nil) {
+		t.Errorf("received: %v, expected: %v", err, nil)
+	}
+	if c.CurrencySettings[0].CrossedOrderbookBehaviour != "reject" {
+		t.Errorf("received: %v, expected: %v", c.CurrencySettings[0].CrossedOrderbookBehaviour, "reject")
+	}
+	c.CurrencySettings[0].CrossedOrderbookBehaviour = "WAIT"
+	err = c.validateCurrencySettings()
+	if !errors.Is(err, nil) {
+		t.Errorf("received: %v, expected: %v", err, nil)
+	}
+}
+
+func TestValidateMaxPositionAge(t *testing.T) {
+	t.Parallel()
+	c := &Config{
+		CurrencySettings: []CurrencySettings{
+			{
+				ExchangeName:          testExchange,
+				Base:                  currency.BTC,
+				Quote:                 currency.USDT,
+				Asset:                 asset.Spot,
+				MaxPositionAgeMinutes: -1,
+			},
+		},
+	}
+	err := c.validateCurrencySettings()
+	if !errors.Is(err, errInvalidMaxPositionAge) {
+		t.Errorf("received: %v, expected: %v", err, errInvalidMaxPositionAge)
+	}
+	c.CurrencySettings[0].MaxPositionAgeMinutes = 1440
+	err = c.validateCurrencySettings()
+	if !errors.Is(err, nil) {
+		t.Errorf("received: %v, expected: %v", err, nil)
+	}
+}
+
+func TestValidateExecutionDelay(t *testing.T) {
+	t.Parallel()
+	c := &Config{
+		CurrencySettings: []CurrencySettings{
+			{
+				ExchangeName:          testExchange,
+				Base:                  currency.BTC,
+				Quote:                 currency.USDT,
+				Asset:                 asset.Spot,
+				ExecutionDelayCandles: -1,
+			},
+		},
+	}
+	err := c.validateCurrencySettings()
+	if !errors.Is(err, errInvalidExecutionDelay) {
+		t.Errorf("received: %v, expected: %v", err, errInvalidExecutionDelay)
+	}
+	c.CurrencySettings[0].ExecutionDelayCandles = 2
+	err = c.validateCurrencySettings()
+	if !errors.Is(err, nil) {
+		t.Errorf("received: %v, expected: %v", err, nil)
+	}
+}
+
+func TestValidateMaximumOrderbookAge(t *testing.T) {
+	t.Parallel()
+	c := &Config{
+		CurrencySettings: []CurrencySettings{
+			{
+				ExchangeName:               testExchange,
+				Base:                       currency.BTC,
+				Quote:                      currency.USDT,
+				Asset:                      asset.Spot,
+				MaximumOrderbookAgeSeconds: -1,
+			},
+		},
+	}
+	err := c.validateCurrencySettings()
+	if !errors.Is(err, errInvalidMaximumOrderbookAge) {
+		t.Errorf("received: %v, expected: %v", err, errInvalidMaximumOrderbookAge)
+	}
+	c.CurrencySettings[0].MaximumOrderbookAgeSeconds = 30
+	err = c.validateCurrencySettings()
+	if !errors.Is(err, nil) {
+		t.Errorf("received: %v, expected: %v", err, nil)
+	}
+}
+
+func TestValidateSubmissionRetries(t *testing.T) {
+	t.Parallel()
+	c := &Config{
+		CurrencySettings: []CurrencySettings{
+			{
+				ExchangeName:      testExchange,
+				Base:              currency.BTC,
+				Quote:             currency.USDT,
+				Asset:             asset.Spot,
+				SubmissionRetries: -1,
+			},
+		},
+	}
+	err := c.validateCurrencySettings()
+	if !errors.Is(err, errInvalidSubmissionRetries) {
+		t.Errorf("received: %v, expected: %v", err, errInvalidSubmissionRetries)
+	}
+	c.CurrencySettings[0].SubmissionRetries = 3
+	c.CurrencySettings[0].SubmissionRetryBackoffMilliseconds = -1
+	err = c.validateCurrencySettings()
+	if !errors.Is(err, errInvalidSubmissionRetries) {
+		t.Errorf("received: %v, expected: %v", err, errInvalidSubmissionRetries)
+	}
+	c.CurrencySettings[0].SubmissionRetryBackoffMilliseconds = 500
+	err = c.validateCurrencySettings()
+	if !errors.Is(err, nil) {
+		t.Errorf("received: %v, expected: %v", err, nil)
+	}
+}
+
+func TestValidateMaximumCapitalAllocation(t *testing.T) {
+	t.Parallel()
+	c := &Config{
+		CurrencySettings: []CurrencySettings{
+			{
+				ExchangeName:             testExchange,
+				Base:                     currency.BTC,
+				Quote:                    currency.USDT,
+				Asset:                    asset.Spot,
+				MaximumCapitalAllocation: decimal.NewFromInt(-1),
+			},
+		},
+	}
+	err := c.validateCurrencySettings()
+	if !errors.Is(err, errInvalidMaximumCapitalAllocation) {
+		t.Errorf("received: %v, expected: %v", err, errInvalidMaximumCapitalAllocation)
+	}
+	c.CurrencySettings[0].MaximumCapitalAllocation = decimal.NewFromInt(1000)
+	err = c.validateCurrencySettings()
+	if !errors.Is(err, nil) {
+		t.Errorf("received: %v, expected: %v", err, nil)
+	}
+}
+
+func TestValidateVolumeFitWindow(t *testing.T) {
+	t.Parallel()
+	c := &Config{
+		CurrencySettings: []CurrencySettings{
+			{
+				ExchangeName:    testExchange,
+				Base:            currency.BTC,
+				Quote:           currency.USDT,
+				Asset:           asset.Spot,
+				VolumeFitWindow: -1,
+			},
+		},
+	}
+	err := c.validateCurrencySettings()
+	if !errors.Is(err, errInvalidVolumeFitWindow) {
+		t.Errorf("received: %v, expected: %v", err, errInvalidVolumeFitWindow)
+	}
+	c.CurrencySettings[0].VolumeFitWindow = 4
+	err = c.validateCurrencySettings()
+	if !errors.Is(err, nil) {
+		t.Errorf("received: %v, expected: %v", err, nil)
+	}
+}
+
+func TestValidatePartialFills(t *testing.T) {
+	t.Parallel()
+	c := &Config{
+		CurrencySettings: []CurrencySettings{
+			{
+				ExchangeName:            testExchange,
+				Base:                    currency.BTC,
+				Quote:                   currency.USDT,
+				Asset:                   asset.Spot,
+				AllowPartialFills:       true,
+				SkipCandleVolumeFitting: true,
+			},
+		},
+	}
+	err := c.validateCurrencySettings()
+	if !errors.Is(err, errInvalidPartialFills) {
+		t.Errorf("received: %v, expected: %v", err, errInvalidPartialFills)
+	}
+	c.CurrencySettings[0].SkipCandleVolumeFitting = false
+	err = c.validateCurrencySettings()
+	if !errors.Is(err, nil) {
+		t.Errorf("received: %v, expected: %v", err, nil)
+	}
+}
+
+func TestValidateVolumeExceedBehaviour(t *testing.T) {
+	t.Parallel()
+	c := &Config{
+		CurrencySettings: []CurrencySettings{
+			{
+				ExchangeName:          testExchange,
+				Base:                  currency.BTC,
+				Quote:                 currency.USDT,
+				Asset:                 asset.Spot,
+				VolumeExceedBehaviour: "ignore",
+			},
+		},
+	}
+	err := c.validateCurrencySettings()
+	if !errors.Is(err, errInvalidVolumeExceedBehaviour) {
+		t.Errorf("received: %v, expected: %v", err, errInvalidVolumeExceedBehaviour)
+	}
+	c.CurrencySettings[0].VolumeExceedBehaviour = ""
+	err = c.validateCurrencySettings()
+	if !errors.Is(err, nil) {
+		t.Errorf("received: %v, expected: %v", err, nil)
+	}
+	if c.CurrencySettings[0].VolumeExceedBehaviour != "shrink" {
+		t.Errorf("received: %v, expected: %v", c.CurrencySettings[0].VolumeExceedBehaviour, "shrink")
+	}
+	c.CurrencySettings[0].VolumeExceedBehaviour = "Reject"
+	err = c.validateCurrencySettings()
+	if !errors.Is(err, nil) {
+		t.Errorf("received: %v, expected: %v", err, nil)
+	}
+	c.CurrencySettings[0].AllowPartialFills = true
+	err = c.validateCurrencySettings()
+	if !errors.Is(err, errInvalidPartialFills) {
+		t.Errorf("received: %v, expected: %v", err, errInvalidPartialFills)
+	}
+}
+
+func TestValidateQuotePrecision(t *testing.T) {
+	t.Parallel()
+	c := &Config{
+		CurrencySettings: []CurrencySettings{
+			{
+				ExchangeName:      testExchange,
+				Base:              currency.BTC,
+				Quote:             currency.USDT,
+				Asset:             asset.Spot,
+				RoundQuoteFunding: true,
+				QuotePrecision:    -1,
+			},
+		},
+	}
+	err := c.validateCurrencySettings()
+	if !errors.Is(err, errInvalidQuotePrecision) {
+		t.Errorf("received: %v, expected: %v", err, errInvalidQuotePrecision)
+	}
+	c.CurrencySettings[0].QuotePrecision = 2
+	err = c.validateCurrencySettings()
+	if !errors.Is(err, nil) {
+		t.Errorf("received: %v, expected: %v", err, nil)
+	}
+}
+
+func TestValidateSlippageTiers(t *testing.T) {
+	t.Parallel()
+	c := &Config{
+		CurrencySettings: []CurrencySettings{
+			{
+				ExchangeName: testExchange,
+				Base:         currency.BTC,
+				Quote:        currency.USDT,
+				Asset:        asset.Spot,
+				SlippageTiers: []SlippageTier{
+					{MinimumNotional: decimal.Zero, BasisPoints: decimal.NewFromInt(20)},
+					{MinimumNotional: decimal.NewFromInt(10000), BasisPoints: decimal.NewFromInt(2)},
+				},
+			},
+		},
+	}
+	err := c.validateCurrencySettings()
+	if !errors.Is(err, errInvalidSlippageTiers) {
+		t.Errorf("received: %v, expected: %v", err, errInvalidSlippageTiers)
+	}
+	c.CurrencySettings[0].SlippageTiers[1].BasisPoints = decimal.NewFromInt(20)
+	c.CurrencySettings[0].SlippageTiers[1].MinimumNotional = decimal.Zero
+	err = c.validateCurrencySettings()
+	if !errors.Is(err, errInvalidSlippageTiers) {
+		t.Errorf("received: %v, expected: %v", err, errInvalidSlippageTiers)
+	}
+	c.CurrencySettings[0].SlippageTiers[1].MinimumNotional = decimal.NewFromInt(10000)
+	c.CurrencySettings[0].SlippageTiers[1].BasisPoints = decimal.NewFromInt(10000)
+	err = c.validateCurrencySettings()
+	if !errors.Is(err, errInvalidSlippageTiers) {
+		t.Errorf("received: %v, expected: %v", err, errInvalidSlippageTiers)
+	}
+	c.CurrencySettings[0].SlippageTiers[0].BasisPoints = decimal.NewFromInt(2)
+	c.CurrencySettings[0].SlippageTiers[1].BasisPoints = decimal.NewFromInt(20)
+	err = c.validateCurrencySettings()
+	if !errors.Is(err, nil) {
+		t.Errorf("received: %v, expected: %v", err, nil)
+	}
+}
+
+func TestValidateFeeTiers(t *testing.T) {
+	t.Parallel()
+	c := &Config{
+		CurrencySettings: []CurrencySettings{
+			{
+				ExchangeName: testExchange,
+				Base:         currency.BTC,
+				Quote:        currency.USDT,
+				Asset:        asset.Spot,
+				FeeTiers: []FeeTier{
+					{VolumeThreshold: decimal.Zero, MakerBasisPoints: decimal.NewFromInt(2), TakerBasisPoints: decimal.NewFromInt(5)},
+					{VolumeThreshold: decimal.NewFromInt(100000), MakerBasisPoints: decimal.NewFromInt(1), TakerBasisPoints: decimal.NewFromInt(10)},
+				},
+			},
+		},
+	}
+	err := c.validateCurrencySettings()
+	if !errors.Is(err, errInvalidFeeTiers) {
+		t.Errorf("received: %v, expected: %v", err, errInvalidFeeTiers)
+	}
+	c.CurrencySettings[0].FeeTiers[1].TakerBasisPoints = decimal.NewFromInt(4)
+	c.CurrencySettings[0].FeeTiers[1].VolumeThreshold = decimal.Zero
+	err = c.validateCurrencySettings()
+	if !errors.Is(err, errInvalidFeeTiers) {
+		t.Errorf("received: %v, expected: %v", err, errInvalidFeeTiers)
+	}
+	c.CurrencySettings[0].FeeTiers[1].VolumeThreshold = decimal.NewFromInt(100000)
+	c.CurrencySettings[0].FeeTiers[0].MakerBasisPoints = decimal.NewFromInt(-10000)
+	err = c.validateCurrencySettings()
+	if !errors.Is(err, errInvalidFeeTiers) {
+		t.Errorf("received: %v, expected: %v", err, errInvalidFeeTiers)
+	}
+	c.CurrencySettings[0].FeeTiers[0].MakerBasisPoints = decimal.NewFromInt(2)
+	err = c.validateCurrencySettings()
+	if !errors.Is(err, nil) {
+		t.Errorf("received: %v, expected: %v", err, nil)
+	}
+	// negative maker basis points are rebates
+	c.CurrencySettings[0].FeeTiers[1].MakerBasisPoints = decimal.NewFromInt(-1)
+	err = c.validateCurrencySettings()
+	if !errors.Is(err, nil) {
+		t.Errorf("received: %v, expected: %v", err, nil)
+	}
+}
+
+func TestValidateMinimumHoldingPeriod(t *testing.T) {
+	t.Parallel()
+	c := &Config{
+		CurrencySettings: []CurrencySettings{
+			{
+				ExchangeName:                testExchange,
+				Base:                        currency.BTC,
+				Quote:                       currency.USDT,
+				Asset:                       asset.Spot,
+				MinimumHoldingPeriodMinutes: -1,
+			},
+		},
+	}
+	err := c.validateCurrencySettings()
+	if !errors.Is(err, errInvalidMinimumHoldingPeriod) {
+		t.Errorf("received: %v, expected: %v", err, errInvalidMinimumHoldingPeriod)
+	}
+	c.CurrencySettings[0].MinimumHoldingPeriodMinutes = 1440
+	err = c.validateCurrencySettings()
+	if !errors.Is(err, nil) {
+		t.Errorf("received: %v, expected: %v", err, nil)
+	}
+}
+
+func TestLosingStreakBreakerValidate(t *testing.T) {
+	t.Parallel()
+	var l *LosingStreakBreaker
+	err := l.Validate()
+	if !errors.Is(err, common.ErrNilArguments) {
+		t.Errorf("received: %v, expected: %v", err, common.ErrNilArguments)
+	}
+
+	l = &LosingStreakBreaker{}
+	err = l.Validate()
+	if !errors.Is(err, errInvalidLosingStreakBreaker) {
+		t.Errorf("received: %v, expected: %v", err, errInvalidLosingStreakBreaker)
+	}
+
+	l.ConsecutiveLosses = 3
+	l.CooldownMinutes = -1
+	err = l.Validate()
+	if !errors.Is(err, errInvalidLosingStreakBreaker) {
+		t.Errorf("received: %v, expected: %v", err, errInvalidLosingStreakBreaker)
+	}
+
+	l.CooldownMinutes = 0
+	err = l.Validate()
+	if !errors.Is(err, nil) {
+		t.Errorf("received: %v, expected: %v", err, nil)
+	}
+}
+
+func TestValidateFillTimeAssignment(t *testing.T) {
+	t.Parallel()
+	c := &Config{
+		CurrencySettings: []CurrencySettings{
+			{
+				ExchangeName:       testExchange,
+				Base:               currency.BTC,
+				Quote:              currency.USDT,
+				Asset:              asset.Spot,
+				FillTimeAssignment: "midnight",
+			},
+		},
+	}
+	err := c.validateCurrencySettings()
+	if !errors.Is(err, errInvalidFillTimeAssignment) {
+		t.Errorf("received: %v, expected: %v", err, errInvalidFillTimeAssignment)
+	}
+	c.CurrencySettings[0].FillTimeAssignment = ""
+	err = c.validateCurrencySettings()
+	if !errors.Is(err, nil) {
+		t.Errorf("received: %v, expected: %v", err, nil)
+	}
+	if c.CurrencySettings[0].FillTimeAssignment != "close" {
+		t.Errorf("received: %v, expected: %v", c.CurrencySettings[0].FillTimeAssignment, "close")
+	}
+	c.CurrencySettings[0].FillTimeAssignment = "Proportional"
+	err = c.validateCurrencySettings()
+	if !errors.Is(err, nil) {
+		t.Errorf("received: %v, expected: %v", err, nil)
+	}
+}
+
+func TestValidateSignalToFillConvention(t *testing.T) {
+	t.Parallel()
+	c := &Config{
+		CurrencySettings: []CurrencySettings{
+			{
+				ExchangeName:           testExchange,
+				Base:                   currency.BTC,
+				Quote:                  currency.USDT,
+				Asset:                  asset.Spot,
+				SignalToFillConvention: "next-candle-close",
+			},
+		},
+	}
+	err := c.validateCurrencySettings()
+	if !errors.Is(err, errInvalidSignalToFillConvention) {
+		t.Errorf("received: %v, expected: %v", err, errInvalidSignalToFillConvention)
+	}
+	c.CurrencySettings[0].SignalToFillConvention = ""
+	err = c.validateCurrencySettings()
+	if !errors.Is(err, nil) {
+		t.Errorf("received: %v, expected: %v", err, nil)
+	}
+	if c.CurrencySettings[0].SignalToFillConvention != "next-candle-open" {
+		t.Errorf("received: %v, expected: %v", c.CurrencySettings[0].SignalToFillConvention, "next-candle-open")
+	}
+	c.CurrencySettings[0].SignalToFillConvention = "Same-Candle-Close"
+	err = c.validateCurrencySettings()
+	if !errors.Is(err, nil) {
+		t.Errorf("received: %v, expected: %v", err, nil)
+	}
+}
+
+func TestValidatePriceTickSize(t *testing.T) {
+	t.Parallel()
+	c := &Config{
+		CurrencySettings: []CurrencySettings{
+			{
+				ExchangeName:  testExchange,
+				Base:          currency.BTC,
+				Quote:         currency.USDT,
+				Asset:         asset.Spot,
+				PriceTickSize: decimal.NewFromInt(-1),
+			},
+		},
+	}
+	err := c.validateCurrencySettings()
+	if !errors.Is(err, errInvalidPriceTickSize) {
+		t.Errorf("received: %v, expected: %v", err, errInvalidPriceTickSize)
+	}
+	c.CurrencySettings[0].PriceTickSize = decimal.NewFromFloat(0.5)
+	err = c.validateCurrencySettings()
+	if !errors.Is(err, errInvalidPriceTickSize) {
+		t.Errorf("received: %v, expected: %v", err, errInvalidPriceTickSize)
+	}
+	c.CurrencySettings[0].CanUseExchangeLimits = true
+	err = c.validateCurrencySettings()
+	if !errors.Is(err, nil) {
+		t.Errorf("received: %v, expected: %v", err, nil)
+	}
+}
+
+func TestValidateFlipPolicy(t *testing.T) {
+	t.Parallel()
+	c := &Config{
+		CurrencySettings: []CurrencySettings{
+			{
+				ExchangeName: testExchange,
+				Base:         currency.BTC,
+				Quote:        currency.USDT,
+				Asset:        asset.Spot,
+				FlipPolicy:   "flip",
+			},
+		},
+	}
+	err := c.validateCurrencySettings()
+	if !errors.Is(err, errInvalidFlipPolicy) {
+		t.Errorf("received: %v, expected: %v", err, errInvalidFlipPolicy)
+	}
+	c.CurrencySettings[0].FlipPolicy = ""
+	err = c.validateCurrencySettings()
+	if !errors.Is(err, nil) {
+		t.Errorf("received: %v, expected: %v", err, nil)
+	}
+	if c.CurrencySettings[0].FlipPolicy != "clamp-to-flat" {
+		t.Errorf("received: %v, expected: %v", c.CurrencySettings[0].FlipPolicy, "clamp-to-flat")
+	}
+	for _, policy := range []string{"Allow-Flip", "reject-excess"} {
+		c.CurrencySettings[0].FlipPolicy = policy
+		err = c.validateCurrencySettings()
+		if !errors.Is(err, nil) {
+			t.Errorf("received: %v, expected: %v", err, nil)
+		}
+	}
+}
+
+func TestValidateInitialBaseEntryPrice(t *testing.T) {
+	t.Parallel()
+	leet := decimal.NewFromInt(1337)
+	c := &Config{
+		CurrencySettings: []CurrencySettings{
+			{
+				ExchangeName: testExchange,
+				Base:         currency.BTC,
+				Quote:        currency.USDT,
+				Asset:        asset.Spot,
+				SpotDetails: &SpotDetails{
+					InitialQuoteFunds:     &leet,
+					InitialBaseEntryPrice: &decimal.Zero,
+				},
+			},
+		},
+	}
+	err := c.validateCurrencySettings()
+	if !errors.Is(err, errInvalidInitialBaseEntryPrice) {
+		t.Errorf("received: %v, expected: %v", err, errInvalidInitialBaseEntryPrice)
+	}
+	entryPrice := decimal.NewFromInt(40)
+	c.CurrencySettings[0].SpotDetails.InitialBaseEntryPrice = &entryPrice
+	err = c.validateCurrencySettings()
+	if !errors.Is(err, errInvalidInitialBaseEntryPrice) {
+		t.Errorf("received: %v, expected: %v", err, errInvalidInitialBaseEntryPrice)
+	}
+	c.CurrencySettings[0].SpotDetails.InitialBaseFunds = &leet
+	err = c.validateCurrencySettings()
+	if !errors.Is(err, nil) {
+		t.Errorf("received: %v, expected: %v", err, nil)
+	}
+}
+
+func TestValidateDepthExhaustionBehaviour(t *testing.T) {
+	t.Parallel()
+	c := &Config{
+		CurrencySettings: []CurrencySettings{
+			{
+				ExchangeName:             testExchange,
+				Base:                     currency.BTC,
+				Quote:                    currency.USDT,
+				Asset:                    asset.Spot,
+				DepthExhaustionBehaviour: "sweep",
+			},
+		},
+	}
+	err := c.validateCurrencySettings()
+	if !errors.Is(err, errInvalidDepthExhaustion) {
+		t.Errorf("received: %v, expected: %v", err, errInvalidDepthExhaustion)
+	}
+	c.CurrencySettings[0].DepthExhaustionBehaviour = ""
+	err = c.validateCurrencySettings()
+	if !errors.Is(err, nil) {
+		t.Errorf("received: %v, expected: %v", err, nil)
+	}
+	if c.CurrencySettings[0].DepthExhaustionBehaviour != "partial-fill" {
+		t.Errorf("received: %v, expected: %v", c.CurrencySettings[0].DepthExhaustionBehaviour, "partial-fill")
+	}
+	c.CurrencySettings[0].DepthExhaustionBehaviour = "Reject"
+	err = c.validateCurrencySettings()
+	if !errors.Is(err, nil) {
+		t.Errorf("received: %v, expected: %v", err, nil)
+	}
+}
+
+func TestValidateTagAllocations(t *testing.T) {
+	t.Parallel()
+	c := &Config{
+		CurrencySettings: []CurrencySettings{
+			{
+				ExchangeName: testExchange,
+				Base:         currency.BTC,
+				Quote:        currency.USDT,
+				Asset:        asset.Spot,
+				TagAllocations: map[string]decimal.Decimal{
+					"": decimal.NewFromInt(10),
+				},
+			},
+		},
+	}
+	err := c.validateCurrencySettings()
+	if !errors.Is(err, errInvalidTagAllocations) {
+		t.Errorf("received: %v, expected: %v", err, errInvalidTagAllocations)
+	}
+	c.CurrencySettings[0].TagAllocations = map[string]decimal.Decimal{"trend": decimal.Zero}
+	err = c.validateCurrencySettings()
+	if !errors.Is(err, errInvalidTagAllocations) {
+		t.Errorf("received: %v, expected: %v", err, errInvalidTagAllocations)
+	}
+	c.CurrencySettings[0].TagAllocations = map[string]decimal.Decimal{
+		"trend":          decimal.NewFromInt(60),
+		"mean-reversion": decimal.NewFromInt(41),
+	}
+	err = c.validateCurrencySettings()
+	if !errors.Is(err, errInvalidTagAllocations) {
+		t.Errorf("received: %v, expected: %v", err, errInvalidTagAllocations)
+	}
+	c.CurrencySettings[0].TagAllocations["mean-reversion"] = decimal.NewFromInt(40)
+	err = c.validateCurrencySettings()
+	if !errors.Is(err, nil) {
+		t.Errorf("received: %v, expected: %v", err, nil)
+	}
+}
+
+func TestValidateAccounts(t *testing.T) {
+	t.Parallel()
+	c := &Config{
+		CurrencySettings: []CurrencySettings{
+			{
+				ExchangeName: testExchange,
+				Base:         currency.BTC,
+				Quote:        currency.USDT,
+				Asset:        asset.Spot,
+				Accounts: map[string]decimal.Decimal{
+					"": decimal.NewFromInt(10),
+				},
+			},
+		},
+	}
+	err := c.validateCurrencySettings()
+	if !errors.Is(err, errInvalidAccounts) {
+		t.Errorf("received: %v, expected: %v", err, errInvalidAccounts)
+	}
+	c.CurrencySettings[0].Accounts = map[string]decimal.Decimal{"variant-a": decimal.Zero}
+	err = c.validateCurrencySettings()
+	if !errors.Is(err, errInvalidAccounts) {
+		t.Errorf("received: %v, expected: %v", err, errInvalidAccounts)
+	}
+	c.CurrencySettings[0].Accounts = map[string]decimal.Decimal{
+		"variant-a": decimal.NewFromInt(60),
+		"variant-b": decimal.NewFromInt(41),
+	}
+	err = c.validateCurrencySettings()
+	if !errors.Is(err, errInvalidAccounts) {
+		t.Errorf("received: %v, expected: %v", err, errInvalidAccounts)
+	}
+	c.CurrencySettings[0].Accounts["variant-b"] = decimal.NewFromInt(40)
+	err = c.validateCurrencySettings()
+	if !errors.Is(err, nil) {
+		t.Errorf("received: %v, expected: %v", err, nil)
+	}
+
+	c.FundingSettings.UseExchangeLevelFunding = true
+	err = c.validateCurrencySettings()
+	if !errors.Is(err, errInvalidAccounts) {
+		t.Errorf("received: %v, expected: %v", err, errInvalidAccounts)
+	}
+	c.FundingSettings.UseExchangeLevelFunding = false
+	c.CurrencySettings[0].Asset = asset.Futures
+	err = c.validateCurrencySettings()
+	if !errors.Is(err, errInvalidAccounts) {
+		t.Errorf("received: %v, expected: %v", err, errInvalidAccounts)
+	}
+}
+
+func TestValidateBorrowRate(t *testing.T) {
+	t.Parallel()
+	c := &Config{
+		CurrencySettings: []CurrencySettings{
+			{
+				ExchangeName:      testExchange,
+				Base:              currency.BTC,
+				Quote:             currency.USDT,
+				Asset:             asset.Spot,
+				BorrowRatePercent: decimal.NewFromInt(-1),
+			},
+		},
+	}
+	err := c.validateCurrencySettings()
+	if !errors.Is(err, errInvalidBorrowRate) {
+		t.Errorf("received: %v, expected: %v", err, errInvalidBorrowRate)
+	}
+	c.CurrencySettings[0].BorrowRatePercent = decimal.NewFromInt(10)
+	err = c.validateCurrencySettings()
+	if !errors.Is(err, errInvalidBorrowRate) {
+		t.Errorf("received: %v, expected: %v", err, errInvalidBorrowRate)
+	}
+	c.CurrencySettings[0].BorrowRatePercent = decimal.Zero
+	err = c.validateCurrencySettings()
+	if !errors.Is(err, nil) {
+		t.Errorf("received: %v, expected: %v", err, nil)
+	}
+}
+
+func TestValidateFundingRates(t *testing.T) {
+	t.Parallel()
+	tt := time.Date(2022, 1, 1, 8, 0, 0, 0, time.UTC)
+	c := &Config{
+		CurrencySettings: []CurrencySettings{
+			{
+				ExchangeName: testExchange,
+				Base:         currency.BTC,
+				Quote:        currency.USDT,
+				Asset:        asset.Spot,
+				FundingRates: []FundingRate{{Time: tt, Rate: decimal.NewFromFloat(0.0001)}},
+			},
+		},
+	}
+	err := c.validateCurrencySettings()
+	if !errors.Is(err, errInvalidFundingRates) {
+		t.Errorf("received: %v, expected: %v", err, errInvalidFundingRates)
+	}
+	c.CurrencySettings[0].Asset = asset.Futures
+	c.CurrencySettings[0].FundingRates = append(c.CurrencySettings[0].FundingRates, FundingRate{Time: tt, Rate: decimal.NewFromFloat(0.0002)})
+	err = c.validateCurrencySettings()
+	if !errors.Is(err, errInvalidFundingRates) {
+		t.Errorf("received: %v, expected: %v", err, errInvalidFundingRates)
+	}
+	c.CurrencySettings[0].FundingRates[1] = FundingRate{Time: tt.Add(time.Hour * 8), Rate: decimal.NewFromInt(1)}
+	err = c.validateCurrencySettings()
+	if !errors.Is(err, errInvalidFundingRates) {
+		t.Errorf("received: %v, expected: %v", err, errInvalidFundingRates)
+	}
+	c.CurrencySettings[0].FundingRates[1] = FundingRate{Rate: decimal.NewFromFloat(0.0002)}
+	err = c.validateCurrencySettings()
+	if !errors.Is(err, errInvalidFundingRates) {
+		t.Errorf("received: %v, expected: %v", err, errInvalidFundingRates)
+	}
+	c.CurrencySettings[0].FundingRates[1] = FundingRate{Time: tt.Add(time.Hour * 8), Rate: decimal.NewFromFloat(-0.0002)}
+	err = c.validateCurrencySettings()
+	if !errors.Is(err, nil) {
+		t.Errorf("received: %v, expected: %v", err, nil)
+	}
+	c.CurrencySettings[0].FundingRateCSVPath = filepath.Join(t.TempDir(), "missing.csv")
+	err = c.validateCurrencySettings()
+	if !errors.Is(err, common.ErrFileNotFound) {
+		t.Errorf("received: %v, expected: %v", err, common.ErrFileNotFound)
+	}
+}
+
+func TestGetFundingRates(t *testing.T) {
+	t.Parallel()
+	tt := time.Date(2022, 1, 1, 8, 0, 0, 0, time.UTC)
+	path := filepath.Join(t.TempDir(), "fundingrates.csv")
+	err := os.WriteFile(path, []byte("1641081600,0.0003\n1641052800, -0.0002\n"), file.DefaultPermissionOctal)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: %v, expected: %v", err, nil)
+	}
+	c := &CurrencySettings{
+		FundingRates:       []FundingRate{{Time: tt, Rate: decimal.NewFromFloat(0.0001)}},
+		FundingRateCSVPath: path,
+	}
+	rates, err := c.GetFundingRates()
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: %v, expected: %v", err, nil)
+	}
+	// rates from the config and csv are merged in time order
+	expected := []FundingRate{
+		{Time: tt, Rate: decimal.NewFromFloat(0.0001)},
+		{Time: tt.Add(time.Hour * 8), Rate: decimal.NewFromFloat(-0.0002)},
+		{Time: tt.Add(time.Hour * 16), Rate: decimal.NewFromFloat(0.0003)},
+	}
+	if len(rates) != len(expected) {
+		t.Fatalf("received: %v, expected: %v", len(rates), len(expected))
+	}
+	for i := range rates {
+		if !rates[i].Time.Equal(expected[i].Time) || !rates[i].Rate.Equal(expected[i].Rate) {
+			t.Errorf("position %v received: %v, expected: %v", i, rates[i], expected[i])
+		}
+	}
+
+	err = os.WriteFile(path, []byte("1641052800,abc\n"), file.DefaultPermissionOctal)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: %v, expected: %v", err, nil)
+	}
+	_, err = c.GetFundingRates()
+	if !errors.Is(err, errInvalidFundingRates) {
+		t.Errorf("received: %v, expected: %v", err, errInvalidFundingRates)
+	}
+	err = os.WriteFile(path, []byte("1641052800\n"), file.DefaultPermissionOctal)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: %v, expected: %v", err, nil)
+	}
+	_, err = c.GetFundingRates()
+	if !errors.Is(err, errInvalidFundingRates) {
+		t.Errorf("received: %v, expected: %v", err, errInvalidFundingRates)
+	}
+}
+
+func TestValidateFeeShortfallBehaviour(t *testing.T) {
+	t.Parallel()
+	c := &Config{
+		CurrencySettings: []CurrencySettings{
+			{
+				ExchangeName:          testExchange,
+				Base:                  currency.BTC,
+				Quote:                 currency.USDT,
+				Asset:                 asset.Spot,
+				FeeShortfallBehaviour: "ignore",
+			},
+		},
+	}
+	err := c.validateCurrencySettings()
+	if !errors.Is(err, errInvalidFeeShortfallBehaviour) {
+		t.Errorf("received: %v, expected: %v", err, errInvalidFeeShortfallBehaviour)
+	}
+	c.CurrencySettings[0].FeeShortfallBehaviour = ""
+	err = c.validateCurrencySettings()
+	if !errors.Is(err, nil) {
+		t.Errorf("received: %v, expected: %v", err, nil)
+	}
+	if c.CurrencySettings[0].FeeShortfallBehaviour != "shrink" {
+		t.Errorf("received: %v, expected: %v", c.CurrencySettings[0].FeeShortfallBehaviour, "shrink")
+	}
+	c.CurrencySettings[0].FeeShortfallBehaviour = "Reject"
+	err = c.validateCurrencySettings()
+	if !errors.Is(err, nil) {
+		t.Errorf("received: %v, expected: %v", err, nil)
+	}
+}
+
+func TestValidateSlippageOverfillBehaviour(t *testing.T) {
+	t.Parallel()
+	c := &Config{
+		CurrencySettings: []CurrencySettings{
+			{
+				ExchangeName:              testExchange,
+				Base:                      currency.BTC,
+				Quote:                     currency.USDT,
+				Asset:                     asset.Spot,
+				SlippageOverfillBehaviour: "ignore",
+			},
+		},
+	}
+	err := c.validateCurrencySettings()
+	if !errors.Is(err, errInvalidSlippageOverfill) {
+		t.Errorf("received: %v, expected: %v", err, errInvalidSlippageOverfill)
+	}
+	c.CurrencySettings[0].SlippageOverfillBehaviour = ""
+	err = c.validateCurrencySettings()
+	if !errors.Is(err, nil) {
+		t.Errorf("received: %v, expected: %v", err, nil)
+	}
+	if c.CurrencySettings[0].SlippageOverfillBehaviour != "shrink" {
+		t.Errorf("received: %v, expected: %v", c.CurrencySettings[0].SlippageOverfillBehaviour, "shrink")
+	}
+	c.CurrencySettings[0].SlippageOverfillBehaviour = "Reject"
+	err = c.validateCurrencySettings()
+	if !errors.Is(err, nil) {
+		t.Errorf("received: %v, expected: %v", err, nil)
+	}
+}
+
+func TestValidateExtremeVolatility(t *testing.T) {
+	t.Parallel()
+	c := &Config{
+		CurrencySettings: []CurrencySettings{
+			{
+				ExchangeName:               testExchange,
+				Base:                       currency.BTC,
+				Quote:                      currency.USDT,
+				Asset:                      asset.Spot,
+				ExtremeVolatilityThreshold: decimal.NewFromInt(-1),
+			},
+		},
+	}
+	err := c.validateCurrencySettings()
+	if !errors.Is(err, errInvalidExtremeVolatility) {
+		t.Errorf("received: %v, expected: %v", err, errInvalidExtremeVolatility)
+	}
+	c.CurrencySettings[0].ExtremeVolatilityThreshold = decimal.NewFromInt(10)
+	c.CurrencySettings[0].ExtremeVolatilityBehaviour = "ignore"
+	err = c.validateCurrencySettings()
+	if !errors.Is(err, errInvalidExtremeVolatility) {
+		t.Errorf("received: %v, expected: %v", err, errInvalidExtremeVolatility)
+	}
+	c.CurrencySettings[0].ExtremeVolatilityBehaviour = ""
+	err = c.validateCurrencySettings()
+	if !errors.Is(err, nil) {
+		t.Errorf("received: %v, expected: %v", err, nil)
+	}
+	if c.CurrencySettings[0].ExtremeVolatilityBehaviour != "reject" {
+		t.Errorf("received: %v, expected: %v", c.CurrencySettings[0].ExtremeVolatilityBehaviour, "reject")
+	}
+	c.CurrencySettings[0].ExtremeVolatilityBehaviour = "Amplify-Slippage"
+	err = c.validateCurrencySettings()
+	if !errors.Is(err, nil) {
+		t.Errorf("received: %v, expected: %v", err, nil)
+	}
+	if !c.CurrencySettings[0].ExtremeVolatilitySlippageMultiplier.Equal(decimal.NewFromInt(2)) {
+		t.Errorf("received: %v, expected: %v", c.CurrencySettings[0].ExtremeVolatilitySlippageMultiplier, 2)
+	}
+	c.CurrencySettings[0].ExtremeVolatilitySlippageMultiplier = decimal.NewFromFloat(0.5)
+	err = c.validateCurrencySettings()
+	if !errors.Is(err, errInvalidExtremeVolatility) {
+		t.Errorf("received: %v, expected: %v", err, errInvalidExtremeVolatility)
+	}
+}
+
+func TestValidateRebatePolicy(t *testing.T) {
+	t.Parallel()
+	c := &Config{
+		CurrencySettings: []CurrencySettings{
+			{
+				ExchangeName: testExchange,
+				Base:         currency.BTC,
+				Quote:        currency.USDT,
+				Asset:        asset.Spot,
+				RebatePolicy: "spend",
+			},
+		},
+	}
+	err := c.validateCurrencySettings()
+	if !errors.Is(err, errInvalidRebatePolicy) {
+		t.Errorf("received: %v, expected: %v", err, errInvalidRebatePolicy)
+	}
+	c.CurrencySettings[0].RebatePolicy = ""
+	err = c.validateCurrencySettings()
+	if !errors.Is(err, nil) {
+		t.Errorf("received: %v, expected: %v", err, nil)
+	}
+	if c.CurrencySettings[0].RebatePolicy != "reinvest" {
+		t.Errorf("received: %v, expected: %v", c.CurrencySettings[0].RebatePolicy, "reinvest")
+	}
+	c.CurrencySettings[0].RebatePolicy = "Accumulate"
+	err = c.validateCurrencySettings()
+	if !errors.Is(err, nil) {
+		t.Errorf("received: %v, expected: %v", err, nil)
+	}
+}
+
+func TestValidateQuoteFundingBasket(t *testing.T) {
+	t.Parallel()
+	c := &Config{
+		StrategySettings: StrategySettings{Name: dca},
+		FundingSettings:  FundingSettings{UseQuoteFundingBasket: true},
+	}
+	err := c.validateStrategySettings()
+	if !errors.Is(err, errInvalidQuoteFundingBasket) {
+		t.Errorf("received: %v, expected: %v", err, errInvalidQuoteFundingBasket)
+	}
+
+	c.FundingSettings.UseQuoteFundingBasket = false
+	c.FundingSettings.QuoteFundingPriority = []currency.Code{currency.USDC}
+	err = c.validateStrategySettings()
+	if !errors.Is(err, errInvalidQuoteFundingBasket) {
+		t.Errorf("received: %v, expected: %v", err, errInvalidQuoteFundingBasket)
+	}
+
+	c.StrategySettings.SimultaneousSignalProcessing = true
+	c.FundingSettings.UseExchangeLevelFunding = true
+	c.FundingSettings.UseQuoteFundingBasket = true
+	c.FundingSettings.ExchangeLevelFunding = []ExchangeLevelFunding{
+		{
+			ExchangeName: testExchange,
+			Asset:        asset.Spot,
+			Currency:     currency.USDC,
+			InitialFunds: decimal.NewFromInt(1337),
+		},
+	}
+	c.FundingSettings.QuoteFundingPriority = []currency.Code{currency.BTC}
+	err = c.validateStrategySettings()
+	if !errors.Is(err, errInvalidQuoteFundingBasket) {
+		t.Errorf("received: %v, expected: %v", err, errInvalidQuoteFundingBasket)
+	}
+
+	c.FundingSettings.QuoteFundingPriority = []currency.Code{currency.USDC, currency.USDT}
+	err = c.validateStrategySettings()
+	if !errors.Is(err, nil) {
+		t.Errorf("received: %v, expected: %v", err, nil)
+	}
+}
+
+func TestHash(t *testing.T) {
+	t.Parallel()
+	var c *Config
+	_, err := c.Hash()
+	if !errors.Is(err, common.ErrNilArguments) {
+		t.Errorf("received '%v' expected '%v'", err, common.ErrNilArguments)
+	}
+	c = &Config{Nickname: "hash"}
+	hash, err := c.Hash()
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	if len(hash) != 64 {
+		t.Errorf("received '%v' expected a hex encoded SHA-256 hash", hash)
+	}
+	same, err := (&Config{Nickname: "hash"}).Hash()
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	if same != hash {
+		t.Errorf("received '%v' expected '%v'", same, hash)
+	}
+	c.Nickname = "different"
+	different, err := c.Hash()
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	if different == hash {
+		t.Error("expected different configs to hash differently")
+	}
+}
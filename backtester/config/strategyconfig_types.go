@@ -25,6 +25,56 @@ var (
 	errMinMaxEqual                      = errors.New("minimum and maximum limits cannot be equal")
 	errPerpetualsUnsupported            = errors.New("perpetual futures not yet supported")
 	errFeatureIncompatible              = errors.New("feature is not compatible")
+	errInvalidExecutionInterval         = errors.New("invalid execution interval, please check your config")
+	errInvalidTradingSession            = errors.New("invalid trading session, please check your config")
+	errInvalidLatencyDistribution       = errors.New("invalid latency distribution, please check your config")
+	errInvalidCostBasisMethod           = errors.New("invalid cost basis method, please check your config")
+	errInvalidDrawdownStopOut           = errors.New("invalid drawdown stop-out, please check your config")
+	errInvalidOrderbookImbalanceDepth   = errors.New("invalid orderbook imbalance depth, please check your config")
+	errInvalidDowntimeGapCandles        = errors.New("invalid downtime gap candles, please check your config")
+	errInvalidCrossedOrderbookBehaviour = errors.New("invalid crossed orderbook behaviour, please check your config")
+	errInvalidMaximumOrderbookAge       = errors.New("invalid maximum orderbook age, please check your config")
+	errInvalidDepthExhaustion           = errors.New("invalid depth exhaustion behaviour, please check your config")
+	errInvalidSubmissionRetries         = errors.New("invalid submission retries, please check your config")
+	errInvalidFeeShortfallBehaviour     = errors.New("invalid fee shortfall behaviour, please check your config")
+	errInvalidVolumeExceedBehaviour     = errors.New("invalid volume exceed behaviour, please check your config")
+	errInvalidSlippageOverfill          = errors.New("invalid slippage overfill behaviour, please check your config")
+	errInvalidExtremeVolatility         = errors.New("invalid extreme volatility settings, please check your config")
+	errInvalidRebatePolicy              = errors.New("invalid rebate policy, please check your config")
+	errInvalidCapitalFlow               = errors.New("invalid capital flow, please check your config")
+	errInvalidQuoteFundingBasket        = errors.New("invalid quote funding basket, please check your config")
+	errInvalidMaximumCapitalAllocation  = errors.New("invalid maximum capital allocation, please check your config")
+	errInvalidTagAllocations            = errors.New("invalid tag allocations, please check your config")
+	errInvalidAccounts                  = errors.New("invalid accounts, please check your config")
+	errInvalidVolumeFitWindow           = errors.New("invalid volume fit window, please check your config")
+	errInvalidPartialFills              = errors.New("invalid partial fills, please check your config")
+	errInvalidQuotePrecision            = errors.New("invalid quote precision, please check your config")
+	errInvalidSlippageTiers             = errors.New("invalid slippage tiers, please check your config")
+	errInvalidMinimumHoldingPeriod      = errors.New("invalid minimum holding period, please check your config")
+	errInvalidMaxPositionAge            = errors.New("invalid max position age, please check your config")
+	errInvalidLosingStreakBreaker       = errors.New("invalid losing streak breaker, please check your config")
+	errInvalidTradingCalendar           = errors.New("invalid trading calendar, please check your config")
+	errInvalidRollingBetaWindow         = errors.New("invalid rolling beta window, please check your config")
+	errInvalidConfidenceLevel           = errors.New("invalid value at risk confidence level, please check your config")
+	errInvalidAutocorrelationLags       = errors.New("invalid autocorrelation lags, please check your config")
+	errInvalidPerformanceFee            = errors.New("invalid performance fee, please check your config")
+	errInvalidWashTradeSettings         = errors.New("invalid wash trade settings, please check your config")
+	errInvalidCapacityParticipation     = errors.New("invalid capacity participation, please check your config")
+	errInvalidLiquidationPenalty        = errors.New("invalid liquidation penalty, please check your config")
+	errInvalidBorrowRate                = errors.New("invalid borrow rate, please check your config")
+	errInvalidFundingRates              = errors.New("invalid funding rates, please check your config")
+	errInvalidOpposingSignalPolicy      = errors.New("invalid opposing signal policy, please check your config")
+	errInvalidSignalPriority            = errors.New("invalid simultaneous signal priority, please check your config")
+	errInvalidFirstCandlePolicy         = errors.New("invalid first candle policy, please check your config")
+	errInvalidEndOfDataPolicy           = errors.New("invalid end of data policy, please check your config")
+	errInvalidFillTimeAssignment        = errors.New("invalid fill time assignment, please check your config")
+	errInvalidSignalToFillConvention    = errors.New("invalid signal to fill convention, please check your config")
+	errInvalidFlipPolicy                = errors.New("invalid flip policy, please check your config")
+	errInvalidInitialBaseEntryPrice     = errors.New("invalid initial base entry price, please check your config")
+	errInvalidPriceTickSize             = errors.New("invalid price tick size, please check your config")
+	errInvalidRoundingMode              = errors.New("invalid rounding mode, please check your config")
+	errInvalidExecutionDelay            = errors.New("invalid execution delay, please check your config")
+	errInvalidFeeTiers                  = errors.New("invalid fee tiers, please check your config")
 )
 
 // Config defines what is in an individual strategy config
@@ -54,6 +104,9 @@ type DataSettings struct {
 type FundingSettings struct {
 	UseExchangeLevelFunding bool                   `json:"use-exchange-level-funding"`
 	ExchangeLevelFunding    []ExchangeLevelFunding `json:"exchange-level-funding,omitempty"`
+	CapitalFlows            []CapitalFlow          `json:"capital-flows,omitempty"`
+	UseQuoteFundingBasket   bool                   `json:"use-quote-funding-basket,omitempty"`
+	QuoteFundingPriority    []currency.Code        `json:"quote-funding-priority,omitempty"`
 }
 
 // StrategySettings contains what strategy to load, along with custom settings map
@@ -62,6 +115,11 @@ type FundingSettings struct {
 type StrategySettings struct {
 	Name                         string `json:"name"`
 	SimultaneousSignalProcessing bool   `json:"use-simultaneous-signal-processing"`
+	OpposingSignalPolicy         string `json:"opposing-signal-policy,omitempty"`
+	SimultaneousSignalPriority   string `json:"simultaneous-signal-priority,omitempty"`
+	FirstCandlePolicy            string `json:"first-candle-policy,omitempty"`
+	EndOfDataPolicy              string `json:"end-of-data-policy,omitempty"`
+	RoundingMode                 string `json:"rounding-mode,omitempty"`
 
 	// If true, won't track USD values against currency pair
 	// bool language is opposite to encourage use by default
@@ -84,10 +142,43 @@ type ExchangeLevelFunding struct {
 	TransferFee  decimal.Decimal `json:"transfer-fee"`
 }
 
+// FundingRate is a futures pair's funding rate falling due at the time,
+// paid by longs and received by shorts when positive
+type FundingRate struct {
+	Time time.Time       `json:"time"`
+	Rate decimal.Decimal `json:"rate"`
+}
+
+// CapitalFlow is an external deposit, or withdrawal when the amount is
+// negative, applied to a funding item once the run reaches its time
+type CapitalFlow struct {
+	Time         time.Time       `json:"time"`
+	ExchangeName string          `json:"exchange-name"`
+	Asset        asset.Item      `json:"asset"`
+	Currency     currency.Code   `json:"currency"`
+	PairedWith   currency.Code   `json:"paired-with,omitempty"`
+	Amount       decimal.Decimal `json:"amount"`
+}
+
 // StatisticSettings adjusts ratios where
 // proper data is currently lacking
 type StatisticSettings struct {
-	RiskFreeRate decimal.Decimal `json:"risk-free-rate"`
+	RiskFreeRate                   decimal.Decimal   `json:"risk-free-rate"`
+	VolatilityRegimeWindow         int64             `json:"volatility-regime-window,omitempty"`
+	VolatilityRegimeCount          int64             `json:"volatility-regime-count,omitempty"`
+	CostBasisMethod                string            `json:"cost-basis-method,omitempty"`
+	TradingCalendar                string            `json:"trading-calendar,omitempty"`
+	RecordReturnsSeries            bool              `json:"record-returns-series,omitempty"`
+	RecordNoTradeReasons           bool              `json:"record-no-trade-reasons,omitempty"`
+	RecordMarketConditions         bool              `json:"record-market-conditions,omitempty"`
+	RollingBetaWindow              int64             `json:"rolling-beta-window,omitempty"`
+	ValueAtRiskConfidenceLevels    []decimal.Decimal `json:"value-at-risk-confidence-levels,omitempty"`
+	AutocorrelationLags            int64             `json:"autocorrelation-lags,omitempty"`
+	PerformanceFeePercent          decimal.Decimal   `json:"performance-fee-percent,omitempty"`
+	PerformanceFeeIntervalDays     int64             `json:"performance-fee-interval-days,omitempty"`
+	WashTradeWindowMinutes         int64             `json:"wash-trade-window-minutes,omitempty"`
+	WashTradePriceTolerancePercent decimal.Decimal   `json:"wash-trade-price-tolerance-percent,omitempty"`
+	CapacityParticipationPercent   decimal.Decimal   `json:"capacity-participation-percent,omitempty"`
 }
 
 // PortfolioSettings act as a global protector for strategies
@@ -139,25 +230,145 @@ type CurrencySettings struct {
 
 	MinimumSlippagePercent decimal.Decimal `json:"min-slippage-percent"`
 	MaximumSlippagePercent decimal.Decimal `json:"max-slippage-percent"`
+	SlippageCapPercent     decimal.Decimal `json:"slippage-cap-percent,omitempty"`
+	SlippageTiers          []SlippageTier  `json:"slippage-tiers,omitempty"`
+	SlippageAsymmetry      decimal.Decimal `json:"slippage-asymmetry,omitempty"`
+	// AllowSlippagePriceImprovement disables the floor which stops
+	// slippage filling at a better price than before slippage
+	AllowSlippagePriceImprovement bool `json:"allow-slippage-price-improvement,omitempty"`
+	// LiquidationSlippagePercent and LiquidationFeeRate penalise
+	// liquidation fills beyond their usual slippage and fee
+	LiquidationSlippagePercent decimal.Decimal `json:"liquidation-slippage-percent,omitempty"`
+	LiquidationFeeRate         decimal.Decimal `json:"liquidation-fee-rate,omitempty"`
+	// BorrowRatePercent is the annual rate charged against the notional of
+	// open short futures positions. Spot sells are limited to the base
+	// currency held as spot funding has no margin to borrow against, so only
+	// futures can hold a short position to accrue it
+	BorrowRatePercent decimal.Decimal `json:"borrow-rate-percent,omitempty"`
+	// FundingRates are the futures pair's historical funding rates, settled
+	// against open positions at each funding time. FundingRateCSVPath loads
+	// further rates from a csv file of unix timestamp and rate rows
+	FundingRates       []FundingRate `json:"funding-rates,omitempty"`
+	FundingRateCSVPath string        `json:"funding-rate-csv-path,omitempty"`
 
 	UsingExchangeMakerFee bool             `json:"-"`
 	MakerFee              *decimal.Decimal `json:"maker-fee-override,omitempty"`
 	UsingExchangeTakerFee bool             `json:"-"`
 	TakerFee              *decimal.Decimal `json:"taker-fee-override,omitempty"`
+	FeeTiers              []FeeTier        `json:"fee-tiers,omitempty"`
+
+	MaximumHoldingsRatio                decimal.Decimal      `json:"maximum-holdings-ratio"`
+	MaximumCapitalAllocation            decimal.Decimal      `json:"maximum-capital-allocation"`
+	MinimumHoldingPeriodMinutes         int64                `json:"minimum-holding-period-minutes,omitempty"`
+	MaxPositionAgeMinutes               int64                `json:"max-position-age-minutes,omitempty"`
+	SkipCandleVolumeFitting             bool                 `json:"skip-candle-volume-fitting"`
+	ExecutionInterval                   kline.Interval       `json:"execution-interval,omitempty"`
+	ExecutionCSVPath                    string               `json:"execution-csv-path,omitempty"`
+	VolumeFitWindow                     int                  `json:"volume-fit-window,omitempty"`
+	AllowPartialFills                   bool                 `json:"allow-partial-fills,omitempty"`
+	VolumeExceedBehaviour               string               `json:"volume-exceed-behaviour,omitempty"`
+	NetOfCostTargets                    bool                 `json:"net-of-cost-targets,omitempty"`
+	RecordFundingLedger                 bool                 `json:"record-funding-ledger,omitempty"`
+	RoundQuoteFunding                   bool                 `json:"round-quote-funding,omitempty"`
+	QuotePrecision                      int32                `json:"quote-precision,omitempty"`
+	TradingSession                      *TradingSession      `json:"trading-session,omitempty"`
+	LatencyDistribution                 *LatencyDistribution `json:"latency-distribution,omitempty"`
+	ExecutionDelayCandles               int64                `json:"execution-delay-candles,omitempty"`
+	DrawdownStopOut                     *DrawdownStopOut     `json:"drawdown-stop-out,omitempty"`
+	LosingStreakBreaker                 *LosingStreakBreaker `json:"losing-streak-breaker,omitempty"`
+	OrderbookImbalanceDepth             int                  `json:"orderbook-imbalance-depth,omitempty"`
+	DowntimeGapCandles                  int64                `json:"downtime-gap-candles,omitempty"`
+	CrossedOrderbookBehaviour           string               `json:"crossed-orderbook-behaviour,omitempty"`
+	MaximumOrderbookAgeSeconds          int64                `json:"maximum-orderbook-age-seconds,omitempty"`
+	DepthExhaustionBehaviour            string               `json:"depth-exhaustion-behaviour,omitempty"`
+	SubmissionRetries                   int                  `json:"submission-retries,omitempty"`
+	SubmissionRetryBackoffMilliseconds  int64                `json:"submission-retry-backoff-milliseconds,omitempty"`
+	FeeShortfallBehaviour               string               `json:"fee-shortfall-behaviour,omitempty"`
+	SlippageOverfillBehaviour           string               `json:"slippage-overfill-behaviour,omitempty"`
+	ExtremeVolatilityThreshold          decimal.Decimal      `json:"extreme-volatility-threshold,omitempty"`
+	ExtremeVolatilityBehaviour          string               `json:"extreme-volatility-behaviour,omitempty"`
+	ExtremeVolatilitySlippageMultiplier decimal.Decimal      `json:"extreme-volatility-slippage-multiplier,omitempty"`
+	RebatePolicy                        string               `json:"rebate-policy,omitempty"`
+	FillTimeAssignment                  string               `json:"fill-time-assignment,omitempty"`
+	SignalToFillConvention              string               `json:"signal-to-fill-convention,omitempty"`
+	FlipPolicy                          string               `json:"flip-policy,omitempty"`
+	PriceTickSize                       decimal.Decimal      `json:"price-tick-size,omitempty"`
 
-	MaximumHoldingsRatio    decimal.Decimal `json:"maximum-holdings-ratio"`
-	SkipCandleVolumeFitting bool            `json:"skip-candle-volume-fitting"`
+	TagAllocations map[string]decimal.Decimal `json:"tag-allocations,omitempty"`
+	Accounts       map[string]decimal.Decimal `json:"accounts,omitempty"`
 
 	CanUseExchangeLimits          bool `json:"use-exchange-order-limits"`
 	ShowExchangeOrderLimitWarning bool `json:"-"`
 	UseExchangePNLCalculation     bool `json:"use-exchange-pnl-calculation"`
 }
 
+// TradingSession restricts order execution to an instrument's trading hours.
+// Open and close times use the "15:04" format in the IANA timezone, which
+// defaults to UTC. Empty days trades every day
+type TradingSession struct {
+	Days                []time.Weekday `json:"days,omitempty"`
+	OpenTime            string         `json:"open-time"`
+	CloseTime           string         `json:"close-time"`
+	Timezone            string         `json:"timezone,omitempty"`
+	DeferOffHoursOrders bool           `json:"defer-off-hours-orders"`
+}
+
+// SlippageTier is the slippage in basis points applied to orders whose
+// notional is at least the minimum notional
+type SlippageTier struct {
+	MinimumNotional decimal.Decimal `json:"minimum-notional"`
+	BasisPoints     decimal.Decimal `json:"basis-points"`
+}
+
+// FeeTier is the maker and taker fee in basis points applied once the
+// exchange account's cumulative traded volume reaches the volume threshold
+type FeeTier struct {
+	VolumeThreshold  decimal.Decimal `json:"volume-threshold"`
+	MakerBasisPoints decimal.Decimal `json:"maker-basis-points"`
+	TakerBasisPoints decimal.Decimal `json:"taker-basis-points"`
+}
+
+// LatencyDistribution samples a variable delay in milliseconds for each order
+// before it fills. The weighted histogram is used when set, otherwise a normal
+// distribution of the mean and standard deviation. The seed makes sampled
+// latencies reproducible between runs
+type LatencyDistribution struct {
+	Histogram                     []LatencyBucket `json:"histogram,omitempty"`
+	MeanMilliseconds              int64           `json:"mean-milliseconds,omitempty"`
+	StandardDeviationMilliseconds int64           `json:"standard-deviation-milliseconds,omitempty"`
+	Seed                          int64           `json:"seed"`
+}
+
+// LatencyBucket is a recorded latency and its relative frequency
+type LatencyBucket struct {
+	LatencyMilliseconds int64 `json:"latency-milliseconds"`
+	Weight              int64 `json:"weight"`
+}
+
+// DrawdownStopOut forcibly closes positions once their drawdown reaches the
+// maximum drawdown percent. The scope is either "position", measuring the
+// close price from its most favourable level since the position opened, or
+// "portfolio", measuring the total value of all holdings from their peak
+type DrawdownStopOut struct {
+	MaximumDrawdownPercent decimal.Decimal `json:"maximum-drawdown-percent"`
+	Scope                  string          `json:"scope"`
+}
+
+// LosingStreakBreaker halts new entries after consecutive losing round trip
+// trades. Entries resume after the cooldown, or never when it is zero
+type LosingStreakBreaker struct {
+	ConsecutiveLosses int64 `json:"consecutive-losses"`
+	CooldownMinutes   int64 `json:"cooldown-minutes"`
+}
+
 // SpotDetails contains funding information that cannot be shared with another
 // pair during the backtesting run. Use exchange level funding to share funds
 type SpotDetails struct {
 	InitialBaseFunds  *decimal.Decimal `json:"initial-base-funds,omitempty"`
 	InitialQuoteFunds *decimal.Decimal `json:"initial-quote-funds,omitempty"`
+	// InitialBaseEntryPrice is the price the initial base funds were bought
+	// at, starting the run with an open position measured from that price
+	InitialBaseEntryPrice *decimal.Decimal `json:"initial-base-entry-price,omitempty"`
 }
 
 // FuturesDetails contains data relevant to futures currency pairs
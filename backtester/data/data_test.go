@@ -235,6 +235,10 @@ func (f fakeDataHandler) GetOpenPrice() decimal.Decimal {
 	return decimal.Zero
 }
 
+func (f fakeDataHandler) GetVolume() decimal.Decimal {
+	return decimal.Zero
+}
+
 func (f fakeDataHandler) GetUnderlyingPair() currency.Pair {
 	return f.Pair()
 }
@@ -1,6 +1,7 @@
 package engine
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"sync"
@@ -96,11 +97,16 @@ func (bt *BackTest) ExecuteStrategy(waitForOfflineCompletion bool) error {
 			}
 		} else {
 			bt.Run()
+			endOfDataErr := bt.processEndOfData()
 			close(bt.shutdown)
 			bt.m.Lock()
 			bt.MetaData.Closed = true
 			bt.MetaData.DateEnded = time.Now()
 			bt.m.Unlock()
+			if endOfDataErr != nil {
+				log.Error(log.Global, endOfDataErr)
+				return
+			}
 			err := bt.Statistic.CalculateAllResults()
 			if err != nil {
 				log.Error(log.Global, err)
@@ -126,28 +132,27 @@ func (bt *BackTest) Run() {
 dataLoadingIssue:
 	for ev := bt.EventQueue.NextEvent(); ; ev = bt.EventQueue.NextEvent() {
 		if ev == nil {
-			dataHandlerMap := bt.Datas.GetAllData()
+			err := bt.saveCheckpointAtInterval()
+			if err != nil {
+				log.Error(common.Backtester, err)
+			}
 			var hasProcessedData bool
-			for exchangeName, exchangeMap := range dataHandlerMap {
-				for assetItem, assetMap := range exchangeMap {
-					for currencyPair, dataHandler := range assetMap {
-						d := dataHandler.Next()
-						if d == nil {
-							if !bt.hasHandledEvent {
-								log.Errorf(common.Backtester, "Unable to perform `Next` for %v %v %v", exchangeName, assetItem, currencyPair)
-							}
-							break dataLoadingIssue
-						}
-						if bt.Strategy.UsingSimultaneousProcessing() && hasProcessedData {
-							// only append one event, as simultaneous processing
-							// will retrieve all relevant events to process under
-							// processSimultaneousDataEvents()
-							continue
-						}
-						bt.EventQueue.AppendEvent(d)
-						hasProcessedData = true
+			for _, h := range sortedDataHandlers(bt.Datas.GetAllData()) {
+				d := h.handler.Next()
+				if d == nil {
+					if !bt.hasHandledEvent {
+						log.Errorf(common.Backtester, "Unable to perform `Next` for %v %v %v", h.exchange, h.asset, h.pair)
 					}
+					break dataLoadingIssue
+				}
+				if bt.Strategy.UsingSimultaneousProcessing() && hasProcessedData {
+					// only append one event, as simultaneous processing
+					// will retrieve all relevant events to process under
+					// processSimultaneousDataEvents()
+					continue
 				}
+				bt.EventQueue.AppendEvent(d)
+				hasProcessedData = true
 			}
 		} else {
 			err := bt.handleEvent(ev)
@@ -183,13 +188,21 @@ func (bt *BackTest) handleEvent(ev common.EventHandler) error {
 
 	switch eType := ev.(type) {
 	case common.DataEventHandler:
+		err = bt.Funding.ApplyCapitalFlows(eType.GetTime())
+		if err != nil {
+			log.Errorf(common.Backtester, "ApplyCapitalFlows %v", err)
+		}
 		if bt.Strategy.UsingSimultaneousProcessing() {
 			err = bt.processSimultaneousDataEvents()
 		} else {
 			err = bt.processSingleDataEvent(eType, funds.FundReleaser())
 		}
 	case signal.Event:
-		err = bt.processSignalEvent(eType, funds.FundReserver())
+		if re := eType.GetRebalanceEvent(); re != nil && !re.IsNil() {
+			err = bt.processRebalance(eType, funds)
+		} else {
+			err = bt.processSignalEvent(eType, funds.FundReserver())
+		}
 	case order.Event:
 		err = bt.processOrderEvent(eType, funds.FundReleaser())
 	case fill.Event:
@@ -213,6 +226,14 @@ func (bt *BackTest) processSingleDataEvent(ev common.DataEventHandler, funds fun
 	if err != nil {
 		return err
 	}
+	err = bt.processDeferredOrders(ev)
+	if err != nil {
+		return err
+	}
+	err = bt.Exchange.AttachOrderbookImbalance(ev)
+	if err != nil {
+		log.Errorf(common.Backtester, "AttachOrderbookImbalance %v", err)
+	}
 	d, err := bt.Datas.GetDataForCurrency(ev)
 	if err != nil {
 		return err
@@ -226,6 +247,7 @@ func (bt *BackTest) processSingleDataEvent(ev common.DataEventHandler, funds fun
 		log.Errorf(common.Backtester, "OnSignal %v", err)
 		return nil
 	}
+	applyFirstCandlePolicy(s, d.Latest(), bt.FirstCandlePolicy)
 	err = bt.Statistic.SetEventForOffset(s)
 	if err != nil {
 		log.Errorf(common.Backtester, "SetEventForOffset %v", err)
@@ -260,10 +282,19 @@ func (bt *BackTest) processSimultaneousDataEvents() error {
 						log.Error(common.Backtester, err)
 					}
 				}
+				err = bt.processDeferredOrders(latestData)
+				if err != nil {
+					return err
+				}
+				err = bt.Exchange.AttachOrderbookImbalance(latestData)
+				if err != nil {
+					log.Errorf(common.Backtester, "AttachOrderbookImbalance %v", err)
+				}
 				dataEvents = append(dataEvents, dataHandler)
 			}
 		}
 	}
+	sortDataHandlers(dataEvents)
 	signals, err := bt.Strategy.OnSimultaneousSignals(dataEvents, bt.Funding, bt.Portfolio)
 	if err != nil {
 		if errors.Is(err, base.ErrTooMuchBadData) {
@@ -273,7 +304,16 @@ func (bt *BackTest) processSimultaneousDataEvents() error {
 		log.Errorf(common.Backtester, "OnSimultaneousSignals %v", err)
 		return nil
 	}
+	resolveOpposingSignals(signals, bt.OpposingSignalPolicy)
+	prioritiseSignals(signals, bt.SignalPriority)
 	for i := range signals {
+		var d data.Handler
+		d, err = bt.Datas.GetDataForCurrency(signals[i])
+		if err != nil {
+			log.Errorf(common.Backtester, "GetDataForCurrency %v %v %v %v", signals[i].GetExchange(), signals[i].GetAssetType(), signals[i].Pair(), err)
+		} else {
+			applyFirstCandlePolicy(signals[i], d.Latest(), bt.FirstCandlePolicy)
+		}
 		err = bt.Statistic.SetEventForOffset(signals[i])
 		if err != nil {
 			log.Errorf(common.Backtester, "SetEventForOffset %v %v %v %v", signals[i].GetExchange(), signals[i].GetAssetType(), signals[i].Pair(), err)
@@ -283,6 +323,23 @@ func (bt *BackTest) processSimultaneousDataEvents() error {
 	return nil
 }
 
+// processDeferredOrders queues any orders deferred by the exchange handler
+// which can now be executed against the data event
+func (bt *BackTest) processDeferredOrders(ev common.DataEventHandler) error {
+	orders, err := bt.Exchange.ReleaseDeferredOrders(ev)
+	if err != nil {
+		return err
+	}
+	for i := range orders {
+		err = bt.Statistic.SetEventForOffset(orders[i])
+		if err != nil {
+			log.Errorf(common.Backtester, "SetEventForOffset %v %v %v %v", orders[i].GetExchange(), orders[i].GetAssetType(), orders[i].Pair(), err)
+		}
+		bt.EventQueue.AppendEvent(orders[i])
+	}
+	return nil
+}
+
 // updateStatsForDataEvent makes various systems aware of price movements from
 // data events
 func (bt *BackTest) updateStatsForDataEvent(ev common.DataEventHandler, funds funding.IFundReleaser) error {
@@ -305,6 +362,17 @@ func (bt *BackTest) updateStatsForDataEvent(ev common.DataEventHandler, funds fu
 	if err != nil {
 		log.Errorf(common.Backtester, "UpdateHoldings %v", err)
 	}
+	exited, err := bt.processMaxPositionAge(ev)
+	if err != nil {
+		log.Errorf(common.Backtester, "processMaxPositionAge %v", err)
+	}
+	if !exited {
+		// a position already being closed by its age cannot also be stopped out
+		err = bt.processDrawdownStopOut(ev)
+		if err != nil {
+			log.Errorf(common.Backtester, "processDrawdownStopOut %v", err)
+		}
+	}
 
 	if ev.GetAssetType().IsFutures() {
 		var cr funding.ICollateralReleaser
@@ -323,6 +391,29 @@ func (bt *BackTest) updateStatsForDataEvent(ev common.DataEventHandler, funds fu
 				return fmt.Errorf("UpdatePNL %v", err)
 			}
 		}
+		var cs exchange.Settings
+		cs, err = bt.Exchange.GetCurrencySettings(ev.GetExchange(), ev.GetAssetType(), ev.Pair())
+		if err != nil {
+			return err
+		}
+		// a cost the collateral cannot cover liquidates the position
+		var liquidationErr error
+		_, err = bt.Portfolio.AccrueBorrowCost(ev, cr, cs.BorrowRatePercent)
+		if err != nil {
+			if !errors.Is(err, gctorder.ErrPositionLiquidated) {
+				return fmt.Errorf("AccrueBorrowCost %v", err)
+			}
+			liquidationErr = err
+		}
+		if cs.FundingRate != nil && liquidationErr == nil {
+			err = bt.applyFundingRate(ev, cr)
+			if err != nil {
+				if !errors.Is(err, gctorder.ErrPositionLiquidated) {
+					return fmt.Errorf("applyFundingRate %v", err)
+				}
+				liquidationErr = err
+			}
+		}
 		var pnl *portfolio.PNLSummary
 		pnl, err = bt.Portfolio.GetLatestPNLForEvent(ev)
 		if err != nil {
@@ -332,7 +423,10 @@ func (bt *BackTest) updateStatsForDataEvent(ev common.DataEventHandler, funds fu
 		if pnl.Result.IsLiquidated {
 			return nil
 		}
-		err = bt.Portfolio.CheckLiquidationStatus(ev, cr, pnl)
+		err = liquidationErr
+		if err == nil {
+			err = bt.Portfolio.CheckLiquidationStatus(ev, cr, pnl)
+		}
 		if err != nil {
 			if errors.Is(err, gctorder.ErrPositionLiquidated) {
 				liquidErr := bt.triggerLiquidationsForExchange(ev, pnl)
@@ -387,6 +481,93 @@ func (bt *BackTest) triggerLiquidationsForExchange(ev common.DataEventHandler, p
 	return bt.Statistic.AddPNLForTime(pnl)
 }
 
+// processDrawdownStopOut raises closing orders for positions which have
+// breached the maximum drawdown of the event's currency settings
+func (bt *BackTest) processDrawdownStopOut(ev common.DataEventHandler) error {
+	if ev == nil {
+		return common.ErrNilEvent
+	}
+	cs, err := bt.Exchange.GetCurrencySettings(ev.GetExchange(), ev.GetAssetType(), ev.Pair())
+	if err != nil {
+		return err
+	}
+	if cs.DrawdownStopOut == nil {
+		return nil
+	}
+	orders, err := bt.Portfolio.CheckDrawdownStopOut(ev, cs.DrawdownStopOut)
+	if err != nil {
+		return err
+	}
+	return bt.queueClosingOrders(orders)
+}
+
+// applyFundingRate settles the funding rate payments falling due within the
+// event's candle against its latest futures position, recording the net
+// payment separately from the position's PNL
+func (bt *BackTest) applyFundingRate(ev common.DataEventHandler, cr funding.ICollateralReleaser) error {
+	positions, err := bt.Portfolio.GetPositions(ev)
+	if err != nil {
+		return err
+	}
+	if len(positions) == 0 {
+		return nil
+	}
+	payment, err := bt.Exchange.ApplyFundingRate(ev, cr, &positions[len(positions)-1])
+	if err != nil {
+		return err
+	}
+	return bt.Portfolio.RecordFundingPayment(ev, payment)
+}
+
+// processMaxPositionAge raises a closing order for the event's position when
+// it has been held for longer than the maximum position age of its currency
+// settings. Returns whether a closing order was raised
+func (bt *BackTest) processMaxPositionAge(ev common.DataEventHandler) (bool, error) {
+	if ev == nil {
+		return false, common.ErrNilEvent
+	}
+	o, err := bt.Exchange.CheckPositionAge(ev)
+	if err != nil || o == nil {
+		return false, err
+	}
+	return true, bt.queueClosingOrders([]*order.Order{o})
+}
+
+// queueClosingOrders reserves the funds of forced closing orders at the
+// latest price of their currency and appends them to the event queue
+func (bt *BackTest) queueClosingOrders(orders []*order.Order) error {
+	var err error
+	for i := range orders {
+		var datas data.Handler
+		datas, err = bt.Datas.GetDataForCurrency(orders[i])
+		if err != nil {
+			return err
+		}
+		latest := datas.Latest()
+		orders[i].ClosePrice = latest.GetClosePrice()
+		err = bt.Statistic.SetupEventForTime(latest)
+		if err != nil && !errors.Is(err, statistics.ErrAlreadyProcessed) {
+			return err
+		}
+		var funds funding.IFundingPair
+		funds, err = bt.Funding.GetFundingForEvent(orders[i])
+		if err != nil {
+			return err
+		}
+		err = funds.FundReserver().Reserve(orders[i].AllocatedFunds, gctorder.ClosePosition)
+		if err != nil {
+			log.Errorf(common.Backtester, "Reserve %v %v %v %v", orders[i].GetExchange(), orders[i].GetAssetType(), orders[i].Pair(), err)
+			continue
+		}
+		err = bt.Statistic.SetEventForOffset(orders[i])
+		if err != nil {
+			log.Errorf(common.Backtester, "SetEventForOffset %v %v %v %v", orders[i].GetExchange(), orders[i].GetAssetType(), orders[i].Pair(), err)
+		}
+		bt.EventQueue.AppendEvent(orders[i])
+	}
+	return nil
+}
+
 // processSignalEvent receives an event from the strategy for processing under the portfolio
 func (bt *BackTest) processSignalEvent(ev signal.Event, funds funding.IFundReserver) error {
 	if ev == nil {
@@ -415,6 +596,24 @@ func (bt *BackTest) processSignalEvent(ev signal.Event, funds funding.IFundReser
 	return nil
 }
 
+// orderContext returns a context which is cancelled once the backtester is
+// shut down, so that real order submissions and their retry backoff are
+// abandoned rather than holding up shutdown
+func (bt *BackTest) orderContext() (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(context.Background())
+	if bt.shutdown == nil {
+		return ctx, cancel
+	}
+	go func() {
+		select {
+		case <-bt.shutdown:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+	return ctx, cancel
+}
+
 func (bt *BackTest) processOrderEvent(ev order.Event, funds funding.IFundReleaser) error {
 	if ev == nil {
 		return common.ErrNilEvent
@@ -426,7 +625,9 @@ func (bt *BackTest) processOrderEvent(ev order.Event, funds funding.IFundRelease
 	if err != nil {
 		return err
 	}
-	f, err := bt.Exchange.ExecuteOrder(ev, d, bt.orderManager, funds)
+	ctx, cancel := bt.orderContext()
+	defer cancel()
+	f, err := bt.Exchange.ExecuteOrder(ctx, ev, d, bt.orderManager, funds)
 	if err != nil {
 		if f == nil {
 			log.Errorf(common.Backtester, "ExecuteOrder fill event should always be returned, please fix, %v", err)
@@ -388,6 +388,7 @@ func TestUpdateStatsForDataEvent(t *testing.T) {
 		Statistic: &statistics.Statistic{},
 		Funding:   &funding.FundManager{},
 		Portfolio: pt,
+		Exchange:  &exchange.Exchange{},
 	}
 	expectedError := common.ErrNilEvent
 	err := bt.updateStatsForDataEvent(nil, nil)
@@ -462,10 +463,69 @@ func TestUpdateStatsForDataEvent(t *testing.T) {
 		t.Errorf("received '%v' expected '%v'", err, expectedError)
 	}
 
+	bt.Exchange = &exchange.Exchange{
+		CurrencySettings: []exchange.Settings{
+			{
+				Exchange:          exch,
+				Pair:              cp,
+				Asset:             a,
+				BorrowRatePercent: decimal.NewFromInt(365),
+				FundingRate: &gctorder.FundingRates{
+					FundingRates: []gctorder.FundingRate{
+						{Time: ev.Time.Add(time.Hour), Rate: decimal.NewFromFloat(0.03)},
+					},
+				},
+			},
+		},
+	}
+	ev.Interval = gctkline.OneDay
+	ev.Close = decimal.NewFromInt(100)
+	available := pair.AvailableFunds()
+	err = bt.updateStatsForDataEvent(ev, pair)
+	if !errors.Is(err, expectedError) {
+		t.Errorf("received '%v' expected '%v'", err, expectedError)
+	}
+	// the short of 1 at 100 is charged a day of its 365% annual borrow rate
+	// and receives the 3% funding rate falling due within the candle
+	if !pair.AvailableFunds().Equal(available.Add(decimal.NewFromInt(2))) {
+		t.Errorf("received '%v' expected '%v'", pair.AvailableFunds(), available.Add(decimal.NewFromInt(2)))
+	}
+	pnl, err := pt.GetLatestPNLForEvent(ev)
+	if !errors.Is(err, expectedError) {
+		t.Fatalf("received '%v' expected '%v'", err, expectedError)
+	}
+	if !pnl.GetFundingCost().Equal(decimal.NewFromInt(-3)) {
+		t.Errorf("received '%v' expected '%v'", pnl.GetFundingCost(), decimal.NewFromInt(-3))
+	}
+
+	// a borrow cost exceeding the collateral liquidates rather than charging it
+	bt.Exchange.(*exchange.Exchange).CurrencySettings[0].BorrowRatePercent = decimal.NewFromInt(3650000)
+	bt.Exchange.(*exchange.Exchange).CurrencySettings[0].FundingRate = nil
+	ev.Time = ev.Time.Add(gctkline.OneDay.Duration())
+	ev.Offset++
+	d := data.Base{}
+	d.SetStream([]common.DataEventHandler{ev})
+	d.Next()
+	bt.Datas = &data.HandlerPerCurrency{}
+	bt.Datas.SetDataForCurrency(testExchange, a, cp, &kline.DataFromKline{
+		Item:        gctkline.Item{Exchange: testExchange, Asset: a, Pair: cp},
+		Base:        d,
+		RangeHolder: &gctkline.IntervalRangeHolder{},
+	})
+	bt.EventQueue = &eventholder.Holder{}
+	available = pair.AvailableFunds()
+	expectedError = gctorder.ErrPositionLiquidated
 	err = bt.updateStatsForDataEvent(ev, pair)
 	if !errors.Is(err, expectedError) {
 		t.Errorf("received '%v' expected '%v'", err, expectedError)
 	}
+	if !pair.AvailableFunds().Equal(available) {
+		t.Errorf("received '%v' expected '%v'", pair.AvailableFunds(), available)
+	}
+	liquidation, ok := bt.EventQueue.NextEvent().(order.Event)
+	if !ok || !liquidation.IsLiquidating() {
+		t.Error("expected a liquidation order")
+	}
 }
 
 func TestProcessSignalEvent(t *testing.T) {
@@ -1210,3 +1270,89 @@ func TestExecuteStrategy(t *testing.T) {
 		t.Errorf("received '%v' expected '%v'", err, gctcommon.ErrNilPointer)
 	}
 }
+
+func TestProcessDrawdownStopOut(t *testing.T) {
+	t.Parallel()
+	bt := BackTest{Exchange: &exchange.Exchange{}}
+	err := bt.processDrawdownStopOut(nil)
+	if !errors.Is(err, common.ErrNilEvent) {
+		t.Errorf("received '%v' expected '%v'", err, common.ErrNilEvent)
+	}
+
+	cp := currency.NewPair(currency.BTC, currency.USDT)
+	ev := &evkline.Kline{
+		Base: &event.Base{
+			Exchange:     testExchange,
+			AssetType:    asset.Spot,
+			CurrencyPair: cp,
+		},
+	}
+	exch := &ftx.FTX{}
+	exch.Name = testExchange
+	bt.Exchange.SetExchangeAssetCurrencySettings(asset.Spot, cp, &exchange.Settings{
+		Exchange: exch,
+		Asset:    asset.Spot,
+		Pair:     cp,
+	})
+	err = bt.processDrawdownStopOut(ev)
+	if !errors.Is(err, nil) {
+		t.Errorf("received '%v' expected '%v'", err, nil)
+	}
+}
+
+func TestProcessMaxPositionAge(t *testing.T) {
+	t.Parallel()
+	bt := BackTest{Exchange: &exchange.Exchange{}}
+	_, err := bt.processMaxPositionAge(nil)
+	if !errors.Is(err, common.ErrNilEvent) {
+		t.Errorf("received '%v' expected '%v'", err, common.ErrNilEvent)
+	}
+
+	cp := currency.NewPair(currency.BTC, currency.USDT)
+	ev := &evkline.Kline{
+		Base: &event.Base{
+			Exchange:     testExchange,
+			AssetType:    asset.Spot,
+			CurrencyPair: cp,
+		},
+	}
+	exch := &ftx.FTX{}
+	exch.Name = testExchange
+	bt.Exchange.SetExchangeAssetCurrencySettings(asset.Spot, cp, &exchange.Settings{
+		Exchange:       exch,
+		Asset:          asset.Spot,
+		Pair:           cp,
+		MaxPositionAge: time.Hour,
+	})
+	exited, err := bt.processMaxPositionAge(ev)
+	if !errors.Is(err, nil) {
+		t.Errorf("received '%v' expected '%v'", err, nil)
+	}
+	if exited {
+		t.Error("expected no exit without an open position")
+	}
+}
+
+func TestOrderContext(t *testing.T) {
+	t.Parallel()
+	bt := &BackTest{}
+	ctx, cancel := bt.orderContext()
+	if ctx.Err() != nil {
+		t.Errorf("received '%v' expected '%v'", ctx.Err(), nil)
+	}
+	cancel()
+
+	bt.shutdown = make(chan struct{})
+	ctx, cancel = bt.orderContext()
+	defer cancel()
+	if ctx.Err() != nil {
+		t.Errorf("received '%v' expected '%v'", ctx.Err(), nil)
+	}
+	// shutting down abandons any order submission still waiting to retry
+	close(bt.shutdown)
+	select {
+	case <-ctx.Done():
+	case <-time.After(time.Second):
+		t.Error("expected the order context to be cancelled on shutdown")
+	}
+}
@@ -14,7 +14,9 @@ import (
 	"github.com/thrasher-corp/gocryptotrader/backtester/eventhandlers/strategies"
 	"github.com/thrasher-corp/gocryptotrader/backtester/funding"
 	"github.com/thrasher-corp/gocryptotrader/backtester/report"
+	"github.com/thrasher-corp/gocryptotrader/currency"
 	"github.com/thrasher-corp/gocryptotrader/engine"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
 )
 
 var (
@@ -27,26 +29,57 @@ var (
 	errNilData                     = errors.New("nil data received")
 	errNilExchange                 = errors.New("nil exchange received")
 	errLiveUSDTrackingNotSupported = errors.New("USD tracking not supported for live data")
+	errLiveExecutionData           = errors.New("execution data not supported for live data")
 	errNotSetup                    = errors.New("backtesting run not setup")
+	errInvalidRebalance            = errors.New("invalid rebalance")
+	errCheckpointUnsupported       = errors.New("checkpoints are unsupported")
+	errCheckpointMismatch          = errors.New("checkpoint does not match run")
 )
 
 // BackTest is the main holder of all backtesting functionality
 type BackTest struct {
-	m               sync.Mutex
-	hasHandledEvent bool
-	MetaData        RunMetaData
-	shutdown        chan struct{}
-	Datas           data.Holder
-	Strategy        strategies.Handler
-	Portfolio       portfolio.Handler
-	Exchange        exchange.ExecutionHandler
-	Statistic       statistics.Handler
-	EventQueue      eventholder.EventHolder
-	Reports         report.Handler
-	Funding         funding.IFundingManager
-	exchangeManager *engine.ExchangeManager
-	orderManager    *engine.OrderManager
-	databaseManager *engine.DatabaseConnectionManager
+	m                    sync.Mutex
+	hasHandledEvent      bool
+	MetaData             RunMetaData
+	shutdown             chan struct{}
+	Datas                data.Holder
+	Strategy             strategies.Handler
+	Portfolio            portfolio.Handler
+	Exchange             exchange.ExecutionHandler
+	Statistic            statistics.Handler
+	EventQueue           eventholder.EventHolder
+	Reports              report.Handler
+	Funding              funding.IFundingManager
+	OpposingSignalPolicy string
+	SignalPriority       string
+	FirstCandlePolicy    string
+	EndOfDataPolicy      string
+	CheckpointInterval   int64
+	CheckpointPath       string
+	checkpointOffset     int64
+	exchangeManager      *engine.ExchangeManager
+	orderManager         *engine.OrderManager
+	databaseManager      *engine.DatabaseConnectionManager
+}
+
+// checkpoint is the gob encoded state of a run once all events
+// at an offset have been handled, allowing the run to be resumed
+type checkpoint struct {
+	Offset          int64
+	DataOffsets     []checkpointDataOffset
+	HasHandledEvent bool
+	Funding         *funding.State
+	Portfolio       *portfolio.State
+	Exchange        *exchange.State
+	Statistic       *statistics.State
+}
+
+// checkpointDataOffset is the offset a currency's data has been streamed to
+type checkpointDataOffset struct {
+	Exchange string
+	Asset    asset.Item
+	Pair     currency.Pair
+	Offset   int64
 }
 
 // RunSummary holds details of a BackTest
@@ -0,0 +1,167 @@
+package engine
+
+import (
+	"bytes"
+	"encoding/gob"
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/thrasher-corp/gocryptotrader/backtester/common"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventhandlers/portfolio"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/fill"
+	evkline "github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/kline"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/order"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/signal"
+	"github.com/thrasher-corp/gocryptotrader/common/file"
+	"github.com/thrasher-corp/gocryptotrader/log"
+)
+
+var registerCheckpointTypes sync.Once
+
+// registerEventTypes registers the event types held by interfaces in the
+// statistics and deferred orders so that they can be gob encoded
+func registerEventTypes() {
+	registerCheckpointTypes.Do(func() {
+		gob.Register(&evkline.Kline{})
+		gob.Register(&signal.Signal{})
+		gob.Register(&order.Order{})
+		gob.Register(&fill.Fill{})
+		gob.Register(&portfolio.PNLSummary{})
+	})
+}
+
+// SaveCheckpoint writes the state of the run to the path so that it can be
+// resumed by LoadCheckpoint. It saves the funding, portfolio holdings and
+// positions, exchange tracking, statistics and the offset each currency's data
+// has been streamed to. It must only be called once all events at the current
+// offset have been handled, which Run does when CheckpointInterval is set.
+// Live data and real orders cannot be checkpointed as the exchange's state
+// cannot be restored
+func (bt *BackTest) SaveCheckpoint(path string) error {
+	if bt.MetaData.LiveTesting || bt.MetaData.RealOrders {
+		return fmt.Errorf("%w for live data or real orders", errCheckpointUnsupported)
+	}
+	cp := checkpoint{
+		Offset:          bt.currentOffset(),
+		HasHandledEvent: bt.hasHandledEvent,
+		Funding:         bt.Funding.SaveState(),
+		Exchange:        bt.Exchange.SaveState(),
+		Statistic:       bt.Statistic.SaveState(),
+	}
+	var err error
+	cp.Portfolio, err = bt.Portfolio.SaveState()
+	if err != nil {
+		return err
+	}
+	for _, h := range sortedDataHandlers(bt.Datas.GetAllData()) {
+		cp.DataOffsets = append(cp.DataOffsets, checkpointDataOffset{
+			Exchange: h.exchange,
+			Asset:    h.asset,
+			Pair:     h.pair,
+			Offset:   int64(h.handler.Offset()),
+		})
+	}
+	registerEventTypes()
+	var buf bytes.Buffer
+	err = gob.NewEncoder(&buf).Encode(cp)
+	if err != nil {
+		return err
+	}
+	err = file.Write(path, buf.Bytes())
+	if err != nil {
+		return err
+	}
+	bt.checkpointOffset = cp.Offset
+	log.Infof(common.Backtester, "Saved checkpoint at offset %v to '%v'", cp.Offset, path)
+	return nil
+}
+
+// LoadCheckpoint restores the state of the run saved by SaveCheckpoint so that
+// Run resumes from the offset the checkpoint was saved at. The run must be set
+// up from the same config as the run which saved the checkpoint and must not
+// have been run. Results match those of an uninterrupted run, other than
+// order IDs which differ between runs regardless
+func (bt *BackTest) LoadCheckpoint(path string) error {
+	if bt.MetaData.LiveTesting || bt.MetaData.RealOrders {
+		return fmt.Errorf("%w for live data or real orders", errCheckpointUnsupported)
+	}
+	d, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	registerEventTypes()
+	var cp checkpoint
+	err = gob.NewDecoder(bytes.NewReader(d)).Decode(&cp)
+	if err != nil {
+		return err
+	}
+	handlers := sortedDataHandlers(bt.Datas.GetAllData())
+	if len(handlers) != len(cp.DataOffsets) {
+		return fmt.Errorf("%w checkpoint has %v currencies, run has %v", errCheckpointMismatch, len(cp.DataOffsets), len(handlers))
+	}
+	for i := range handlers {
+		if handlers[i].exchange != cp.DataOffsets[i].Exchange ||
+			handlers[i].asset != cp.DataOffsets[i].Asset ||
+			!handlers[i].pair.Equal(cp.DataOffsets[i].Pair) {
+			return fmt.Errorf("%w checkpoint currency %v %v %v, run currency %v %v %v",
+				errCheckpointMismatch,
+				cp.DataOffsets[i].Exchange, cp.DataOffsets[i].Asset, cp.DataOffsets[i].Pair,
+				handlers[i].exchange, handlers[i].asset, handlers[i].pair)
+		}
+		if int64(handlers[i].handler.Offset()) > cp.DataOffsets[i].Offset {
+			return fmt.Errorf("%w %v %v %v data already streamed past checkpoint offset %v",
+				errCheckpointMismatch, handlers[i].exchange, handlers[i].asset, handlers[i].pair, cp.DataOffsets[i].Offset)
+		}
+	}
+	err = bt.Funding.RestoreState(cp.Funding)
+	if err != nil {
+		return err
+	}
+	err = bt.Portfolio.RestoreState(cp.Portfolio)
+	if err != nil {
+		return err
+	}
+	err = bt.Exchange.RestoreState(cp.Exchange)
+	if err != nil {
+		return err
+	}
+	err = bt.Statistic.RestoreState(cp.Statistic)
+	if err != nil {
+		return err
+	}
+	for i := range handlers {
+		for int64(handlers[i].handler.Offset()) < cp.DataOffsets[i].Offset {
+			if handlers[i].handler.Next() == nil {
+				return fmt.Errorf("%w %v %v %v data ends before checkpoint offset %v",
+					errCheckpointMismatch, handlers[i].exchange, handlers[i].asset, handlers[i].pair, cp.DataOffsets[i].Offset)
+			}
+		}
+	}
+	bt.hasHandledEvent = cp.HasHandledEvent
+	bt.checkpointOffset = cp.Offset
+	log.Infof(common.Backtester, "Loaded checkpoint at offset %v from '%v'", cp.Offset, path)
+	return nil
+}
+
+// saveCheckpointAtInterval saves a checkpoint when the run has completed
+// a multiple of the checkpoint interval's offsets not yet saved
+func (bt *BackTest) saveCheckpointAtInterval() error {
+	if bt.CheckpointInterval <= 0 {
+		return nil
+	}
+	offset := bt.currentOffset()
+	if offset == 0 || offset%bt.CheckpointInterval != 0 || offset == bt.checkpointOffset {
+		return nil
+	}
+	return bt.SaveCheckpoint(bt.CheckpointPath)
+}
+
+// currentOffset returns the offset the run's data has been streamed to
+func (bt *BackTest) currentOffset() int64 {
+	handlers := sortedDataHandlers(bt.Datas.GetAllData())
+	if len(handlers) == 0 {
+		return 0
+	}
+	return int64(handlers[0].handler.Offset())
+}
@@ -0,0 +1,260 @@
+package engine
+
+import (
+	"errors"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/thrasher-corp/gocryptotrader/backtester/data"
+	"github.com/thrasher-corp/gocryptotrader/backtester/data/kline"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventhandlers/eventholder"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventhandlers/exchange"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventhandlers/portfolio"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventhandlers/portfolio/risk"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventhandlers/portfolio/size"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventhandlers/statistics"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventhandlers/strategies/dollarcostaverage"
+	"github.com/thrasher-corp/gocryptotrader/backtester/funding"
+	"github.com/thrasher-corp/gocryptotrader/backtester/report"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/engine"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/ftx"
+	gctkline "github.com/thrasher-corp/gocryptotrader/exchanges/kline"
+)
+
+// setupCheckpointBackTest sets up an offline run which buys each candle
+func setupCheckpointBackTest(t *testing.T, candles int) *BackTest {
+	t.Helper()
+	cp := currency.NewPair(currency.BTC, currency.USDT)
+	a := asset.Spot
+	tt := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	em := engine.SetupExchangeManager()
+	exch := &ftx.FTX{}
+	exch.SetDefaults()
+	em.Add(exch)
+	om, err := engine.SetupOrderManager(em, &engine.CommunicationManager{}, &sync.WaitGroup{}, false, false, 0)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	err = om.Start()
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+
+	limits := exchange.MinMax{MaximumSize: decimal.NewFromInt(1), MaximumTotal: decimal.NewFromInt(10000)}
+	port, err := portfolio.Setup(&size.Size{BuySide: limits, SellSide: limits}, &risk.Risk{
+		CurrencySettings: map[string]map[asset.Item]map[currency.Pair]*risk.CurrencySettings{
+			testExchange: {a: {cp: {}}},
+		},
+	}, decimal.Zero)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	settings := &exchange.Settings{
+		Exchange:            exch,
+		Asset:               a,
+		Pair:                cp,
+		TakerFee:            decimal.NewFromFloat(0.001),
+		MakerFee:            decimal.NewFromFloat(0.001),
+		BuySide:             limits,
+		SellSide:            limits,
+		MinimumSlippageRate: decimal.NewFromInt(100),
+		MaximumSlippageRate: decimal.NewFromInt(100),
+	}
+	err = port.SetupCurrencySettingsMap(settings)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	ex := &exchange.Exchange{}
+	ex.SetExchangeAssetCurrencySettings(a, cp, settings)
+
+	f, err := funding.SetupFundingManager(em, false, true)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	b, err := funding.CreateItem(testExchange, a, cp.Base, decimal.Zero, decimal.Zero)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	quote, err := funding.CreateItem(testExchange, a, cp.Quote, decimal.NewFromInt(100000), decimal.Zero)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	pair, err := funding.CreatePair(b, quote)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	err = f.AddPair(pair)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+
+	stats := &statistics.Statistic{
+		ExchangeAssetPairStatistics: make(map[string]map[asset.Item]map[currency.Pair]*statistics.CurrencyPairStatistic),
+	}
+	bt := &BackTest{
+		Datas:           &data.HandlerPerCurrency{},
+		Strategy:        &dollarcostaverage.Strategy{},
+		Portfolio:       port,
+		Exchange:        ex,
+		Statistic:       stats,
+		EventQueue:      &eventholder.Holder{},
+		Reports:         &report.Data{},
+		Funding:         f,
+		exchangeManager: em,
+		orderManager:    om,
+	}
+	bt.MetaData.DateLoaded = time.Now()
+
+	k := &kline.DataFromKline{
+		Item: gctkline.Item{
+			Exchange: testExchange,
+			Pair:     cp,
+			Asset:    a,
+			Interval: gctkline.OneDay,
+		},
+	}
+	for i := 0; i < candles; i++ {
+		price := float64(1000 + (i%3)*100)
+		k.Item.Candles = append(k.Item.Candles, gctkline.Candle{
+			Time:   tt.Add(time.Duration(i) * gctkline.OneDay.Duration()),
+			Open:   price,
+			High:   price + 50,
+			Low:    price - 50,
+			Close:  price,
+			Volume: 1337,
+		})
+	}
+	k.RangeHolder, err = gctkline.CalculateCandleDateRanges(tt, tt.Add(time.Duration(candles)*gctkline.OneDay.Duration()), gctkline.OneDay, 100000)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	k.RangeHolder.SetHasDataFromCandles(k.Item.Candles)
+	err = k.Load()
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	bt.Datas.Setup()
+	bt.Datas.SetDataForCurrency(testExchange, a, cp, k)
+	return bt
+}
+
+func TestCheckpointResume(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "checkpoint")
+	uninterrupted := setupCheckpointBackTest(t, 9)
+	uninterrupted.CheckpointInterval = 5
+	uninterrupted.CheckpointPath = path
+	uninterrupted.Run()
+	if uninterrupted.checkpointOffset != 5 {
+		t.Fatalf("received '%v' expected '%v'", uninterrupted.checkpointOffset, 5)
+	}
+
+	resumed := setupCheckpointBackTest(t, 9)
+	err := resumed.LoadCheckpoint(path)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	resumed.Run()
+
+	expectedFunds := uninterrupted.Funding.GetAllFunding()
+	funds := resumed.Funding.GetAllFunding()
+	if len(funds) != len(expectedFunds) {
+		t.Fatalf("received '%v' expected '%v'", len(funds), len(expectedFunds))
+	}
+	for i := range funds {
+		if !funds[i].Available.Equal(expectedFunds[i].Available) || !funds[i].Reserved.Equal(expectedFunds[i].Reserved) {
+			t.Errorf("received '%+v' expected '%+v'", funds[i], expectedFunds[i])
+		}
+	}
+	if funds[0].Available.IsZero() {
+		t.Error("expected the run to have bought")
+	}
+
+	expectedPortfolio, err := uninterrupted.Portfolio.SaveState()
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	resumedPortfolio, err := resumed.Portfolio.SaveState()
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	expectedHoldings := expectedPortfolio.Settings[0].HoldingsSnapshots
+	holdings := resumedPortfolio.Settings[0].HoldingsSnapshots
+	if len(holdings) != len(expectedHoldings) {
+		t.Fatalf("received '%v' expected '%v'", len(holdings), len(expectedHoldings))
+	}
+	for i := range holdings {
+		if holdings[i].Offset != expectedHoldings[i].Offset ||
+			!holdings[i].BaseSize.Equal(expectedHoldings[i].BaseSize) ||
+			!holdings[i].QuoteSize.Equal(expectedHoldings[i].QuoteSize) ||
+			!holdings[i].TotalValue.Equal(expectedHoldings[i].TotalValue) ||
+			!holdings[i].TotalFees.Equal(expectedHoldings[i].TotalFees) {
+			t.Errorf("received '%+v' expected '%+v'", holdings[i], expectedHoldings[i])
+		}
+	}
+	if !resumedPortfolio.PeakPortfolioValue.Equal(expectedPortfolio.PeakPortfolioValue) {
+		t.Errorf("received '%v' expected '%v'", resumedPortfolio.PeakPortfolioValue, expectedPortfolio.PeakPortfolioValue)
+	}
+
+	expectedStats := uninterrupted.Statistic.SaveState().Statistics
+	stats := resumed.Statistic.SaveState().Statistics
+	if len(stats) != 1 || len(expectedStats) != 1 {
+		t.Fatalf("received '%v' expected '%v'", len(stats), len(expectedStats))
+	}
+	if len(stats[0].Events) != len(expectedStats[0].Events) {
+		t.Fatalf("received '%v' expected '%v'", len(stats[0].Events), len(expectedStats[0].Events))
+	}
+	for i := range stats[0].Events {
+		if stats[0].Events[i].Offset != expectedStats[0].Events[i].Offset ||
+			!stats[0].Events[i].Holdings.TotalValue.Equal(expectedStats[0].Events[i].Holdings.TotalValue) ||
+			(stats[0].Events[i].FillEvent == nil) != (expectedStats[0].Events[i].FillEvent == nil) {
+			t.Errorf("received '%+v' expected '%+v'", stats[0].Events[i], expectedStats[0].Events[i])
+		}
+	}
+	if stats[0].Funnel != expectedStats[0].Funnel {
+		t.Errorf("received '%+v' expected '%+v'", stats[0].Funnel, expectedStats[0].Funnel)
+	}
+}
+
+func TestSaveCheckpoint(t *testing.T) {
+	t.Parallel()
+	bt := &BackTest{}
+	bt.MetaData.LiveTesting = true
+	err := bt.SaveCheckpoint(filepath.Join(t.TempDir(), "checkpoint"))
+	if !errors.Is(err, errCheckpointUnsupported) {
+		t.Errorf("received '%v' expected '%v'", err, errCheckpointUnsupported)
+	}
+	err = bt.LoadCheckpoint(filepath.Join(t.TempDir(), "checkpoint"))
+	if !errors.Is(err, errCheckpointUnsupported) {
+		t.Errorf("received '%v' expected '%v'", err, errCheckpointUnsupported)
+	}
+}
+
+func TestLoadCheckpoint(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "checkpoint")
+	bt := setupCheckpointBackTest(t, 3)
+	err := bt.SaveCheckpoint(path)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+
+	bt.Datas.SetDataForCurrency(testExchange, asset.Spot, currency.NewPair(currency.ETH, currency.USDT), &kline.DataFromKline{})
+	err = bt.LoadCheckpoint(path)
+	if !errors.Is(err, errCheckpointMismatch) {
+		t.Errorf("received '%v' expected '%v'", err, errCheckpointMismatch)
+	}
+
+	bt = setupCheckpointBackTest(t, 3)
+	bt.Run()
+	err = bt.LoadCheckpoint(path)
+	if !errors.Is(err, errCheckpointMismatch) {
+		t.Errorf("received '%v' expected '%v'", err, errCheckpointMismatch)
+	}
+}
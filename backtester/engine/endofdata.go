@@ -0,0 +1,102 @@
+package engine
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/thrasher-corp/gocryptotrader/backtester/common"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/order"
+	"github.com/thrasher-corp/gocryptotrader/log"
+)
+
+const (
+	// EndOfDataMarkToMarket leaves positions open once the data feed has no
+	// more data, valuing them at the final close price. This is the default
+	EndOfDataMarkToMarket = "mark-to-market"
+	// EndOfDataForceClose closes all open positions at the final close
+	// price once the data feed has no more data
+	EndOfDataForceClose = "force-close"
+	// EndOfDataError fails the run when positions remain
+	// open once the data feed has no more data
+	EndOfDataError = "error"
+)
+
+var errOpenPositionsAtEndOfData = errors.New("positions remain open at the end of data")
+
+// processEndOfData resolves positions left open once every data feed has no
+// more data according to the end of data policy. Force closed positions are
+// processed through the exchange, portfolio and statistics as any other order.
+// Orders still deferred by the exchange are first cancelled, releasing their funds
+func (bt *BackTest) processEndOfData() error {
+	err := bt.cancelDeferredOrders()
+	if err != nil {
+		return err
+	}
+	switch bt.EndOfDataPolicy {
+	case EndOfDataForceClose, EndOfDataError:
+	default:
+		return nil
+	}
+	var closingOrders []*order.Order
+	for _, exchangeMap := range bt.Datas.GetAllData() {
+		for _, assetMap := range exchangeMap {
+			for _, dataHandler := range assetMap {
+				latest := dataHandler.Latest()
+				if latest == nil {
+					continue
+				}
+				o, err := bt.Portfolio.CreateClosingOrder(latest, fmt.Sprintf("END OF DATA, closing position at final close price %v", latest.GetClosePrice()))
+				if err != nil {
+					return err
+				}
+				if o != nil {
+					closingOrders = append(closingOrders, o)
+				}
+			}
+		}
+	}
+	if len(closingOrders) == 0 {
+		return nil
+	}
+	if bt.EndOfDataPolicy == EndOfDataError {
+		return fmt.Errorf("%w, %v open positions", errOpenPositionsAtEndOfData, len(closingOrders))
+	}
+	err = bt.queueClosingOrders(closingOrders)
+	if err != nil {
+		return err
+	}
+	bt.drainEventQueue()
+	return nil
+}
+
+// cancelDeferredOrders cancels orders the exchange has yet to fill at the end
+// of the run and processes their fills so that the released funds are recorded
+func (bt *BackTest) cancelDeferredOrders() error {
+	fills, err := bt.Exchange.CancelDeferredOrders(bt.Funding)
+	if err != nil {
+		return err
+	}
+	if len(fills) == 0 {
+		return nil
+	}
+	for i := range fills {
+		log.Infof(common.Backtester, "%v %v %v deferred order cancelled at end of data", fills[i].GetExchange(), fills[i].GetAssetType(), fills[i].Pair())
+		err = bt.Statistic.SetEventForOffset(fills[i])
+		if err != nil {
+			log.Errorf(common.Backtester, "SetEventForOffset %v %v %v %v", fills[i].GetExchange(), fills[i].GetAssetType(), fills[i].Pair(), err)
+		}
+		bt.EventQueue.AppendEvent(fills[i])
+	}
+	bt.drainEventQueue()
+	return nil
+}
+
+// drainEventQueue handles every queued event until the queue is empty
+func (bt *BackTest) drainEventQueue() {
+	for ev := bt.EventQueue.NextEvent(); ev != nil; ev = bt.EventQueue.NextEvent() {
+		err := bt.handleEvent(ev)
+		if err != nil {
+			log.Error(common.Backtester, err)
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package engine
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/backtester/common"
+	"github.com/thrasher-corp/gocryptotrader/backtester/data"
+	"github.com/thrasher-corp/gocryptotrader/backtester/data/kline"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventhandlers/exchange"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventhandlers/portfolio"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/event"
+	evkline "github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/kline"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/order"
+	"github.com/thrasher-corp/gocryptotrader/backtester/funding"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	gctkline "github.com/thrasher-corp/gocryptotrader/exchanges/kline"
+	gctorder "github.com/thrasher-corp/gocryptotrader/exchanges/order"
+)
+
+type openPositionPortfolio struct {
+	portfolio.Portfolio
+	hasPosition bool
+}
+
+func (p *openPositionPortfolio) CreateClosingOrder(ev common.DataEventHandler, reason string) (*order.Order, error) {
+	if !p.hasPosition {
+		return nil, nil
+	}
+	return &order.Order{
+		Base:      &event.Base{Exchange: ev.GetExchange(), AssetType: ev.GetAssetType(), CurrencyPair: ev.Pair(), Reasons: []string{reason}},
+		Direction: gctorder.Sell,
+		Amount:    leet,
+	}, nil
+}
+
+func TestProcessEndOfData(t *testing.T) {
+	t.Parallel()
+	cp := currency.NewPair(currency.BTC, currency.USDT)
+	p := &openPositionPortfolio{hasPosition: true}
+	bt := BackTest{
+		Datas:     &data.HandlerPerCurrency{},
+		Portfolio: p,
+		Exchange:  &exchange.Exchange{},
+	}
+	d := data.Base{}
+	d.SetStream([]common.DataEventHandler{&evkline.Kline{
+		Base: &event.Base{
+			Exchange:     testExchange,
+			Time:         time.Now(),
+			Interval:     gctkline.OneDay,
+			CurrencyPair: cp,
+			AssetType:    asset.Spot,
+		},
+		Close: leet,
+	}})
+	d.Next()
+	bt.Datas.SetDataForCurrency(testExchange, asset.Spot, cp, &kline.DataFromKline{Base: d})
+
+	err := bt.processEndOfData()
+	if !errors.Is(err, common.ErrNilArguments) {
+		t.Errorf("received '%v' expected '%v'", err, common.ErrNilArguments)
+	}
+
+	bt.Funding = &funding.FundManager{}
+	err = bt.processEndOfData()
+	if !errors.Is(err, nil) {
+		t.Errorf("received '%v' expected '%v'", err, nil)
+	}
+	bt.EndOfDataPolicy = EndOfDataMarkToMarket
+	err = bt.processEndOfData()
+	if !errors.Is(err, nil) {
+		t.Errorf("received '%v' expected '%v'", err, nil)
+	}
+
+	bt.EndOfDataPolicy = EndOfDataError
+	err = bt.processEndOfData()
+	if !errors.Is(err, errOpenPositionsAtEndOfData) {
+		t.Errorf("received '%v' expected '%v'", err, errOpenPositionsAtEndOfData)
+	}
+
+	p.hasPosition = false
+	bt.EndOfDataPolicy = EndOfDataForceClose
+	err = bt.processEndOfData()
+	if !errors.Is(err, nil) {
+		t.Errorf("received '%v' expected '%v'", err, nil)
+	}
+
+	bt.Portfolio = &portfolio.Portfolio{}
+	err = bt.processEndOfData()
+	if err == nil {
+		t.Error("expected an error for a currency without portfolio settings")
+	}
+}
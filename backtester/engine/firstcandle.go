@@ -0,0 +1,53 @@
+package engine
+
+import (
+	"github.com/thrasher-corp/gocryptotrader/backtester/common"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/signal"
+	gctorder "github.com/thrasher-corp/gocryptotrader/exchanges/order"
+)
+
+const (
+	// FirstCandleSkip ignores signals raised on the first candle of a data
+	// feed, as it has no prior close for strategies to reference. This is
+	// the default
+	FirstCandleSkip = "skip"
+	// FirstCandleOpen acts upon signals raised on the first candle using the
+	// candle's open as the reference price in place of a prior close
+	FirstCandleOpen = "open"
+	// FirstCandleTrade acts upon signals raised on the first candle at its
+	// close, the same as any other candle
+	FirstCandleTrade = "trade"
+
+	firstCandleOffset = 1
+)
+
+// isFirstCandle returns whether the data event is the first of its data feed
+func isFirstCandle(ev common.DataEventHandler) bool {
+	return ev != nil && ev.GetOffset() == firstCandleOffset
+}
+
+// applyFirstCandlePolicy applies the first candle policy to a signal raised
+// against the latest data event. Signals for any later candle are untouched
+func applyFirstCandlePolicy(s signal.Event, latest common.DataEventHandler, policy string) {
+	if s == nil || !isFirstCandle(latest) {
+		return
+	}
+	switch s.GetDirection() {
+	case gctorder.DoNothing, gctorder.MissingData, gctorder.TransferredFunds:
+		return
+	}
+	switch policy {
+	case FirstCandleTrade:
+	case FirstCandleOpen:
+		if !latest.GetOpenPrice().IsPositive() {
+			s.AppendReasonf("%v signal cancelled on the first candle as it has no valid open price", s.GetDirection())
+			s.SetDirection(gctorder.DoNothing)
+			return
+		}
+		s.SetPrice(latest.GetOpenPrice())
+		s.AppendReasonf("First candle has no prior close, using open price %v", latest.GetOpenPrice())
+	default:
+		s.AppendReasonf("%v signal cancelled on the first candle as it has no prior close", s.GetDirection())
+		s.SetDirection(gctorder.DoNothing)
+	}
+}
@@ -0,0 +1,90 @@
+package engine
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/thrasher-corp/gocryptotrader/backtester/data/kline"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/event"
+	evkline "github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/kline"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/signal"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	gctkline "github.com/thrasher-corp/gocryptotrader/exchanges/kline"
+	gctorder "github.com/thrasher-corp/gocryptotrader/exchanges/order"
+)
+
+// newSingleCandleFeed returns a data feed holding a single candle
+// which has been moved to as the latest data event
+func newSingleCandleFeed(t *testing.T) *kline.DataFromKline {
+	t.Helper()
+	d := &kline.DataFromKline{
+		Item: gctkline.Item{
+			Exchange: testExchange,
+			Pair:     currency.NewPair(currency.BTC, currency.USDT),
+			Asset:    asset.Spot,
+			Interval: gctkline.OneDay,
+			Candles: []gctkline.Candle{
+				{Time: time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC), Open: 90, High: 110, Low: 80, Close: 100, Volume: 1000},
+			},
+		},
+	}
+	if err := d.Load(); err != nil {
+		t.Fatal(err)
+	}
+	if d.Next() == nil {
+		t.Fatal("expected single candle to be loaded")
+	}
+	return d
+}
+
+func TestApplyFirstCandlePolicy(t *testing.T) {
+	t.Parallel()
+	d := newSingleCandleFeed(t)
+	latest := d.Latest()
+	if !isFirstCandle(latest) {
+		t.Fatal("expected single candle to be the first candle")
+	}
+	newSignal := func() *signal.Signal {
+		b := *latest.GetBase()
+		return &signal.Signal{Base: &b, Direction: gctorder.Buy, ClosePrice: latest.GetClosePrice()}
+	}
+
+	s := newSignal()
+	applyFirstCandlePolicy(s, latest, "")
+	if s.GetDirection() != gctorder.DoNothing {
+		t.Errorf("received '%v' expected '%v'", s.GetDirection(), gctorder.DoNothing)
+	}
+	s = newSignal()
+	applyFirstCandlePolicy(s, latest, FirstCandleSkip)
+	if s.GetDirection() != gctorder.DoNothing {
+		t.Errorf("received '%v' expected '%v'", s.GetDirection(), gctorder.DoNothing)
+	}
+
+	s = newSignal()
+	applyFirstCandlePolicy(s, latest, FirstCandleOpen)
+	if s.GetDirection() != gctorder.Buy {
+		t.Errorf("received '%v' expected '%v'", s.GetDirection(), gctorder.Buy)
+	}
+	if !s.GetClosePrice().Equal(decimal.NewFromInt(90)) {
+		t.Errorf("received '%v' expected '%v'", s.GetClosePrice(), 90)
+	}
+
+	s = newSignal()
+	applyFirstCandlePolicy(s, latest, FirstCandleTrade)
+	if s.GetDirection() != gctorder.Buy {
+		t.Errorf("received '%v' expected '%v'", s.GetDirection(), gctorder.Buy)
+	}
+	if !s.GetClosePrice().Equal(decimal.NewFromInt(100)) {
+		t.Errorf("received '%v' expected '%v'", s.GetClosePrice(), 100)
+	}
+
+	s = newSignal()
+	later := &event.Base{Offset: 2}
+	s.Base = later
+	applyFirstCandlePolicy(s, &evkline.Kline{Base: later}, FirstCandleSkip)
+	if s.GetDirection() != gctorder.Buy {
+		t.Errorf("received '%v' expected later candles to be untouched", s.GetDirection())
+	}
+}
@@ -0,0 +1,81 @@
+package engine
+
+import (
+	"github.com/shopspring/decimal"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/signal"
+	gctorder "github.com/thrasher-corp/gocryptotrader/exchanges/order"
+)
+
+const (
+	// OpposingSignalsHedge processes opposing signals for the same currency
+	// and offset independently as a straddle. This is the default
+	OpposingSignalsHedge = "hedge"
+	// OpposingSignalsNet offsets opposing signals for the same currency and
+	// offset against each other, keeping only the larger signal's excess
+	OpposingSignalsNet = "net"
+	// OpposingSignalsReject cancels both opposing signals
+	// for the same currency and offset
+	OpposingSignalsReject = "reject"
+)
+
+// areOpposingSignals returns whether both signals act on the same exchange,
+// asset and pair in opposite directions
+func areOpposingSignals(a, b signal.Event) bool {
+	if a.GetExchange() != b.GetExchange() ||
+		a.GetAssetType() != b.GetAssetType() ||
+		!a.Pair().Equal(b.Pair()) {
+		return false
+	}
+	return (a.GetDirection().IsLong() && b.GetDirection().IsShort()) ||
+		(a.GetDirection().IsShort() && b.GetDirection().IsLong())
+}
+
+// resolveOpposingSignals applies the opposing signal policy to signals raised
+// for the same currency at the same offset in opposite directions. Netting
+// requires both signals to set an amount, signals without amounts cannot be
+// sized against each other and net to nothing
+func resolveOpposingSignals(signals []signal.Event, policy string) {
+	if policy == "" || policy == OpposingSignalsHedge {
+		return
+	}
+	for i := range signals {
+		for j := i + 1; j < len(signals); j++ {
+			if !areOpposingSignals(signals[i], signals[j]) {
+				continue
+			}
+			if policy == OpposingSignalsReject {
+				cancelOpposingSignal(signals[i], signals[j].GetDirection())
+				cancelOpposingSignal(signals[j], signals[i].GetDirection())
+				continue
+			}
+			netOpposingSignals(signals[i], signals[j])
+		}
+	}
+}
+
+// netOpposingSignals offsets the smaller signal's amount against the larger,
+// cancelling the smaller signal. Equal or unset amounts cancel both signals
+func netOpposingSignals(a, b signal.Event) {
+	aAmount, bAmount := a.GetAmount(), b.GetAmount()
+	switch {
+	case !aAmount.IsPositive() || !bAmount.IsPositive(), aAmount.Equal(bAmount):
+		cancelOpposingSignal(a, b.GetDirection())
+		cancelOpposingSignal(b, a.GetDirection())
+	case aAmount.GreaterThan(bAmount):
+		nettedAmount(a, aAmount.Sub(bAmount))
+		cancelOpposingSignal(b, a.GetDirection())
+	default:
+		nettedAmount(b, bAmount.Sub(aAmount))
+		cancelOpposingSignal(a, b.GetDirection())
+	}
+}
+
+func nettedAmount(s signal.Event, amount decimal.Decimal) {
+	s.AppendReasonf("Amount netted from %v to %v against an opposing signal", s.GetAmount(), amount)
+	s.SetAmount(amount)
+}
+
+func cancelOpposingSignal(s signal.Event, opposing gctorder.Side) {
+	s.AppendReasonf("%v signal cancelled by opposing %v signal at the same offset", s.GetDirection(), opposing)
+	s.SetDirection(gctorder.DoNothing)
+}
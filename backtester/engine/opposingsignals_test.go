@@ -0,0 +1,81 @@
+package engine
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/event"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/signal"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	gctorder "github.com/thrasher-corp/gocryptotrader/exchanges/order"
+)
+
+func newOpposingSignals(longAmount, shortAmount int64) []signal.Event {
+	b := event.Base{
+		Exchange:     testExchange,
+		AssetType:    asset.Spot,
+		CurrencyPair: currency.NewPair(currency.BTC, currency.USDT),
+	}
+	long, short := b, b
+	return []signal.Event{
+		&signal.Signal{Base: &long, Direction: gctorder.Buy, Amount: decimal.NewFromInt(longAmount)},
+		&signal.Signal{Base: &short, Direction: gctorder.Sell, Amount: decimal.NewFromInt(shortAmount)},
+	}
+}
+
+func TestAreOpposingSignals(t *testing.T) {
+	t.Parallel()
+	s := newOpposingSignals(1, 1)
+	if !areOpposingSignals(s[0], s[1]) {
+		t.Error("expected buy and sell signals to oppose")
+	}
+	s[1].SetDirection(gctorder.Buy)
+	if areOpposingSignals(s[0], s[1]) {
+		t.Error("expected signals in the same direction to not oppose")
+	}
+	s[1].SetDirection(gctorder.Sell)
+	s[1].GetBase().CurrencyPair = currency.NewPair(currency.ETH, currency.USDT)
+	if areOpposingSignals(s[0], s[1]) {
+		t.Error("expected signals for different pairs to not oppose")
+	}
+}
+
+func TestResolveOpposingSignals(t *testing.T) {
+	t.Parallel()
+	s := newOpposingSignals(1, 1)
+	resolveOpposingSignals(s, OpposingSignalsHedge)
+	if s[0].GetDirection() != gctorder.Buy || s[1].GetDirection() != gctorder.Sell {
+		t.Errorf("received '%v' '%v' expected hedged signals to be unchanged", s[0].GetDirection(), s[1].GetDirection())
+	}
+
+	s = newOpposingSignals(3, 1)
+	resolveOpposingSignals(s, OpposingSignalsReject)
+	if s[0].GetDirection() != gctorder.DoNothing || s[1].GetDirection() != gctorder.DoNothing {
+		t.Errorf("received '%v' '%v' expected '%v'", s[0].GetDirection(), s[1].GetDirection(), gctorder.DoNothing)
+	}
+
+	s = newOpposingSignals(3, 1)
+	resolveOpposingSignals(s, OpposingSignalsNet)
+	if s[0].GetDirection() != gctorder.Buy || !s[0].GetAmount().Equal(decimal.NewFromInt(2)) {
+		t.Errorf("received '%v' '%v' expected '%v' '%v'", s[0].GetDirection(), s[0].GetAmount(), gctorder.Buy, 2)
+	}
+	if s[1].GetDirection() != gctorder.DoNothing {
+		t.Errorf("received '%v' expected '%v'", s[1].GetDirection(), gctorder.DoNothing)
+	}
+
+	s = newOpposingSignals(1, 4)
+	resolveOpposingSignals(s, OpposingSignalsNet)
+	if s[1].GetDirection() != gctorder.Sell || !s[1].GetAmount().Equal(decimal.NewFromInt(3)) {
+		t.Errorf("received '%v' '%v' expected '%v' '%v'", s[1].GetDirection(), s[1].GetAmount(), gctorder.Sell, 3)
+	}
+	if s[0].GetDirection() != gctorder.DoNothing {
+		t.Errorf("received '%v' expected '%v'", s[0].GetDirection(), gctorder.DoNothing)
+	}
+
+	s = newOpposingSignals(0, 4)
+	resolveOpposingSignals(s, OpposingSignalsNet)
+	if s[0].GetDirection() != gctorder.DoNothing || s[1].GetDirection() != gctorder.DoNothing {
+		t.Errorf("received '%v' '%v' expected unsized signals to net to '%v'", s[0].GetDirection(), s[1].GetDirection(), gctorder.DoNothing)
+	}
+}
@@ -0,0 +1,209 @@
+package engine
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/shopspring/decimal"
+	"github.com/thrasher-corp/gocryptotrader/backtester/common"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventhandlers/exchange"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/fill"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/signal"
+	"github.com/thrasher-corp/gocryptotrader/backtester/funding"
+	"github.com/thrasher-corp/gocryptotrader/engine"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	gctorder "github.com/thrasher-corp/gocryptotrader/exchanges/order"
+	"github.com/thrasher-corp/gocryptotrader/log"
+)
+
+// processRebalance sells part of one spot position and buys another with the
+// proceeds within the same offset. The buy is sized to the sell's proceeds
+// after fees. If the sell cannot fill, the buy is not placed. If the buy
+// cannot fill, the sell's funding is reverted, its order cancelled, the
+// exchange's tracking state restored to before the sell and its fill rolled
+// back so that neither leg is kept. An error is returned when the sell cannot
+// be rolled back
+func (bt *BackTest) processRebalance(ev signal.Event, funds funding.IFundingPair) error {
+	target := ev.GetRebalanceEvent()
+	target.SetOffset(ev.GetOffset())
+	sellSettings, err := bt.Exchange.GetCurrencySettings(ev.GetExchange(), ev.GetAssetType(), ev.Pair())
+	if err != nil {
+		return fmt.Errorf("GetCurrencySettings %v %v %v %v", ev.GetExchange(), ev.GetAssetType(), ev.Pair(), err)
+	}
+	buySettings, err := bt.Exchange.GetCurrencySettings(target.GetExchange(), target.GetAssetType(), target.Pair())
+	if err != nil {
+		return fmt.Errorf("GetCurrencySettings %v %v %v %v", target.GetExchange(), target.GetAssetType(), target.Pair(), err)
+	}
+	err = validateRebalance(ev, target, &sellSettings, &buySettings, bt.Funding.IsUsingExchangeLevelFunding())
+	if err != nil {
+		return err
+	}
+	targetFunds, err := bt.Funding.GetFundingForEvent(target)
+	if err != nil {
+		return err
+	}
+	sellReader, err := funds.FundReader().GetPairReader()
+	if err != nil {
+		return err
+	}
+	baseBefore, quoteBefore := sellReader.BaseAvailable(), sellReader.QuoteAvailable()
+	exchangeState := bt.Exchange.SaveState()
+
+	sellFill, err := bt.executeRebalanceLeg(ev, &sellSettings, funds)
+	if err != nil {
+		return err
+	}
+	if !common.CanTransact(sellFill.GetDirection()) {
+		sellFill.AppendReasonf("Rebalance into %v %v %v cancelled as the sell could not fill", target.GetExchange(), target.GetAssetType(), target.Pair())
+		bt.EventQueue.AppendEvent(sellFill)
+		return nil
+	}
+	spent := baseBefore.Sub(sellReader.BaseAvailable())
+	proceeds := sellReader.QuoteAvailable().Sub(quoteBefore)
+	target.AppendReasonf("Rebalancing from %v %v %v", ev.GetExchange(), ev.GetAssetType(), ev.Pair())
+	var buyFill fill.Event
+	var buyErr error
+	if sizeRebalanceTarget(target, proceeds, &buySettings) {
+		buyFill, buyErr = bt.executeRebalanceLeg(target, &buySettings, targetFunds)
+	}
+	if buyFill != nil && buyErr == nil && common.CanTransact(buyFill.GetDirection()) {
+		bt.EventQueue.AppendEvent(sellFill)
+		bt.EventQueue.AppendEvent(buyFill)
+		return nil
+	}
+	err = rollBackRebalanceLeg(sellFill, funds.FundReleaser(), bt.orderManager, spent, proceeds)
+	if err == nil {
+		err = bt.Exchange.RestoreState(exchangeState)
+	}
+	bt.EventQueue.AppendEvent(sellFill)
+	if buyFill != nil {
+		bt.EventQueue.AppendEvent(buyFill)
+	}
+	if err != nil {
+		return fmt.Errorf("rollBackRebalanceLeg %v %v %v %w", ev.GetExchange(), ev.GetAssetType(), ev.Pair(), err)
+	}
+	return buyErr
+}
+
+// executeRebalanceLeg sizes and executes a rebalance leg's signal without
+// queueing its order, returning the fill for the offset
+func (bt *BackTest) executeRebalanceLeg(ev signal.Event, cs *exchange.Settings, funds funding.IFundingPair) (fill.Event, error) {
+	o, err := bt.Portfolio.OnSignal(ev, cs, funds.FundReserver())
+	if err != nil {
+		return nil, fmt.Errorf("OnSignal %v %v %v %v", ev.GetExchange(), ev.GetAssetType(), ev.Pair(), err)
+	}
+	err = bt.Statistic.SetEventForOffset(o)
+	if err != nil {
+		log.Errorf(common.Backtester, "SetEventForOffset %v %v %v %v", ev.GetExchange(), ev.GetAssetType(), ev.Pair(), err)
+	}
+	d, err := bt.Datas.GetDataForCurrency(o)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := bt.orderContext()
+	defer cancel()
+	f, err := bt.Exchange.ExecuteOrder(ctx, o, d, bt.orderManager, funds.FundReleaser())
+	if f == nil {
+		return nil, fmt.Errorf("ExecuteOrder fill event should always be returned, please fix, %v", err)
+	}
+	if err != nil && !errors.Is(err, exchange.ErrCannotTransact) {
+		log.Errorf(common.Backtester, "ExecuteOrder %v %v %v %v", f.GetExchange(), f.GetAssetType(), f.Pair(), err)
+	}
+	err = bt.Statistic.SetEventForOffset(f)
+	if err != nil {
+		log.Errorf(common.Backtester, "SetEventForOffset %v %v %v %v", ev.GetExchange(), ev.GetAssetType(), ev.Pair(), err)
+	}
+	return f, nil
+}
+
+// validateRebalance ensures a rebalance sells and buys spot pairs sharing a
+// quote currency on the same exchange, so that the sell's proceeds can fund
+// the buy, and that neither leg can be deferred, left open as a resting limit,
+// dormant stop, pending take-profit or dormant trailing stop order or sent to
+// a real exchange where it could not be rolled back
+func validateRebalance(sell, buy signal.Event, sellSettings, buySettings *exchange.Settings, exchangeLevelFunding bool) error {
+	switch {
+	case sell.GetDirection() != gctorder.Sell && sell.GetDirection() != gctorder.Ask:
+		return fmt.Errorf("%w, %v %v %v must sell, received %v", errInvalidRebalance, sell.GetExchange(), sell.GetAssetType(), sell.Pair(), sell.GetDirection())
+	case buy.GetDirection() != gctorder.Buy && buy.GetDirection() != gctorder.Bid:
+		return fmt.Errorf("%w, %v %v %v must buy, received %v", errInvalidRebalance, buy.GetExchange(), buy.GetAssetType(), buy.Pair(), buy.GetDirection())
+	case sell.GetAssetType() != asset.Spot || buy.GetAssetType() != asset.Spot:
+		return fmt.Errorf("%w, both legs must be spot", errInvalidRebalance)
+	case sell.GetExchange() != buy.GetExchange() || !sell.Pair().Quote.Equal(buy.Pair().Quote):
+		return fmt.Errorf("%w, both legs must share an exchange and quote currency", errInvalidRebalance)
+	case !exchangeLevelFunding:
+		return fmt.Errorf("%w, exchange level funding is required to share proceeds between pairs", errInvalidRebalance)
+	case !sell.GetLimitPrice().IsZero() || !buy.GetLimitPrice().IsZero():
+		return fmt.Errorf("%w, limit orders cannot be rolled back as they may be left open", errInvalidRebalance)
+	case !sell.GetStopPrice().IsZero() || !buy.GetStopPrice().IsZero():
+		return fmt.Errorf("%w, stop orders cannot be rolled back as they may be left dormant", errInvalidRebalance)
+	case !sell.GetTakeProfitPrice().IsZero() || !buy.GetTakeProfitPrice().IsZero() ||
+		!sell.GetTakeProfitPercent().IsZero() || !buy.GetTakeProfitPercent().IsZero():
+		return fmt.Errorf("%w, take-profit orders cannot be rolled back as they may be left pending", errInvalidRebalance)
+	case !sell.GetTrailingStopDistance().IsZero() || !buy.GetTrailingStopDistance().IsZero() ||
+		!sell.GetTrailingStopPercent().IsZero() || !buy.GetTrailingStopPercent().IsZero():
+		return fmt.Errorf("%w, trailing stop orders cannot be rolled back as they may be left dormant", errInvalidRebalance)
+	}
+	for _, cs := range []*exchange.Settings{sellSettings, buySettings} {
+		if cs.UseRealOrders ||
+			cs.Latency != nil ||
+			cs.FillsAtNextCandleOpen() ||
+			(cs.TradingSession != nil && cs.TradingSession.DeferOffHoursOrders) {
+			return fmt.Errorf("%w, %v %v orders cannot be rolled back when using real orders, latency, next candle open fills or deferred orders", errInvalidRebalance, cs.Asset, cs.Pair)
+		}
+	}
+	return nil
+}
+
+// sizeRebalanceTarget sets the buy's amount to what the sell's proceeds can
+// fund after fees at the buy's close price. A smaller amount set by the
+// strategy is kept. Returns false when the proceeds cannot fund a buy
+func sizeRebalanceTarget(target signal.Event, proceeds decimal.Decimal, cs *exchange.Settings) bool {
+	price := target.GetClosePrice()
+	if !price.IsPositive() || !proceeds.IsPositive() {
+		target.AppendReasonf("Rebalance proceeds %v cannot fund a buy at price %v", proceeds, price)
+		return false
+	}
+	feeRate := cs.TakerFee
+	if feeOverride := target.GetFeeOverride(); feeOverride != nil {
+		feeRate = *feeOverride
+	}
+	funded := proceeds.Div(price.Mul(decimal.NewFromInt(1).Add(feeRate)))
+	if amount := target.GetAmount(); amount.IsPositive() && amount.LessThanOrEqual(funded) {
+		return true
+	}
+	target.SetAmount(funded)
+	target.AppendReasonf("Amount sized to %v funded by rebalance proceeds of %v", funded, proceeds)
+	return true
+}
+
+// rollBackRebalanceLeg reverts a filled rebalance leg's funding, cancels its
+// order in the order manager and marks its fill as rolled back so that it is
+// not recorded as a trade
+func rollBackRebalanceLeg(f fill.Event, funds funding.IFundReleaser, orderManager *engine.OrderManager, spent, acquired decimal.Decimal) error {
+	pr, err := funds.PairReleaser()
+	if err != nil {
+		return err
+	}
+	err = pr.RevertFill(spent, acquired, f.GetDirection())
+	if err != nil {
+		return err
+	}
+	if ord := f.GetOrder(); ord != nil {
+		var od *gctorder.Detail
+		od, err = orderManager.GetByExchangeAndID(ord.Exchange, ord.OrderID)
+		if err != nil {
+			return err
+		}
+		od.Status = gctorder.Cancelled
+		od.LastUpdated = f.GetTime()
+		err = orderManager.UpdateExistingOrder(od)
+		if err != nil {
+			return err
+		}
+	}
+	direction := f.GetDirection()
+	f.RollBack()
+	f.AppendReasonf("%v of %v rolled back as the other leg of the rebalance could not fill", direction, f.GetAmount())
+	return nil
+}
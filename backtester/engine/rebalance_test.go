@@ -0,0 +1,250 @@
+package engine
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventhandlers/exchange"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/event"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/fill"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/signal"
+	"github.com/thrasher-corp/gocryptotrader/backtester/funding"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/engine"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	gctorder "github.com/thrasher-corp/gocryptotrader/exchanges/order"
+)
+
+func newRebalanceSignals() (sell, buy *signal.Signal) {
+	sell = &signal.Signal{
+		Base: &event.Base{
+			Exchange:     testExchange,
+			AssetType:    asset.Spot,
+			CurrencyPair: currency.NewPair(currency.BTC, currency.USDT),
+		},
+		Direction: gctorder.Sell,
+	}
+	buy = &signal.Signal{
+		Base: &event.Base{
+			Exchange:     testExchange,
+			AssetType:    asset.Spot,
+			CurrencyPair: currency.NewPair(currency.ETH, currency.USDT),
+		},
+		Direction:  gctorder.Buy,
+		ClosePrice: decimal.NewFromInt(100),
+	}
+	sell.RebalanceEvent = buy
+	return sell, buy
+}
+
+func TestValidateRebalance(t *testing.T) {
+	t.Parallel()
+	sell, buy := newRebalanceSignals()
+	cs := &exchange.Settings{SignalToFillConvention: exchange.SameCandleClose}
+	err := validateRebalance(sell, buy, cs, cs, true)
+	if !errors.Is(err, nil) {
+		t.Errorf("received '%v' expected '%v'", err, nil)
+	}
+	err = validateRebalance(sell, buy, cs, cs, false)
+	if !errors.Is(err, errInvalidRebalance) {
+		t.Errorf("received '%v' expected '%v'", err, errInvalidRebalance)
+	}
+
+	buy.CurrencyPair = currency.NewPair(currency.ETH, currency.BTC)
+	err = validateRebalance(sell, buy, cs, cs, true)
+	if !errors.Is(err, errInvalidRebalance) {
+		t.Errorf("received '%v' expected '%v'", err, errInvalidRebalance)
+	}
+	buy.CurrencyPair = currency.NewPair(currency.ETH, currency.USDT)
+
+	buy.AssetType = asset.Futures
+	err = validateRebalance(sell, buy, cs, cs, true)
+	if !errors.Is(err, errInvalidRebalance) {
+		t.Errorf("received '%v' expected '%v'", err, errInvalidRebalance)
+	}
+	buy.AssetType = asset.Spot
+
+	buy.LimitPrice = decimal.NewFromInt(1337)
+	err = validateRebalance(sell, buy, cs, cs, true)
+	if !errors.Is(err, errInvalidRebalance) {
+		t.Errorf("received '%v' expected '%v'", err, errInvalidRebalance)
+	}
+	buy.LimitPrice = decimal.Zero
+
+	sell.StopPrice = decimal.NewFromInt(1337)
+	err = validateRebalance(sell, buy, cs, cs, true)
+	if !errors.Is(err, errInvalidRebalance) {
+		t.Errorf("received '%v' expected '%v'", err, errInvalidRebalance)
+	}
+	sell.StopPrice = decimal.Zero
+
+	sell.TakeProfitPrice = decimal.NewFromInt(1337)
+	err = validateRebalance(sell, buy, cs, cs, true)
+	if !errors.Is(err, errInvalidRebalance) {
+		t.Errorf("received '%v' expected '%v'", err, errInvalidRebalance)
+	}
+	sell.TakeProfitPrice = decimal.Zero
+
+	buy.TrailingStopPercent = decimal.NewFromInt(5)
+	err = validateRebalance(sell, buy, cs, cs, true)
+	if !errors.Is(err, errInvalidRebalance) {
+		t.Errorf("received '%v' expected '%v'", err, errInvalidRebalance)
+	}
+	buy.TrailingStopPercent = decimal.Zero
+
+	err = validateRebalance(buy, sell, cs, cs, true)
+	if !errors.Is(err, errInvalidRebalance) {
+		t.Errorf("received '%v' expected '%v'", err, errInvalidRebalance)
+	}
+
+	err = validateRebalance(sell, buy, cs, &exchange.Settings{Latency: &exchange.LatencyDistribution{}}, true)
+	if !errors.Is(err, errInvalidRebalance) {
+		t.Errorf("received '%v' expected '%v'", err, errInvalidRebalance)
+	}
+	err = validateRebalance(sell, buy, &exchange.Settings{UseRealOrders: true}, cs, true)
+	if !errors.Is(err, errInvalidRebalance) {
+		t.Errorf("received '%v' expected '%v'", err, errInvalidRebalance)
+	}
+	err = validateRebalance(sell, buy, cs, &exchange.Settings{SignalToFillConvention: exchange.NextCandleOpen}, true)
+	if !errors.Is(err, errInvalidRebalance) {
+		t.Errorf("received '%v' expected '%v'", err, errInvalidRebalance)
+	}
+	// orders default to filling at the next candle open
+	err = validateRebalance(sell, buy, cs, &exchange.Settings{}, true)
+	if !errors.Is(err, errInvalidRebalance) {
+		t.Errorf("received '%v' expected '%v'", err, errInvalidRebalance)
+	}
+}
+
+func TestSizeRebalanceTarget(t *testing.T) {
+	t.Parallel()
+	_, buy := newRebalanceSignals()
+	cs := &exchange.Settings{TakerFee: decimal.NewFromFloat(0.25)}
+	if sizeRebalanceTarget(buy, decimal.Zero, cs) {
+		t.Error("expected zero proceeds to not fund a buy")
+	}
+	// 1000 proceeds funds 8 at a price of 100 with a 25% fee
+	if !sizeRebalanceTarget(buy, decimal.NewFromInt(1000), cs) {
+		t.Fatal("expected proceeds to fund a buy")
+	}
+	if !buy.GetAmount().Equal(decimal.NewFromInt(8)) {
+		t.Errorf("received '%v' expected '%v'", buy.GetAmount(), 8)
+	}
+
+	// a smaller amount set by the strategy is kept
+	buy.Amount = decimal.NewFromInt(2)
+	if !sizeRebalanceTarget(buy, decimal.NewFromInt(1000), cs) {
+		t.Fatal("expected proceeds to fund a buy")
+	}
+	if !buy.GetAmount().Equal(decimal.NewFromInt(2)) {
+		t.Errorf("received '%v' expected '%v'", buy.GetAmount(), 2)
+	}
+
+	zeroFee := decimal.Zero
+	buy.Amount = decimal.Zero
+	buy.FeeOverride = &zeroFee
+	if !sizeRebalanceTarget(buy, decimal.NewFromInt(1000), cs) {
+		t.Fatal("expected proceeds to fund a buy")
+	}
+	if !buy.GetAmount().Equal(decimal.NewFromInt(10)) {
+		t.Errorf("received '%v' expected '%v'", buy.GetAmount(), 10)
+	}
+}
+
+func TestRollBackRebalanceLeg(t *testing.T) {
+	t.Parallel()
+	base, err := funding.CreateItem(testExchange, asset.Spot, currency.BTC, decimal.NewFromInt(1), decimal.Zero)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	quote, err := funding.CreateItem(testExchange, asset.Spot, currency.USDT, decimal.NewFromInt(1000), decimal.Zero)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	pair, err := funding.CreatePair(base, quote)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	em := engine.SetupExchangeManager()
+	exch, err := em.NewExchangeByName(testExchange)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	exch.SetDefaults()
+	em.Add(exch)
+	om, err := engine.SetupOrderManager(em, &engine.CommunicationManager{}, &sync.WaitGroup{}, false, false, 0)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	err = om.Start()
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	sell, _ := newRebalanceSignals()
+	submit := &gctorder.Submit{
+		Exchange:  testExchange,
+		AssetType: asset.Spot,
+		Pair:      sell.Pair(),
+		Side:      gctorder.Sell,
+		Type:      gctorder.Market,
+		Amount:    1,
+		Price:     900,
+	}
+	submitResponse, err := submit.DeriveSubmitResponse("1337")
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	submitResponse.Status = gctorder.Filled
+	_, err = om.SubmitFakeOrder(submit, submitResponse, false)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	f := &fill.Fill{
+		Base:      sell.Base,
+		Direction: gctorder.Sell,
+		Amount:    decimal.NewFromInt(1),
+		Order:     &gctorder.Detail{Exchange: testExchange, OrderID: "1337"},
+	}
+	err = rollBackRebalanceLeg(f, pair, om, decimal.NewFromInt(1), decimal.NewFromInt(1337))
+	if err == nil {
+		t.Error("expected error reverting more proceeds than available")
+	}
+	if f.IsRolledBack() {
+		t.Error("expected failed roll back to leave the fill untouched")
+	}
+
+	err = rollBackRebalanceLeg(f, pair, om, decimal.NewFromInt(1), decimal.NewFromInt(900))
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	if !f.IsRolledBack() || f.GetDirection() != gctorder.CouldNotSell || f.GetOrder() != nil {
+		t.Errorf("received '%v' expected '%v'", f.GetDirection(), gctorder.CouldNotSell)
+	}
+	if !pair.BaseAvailable().Equal(decimal.NewFromInt(2)) {
+		t.Errorf("received '%v' expected '%v'", pair.BaseAvailable(), 2)
+	}
+	if !pair.QuoteAvailable().Equal(decimal.NewFromInt(100)) {
+		t.Errorf("received '%v' expected '%v'", pair.QuoteAvailable(), 100)
+	}
+	od, err := om.GetByExchangeAndID(testExchange, "1337")
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	if od.Status != gctorder.Cancelled {
+		t.Errorf("received '%v' expected '%v'", od.Status, gctorder.Cancelled)
+	}
+
+	// an order missing from the order manager cannot be rolled back
+	f = &fill.Fill{
+		Base:      sell.Base,
+		Direction: gctorder.Sell,
+		Amount:    decimal.NewFromInt(1),
+		Order:     &gctorder.Detail{Exchange: testExchange, OrderID: "1338"},
+	}
+	err = rollBackRebalanceLeg(f, pair, om, decimal.Zero, decimal.Zero)
+	if !errors.Is(err, engine.ErrOrderNotFound) {
+		t.Errorf("received '%v' expected '%v'", err, engine.ErrOrderNotFound)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"path/filepath"
 	"runtime"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -13,6 +14,7 @@ import (
 	"github.com/shopspring/decimal"
 	"github.com/thrasher-corp/gocryptotrader/backtester/common"
 	"github.com/thrasher-corp/gocryptotrader/backtester/config"
+	"github.com/thrasher-corp/gocryptotrader/backtester/data"
 	"github.com/thrasher-corp/gocryptotrader/backtester/data/kline"
 	"github.com/thrasher-corp/gocryptotrader/backtester/data/kline/api"
 	"github.com/thrasher-corp/gocryptotrader/backtester/data/kline/csv"
@@ -48,6 +50,10 @@ func NewFromConfig(cfg *config.Config, templatePath, output string, verbose bool
 	if cfg == nil {
 		return nil, errNilConfig
 	}
+	configHash, err := cfg.Hash()
+	if err != nil {
+		return nil, err
+	}
 	bt, err := New()
 	if err != nil {
 		return nil, err
@@ -339,6 +345,31 @@ func NewFromConfig(cfg *config.Config, templatePath, output string, verbose bool
 		}
 	}
 
+	if len(cfg.FundingSettings.CapitalFlows) > 0 {
+		flows := make([]funding.CapitalFlow, len(cfg.FundingSettings.CapitalFlows))
+		for i := range cfg.FundingSettings.CapitalFlows {
+			flows[i] = funding.CapitalFlow{
+				Time:       cfg.FundingSettings.CapitalFlows[i].Time,
+				Exchange:   cfg.FundingSettings.CapitalFlows[i].ExchangeName,
+				Asset:      cfg.FundingSettings.CapitalFlows[i].Asset,
+				Currency:   cfg.FundingSettings.CapitalFlows[i].Currency,
+				PairedWith: cfg.FundingSettings.CapitalFlows[i].PairedWith,
+				Amount:     cfg.FundingSettings.CapitalFlows[i].Amount,
+			}
+		}
+		err = funds.SetCapitalFlows(flows)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if cfg.FundingSettings.UseQuoteFundingBasket {
+		err = funds.SetQuoteFundingBasket(cfg.FundingSettings.QuoteFundingPriority)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	bt.Funding = funds
 	var p *portfolio.Portfolio
 	p, err = portfolio.Setup(sizeManager, portfolioRisk, cfg.StatisticSettings.RiskFreeRate)
@@ -350,6 +381,10 @@ func NewFromConfig(cfg *config.Config, templatePath, output string, verbose bool
 	if err != nil {
 		return nil, err
 	}
+	bt.OpposingSignalPolicy = cfg.StrategySettings.OpposingSignalPolicy
+	bt.SignalPriority = cfg.StrategySettings.SimultaneousSignalPriority
+	bt.FirstCandlePolicy = cfg.StrategySettings.FirstCandlePolicy
+	bt.EndOfDataPolicy = cfg.StrategySettings.EndOfDataPolicy
 	bt.MetaData.Strategy = bt.Strategy.Name()
 	bt.Strategy.SetDefaults()
 
@@ -366,6 +401,21 @@ func NewFromConfig(cfg *config.Config, templatePath, output string, verbose bool
 		StrategyGoal:                cfg.Goal,
 		ExchangeAssetPairStatistics: make(map[string]map[asset.Item]map[currency.Pair]*statistics.CurrencyPairStatistic),
 		RiskFreeRate:                cfg.StatisticSettings.RiskFreeRate,
+		VolatilityRegimeWindow:      cfg.StatisticSettings.VolatilityRegimeWindow,
+		VolatilityRegimeCount:       cfg.StatisticSettings.VolatilityRegimeCount,
+		CostBasisMethod:             cfg.StatisticSettings.CostBasisMethod,
+		TradingCalendar:             cfg.StatisticSettings.TradingCalendar,
+		RecordReturnsSeries:         cfg.StatisticSettings.RecordReturnsSeries,
+		RecordNoTradeReasons:        cfg.StatisticSettings.RecordNoTradeReasons,
+		RecordMarketConditions:      cfg.StatisticSettings.RecordMarketConditions,
+		RollingBetaWindow:           cfg.StatisticSettings.RollingBetaWindow,
+		ValueAtRiskConfidenceLevels: cfg.StatisticSettings.ValueAtRiskConfidenceLevels,
+		AutocorrelationLags:         cfg.StatisticSettings.AutocorrelationLags,
+		PerformanceFeeRate:          cfg.StatisticSettings.PerformanceFeePercent,
+		PerformanceFeeInterval:      time.Duration(cfg.StatisticSettings.PerformanceFeeIntervalDays) * time.Hour * 24,
+		WashTradeWindow:             time.Duration(cfg.StatisticSettings.WashTradeWindowMinutes) * time.Minute,
+		WashTradePriceTolerance:     cfg.StatisticSettings.WashTradePriceTolerancePercent,
+		CapacityParticipation:       cfg.StatisticSettings.CapacityParticipationPercent,
 		CandleInterval:              cfg.DataSettings.Interval,
 		FundManager:                 bt.Funding,
 	}
@@ -412,11 +462,49 @@ func NewFromConfig(cfg *config.Config, templatePath, output string, verbose bool
 	}
 
 	bt.Exchange = &e
+	stats.Manifest = statistics.NewRunManifest(configHash, cfg.DataSettings.Interval, cfg.DataSettings.DataType, e.CurrencySettings)
 	for i := range e.CurrencySettings {
 		err = p.SetupCurrencySettingsMap(&e.CurrencySettings[i])
 		if err != nil {
 			return nil, err
 		}
+		for j := range e.CurrencySettings[i].Downtime {
+			stats.DowntimePeriods = append(stats.DowntimePeriods, statistics.DowntimePeriod{
+				Exchange: e.CurrencySettings[i].Exchange.GetName(),
+				Asset:    e.CurrencySettings[i].Asset,
+				Pair:     e.CurrencySettings[i].Pair,
+				Start:    e.CurrencySettings[i].Downtime[j].Start,
+				End:      e.CurrencySettings[i].Downtime[j].End,
+			})
+		}
+		tags := make([]string, 0, len(e.CurrencySettings[i].TagAllocations))
+		for tag := range e.CurrencySettings[i].TagAllocations {
+			tags = append(tags, tag)
+		}
+		sort.Strings(tags)
+		for j := range tags {
+			stats.TagAllocations = append(stats.TagAllocations, statistics.TagAllocation{
+				Exchange: e.CurrencySettings[i].Exchange.GetName(),
+				Asset:    e.CurrencySettings[i].Asset,
+				Pair:     e.CurrencySettings[i].Pair,
+				Tag:      tags[j],
+				Percent:  e.CurrencySettings[i].TagAllocations[tags[j]],
+			})
+		}
+		accounts := make([]string, 0, len(e.CurrencySettings[i].Accounts))
+		for account := range e.CurrencySettings[i].Accounts {
+			accounts = append(accounts, account)
+		}
+		sort.Strings(accounts)
+		for j := range accounts {
+			stats.AccountAllocations = append(stats.AccountAllocations, statistics.AccountAllocation{
+				Exchange: e.CurrencySettings[i].Exchange.GetName(),
+				Asset:    e.CurrencySettings[i].Asset,
+				Pair:     e.CurrencySettings[i].Pair,
+				Account:  accounts[j],
+				Percent:  e.CurrencySettings[i].Accounts[accounts[j]],
+			})
+		}
 	}
 	bt.Portfolio = p
 
@@ -470,9 +558,14 @@ func (bt *BackTest) setupExchangeSettings(cfg *config.Config) (exchange.Exchange
 		}
 
 		bt.Datas.SetDataForCurrency(exchangeName, a, pair, klineData)
+		executionData, err := bt.loadExecutionData(cfg, exch, pair, a, &cfg.CurrencySettings[i])
+		if err != nil {
+			return resp, err
+		}
 
 		var makerFee, takerFee decimal.Decimal
-		if cfg.CurrencySettings[i].MakerFee != nil && cfg.CurrencySettings[i].MakerFee.GreaterThan(decimal.Zero) {
+		if cfg.CurrencySettings[i].MakerFee != nil && !cfg.CurrencySettings[i].MakerFee.IsZero() {
+			// a negative maker fee is a rebate paid by the exchange
 			makerFee = *cfg.CurrencySettings[i].MakerFee
 		}
 		if cfg.CurrencySettings[i].TakerFee != nil && cfg.CurrencySettings[i].TakerFee.GreaterThan(decimal.Zero) {
@@ -547,6 +640,9 @@ func (bt *BackTest) setupExchangeSettings(cfg *config.Config) (exchange.Exchange
 				cfg.CurrencySettings[i].ShowExchangeOrderLimitWarning = true
 			}
 		}
+		if cfg.CurrencySettings[i].PriceTickSize.IsPositive() {
+			limits.PriceStepIncrementSize = cfg.CurrencySettings[i].PriceTickSize.InexactFloat64()
+		}
 		var lev exchange.Leverage
 		if cfg.CurrencySettings[i].FuturesDetails != nil {
 			lev = exchange.Leverage{
@@ -555,28 +651,164 @@ func (bt *BackTest) setupExchangeSettings(cfg *config.Config) (exchange.Exchange
 				MaximumOrdersWithLeverageRatio: cfg.CurrencySettings[i].FuturesDetails.Leverage.MaximumOrdersWithLeverageRatio,
 			}
 		}
+		var session *exchange.TradingSession
+		if cfg.CurrencySettings[i].TradingSession != nil {
+			openTime, closeTime, loc, err := cfg.CurrencySettings[i].TradingSession.Parse()
+			if err != nil {
+				return resp, err
+			}
+			session = &exchange.TradingSession{
+				Days:                cfg.CurrencySettings[i].TradingSession.Days,
+				Open:                openTime,
+				Close:               closeTime,
+				Location:            loc,
+				DeferOffHoursOrders: cfg.CurrencySettings[i].TradingSession.DeferOffHoursOrders,
+			}
+		}
+		var latency *exchange.LatencyDistribution
+		if cfg.CurrencySettings[i].LatencyDistribution != nil {
+			l := cfg.CurrencySettings[i].LatencyDistribution
+			latency = &exchange.LatencyDistribution{
+				Mean:              time.Duration(l.MeanMilliseconds) * time.Millisecond,
+				StandardDeviation: time.Duration(l.StandardDeviationMilliseconds) * time.Millisecond,
+				Seed:              l.Seed,
+			}
+			for j := range l.Histogram {
+				latency.Histogram = append(latency.Histogram, exchange.LatencyBucket{
+					Latency: time.Duration(l.Histogram[j].LatencyMilliseconds) * time.Millisecond,
+					Weight:  l.Histogram[j].Weight,
+				})
+			}
+		}
+		var feeTiers []exchange.FeeTier
+		for j := range cfg.CurrencySettings[i].FeeTiers {
+			feeTiers = append(feeTiers, exchange.FeeTier{
+				VolumeThreshold: cfg.CurrencySettings[i].FeeTiers[j].VolumeThreshold,
+				MakerFee:        cfg.CurrencySettings[i].FeeTiers[j].MakerBasisPoints.Div(decimal.NewFromInt(10000)),
+				TakerFee:        cfg.CurrencySettings[i].FeeTiers[j].TakerBasisPoints.Div(decimal.NewFromInt(10000)),
+			})
+		}
+		var slippageTiers []slippage.Tier
+		for j := range cfg.CurrencySettings[i].SlippageTiers {
+			slippageTiers = append(slippageTiers, slippage.Tier{
+				MinimumNotional: cfg.CurrencySettings[i].SlippageTiers[j].MinimumNotional,
+				BasisPoints:     cfg.CurrencySettings[i].SlippageTiers[j].BasisPoints,
+			})
+		}
+		var stopOut *exchange.DrawdownStopOut
+		if cfg.CurrencySettings[i].DrawdownStopOut != nil {
+			stopOut = &exchange.DrawdownStopOut{
+				MaximumDrawdownPercent: cfg.CurrencySettings[i].DrawdownStopOut.MaximumDrawdownPercent,
+				Scope:                  strings.ToLower(cfg.CurrencySettings[i].DrawdownStopOut.Scope),
+			}
+		}
+		var losingStreak *exchange.LosingStreakBreaker
+		if cfg.CurrencySettings[i].LosingStreakBreaker != nil {
+			losingStreak = &exchange.LosingStreakBreaker{
+				ConsecutiveLosses: cfg.CurrencySettings[i].LosingStreakBreaker.ConsecutiveLosses,
+				Cooldown:          time.Duration(cfg.CurrencySettings[i].LosingStreakBreaker.CooldownMinutes) * time.Minute,
+			}
+		}
+		rates, err := cfg.CurrencySettings[i].GetFundingRates()
+		if err != nil {
+			return resp, err
+		}
+		fundingRate := fundingRatesFromConfig(exchangeName, a, pair, rates)
+		var initialBaseEntryPrice decimal.Decimal
+		if cfg.CurrencySettings[i].SpotDetails != nil && cfg.CurrencySettings[i].SpotDetails.InitialBaseEntryPrice != nil {
+			initialBaseEntryPrice = *cfg.CurrencySettings[i].SpotDetails.InitialBaseEntryPrice
+		}
 		resp.CurrencySettings = append(resp.CurrencySettings, exchange.Settings{
-			Exchange:                  exch,
-			MinimumSlippageRate:       cfg.CurrencySettings[i].MinimumSlippagePercent,
-			MaximumSlippageRate:       cfg.CurrencySettings[i].MaximumSlippagePercent,
-			Pair:                      pair,
-			Asset:                     a,
-			MakerFee:                  makerFee,
-			TakerFee:                  takerFee,
-			UseRealOrders:             realOrders,
-			BuySide:                   buyRule,
-			SellSide:                  sellRule,
-			Leverage:                  lev,
-			Limits:                    limits,
-			SkipCandleVolumeFitting:   cfg.CurrencySettings[i].SkipCandleVolumeFitting,
-			CanUseExchangeLimits:      cfg.CurrencySettings[i].CanUseExchangeLimits,
-			UseExchangePNLCalculation: cfg.CurrencySettings[i].UseExchangePNLCalculation,
+			Exchange:                            exch,
+			MinimumSlippageRate:                 cfg.CurrencySettings[i].MinimumSlippagePercent,
+			MaximumSlippageRate:                 cfg.CurrencySettings[i].MaximumSlippagePercent,
+			MaxSlippagePercent:                  cfg.CurrencySettings[i].SlippageCapPercent,
+			SlippageTiers:                       slippageTiers,
+			FeeTiers:                            feeTiers,
+			SlippageAsymmetry:                   cfg.CurrencySettings[i].SlippageAsymmetry,
+			AllowSlippagePriceImprovement:       cfg.CurrencySettings[i].AllowSlippagePriceImprovement,
+			LiquidationSlippagePercent:          cfg.CurrencySettings[i].LiquidationSlippagePercent,
+			LiquidationFeeRate:                  cfg.CurrencySettings[i].LiquidationFeeRate,
+			BorrowRatePercent:                   cfg.CurrencySettings[i].BorrowRatePercent,
+			FundingRate:                         fundingRate,
+			ExtremeVolatilityThreshold:          cfg.CurrencySettings[i].ExtremeVolatilityThreshold,
+			ExtremeVolatilityBehaviour:          strings.ToLower(cfg.CurrencySettings[i].ExtremeVolatilityBehaviour),
+			ExtremeVolatilitySlippageMultiplier: cfg.CurrencySettings[i].ExtremeVolatilitySlippageMultiplier,
+			MaximumCapitalAllocation:            cfg.CurrencySettings[i].MaximumCapitalAllocation,
+			TagAllocations:                      cfg.CurrencySettings[i].TagAllocations,
+			Accounts:                            cfg.CurrencySettings[i].Accounts,
+			MinimumHoldingPeriod:                time.Duration(cfg.CurrencySettings[i].MinimumHoldingPeriodMinutes) * time.Minute,
+			MaxPositionAge:                      time.Duration(cfg.CurrencySettings[i].MaxPositionAgeMinutes) * time.Minute,
+			Pair:                                pair,
+			Asset:                               a,
+			MakerFee:                            makerFee,
+			TakerFee:                            takerFee,
+			UseRealOrders:                       realOrders,
+			BuySide:                             buyRule,
+			SellSide:                            sellRule,
+			Leverage:                            lev,
+			Limits:                              limits,
+			SkipCandleVolumeFitting:             cfg.CurrencySettings[i].SkipCandleVolumeFitting,
+			ExecutionData:                       executionData,
+			VolumeFitWindow:                     cfg.CurrencySettings[i].VolumeFitWindow,
+			AllowPartialFills:                   cfg.CurrencySettings[i].AllowPartialFills,
+			VolumeExceedBehaviour:               strings.ToLower(cfg.CurrencySettings[i].VolumeExceedBehaviour),
+			NetOfCostTargets:                    cfg.CurrencySettings[i].NetOfCostTargets,
+			TradingSession:                      session,
+			Downtime:                            exchange.CalculateDowntimePeriods(klineData.RangeHolder, cfg.CurrencySettings[i].DowntimeGapCandles),
+			Latency:                             latency,
+			ExecutionDelay:                      cfg.CurrencySettings[i].ExecutionDelayCandles,
+			DrawdownStopOut:                     stopOut,
+			LosingStreakBreaker:                 losingStreak,
+			OrderbookImbalanceDepth:             cfg.CurrencySettings[i].OrderbookImbalanceDepth,
+			CrossedOrderbookBehaviour:           strings.ToLower(cfg.CurrencySettings[i].CrossedOrderbookBehaviour),
+			MaximumOrderbookAge:                 time.Duration(cfg.CurrencySettings[i].MaximumOrderbookAgeSeconds) * time.Second,
+			DepthExhaustionBehaviour:            strings.ToLower(cfg.CurrencySettings[i].DepthExhaustionBehaviour),
+			SubmissionRetries:                   cfg.CurrencySettings[i].SubmissionRetries,
+			SubmissionRetryBackoff:              time.Duration(cfg.CurrencySettings[i].SubmissionRetryBackoffMilliseconds) * time.Millisecond,
+			FeeShortfallBehaviour:               strings.ToLower(cfg.CurrencySettings[i].FeeShortfallBehaviour),
+			SlippageOverfillBehaviour:           strings.ToLower(cfg.CurrencySettings[i].SlippageOverfillBehaviour),
+			RebatePolicy:                        strings.ToLower(cfg.CurrencySettings[i].RebatePolicy),
+			FillTimeAssignment:                  strings.ToLower(cfg.CurrencySettings[i].FillTimeAssignment),
+			SignalToFillConvention:              strings.ToLower(cfg.CurrencySettings[i].SignalToFillConvention),
+			FlipPolicy:                          strings.ToLower(cfg.CurrencySettings[i].FlipPolicy),
+			InitialBaseEntryPrice:               initialBaseEntryPrice,
+			RecordFundingLedger:                 cfg.CurrencySettings[i].RecordFundingLedger,
+			RoundQuoteFunding:                   cfg.CurrencySettings[i].RoundQuoteFunding,
+			QuotePrecision:                      cfg.CurrencySettings[i].QuotePrecision,
+			RoundingMode:                        cfg.StrategySettings.RoundingMode,
+			CanUseExchangeLimits:                cfg.CurrencySettings[i].CanUseExchangeLimits,
+			UseExchangePNLCalculation:           cfg.CurrencySettings[i].UseExchangePNLCalculation,
 		})
 	}
 
 	return resp, nil
 }
 
+// fundingRatesFromConfig converts the currency's configured funding rates
+// into the funding rate history settled against its open futures positions.
+// Returns nil when there are no funding rates
+func fundingRatesFromConfig(exch string, a asset.Item, pair currency.Pair, rates []config.FundingRate) *gctorder.FundingRates {
+	if len(rates) == 0 {
+		return nil
+	}
+	resp := &gctorder.FundingRates{
+		Exchange:     exch,
+		Asset:        a,
+		Pair:         pair,
+		StartDate:    rates[0].Time,
+		EndDate:      rates[len(rates)-1].Time,
+		FundingRates: make([]gctorder.FundingRate, len(rates)),
+	}
+	for i := range rates {
+		resp.FundingRates[i] = gctorder.FundingRate{
+			Time: rates[i].Time,
+			Rate: rates[i].Rate,
+		}
+	}
+	return resp
+}
+
 func (bt *BackTest) loadExchangePairAssetBase(exch string, base, quote currency.Code, ai asset.Item) (gctexchange.IBotExchange, currency.Pair, asset.Item, error) {
 	e, err := bt.exchangeManager.GetExchangeByName(exch)
 	if err != nil {
@@ -629,6 +861,53 @@ func getFees(ctx context.Context, exch gctexchange.IBotExchange, fPair currency.
 // loadData will create kline data from the sources defined in start config files. It can exist from databases, csv or API endpoints
 // it can also be generated from trade data which will be converted into kline data
 func (bt *BackTest) loadData(cfg *config.Config, exch gctexchange.IBotExchange, fPair currency.Pair, a asset.Item, isUSDTrackingPair bool) (*kline.DataFromKline, error) {
+	resp, err := bt.loadCandles(cfg, exch, fPair, a, isUSDTrackingPair)
+	if err != nil || cfg.DataSettings.LiveData != nil {
+		return resp, err
+	}
+	bt.Reports.AddKlineItem(&resp.Item)
+	return resp, nil
+}
+
+// loadExecutionData loads the currency's finer execution interval candles
+// from the same data source as the signal data, reading csv data from the
+// currency's execution csv file. The signal data must be loaded first, as it
+// applies any inclusive end date. Execution candles are not reported.
+// Returns nil when the currency has no execution interval
+func (bt *BackTest) loadExecutionData(cfg *config.Config, exch gctexchange.IBotExchange, fPair currency.Pair, a asset.Item, cs *config.CurrencySettings) (data.Handler, error) {
+	if cs.ExecutionInterval <= 0 {
+		return nil, nil
+	}
+	if cfg.DataSettings.LiveData != nil {
+		return nil, errLiveExecutionData
+	}
+	executionCfg := *cfg
+	executionCfg.DataSettings.Interval = cs.ExecutionInterval
+	if cfg.DataSettings.CSVData != nil {
+		csvData := *cfg.DataSettings.CSVData
+		csvData.FullPath = cs.ExecutionCSVPath
+		executionCfg.DataSettings.CSVData = &csvData
+	}
+	if cfg.DataSettings.APIData != nil {
+		apiData := *cfg.DataSettings.APIData
+		apiData.InclusiveEndDate = false
+		executionCfg.DataSettings.APIData = &apiData
+	}
+	if cfg.DataSettings.DatabaseData != nil {
+		databaseData := *cfg.DataSettings.DatabaseData
+		databaseData.InclusiveEndDate = false
+		executionCfg.DataSettings.DatabaseData = &databaseData
+	}
+	executionData, err := bt.loadCandles(&executionCfg, exch, fPair, a, false)
+	if err != nil {
+		return nil, err
+	}
+	return executionData, nil
+}
+
+// loadCandles loads and validates kline data from the data source
+// defined in the config at the config's interval
+func (bt *BackTest) loadCandles(cfg *config.Config, exch gctexchange.IBotExchange, fPair currency.Pair, a asset.Item, isUSDTrackingPair bool) (*kline.DataFromKline, error) {
 	if exch == nil {
 		return nil, engine.ErrExchangeNotFound
 	}
@@ -794,7 +1073,6 @@ func (bt *BackTest) loadData(cfg *config.Config, exch gctexchange.IBotExchange,
 	if err != nil {
 		return nil, err
 	}
-	bt.Reports.AddKlineItem(&resp.Item)
 	return resp, nil
 }
 
@@ -897,10 +1175,15 @@ func NewBacktesterFromConfigs(strategyCfg *config.Config, backtesterCfg *config.
 	if err := strategyCfg.Validate(); err != nil {
 		return nil, err
 	}
+	if err := backtesterCfg.Checkpoint.Validate(); err != nil {
+		return nil, err
+	}
 	bt, err := NewFromConfig(strategyCfg, backtesterCfg.Report.TemplatePath, backtesterCfg.Report.OutputPath, backtesterCfg.Verbose)
 	if err != nil {
 		return nil, err
 	}
+	bt.CheckpointInterval = backtesterCfg.Checkpoint.Interval
+	bt.CheckpointPath = backtesterCfg.Checkpoint.Path
 	err = bt.SetupMetaData()
 	if err != nil {
 		return nil, err
@@ -2,7 +2,9 @@ package engine
 
 import (
 	"errors"
+	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
@@ -401,6 +403,13 @@ func TestNewBacktesterFromConfigs(t *testing.T) {
 		t.Errorf("received '%v' expected '%v'", err, gctcommon.ErrNilPointer)
 	}
 
+	dc.Checkpoint.Interval = -1
+	_, err = NewBacktesterFromConfigs(cfg, dc)
+	if err == nil {
+		t.Error("expected invalid checkpoint error")
+	}
+	dc.Checkpoint.Interval = 0
+
 	bt, err := NewBacktesterFromConfigs(cfg, dc)
 	if !errors.Is(err, nil) {
 		t.Errorf("received '%v' expected '%v'", err, nil)
@@ -409,3 +418,104 @@ func TestNewBacktesterFromConfigs(t *testing.T) {
 		t.Errorf("received '%v' expected '%v'", bt.MetaData.DateLoaded, "a date")
 	}
 }
+
+func TestLoadExecutionData(t *testing.T) {
+	t.Parallel()
+	bt := BackTest{
+		Reports:   &report.Data{},
+		Statistic: &statistics.Statistic{},
+	}
+	cp := currency.NewPair(currency.BTC, currency.USDT)
+	em := engine.ExchangeManager{}
+	exch, err := em.NewExchangeByName("Binance")
+	if err != nil {
+		t.Fatal(err)
+	}
+	exch.SetDefaults()
+	b := exch.GetBase()
+	b.CurrencyPairs.Pairs = make(map[asset.Item]*currency.PairStore)
+	b.CurrencyPairs.Pairs[asset.Spot] = &currency.PairStore{
+		Available:     currency.Pairs{cp},
+		Enabled:       currency.Pairs{cp},
+		AssetEnabled:  convert.BoolPtr(true),
+		ConfigFormat:  &currency.PairFormat{Uppercase: true},
+		RequestFormat: &currency.PairFormat{Uppercase: true}}
+	cfg := &config.Config{
+		DataSettings: config.DataSettings{
+			DataType: common.CandleStr,
+			Interval: gctkline.OneHour,
+			CSVData:  &config.CSVData{FullPath: "signal.csv"},
+		},
+	}
+	cs := &config.CurrencySettings{}
+	d, err := bt.loadExecutionData(cfg, exch, cp, asset.Spot, cs)
+	if !errors.Is(err, nil) {
+		t.Errorf("received '%v' expected '%v'", err, nil)
+	}
+	if d != nil {
+		t.Errorf("received '%v' expected '%v'", d, nil)
+	}
+
+	// an hour of fifteen minute candles
+	var rows string
+	for i := int64(0); i < 4; i++ {
+		rows += strconv.FormatInt(1546300800+i*900, 10) + ",100,1337,1338,1336,1337\n"
+	}
+	cs.ExecutionInterval = gctkline.FifteenMin
+	cs.ExecutionCSVPath = filepath.Join(t.TempDir(), "execution.csv")
+	err = os.WriteFile(cs.ExecutionCSVPath, []byte(rows), 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	d, err = bt.loadExecutionData(cfg, exch, cp, asset.Spot, cs)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	if candles := d.List(); len(candles) != 4 || candles[0].GetInterval() != gctkline.FifteenMin {
+		t.Errorf("received '%v' candles expected '%v' of interval '%v'", len(candles), 4, gctkline.FifteenMin)
+	}
+	if cfg.DataSettings.Interval != gctkline.OneHour || cfg.DataSettings.CSVData.FullPath != "signal.csv" {
+		t.Error("expected the signal data settings to be unchanged")
+	}
+	if len(bt.Reports.(*report.Data).OriginalCandles) != 0 {
+		t.Error("expected execution candles to not be reported")
+	}
+
+	cfg.DataSettings.CSVData = nil
+	cfg.DataSettings.LiveData = &config.LiveData{}
+	_, err = bt.loadExecutionData(cfg, exch, cp, asset.Spot, cs)
+	if !errors.Is(err, errLiveExecutionData) {
+		t.Errorf("received '%v' expected '%v'", err, errLiveExecutionData)
+	}
+}
+
+func TestFundingRatesFromConfig(t *testing.T) {
+	t.Parallel()
+	cp := currency.NewPair(currency.BTC, currency.USDT)
+	if fr := fundingRatesFromConfig(testExchange, asset.Futures, cp, nil); fr != nil {
+		t.Errorf("received '%v' expected '%v'", fr, nil)
+	}
+	tt := time.Date(2022, 1, 1, 8, 0, 0, 0, time.UTC)
+	rates := []config.FundingRate{
+		{Time: tt, Rate: decimal.NewFromFloat(0.0001)},
+		{Time: tt.Add(time.Hour * 8), Rate: decimal.NewFromFloat(-0.0002)},
+	}
+	fr := fundingRatesFromConfig(testExchange, asset.Futures, cp, rates)
+	if fr == nil {
+		t.Fatal("expected funding rates")
+	}
+	if fr.Exchange != testExchange || fr.Asset != asset.Futures || !fr.Pair.Equal(cp) {
+		t.Errorf("received '%v' '%v' '%v' expected '%v' '%v' '%v'", fr.Exchange, fr.Asset, fr.Pair, testExchange, asset.Futures, cp)
+	}
+	if !fr.StartDate.Equal(tt) || !fr.EndDate.Equal(tt.Add(time.Hour*8)) {
+		t.Errorf("received '%v' '%v' expected '%v' '%v'", fr.StartDate, fr.EndDate, tt, tt.Add(time.Hour*8))
+	}
+	if len(fr.FundingRates) != len(rates) {
+		t.Fatalf("received '%v' expected '%v'", len(fr.FundingRates), len(rates))
+	}
+	for i := range rates {
+		if !fr.FundingRates[i].Time.Equal(rates[i].Time) || !fr.FundingRates[i].Rate.Equal(rates[i].Rate) {
+			t.Errorf("position %v received '%v' expected '%v'", i, fr.FundingRates[i], rates[i])
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package engine
+
+import (
+	"sort"
+
+	"github.com/thrasher-corp/gocryptotrader/backtester/data"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/signal"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+)
+
+const (
+	// SignalPriorityCurrency processes simultaneous signals ordered by
+	// exchange, asset and currency pair. This is the default
+	SignalPriorityCurrency = "currency"
+	// SignalPriorityStrength processes simultaneous signals
+	// with the largest strength first
+	SignalPriorityStrength = "signal-strength"
+	// SignalPriorityAmount processes simultaneous signals requesting the
+	// largest amount valued at their close price first. Signals without an
+	// amount are sized by the portfolio so are processed last
+	SignalPriorityAmount = "allocated-amount"
+)
+
+// lessByCurrency returns whether the first exchange, asset and pair sorts
+// before the second, giving a deterministic order independent of map
+// iteration
+func lessByCurrency(aExch, aAsset, aPair, bExch, bAsset, bPair string) bool {
+	if aExch != bExch {
+		return aExch < bExch
+	}
+	if aAsset != bAsset {
+		return aAsset < bAsset
+	}
+	return aPair < bPair
+}
+
+// sortDataHandlers orders data handlers by exchange, asset and currency pair
+// so that strategies receive simultaneous data events in the same order
+// every run
+func sortDataHandlers(handlers []data.Handler) {
+	sort.SliceStable(handlers, func(i, j int) bool {
+		a, b := handlers[i].Latest(), handlers[j].Latest()
+		return lessByCurrency(a.GetExchange(), a.GetAssetType().String(), a.Pair().String(),
+			b.GetExchange(), b.GetAssetType().String(), b.Pair().String())
+	})
+}
+
+// currencyDataHandler is a data handler alongside the exchange, asset and
+// currency pair it is stored under
+type currencyDataHandler struct {
+	exchange string
+	asset    asset.Item
+	pair     currency.Pair
+	handler  data.Handler
+}
+
+// sortedDataHandlers flattens data handlers ordered by exchange, asset and
+// currency pair. The map keys are used for sorting as handlers have no latest
+// event before their first offset, so that data events at each offset are
+// queued in the same order every run
+func sortedDataHandlers(handlers map[string]map[asset.Item]map[currency.Pair]data.Handler) []currencyDataHandler {
+	var resp []currencyDataHandler
+	for exch, assetMap := range handlers {
+		for a, pairMap := range assetMap {
+			for cp, d := range pairMap {
+				resp = append(resp, currencyDataHandler{
+					exchange: exch,
+					asset:    a,
+					pair:     cp,
+					handler:  d,
+				})
+			}
+		}
+	}
+	sort.Slice(resp, func(i, j int) bool {
+		return lessByCurrency(resp[i].exchange, resp[i].asset.String(), resp[i].pair.String(),
+			resp[j].exchange, resp[j].asset.String(), resp[j].pair.String())
+	})
+	return resp
+}
+
+// prioritiseSignals orders signals raised at the same offset by the signal
+// priority. As funding is reserved as each signal is processed, signals
+// earlier in the order are funded first when shared funding is tight. Ties
+// are broken by currency so that the order is reproducible
+func prioritiseSignals(signals []signal.Event, priority string) {
+	sort.SliceStable(signals, func(i, j int) bool {
+		a, b := signals[i], signals[j]
+		switch priority {
+		case SignalPriorityStrength:
+			if !a.GetStrength().Equal(b.GetStrength()) {
+				return a.GetStrength().GreaterThan(b.GetStrength())
+			}
+		case SignalPriorityAmount:
+			aValue, bValue := a.GetAmount().Mul(a.GetClosePrice()), b.GetAmount().Mul(b.GetClosePrice())
+			if !aValue.Equal(bValue) {
+				return aValue.GreaterThan(bValue)
+			}
+		}
+		return lessByCurrency(a.GetExchange(), a.GetAssetType().String(), a.Pair().String(),
+			b.GetExchange(), b.GetAssetType().String(), b.Pair().String())
+	})
+}
@@ -0,0 +1,150 @@
+package engine
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/thrasher-corp/gocryptotrader/backtester/data"
+	"github.com/thrasher-corp/gocryptotrader/backtester/data/kline"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/event"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/signal"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	gctkline "github.com/thrasher-corp/gocryptotrader/exchanges/kline"
+)
+
+func newPrioritySignal(pair currency.Pair, amount, price, strength int64) *signal.Signal {
+	return &signal.Signal{
+		Base: &event.Base{
+			Exchange:     testExchange,
+			AssetType:    asset.Spot,
+			CurrencyPair: pair,
+		},
+		Amount:     decimal.NewFromInt(amount),
+		ClosePrice: decimal.NewFromInt(price),
+		Strength:   decimal.NewFromInt(strength),
+	}
+}
+
+func TestPrioritiseSignals(t *testing.T) {
+	t.Parallel()
+	btc := currency.NewPair(currency.BTC, currency.USDT)
+	eth := currency.NewPair(currency.ETH, currency.USDT)
+	ltc := currency.NewPair(currency.LTC, currency.USDT)
+	newSignals := func() []signal.Event {
+		return []signal.Event{
+			newPrioritySignal(ltc, 10, 100, 1),
+			newPrioritySignal(eth, 1, 2000, 1),
+			newPrioritySignal(btc, 0, 30000, 3),
+		}
+	}
+	for _, tc := range []struct {
+		priority string
+		expected []currency.Pair
+	}{
+		{"", []currency.Pair{btc, eth, ltc}},
+		{SignalPriorityCurrency, []currency.Pair{btc, eth, ltc}},
+		// equal strengths fall back to currency order
+		{SignalPriorityStrength, []currency.Pair{btc, eth, ltc}},
+		// signals without an amount are processed last
+		{SignalPriorityAmount, []currency.Pair{eth, ltc, btc}},
+	} {
+		signals := newSignals()
+		prioritiseSignals(signals, tc.priority)
+		for i := range signals {
+			if !signals[i].Pair().Equal(tc.expected[i]) {
+				t.Errorf("priority '%v' position %v received '%v' expected '%v'", tc.priority, i, signals[i].Pair(), tc.expected[i])
+			}
+		}
+	}
+
+	signals := newSignals()
+	signals[0].(*signal.Signal).Strength = decimal.NewFromInt(5)
+	prioritiseSignals(signals, SignalPriorityStrength)
+	if !signals[0].Pair().Equal(ltc) || !signals[1].Pair().Equal(btc) {
+		t.Errorf("received '%v' '%v' expected '%v' '%v'", signals[0].Pair(), signals[1].Pair(), ltc, btc)
+	}
+}
+
+func TestSortDataHandlers(t *testing.T) {
+	t.Parallel()
+	newHandler := func(exch string, a asset.Item, pair currency.Pair) data.Handler {
+		d := &kline.DataFromKline{
+			Item: gctkline.Item{
+				Exchange: exch,
+				Pair:     pair,
+				Asset:    a,
+				Interval: gctkline.OneDay,
+				Candles: []gctkline.Candle{
+					{Time: time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC), Open: 1, High: 1, Low: 1, Close: 1, Volume: 1},
+				},
+			},
+		}
+		if err := d.Load(); err != nil {
+			t.Fatal(err)
+		}
+		d.Next()
+		return d
+	}
+	btc := currency.NewPair(currency.BTC, currency.USDT)
+	eth := currency.NewPair(currency.ETH, currency.USDT)
+	handlers := []data.Handler{
+		newHandler(testExchange, asset.Spot, eth),
+		newHandler(testExchange, asset.Futures, btc),
+		newHandler("binance", asset.Spot, eth),
+		newHandler(testExchange, asset.Spot, btc),
+	}
+	sortDataHandlers(handlers)
+	expected := []string{
+		"binance spot " + eth.String(),
+		testExchange + " futures " + btc.String(),
+		testExchange + " spot " + btc.String(),
+		testExchange + " spot " + eth.String(),
+	}
+	for i := range handlers {
+		l := handlers[i].Latest()
+		received := l.GetExchange() + " " + l.GetAssetType().String() + " " + l.Pair().String()
+		if received != expected[i] {
+			t.Errorf("position %v received '%v' expected '%v'", i, received, expected[i])
+		}
+	}
+}
+
+func TestSortedDataHandlers(t *testing.T) {
+	t.Parallel()
+	btc := currency.NewPair(currency.BTC, currency.USDT)
+	eth := currency.NewPair(currency.ETH, currency.USDT)
+	ltc := currency.NewPair(currency.LTC, currency.USDT)
+	d := data.HandlerPerCurrency{}
+	d.Setup()
+	// handlers without loaded data have no latest event so cannot be sorted
+	// by their events
+	d.SetDataForCurrency(testExchange, asset.Spot, ltc, &kline.DataFromKline{})
+	d.SetDataForCurrency(testExchange, asset.Futures, btc, &kline.DataFromKline{})
+	d.SetDataForCurrency("binance", asset.Spot, eth, &kline.DataFromKline{})
+	d.SetDataForCurrency(testExchange, asset.Spot, btc, &kline.DataFromKline{})
+	d.SetDataForCurrency(testExchange, asset.Spot, eth, &kline.DataFromKline{})
+	expected := []string{
+		"binance spot " + eth.String(),
+		testExchange + " futures " + btc.String(),
+		testExchange + " spot " + btc.String(),
+		testExchange + " spot " + eth.String(),
+		testExchange + " spot " + ltc.String(),
+	}
+	for x := 0; x < 10; x++ {
+		handlers := sortedDataHandlers(d.GetAllData())
+		if len(handlers) != len(expected) {
+			t.Fatalf("received '%v' expected '%v'", len(handlers), len(expected))
+		}
+		for i := range handlers {
+			received := handlers[i].exchange + " " + handlers[i].asset.String() + " " + handlers[i].pair.String()
+			if received != expected[i] {
+				t.Errorf("position %v received '%v' expected '%v'", i, received, expected[i])
+			}
+			if handlers[i].handler == nil {
+				t.Errorf("position %v received nil handler", i)
+			}
+		}
+	}
+}
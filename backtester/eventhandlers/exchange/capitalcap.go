@@ -0,0 +1,91 @@
+package exchange
+
+import (
+	"github.com/shopspring/decimal"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/fill"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/order"
+	"github.com/thrasher-corp/gocryptotrader/backtester/funding"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	gctorder "github.com/thrasher-corp/gocryptotrader/exchanges/order"
+)
+
+// isCapitalEntry returns whether the order increases the capital
+// consumed by its currency. Closing orders are always allowed
+func isCapitalEntry(o order.Event) bool {
+	if o.IsClosingPosition() || o.IsLiquidating() || o.GetDirection() == gctorder.ClosePosition {
+		return false
+	}
+	if o.GetAssetType().IsFutures() {
+		return true
+	}
+	return o.GetDirection().IsLong()
+}
+
+// getCapitalAllocation returns the capital currently consumed by the
+// exchange, asset and pair's open positions
+func (e *Exchange) getCapitalAllocation(exch string, a asset.Item, p currency.Pair) *capitalAllocation {
+	if e.capitalAllocations == nil {
+		e.capitalAllocations = make(map[string]map[asset.Item]map[currency.Pair]*capitalAllocation)
+	}
+	if e.capitalAllocations[exch] == nil {
+		e.capitalAllocations[exch] = make(map[asset.Item]map[currency.Pair]*capitalAllocation)
+	}
+	if e.capitalAllocations[exch][a] == nil {
+		e.capitalAllocations[exch][a] = make(map[currency.Pair]*capitalAllocation)
+	}
+	ca, ok := e.capitalAllocations[exch][a][p]
+	if !ok {
+		ca = &capitalAllocation{}
+		e.capitalAllocations[exch][a][p] = ca
+	}
+	return ca
+}
+
+// exceedsCapitalCap returns whether an entry order's estimated value would
+// take the capital consumed by its currency beyond the maximum allocation
+func (e *Exchange) exceedsCapitalCap(o order.Event, cs *Settings) (committed, requested decimal.Decimal, exceeded bool) {
+	if !cs.MaximumCapitalAllocation.IsPositive() || !isCapitalEntry(o) {
+		return decimal.Zero, decimal.Zero, false
+	}
+	committed = e.getCapitalAllocation(o.GetExchange(), o.GetAssetType(), o.Pair()).capital
+	requested = o.GetAmount().Mul(o.GetClosePrice())
+	if requested.IsZero() {
+		requested = o.GetAllocatedFunds()
+	}
+	return committed, requested, committed.Add(requested).GreaterThan(cs.MaximumCapitalAllocation)
+}
+
+// handleCapitalCapExceeded rejects an entry order which would
+// exceed the currency's maximum capital allocation
+func handleCapitalCapExceeded(o order.Event, f *fill.Fill, funds funding.IFundReleaser, cs *Settings, committed, requested decimal.Decimal) (fill.Event, error) {
+	f.CapitalCapBound = true
+	f.AppendReasonf("Order value %v with %v capital already committed exceeds maximum capital allocation of %v, rejected", requested, committed, cs.MaximumCapitalAllocation)
+	return f, allocateFundsPostOrder(f, funds, errCapitalCapExceeded, o.GetAmount(), o.GetAllocatedFunds(), decimal.Zero, decimal.Zero, decimal.Zero, cs)
+}
+
+// updateCapitalAllocation tracks the capital consumed by the currency's open
+// positions after a successful fill. Entries add the filled value, spot sales
+// release capital in proportion to the amount sold and closing futures orders
+// release all capital
+func (e *Exchange) updateCapitalAllocation(o order.Event, f fill.Event, cs *Settings) {
+	if !cs.MaximumCapitalAllocation.IsPositive() {
+		return
+	}
+	ca := e.getCapitalAllocation(o.GetExchange(), o.GetAssetType(), o.Pair())
+	switch {
+	case isCapitalEntry(o):
+		ca.capital = ca.capital.Add(f.GetPurchasePrice().Mul(f.GetAmount()).Add(f.GetExchangeFee()))
+		ca.amount = ca.amount.Add(f.GetAmount())
+	case o.GetAssetType().IsFutures() || o.IsLiquidating() || !ca.amount.IsPositive():
+		*ca = capitalAllocation{}
+	default:
+		remaining := ca.amount.Sub(f.GetAmount())
+		if !remaining.IsPositive() {
+			*ca = capitalAllocation{}
+			return
+		}
+		ca.capital = ca.capital.Mul(remaining).Div(ca.amount)
+		ca.amount = remaining
+	}
+}
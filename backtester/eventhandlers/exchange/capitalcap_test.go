@@ -0,0 +1,125 @@
+package exchange
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/event"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/fill"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/order"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	gctkline "github.com/thrasher-corp/gocryptotrader/exchanges/kline"
+	gctorder "github.com/thrasher-corp/gocryptotrader/exchanges/order"
+)
+
+func TestIsCapitalEntry(t *testing.T) {
+	t.Parallel()
+	o := &order.Order{Base: &event.Base{AssetType: asset.Spot}, Direction: gctorder.Buy}
+	if !isCapitalEntry(o) {
+		t.Error("expected spot buy to be an entry")
+	}
+	o.Direction = gctorder.Sell
+	if isCapitalEntry(o) {
+		t.Error("expected spot sell to not be an entry")
+	}
+	o.AssetType = asset.Futures
+	if !isCapitalEntry(o) {
+		t.Error("expected futures short to be an entry")
+	}
+	o.ClosingPosition = true
+	if isCapitalEntry(o) {
+		t.Error("expected closing order to not be an entry")
+	}
+}
+
+func TestUpdateCapitalAllocation(t *testing.T) {
+	t.Parallel()
+	e := Exchange{}
+	p := currency.NewPair(currency.BTC, currency.USDT)
+	cs := &Settings{MaximumCapitalAllocation: decimal.NewFromInt(1000)}
+	o := &order.Order{
+		Base: &event.Base{
+			Exchange:     testExchange,
+			CurrencyPair: p,
+			AssetType:    asset.Spot,
+		},
+		Direction:  gctorder.Buy,
+		Amount:     decimal.NewFromInt(4),
+		ClosePrice: decimal.NewFromInt(100),
+	}
+	f := &fill.Fill{
+		Base:          o.Base,
+		Amount:        decimal.NewFromInt(4),
+		PurchasePrice: decimal.NewFromInt(100),
+	}
+	if _, _, exceeded := e.exceedsCapitalCap(o, cs); exceeded {
+		t.Error("expected entry to be within the cap")
+	}
+	e.updateCapitalAllocation(o, f, cs)
+	e.updateCapitalAllocation(o, f, cs)
+	committed, requested, exceeded := e.exceedsCapitalCap(o, cs)
+	if !exceeded {
+		t.Error("expected entry to exceed the cap")
+	}
+	if !committed.Equal(decimal.NewFromInt(800)) {
+		t.Errorf("received '%v' expected '%v'", committed, 800)
+	}
+	if !requested.Equal(decimal.NewFromInt(400)) {
+		t.Errorf("received '%v' expected '%v'", requested, 400)
+	}
+
+	o.Direction = gctorder.Sell
+	if _, _, exceeded = e.exceedsCapitalCap(o, cs); exceeded {
+		t.Error("expected sell to always be allowed")
+	}
+	e.updateCapitalAllocation(o, f, cs)
+	ca := e.getCapitalAllocation(testExchange, asset.Spot, p)
+	if !ca.capital.Equal(decimal.NewFromInt(400)) {
+		t.Errorf("received '%v' expected '%v'", ca.capital, 400)
+	}
+	f.Amount = decimal.NewFromInt(10)
+	e.updateCapitalAllocation(o, f, cs)
+	if !ca.capital.IsZero() || !ca.amount.IsZero() {
+		t.Errorf("received '%v' expected '%v'", ca.capital, 0)
+	}
+}
+
+func TestExecuteOrderCapitalCap(t *testing.T) {
+	t.Parallel()
+	om, exch := setupOfflineOrderManager(t)
+	o, d := setupOfflineOrder(t, gctorder.Buy, decimal.NewFromInt(1), decimal.NewFromInt(100),
+		gctkline.Candle{Close: 100, High: 110, Low: 90, Volume: 1000})
+	cs := Settings{
+		Exchange:                 exch,
+		Pair:                     o.Pair(),
+		Asset:                    o.GetAssetType(),
+		MinimumSlippageRate:      decimal.NewFromInt(100),
+		MaximumSlippageRate:      decimal.NewFromInt(100),
+		MaximumCapitalAllocation: decimal.NewFromInt(150),
+		SignalToFillConvention:   SameCandleClose,
+	}
+	e := Exchange{CurrencySettings: []Settings{cs}}
+	f, err := e.ExecuteOrder(context.Background(), o, d, om, &fakeFund{})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	if f.IsCapitalCapBound() {
+		t.Error("expected first entry to be within the cap")
+	}
+
+	o, d = setupOfflineOrder(t, gctorder.Buy, decimal.NewFromInt(1), decimal.NewFromInt(100),
+		gctkline.Candle{Close: 100, High: 110, Low: 90, Volume: 1000})
+	f, err = e.ExecuteOrder(context.Background(), o, d, om, &fakeFund{})
+	if !errors.Is(err, errCapitalCapExceeded) {
+		t.Fatalf("received '%v' expected '%v'", err, errCapitalCapExceeded)
+	}
+	if f.GetDirection() != gctorder.CouldNotBuy {
+		t.Errorf("received '%v' expected '%v'", f.GetDirection(), gctorder.CouldNotBuy)
+	}
+	if !f.IsCapitalCapBound() {
+		t.Error("expected capital cap to bind")
+	}
+}
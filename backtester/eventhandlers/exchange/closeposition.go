@@ -0,0 +1,14 @@
+package exchange
+
+import (
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/order"
+	gctorder "github.com/thrasher-corp/gocryptotrader/exchanges/order"
+)
+
+// isClosingOrder returns whether the order exits a position. Closing orders
+// are filled at the full size of the position, ignoring candle volume and the
+// minimum and maximum size limits, so that a position can always be exited
+// completely. Slippage and fees are still applied
+func isClosingOrder(o order.Event) bool {
+	return o.IsClosingPosition() || o.GetDirection() == gctorder.ClosePosition
+}
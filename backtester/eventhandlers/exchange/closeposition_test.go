@@ -0,0 +1,74 @@
+package exchange
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventhandlers/exchange/slippage"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/order"
+	gctkline "github.com/thrasher-corp/gocryptotrader/exchanges/kline"
+	gctorder "github.com/thrasher-corp/gocryptotrader/exchanges/order"
+)
+
+func TestIsClosingOrder(t *testing.T) {
+	t.Parallel()
+	o := &order.Order{Direction: gctorder.Sell}
+	if isClosingOrder(o) {
+		t.Error("expected a sell to not be a closing order")
+	}
+	o.ClosingPosition = true
+	if !isClosingOrder(o) {
+		t.Error("expected an order closing a position to be a closing order")
+	}
+	o = &order.Order{Direction: gctorder.ClosePosition}
+	if !isClosingOrder(o) {
+		t.Error("expected a close position order to be a closing order")
+	}
+}
+
+func TestExecuteOrderClosingPositionIgnoresLimits(t *testing.T) {
+	t.Parallel()
+	om, exch := setupOfflineOrderManager(t)
+	cs := Settings{
+		Exchange:               exch,
+		SlippageTiers:          []slippage.Tier{{BasisPoints: decimal.NewFromInt(1000)}},
+		TakerFee:               decimal.NewFromFloat(0.001),
+		SellSide:               MinMax{MinimumSize: decimal.NewFromInt(10), MaximumSize: decimal.NewFromInt(20)},
+		SignalToFillConvention: SameCandleClose,
+	}
+	candle := gctkline.Candle{Close: 100, High: 110, Low: 90, Volume: 1}
+	o, d := setupOfflineOrder(t, gctorder.Sell, decimal.NewFromInt(5), decimal.NewFromInt(100), candle)
+	cs.Pair = o.Pair()
+	cs.Asset = o.GetAssetType()
+	e := Exchange{CurrencySettings: []Settings{cs}}
+	f, err := e.ExecuteOrder(context.Background(), o, d, om, &fakeFund{})
+	if !errors.Is(err, errExceededPortfolioLimit) {
+		t.Fatalf("received '%v' expected '%v'", err, errExceededPortfolioLimit)
+	}
+	if f.GetDirection() != gctorder.CouldNotSell {
+		t.Errorf("received '%v' expected '%v'", f.GetDirection(), gctorder.CouldNotSell)
+	}
+
+	// the same order closing the position fills in full despite being
+	// below the minimum size and exceeding the candle's volume
+	o, d = setupOfflineOrder(t, gctorder.Sell, decimal.NewFromInt(5), decimal.NewFromInt(100), candle)
+	o.ClosingPosition = true
+	f, err = e.ExecuteOrder(context.Background(), o, d, om, &fakeFund{})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	if f.GetDirection() != gctorder.Sell {
+		t.Errorf("received '%v' expected '%v'", f.GetDirection(), gctorder.Sell)
+	}
+	if !f.GetAmount().Equal(decimal.NewFromInt(5)) {
+		t.Errorf("received '%v' expected '%v'", f.GetAmount(), 5)
+	}
+	if !f.GetPurchasePrice().Equal(decimal.NewFromInt(90)) {
+		t.Errorf("received '%v' expected slipped price '%v'", f.GetPurchasePrice(), 90)
+	}
+	if !f.GetExchangeFee().Equal(decimal.NewFromFloat(0.45)) {
+		t.Errorf("received '%v' expected '%v'", f.GetExchangeFee(), 0.45)
+	}
+}
@@ -0,0 +1,34 @@
+package exchange
+
+import (
+	"fmt"
+
+	"github.com/shopspring/decimal"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/fill"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/order"
+	"github.com/thrasher-corp/gocryptotrader/backtester/funding"
+	gctorder "github.com/thrasher-corp/gocryptotrader/exchanges/order"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/orderbook"
+)
+
+// isCrossedOrderbook returns whether the best bid is at or above the best ask,
+// which indicates a crossed or locked book from stale or bad data
+func isCrossedOrderbook(ob *orderbook.Base) bool {
+	if ob == nil || len(ob.Bids) == 0 || len(ob.Asks) == 0 {
+		return false
+	}
+	return ob.Bids[0].Price >= ob.Asks[0].Price
+}
+
+// handleCrossedOrderbook either rejects an order against a crossed or locked
+// orderbook, or defers it until an orderbook which is not crossed is available.
+// Deferred orders keep their reserved funds until released
+func (e *Exchange) handleCrossedOrderbook(ob *orderbook.Base, o order.Event, f *fill.Fill, funds funding.IFundReleaser, cs *Settings) (fill.Event, error) {
+	if cs.CrossedOrderbookBehaviour == CrossedOrderbookWait && e.deferOrder(o) != nil {
+		f.SetDirection(gctorder.DoNothing)
+		f.AppendReasonf("Orderbook crossed with best bid %v and best ask %v, deferred until the next valid orderbook", ob.Bids[0].Price, ob.Asks[0].Price)
+		return f, fmt.Errorf("%w %v", ErrCannotTransact, errCrossedOrderbook)
+	}
+	f.AppendReasonf("Orderbook crossed with best bid %v and best ask %v, rejected", ob.Bids[0].Price, ob.Asks[0].Price)
+	return f, allocateFundsPostOrder(f, funds, errCrossedOrderbook, o.GetAmount(), o.GetAllocatedFunds(), decimal.Zero, decimal.Zero, decimal.Zero, cs)
+}
@@ -0,0 +1,110 @@
+package exchange
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/event"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/kline"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	gctkline "github.com/thrasher-corp/gocryptotrader/exchanges/kline"
+	gctorder "github.com/thrasher-corp/gocryptotrader/exchanges/order"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/orderbook"
+)
+
+func TestIsCrossedOrderbook(t *testing.T) {
+	t.Parallel()
+	if isCrossedOrderbook(nil) {
+		t.Error("expected nil orderbook to not be crossed")
+	}
+	ob := &orderbook.Base{
+		Bids: orderbook.Items{{Price: 100}},
+		Asks: orderbook.Items{{Price: 101}},
+	}
+	if isCrossedOrderbook(ob) {
+		t.Error("expected orderbook to not be crossed")
+	}
+	ob.Asks[0].Price = 100
+	if !isCrossedOrderbook(ob) {
+		t.Error("expected locked orderbook to be crossed")
+	}
+	ob.Asks[0].Price = 99
+	if !isCrossedOrderbook(ob) {
+		t.Error("expected orderbook to be crossed")
+	}
+	ob.Asks = nil
+	if isCrossedOrderbook(ob) {
+		t.Error("expected one sided orderbook to not be crossed")
+	}
+}
+
+func TestExecuteOrderCrossedOrderbook(t *testing.T) {
+	t.Parallel()
+	om, exch := setupOfflineOrderManager(t)
+	p := currency.NewPair(currency.LTC, currency.DOGE)
+	ob := &orderbook.Base{
+		Exchange:        exch.GetName(),
+		Pair:            p,
+		Asset:           asset.Spot,
+		Bids:            orderbook.Items{{Price: 101, Amount: 10}},
+		Asks:            orderbook.Items{{Price: 100, Amount: 10}},
+		VerifyOrderbook: true,
+	}
+	err := ob.Process()
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+
+	o, d := setupOfflineOrder(t, gctorder.Buy, decimal.NewFromInt(1), decimal.NewFromInt(100),
+		gctkline.Candle{Close: 100, High: 110, Low: 90, Volume: 1000})
+	o.CurrencyPair = p
+	e := Exchange{}
+	e.SetExchangeAssetCurrencySettings(asset.Spot, p, &Settings{
+		Exchange:      exch,
+		Pair:          p,
+		Asset:         asset.Spot,
+		UseRealOrders: true,
+	})
+	f, err := e.ExecuteOrder(context.Background(), o, d, om, &fakeFund{})
+	if !errors.Is(err, errCrossedOrderbook) {
+		t.Fatalf("received '%v' expected '%v'", err, errCrossedOrderbook)
+	}
+	if f.GetDirection() != gctorder.CouldNotBuy {
+		t.Errorf("received '%v' expected '%v'", f.GetDirection(), gctorder.CouldNotBuy)
+	}
+
+	e.CurrencySettings[0].CrossedOrderbookBehaviour = CrossedOrderbookWait
+	o, d = setupOfflineOrder(t, gctorder.Buy, decimal.NewFromInt(1), decimal.NewFromInt(100),
+		gctkline.Candle{Close: 100, High: 110, Low: 90, Volume: 1000})
+	o.CurrencyPair = p
+	f, err = e.ExecuteOrder(context.Background(), o, d, om, &fakeFund{})
+	if !errors.Is(err, ErrCannotTransact) {
+		t.Fatalf("received '%v' expected '%v'", err, ErrCannotTransact)
+	}
+	if f.GetDirection() != gctorder.DoNothing {
+		t.Errorf("received '%v' expected '%v'", f.GetDirection(), gctorder.DoNothing)
+	}
+	if len(e.deferredOrders) != 1 {
+		t.Fatalf("received '%v' expected '%v'", len(e.deferredOrders), 1)
+	}
+	released, err := e.ReleaseDeferredOrders(&kline.Kline{
+		Base: &event.Base{
+			Offset:       o.GetOffset() + 1,
+			Exchange:     o.GetExchange(),
+			Time:         o.GetTime().Add(o.GetInterval().Duration()),
+			Interval:     o.GetInterval(),
+			CurrencyPair: p,
+			AssetType:    asset.Spot,
+		},
+		Close: decimal.NewFromInt(100),
+	})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	if len(released) != 1 {
+		t.Errorf("received '%v' expected '%v'", len(released), 1)
+	}
+}
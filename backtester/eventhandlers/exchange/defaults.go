@@ -0,0 +1,52 @@
+package exchange
+
+import (
+	"github.com/shopspring/decimal"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+)
+
+var (
+	// spot markets commonly charge a flat fee to both sides of the book
+	defaultSpotMakerFee = decimal.NewFromFloat(0.001)
+	defaultSpotTakerFee = decimal.NewFromFloat(0.001)
+	// futures markets reward liquidity with lower maker fees
+	defaultFuturesMakerFee = decimal.NewFromFloat(0.0002)
+	defaultFuturesTakerFee = decimal.NewFromFloat(0.0005)
+	// slippage rates are the percentage of the price kept, eg 99 slips up to 1%
+	defaultMinimumSlippageRate = decimal.NewFromInt(100)
+	defaultMaximumSlippageRate = decimal.NewFromInt(99)
+	defaultMaxSlippagePercent  = decimal.NewFromInt(5)
+	// futures positions may be opened with leverage up to this rate
+	defaultMaximumLeverageRate = decimal.NewFromInt(10)
+)
+
+// DefaultSettingsForAsset returns sensible starting settings for the asset
+// class which can then be overridden. Spot fills are fitted to the candle's
+// volume while futures skip candle volume fitting and allow leverage.
+// The exchange and pair are left unset. Asset classes without defaults
+// only have their asset set
+func DefaultSettingsForAsset(a asset.Item) Settings {
+	s := Settings{
+		Asset: a,
+	}
+	switch {
+	case a == asset.Spot:
+		s.MakerFee = defaultSpotMakerFee
+		s.TakerFee = defaultSpotTakerFee
+	case a.IsFutures():
+		s.MakerFee = defaultFuturesMakerFee
+		s.TakerFee = defaultFuturesTakerFee
+		s.SkipCandleVolumeFitting = true
+		s.Leverage = Leverage{
+			CanUseLeverage:      true,
+			MaximumLeverageRate: defaultMaximumLeverageRate,
+		}
+	default:
+		return s
+	}
+	s.MinimumSlippageRate = defaultMinimumSlippageRate
+	s.MaximumSlippageRate = defaultMaximumSlippageRate
+	s.MaxSlippagePercent = defaultMaxSlippagePercent
+	s.CanUseExchangeLimits = true
+	return s
+}
@@ -0,0 +1,46 @@
+package exchange
+
+import (
+	"testing"
+
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+)
+
+func TestDefaultSettingsForAsset(t *testing.T) {
+	t.Parallel()
+	s := DefaultSettingsForAsset(asset.Spot)
+	if s.Asset != asset.Spot {
+		t.Errorf("received '%v' expected '%v'", s.Asset, asset.Spot)
+	}
+	if !s.TakerFee.Equal(defaultSpotTakerFee) || !s.MakerFee.Equal(defaultSpotMakerFee) {
+		t.Errorf("received '%v' '%v' expected '%v' '%v'", s.MakerFee, s.TakerFee, defaultSpotMakerFee, defaultSpotTakerFee)
+	}
+	if s.SkipCandleVolumeFitting {
+		t.Error("expected spot to fit orders to candle volume")
+	}
+	if s.Leverage.CanUseLeverage {
+		t.Error("expected spot to not use leverage")
+	}
+	if !s.MaximumSlippageRate.Equal(defaultMaximumSlippageRate) {
+		t.Errorf("received '%v' expected '%v'", s.MaximumSlippageRate, defaultMaximumSlippageRate)
+	}
+
+	s = DefaultSettingsForAsset(asset.Futures)
+	if !s.TakerFee.Equal(defaultFuturesTakerFee) || !s.MakerFee.Equal(defaultFuturesMakerFee) {
+		t.Errorf("received '%v' '%v' expected '%v' '%v'", s.MakerFee, s.TakerFee, defaultFuturesMakerFee, defaultFuturesTakerFee)
+	}
+	if !s.SkipCandleVolumeFitting {
+		t.Error("expected futures to skip candle volume fitting")
+	}
+	if !s.Leverage.CanUseLeverage || !s.Leverage.MaximumLeverageRate.Equal(defaultMaximumLeverageRate) {
+		t.Errorf("received '%+v' expected leverage up to '%v'", s.Leverage, defaultMaximumLeverageRate)
+	}
+
+	s = DefaultSettingsForAsset(asset.Margin)
+	if s.Asset != asset.Margin {
+		t.Errorf("received '%v' expected '%v'", s.Asset, asset.Margin)
+	}
+	if !s.TakerFee.IsZero() || s.CanUseExchangeLimits {
+		t.Errorf("received '%+v' expected no defaults", s)
+	}
+}
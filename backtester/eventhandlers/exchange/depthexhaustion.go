@@ -0,0 +1,48 @@
+package exchange
+
+import (
+	"github.com/shopspring/decimal"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/fill"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/order"
+	"github.com/thrasher-corp/gocryptotrader/backtester/funding"
+	gctorder "github.com/thrasher-corp/gocryptotrader/exchanges/order"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/orderbook"
+)
+
+// orderbookDepth returns the total depth of the orderbook side an order fills
+// against, measured the same way as the order's allocated funds when
+// simulated against the orderbook. Buys are measured in the quote notional of
+// the asks and all other sides in the base amount of the bids. The base
+// amount available on that side is also returned
+func orderbookDepth(ob *orderbook.Base, side gctorder.Side) (depth, baseDepth decimal.Decimal) {
+	if ob == nil {
+		return decimal.Zero, decimal.Zero
+	}
+	var quote, base float64
+	levels := ob.Bids
+	if side == gctorder.Buy {
+		levels = ob.Asks
+	}
+	for i := range levels {
+		quote += levels[i].Price * levels[i].Amount
+		base += levels[i].Amount
+	}
+	if side == gctorder.Buy {
+		return decimal.NewFromFloat(quote), decimal.NewFromFloat(base)
+	}
+	return decimal.NewFromFloat(base), decimal.NewFromFloat(base)
+}
+
+// exceedsOrderbookDepth returns whether the order's allocated funds
+// would consume the entire depth of the orderbook side it fills against
+func exceedsOrderbookDepth(ob *orderbook.Base, side gctorder.Side, allocatedFunds decimal.Decimal) (depth, baseDepth decimal.Decimal, isExceeded bool) {
+	depth, baseDepth = orderbookDepth(ob, side)
+	return depth, baseDepth, allocatedFunds.GreaterThan(depth)
+}
+
+// handleDepthExhaustion rejects an order which
+// exceeds the total depth of the orderbook
+func handleDepthExhaustion(o order.Event, f *fill.Fill, funds funding.IFundReleaser, cs *Settings, depth decimal.Decimal) (fill.Event, error) {
+	f.AppendReasonf("Order of %v exceeds orderbook depth of %v, rejected", o.GetAllocatedFunds(), depth)
+	return f, allocateFundsPostOrder(f, funds, errOrderbookDepthExhausted, o.GetAmount(), o.GetAllocatedFunds(), decimal.Zero, decimal.Zero, decimal.Zero, cs)
+}
@@ -0,0 +1,106 @@
+package exchange
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	gctkline "github.com/thrasher-corp/gocryptotrader/exchanges/kline"
+	gctorder "github.com/thrasher-corp/gocryptotrader/exchanges/order"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/orderbook"
+)
+
+func TestOrderbookDepth(t *testing.T) {
+	t.Parallel()
+	if depth, baseDepth := orderbookDepth(nil, gctorder.Buy); !depth.IsZero() || !baseDepth.IsZero() {
+		t.Errorf("received '%v' '%v' expected '%v'", depth, baseDepth, decimal.Zero)
+	}
+	ob := &orderbook.Base{
+		Bids: orderbook.Items{{Price: 99, Amount: 1}, {Price: 98, Amount: 2}},
+		Asks: orderbook.Items{{Price: 100, Amount: 1}, {Price: 101, Amount: 2}},
+	}
+	depth, baseDepth := orderbookDepth(ob, gctorder.Buy)
+	if !depth.Equal(decimal.NewFromInt(302)) || !baseDepth.Equal(decimal.NewFromInt(3)) {
+		t.Errorf("received '%v' '%v' expected '%v' '%v'", depth, baseDepth, 302, 3)
+	}
+	depth, baseDepth = orderbookDepth(ob, gctorder.Sell)
+	if !depth.Equal(decimal.NewFromInt(3)) || !baseDepth.Equal(decimal.NewFromInt(3)) {
+		t.Errorf("received '%v' '%v' expected '%v' '%v'", depth, baseDepth, 3, 3)
+	}
+	if _, _, isExceeded := exceedsOrderbookDepth(ob, gctorder.Sell, decimal.NewFromInt(3)); isExceeded {
+		t.Error("expected order matching the orderbook depth to not exceed it")
+	}
+	depth, _, isExceeded := exceedsOrderbookDepth(ob, gctorder.Buy, decimal.NewFromInt(303))
+	if !isExceeded {
+		t.Error("expected order to exceed the orderbook depth")
+	}
+	if !depth.Equal(decimal.NewFromInt(302)) {
+		t.Errorf("received '%v' expected '%v'", depth, 302)
+	}
+}
+
+func TestExecuteOrderDepthExhaustion(t *testing.T) {
+	t.Parallel()
+	om, exch := setupOfflineOrderManager(t)
+	p := currency.NewPair(currency.ADA, currency.DOGE)
+	ob := &orderbook.Base{
+		Exchange:        exch.GetName(),
+		Pair:            p,
+		Asset:           asset.Spot,
+		Bids:            orderbook.Items{{Price: 99, Amount: 1}, {Price: 98, Amount: 2}},
+		Asks:            orderbook.Items{{Price: 100, Amount: 1}, {Price: 101, Amount: 2}},
+		VerifyOrderbook: true,
+	}
+	err := ob.Process()
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	e := Exchange{}
+	cs := &Settings{
+		Exchange:                 exch,
+		Pair:                     p,
+		Asset:                    asset.Spot,
+		UseRealOrders:            true,
+		DepthExhaustionBehaviour: DepthExhaustionReject,
+	}
+	e.SetExchangeAssetCurrencySettings(asset.Spot, p, cs)
+
+	// the 1337 allocated to the buy exceeds the 302 of ask depth
+	o, d := setupOfflineOrder(t, gctorder.Buy, decimal.NewFromInt(10), decimal.NewFromInt(100),
+		gctkline.Candle{Close: 100, High: 110, Low: 90, Volume: 1000})
+	o.CurrencyPair = p
+	f, err := e.ExecuteOrder(context.Background(), o, d, om, &fakeFund{})
+	if !errors.Is(err, errOrderbookDepthExhausted) {
+		t.Fatalf("received '%v' expected '%v'", err, errOrderbookDepthExhausted)
+	}
+	if f.GetDirection() != gctorder.CouldNotBuy {
+		t.Errorf("received '%v' expected '%v'", f.GetDirection(), gctorder.CouldNotBuy)
+	}
+	if len(f.GetReasons()) == 0 {
+		t.Error("expected a rejection reason")
+	}
+
+	cs.DepthExhaustionBehaviour = DepthExhaustionPartialFill
+	e.SetExchangeAssetCurrencySettings(asset.Spot, p, cs)
+	o, d = setupOfflineOrder(t, gctorder.Sell, decimal.NewFromInt(10), decimal.NewFromInt(100),
+		gctkline.Candle{Close: 100, High: 110, Low: 90, Volume: 1000})
+	o.CurrencyPair = p
+	o.AllocatedFunds = decimal.NewFromInt(10)
+	// the offline exchange cannot submit the order, so only the
+	// capped amount recorded before submission is checked
+	f, err = e.ExecuteOrder(context.Background(), o, d, om, &fakeFund{})
+	if errors.Is(err, errOrderbookDepthExhausted) {
+		t.Fatalf("received '%v' expected order to not be rejected", err)
+	}
+	var hasReason bool
+	for _, reason := range f.GetReasons() {
+		hasReason = hasReason || strings.Contains(reason, "filling 3 against the available depth leaving the remainder unfilled")
+	}
+	if !hasReason {
+		t.Errorf("received '%v' expected a depth exhaustion reason", f.GetReasons())
+	}
+}
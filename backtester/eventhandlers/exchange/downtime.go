@@ -0,0 +1,59 @@
+package exchange
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/fill"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/order"
+	"github.com/thrasher-corp/gocryptotrader/backtester/funding"
+	gctkline "github.com/thrasher-corp/gocryptotrader/exchanges/kline"
+)
+
+// CalculateDowntimePeriods treats each run of at least minimumCandles
+// consecutive missing candles as a period of exchange downtime. A period ends
+// at the start of the next candle with data, or the end of the range
+func CalculateDowntimePeriods(rh *gctkline.IntervalRangeHolder, minimumCandles int64) []DowntimePeriod {
+	if rh == nil || minimumCandles <= 0 {
+		return nil
+	}
+	var periods []DowntimePeriod
+	var gapStart time.Time
+	var missing int64
+	for i := range rh.Ranges {
+		for j := range rh.Ranges[i].Intervals {
+			interval := rh.Ranges[i].Intervals[j]
+			if !interval.HasData {
+				if missing == 0 {
+					gapStart = interval.Start.Time
+				}
+				missing++
+				continue
+			}
+			if missing >= minimumCandles {
+				periods = append(periods, DowntimePeriod{Start: gapStart, End: interval.Start.Time})
+			}
+			missing = 0
+		}
+	}
+	if missing >= minimumCandles {
+		periods = append(periods, DowntimePeriod{Start: gapStart, End: rh.End.Time})
+	}
+	return periods
+}
+
+// inDowntime returns whether the exchange was down at the time provided
+func (s *Settings) inDowntime(t time.Time) bool {
+	for i := range s.Downtime {
+		if !t.Before(s.Downtime[i].Start) && t.Before(s.Downtime[i].End) {
+			return true
+		}
+	}
+	return false
+}
+
+// handleDowntimeOrder rejects an order placed while the exchange was down
+func handleDowntimeOrder(o order.Event, f *fill.Fill, funds funding.IFundReleaser, cs *Settings) (fill.Event, error) {
+	f.AppendReasonf("Order placed during exchange downtime at %v, rejected", o.GetTime())
+	return f, allocateFundsPostOrder(f, funds, errExchangeDowntime, o.GetAmount(), o.GetAllocatedFunds(), decimal.Zero, decimal.Zero, decimal.Zero, cs)
+}
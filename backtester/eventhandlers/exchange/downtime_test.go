@@ -0,0 +1,122 @@
+package exchange
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/event"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/kline"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/order"
+	gctkline "github.com/thrasher-corp/gocryptotrader/exchanges/kline"
+	gctorder "github.com/thrasher-corp/gocryptotrader/exchanges/order"
+)
+
+func TestCalculateDowntimePeriods(t *testing.T) {
+	t.Parallel()
+	if p := CalculateDowntimePeriods(nil, 1); p != nil {
+		t.Errorf("received '%v' expected '%v'", p, nil)
+	}
+	tt := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	rh, err := gctkline.CalculateCandleDateRanges(tt, tt.Add(time.Hour*10), gctkline.OneHour, 0)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	// one missing candle at 01:00, three missing from 03:00 and two trailing from 08:00
+	rh.SetHasDataFromCandles([]gctkline.Candle{
+		{Time: tt},
+		{Time: tt.Add(time.Hour * 2)},
+		{Time: tt.Add(time.Hour * 6)},
+		{Time: tt.Add(time.Hour * 7)},
+	})
+	if p := CalculateDowntimePeriods(rh, 0); p != nil {
+		t.Errorf("received '%v' expected '%v'", p, nil)
+	}
+	periods := CalculateDowntimePeriods(rh, 2)
+	if len(periods) != 2 {
+		t.Fatalf("received '%v' expected '%v'", len(periods), 2)
+	}
+	if !periods[0].Start.Equal(tt.Add(time.Hour*3)) || !periods[0].End.Equal(tt.Add(time.Hour*6)) {
+		t.Errorf("received '%v' expected '%v' to '%v'", periods[0], tt.Add(time.Hour*3), tt.Add(time.Hour*6))
+	}
+	if !periods[1].Start.Equal(tt.Add(time.Hour*8)) || !periods[1].End.Equal(tt.Add(time.Hour*10)) {
+		t.Errorf("received '%v' expected '%v' to '%v'", periods[1], tt.Add(time.Hour*8), tt.Add(time.Hour*10))
+	}
+	if p := CalculateDowntimePeriods(rh, 1); len(p) != 3 {
+		t.Errorf("received '%v' expected '%v'", len(p), 3)
+	}
+}
+
+func TestInDowntime(t *testing.T) {
+	t.Parallel()
+	tt := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	cs := &Settings{Downtime: []DowntimePeriod{{Start: tt, End: tt.Add(time.Hour)}}}
+	if !cs.inDowntime(tt) {
+		t.Error("expected downtime at the start of the period")
+	}
+	if cs.inDowntime(tt.Add(time.Hour)) {
+		t.Error("expected no downtime at the end of the period")
+	}
+	if cs.inDowntime(tt.Add(-time.Minute)) {
+		t.Error("expected no downtime before the period")
+	}
+}
+
+func TestExecuteOrderDuringDowntime(t *testing.T) {
+	t.Parallel()
+	om, exch := setupOfflineOrderManager(t)
+	o, d := setupOfflineOrder(t, gctorder.Buy, decimal.NewFromInt(1), decimal.NewFromInt(100),
+		gctkline.Candle{Close: 100, High: 110, Low: 90, Volume: 1000})
+	cs := Settings{
+		Exchange:            exch,
+		Pair:                o.Pair(),
+		Asset:               o.GetAssetType(),
+		MinimumSlippageRate: decimal.NewFromInt(100),
+		MaximumSlippageRate: decimal.NewFromInt(100),
+		Downtime:            []DowntimePeriod{{Start: o.GetTime(), End: o.GetTime().Add(time.Hour)}},
+	}
+	e := Exchange{CurrencySettings: []Settings{cs}}
+	f, err := e.ExecuteOrder(context.Background(), o, d, om, &fakeFund{})
+	if !errors.Is(err, errExchangeDowntime) {
+		t.Fatalf("received '%v' expected '%v'", err, errExchangeDowntime)
+	}
+	if f.GetDirection() != gctorder.CouldNotBuy {
+		t.Errorf("received '%v' expected '%v'", f.GetDirection(), gctorder.CouldNotBuy)
+	}
+
+	e.deferredOrders = []*order.Order{{Base: &event.Base{
+		Offset:       o.GetOffset(),
+		Exchange:     o.GetExchange(),
+		Time:         o.GetTime(),
+		Interval:     o.GetInterval(),
+		CurrencyPair: o.Pair(),
+		AssetType:    o.GetAssetType(),
+	}, Direction: gctorder.Buy}}
+	ev := &kline.Kline{
+		Base: &event.Base{
+			Offset:       o.GetOffset() + 1,
+			Exchange:     o.GetExchange(),
+			Time:         o.GetTime().Add(time.Minute * 30),
+			Interval:     o.GetInterval(),
+			CurrencyPair: o.Pair(),
+			AssetType:    o.GetAssetType(),
+		},
+	}
+	released, err := e.ReleaseDeferredOrders(ev)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	if len(released) != 0 {
+		t.Errorf("received '%v' expected '%v'", len(released), 0)
+	}
+	ev.Time = o.GetTime().Add(time.Hour)
+	released, err = e.ReleaseDeferredOrders(ev)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	if len(released) != 1 {
+		t.Errorf("received '%v' expected '%v'", len(released), 1)
+	}
+}
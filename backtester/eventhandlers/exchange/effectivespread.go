@@ -0,0 +1,47 @@
+package exchange
+
+import (
+	"github.com/shopspring/decimal"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/fill"
+	gctorder "github.com/thrasher-corp/gocryptotrader/exchanges/order"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/orderbook"
+)
+
+// orderbookMidpoint returns the midpoint of the orderbook's best bid and ask.
+// Returns zero when either side of the book is empty
+func orderbookMidpoint(ob *orderbook.Base) decimal.Decimal {
+	if ob == nil || len(ob.Bids) == 0 || len(ob.Asks) == 0 {
+		return decimal.Zero
+	}
+	return decimal.NewFromFloat(ob.Bids[0].Price).Add(decimal.NewFromFloat(ob.Asks[0].Price)).Div(decimal.NewFromInt(2))
+}
+
+// calculateEffectiveSpread returns the difference between the fill price and
+// the orderbook midpoint as a percentage of the midpoint. It is signed by the
+// order side so that a positive spread is a cost paid and a negative spread is
+// price improvement
+func calculateEffectiveSpread(side gctorder.Side, price, midpoint decimal.Decimal) (decimal.Decimal, bool) {
+	if !midpoint.IsPositive() || !price.IsPositive() {
+		return decimal.Zero, false
+	}
+	spread := price.Sub(midpoint).Div(midpoint).Mul(decimal.NewFromInt(100))
+	switch {
+	case side.IsLong():
+		return spread, true
+	case side.IsShort():
+		return spread.Neg(), true
+	default:
+		return decimal.Zero, false
+	}
+}
+
+// setEffectiveSpread records the effective spread paid by the fill
+// against the midpoint of the orderbook it was executed against
+func setEffectiveSpread(f *fill.Fill, midpoint decimal.Decimal) {
+	spread, ok := calculateEffectiveSpread(f.GetDirection(), f.GetPurchasePrice(), midpoint)
+	if !ok {
+		return
+	}
+	f.EffectiveSpread = spread
+	f.HasEffectiveSpread = true
+}
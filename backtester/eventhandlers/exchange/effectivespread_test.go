@@ -0,0 +1,57 @@
+package exchange
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/fill"
+	gctorder "github.com/thrasher-corp/gocryptotrader/exchanges/order"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/orderbook"
+)
+
+func TestOrderbookMidpoint(t *testing.T) {
+	t.Parallel()
+	if m := orderbookMidpoint(nil); !m.IsZero() {
+		t.Errorf("received '%v' expected '%v'", m, 0)
+	}
+	ob := &orderbook.Base{
+		Bids: orderbook.Items{{Price: 99}},
+		Asks: orderbook.Items{{Price: 101}},
+	}
+	if m := orderbookMidpoint(ob); !m.Equal(decimal.NewFromInt(100)) {
+		t.Errorf("received '%v' expected '%v'", m, 100)
+	}
+	ob.Bids = nil
+	if m := orderbookMidpoint(ob); !m.IsZero() {
+		t.Errorf("received '%v' expected '%v'", m, 0)
+	}
+}
+
+func TestCalculateEffectiveSpread(t *testing.T) {
+	t.Parallel()
+	midpoint := decimal.NewFromInt(100)
+	if _, ok := calculateEffectiveSpread(gctorder.Buy, decimal.NewFromInt(101), decimal.Zero); ok {
+		t.Error("expected no effective spread without a midpoint")
+	}
+	if _, ok := calculateEffectiveSpread(gctorder.DoNothing, decimal.NewFromInt(101), midpoint); ok {
+		t.Error("expected no effective spread without a side")
+	}
+	spread, ok := calculateEffectiveSpread(gctorder.Buy, decimal.NewFromInt(101), midpoint)
+	if !ok || !spread.Equal(decimal.NewFromInt(1)) {
+		t.Errorf("received '%v' expected '%v'", spread, 1)
+	}
+	spread, ok = calculateEffectiveSpread(gctorder.Sell, decimal.NewFromInt(98), midpoint)
+	if !ok || !spread.Equal(decimal.NewFromInt(2)) {
+		t.Errorf("received '%v' expected '%v'", spread, 2)
+	}
+	spread, ok = calculateEffectiveSpread(gctorder.Short, decimal.NewFromInt(101), midpoint)
+	if !ok || !spread.Equal(decimal.NewFromInt(-1)) {
+		t.Errorf("received '%v' expected price improvement of '%v'", spread, -1)
+	}
+
+	f := &fill.Fill{Direction: gctorder.Buy, PurchasePrice: decimal.NewFromInt(101)}
+	setEffectiveSpread(f, midpoint)
+	if spread, ok = f.GetEffectiveSpread(); !ok || !spread.Equal(decimal.NewFromInt(1)) {
+		t.Errorf("received '%v' expected '%v'", spread, 1)
+	}
+}
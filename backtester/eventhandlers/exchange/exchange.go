@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/gofrs/uuid"
 	"github.com/shopspring/decimal"
@@ -30,30 +31,87 @@ func (e *Exchange) Reset() {
 var ErrCannotTransact = errors.New("cannot transact")
 
 // ExecuteOrder assesses the portfolio manager's order event and if it passes validation
-// will send an order to the exchange/fake order manager to be stored and raise a fill event
-func (e *Exchange) ExecuteOrder(o order.Event, data data.Handler, orderManager *engine.OrderManager, funds funding.IFundReleaser) (fill.Event, error) {
+// will send an order to the exchange/fake order manager to be stored and raise a fill event.
+// The context bounds real order submissions, including any retry backoff
+func (e *Exchange) ExecuteOrder(ctx context.Context, o order.Event, d data.Handler, orderManager *engine.OrderManager, funds funding.IFundReleaser) (fill.Event, error) {
 	f := &fill.Fill{
-		Base:               o.GetBase(),
-		Direction:          o.GetDirection(),
-		Amount:             o.GetAmount(),
-		ClosePrice:         o.GetClosePrice(),
-		FillDependentEvent: o.GetFillDependentEvent(),
-		Liquidated:         o.IsLiquidating(),
+		Base:                o.GetBase(),
+		Direction:           o.GetDirection(),
+		Amount:              o.GetAmount(),
+		IntendedAmount:      o.GetAmount(),
+		ClosePrice:          o.GetClosePrice(),
+		VolumeAdjustedPrice: o.GetClosePrice(),
+		FillDependentEvent:  o.GetFillDependentEvent(),
+		Liquidated:          o.IsLiquidating(),
+		StopOut:             o.IsStopOut(),
+		Tag:                 o.GetTag(),
+		Account:             o.GetAccount(),
+		LimitPrice:          o.GetLimitPrice(),
+		StopPrice:           o.GetStopPrice(),
+		TakeProfitPrice:     o.GetTakeProfitPrice(),
+		DecisionTime:        o.GetDecisionTime(),
 	}
 	if !common.CanTransact(o.GetDirection()) {
 		return f, fmt.Errorf("%w order direction %v", ErrCannotTransact, o.GetDirection())
 	}
+	defer e.publishFill(f)
 
 	allocatedFunds := o.GetAllocatedFunds()
+	if !o.IsLiquidating() && allocatedFunds.LessThanOrEqual(decimal.Zero) {
+		f.SetDirection(gctorder.DoNothing)
+		f.AppendReasonf("No funds allocated to order, allocated funds %v", allocatedFunds)
+		return f, fmt.Errorf("%w %v", ErrCannotTransact, errNoFundsAllocated)
+	}
 	cs, err := e.GetCurrencySettings(o.GetExchange(), o.GetAssetType(), o.Pair())
 	if err != nil {
 		return f, err
 	}
 	f.Direction = o.GetDirection()
+	if !o.IsLiquidating() && cs.inDowntime(o.GetTime()) {
+		return handleDowntimeOrder(o, f, funds, &cs)
+	}
+	if !o.IsLiquidating() && !o.IsStopOut() && !cs.TradingSession.IsOpen(o.GetTime()) {
+		return e.handleOffHoursOrder(o, f, funds, &cs)
+	}
+	if e.handleExecutionDelay(o, f, &cs) {
+		return f, fmt.Errorf("%w order delayed by execution delay", ErrCannotTransact)
+	}
+	if e.deferToNextOpen(o, f, &cs) {
+		return f, fmt.Errorf("%w order deferred to next candle open", ErrCannotTransact)
+	}
+	if e.handleOrderLatency(o, f, &cs) {
+		return f, fmt.Errorf("%w order delayed by latency", ErrCannotTransact)
+	}
+	if committed, requested, exceeded := e.exceedsCapitalCap(o, &cs); exceeded {
+		return handleCapitalCapExceeded(o, f, funds, &cs, committed, requested)
+	}
+	if e.isCircuitBroken(o, &cs) {
+		return e.handleCircuitBroken(o, f, funds, &cs)
+	}
+	if held, tooBrief := e.heldTooBriefly(o, &cs); tooBrief {
+		return handleMinimumHoldingPeriod(o, f, funds, &cs, held)
+	}
+	if err = validateOrderType(o, &cs); err != nil {
+		f.AppendReasonf("Order rejected, %v", err)
+		return f, allocateFundsPostOrder(f, funds, err, o.GetAmount(), allocatedFunds, decimal.Zero, decimal.Zero, decimal.Zero, &cs)
+	}
+	if o.GetTakeProfitPercent().IsPositive() {
+		if err = e.resolveTakeProfitPercent(o, f, &cs); err != nil {
+			f.AppendReasonf("Order rejected, %v", err)
+			return f, allocateFundsPostOrder(f, funds, err, o.GetAmount(), allocatedFunds, decimal.Zero, decimal.Zero, decimal.Zero, &cs)
+		}
+	}
+	if !o.IsLiquidating() {
+		if err = validateExecutionInputs(o.GetClosePrice(), o.GetAmount()); err != nil {
+			return handleInvalidExecutionInputs(o, f, funds, &cs, err)
+		}
+	}
 
-	var price, adjustedPrice,
+	var price, adjustedPrice, preSlippagePrice,
 		amount, adjustedAmount,
-		fee decimal.Decimal
+		fee, midpoint decimal.Decimal
+	var overrideData data.Handler
+	var isVolumeLimited bool
 	amount = o.GetAmount()
 	price = o.GetClosePrice()
 	if cs.UseRealOrders {
@@ -84,32 +142,119 @@ func (e *Exchange) ExecuteOrder(o order.Event, data data.Handler, orderManager *
 		if err != nil {
 			return f, err
 		}
-		// calculate an estimated slippage rate
-		price, amount = slippage.CalculateSlippageByOrderbook(ob, o.GetDirection(), allocatedFunds, f.ExchangeFee)
+		if age, isStale := isStaleOrderbook(ob, o.GetTime(), cs.MaximumOrderbookAge); isStale {
+			return handleStaleOrderbook(ob, o, f, funds, &cs, age)
+		}
+		if isCrossedOrderbook(ob) {
+			return e.handleCrossedOrderbook(ob, o, f, funds, &cs)
+		}
+		depth, baseDepth, isExhausted := exceedsOrderbookDepth(ob, o.GetDirection(), allocatedFunds)
+		if isExhausted && cs.DepthExhaustionBehaviour == DepthExhaustionReject {
+			return handleDepthExhaustion(o, f, funds, &cs, depth)
+		}
+		midpoint = orderbookMidpoint(ob)
+		preSlippagePrice = f.ClosePrice
+		if cs.SlippageModel != nil {
+			price, amount, err = applySlippageModel(f, &cs, f.ClosePrice, amount, ob)
+			if err != nil {
+				return handleSlippageModelError(o, f, funds, &cs, err)
+			}
+		} else {
+			// calculate an estimated slippage rate
+			price, amount = slippage.CalculateSlippageByOrderbook(ob, o.GetDirection(), allocatedFunds, f.ExchangeFee)
+		}
+		if isExhausted {
+			amount = decimal.Min(amount, baseDepth)
+			f.AppendReasonf("Order of %v exceeds orderbook depth of %v, filling %v against the available depth leaving the remainder unfilled", allocatedFunds, depth, amount)
+		}
+		cappedPrice, isCapped := capSlippage(o.GetDirection(), f.ClosePrice, price, cs.MaxSlippagePercent)
+		if isCapped {
+			f.AppendReasonf("Slippage capped at %v%%, price adjusted from %v to %v", cs.MaxSlippagePercent, price, cappedPrice)
+			price = cappedPrice
+		}
 		f.Slippage = price.Sub(f.ClosePrice).Div(f.ClosePrice).Mul(decimal.NewFromInt(100))
+	} else if e.PriceOverride != nil {
+		overrideData, err = getExecutionData(d, &cs, f)
+		if err != nil {
+			return f, err
+		}
+		if e.restUntouchedLimit(o, f, overrideData.Latest()) {
+			return f, fmt.Errorf("%w limit order left open", ErrCannotTransact)
+		}
+		if e.holdDormantStop(o, f, overrideData.Latest()) {
+			return f, fmt.Errorf("%w stop order left dormant", ErrCannotTransact)
+		}
+		if e.holdPendingTakeProfit(o, f, overrideData.Latest()) {
+			return f, fmt.Errorf("%w take-profit order left pending", ErrCannotTransact)
+		}
+		if e.holdDormantTrailingStop(o, f, overrideData.Latest()) {
+			return f, fmt.Errorf("%w trailing stop order left dormant", ErrCannotTransact)
+		}
+		preSlippagePrice = price
+		adjustedPrice = price
+		amount = f.Amount
 	} else {
-		slippageRate := slippage.EstimateSlippagePercentage(cs.MinimumSlippageRate, cs.MaximumSlippageRate)
-		if cs.SkipCandleVolumeFitting || o.GetAssetType().IsFutures() {
-			f.VolumeAdjustedPrice = f.ClosePrice
+		var executionData data.Handler
+		executionData, err = getExecutionData(d, &cs, f)
+		if err != nil {
+			return f, err
+		}
+		if e.restUntouchedLimit(o, f, executionData.Latest()) {
+			return f, fmt.Errorf("%w limit order left open", ErrCannotTransact)
+		}
+		if e.holdDormantStop(o, f, executionData.Latest()) {
+			return f, fmt.Errorf("%w stop order left dormant", ErrCannotTransact)
+		}
+		if e.holdPendingTakeProfit(o, f, executionData.Latest()) {
+			return f, fmt.Errorf("%w take-profit order left pending", ErrCannotTransact)
+		}
+		if e.holdDormantTrailingStop(o, f, executionData.Latest()) {
+			return f, fmt.Errorf("%w trailing stop order left dormant", ErrCannotTransact)
+		}
+		stopPrice := o.GetStopPrice()
+		if triggerPrice := f.GetTrailingStopTriggerPrice(); triggerPrice.IsPositive() {
+			stopPrice = triggerPrice
+		}
+		if stopPrice.IsPositive() {
+			stoppedPrice := stopFillPrice(f.GetDirection(), stopPrice, price)
+			switch {
+			case !stoppedPrice.Equal(price):
+				f.AppendReasonf("Price adjusted from %v to execute at stop price %v", price, stoppedPrice)
+				price = stoppedPrice
+			case !price.Equal(stopPrice):
+				f.AppendReasonf("Price of %v gapped past stop price %v, executing at the worse price", price, stopPrice)
+			}
+		}
+		rangePercent, isExtreme := isExtremeVolatility(executionData.Latest(), cs.ExtremeVolatilityThreshold)
+		if isExtreme && !o.IsLiquidating() && cs.ExtremeVolatilityBehaviour != ExtremeVolatilityAmplifySlippage {
+			return handleExtremeVolatility(o, f, funds, &cs, rangePercent)
+		}
+		if cs.SkipCandleVolumeFitting || o.GetAssetType().IsFutures() || isClosingOrder(o) {
 			amount = f.Amount
 		} else {
-			highStr := data.StreamHigh()
-			high := highStr[len(highStr)-1]
-
-			lowStr := data.StreamLow()
-			low := lowStr[len(lowStr)-1]
-
-			volStr := data.StreamVol()
-			volume := volStr[len(volStr)-1]
+			high, low, volume, candles := aggregateHLV(executionData, cs.VolumeFitWindow)
+			if candles > 1 {
+				f.AppendReasonf("Fitting order against %v candles with high %v low %v volume %v", candles, high, low, volume)
+			}
 			adjustedPrice, adjustedAmount = ensureOrderFitsWithinHLV(price, amount, high, low, volume)
 			if !amount.Equal(adjustedAmount) {
-				f.AppendReasonf("Order size shrunk from %v to %v to fit candle", amount, adjustedAmount)
-				amount = adjustedAmount
+				switch cs.VolumeExceedBehaviour {
+				case VolumeExceedReject:
+					return handleCandleVolumeExceeded(o, f, funds, &cs, amount, adjustedAmount)
+				case VolumeExceedAllow:
+					f.AppendReasonf("Order size %v exceeds the %v fitting the candle's volume, filled in full as exceeding candle volume is allowed", amount, adjustedAmount)
+				default:
+					f.AppendReasonf("Order size shrunk from %v to %v to fit candle", amount, adjustedAmount)
+					amount = adjustedAmount
+					f.WasVolumeAdjusted = true
+					isVolumeLimited = true
+				}
 			}
 			if !adjustedPrice.Equal(price) {
 				f.AppendReasonf("Price adjusted fitting to candle from %v to %v", price, adjustedPrice)
 				price = adjustedPrice
 				f.VolumeAdjustedPrice = price
+				f.WasVolumeAdjusted = true
 			}
 		}
 		if amount.LessThanOrEqual(decimal.Zero) && f.GetAmount().GreaterThan(decimal.Zero) {
@@ -128,15 +273,87 @@ func (e *Exchange) ExecuteOrder(o order.Event, data data.Handler, orderManager *
 			f.AppendReasonf("amount set to 0, %s", errDataMayBeIncorrect)
 			return f, err
 		}
-		adjustedPrice, err = applySlippageToPrice(f.GetDirection(), price, slippageRate)
-		if err != nil {
-			return f, err
+		preSlippagePrice = price
+		if cs.SlippageModel != nil {
+			adjustedPrice, amount, err = applySlippageModel(f, &cs, price, amount, nil)
+			if err != nil {
+				return handleSlippageModelError(o, f, funds, &cs, err)
+			}
+			f.Slippage = adverseSlippagePercent(f.GetDirection(), price, adjustedPrice).Neg()
+		} else {
+			adjustedPrice, err = estimateSlippage(f, &cs, e.getSlippageRand(), executionData.Latest(), price, amount, rangePercent, isExtreme)
+			if err != nil {
+				return f, err
+			}
+		}
+		if !cs.AllowSlippagePriceImprovement {
+			flooredPrice, isFloored := floorSlippage(f.GetDirection(), price, adjustedPrice)
+			if isFloored {
+				f.AppendReasonf("Slippage floored at zero, price adjusted from %v to %v", adjustedPrice, flooredPrice)
+				adjustedPrice = flooredPrice
+				f.Slippage = decimal.Zero
+			}
+		}
+		cappedPrice, isCapped := capSlippage(f.GetDirection(), price, adjustedPrice, cs.MaxSlippagePercent)
+		if isCapped {
+			f.AppendReasonf("Slippage capped at %v%%, price adjusted from %v to %v", cs.MaxSlippagePercent, adjustedPrice, cappedPrice)
+			adjustedPrice = cappedPrice
+			f.Slippage = cs.MaxSlippagePercent.Neg()
 		}
 		if !adjustedPrice.Equal(price) {
 			f.AppendReasonf("Price has slipped from %v to %v", price, adjustedPrice)
 			price = adjustedPrice
 		}
-		f.Slippage = slippageRate.Mul(decimal.NewFromInt(100)).Sub(decimal.NewFromInt(100))
+		if limitPrice := o.GetLimitPrice(); limitPrice.IsPositive() {
+			if limitedPrice := limitFillPrice(f.GetDirection(), limitPrice, price); !limitedPrice.Equal(price) {
+				f.AppendReasonf("Price adjusted from %v to fill at limit price %v", price, limitedPrice)
+				price = limitedPrice
+				adjustedPrice = limitedPrice
+			}
+		}
+		// the take-profit fills at its target or better, so the adjusted price
+		// used to fit the amount within portfolio limits is the target price
+		if target := o.GetTakeProfitPrice(); target.IsPositive() {
+			if targetPrice := limitFillPrice(f.GetDirection(), target, price); !targetPrice.Equal(price) {
+				f.AppendReasonf("Price adjusted from %v to fill at take-profit target %v", price, targetPrice)
+				price = targetPrice
+				adjustedPrice = targetPrice
+			}
+		}
+	}
+
+	var liquidationPriceMovement decimal.Decimal
+	if o.IsLiquidating() {
+		if penalisedPrice := liquidationPenaltyPrice(f.GetDirection(), price, cs.LiquidationSlippagePercent); !penalisedPrice.Equal(price) {
+			f.AppendReasonf("Liquidation penalty slippage of %v%% moved price from %v to %v", cs.LiquidationSlippagePercent, price, penalisedPrice)
+			liquidationPriceMovement = penalisedPrice.Sub(price).Abs()
+			price = penalisedPrice
+		}
+	}
+
+	if adverseSlippage, exceeded := exceedsSlippageTolerance(f.GetDirection(), preSlippagePrice, price, o.GetMaxSlippageTolerance()); exceeded {
+		f.AppendReasonf("Order cancelled as slippage of %v%% exceeds tolerance of %v%%", adverseSlippage.Round(4), o.GetMaxSlippageTolerance())
+		err = allocateFundsPostOrder(f, funds, errSlippageToleranceExceeded, o.GetAmount(), allocatedFunds, decimal.Zero, decimal.Zero, decimal.Zero, &cs)
+		if err != nil && !errors.Is(err, errSlippageToleranceExceeded) {
+			return f, err
+		}
+		f.SetDirection(gctorder.DoNothing)
+		return f, nil
+	}
+
+	pricePrecision, amountPrecision := getPrecision(&cs)
+	if roundedPrice := common.Round(price, pricePrecision, cs.RoundingMode); !roundedPrice.Equal(price) {
+		f.AppendReasonf("Price rounded from %v to %v to match exchange price precision", price, roundedPrice)
+		price = roundedPrice
+	}
+	adjustedPrice = common.Round(adjustedPrice, pricePrecision, cs.RoundingMode)
+	if tickPrice := conformToPriceTick(price, &cs); !tickPrice.Equal(price) {
+		f.AppendReasonf("Price rounded from %v to %v to match %v price tick size of %v", price, tickPrice, o.Pair(), cs.Limits.PriceStepIncrementSize)
+		price = tickPrice
+	}
+	adjustedPrice = conformToPriceTick(adjustedPrice, &cs)
+	if err = validateExecutionInputs(price, amount); err != nil {
+		return handleInvalidExecutionInputs(o, f, funds, &cs, err)
 	}
 
 	adjustedAmount = reduceAmountToFitPortfolioLimit(adjustedPrice, amount, allocatedFunds, f.GetDirection())
@@ -149,20 +366,93 @@ func (e *Exchange) ExecuteOrder(o order.Event, data data.Handler, orderManager *
 		// Conforms the amount to the exchange order defined step amount
 		// reducing it when needed
 		adjustedAmount = cs.Limits.ConformToDecimalAmount(amount)
+		if !o.IsLiquidating() && roundsToZero(amount, adjustedAmount) {
+			return handleAmountRoundedToZero(o, f, funds, &cs, amount, fmt.Sprintf("exchange step size %v", cs.Limits.AmountStepIncrementSize))
+		}
 		if !adjustedAmount.Equal(amount) {
 			f.AppendReasonf("Order size shrunk from %v to %v to remain within exchange step amount limits",
-				adjustedAmount,
-				amount)
+				amount,
+				adjustedAmount)
 			amount = adjustedAmount
 		}
 	}
-	err = verifyOrderWithinLimits(f, amount, &cs)
-	if err != nil {
-		return f, err
+	if truncatedAmount := amount.Truncate(amountPrecision); !truncatedAmount.Equal(amount) {
+		if !o.IsLiquidating() && roundsToZero(amount, truncatedAmount) {
+			return handleAmountRoundedToZero(o, f, funds, &cs, amount, fmt.Sprintf("exchange amount precision of %v decimal places", amountPrecision))
+		}
+		f.AppendReasonf("Order size shrunk from %v to %v to match exchange amount precision", amount, truncatedAmount)
+		amount = truncatedAmount
+	}
+	if !o.IsLiquidating() && overrideData == nil {
+		if overfill, isOverfilled := slippageOverfill(f.GetDirection(), price, amount, allocatedFunds); isOverfilled {
+			var shrunkAmount decimal.Decimal
+			if cs.SlippageOverfillBehaviour != SlippageOverfillReject {
+				shrunkAmount = shrinkAmountToFitFunds(price, allocatedFunds, amountPrecision, &cs)
+			}
+			if !shrunkAmount.IsPositive() {
+				return handleSlippageOverfill(o, f, funds, &cs, overfill)
+			}
+			f.AppendReasonf("Order size shrunk from %v to %v as notional exceeded allocated funds by %v after slippage", amount, shrunkAmount, overfill)
+			amount = shrunkAmount
+		}
+	}
+	if !isClosingOrder(o) {
+		err = verifyOrderWithinLimits(f, amount, &cs)
+		if err != nil {
+			return f, err
+		}
 	}
 
-	fee = calculateExchangeFee(price, amount, cs.TakerFee)
-	orderID, err := e.placeOrder(context.TODO(), price, amount, fee, cs.UseRealOrders, cs.CanUseExchangeLimits, f, orderManager)
+	if overrideData != nil {
+		price, err = e.overrideFillPrice(f, overrideData, &cs)
+		if err != nil {
+			return f, allocateFundsPostOrder(f, funds, err, o.GetAmount(), allocatedFunds, decimal.Zero, decimal.Zero, decimal.Zero, &cs)
+		}
+		adjustedPrice = price
+		adjustedAmount = reduceAmountToFitPortfolioLimit(price, amount, allocatedFunds, f.GetDirection()).Truncate(amountPrecision)
+		if !adjustedAmount.Equal(amount) {
+			f.AppendReasonf("Order size shrunk from %v to %v to remain within allocated funds at the overridden price", amount, adjustedAmount)
+			amount = adjustedAmount
+		}
+	}
+
+	makerFee, takerFee := e.feeRatesForVolume(o, f, &cs)
+	feeRate := takerFee
+	f.FeeType = fill.FeeTypeTaker
+	if isMakerFill(o, &cs) {
+		feeRate = makerFee
+		f.FeeType = fill.FeeTypeMaker
+		f.AppendReasonf("Maker fee rate %v applied as the limit order filled passively", feeRate)
+	}
+	if feeOverride := o.GetFeeOverride(); feeOverride != nil {
+		f.AppendReasonf("Fee rate overridden from %v to %v", feeRate, *feeOverride)
+		feeRate = *feeOverride
+	}
+	feePrecision := getFeePrecision(&cs)
+	fee = common.Round(calculateExchangeFee(price, amount, feeRate), feePrecision, cs.RoundingMode)
+	if o.IsLiquidating() {
+		penaltyFee := common.Round(calculateExchangeFee(price, amount, cs.LiquidationFeeRate), feePrecision, cs.RoundingMode)
+		if penaltyFee.IsPositive() {
+			f.AppendReasonf("Liquidation penalty fee of %v charged at rate %v", penaltyFee, cs.LiquidationFeeRate)
+			fee = fee.Add(penaltyFee)
+		}
+		f.LiquidationCost = liquidationPriceMovement.Mul(amount).Add(penaltyFee)
+	}
+	if !o.IsLiquidating() && o.GetAssetType() == asset.Spot {
+		if shortfall, isShort := feeShortfall(f.GetDirection(), adjustedPrice, amount, fee, allocatedFunds); isShort {
+			var shrunkAmount decimal.Decimal
+			if cs.FeeShortfallBehaviour != FeeShortfallReject && f.GetDirection().IsLong() {
+				shrunkAmount = shrinkAmountToCoverFee(adjustedPrice, price, feeRate, allocatedFunds, amountPrecision)
+			}
+			if !shrunkAmount.IsPositive() {
+				return handleFeeShortfall(o, f, funds, &cs, shortfall, fee)
+			}
+			f.AppendReasonf("Order size shrunk from %v to %v as allocated funds could not cover fee %v", amount, shrunkAmount, fee)
+			amount = shrunkAmount
+			fee = common.Round(calculateExchangeFee(price, amount, feeRate), feePrecision, cs.RoundingMode)
+		}
+	}
+	orderID, err := e.placeOrder(ctx, price, amount, fee, &cs, f, orderManager)
 	if err != nil {
 		return f, err
 	}
@@ -178,13 +468,22 @@ func (e *Exchange) ExecuteOrder(o order.Event, data data.Handler, orderManager *
 		f.Order = &ords[i]
 		f.PurchasePrice = decimal.NewFromFloat(ords[i].Price)
 		f.Amount = decimal.NewFromFloat(ords[i].Amount)
-		if ords[i].Fee > 0 {
+		if ords[i].Fee != 0 {
+			// negative fees are maker rebates and are kept as such
 			f.ExchangeFee = decimal.NewFromFloat(ords[i].Fee)
 		}
 		f.Total = f.PurchasePrice.Mul(f.Amount).Add(f.ExchangeFee)
 	}
+	if !midpoint.IsZero() {
+		setEffectiveSpread(f, midpoint)
+	}
+	var remaining, remainingFunds decimal.Decimal
+	if isVolumeLimited && cs.AllowPartialFills && !o.IsLiquidating() {
+		remaining, remainingFunds = partialFillRemainder(o, amount, adjustedPrice, fee, &cs)
+	}
 	if !o.IsLiquidating() {
-		err = allocateFundsPostOrder(f, funds, err, o.GetAmount(), allocatedFunds, amount, adjustedPrice, fee)
+		// funds for any remainder stay reserved to fill on subsequent candles
+		err = allocateFundsPostOrder(f, funds, err, o.GetAmount(), allocatedFunds.Sub(remainingFunds), amount, adjustedPrice, fee, &cs)
 		if err != nil {
 			return f, err
 		}
@@ -193,17 +492,57 @@ func (e *Exchange) ExecuteOrder(o order.Event, data data.Handler, orderManager *
 	if f.Order == nil {
 		return nil, fmt.Errorf("placed order %v not found in order manager", orderID)
 	}
+	if stopPrice := o.GetStopPrice(); stopPrice.IsPositive() {
+		f.AppendReasonf("Stop order triggered at %v executed at %v", stopPrice, f.PurchasePrice)
+	}
+	if target := o.GetTakeProfitPrice(); target.IsPositive() {
+		f.AppendReasonf("Take-profit order targeting %v executed at %v", target, f.PurchasePrice)
+	}
+	if triggerPrice := f.GetTrailingStopTriggerPrice(); triggerPrice.IsPositive() {
+		f.AppendReasonf("Trailing stop order triggered at %v from high-water mark %v executed at %v", triggerPrice, f.GetTrailingStopHighWaterMark(), f.PurchasePrice)
+	}
+	if remaining.IsPositive() {
+		e.carryPartialFill(o, f, amount, remaining, remainingFunds)
+	}
+	assignFillTime(f, d, &cs)
+	e.updateCapitalAllocation(o, f, &cs)
+	e.updatePositionEntry(o, f)
+	e.updateLosingStreak(o, f, &cs)
+	e.addTradedVolume(o, f)
 
 	return f, nil
 }
 
-func allocateFundsPostOrder(f *fill.Fill, funds funding.IFundReleaser, orderError error, orderAmount, allocatedFunds, limitReducedAmount, adjustedPrice, fee decimal.Decimal) error {
+func allocateFundsPostOrder(f *fill.Fill, funds funding.IFundReleaser, orderError error, orderAmount, allocatedFunds, limitReducedAmount, adjustedPrice, fee decimal.Decimal, cs *Settings) error {
 	if f == nil {
 		return fmt.Errorf("%w: fill event", common.ErrNilEvent)
 	}
 	if funds == nil {
 		return fmt.Errorf("%w: funding", common.ErrNilArguments)
 	}
+	pricePrecision, amountPrecision := getPrecision(cs)
+	quotePrecision, roundQuote := getQuotePrecision(cs)
+	roundQuoteFunds := func(amount decimal.Decimal) decimal.Decimal {
+		if !roundQuote {
+			return amount
+		}
+		return amount.RoundFloor(quotePrecision)
+	}
+	record := func(operation string, code currency.Code, reservedChange, availableChange decimal.Decimal) {
+		if cs != nil && cs.RecordFundingLedger {
+			f.FundingLedger = append(f.FundingLedger, fill.LedgerEntry{
+				Operation:       operation,
+				Currency:        code,
+				ReservedChange:  reservedChange,
+				AvailableChange: availableChange,
+			})
+		}
+	}
+	reservedCurrency, acquiredCurrency := f.CurrencyPair.Quote, f.CurrencyPair.Base
+	switch f.GetDirection() {
+	case gctorder.Sell, gctorder.Ask, gctorder.ClosePosition:
+		reservedCurrency, acquiredCurrency = f.CurrencyPair.Base, f.CurrencyPair.Quote
+	}
 
 	switch f.AssetType {
 	case asset.Spot:
@@ -215,6 +554,9 @@ func allocateFundsPostOrder(f *fill.Fill, funds funding.IFundReleaser, orderErro
 			err = pr.Release(allocatedFunds, allocatedFunds, f.GetDirection())
 			if err != nil {
 				f.AppendReason(err.Error())
+			} else {
+				record(fill.LedgerReserve, reservedCurrency, allocatedFunds, allocatedFunds.Neg())
+				record(fill.LedgerRelease, reservedCurrency, allocatedFunds.Neg(), allocatedFunds)
 			}
 			switch f.GetDirection() {
 			case gctorder.Buy, gctorder.Bid:
@@ -225,9 +567,15 @@ func allocateFundsPostOrder(f *fill.Fill, funds funding.IFundReleaser, orderErro
 			return orderError
 		}
 
+		rebate := withheldRebate(fee, cs)
+		if rebate.IsPositive() {
+			f.AccumulatedRebate = rebate
+			f.AppendReasonf("Rebate of %v accumulated separately from available funds", rebate)
+		}
 		switch f.GetDirection() {
 		case gctorder.Buy, gctorder.Bid:
-			err = pr.Release(allocatedFunds, allocatedFunds.Sub(limitReducedAmount.Mul(adjustedPrice).Add(fee)), f.GetDirection())
+			diff := roundQuoteFunds(allocatedFunds.Sub(limitReducedAmount.Mul(adjustedPrice).Add(fee).Add(rebate)))
+			err = pr.Release(allocatedFunds, diff, f.GetDirection())
 			if err != nil {
 				return err
 			}
@@ -235,19 +583,27 @@ func allocateFundsPostOrder(f *fill.Fill, funds funding.IFundReleaser, orderErro
 			if err != nil {
 				return err
 			}
+			record(fill.LedgerReserve, reservedCurrency, allocatedFunds, allocatedFunds.Neg())
+			record(fill.LedgerRelease, reservedCurrency, allocatedFunds.Neg(), diff)
+			record(fill.LedgerIncreaseAvailable, acquiredCurrency, decimal.Zero, limitReducedAmount)
 		case gctorder.Sell, gctorder.Ask:
-			err = pr.Release(allocatedFunds, allocatedFunds.Sub(limitReducedAmount), f.GetDirection())
+			diff := allocatedFunds.Sub(limitReducedAmount)
+			err = pr.Release(allocatedFunds, diff, f.GetDirection())
 			if err != nil {
 				return err
 			}
-			err = pr.IncreaseAvailable(limitReducedAmount.Mul(adjustedPrice).Sub(fee), f.GetDirection())
+			proceeds := roundQuoteFunds(limitReducedAmount.Mul(adjustedPrice).Sub(fee).Sub(rebate))
+			err = pr.IncreaseAvailable(proceeds, f.GetDirection())
 			if err != nil {
 				return err
 			}
+			record(fill.LedgerReserve, reservedCurrency, allocatedFunds, allocatedFunds.Neg())
+			record(fill.LedgerRelease, reservedCurrency, allocatedFunds.Neg(), diff)
+			record(fill.LedgerIncreaseAvailable, acquiredCurrency, decimal.Zero, proceeds)
 		default:
 			return fmt.Errorf("%w asset type %v", common.ErrInvalidDataType, f.GetDirection())
 		}
-		f.AppendReason(summarisePosition(f.GetDirection(), f.Amount, f.Amount.Mul(f.PurchasePrice), f.ExchangeFee, f.Order.Pair, currency.EMPTYPAIR))
+		f.AppendReason(summarisePosition(f.GetDirection(), f.Amount, f.Amount.Mul(f.PurchasePrice), f.ExchangeFee, f.Order.Pair, currency.EMPTYPAIR, pricePrecision, amountPrecision))
 	case asset.Futures:
 		cr, err := funds.CollateralReleaser()
 		if err != nil {
@@ -258,6 +614,7 @@ func allocateFundsPostOrder(f *fill.Fill, funds funding.IFundReleaser, orderErro
 			if err != nil {
 				return err
 			}
+			record(fill.LedgerReleaseContracts, cr.ContractCurrency(), decimal.Zero, orderAmount.Neg())
 			switch f.GetDirection() {
 			case gctorder.Short:
 				f.SetDirection(gctorder.CouldNotShort)
@@ -268,14 +625,14 @@ func allocateFundsPostOrder(f *fill.Fill, funds funding.IFundReleaser, orderErro
 			}
 			return orderError
 		}
-		f.AppendReason(summarisePosition(f.GetDirection(), f.Amount, f.Amount.Mul(f.PurchasePrice), f.ExchangeFee, f.Order.Pair, f.UnderlyingPair))
+		f.AppendReason(summarisePosition(f.GetDirection(), f.Amount, f.Amount.Mul(f.PurchasePrice), f.ExchangeFee, f.Order.Pair, f.UnderlyingPair, pricePrecision, amountPrecision))
 	default:
 		return fmt.Errorf("%w asset type %v", common.ErrInvalidDataType, f.AssetType)
 	}
 	return nil
 }
 
-func summarisePosition(direction gctorder.Side, orderAmount, orderTotal, orderFee decimal.Decimal, pair, underlying currency.Pair) string {
+func summarisePosition(direction gctorder.Side, orderAmount, orderTotal, orderFee decimal.Decimal, pair, underlying currency.Pair, pricePrecision, amountPrecision int32) string {
 	baseCurr := pair.Base.String()
 	quoteCurr := pair.Quote
 	if !underlying.IsEmpty() {
@@ -284,17 +641,68 @@ func summarisePosition(direction gctorder.Side, orderAmount, orderTotal, orderFe
 	}
 	return fmt.Sprintf("Placed %s order of %v %v for %v %v, with %v %v in fees, totalling %v %v",
 		direction,
-		orderAmount.Round(8),
+		orderAmount.Round(amountPrecision),
 		baseCurr,
-		orderTotal.Round(8),
+		orderTotal.Round(pricePrecision),
 		quoteCurr,
-		orderFee.Round(8),
+		orderFee,
 		quoteCurr,
-		orderTotal.Add(orderFee).Round(8),
+		orderTotal.Round(pricePrecision).Add(orderFee),
 		quoteCurr,
 	)
 }
 
+// getPrecision returns the price and amount decimal places derived from the
+// exchange limit step increment sizes, falling back to the default precision
+// when exchange limits are not in use or not configured
+func getPrecision(cs *Settings) (pricePrecision, amountPrecision int32) {
+	pricePrecision, amountPrecision = defaultPrecision, defaultPrecision
+	if cs == nil || !cs.CanUseExchangeLimits {
+		return pricePrecision, amountPrecision
+	}
+	if cs.Limits.PriceStepIncrementSize > 0 {
+		pricePrecision = precisionFromStep(cs.Limits.PriceStepIncrementSize)
+	}
+	if cs.Limits.AmountStepIncrementSize > 0 {
+		amountPrecision = precisionFromStep(cs.Limits.AmountStepIncrementSize)
+	}
+	return pricePrecision, amountPrecision
+}
+
+// getQuotePrecision returns the decimal places quote currency funding is
+// rounded to and whether quote funding should be rounded at all
+func getQuotePrecision(cs *Settings) (precision int32, round bool) {
+	if cs == nil || !cs.RoundQuoteFunding {
+		return 0, false
+	}
+	if cs.QuotePrecision > 0 {
+		return cs.QuotePrecision, true
+	}
+	pricePrecision, _ := getPrecision(cs)
+	return pricePrecision, true
+}
+
+// getFeePrecision returns the decimal places fees are rounded to. Fees are
+// charged in the quote currency so are rounded to the quote precision rather
+// than the price tick, which would round sub-unit fees away when the tick is a
+// whole number. A zero QuotePrecision uses the default precision
+func getFeePrecision(cs *Settings) int32 {
+	if cs == nil || cs.QuotePrecision <= 0 {
+		return defaultPrecision
+	}
+	return cs.QuotePrecision
+}
+
+// precisionFromStep returns the number of decimal places in a step increment
+// size eg 0.01 returns 2
+func precisionFromStep(step float64) int32 {
+	exponent := decimal.NewFromFloat(step).Exponent()
+	if exponent >= 0 {
+		return 0
+	}
+	return -exponent
+}
+
 // verifyOrderWithinLimits conforms the amount to fall into the minimum size and maximum size limit after reduced
 func verifyOrderWithinLimits(f fill.Event, amount decimal.Decimal, cs *Settings) error {
 	if f == nil {
@@ -352,12 +760,12 @@ func verifyOrderWithinLimits(f fill.Event, amount decimal.Decimal, cs *Settings)
 func reduceAmountToFitPortfolioLimit(adjustedPrice, amount, sizedPortfolioTotal decimal.Decimal, side gctorder.Side) decimal.Decimal {
 	switch side {
 	case gctorder.Buy, gctorder.Bid:
-		if adjustedPrice.Mul(amount).GreaterThan(sizedPortfolioTotal) {
+		if adjustedPrice.IsPositive() && adjustedPrice.Mul(amount).GreaterThan(sizedPortfolioTotal) {
 			// adjusted amounts exceeds portfolio manager's allowed funds
 			// the amount has to be reduced to equal the sizedPortfolioTotal
 			amount = sizedPortfolioTotal.Div(adjustedPrice)
 		}
-	case gctorder.Sell, gctorder.Ask:
+	case gctorder.Sell, gctorder.Ask, gctorder.ClosePosition:
 		if amount.GreaterThan(sizedPortfolioTotal) {
 			amount = sizedPortfolioTotal
 		}
@@ -365,10 +773,13 @@ func reduceAmountToFitPortfolioLimit(adjustedPrice, amount, sizedPortfolioTotal
 	return amount
 }
 
-func (e *Exchange) placeOrder(ctx context.Context, price, amount, fee decimal.Decimal, useRealOrders, useExchangeLimits bool, f fill.Event, orderManager *engine.OrderManager) (string, error) {
+func (e *Exchange) placeOrder(ctx context.Context, price, amount, fee decimal.Decimal, cs *Settings, f *fill.Fill, orderManager *engine.OrderManager) (string, error) {
 	if f == nil {
 		return "", common.ErrNilEvent
 	}
+	if cs == nil {
+		return "", errNilCurrencySettings
+	}
 	orderID, err := uuid.NewV4()
 	if err != nil {
 		return "", err
@@ -383,47 +794,211 @@ func (e *Exchange) placeOrder(ctx context.Context, price, amount, fee decimal.De
 		Pair:      f.Pair(),
 		Type:      gctorder.Market,
 	}
+	if f.LimitPrice.IsPositive() {
+		submit.Type = gctorder.Limit
+	}
 
-	var resp *engine.OrderSubmitResponse
-	if useRealOrders {
-		resp, err = orderManager.Submit(ctx, submit)
-	} else {
-		var submitResponse *gctorder.SubmitResponse
-		submitResponse, err = submit.DeriveSubmitResponse(orderID.String())
+	if cs.UseRealOrders {
+		// the client order ID allows a submission which failed in transit
+		// to be found on the exchange rather than submitted again
+		submit.ClientOrderID = orderID.String()
+		if submit.Type == gctorder.Limit {
+			submit.Price = f.LimitPrice.InexactFloat64()
+		}
+		var realOrderID string
+		submittedAt := time.Now()
+		realOrderID, f.SubmissionRetries, err = submitWithRetries(ctx, cs.SubmissionRetries, cs.SubmissionRetryBackoff,
+			func(ctx context.Context) (string, error) {
+				resp, submitErr := orderManager.Submit(ctx, submit)
+				if submitErr != nil {
+					return "", submitErr
+				}
+				return resp.OrderID, nil
+			},
+			func(ctx context.Context) (string, bool) {
+				return findSubmittedOrder(ctx, cs.Exchange, orderManager, submit, submittedAt)
+			})
+		if f.SubmissionRetries > 0 {
+			f.AppendReasonf("Order submission retried %v times", f.SubmissionRetries)
+		}
 		if err != nil {
 			return orderID.String(), err
 		}
-		submitResponse.Status = gctorder.Filled
-		submitResponse.OrderID = orderID.String()
-		submitResponse.Fee = fee.InexactFloat64()
-		submitResponse.Cost = submit.Price
-		submitResponse.LastUpdated = f.GetTime()
-		submitResponse.Date = f.GetTime()
-		resp, err = orderManager.SubmitFakeOrder(submit, submitResponse, useExchangeLimits)
+		return realOrderID, nil
+	}
+
+	submitResponse, err := submit.DeriveSubmitResponse(orderID.String())
+	if err != nil {
+		return orderID.String(), err
 	}
+	submitResponse.Status = gctorder.Filled
+	submitResponse.OrderID = orderID.String()
+	submitResponse.Fee = fee.InexactFloat64()
+	submitResponse.Cost = submit.Price
+	submitResponse.LastUpdated = f.GetTime()
+	submitResponse.Date = f.GetTime()
+	resp, err := orderManager.SubmitFakeOrder(submit, submitResponse, cs.CanUseExchangeLimits)
 	if err != nil {
 		return orderID.String(), err
 	}
 	return resp.OrderID, nil
 }
 
+// applySlippageToPrice moves the price against the trade direction by the
+// slippage rate. The rate is a multiplier where 0.99 represents 1% slippage,
+// so buys are mirrored above the price by the same fraction sells receive
+// below it. An unset rate leaves the price unchanged for every direction
 func applySlippageToPrice(direction gctorder.Side, price, slippageRate decimal.Decimal) (decimal.Decimal, error) {
 	var adjustedPrice decimal.Decimal
 	switch direction {
 	case gctorder.Buy, gctorder.Bid, gctorder.Long:
-		adjustedPrice = price.Add(price.Mul(decimal.NewFromInt(1).Sub(slippageRate)))
+		adjustedPrice = price.Mul(decimal.NewFromInt(2).Sub(slippageRate))
 	case gctorder.Sell, gctorder.Ask, gctorder.Short:
 		adjustedPrice = price.Mul(slippageRate)
 	default:
 		return decimal.Zero, fmt.Errorf("%v %w", direction, gctorder.ErrSideIsInvalid)
 	}
-	if adjustedPrice.IsZero() {
+	if slippageRate.IsZero() || adjustedPrice.IsZero() {
 		adjustedPrice = price
 	}
 
 	return adjustedPrice, nil
 }
 
+// floorSlippage clamps a slipped price which improves on the reference price
+// for the trade direction back to the reference price, so that slippage is
+// always a cost or neutral
+func floorSlippage(direction gctorder.Side, referencePrice, slippedPrice decimal.Decimal) (decimal.Decimal, bool) {
+	switch direction {
+	case gctorder.Buy, gctorder.Bid, gctorder.Long:
+		if slippedPrice.LessThan(referencePrice) {
+			return referencePrice, true
+		}
+	case gctorder.Sell, gctorder.Ask, gctorder.Short:
+		if slippedPrice.GreaterThan(referencePrice) {
+			return referencePrice, true
+		}
+	}
+	return slippedPrice, false
+}
+
+// capSlippage clamps the slipped price so that its difference from the
+// reference price does not exceed the maximum slippage percent. A maximum
+// slippage percent of zero disables the cap
+func capSlippage(direction gctorder.Side, referencePrice, slippedPrice, maxSlippagePercent decimal.Decimal) (decimal.Decimal, bool) {
+	if maxSlippagePercent.LessThanOrEqual(decimal.Zero) || referencePrice.IsZero() {
+		return slippedPrice, false
+	}
+	maxMovement := referencePrice.Mul(maxSlippagePercent).Div(decimal.NewFromInt(100))
+	switch direction {
+	case gctorder.Buy, gctorder.Bid, gctorder.Long:
+		if upper := referencePrice.Add(maxMovement); slippedPrice.GreaterThan(upper) {
+			return upper, true
+		}
+	case gctorder.Sell, gctorder.Ask, gctorder.Short:
+		if lower := referencePrice.Sub(maxMovement); slippedPrice.LessThan(lower) {
+			return lower, true
+		}
+	}
+	return slippedPrice, false
+}
+
+// getExecutionData returns the data orders are executed against, being the
+// finer execution data when set, aligned with the signal data
+func getExecutionData(signalData data.Handler, cs *Settings, f *fill.Fill) (data.Handler, error) {
+	if cs.ExecutionData == nil {
+		return signalData, nil
+	}
+	err := alignExecutionData(signalData, cs.ExecutionData)
+	if err != nil {
+		return nil, err
+	}
+	f.AppendReasonf("Executing against %v candle at %v", cs.ExecutionData.Latest().GetInterval(), cs.ExecutionData.Latest().GetTime())
+	return cs.ExecutionData, nil
+}
+
+// overrideFillPrice supplies the final fill price from the PriceOverride using
+// the execution candle's high, low, close and volume. The high, low and volume
+// span the volume fit window when set
+func (e *Exchange) overrideFillPrice(f *fill.Fill, executionData data.Handler, cs *Settings) (decimal.Decimal, error) {
+	high, low, volume, _ := aggregateHLV(executionData, cs.VolumeFitWindow)
+	closes := executionData.StreamClose()
+	overridePrice := e.PriceOverride(f, high, low, closes[len(closes)-1], volume)
+	if !overridePrice.IsPositive() {
+		return decimal.Zero, fmt.Errorf("%w %v", errInvalidOverridePrice, overridePrice)
+	}
+	pricePrecision, _ := getPrecision(cs)
+	overridePrice = common.Round(overridePrice, pricePrecision, cs.RoundingMode)
+	f.AppendReasonf("Fill price overridden from %v to %v", f.ClosePrice, overridePrice)
+	if !f.ClosePrice.IsZero() {
+		f.Slippage = overridePrice.Sub(f.ClosePrice).Div(f.ClosePrice).Mul(decimal.NewFromInt(100))
+	}
+	return overridePrice, nil
+}
+
+// alignExecutionData advances finer timeframe execution data to the candle
+// aligned with the latest signal candle. The aligned candle is the last
+// execution candle which closes at or before the signal candle closes. It must
+// also open at or after the signal candle opens, otherwise execution data is
+// missing for the signal candle. Execution data only moves forward, so offsets
+// remain coordinated regardless of how often orders are placed
+func alignExecutionData(signalData, executionData data.Handler) error {
+	if signalData == nil || executionData == nil {
+		return fmt.Errorf("%w data handler", common.ErrNilArguments)
+	}
+	signal := signalData.Latest()
+	if signal == nil {
+		return fmt.Errorf("%w signal data", errNoAlignedExecutionData)
+	}
+	signalClose := signal.GetTime().Add(signal.GetInterval().Duration())
+	upcoming := executionData.List()
+	for i := range upcoming {
+		if upcoming[i].GetInterval() >= signal.GetInterval() {
+			return fmt.Errorf("%w execution interval %v must be smaller than signal interval %v",
+				errInvalidExecutionInterval, upcoming[i].GetInterval(), signal.GetInterval())
+		}
+		if upcoming[i].GetTime().Add(upcoming[i].GetInterval().Duration()).After(signalClose) {
+			break
+		}
+		executionData.Next()
+	}
+	if executionData.Offset() == 0 || executionData.Latest().GetTime().Before(signal.GetTime()) {
+		return fmt.Errorf("%w for signal candle at %v", errNoAlignedExecutionData, signal.GetTime())
+	}
+	return nil
+}
+
+// exceedsSlippageTolerance returns the adverse slippage percentage between the
+// reference and slipped prices and whether it exceeds the tolerance. Buys and
+// longs are adversely affected by higher prices, sells and shorts by lower.
+// A tolerance of zero disables the check
+func exceedsSlippageTolerance(direction gctorder.Side, referencePrice, slippedPrice, tolerance decimal.Decimal) (decimal.Decimal, bool) {
+	if tolerance.LessThanOrEqual(decimal.Zero) {
+		return decimal.Zero, false
+	}
+	adverse := adverseSlippagePercent(direction, referencePrice, slippedPrice)
+	return adverse, adverse.GreaterThan(tolerance)
+}
+
+// adverseSlippagePercent returns the percentage the slipped price moved
+// against the direction from the reference price. Buys and longs are
+// adversely affected by higher prices, sells and shorts by lower
+func adverseSlippagePercent(direction gctorder.Side, referencePrice, slippedPrice decimal.Decimal) decimal.Decimal {
+	if referencePrice.IsZero() {
+		return decimal.Zero
+	}
+	var adverse decimal.Decimal
+	switch direction {
+	case gctorder.Buy, gctorder.Bid, gctorder.Long:
+		adverse = slippedPrice.Sub(referencePrice)
+	case gctorder.Sell, gctorder.Ask, gctorder.Short:
+		adverse = referencePrice.Sub(slippedPrice)
+	default:
+		return decimal.Zero
+	}
+	return adverse.Div(referencePrice).Mul(decimal.NewFromInt(100))
+}
+
 // SetExchangeAssetCurrencySettings sets the settings for an exchange, asset, currency
 func (e *Exchange) SetExchangeAssetCurrencySettings(a asset.Item, cp currency.Pair, c *Settings) {
 	if c.Exchange == nil ||
@@ -457,6 +1032,31 @@ func (e *Exchange) GetCurrencySettings(exch string, a asset.Item, cp currency.Pa
 	return Settings{}, fmt.Errorf("%w for %v %v %v", errNoCurrencySettingsFound, exch, a, cp)
 }
 
+// aggregateHLV returns the highest high, lowest low and summed volume of the
+// latest window candles, modelling an order executed over several candles.
+// A window of one or less uses only the latest candle
+func aggregateHLV(d data.Handler, window int) (high, low, volume decimal.Decimal, candles int) {
+	highs := d.StreamHigh()
+	lows := d.StreamLow()
+	volumes := d.StreamVol()
+	if window < 1 {
+		window = 1
+	}
+	start := len(highs) - window
+	if start < 0 {
+		start = 0
+	}
+	high = highs[len(highs)-1]
+	low = lows[len(lows)-1]
+	for i := start; i < len(highs); i++ {
+		high = decimal.Max(high, highs[i])
+		low = decimal.Min(low, lows[i])
+		volume = volume.Add(volumes[i])
+		candles++
+	}
+	return high, low, volume, candles
+}
+
 func ensureOrderFitsWithinHLV(price, amount, high, low, volume decimal.Decimal) (adjustedPrice, adjustedAmount decimal.Decimal) {
 	adjustedPrice = price
 	if adjustedPrice.LessThan(low) {
@@ -10,6 +10,7 @@ import (
 	"github.com/shopspring/decimal"
 	"github.com/thrasher-corp/gocryptotrader/backtester/common"
 	"github.com/thrasher-corp/gocryptotrader/backtester/data/kline"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventhandlers/exchange/slippage"
 	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/event"
 	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/fill"
 	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/order"
@@ -67,6 +68,68 @@ func (f *fakeFund) Release(decimal.Decimal, decimal.Decimal, gctorder.Side) erro
 	return nil
 }
 
+// setupOfflineOrderManager creates a started order manager with an exchange
+// which has not been setup, allowing simulated orders to be placed without
+// any network access
+func setupOfflineOrderManager(t *testing.T) (*engine.OrderManager, exchange.IBotExchange) {
+	t.Helper()
+	bot := &engine.Engine{}
+	em := engine.SetupExchangeManager()
+	exch, err := em.NewExchangeByName(testExchange)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exch.SetDefaults()
+	em.Add(exch)
+	om, err := engine.SetupOrderManager(em, &engine.CommunicationManager{}, &bot.ServicesWG, false, false, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = om.Start()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return om, exch
+}
+
+// setupOfflineOrder creates an order event alongside loaded candle data
+// for use with setupOfflineOrderManager
+func setupOfflineOrder(t *testing.T, side gctorder.Side, amount, price decimal.Decimal, candles ...gctkline.Candle) (*order.Order, *kline.DataFromKline) {
+	t.Helper()
+	p := currency.NewPair(currency.BTC, currency.USDT)
+	o := &order.Order{
+		Base: &event.Base{
+			Offset:       int64(len(candles)),
+			Exchange:     testExchange,
+			Time:         time.Now(),
+			Interval:     gctkline.FifteenMin,
+			CurrencyPair: p,
+			AssetType:    asset.Spot,
+		},
+		Direction:      side,
+		Amount:         amount,
+		AllocatedFunds: decimal.NewFromInt(1337),
+		ClosePrice:     price,
+	}
+	d := &kline.DataFromKline{
+		Item: gctkline.Item{
+			Exchange: testExchange,
+			Pair:     p,
+			Asset:    asset.Spot,
+			Interval: gctkline.FifteenMin,
+			Candles:  candles,
+		},
+	}
+	err := d.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for range candles {
+		d.Next()
+	}
+	return o, d
+}
+
 func TestReset(t *testing.T) {
 	t.Parallel()
 	e := Exchange{
@@ -138,6 +201,60 @@ func TestCalculateExchangeFee(t *testing.T) {
 	}
 }
 
+func TestExecuteOrderFeeOverride(t *testing.T) {
+	t.Parallel()
+	om, exch := setupOfflineOrderManager(t)
+	cs := Settings{
+		Exchange:               exch,
+		TakerFee:               decimal.NewFromFloat(0.01),
+		MinimumSlippageRate:    decimal.NewFromInt(100),
+		MaximumSlippageRate:    decimal.NewFromInt(100),
+		SignalToFillConvention: SameCandleClose,
+	}
+	o, d := setupOfflineOrder(t, gctorder.Buy, decimal.NewFromInt(1), decimal.NewFromInt(100),
+		gctkline.Candle{Close: 100, High: 110, Low: 90, Volume: 1000})
+	cs.Pair = o.Pair()
+	cs.Asset = o.GetAssetType()
+	e := Exchange{CurrencySettings: []Settings{cs}}
+	f, err := e.ExecuteOrder(context.Background(), o, d, om, &fakeFund{})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	if !f.GetExchangeFee().Equal(decimal.NewFromInt(1)) {
+		t.Errorf("received '%v' expected '%v'", f.GetExchangeFee(), 1)
+	}
+
+	o, d = setupOfflineOrder(t, gctorder.Buy, decimal.NewFromInt(1), decimal.NewFromInt(100),
+		gctkline.Candle{Close: 100, High: 110, Low: 90, Volume: 1000})
+	zeroFee := decimal.Zero
+	o.FeeOverride = &zeroFee
+	f, err = e.ExecuteOrder(context.Background(), o, d, om, &fakeFund{})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	if !f.GetExchangeFee().IsZero() {
+		t.Errorf("received '%v' expected '%v'", f.GetExchangeFee(), 0)
+	}
+	if !f.GetTotal().Equal(decimal.NewFromInt(100)) {
+		t.Errorf("received '%v' expected '%v'", f.GetTotal(), 100)
+	}
+	if !strings.Contains(f.GetConcatReasons(), "Fee rate overridden") {
+		t.Errorf("expected fee override reason, received '%v'", f.GetConcatReasons())
+	}
+
+	o, d = setupOfflineOrder(t, gctorder.Buy, decimal.NewFromInt(1), decimal.NewFromInt(100),
+		gctkline.Candle{Close: 100, High: 110, Low: 90, Volume: 1000})
+	negativeFee := decimal.NewFromFloat(-0.01)
+	o.FeeOverride = &negativeFee
+	f, err = e.ExecuteOrder(context.Background(), o, d, om, &fakeFund{})
+	if !errors.Is(err, errInvalidFeeOverride) {
+		t.Fatalf("received '%v' expected '%v'", err, errInvalidFeeOverride)
+	}
+	if f.GetDirection() != gctorder.CouldNotBuy {
+		t.Errorf("received '%v' expected '%v'", f.GetDirection(), gctorder.CouldNotBuy)
+	}
+}
+
 func TestPlaceOrder(t *testing.T) {
 	t.Parallel()
 	bot := &engine.Engine{}
@@ -167,32 +284,32 @@ func TestPlaceOrder(t *testing.T) {
 		t.Error(err)
 	}
 	e := Exchange{}
-	_, err = e.placeOrder(context.Background(), decimal.NewFromInt(1), decimal.NewFromInt(1), decimal.Zero, false, true, nil, nil)
+	_, err = e.placeOrder(context.Background(), decimal.NewFromInt(1), decimal.NewFromInt(1), decimal.Zero, &Settings{CanUseExchangeLimits: true}, nil, nil)
 	if !errors.Is(err, common.ErrNilEvent) {
 		t.Errorf("received: %v, expected: %v", err, common.ErrNilEvent)
 	}
 	f := &fill.Fill{
 		Base: &event.Base{},
 	}
-	_, err = e.placeOrder(context.Background(), decimal.NewFromInt(1), decimal.NewFromInt(1), decimal.Zero, false, true, f, bot.OrderManager)
+	_, err = e.placeOrder(context.Background(), decimal.NewFromInt(1), decimal.NewFromInt(1), decimal.Zero, &Settings{CanUseExchangeLimits: true}, f, bot.OrderManager)
 	if !errors.Is(err, engine.ErrExchangeNameIsEmpty) {
 		t.Errorf("received: %v, expected: %v", err, engine.ErrExchangeNameIsEmpty)
 	}
 
 	f.Exchange = testExchange
-	_, err = e.placeOrder(context.Background(), decimal.NewFromInt(1), decimal.NewFromInt(1), decimal.Zero, false, true, f, bot.OrderManager)
+	_, err = e.placeOrder(context.Background(), decimal.NewFromInt(1), decimal.NewFromInt(1), decimal.Zero, &Settings{CanUseExchangeLimits: true}, f, bot.OrderManager)
 	if !errors.Is(err, gctorder.ErrPairIsEmpty) {
 		t.Errorf("received: %v, expected: %v", err, gctorder.ErrPairIsEmpty)
 	}
 	f.CurrencyPair = currency.NewPair(currency.BTC, currency.USDT)
 	f.AssetType = asset.Spot
 	f.Direction = gctorder.Buy
-	_, err = e.placeOrder(context.Background(), decimal.NewFromInt(1), decimal.NewFromInt(1), decimal.Zero, false, true, f, bot.OrderManager)
+	_, err = e.placeOrder(context.Background(), decimal.NewFromInt(1), decimal.NewFromInt(1), decimal.Zero, &Settings{CanUseExchangeLimits: true}, f, bot.OrderManager)
 	if err != nil {
 		t.Error(err)
 	}
 
-	_, err = e.placeOrder(context.Background(), decimal.NewFromInt(1), decimal.NewFromInt(1), decimal.Zero, true, true, f, bot.OrderManager)
+	_, err = e.placeOrder(context.Background(), decimal.NewFromInt(1), decimal.NewFromInt(1), decimal.Zero, &Settings{UseRealOrders: true, CanUseExchangeLimits: true}, f, bot.OrderManager)
 	if !errors.Is(err, exchange.ErrAuthenticationSupportNotEnabled) {
 		t.Errorf("received: %v but expected: %v", err, exchange.ErrAuthenticationSupportNotEnabled)
 	}
@@ -236,13 +353,14 @@ func TestExecuteOrder(t *testing.T) {
 	f := &ftx.FTX{}
 	f.Name = testExchange
 	cs := Settings{
-		Exchange:            f,
-		UseRealOrders:       false,
-		Pair:                p,
-		Asset:               a,
-		MakerFee:            decimal.NewFromFloat(0.01),
-		TakerFee:            decimal.NewFromFloat(0.01),
-		MaximumSlippageRate: decimal.NewFromInt(1),
+		Exchange:               f,
+		UseRealOrders:          false,
+		Pair:                   p,
+		Asset:                  a,
+		MakerFee:               decimal.NewFromFloat(0.01),
+		TakerFee:               decimal.NewFromFloat(0.01),
+		MaximumSlippageRate:    decimal.NewFromInt(1),
+		SignalToFillConvention: SameCandleClose,
 	}
 	e := Exchange{}
 	ev := &event.Base{
@@ -282,7 +400,7 @@ func TestExecuteOrder(t *testing.T) {
 		t.Error(err)
 	}
 	d.Next()
-	_, err = e.ExecuteOrder(o, d, bot.OrderManager, &fakeFund{})
+	_, err = e.ExecuteOrder(context.Background(), o, d, bot.OrderManager, &fakeFund{})
 	if !errors.Is(err, errNoCurrencySettingsFound) {
 		t.Error(err)
 	}
@@ -291,7 +409,7 @@ func TestExecuteOrder(t *testing.T) {
 	cs.CanUseExchangeLimits = true
 	o.Direction = gctorder.Sell
 	e.CurrencySettings = []Settings{cs}
-	_, err = e.ExecuteOrder(o, d, bot.OrderManager, &fakeFund{})
+	_, err = e.ExecuteOrder(context.Background(), o, d, bot.OrderManager, &fakeFund{})
 	if !errors.Is(err, exchange.ErrAuthenticationSupportNotEnabled) {
 		t.Errorf("received: %v but expected: %v", err, exchange.ErrAuthenticationSupportNotEnabled)
 	}
@@ -357,8 +475,9 @@ func TestExecuteOrderBuySellSizeLimit(t *testing.T) {
 		SellSide: MinMax{
 			MaximumSize: decimal.NewFromFloat(0.1),
 		},
-		MaximumSlippageRate: decimal.NewFromInt(1),
-		Limits:              limits,
+		MaximumSlippageRate:    decimal.NewFromInt(1),
+		Limits:                 limits,
+		SignalToFillConvention: SameCandleClose,
 	}
 	e := Exchange{
 		CurrencySettings: []Settings{cs},
@@ -398,7 +517,7 @@ func TestExecuteOrderBuySellSizeLimit(t *testing.T) {
 		t.Error(err)
 	}
 	d.Next()
-	_, err = e.ExecuteOrder(o, d, bot.OrderManager, &fakeFund{})
+	_, err = e.ExecuteOrder(context.Background(), o, d, bot.OrderManager, &fakeFund{})
 	if !errors.Is(err, errExceededPortfolioLimit) {
 		t.Errorf("received %v expected %v", err, errExceededPortfolioLimit)
 	}
@@ -411,7 +530,7 @@ func TestExecuteOrderBuySellSizeLimit(t *testing.T) {
 	cs.BuySide.MaximumSize = decimal.Zero
 	cs.BuySide.MinimumSize = decimal.NewFromFloat(0.01)
 	e.CurrencySettings = []Settings{cs}
-	_, err = e.ExecuteOrder(o, d, bot.OrderManager, &fakeFund{})
+	_, err = e.ExecuteOrder(context.Background(), o, d, bot.OrderManager, &fakeFund{})
 	if err != nil && !strings.Contains(err.Error(), "exceed minimum size") {
 		t.Error(err)
 	}
@@ -427,7 +546,7 @@ func TestExecuteOrderBuySellSizeLimit(t *testing.T) {
 	cs.SellSide.MaximumSize = decimal.Zero
 	cs.SellSide.MinimumSize = decimal.NewFromFloat(0.01)
 	e.CurrencySettings = []Settings{cs}
-	_, err = e.ExecuteOrder(o, d, bot.OrderManager, &fakeFund{})
+	_, err = e.ExecuteOrder(context.Background(), o, d, bot.OrderManager, &fakeFund{})
 	if err != nil && !strings.Contains(err.Error(), "exceed minimum size") {
 		t.Error(err)
 	}
@@ -444,7 +563,7 @@ func TestExecuteOrderBuySellSizeLimit(t *testing.T) {
 	cs.SellSide.MaximumSize = decimal.Zero
 	cs.SellSide.MinimumSize = decimal.NewFromInt(1)
 	e.CurrencySettings = []Settings{cs}
-	_, err = e.ExecuteOrder(o, d, bot.OrderManager, &fakeFund{})
+	_, err = e.ExecuteOrder(context.Background(), o, d, bot.OrderManager, &fakeFund{})
 	if !errors.Is(err, errExceededPortfolioLimit) {
 		t.Errorf("received %v expected %v", err, errExceededPortfolioLimit)
 	}
@@ -464,12 +583,65 @@ func TestExecuteOrderBuySellSizeLimit(t *testing.T) {
 	o.Direction = gctorder.Sell
 
 	e.CurrencySettings = []Settings{cs}
-	_, err = e.ExecuteOrder(o, d, bot.OrderManager, &fakeFund{})
+	_, err = e.ExecuteOrder(context.Background(), o, d, bot.OrderManager, &fakeFund{})
 	if !errors.Is(err, exchange.ErrAuthenticationSupportNotEnabled) {
 		t.Errorf("received: %v but expected: %v", err, exchange.ErrAuthenticationSupportNotEnabled)
 	}
 }
 
+func TestExecuteOrderVolumeAdjustedPrice(t *testing.T) {
+	t.Parallel()
+	om, exch := setupOfflineOrderManager(t)
+	o, d := setupOfflineOrder(t, gctorder.Buy, decimal.NewFromInt(1), decimal.NewFromInt(100),
+		gctkline.Candle{Close: 100, High: 110, Low: 90, Volume: 100})
+	cs := Settings{
+		Exchange:                exch,
+		Pair:                    o.Pair(),
+		Asset:                   o.GetAssetType(),
+		SkipCandleVolumeFitting: true,
+		SignalToFillConvention:  SameCandleClose,
+	}
+	e := Exchange{CurrencySettings: []Settings{cs}}
+	f, err := e.ExecuteOrder(context.Background(), o, d, om, &fakeFund{})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	if !f.GetVolumeAdjustedPrice().Equal(o.ClosePrice) {
+		t.Errorf("received '%v' expected '%v'", f.GetVolumeAdjustedPrice(), o.ClosePrice)
+	}
+	if f.IsVolumeAdjusted() {
+		t.Error("expected no volume adjustment")
+	}
+
+	cs.SkipCandleVolumeFitting = false
+	e.CurrencySettings = []Settings{cs}
+	o, d = setupOfflineOrder(t, gctorder.Buy, decimal.NewFromInt(1), decimal.NewFromInt(100),
+		gctkline.Candle{Close: 100, High: 110, Low: 90, Volume: 1000})
+	f, err = e.ExecuteOrder(context.Background(), o, d, om, &fakeFund{})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	if !f.GetVolumeAdjustedPrice().Equal(o.ClosePrice) {
+		t.Errorf("received '%v' expected '%v'", f.GetVolumeAdjustedPrice(), o.ClosePrice)
+	}
+	if f.IsVolumeAdjusted() {
+		t.Error("expected no volume adjustment")
+	}
+
+	o, d = setupOfflineOrder(t, gctorder.Buy, decimal.NewFromInt(1), decimal.NewFromInt(120),
+		gctkline.Candle{Close: 100, High: 110, Low: 90, Volume: 1000})
+	f, err = e.ExecuteOrder(context.Background(), o, d, om, &fakeFund{})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	if !f.GetVolumeAdjustedPrice().Equal(decimal.NewFromInt(110)) {
+		t.Errorf("received '%v' expected '%v'", f.GetVolumeAdjustedPrice(), 110)
+	}
+	if !f.IsVolumeAdjusted() {
+		t.Error("expected volume adjustment")
+	}
+}
+
 func TestApplySlippageToPrice(t *testing.T) {
 	t.Parallel()
 	resp, err := applySlippageToPrice(gctorder.Buy, decimal.NewFromInt(1), decimal.NewFromFloat(0.9))
@@ -502,6 +674,193 @@ func TestApplySlippageToPrice(t *testing.T) {
 	}
 }
 
+func TestApplySlippageToPriceSymmetry(t *testing.T) {
+	t.Parallel()
+	price := decimal.NewFromInt(1337)
+	for _, tc := range []struct {
+		direction    gctorder.Side
+		slippageRate decimal.Decimal
+	}{
+		{gctorder.Buy, decimal.NewFromFloat(0.95)},
+		{gctorder.Buy, decimal.NewFromFloat(0.99)},
+		{gctorder.Buy, decimal.NewFromInt(1)},
+		{gctorder.Long, decimal.NewFromFloat(0.95)},
+		{gctorder.Long, decimal.NewFromFloat(0.99)},
+		{gctorder.Long, decimal.NewFromInt(1)},
+		{gctorder.Sell, decimal.NewFromFloat(0.95)},
+		{gctorder.Sell, decimal.NewFromFloat(0.99)},
+		{gctorder.Sell, decimal.NewFromInt(1)},
+		{gctorder.Short, decimal.NewFromFloat(0.95)},
+		{gctorder.Short, decimal.NewFromFloat(0.99)},
+		{gctorder.Short, decimal.NewFromInt(1)},
+	} {
+		adjusted, err := applySlippageToPrice(tc.direction, price, tc.slippageRate)
+		if !errors.Is(err, nil) {
+			t.Fatalf("received '%v' expected '%v'", err, nil)
+		}
+		// the price moves against the trade by exactly the slippage fraction
+		expectedMove := price.Mul(decimal.NewFromInt(1).Sub(tc.slippageRate))
+		move := adjusted.Sub(price)
+		if tc.direction == gctorder.Sell || tc.direction == gctorder.Short {
+			move = move.Neg()
+		}
+		if !move.Equal(expectedMove) {
+			t.Errorf("%v rate %v received '%v' expected adverse move of '%v'", tc.direction, tc.slippageRate, adjusted, expectedMove)
+		}
+	}
+
+	for _, direction := range []gctorder.Side{gctorder.Buy, gctorder.Long, gctorder.Sell, gctorder.Short} {
+		adjusted, err := applySlippageToPrice(direction, price, decimal.Zero)
+		if !errors.Is(err, nil) {
+			t.Fatalf("received '%v' expected '%v'", err, nil)
+		}
+		if !adjusted.Equal(price) {
+			t.Errorf("%v received '%v' expected unset rate to leave price at '%v'", direction, adjusted, price)
+		}
+	}
+}
+
+func TestFloorSlippage(t *testing.T) {
+	t.Parallel()
+	reference := decimal.NewFromInt(100)
+	for _, tc := range []struct {
+		direction     gctorder.Side
+		slippageRate  decimal.Decimal
+		expectedPrice decimal.Decimal
+		isFloored     bool
+	}{
+		{gctorder.Buy, decimal.NewFromFloat(0.9), decimal.NewFromInt(110), false},
+		{gctorder.Buy, decimal.NewFromInt(1), reference, false},
+		{gctorder.Buy, decimal.NewFromFloat(1.0001), reference, true},
+		{gctorder.Buy, decimal.NewFromFloat(1.5), reference, true},
+		{gctorder.Long, decimal.NewFromFloat(1.25), reference, true},
+		{gctorder.Sell, decimal.NewFromFloat(0.9), decimal.NewFromInt(90), false},
+		{gctorder.Sell, decimal.NewFromInt(1), reference, false},
+		{gctorder.Sell, decimal.NewFromFloat(1.0001), reference, true},
+		{gctorder.Short, decimal.NewFromFloat(1.5), reference, true},
+		{gctorder.Sell, decimal.Zero, reference, false},
+	} {
+		slipped, err := applySlippageToPrice(tc.direction, reference, tc.slippageRate)
+		if !errors.Is(err, nil) {
+			t.Fatalf("received '%v' expected '%v'", err, nil)
+		}
+		price, isFloored := floorSlippage(tc.direction, reference, slipped)
+		if isFloored != tc.isFloored {
+			t.Errorf("%v rate %v received '%v' expected '%v'", tc.direction, tc.slippageRate, isFloored, tc.isFloored)
+		}
+		if !price.Equal(tc.expectedPrice) {
+			t.Errorf("%v rate %v received '%v' expected '%v'", tc.direction, tc.slippageRate, price, tc.expectedPrice)
+		}
+	}
+	price, isFloored := floorSlippage(gctorder.UnknownSide, reference, decimal.NewFromInt(1))
+	if isFloored || !price.Equal(decimal.NewFromInt(1)) {
+		t.Errorf("received '%v' expected '%v'", price, 1)
+	}
+}
+
+func TestCapSlippage(t *testing.T) {
+	t.Parallel()
+	price, capped := capSlippage(gctorder.Buy, decimal.NewFromInt(100), decimal.NewFromInt(120), decimal.Zero)
+	if capped {
+		t.Error("expected no cap when unset")
+	}
+	if !price.Equal(decimal.NewFromInt(120)) {
+		t.Errorf("received: %v, expected: %v", price, decimal.NewFromInt(120))
+	}
+
+	price, capped = capSlippage(gctorder.Buy, decimal.NewFromInt(100), decimal.NewFromInt(120), decimal.NewFromInt(5))
+	if !capped {
+		t.Error("expected cap to bind")
+	}
+	if !price.Equal(decimal.NewFromInt(105)) {
+		t.Errorf("received: %v, expected: %v", price, decimal.NewFromInt(105))
+	}
+
+	price, capped = capSlippage(gctorder.Buy, decimal.NewFromInt(100), decimal.NewFromInt(102), decimal.NewFromInt(5))
+	if capped {
+		t.Error("expected no cap within limit")
+	}
+	if !price.Equal(decimal.NewFromInt(102)) {
+		t.Errorf("received: %v, expected: %v", price, decimal.NewFromInt(102))
+	}
+
+	price, capped = capSlippage(gctorder.Sell, decimal.NewFromInt(100), decimal.NewFromInt(80), decimal.NewFromInt(5))
+	if !capped {
+		t.Error("expected cap to bind")
+	}
+	if !price.Equal(decimal.NewFromInt(95)) {
+		t.Errorf("received: %v, expected: %v", price, decimal.NewFromInt(95))
+	}
+}
+
+func TestExecuteOrderSlippageCap(t *testing.T) {
+	t.Parallel()
+	om, exch := setupOfflineOrderManager(t)
+	o, d := setupOfflineOrder(t, gctorder.Sell, decimal.NewFromInt(1), decimal.NewFromInt(100),
+		gctkline.Candle{Close: 100, High: 110, Low: 90, Volume: 1000})
+	cs := Settings{
+		Exchange:               exch,
+		Pair:                   o.Pair(),
+		Asset:                  o.GetAssetType(),
+		MinimumSlippageRate:    decimal.NewFromInt(50),
+		MaximumSlippageRate:    decimal.NewFromInt(60),
+		MaxSlippagePercent:     decimal.NewFromInt(10),
+		SignalToFillConvention: SameCandleClose,
+	}
+	e := Exchange{CurrencySettings: []Settings{cs}}
+	f, err := e.ExecuteOrder(context.Background(), o, d, om, &fakeFund{})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	if !f.GetPurchasePrice().Equal(decimal.NewFromInt(90)) {
+		t.Errorf("received '%v' expected '%v'", f.GetPurchasePrice(), 90)
+	}
+	if !f.GetSlippageRate().Equal(decimal.NewFromInt(-10)) {
+		t.Errorf("received '%v' expected '%v'", f.GetSlippageRate(), -10)
+	}
+	if !strings.Contains(f.GetConcatReasons(), "Slippage capped") {
+		t.Errorf("expected slippage cap reason, received '%v'", f.GetConcatReasons())
+	}
+}
+
+func TestExecuteOrderSlippageTiers(t *testing.T) {
+	t.Parallel()
+	om, exch := setupOfflineOrderManager(t)
+	cs := Settings{
+		Exchange:            exch,
+		MinimumSlippageRate: decimal.NewFromInt(50),
+		MaximumSlippageRate: decimal.NewFromInt(60),
+		SlippageTiers: []slippage.Tier{
+			{MinimumNotional: decimal.Zero, BasisPoints: decimal.NewFromInt(2)},
+			{MinimumNotional: decimal.NewFromInt(1000), BasisPoints: decimal.NewFromInt(20)},
+		},
+		SignalToFillConvention: SameCandleClose,
+	}
+	for _, tt := range []struct {
+		amount, expectedPrice int64
+		expectedSlippage      float64
+	}{
+		{amount: 1, expectedPrice: 10002, expectedSlippage: -0.02},
+		{amount: 12, expectedPrice: 10020, expectedSlippage: -0.2},
+	} {
+		o, d := setupOfflineOrder(t, gctorder.Buy, decimal.NewFromInt(tt.amount), decimal.NewFromInt(100),
+			gctkline.Candle{Close: 100, High: 110, Low: 90, Volume: 10000})
+		cs.Pair = o.Pair()
+		cs.Asset = o.GetAssetType()
+		e := Exchange{CurrencySettings: []Settings{cs}}
+		f, err := e.ExecuteOrder(context.Background(), o, d, om, &fakeFund{})
+		if !errors.Is(err, nil) {
+			t.Fatalf("received '%v' expected '%v'", err, nil)
+		}
+		if expected := decimal.New(tt.expectedPrice, -2); !f.GetPurchasePrice().Equal(expected) {
+			t.Errorf("received '%v' expected '%v'", f.GetPurchasePrice(), expected)
+		}
+		if expected := decimal.NewFromFloat(tt.expectedSlippage); !f.GetSlippageRate().Equal(expected) {
+			t.Errorf("received '%v' expected '%v'", f.GetSlippageRate(), expected)
+		}
+	}
+}
+
 func TestReduceAmountToFitPortfolioLimit(t *testing.T) {
 	t.Parallel()
 	initialPrice := decimal.NewFromInt(100)
@@ -521,6 +880,10 @@ func TestReduceAmountToFitPortfolioLimit(t *testing.T) {
 	if !finalAmount.Equal(decimal.NewFromInt(1)) {
 		t.Errorf("expected value %v to match portfolio total %v", finalAmount, portfolioAdjustedTotal)
 	}
+	finalAmount = reduceAmountToFitPortfolioLimit(decimal.Zero, amount, portfolioAdjustedTotal, gctorder.Buy)
+	if !finalAmount.Equal(amount) {
+		t.Errorf("received '%v' expected '%v'", finalAmount, amount)
+	}
 }
 
 func TestVerifyOrderWithinLimits(t *testing.T) {
@@ -582,7 +945,7 @@ func TestVerifyOrderWithinLimits(t *testing.T) {
 func TestAllocateFundsPostOrder(t *testing.T) {
 	t.Parallel()
 	expectedError := common.ErrNilEvent
-	err := allocateFundsPostOrder(nil, nil, nil, decimal.Zero, decimal.Zero, decimal.Zero, decimal.Zero, decimal.Zero)
+	err := allocateFundsPostOrder(nil, nil, nil, decimal.Zero, decimal.Zero, decimal.Zero, decimal.Zero, decimal.Zero, nil)
 	if !errors.Is(err, expectedError) {
 		t.Errorf("received '%v' expected '%v'", err, expectedError)
 	}
@@ -594,7 +957,7 @@ func TestAllocateFundsPostOrder(t *testing.T) {
 		},
 		Direction: gctorder.Buy,
 	}
-	err = allocateFundsPostOrder(f, nil, nil, decimal.Zero, decimal.Zero, decimal.Zero, decimal.Zero, decimal.Zero)
+	err = allocateFundsPostOrder(f, nil, nil, decimal.Zero, decimal.Zero, decimal.Zero, decimal.Zero, decimal.Zero, nil)
 	if !errors.Is(err, expectedError) {
 		t.Errorf("received '%v' expected '%v'", err, expectedError)
 	}
@@ -622,19 +985,19 @@ func TestAllocateFundsPostOrder(t *testing.T) {
 		t.Errorf("received '%v' expected '%v'", err, expectedError)
 	}
 	f.Order = &gctorder.Detail{}
-	err = allocateFundsPostOrder(f, fundPair, nil, one, one, one, one, decimal.Zero)
+	err = allocateFundsPostOrder(f, fundPair, nil, one, one, one, one, decimal.Zero, nil)
 	if !errors.Is(err, expectedError) {
 		t.Errorf("received '%v' expected '%v'", err, expectedError)
 	}
 	f.SetDirection(gctorder.Sell)
-	err = allocateFundsPostOrder(f, fundPair, nil, one, one, one, one, decimal.Zero)
+	err = allocateFundsPostOrder(f, fundPair, nil, one, one, one, one, decimal.Zero, nil)
 	if !errors.Is(err, expectedError) {
 		t.Errorf("received '%v' expected '%v'", err, expectedError)
 	}
 
 	expectedError = gctorder.ErrSubmissionIsNil
 	orderError := gctorder.ErrSubmissionIsNil
-	err = allocateFundsPostOrder(f, fundPair, orderError, one, one, one, one, decimal.Zero)
+	err = allocateFundsPostOrder(f, fundPair, orderError, one, one, one, one, decimal.Zero, nil)
 	if !errors.Is(err, expectedError) {
 		t.Errorf("received '%v' expected '%v'", err, expectedError)
 	}
@@ -664,32 +1027,697 @@ func TestAllocateFundsPostOrder(t *testing.T) {
 	}
 
 	expectedError = gctorder.ErrSubmissionIsNil
-	err = allocateFundsPostOrder(f, collateralPair, orderError, one, one, one, one, decimal.Zero)
+	err = allocateFundsPostOrder(f, collateralPair, orderError, one, one, one, one, decimal.Zero, nil)
 	if !errors.Is(err, expectedError) {
 		t.Errorf("received '%v' expected '%v'", err, expectedError)
 	}
 	expectedError = nil
-	err = allocateFundsPostOrder(f, collateralPair, nil, one, one, one, one, decimal.Zero)
+	err = allocateFundsPostOrder(f, collateralPair, nil, one, one, one, one, decimal.Zero, nil)
 	if !errors.Is(err, expectedError) {
 		t.Errorf("received '%v' expected '%v'", err, expectedError)
 	}
 
 	expectedError = gctorder.ErrSubmissionIsNil
 	f.SetDirection(gctorder.Long)
-	err = allocateFundsPostOrder(f, collateralPair, orderError, one, one, one, one, decimal.Zero)
+	err = allocateFundsPostOrder(f, collateralPair, orderError, one, one, one, one, decimal.Zero, nil)
 	if !errors.Is(err, expectedError) {
 		t.Errorf("received '%v' expected '%v'", err, expectedError)
 	}
 	expectedError = nil
-	err = allocateFundsPostOrder(f, collateralPair, nil, one, one, one, one, decimal.Zero)
+	err = allocateFundsPostOrder(f, collateralPair, nil, one, one, one, one, decimal.Zero, nil)
 	if !errors.Is(err, expectedError) {
 		t.Errorf("received '%v' expected '%v'", err, expectedError)
 	}
 
 	f.AssetType = asset.Margin
 	expectedError = common.ErrInvalidDataType
-	err = allocateFundsPostOrder(f, collateralPair, nil, one, one, one, one, decimal.Zero)
+	err = allocateFundsPostOrder(f, collateralPair, nil, one, one, one, one, decimal.Zero, nil)
 	if !errors.Is(err, expectedError) {
 		t.Errorf("received '%v' expected '%v'", err, expectedError)
 	}
 }
+
+func TestExecuteOrderRoundingMode(t *testing.T) {
+	t.Parallel()
+	om, exch := setupOfflineOrderManager(t)
+	candle := gctkline.Candle{Close: 100, High: 100, Low: 100, Volume: 1000}
+	// a fee of 0.000000025 is exactly half way between two values
+	// at the default precision of eight decimal places
+	feeRate := decimal.RequireFromString("0.00000000025")
+	for _, tc := range []struct {
+		mode     string
+		expected decimal.Decimal
+	}{
+		{"", decimal.RequireFromString("0.00000002")},
+		{common.RoundHalfEven, decimal.RequireFromString("0.00000002")},
+		{common.RoundHalfUp, decimal.RequireFromString("0.00000003")},
+		{common.RoundTowardZero, decimal.RequireFromString("0.00000002")},
+	} {
+		// buys and sells of the same size are rounded identically
+		for _, side := range []gctorder.Side{gctorder.Buy, gctorder.Sell} {
+			o, d := setupOfflineOrder(t, side, decimal.NewFromInt(1), decimal.NewFromInt(100), candle)
+			cs := Settings{
+				Exchange:               exch,
+				Pair:                   o.Pair(),
+				Asset:                  o.GetAssetType(),
+				TakerFee:               feeRate,
+				RoundingMode:           tc.mode,
+				SignalToFillConvention: SameCandleClose,
+			}
+			e := Exchange{CurrencySettings: []Settings{cs}}
+			f, err := e.ExecuteOrder(context.Background(), o, d, om, &fakeFund{})
+			if !errors.Is(err, nil) {
+				t.Fatalf("received '%v' expected '%v'", err, nil)
+			}
+			if !f.GetExchangeFee().Equal(tc.expected) {
+				t.Errorf("mode '%v' side '%v' received '%v' expected '%v'", tc.mode, side, f.GetExchangeFee(), tc.expected)
+			}
+		}
+	}
+}
+
+func TestExecuteOrderFeeWholeNumberTick(t *testing.T) {
+	t.Parallel()
+	om, exch := setupOfflineOrderManager(t)
+	candle := gctkline.Candle{Close: 100, High: 100, Low: 100, Volume: 1000}
+	for _, tc := range []struct {
+		quotePrecision int32
+		expected       decimal.Decimal
+	}{
+		// a whole number price tick must not round the fee of 0.1 away
+		{0, decimal.RequireFromString("0.1")},
+		{2, decimal.RequireFromString("0.1")},
+	} {
+		o, d := setupOfflineOrder(t, gctorder.Buy, decimal.NewFromInt(1), decimal.NewFromInt(100), candle)
+		e := Exchange{CurrencySettings: []Settings{{
+			Exchange:               exch,
+			Pair:                   o.Pair(),
+			Asset:                  o.GetAssetType(),
+			TakerFee:               decimal.RequireFromString("0.001"),
+			MinimumSlippageRate:    decimal.NewFromInt(100),
+			MaximumSlippageRate:    decimal.NewFromInt(100),
+			CanUseExchangeLimits:   true,
+			Limits:                 gctorder.MinMaxLevel{PriceStepIncrementSize: 1},
+			QuotePrecision:         tc.quotePrecision,
+			SignalToFillConvention: SameCandleClose,
+		}}}
+		f, err := e.ExecuteOrder(context.Background(), o, d, om, &fakeFund{})
+		if !errors.Is(err, nil) {
+			t.Fatalf("received '%v' expected '%v'", err, nil)
+		}
+		if !f.GetExchangeFee().Equal(tc.expected) {
+			t.Errorf("quote precision '%v' received '%v' expected '%v'", tc.quotePrecision, f.GetExchangeFee(), tc.expected)
+		}
+	}
+}
+
+func TestGetFeePrecision(t *testing.T) {
+	t.Parallel()
+	if p := getFeePrecision(nil); p != defaultPrecision {
+		t.Errorf("received '%v' expected '%v'", p, defaultPrecision)
+	}
+	cs := &Settings{
+		CanUseExchangeLimits: true,
+		Limits:               gctorder.MinMaxLevel{PriceStepIncrementSize: 1},
+	}
+	if p := getFeePrecision(cs); p != defaultPrecision {
+		t.Errorf("received '%v' expected '%v'", p, defaultPrecision)
+	}
+	cs.QuotePrecision = 2
+	if p := getFeePrecision(cs); p != 2 {
+		t.Errorf("received '%v' expected '%v'", p, 2)
+	}
+}
+
+func TestGetPrecision(t *testing.T) {
+	t.Parallel()
+	pricePrecision, amountPrecision := getPrecision(nil)
+	if pricePrecision != defaultPrecision || amountPrecision != defaultPrecision {
+		t.Errorf("received '%v' '%v' expected '%v'", pricePrecision, amountPrecision, defaultPrecision)
+	}
+	cs := &Settings{
+		Limits: gctorder.MinMaxLevel{
+			PriceStepIncrementSize:  0.01,
+			AmountStepIncrementSize: 0.0001,
+		},
+	}
+	pricePrecision, amountPrecision = getPrecision(cs)
+	if pricePrecision != defaultPrecision || amountPrecision != defaultPrecision {
+		t.Errorf("received '%v' '%v' expected '%v'", pricePrecision, amountPrecision, defaultPrecision)
+	}
+	cs.CanUseExchangeLimits = true
+	pricePrecision, amountPrecision = getPrecision(cs)
+	if pricePrecision != 2 {
+		t.Errorf("received '%v' expected '%v'", pricePrecision, 2)
+	}
+	if amountPrecision != 4 {
+		t.Errorf("received '%v' expected '%v'", amountPrecision, 4)
+	}
+	cs.Limits.PriceStepIncrementSize = 10
+	cs.Limits.AmountStepIncrementSize = 0
+	pricePrecision, amountPrecision = getPrecision(cs)
+	if pricePrecision != 0 {
+		t.Errorf("received '%v' expected '%v'", pricePrecision, 0)
+	}
+	if amountPrecision != defaultPrecision {
+		t.Errorf("received '%v' expected '%v'", amountPrecision, defaultPrecision)
+	}
+}
+
+func TestExecuteOrderLimitPrecision(t *testing.T) {
+	t.Parallel()
+	om, exch := setupOfflineOrderManager(t)
+	o, d := setupOfflineOrder(t, gctorder.Buy, decimal.NewFromFloat(1.23456789), decimal.NewFromFloat(100.123456),
+		gctkline.Candle{Close: 100.123456, High: 110, Low: 90, Volume: 1000})
+	cs := Settings{
+		Exchange:                exch,
+		Pair:                    o.Pair(),
+		Asset:                   o.GetAssetType(),
+		MinimumSlippageRate:     decimal.NewFromInt(100),
+		MaximumSlippageRate:     decimal.NewFromInt(100),
+		SkipCandleVolumeFitting: true,
+		CanUseExchangeLimits:    true,
+		Limits: gctorder.MinMaxLevel{
+			PriceStepIncrementSize:  0.01,
+			AmountStepIncrementSize: 0.0001,
+			MaxAmount:               1337,
+			MaxPrice:                1337,
+		},
+		SignalToFillConvention: SameCandleClose,
+	}
+	e := Exchange{CurrencySettings: []Settings{cs}}
+	f, err := e.ExecuteOrder(context.Background(), o, d, om, &fakeFund{})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	if !f.GetPurchasePrice().Equal(decimal.NewFromFloat(100.12)) {
+		t.Errorf("received '%v' expected '%v'", f.GetPurchasePrice(), 100.12)
+	}
+	if !f.GetAmount().Equal(decimal.NewFromFloat(1.2345)) {
+		t.Errorf("received '%v' expected '%v'", f.GetAmount(), 1.2345)
+	}
+	if !strings.Contains(f.GetConcatReasons(), "exchange price precision") {
+		t.Errorf("expected price precision reason, received '%v'", f.GetConcatReasons())
+	}
+	if !strings.Contains(f.GetConcatReasons(), "Placed BUY order of 1.2345 BTC for 123.6") {
+		t.Errorf("expected summary to use exchange precision, received '%v'", f.GetConcatReasons())
+	}
+}
+
+func TestAlignExecutionData(t *testing.T) {
+	t.Parallel()
+	err := alignExecutionData(nil, nil)
+	if !errors.Is(err, common.ErrNilArguments) {
+		t.Errorf("received '%v' expected '%v'", err, common.ErrNilArguments)
+	}
+
+	p := currency.NewPair(currency.BTC, currency.USDT)
+	tt := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	loadData := func(interval gctkline.Interval, count int) *kline.DataFromKline {
+		d := &kline.DataFromKline{
+			Item: gctkline.Item{
+				Exchange: testExchange,
+				Pair:     p,
+				Asset:    asset.Spot,
+				Interval: interval,
+			},
+		}
+		for i := 0; i < count; i++ {
+			d.Item.Candles = append(d.Item.Candles, gctkline.Candle{
+				Time:   tt.Add(interval.Duration() * time.Duration(i)),
+				Close:  float64(100 + i),
+				High:   float64(101 + i),
+				Low:    float64(99 + i),
+				Volume: float64(i + 1),
+			})
+		}
+		if err = d.Load(); err != nil {
+			t.Fatal(err)
+		}
+		return d
+	}
+	signal := loadData(gctkline.OneHour, 2)
+	execution := loadData(gctkline.FifteenMin, 8)
+	signal.Next()
+	err = alignExecutionData(signal, execution)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	if execution.Offset() != 4 {
+		t.Errorf("received '%v' expected '%v'", execution.Offset(), 4)
+	}
+	if !execution.Latest().GetTime().Equal(tt.Add(gctkline.FifteenMin.Duration() * 3)) {
+		t.Errorf("received '%v' expected '%v'", execution.Latest().GetTime(), tt.Add(gctkline.FifteenMin.Duration()*3))
+	}
+
+	// aligning again does not move the execution data
+	err = alignExecutionData(signal, execution)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	if execution.Offset() != 4 {
+		t.Errorf("received '%v' expected '%v'", execution.Offset(), 4)
+	}
+
+	signal.Next()
+	err = alignExecutionData(signal, execution)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	if execution.Offset() != 8 {
+		t.Errorf("received '%v' expected '%v'", execution.Offset(), 8)
+	}
+
+	// execution data which ends before the signal candle is not aligned
+	signal = loadData(gctkline.OneHour, 3)
+	execution = loadData(gctkline.FifteenMin, 4)
+	signal.Next()
+	signal.Next()
+	err = alignExecutionData(signal, execution)
+	if !errors.Is(err, errNoAlignedExecutionData) {
+		t.Errorf("received '%v' expected '%v'", err, errNoAlignedExecutionData)
+	}
+
+	signal = loadData(gctkline.FifteenMin, 1)
+	execution = loadData(gctkline.OneHour, 1)
+	signal.Next()
+	err = alignExecutionData(signal, execution)
+	if !errors.Is(err, errInvalidExecutionInterval) {
+		t.Errorf("received '%v' expected '%v'", err, errInvalidExecutionInterval)
+	}
+}
+
+func TestExecuteOrderExecutionData(t *testing.T) {
+	t.Parallel()
+	om, exch := setupOfflineOrderManager(t)
+	o, signal := setupOfflineOrder(t, gctorder.Buy, decimal.NewFromInt(10), decimal.NewFromInt(100),
+		gctkline.Candle{Time: time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC), Close: 100, High: 110, Low: 90, Volume: 1000})
+	execution := &kline.DataFromKline{
+		Item: gctkline.Item{
+			Exchange: testExchange,
+			Pair:     o.Pair(),
+			Asset:    asset.Spot,
+			Interval: gctkline.FiveMin,
+		},
+	}
+	for i := 0; i < 3; i++ {
+		execution.Item.Candles = append(execution.Item.Candles, gctkline.Candle{
+			Time:   time.Date(2022, 1, 1, 0, 5*i, 0, 0, time.UTC),
+			Close:  100,
+			High:   101,
+			Low:    99,
+			Volume: float64(i + 1),
+		})
+	}
+	err := execution.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	cs := Settings{
+		Exchange:               exch,
+		Pair:                   o.Pair(),
+		Asset:                  o.GetAssetType(),
+		MinimumSlippageRate:    decimal.NewFromInt(100),
+		MaximumSlippageRate:    decimal.NewFromInt(100),
+		ExecutionData:          execution,
+		SignalToFillConvention: SameCandleClose,
+	}
+	e := Exchange{CurrencySettings: []Settings{cs}}
+	f, err := e.ExecuteOrder(context.Background(), o, signal, om, &fakeFund{})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	if execution.Offset() != 3 {
+		t.Errorf("received '%v' expected '%v'", execution.Offset(), 3)
+	}
+	// the order is fitted against the final execution candle's volume
+	// rather than the signal candle's volume
+	if !f.GetAmount().LessThanOrEqual(decimal.NewFromInt(3)) {
+		t.Errorf("received '%v' expected no more than '%v'", f.GetAmount(), 3)
+	}
+	if !f.IsVolumeAdjusted() {
+		t.Error("expected fill to be volume adjusted")
+	}
+}
+
+func TestAllocateFundsPostOrderFundingLedger(t *testing.T) {
+	t.Parallel()
+	initialFunds := decimal.NewFromInt(1337)
+	btc, err := funding.CreateItem(testExchange, asset.Spot, currency.BTC, initialFunds, decimal.Zero)
+	if err != nil {
+		t.Fatal(err)
+	}
+	usd, err := funding.CreateItem(testExchange, asset.Spot, currency.USD, initialFunds, decimal.Zero)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fundPair, err := funding.CreatePair(btc, usd)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := currency.NewPair(currency.BTC, currency.USD)
+	cs := &Settings{RecordFundingLedger: true}
+	price := decimal.NewFromInt(100)
+	fee := decimal.NewFromInt(1)
+	var fills []*fill.Fill
+	for _, side := range []gctorder.Side{gctorder.Buy, gctorder.Sell} {
+		allocated := decimal.NewFromInt(500)
+		amount := decimal.NewFromInt(4)
+		if side == gctorder.Sell {
+			allocated = decimal.NewFromInt(2)
+			amount = decimal.NewFromInt(2)
+		}
+		err = fundPair.Reserve(allocated, side)
+		if err != nil {
+			t.Fatal(err)
+		}
+		f := &fill.Fill{
+			Base: &event.Base{
+				AssetType:    asset.Spot,
+				CurrencyPair: p,
+			},
+			Direction: side,
+			Order:     &gctorder.Detail{},
+		}
+		err = allocateFundsPostOrder(f, fundPair, nil, amount, allocated, amount, price, fee, cs)
+		if !errors.Is(err, nil) {
+			t.Fatalf("received '%v' expected '%v'", err, nil)
+		}
+		if len(f.GetFundingLedger()) != 3 {
+			t.Fatalf("received '%v' expected '%v'", len(f.GetFundingLedger()), 3)
+		}
+		fills = append(fills, f)
+	}
+
+	baseChange, quoteChange := decimal.Zero, decimal.Zero
+	for i := range fills {
+		ledger := fills[i].GetFundingLedger()
+		for j := range ledger {
+			switch {
+			case ledger[j].Currency.Equal(currency.BTC):
+				baseChange = baseChange.Add(ledger[j].AvailableChange)
+			case ledger[j].Currency.Equal(currency.USD):
+				quoteChange = quoteChange.Add(ledger[j].AvailableChange)
+			default:
+				t.Errorf("unexpected ledger currency %v", ledger[j].Currency)
+			}
+		}
+	}
+	if !initialFunds.Add(baseChange).Equal(fundPair.BaseAvailable()) {
+		t.Errorf("received '%v' expected '%v'", initialFunds.Add(baseChange), fundPair.BaseAvailable())
+	}
+	if !initialFunds.Add(quoteChange).Equal(fundPair.QuoteAvailable()) {
+		t.Errorf("received '%v' expected '%v'", initialFunds.Add(quoteChange), fundPair.QuoteAvailable())
+	}
+
+	cs.RecordFundingLedger = false
+	err = fundPair.Reserve(decimal.NewFromInt(1), gctorder.Buy)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f := &fill.Fill{
+		Base: &event.Base{
+			AssetType:    asset.Spot,
+			CurrencyPair: p,
+		},
+		Direction: gctorder.Buy,
+	}
+	err = allocateFundsPostOrder(f, fundPair, errOutsideTradingSession, decimal.NewFromInt(1), decimal.NewFromInt(1), decimal.Zero, decimal.Zero, decimal.Zero, cs)
+	if !errors.Is(err, errOutsideTradingSession) {
+		t.Fatalf("received '%v' expected '%v'", err, errOutsideTradingSession)
+	}
+	if len(f.GetFundingLedger()) != 0 {
+		t.Errorf("received '%v' expected '%v'", len(f.GetFundingLedger()), 0)
+	}
+}
+
+func TestGetQuotePrecision(t *testing.T) {
+	t.Parallel()
+	if _, round := getQuotePrecision(nil); round {
+		t.Error("expected nil settings to not round quote funding")
+	}
+	cs := &Settings{QuotePrecision: 2}
+	if _, round := getQuotePrecision(cs); round {
+		t.Error("expected quote funding to not be rounded when disabled")
+	}
+	cs.RoundQuoteFunding = true
+	if precision, round := getQuotePrecision(cs); !round || precision != 2 {
+		t.Errorf("received '%v' '%v' expected '%v' '%v'", precision, round, 2, true)
+	}
+	cs.QuotePrecision = 0
+	if precision, round := getQuotePrecision(cs); !round || precision != defaultPrecision {
+		t.Errorf("received '%v' '%v' expected '%v' '%v'", precision, round, defaultPrecision, true)
+	}
+	cs.CanUseExchangeLimits = true
+	cs.Limits.PriceStepIncrementSize = 0.001
+	if precision, round := getQuotePrecision(cs); !round || precision != 3 {
+		t.Errorf("received '%v' '%v' expected '%v' '%v'", precision, round, 3, true)
+	}
+}
+
+func TestAllocateFundsPostOrderQuoteRounding(t *testing.T) {
+	t.Parallel()
+	initialFunds := decimal.NewFromInt(100000)
+	btc, err := funding.CreateItem(testExchange, asset.Spot, currency.BTC, initialFunds, decimal.Zero)
+	if err != nil {
+		t.Fatal(err)
+	}
+	usd, err := funding.CreateItem(testExchange, asset.Spot, currency.USD, initialFunds, decimal.Zero)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fundPair, err := funding.CreatePair(btc, usd)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := currency.NewPair(currency.BTC, currency.USD)
+	cs := &Settings{RoundQuoteFunding: true, QuotePrecision: 2}
+	price := decimal.RequireFromString("1234.56789")
+	amount := decimal.RequireFromString("0.0123")
+	fee := amount.Mul(price).Mul(decimal.RequireFromString("0.001"))
+	for i := 0; i < 1000; i++ {
+		side := gctorder.Buy
+		allocated := decimal.NewFromInt(20)
+		if i%2 == 1 {
+			side = gctorder.Sell
+			allocated = amount
+		}
+		err = fundPair.Reserve(allocated, side)
+		if err != nil {
+			t.Fatal(err)
+		}
+		f := &fill.Fill{
+			Base: &event.Base{
+				AssetType:    asset.Spot,
+				CurrencyPair: p,
+			},
+			Direction: side,
+			Order:     &gctorder.Detail{},
+		}
+		err = allocateFundsPostOrder(f, fundPair, nil, amount, allocated, amount, price, fee, cs)
+		if !errors.Is(err, nil) {
+			t.Fatalf("received '%v' expected '%v'", err, nil)
+		}
+		if quote := fundPair.QuoteAvailable(); !quote.Equal(quote.Round(2)) {
+			t.Fatalf("received '%v' expected no sub-cent residual after %v fills", quote, i+1)
+		}
+	}
+	if !fundPair.BaseAvailable().Equal(initialFunds) {
+		t.Errorf("received '%v' expected '%v'", fundPair.BaseAvailable(), initialFunds)
+	}
+
+	cs.RoundQuoteFunding = false
+	err = fundPair.Reserve(decimal.NewFromInt(20), gctorder.Buy)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f := &fill.Fill{
+		Base: &event.Base{
+			AssetType:    asset.Spot,
+			CurrencyPair: p,
+		},
+		Direction: gctorder.Buy,
+		Order:     &gctorder.Detail{},
+	}
+	err = allocateFundsPostOrder(f, fundPair, nil, amount, decimal.NewFromInt(20), amount, price, fee, cs)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	if quote := fundPair.QuoteAvailable(); quote.Equal(quote.Round(2)) {
+		t.Errorf("received '%v' expected unrounded quote funding", quote)
+	}
+}
+
+func TestExceedsSlippageTolerance(t *testing.T) {
+	t.Parallel()
+	hundred := decimal.NewFromInt(100)
+	one := decimal.NewFromInt(1)
+	if _, exceeded := exceedsSlippageTolerance(gctorder.Buy, hundred, decimal.NewFromInt(110), decimal.Zero); exceeded {
+		t.Error("expected zero tolerance to disable check")
+	}
+	if _, exceeded := exceedsSlippageTolerance(gctorder.Buy, decimal.Zero, decimal.NewFromInt(110), one); exceeded {
+		t.Error("expected zero reference price to disable check")
+	}
+	adverse, exceeded := exceedsSlippageTolerance(gctorder.Buy, hundred, decimal.NewFromInt(110), one)
+	if !exceeded {
+		t.Error("expected buy slippage to exceed tolerance")
+	}
+	if !adverse.Equal(decimal.NewFromInt(10)) {
+		t.Errorf("received '%v' expected '%v'", adverse, 10)
+	}
+	if _, exceeded = exceedsSlippageTolerance(gctorder.Buy, hundred, decimal.NewFromInt(90), one); exceeded {
+		t.Error("expected favourable buy price to not exceed tolerance")
+	}
+	if _, exceeded = exceedsSlippageTolerance(gctorder.Sell, hundred, decimal.NewFromInt(90), one); !exceeded {
+		t.Error("expected sell slippage to exceed tolerance")
+	}
+	if _, exceeded = exceedsSlippageTolerance(gctorder.Short, hundred, decimal.NewFromFloat(99.5), one); exceeded {
+		t.Error("expected short slippage within tolerance to not exceed tolerance")
+	}
+	if _, exceeded = exceedsSlippageTolerance(gctorder.DoNothing, hundred, decimal.NewFromInt(50), one); exceeded {
+		t.Error("expected unsupported direction to not exceed tolerance")
+	}
+}
+
+func TestExecuteOrderSlippageTolerance(t *testing.T) {
+	t.Parallel()
+	om, exch := setupOfflineOrderManager(t)
+	o, d := setupOfflineOrder(t, gctorder.Buy, decimal.NewFromInt(1), decimal.NewFromInt(100),
+		gctkline.Candle{Close: 100, High: 200, Low: 90, Volume: 1000})
+	o.MaxSlippageTolerance = decimal.NewFromInt(1)
+	cs := Settings{
+		Exchange:                exch,
+		Pair:                    o.Pair(),
+		Asset:                   o.GetAssetType(),
+		MinimumSlippageRate:     decimal.NewFromInt(90),
+		MaximumSlippageRate:     decimal.NewFromInt(95),
+		SkipCandleVolumeFitting: true,
+		SignalToFillConvention:  SameCandleClose,
+	}
+	e := Exchange{CurrencySettings: []Settings{cs}}
+	f, err := e.ExecuteOrder(context.Background(), o, d, om, &fakeFund{})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	if f.GetDirection() != gctorder.DoNothing {
+		t.Errorf("received '%v' expected '%v'", f.GetDirection(), gctorder.DoNothing)
+	}
+	if !strings.Contains(f.GetConcatReasons(), "exceeds tolerance") {
+		t.Errorf("expected tolerance reason, received '%v'", f.GetConcatReasons())
+	}
+
+	o, d = setupOfflineOrder(t, gctorder.Buy, decimal.NewFromInt(1), decimal.NewFromInt(100),
+		gctkline.Candle{Close: 100, High: 200, Low: 90, Volume: 1000})
+	o.MaxSlippageTolerance = decimal.NewFromInt(20)
+	f, err = e.ExecuteOrder(context.Background(), o, d, om, &fakeFund{})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	if f.GetDirection() != gctorder.Buy {
+		t.Errorf("received '%v' expected '%v'", f.GetDirection(), gctorder.Buy)
+	}
+}
+
+func TestExecuteOrderNoFundsAllocated(t *testing.T) {
+	t.Parallel()
+	om, exch := setupOfflineOrderManager(t)
+	o, d := setupOfflineOrder(t, gctorder.Buy, decimal.NewFromInt(1), decimal.NewFromInt(100),
+		gctkline.Candle{Close: 100, High: 110, Low: 90, Volume: 1000})
+	o.AllocatedFunds = decimal.Zero
+	cs := Settings{
+		Exchange:            exch,
+		Pair:                o.Pair(),
+		Asset:               o.GetAssetType(),
+		MinimumSlippageRate: decimal.NewFromInt(100),
+		MaximumSlippageRate: decimal.NewFromInt(100),
+	}
+	e := Exchange{CurrencySettings: []Settings{cs}}
+	f, err := e.ExecuteOrder(context.Background(), o, d, om, &fakeFund{})
+	if !errors.Is(err, ErrCannotTransact) {
+		t.Fatalf("received '%v' expected '%v'", err, ErrCannotTransact)
+	}
+	if f.GetDirection() != gctorder.DoNothing {
+		t.Errorf("received '%v' expected '%v'", f.GetDirection(), gctorder.DoNothing)
+	}
+	if !strings.Contains(f.GetConcatReasons(), "No funds allocated") {
+		t.Errorf("expected no funds allocated reason, received '%v'", f.GetConcatReasons())
+	}
+
+	o.AllocatedFunds = decimal.NewFromInt(-1)
+	f, err = e.ExecuteOrder(context.Background(), o, d, om, &fakeFund{})
+	if !errors.Is(err, ErrCannotTransact) {
+		t.Fatalf("received '%v' expected '%v'", err, ErrCannotTransact)
+	}
+	if f.GetDirection() != gctorder.DoNothing {
+		t.Errorf("received '%v' expected '%v'", f.GetDirection(), gctorder.DoNothing)
+	}
+}
+
+func TestAggregateHLV(t *testing.T) {
+	t.Parallel()
+	_, d := setupOfflineOrder(t, gctorder.Buy, decimal.NewFromInt(1), decimal.NewFromInt(100),
+		gctkline.Candle{High: 120, Low: 80, Volume: 1},
+		gctkline.Candle{High: 105, Low: 95, Volume: 2},
+		gctkline.Candle{High: 110, Low: 90, Volume: 3})
+	high, low, volume, candles := aggregateHLV(d, 0)
+	if !high.Equal(decimal.NewFromInt(110)) || !low.Equal(decimal.NewFromInt(90)) || !volume.Equal(decimal.NewFromInt(3)) || candles != 1 {
+		t.Errorf("received '%v' '%v' '%v' '%v' expected '%v' '%v' '%v' '%v'", high, low, volume, candles, 110, 90, 3, 1)
+	}
+	high, low, volume, candles = aggregateHLV(d, 2)
+	if !high.Equal(decimal.NewFromInt(110)) || !low.Equal(decimal.NewFromInt(90)) || !volume.Equal(decimal.NewFromInt(5)) || candles != 2 {
+		t.Errorf("received '%v' '%v' '%v' '%v' expected '%v' '%v' '%v' '%v'", high, low, volume, candles, 110, 90, 5, 2)
+	}
+	high, low, volume, candles = aggregateHLV(d, 10)
+	if !high.Equal(decimal.NewFromInt(120)) || !low.Equal(decimal.NewFromInt(80)) || !volume.Equal(decimal.NewFromInt(6)) || candles != 3 {
+		t.Errorf("received '%v' '%v' '%v' '%v' expected '%v' '%v' '%v' '%v'", high, low, volume, candles, 120, 80, 6, 3)
+	}
+}
+
+func TestExecuteOrderPriceOverride(t *testing.T) {
+	t.Parallel()
+	om, exch := setupOfflineOrderManager(t)
+	o, d := setupOfflineOrder(t, gctorder.Buy, decimal.NewFromInt(1), decimal.NewFromInt(100),
+		gctkline.Candle{Close: 100, High: 110, Low: 90, Volume: 0.5})
+	cs := Settings{
+		Exchange:               exch,
+		Pair:                   o.Pair(),
+		Asset:                  o.GetAssetType(),
+		MinimumSlippageRate:    decimal.NewFromInt(90),
+		MaximumSlippageRate:    decimal.NewFromInt(95),
+		SignalToFillConvention: SameCandleClose,
+	}
+	var receivedHigh, receivedVolume decimal.Decimal
+	e := Exchange{
+		CurrencySettings: []Settings{cs},
+		PriceOverride: func(f fill.Event, candleHigh, candleLow, candleClose, candleVol decimal.Decimal) decimal.Decimal {
+			receivedHigh, receivedVolume = candleHigh, candleVol
+			return candleHigh.Add(candleLow).Div(decimal.NewFromInt(2)).Add(decimal.NewFromInt(1))
+		},
+	}
+	f, err := e.ExecuteOrder(context.Background(), o, d, om, &fakeFund{})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	if !f.GetPurchasePrice().Equal(decimal.NewFromInt(101)) {
+		t.Errorf("received '%v' expected '%v'", f.GetPurchasePrice(), 101)
+	}
+	if !f.GetAmount().Equal(decimal.NewFromInt(1)) {
+		t.Errorf("received '%v' expected '%v', volume fitting should be bypassed", f.GetAmount(), 1)
+	}
+	if !receivedHigh.Equal(decimal.NewFromInt(110)) || !receivedVolume.Equal(decimal.NewFromFloat(0.5)) {
+		t.Errorf("received '%v' '%v' expected '%v' '%v'", receivedHigh, receivedVolume, 110, 0.5)
+	}
+
+	e.PriceOverride = func(fill.Event, decimal.Decimal, decimal.Decimal, decimal.Decimal, decimal.Decimal) decimal.Decimal {
+		return decimal.Zero
+	}
+	o, d = setupOfflineOrder(t, gctorder.Buy, decimal.NewFromInt(1), decimal.NewFromInt(100),
+		gctkline.Candle{Close: 100, High: 110, Low: 90, Volume: 1000})
+	f, err = e.ExecuteOrder(context.Background(), o, d, om, &fakeFund{})
+	if !errors.Is(err, errInvalidOverridePrice) {
+		t.Fatalf("received '%v' expected '%v'", err, errInvalidOverridePrice)
+	}
+	if f.GetDirection() != gctorder.CouldNotBuy {
+		t.Errorf("received '%v' expected '%v'", f.GetDirection(), gctorder.CouldNotBuy)
+	}
+}
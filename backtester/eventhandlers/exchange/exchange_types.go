@@ -1,10 +1,15 @@
 package exchange
 
 import (
+	"context"
 	"errors"
+	"math/rand"
+	"time"
 
 	"github.com/shopspring/decimal"
+	"github.com/thrasher-corp/gocryptotrader/backtester/common"
 	"github.com/thrasher-corp/gocryptotrader/backtester/data"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventhandlers/exchange/slippage"
 	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/fill"
 	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/order"
 	"github.com/thrasher-corp/gocryptotrader/backtester/funding"
@@ -13,39 +18,188 @@ import (
 	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
 	gctorder "github.com/thrasher-corp/gocryptotrader/exchanges/order"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/orderbook"
 )
 
+// defaultPrecision is the number of decimal places used for rounding prices
+// and amounts when exchange limits do not define a step increment size
+const defaultPrecision int32 = 8
+
 var (
-	errDataMayBeIncorrect      = errors.New("data may be incorrect")
-	errExceededPortfolioLimit  = errors.New("exceeded portfolio limit")
-	errNilCurrencySettings     = errors.New("received nil currency settings")
-	errInvalidDirection        = errors.New("received invalid order direction")
-	errNoCurrencySettingsFound = errors.New("no currency settings found")
+	errDataMayBeIncorrect         = errors.New("data may be incorrect")
+	errInvalidExecutionInput      = errors.New("invalid execution input")
+	errInsufficientFeeFunds       = errors.New("allocated funds cannot cover fee")
+	errExceededPortfolioLimit     = errors.New("exceeded portfolio limit")
+	errNilCurrencySettings        = errors.New("received nil currency settings")
+	errInvalidDirection           = errors.New("received invalid order direction")
+	errNoCurrencySettingsFound    = errors.New("no currency settings found")
+	errInvalidTargetSettings      = errors.New("invalid target settings")
+	errNoAlignedExecutionData     = errors.New("no aligned execution data")
+	errInvalidExecutionInterval   = errors.New("invalid execution interval")
+	errNoPositionEntry            = errors.New("no open position entry")
+	errOutsideTradingSession      = errors.New("order placed outside trading session")
+	errSlippageToleranceExceeded  = errors.New("slippage exceeds order tolerance")
+	errInvalidOrderbookDepth      = errors.New("invalid orderbook depth")
+	errNoOrderbookVolume          = errors.New("no orderbook volume")
+	errNoFundsAllocated           = errors.New("no funds allocated")
+	errExchangeDowntime           = errors.New("order placed during exchange downtime")
+	errCrossedOrderbook           = errors.New("orderbook is crossed or locked")
+	errStaleOrderbook             = errors.New("orderbook is stale")
+	errOrderbookDepthExhausted    = errors.New("order exceeds orderbook depth")
+	errCapitalCapExceeded         = errors.New("maximum capital allocation exceeded")
+	errInvalidOverridePrice       = errors.New("price override returned invalid price")
+	errInvalidPublisherBufferSize = errors.New("invalid publisher buffer size")
+	errInvalidFeeOverride         = errors.New("invalid fee override")
+	errMinimumHoldingPeriod       = errors.New("position held for less than minimum holding period")
+	errCircuitBreakerHalted       = errors.New("entries halted by losing streak circuit breaker")
+	errAmountBelowStepSize        = errors.New("amount rounds to zero at exchange step size")
+	errSlippageOverfill           = errors.New("order notional exceeds allocated funds after slippage")
+	errExtremeVolatility          = errors.New("order placed on extreme volatility candle")
+	errNilState                   = errors.New("received nil exchange state")
+	errStateMismatch              = errors.New("exchange state does not match currency settings")
+	errInvalidLimitPrice          = errors.New("invalid limit price")
+	errDeferredOrderCancelled     = errors.New("deferred order cancelled at end of run")
+	errInvalidStopPrice           = errors.New("invalid stop price")
+	errInvalidTakeProfitPrice     = errors.New("invalid take-profit price")
+	errInvalidTakeProfitPercent   = errors.New("invalid take-profit percent")
+	errInvalidTrailingStop        = errors.New("invalid trailing stop")
+	errCandleVolumeExceeded       = errors.New("order exceeds candle volume")
 )
 
 // ExecutionHandler interface dictates what functions are required to submit an order
 type ExecutionHandler interface {
 	SetExchangeAssetCurrencySettings(asset.Item, currency.Pair, *Settings)
 	GetCurrencySettings(string, asset.Item, currency.Pair) (Settings, error)
-	ExecuteOrder(order.Event, data.Handler, *engine.OrderManager, funding.IFundReleaser) (fill.Event, error)
+	ExecuteOrder(context.Context, order.Event, data.Handler, *engine.OrderManager, funding.IFundReleaser) (fill.Event, error)
+	CalculateTargetPrice(string, asset.Item, currency.Pair, decimal.Decimal) (decimal.Decimal, error)
+	ReleaseDeferredOrders(common.DataEventHandler) ([]order.Event, error)
+	CancelDeferredOrders(funding.IFundingReader) ([]fill.Event, error)
+	ApplyFundingRate(common.DataEventHandler, funding.ICollateralReleaser, *gctorder.Position) (decimal.Decimal, error)
+	CheckPositionAge(common.DataEventHandler) (*order.Order, error)
+	AttachOrderbookImbalance(common.DataEventHandler) error
+	SaveState() *State
+	RestoreState(*State) error
 	Reset()
 }
 
 // Exchange contains all the currency settings
 type Exchange struct {
 	CurrencySettings []Settings
+	// PriceOverride is an optional custom execution model which supplies the
+	// final fill price when not using real orders, bypassing the built-in
+	// slippage and candle volume fitting. It receives the fill along with the
+	// execution candle's high, low, close and volume and runs after the
+	// portfolio and exchange limit checks, so the price it returns is not
+	// checked against them. The amount is only reduced when required to
+	// remain within the order's allocated funds. A non-positive price
+	// rejects the order
+	PriceOverride func(f fill.Event, candleHigh, candleLow, candleClose, candleVol decimal.Decimal) decimal.Decimal
+	// Publisher optionally receives each fill executed, including rejected
+	// orders, in addition to it being returned. It is called synchronously
+	// so implementations must not block
+	Publisher          FillPublisher
+	deferredOrders     []*order.Order
+	capitalAllocations map[string]map[asset.Item]map[currency.Pair]*capitalAllocation
+	positionEntries    map[string]map[asset.Item]map[currency.Pair]*positionEntry
+	losingStreaks      map[string]map[asset.Item]map[currency.Pair]*losingStreak
+	tradedVolumes      map[string]map[string]decimal.Decimal
+	slippageSource     *slippageSource
+	slippageRand       *rand.Rand
+}
+
+// FillPublisher receives fills as they are executed to stream them to
+// downstream systems such as a database, UI or message queue
+type FillPublisher interface {
+	Publish(PublishedFill)
+}
+
+// SlippageModel applies slippage to an order, returning the price and amount
+// it fills at. The orderbook is only supplied when using real orders
+type SlippageModel interface {
+	Apply(direction gctorder.Side, price, amount decimal.Decimal, ob *orderbook.Base) (adjPrice, adjAmount decimal.Decimal, err error)
+}
+
+// ChannelPublisher is a bounded FillPublisher which sends fills to a
+// buffered channel, dropping fills rather than blocking when it is full
+type ChannelPublisher struct {
+	fills   chan PublishedFill
+	dropped int64
+}
+
+// PublishedFill is a serialisable representation of a fill event
+type PublishedFill struct {
+	Exchange      string          `json:"exchange"`
+	Asset         string          `json:"asset"`
+	Pair          string          `json:"pair"`
+	Interval      string          `json:"interval"`
+	Offset        int64           `json:"offset"`
+	Time          time.Time       `json:"time"`
+	Direction     string          `json:"direction"`
+	Amount        decimal.Decimal `json:"amount"`
+	ClosePrice    decimal.Decimal `json:"close-price"`
+	PurchasePrice decimal.Decimal `json:"purchase-price"`
+	Total         decimal.Decimal `json:"total"`
+	ExchangeFee   decimal.Decimal `json:"exchange-fee"`
+	Slippage      decimal.Decimal `json:"slippage"`
+	Liquidated    bool            `json:"liquidated,omitempty"`
+	StopOut       bool            `json:"stop-out,omitempty"`
+	Tag           string          `json:"tag,omitempty"`
+	Account       string          `json:"account,omitempty"`
+	FeeType       string          `json:"fee-type,omitempty"`
+	OrderID       string          `json:"order-id,omitempty"`
+	Reasons       string          `json:"reasons,omitempty"`
+}
+
+// capitalAllocation is the capital consumed by a currency's open
+// positions along with the amount held to release it proportionally
+type capitalAllocation struct {
+	capital decimal.Decimal
+	amount  decimal.Decimal
+}
+
+// positionEntry is when a currency's open position was entered
+// along with its direction and the amount held to track its exit. The
+// notional, fees and spread paid by the entry fills are kept to calculate
+// targets from the entry price
+type positionEntry struct {
+	time          time.Time
+	direction     gctorder.Side
+	amount        decimal.Decimal
+	enteredAmount decimal.Decimal
+	notional      decimal.Decimal
+	fee           decimal.Decimal
+	spreadCost    decimal.Decimal
+}
+
+// losingStreak tracks a currency's open round trip trade along with its
+// consecutive losing round trips and whether entries are halted
+type losingStreak struct {
+	position          decimal.Decimal
+	cashFlow          decimal.Decimal
+	consecutiveLosses int64
+	halted            bool
+	haltedUntil       time.Time
 }
 
 // Settings allow the eventhandler to size an order within the limitations set by the config file
 type Settings struct {
-	Exchange      exchange.IBotExchange
+	Exchange      exchange.IBotExchange `json:"-"`
 	UseRealOrders bool
 
 	Pair  currency.Pair
 	Asset asset.Item
 
+	// MakerFee is charged to limit orders which fill passively, either
+	// resting from a previous candle or placed away from the price.
+	// TakerFee is charged to all other orders
 	MakerFee decimal.Decimal
 	TakerFee decimal.Decimal
+	// FeeTiers replace the maker and taker fees once the exchange account's
+	// cumulative traded volume over the run reaches a tier's threshold,
+	// applying the tier with the highest threshold reached. Volume is the
+	// notional traded across all of the exchange's currencies. Empty
+	// always applies the maker and taker fees
+	FeeTiers []FeeTier
 
 	BuySide  MinMax
 	SellSide MinMax
@@ -54,10 +208,190 @@ type Settings struct {
 
 	MinimumSlippageRate decimal.Decimal
 	MaximumSlippageRate decimal.Decimal
+	// SlippageModel is an optional custom slippage model used instead of
+	// the built-in slippage estimate. When set, the minimum and maximum
+	// slippage rates, slippage tiers, asymmetry and extreme volatility
+	// amplification are not applied, while the slippage floor and cap are
+	SlippageModel SlippageModel `json:"-"`
+	// SlippageTiers selects the slippage rate by the order's notional,
+	// replacing the minimum and maximum slippage range when set
+	SlippageTiers []slippage.Tier
+	// MaxSlippagePercent caps the price movement caused by slippage
+	// eg 5 means a fill price cannot be more than 5% worse than
+	// the price before slippage. Zero means no cap
+	MaxSlippagePercent decimal.Decimal
+	// SlippageAsymmetry scales estimated slippage by the candle's direction.
+	// eg 0.5 means buying in an up candle or selling in a down candle slips
+	// 50% more, while opposing the candle slips 50% less. Zero disables it
+	SlippageAsymmetry decimal.Decimal
+	// AllowSlippagePriceImprovement disables the zero slippage floor, which
+	// clamps a slipped price that improves on the price before slippage
+	// back to that price so that slippage is always a cost or neutral
+	AllowSlippagePriceImprovement bool
+	// LiquidationSlippagePercent moves liquidation fill prices against the
+	// liquidated position by the percentage, on top of any usual slippage.
+	// LiquidationFeeRate is a penalty fee charged on the liquidation's
+	// notional in addition to the taker fee. Zero values disable them
+	LiquidationSlippagePercent decimal.Decimal
+	LiquidationFeeRate         decimal.Decimal
+	// BorrowRatePercent is the annual percentage rate charged against the
+	// notional of open short futures positions, accrued every candle the
+	// position is held. Spot cannot be shorted, as spot sells are limited to
+	// the base currency held with no margin to borrow against. A cost the
+	// collateral cannot cover liquidates the position. Zero disables it
+	BorrowRatePercent decimal.Decimal
+	// FundingRate is the futures pair's funding rate history. At each funding
+	// time, open positions pay their notional at the candle's close multiplied
+	// by the rate when long and receive it when short, with negative rates
+	// reversing the flow. Positions opened since the previous funding time
+	// are pro-rated. It is loaded from the currency's funding-rates and
+	// funding-rate-csv-path config. Nil disables funding payments
+	FundingRate *gctorder.FundingRates `json:"-"`
+	// ExtremeVolatilityThreshold is the candle range, being its high less its
+	// low as a percentage of its close, at or above which a candle is treated
+	// as a flash event where fills are unreliable. Zero disables the check
+	ExtremeVolatilityThreshold decimal.Decimal
+	// ExtremeVolatilityBehaviour determines whether orders on extreme
+	// volatility candles are rejected or have their slippage multiplied by
+	// ExtremeVolatilitySlippageMultiplier. Empty rejects the order
+	ExtremeVolatilityBehaviour          string
+	ExtremeVolatilitySlippageMultiplier decimal.Decimal
+	// MaximumCapitalAllocation caps the total capital the currency can
+	// consume across its open positions. Entries which would exceed it
+	// are rejected, closing orders are always allowed. Zero means no cap
+	MaximumCapitalAllocation decimal.Decimal
+	// TagAllocations is the percentage of the currency's initial quote or
+	// collateral funds each strategy tag's entries may commit, letting
+	// composite strategies fund sub-strategies independently. Orders with
+	// tags without an allocation share the unallocated remainder
+	TagAllocations map[string]decimal.Decimal
+	// Accounts is the percentage of the currency's initial quote and base
+	// funds segregated into each named account. Signals are funded by their
+	// account and signals without one share the unallocated remainder
+	Accounts map[string]decimal.Decimal
+	// MinimumHoldingPeriod rejects orders exiting a position which has been
+	// held for less than the period, modelling lock-up or anti-flip
+	// constraints. Liquidations and stop-outs bypass it. Zero disables it
+	MinimumHoldingPeriod time.Duration
+	// MaxPositionAge closes any position which has been held for longer than
+	// the age, regardless of its PNL, modelling a time-stop exit. Zero means
+	// positions are held without limit
+	MaxPositionAge time.Duration
 
 	Limits                  gctorder.MinMaxLevel
 	CanUseExchangeLimits    bool
 	SkipCandleVolumeFitting bool
+	// VolumeFitWindow is the number of latest candles whose summed volume and
+	// high low range an order is fitted against, modelling an order executed
+	// over several candles. Zero or one fits against the latest candle only
+	VolumeFitWindow int
+	// AllowPartialFills carries the remainder of a spot order shrunk to fit
+	// the candle's volume over to subsequent candles, keeping its funds
+	// reserved until it is fully filled or cancelled at the end of the run
+	AllowPartialFills bool
+	// VolumeExceedBehaviour determines whether spot orders exceeding the
+	// volume of the candle they are fitted against are shrunk to fit, rejected
+	// or filled in full ignoring the volume. Empty shrinks the order
+	VolumeExceedBehaviour string
+	// ExecutionData is optional finer timeframe data for the same exchange,
+	// asset and pair. When set, orders derived from the coarser signal data
+	// are fitted against the execution candle closing with the signal candle.
+	// Orders still fill at the signal candle's close price, with only the
+	// execution candle's high, low and volume used when executing orders
+	// are fitted against the execution candle closing with the signal candle
+	ExecutionData data.Handler `json:"-"`
+	// TradingSession restricts when orders can fill. A nil
+	// TradingSession is always open, as with 24/7 crypto pairs
+	TradingSession *TradingSession
+	// Downtime are gaps in the data feed treated as exchange downtime.
+	// Orders placed during downtime are rejected and deferred orders
+	// are not released until the exchange is back up
+	Downtime []DowntimePeriod
+	// Latency samples a variable delay for each order before it fills.
+	// A nil Latency fills orders on the candle they are placed
+	Latency *LatencyDistribution
+	// ExecutionDelay is the number of candle intervals between an order
+	// being decided upon and it filling. Delayed orders fill at the close
+	// of the candle that many intervals later, taking priority over the
+	// signal to fill convention and sampled latency. Zero disables it
+	ExecutionDelay int64
+	// DrawdownStopOut closes positions when their drawdown breaches the
+	// threshold, modelling a hard stop-out. A nil DrawdownStopOut is disabled
+	DrawdownStopOut *DrawdownStopOut
+	// LosingStreakBreaker halts new entries after consecutive losing round
+	// trip trades. A nil LosingStreakBreaker is disabled
+	LosingStreakBreaker *LosingStreakBreaker
+	// OrderbookImbalanceDepth is the number of top orderbook levels used to
+	// calculate the imbalance attached to data events when using real orders.
+	// Zero disables the calculation
+	OrderbookImbalanceDepth int
+	// CrossedOrderbookBehaviour determines whether orders against a crossed
+	// or locked orderbook are rejected or wait for the next valid orderbook
+	// when using real orders. Empty rejects the order
+	CrossedOrderbookBehaviour string
+	// MaximumOrderbookAge rejects orders when using real orders if the
+	// orderbook was last updated longer than this before the order, guarding
+	// against filling on the depth of a stalled feed. Zero disables the check
+	MaximumOrderbookAge time.Duration
+	// DepthExhaustionBehaviour determines whether orders exceeding the total
+	// depth of the orderbook side they fill against are filled against the
+	// available depth or rejected when using real orders. Empty fills
+	// against the available depth
+	DepthExhaustionBehaviour string
+	// SubmissionRetries is how many times a real order submission is retried
+	// after a recoverable error such as a rate limit or timeout, waiting
+	// SubmissionRetryBackoff, doubled after each attempt, between them.
+	// Zero submits once
+	SubmissionRetries      int
+	SubmissionRetryBackoff time.Duration
+	// FeeShortfallBehaviour determines whether spot buys whose allocated
+	// funds cannot cover both their cost and fee are shrunk to fit or
+	// rejected. Sells whose proceeds cannot cover their fee are always
+	// rejected. Empty shrinks the order
+	FeeShortfallBehaviour string
+	// SlippageOverfillBehaviour determines whether buys whose notional at the
+	// price after slippage exceeds their allocated funds are shrunk to fit or
+	// rejected, preventing funding from going negative. Empty shrinks the order
+	SlippageOverfillBehaviour string
+	// RebatePolicy determines whether rebates earned from negative spot fees
+	// are reinvested into available funds immediately or accumulated
+	// separately without compounding. Empty reinvests rebates
+	RebatePolicy string
+	// FillTimeAssignment determines when within its candle an order fills,
+	// being its close, open or proportionally by where the fill price sat in
+	// the candle's range. Empty uses the candle close
+	FillTimeAssignment string
+	// SignalToFillConvention determines whether orders fill at the close of
+	// the candle which generated their signal or are deferred to fill at the
+	// open of the next candle. Empty defers to the next candle open
+	SignalToFillConvention string
+	// InitialBaseEntryPrice is the price the spot initial base funds were
+	// bought at, starting the run with an open position whose profit and
+	// loss is measured from the entry price. Zero values the initial base
+	// funds at the first close price
+	InitialBaseEntryPrice decimal.Decimal
+	// FlipPolicy determines how futures orders which exceed the open position
+	// in the opposite direction are treated, flipping the position, being
+	// clamped to close it exactly or being rejected. Empty allows the flip
+	FlipPolicy string
+	// RoundQuoteFunding rounds spot funding released and received in the
+	// quote currency down to QuotePrecision decimal places, preventing dust
+	// balances. A zero QuotePrecision uses the exchange limit price step
+	// when exchange limits are in use, otherwise the default precision.
+	// Fees are always rounded to QuotePrecision, or the default precision
+	// when zero
+	RoundQuoteFunding bool
+	QuotePrecision    int32
+	// RoundingMode is how prices are rounded to the price precision and fees
+	// to the quote precision. Empty uses the default common.RoundHalfEven. Amounts are
+	// always rounded toward zero so orders never exceed their reserved funds
+	RoundingMode string
+	// RecordFundingLedger attaches a ledger of each funding
+	// operation performed for an order to its fill event
+	RecordFundingLedger bool
+	// NetOfCostTargets calculates target prices net of the
+	// position's entry fees on both legs and the spread it paid
+	NetOfCostTargets bool
 
 	UseExchangePNLCalculation bool
 }
@@ -76,3 +410,188 @@ type Leverage struct {
 	MaximumOrdersWithLeverageRatio decimal.Decimal
 	MaximumLeverageRate            decimal.Decimal
 }
+
+// LosingStreakBreaker is a circuit breaker which halts new entries once
+// ConsecutiveLosses round trip trades in a row have lost money. Entries resume
+// after the Cooldown, or never resume when the Cooldown is zero. Exits are
+// always allowed
+type LosingStreakBreaker struct {
+	ConsecutiveLosses int64
+	Cooldown          time.Duration
+}
+
+// TradingSession defines the hours an instrument can be traded. Open and Close
+// are durations since midnight in Location, which defaults to UTC. A Close
+// before the Open is an overnight session which belongs to the day it opens.
+// Equal Open and Close values trade the whole day. Empty Days trades every day
+type TradingSession struct {
+	Days     []time.Weekday
+	Open     time.Duration
+	Close    time.Duration
+	Location *time.Location
+	// DeferOffHoursOrders holds orders placed outside the session
+	// until the next candle within the session, rather than rejecting them
+	DeferOffHoursOrders bool
+}
+
+// OrderbookImbalanceSetter is implemented by data
+// events which can hold the orderbook imbalance
+type OrderbookImbalanceSetter interface {
+	SetOrderbookImbalance(decimal.Decimal)
+}
+
+// Drawdown stop-out scopes
+const (
+	// DrawdownScopePosition measures the drawdown of the close price from its
+	// most favourable level since the pair's position was opened
+	DrawdownScopePosition = "position"
+	// DrawdownScopePortfolio measures the drawdown of the total value of all
+	// holdings from their peak and closes every open position when breached
+	DrawdownScopePortfolio = "portfolio"
+)
+
+const (
+	// CrossedOrderbookReject rejects orders placed against a crossed orderbook
+	CrossedOrderbookReject = "reject"
+	// CrossedOrderbookWait defers orders placed against a crossed orderbook
+	// until an orderbook which is not crossed is available
+	CrossedOrderbookWait = "wait"
+)
+
+const (
+	// DepthExhaustionPartialFill fills orders exceeding the orderbook's depth
+	// against the available depth, leaving the remainder unfilled
+	DepthExhaustionPartialFill = "partial-fill"
+	// DepthExhaustionReject rejects orders exceeding the orderbook's depth
+	DepthExhaustionReject = "reject"
+)
+
+const (
+	// VolumeExceedShrink shrinks orders exceeding the
+	// candle's volume to fit within it
+	VolumeExceedShrink = "shrink"
+	// VolumeExceedReject rejects orders exceeding the candle's volume
+	VolumeExceedReject = "reject"
+	// VolumeExceedAllow fills orders exceeding the
+	// candle's volume in full, ignoring the volume
+	VolumeExceedAllow = "allow"
+)
+
+const (
+	// FeeShortfallShrink shrinks spot buys until their
+	// allocated funds cover both their cost and fee
+	FeeShortfallShrink = "shrink"
+	// FeeShortfallReject rejects spot orders whose
+	// allocated funds cannot cover their fee
+	FeeShortfallReject = "reject"
+)
+
+const (
+	// SlippageOverfillShrink shrinks buys until their notional
+	// after slippage fits within their allocated funds
+	SlippageOverfillShrink = "shrink"
+	// SlippageOverfillReject rejects buys whose notional
+	// after slippage exceeds their allocated funds
+	SlippageOverfillReject = "reject"
+)
+
+const (
+	// ExtremeVolatilityReject rejects orders
+	// placed on extreme volatility candles
+	ExtremeVolatilityReject = "reject"
+	// ExtremeVolatilityAmplifySlippage multiplies the slippage of
+	// orders placed on extreme volatility candles
+	ExtremeVolatilityAmplifySlippage = "amplify-slippage"
+)
+
+const (
+	// RebateReinvest adds rebates to available funds as
+	// the order's funds are released
+	RebateReinvest = "reinvest"
+	// RebateAccumulate withholds rebates from available
+	// funds, accumulating them separately
+	RebateAccumulate = "accumulate"
+)
+
+const (
+	// FillTimeClose fills orders at their candle's close time
+	FillTimeClose = "close"
+	// FillTimeOpen fills orders at their candle's open time
+	FillTimeOpen = "open"
+	// FillTimeProportional fills orders between their candle's open and close
+	// time in proportion to where the fill price sat in the candle's range
+	FillTimeProportional = "proportional"
+)
+
+const (
+	// SameCandleClose fills orders at the close of the candle which
+	// generated their signal. This is optimistic, the strategy has already
+	// seen the close price it is filled at, introducing look-ahead bias
+	SameCandleClose = "same-candle-close"
+	// NextCandleOpen defers orders to fill at the open of the candle after
+	// their signal, the first price available once the signal is known
+	NextCandleOpen = "next-candle-open"
+)
+
+const (
+	// AllowFlip lets orders exceeding the open position in
+	// the opposite direction flip it through flat
+	AllowFlip = "allow-flip"
+	// ClampToFlat reduces orders exceeding the open position in
+	// the opposite direction to the size which closes it exactly
+	ClampToFlat = "clamp-to-flat"
+	// RejectExcess rejects orders exceeding the open
+	// position in the opposite direction
+	RejectExcess = "reject-excess"
+)
+
+// DrawdownStopOut defines a drawdown percentage which forcibly closes
+// open positions when breached. Stop-outs are recorded separately
+// from exchange-side liquidations
+type DrawdownStopOut struct {
+	MaximumDrawdownPercent decimal.Decimal
+	Scope                  string
+}
+
+// DowntimePeriod is a span of time in which the exchange could not
+// fill orders. Start is inclusive and End is exclusive
+type DowntimePeriod struct {
+	Start time.Time
+	End   time.Time
+}
+
+// FeeTier is a level of a volume based fee schedule, applying its maker
+// and taker fee rates once the traded volume reaches its threshold
+type FeeTier struct {
+	VolumeThreshold decimal.Decimal
+	MakerFee        decimal.Decimal
+	TakerFee        decimal.Decimal
+}
+
+// LatencyDistribution samples the delay each order experiences before filling.
+// Latencies are sampled from the weighted Histogram when set, otherwise from a
+// normal distribution with the Mean and StandardDeviation. Negative samples are
+// treated as zero. The Seed makes sampled latencies reproducible between runs
+type LatencyDistribution struct {
+	Histogram         []LatencyBucket
+	Mean              time.Duration
+	StandardDeviation time.Duration
+	Seed              int64
+	rng               *rand.Rand
+	samples           int64
+}
+
+// slippageSource is the source random slippage rates are drawn from. It
+// counts the values drawn so that a restored exchange can replay the source
+// to continue the same sequence
+type slippageSource struct {
+	source rand.Source
+	draws  int64
+}
+
+// LatencyBucket is a recorded latency and how
+// frequently it occurred relative to other buckets
+type LatencyBucket struct {
+	Latency time.Duration
+	Weight  int64
+}
@@ -0,0 +1,46 @@
+package exchange
+
+import (
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/fill"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/order"
+	gctorder "github.com/thrasher-corp/gocryptotrader/exchanges/order"
+)
+
+// handleExecutionDelay defers the order to fill at the close of the candle
+// the execution delay's number of intervals after the order was decided
+// upon. The delay is recorded as the order's latency so that it is released
+// by ReleaseDeferredOrders once elapsed and is not delayed again. The order
+// keeps its reserved funds until released. Liquidations, stop-outs, real
+// orders, resting limit orders, dormant stop orders, pending take-profit
+// orders, dormant trailing stop orders, partial fill remainders and orders
+// already deferred are filled without delay
+func (e *Exchange) handleExecutionDelay(o order.Event, f *fill.Fill, cs *Settings) bool {
+	if cs.ExecutionDelay <= 0 ||
+		cs.UseRealOrders ||
+		o.IsLiquidating() ||
+		o.IsStopOut() ||
+		o.IsDeferredToNextOpen() ||
+		o.IsRestingLimit() ||
+		o.IsDormantStop() ||
+		o.IsPendingTakeProfit() ||
+		o.IsDormantTrailingStop() ||
+		o.IsPartialFill() ||
+		o.GetLatency() > 0 {
+		return false
+	}
+	delay := time.Duration(cs.ExecutionDelay) * o.GetInterval().Duration()
+	if delay <= 0 {
+		return false
+	}
+	ord := e.deferOrder(o)
+	if ord == nil {
+		return false
+	}
+	ord.Latency = delay
+	f.Latency = delay
+	f.SetDirection(gctorder.DoNothing)
+	f.AppendReasonf("Order decided at %v delayed by %v candles, deferred until %v", o.GetTime(), cs.ExecutionDelay, o.GetTime().Add(delay))
+	return true
+}
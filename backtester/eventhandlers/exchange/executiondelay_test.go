@@ -0,0 +1,111 @@
+package exchange
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/event"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/kline"
+	gctkline "github.com/thrasher-corp/gocryptotrader/exchanges/kline"
+	gctorder "github.com/thrasher-corp/gocryptotrader/exchanges/order"
+)
+
+func TestExecuteOrderExecutionDelay(t *testing.T) {
+	t.Parallel()
+	om, exch := setupOfflineOrderManager(t)
+	decided := gctkline.Candle{Open: 100, Close: 100, High: 104, Low: 96, Volume: 1000}
+	o, d := setupOfflineOrder(t, gctorder.Buy, decimal.NewFromInt(1), decimal.NewFromInt(100), decided)
+	cs := Settings{
+		Exchange:               exch,
+		Pair:                   o.Pair(),
+		Asset:                  o.GetAssetType(),
+		MinimumSlippageRate:    decimal.NewFromInt(100),
+		MaximumSlippageRate:    decimal.NewFromInt(100),
+		SignalToFillConvention: NextCandleOpen,
+		ExecutionDelay:         2,
+	}
+	e := Exchange{CurrencySettings: []Settings{cs}}
+	f, err := e.ExecuteOrder(context.Background(), o, d, om, &fakeFund{})
+	if !errors.Is(err, ErrCannotTransact) {
+		t.Fatalf("received '%v' expected '%v'", err, ErrCannotTransact)
+	}
+	if f.GetDirection() != gctorder.DoNothing {
+		t.Errorf("received '%v' expected '%v'", f.GetDirection(), gctorder.DoNothing)
+	}
+	// the execution delay takes priority over the signal to fill convention
+	if len(e.deferredOrders) != 1 || e.deferredOrders[0].IsDeferredToNextOpen() {
+		t.Fatalf("expected a single delayed order, received '%v'", e.deferredOrders)
+	}
+	if e.deferredOrders[0].GetLatency() != 2*o.GetInterval().Duration() {
+		t.Errorf("received '%v' expected '%v'", e.deferredOrders[0].GetLatency(), 2*o.GetInterval().Duration())
+	}
+
+	ev := &kline.Kline{
+		Base: &event.Base{
+			Offset:       o.GetOffset() + 1,
+			Exchange:     o.GetExchange(),
+			Time:         o.GetTime().Add(o.GetInterval().Duration()),
+			Interval:     o.GetInterval(),
+			CurrencyPair: o.Pair(),
+			AssetType:    o.GetAssetType(),
+		},
+		Open:  decimal.NewFromInt(100),
+		Close: decimal.NewFromInt(110),
+	}
+	released, err := e.ReleaseDeferredOrders(ev)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	if len(released) != 0 {
+		t.Fatalf("received '%v' expected '%v'", len(released), 0)
+	}
+
+	ev.Offset++
+	ev.Time = ev.Time.Add(o.GetInterval().Duration())
+	ev.Open = decimal.NewFromInt(110)
+	ev.Close = decimal.NewFromInt(120)
+	released, err = e.ReleaseDeferredOrders(ev)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	if len(released) != 1 {
+		t.Fatalf("received '%v' expected '%v'", len(released), 1)
+	}
+	_, d = setupOfflineOrder(t, gctorder.Buy, decimal.NewFromInt(1), decimal.NewFromInt(100),
+		decided,
+		gctkline.Candle{Open: 100, Close: 110, High: 112, Low: 98, Volume: 1000},
+		gctkline.Candle{Open: 110, Close: 120, High: 124, Low: 108, Volume: 1000})
+	f, err = e.ExecuteOrder(context.Background(), released[0], d, om, &fakeFund{})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	if f.GetDirection() != gctorder.Buy {
+		t.Errorf("received '%v' expected '%v'", f.GetDirection(), gctorder.Buy)
+	}
+	// the fill is priced at the close of the candle two intervals later
+	if !f.GetPurchasePrice().Equal(decimal.NewFromInt(120)) {
+		t.Errorf("received '%v' expected '%v'", f.GetPurchasePrice(), decimal.NewFromInt(120))
+	}
+	if !f.GetDecisionTime().Equal(o.GetTime()) {
+		t.Errorf("received '%v' expected '%v'", f.GetDecisionTime(), o.GetTime())
+	}
+	if !f.GetFillTime().Equal(ev.GetTime()) {
+		t.Errorf("received '%v' expected '%v'", f.GetFillTime(), ev.GetTime())
+	}
+	if len(e.deferredOrders) != 0 {
+		t.Errorf("received '%v' expected '%v'", len(e.deferredOrders), 0)
+	}
+
+	e.CurrencySettings[0].ExecutionDelay = 0
+	e.CurrencySettings[0].SignalToFillConvention = SameCandleClose
+	o, d = setupOfflineOrder(t, gctorder.Buy, decimal.NewFromInt(1), decimal.NewFromInt(100), decided)
+	f, err = e.ExecuteOrder(context.Background(), o, d, om, &fakeFund{})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	if !f.GetDecisionTime().Equal(f.GetFillTime()) {
+		t.Errorf("received '%v' expected '%v'", f.GetDecisionTime(), f.GetFillTime())
+	}
+}
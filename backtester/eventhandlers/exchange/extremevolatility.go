@@ -0,0 +1,47 @@
+package exchange
+
+import (
+	"github.com/shopspring/decimal"
+	"github.com/thrasher-corp/gocryptotrader/backtester/common"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/fill"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/order"
+	"github.com/thrasher-corp/gocryptotrader/backtester/funding"
+)
+
+// candleRangePercent returns the range of a candle, being
+// its high less its low as a percentage of its close
+func candleRangePercent(d common.DataEventHandler) decimal.Decimal {
+	if d == nil || !d.GetClosePrice().IsPositive() {
+		return decimal.Zero
+	}
+	return d.GetHighPrice().Sub(d.GetLowPrice()).Div(d.GetClosePrice()).Mul(decimal.NewFromInt(100))
+}
+
+// isExtremeVolatility returns the candle's range and whether it reaches the
+// extreme volatility threshold. A threshold of zero disables the check
+func isExtremeVolatility(d common.DataEventHandler, threshold decimal.Decimal) (decimal.Decimal, bool) {
+	if !threshold.IsPositive() {
+		return decimal.Zero, false
+	}
+	rangePercent := candleRangePercent(d)
+	return rangePercent, rangePercent.GreaterThanOrEqual(threshold)
+}
+
+// amplifySlippage multiplies the slippage of a slippage rate, being the
+// multiplier applied to the price where 1 is no slippage, by the multiplier.
+// Slippage cannot exceed the whole price
+func amplifySlippage(slippageRate, multiplier decimal.Decimal) decimal.Decimal {
+	one := decimal.NewFromInt(1)
+	amplified := one.Sub(one.Sub(slippageRate).Mul(multiplier))
+	if amplified.IsNegative() {
+		return decimal.Zero
+	}
+	return amplified
+}
+
+// handleExtremeVolatility rejects an order placed on a candle
+// whose range reaches the extreme volatility threshold
+func handleExtremeVolatility(o order.Event, f *fill.Fill, funds funding.IFundReleaser, cs *Settings, rangePercent decimal.Decimal) (fill.Event, error) {
+	f.AppendReasonf("Order rejected as candle range of %v%% reaches extreme volatility threshold of %v%%", rangePercent.Round(4), cs.ExtremeVolatilityThreshold)
+	return f, allocateFundsPostOrder(f, funds, errExtremeVolatility, o.GetAmount(), o.GetAllocatedFunds(), decimal.Zero, decimal.Zero, decimal.Zero, cs)
+}
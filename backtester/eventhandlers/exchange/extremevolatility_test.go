@@ -0,0 +1,88 @@
+package exchange
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/event"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/kline"
+	gctkline "github.com/thrasher-corp/gocryptotrader/exchanges/kline"
+	gctorder "github.com/thrasher-corp/gocryptotrader/exchanges/order"
+)
+
+func TestIsExtremeVolatility(t *testing.T) {
+	t.Parallel()
+	k := &kline.Kline{
+		Base:  &event.Base{},
+		High:  decimal.NewFromInt(110),
+		Low:   decimal.NewFromInt(95),
+		Close: decimal.NewFromInt(100),
+	}
+	rangePercent, isExtreme := isExtremeVolatility(k, decimal.NewFromInt(10))
+	if !isExtreme || !rangePercent.Equal(decimal.NewFromInt(15)) {
+		t.Errorf("received '%v' '%v' expected '%v' '%v'", rangePercent, isExtreme, 15, true)
+	}
+	if _, isExtreme = isExtremeVolatility(k, decimal.NewFromInt(20)); isExtreme {
+		t.Error("expected candle within threshold")
+	}
+	if _, isExtreme = isExtremeVolatility(k, decimal.Zero); isExtreme {
+		t.Error("expected zero threshold to disable the check")
+	}
+	if _, isExtreme = isExtremeVolatility(nil, decimal.NewFromInt(10)); isExtreme {
+		t.Error("expected nil candle to be ignored")
+	}
+}
+
+func TestAmplifySlippage(t *testing.T) {
+	t.Parallel()
+	resp := amplifySlippage(decimal.NewFromFloat(0.99), decimal.NewFromInt(3))
+	if !resp.Equal(decimal.NewFromFloat(0.97)) {
+		t.Errorf("received '%v' expected '%v'", resp, 0.97)
+	}
+	resp = amplifySlippage(decimal.NewFromFloat(0.5), decimal.NewFromInt(3))
+	if !resp.IsZero() {
+		t.Errorf("received '%v' expected '%v'", resp, 0)
+	}
+	resp = amplifySlippage(decimal.NewFromInt(1), decimal.NewFromInt(3))
+	if !resp.Equal(decimal.NewFromInt(1)) {
+		t.Errorf("received '%v' expected '%v'", resp, 1)
+	}
+}
+
+func TestExecuteOrderExtremeVolatility(t *testing.T) {
+	t.Parallel()
+	om, exch := setupOfflineOrderManager(t)
+	candle := gctkline.Candle{Close: 100, High: 120, Low: 90, Volume: 100000}
+	o, d := setupOfflineOrder(t, gctorder.Buy, decimal.NewFromInt(1), decimal.NewFromInt(100), candle)
+	cs := Settings{
+		Exchange:                   exch,
+		Pair:                       o.Pair(),
+		Asset:                      o.GetAssetType(),
+		MinimumSlippageRate:        decimal.NewFromInt(99),
+		MaximumSlippageRate:        decimal.NewFromInt(100),
+		ExtremeVolatilityThreshold: decimal.NewFromInt(10),
+		SignalToFillConvention:     SameCandleClose,
+	}
+	e := Exchange{CurrencySettings: []Settings{cs}}
+	f, err := e.ExecuteOrder(context.Background(), o, d, om, &fakeFund{})
+	if !errors.Is(err, errExtremeVolatility) {
+		t.Errorf("received '%v' expected '%v'", err, errExtremeVolatility)
+	}
+	if f.GetDirection() != gctorder.CouldNotBuy {
+		t.Errorf("received '%v' expected '%v'", f.GetDirection(), gctorder.CouldNotBuy)
+	}
+
+	o, d = setupOfflineOrder(t, gctorder.Buy, decimal.NewFromInt(1), decimal.NewFromInt(100), candle)
+	cs.ExtremeVolatilityBehaviour = ExtremeVolatilityAmplifySlippage
+	cs.ExtremeVolatilitySlippageMultiplier = decimal.NewFromInt(3)
+	e = Exchange{CurrencySettings: []Settings{cs}}
+	f, err = e.ExecuteOrder(context.Background(), o, d, om, &fakeFund{})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	if !f.GetPurchasePrice().Equal(decimal.NewFromInt(103)) {
+		t.Errorf("received '%v' expected '%v'", f.GetPurchasePrice(), 103)
+	}
+}
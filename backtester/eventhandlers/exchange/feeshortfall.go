@@ -0,0 +1,45 @@
+package exchange
+
+import (
+	"github.com/shopspring/decimal"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/fill"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/order"
+	"github.com/thrasher-corp/gocryptotrader/backtester/funding"
+	gctorder "github.com/thrasher-corp/gocryptotrader/exchanges/order"
+)
+
+// feeShortfall returns how far the allocated funds fall short of covering a
+// spot order and its fee. Buys must cover the order's cost plus its fee from
+// the reserved quote, while sells must cover the fee from their proceeds
+func feeShortfall(direction gctorder.Side, adjustedPrice, amount, fee, allocatedFunds decimal.Decimal) (decimal.Decimal, bool) {
+	var remaining decimal.Decimal
+	switch direction {
+	case gctorder.Buy, gctorder.Bid:
+		remaining = allocatedFunds.Sub(adjustedPrice.Mul(amount)).Sub(fee)
+	case gctorder.Sell, gctorder.Ask:
+		remaining = adjustedPrice.Mul(amount).Sub(fee)
+	default:
+		return decimal.Zero, false
+	}
+	if !remaining.IsNegative() {
+		return decimal.Zero, false
+	}
+	return remaining.Neg(), true
+}
+
+// shrinkAmountToCoverFee returns the largest buy amount whose cost at the
+// adjusted price plus its fee at the fee price fits within the allocated funds
+func shrinkAmountToCoverFee(adjustedPrice, feePrice, feeRate, allocatedFunds decimal.Decimal, amountPrecision int32) decimal.Decimal {
+	unitCost := adjustedPrice.Add(feePrice.Mul(feeRate))
+	if !unitCost.IsPositive() || !allocatedFunds.IsPositive() {
+		return decimal.Zero
+	}
+	return allocatedFunds.Div(unitCost).Truncate(amountPrecision)
+}
+
+// handleFeeShortfall rejects a spot order whose allocated
+// funds cannot cover its fee
+func handleFeeShortfall(o order.Event, f *fill.Fill, funds funding.IFundReleaser, cs *Settings, shortfall, fee decimal.Decimal) (fill.Event, error) {
+	f.AppendReasonf("Allocated funds %v cannot cover fee %v, short by %v, rejected", o.GetAllocatedFunds(), fee, shortfall)
+	return f, allocateFundsPostOrder(f, funds, errInsufficientFeeFunds, o.GetAmount(), o.GetAllocatedFunds(), decimal.Zero, decimal.Zero, decimal.Zero, cs)
+}
@@ -0,0 +1,83 @@
+package exchange
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	gctkline "github.com/thrasher-corp/gocryptotrader/exchanges/kline"
+	gctorder "github.com/thrasher-corp/gocryptotrader/exchanges/order"
+)
+
+func TestFeeShortfall(t *testing.T) {
+	t.Parallel()
+	price := decimal.NewFromInt(100)
+	shortfall, isShort := feeShortfall(gctorder.Buy, price, decimal.NewFromInt(1), decimal.NewFromInt(1), decimal.NewFromInt(101))
+	if isShort || !shortfall.IsZero() {
+		t.Errorf("received '%v' '%v' expected '%v' '%v'", shortfall, isShort, 0, false)
+	}
+	shortfall, isShort = feeShortfall(gctorder.Buy, price, decimal.NewFromInt(1), decimal.NewFromInt(5), decimal.NewFromInt(101))
+	if !isShort || !shortfall.Equal(decimal.NewFromInt(4)) {
+		t.Errorf("received '%v' '%v' expected '%v' '%v'", shortfall, isShort, 4, true)
+	}
+	shortfall, isShort = feeShortfall(gctorder.Sell, price, decimal.NewFromInt(1), decimal.NewFromInt(101), decimal.NewFromInt(1))
+	if !isShort || !shortfall.Equal(decimal.NewFromInt(1)) {
+		t.Errorf("received '%v' '%v' expected '%v' '%v'", shortfall, isShort, 1, true)
+	}
+	if _, isShort = feeShortfall(gctorder.Short, price, decimal.NewFromInt(1), decimal.NewFromInt(1337), decimal.Zero); isShort {
+		t.Error("expected unsupported direction to be ignored")
+	}
+}
+
+func TestShrinkAmountToCoverFee(t *testing.T) {
+	t.Parallel()
+	resp := shrinkAmountToCoverFee(decimal.NewFromInt(100), decimal.NewFromInt(100), decimal.NewFromFloat(0.1), decimal.NewFromInt(1100), 8)
+	if !resp.Equal(decimal.NewFromInt(10)) {
+		t.Errorf("received '%v' expected '%v'", resp, 10)
+	}
+	resp = shrinkAmountToCoverFee(decimal.Zero, decimal.Zero, decimal.Zero, decimal.NewFromInt(1100), 8)
+	if !resp.IsZero() {
+		t.Errorf("received '%v' expected '%v'", resp, 0)
+	}
+}
+
+func TestExecuteOrderFeeShortfall(t *testing.T) {
+	t.Parallel()
+	om, exch := setupOfflineOrderManager(t)
+	// the order's cost consumes all allocated funds leaving no buffer for its fee
+	o, d := setupOfflineOrder(t, gctorder.Buy, decimal.NewFromFloat(13.37), decimal.NewFromInt(100),
+		gctkline.Candle{Close: 100, High: 110, Low: 90, Volume: 100000})
+	cs := Settings{
+		Exchange:               exch,
+		Pair:                   o.Pair(),
+		Asset:                  o.GetAssetType(),
+		MinimumSlippageRate:    decimal.NewFromInt(100),
+		MaximumSlippageRate:    decimal.NewFromInt(100),
+		TakerFee:               decimal.NewFromFloat(0.1),
+		SignalToFillConvention: SameCandleClose,
+	}
+	e := Exchange{CurrencySettings: []Settings{cs}}
+	f, err := e.ExecuteOrder(context.Background(), o, d, om, &fakeFund{})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	if !f.GetAmount().Equal(decimal.NewFromFloat(12.15454545)) {
+		t.Errorf("received '%v' expected '%v'", f.GetAmount(), 12.15454545)
+	}
+	if f.GetAmount().Mul(f.GetPurchasePrice()).Add(f.GetExchangeFee()).GreaterThan(o.GetAllocatedFunds()) {
+		t.Errorf("received cost '%v' expected it to fit within '%v'", f.GetAmount().Mul(f.GetPurchasePrice()).Add(f.GetExchangeFee()), o.GetAllocatedFunds())
+	}
+
+	cs.FeeShortfallBehaviour = FeeShortfallReject
+	e = Exchange{CurrencySettings: []Settings{cs}}
+	o, d = setupOfflineOrder(t, gctorder.Buy, decimal.NewFromFloat(13.37), decimal.NewFromInt(100),
+		gctkline.Candle{Close: 100, High: 110, Low: 90, Volume: 100000})
+	f, err = e.ExecuteOrder(context.Background(), o, d, om, &fakeFund{})
+	if !errors.Is(err, errInsufficientFeeFunds) {
+		t.Fatalf("received '%v' expected '%v'", err, errInsufficientFeeFunds)
+	}
+	if f.GetDirection() != gctorder.CouldNotBuy {
+		t.Errorf("received '%v' expected '%v'", f.GetDirection(), gctorder.CouldNotBuy)
+	}
+}
@@ -0,0 +1,64 @@
+package exchange
+
+import (
+	"github.com/shopspring/decimal"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/fill"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/order"
+)
+
+// getTradedVolume returns the cumulative notional the
+// exchange account has traded over the run
+func (e *Exchange) getTradedVolume(exch, account string) decimal.Decimal {
+	if e.tradedVolumes == nil {
+		return decimal.Zero
+	}
+	return e.tradedVolumes[exch][account]
+}
+
+// addTradedVolume adds the notional of a successful fill
+// to its exchange account's cumulative traded volume
+func (e *Exchange) addTradedVolume(o order.Event, f fill.Event) {
+	notional := f.GetAmount().Mul(f.GetPurchasePrice())
+	if !notional.IsPositive() {
+		return
+	}
+	exch := o.GetExchange()
+	if e.tradedVolumes == nil {
+		e.tradedVolumes = make(map[string]map[string]decimal.Decimal)
+	}
+	if e.tradedVolumes[exch] == nil {
+		e.tradedVolumes[exch] = make(map[string]decimal.Decimal)
+	}
+	e.tradedVolumes[exch][o.GetAccount()] = e.tradedVolumes[exch][o.GetAccount()].Add(notional)
+}
+
+// feeTierForVolume returns the fee tier with the highest threshold
+// the traded volume has reached. Returns nil when no tier is reached
+func (s *Settings) feeTierForVolume(volume decimal.Decimal) *FeeTier {
+	var tier *FeeTier
+	for i := range s.FeeTiers {
+		if volume.LessThan(s.FeeTiers[i].VolumeThreshold) {
+			continue
+		}
+		if tier == nil || s.FeeTiers[i].VolumeThreshold.GreaterThan(tier.VolumeThreshold) {
+			tier = &s.FeeTiers[i]
+		}
+	}
+	return tier
+}
+
+// feeRatesForVolume returns the maker and taker fee rates for the order,
+// being those of the fee tier its exchange account's traded volume has
+// reached, or the currency's maker and taker fees when no tier is reached
+func (e *Exchange) feeRatesForVolume(o order.Event, f *fill.Fill, cs *Settings) (makerFee, takerFee decimal.Decimal) {
+	if len(cs.FeeTiers) == 0 {
+		return cs.MakerFee, cs.TakerFee
+	}
+	volume := e.getTradedVolume(o.GetExchange(), o.GetAccount())
+	tier := cs.feeTierForVolume(volume)
+	if tier == nil {
+		return cs.MakerFee, cs.TakerFee
+	}
+	f.AppendReasonf("Fee tier from %v traded volume applied at %v traded, maker fee %v taker fee %v", tier.VolumeThreshold, volume, tier.MakerFee, tier.TakerFee)
+	return tier.MakerFee, tier.TakerFee
+}
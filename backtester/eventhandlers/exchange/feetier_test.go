@@ -0,0 +1,77 @@
+package exchange
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	gctkline "github.com/thrasher-corp/gocryptotrader/exchanges/kline"
+	gctorder "github.com/thrasher-corp/gocryptotrader/exchanges/order"
+)
+
+func TestFeeTierForVolume(t *testing.T) {
+	t.Parallel()
+	s := &Settings{}
+	if tier := s.feeTierForVolume(decimal.NewFromInt(1337)); tier != nil {
+		t.Errorf("received '%v' expected '%v'", tier, nil)
+	}
+	s.FeeTiers = []FeeTier{
+		{VolumeThreshold: decimal.NewFromInt(1000), TakerFee: decimal.NewFromFloat(0.0001)},
+		{VolumeThreshold: decimal.NewFromInt(100), TakerFee: decimal.NewFromFloat(0.0005)},
+	}
+	if tier := s.feeTierForVolume(decimal.NewFromInt(99)); tier != nil {
+		t.Errorf("received '%v' expected '%v'", tier, nil)
+	}
+	tier := s.feeTierForVolume(decimal.NewFromInt(100))
+	if tier == nil || !tier.TakerFee.Equal(decimal.NewFromFloat(0.0005)) {
+		t.Errorf("received '%v' expected '%v'", tier, s.FeeTiers[1])
+	}
+	tier = s.feeTierForVolume(decimal.NewFromInt(1337))
+	if tier == nil || !tier.TakerFee.Equal(decimal.NewFromFloat(0.0001)) {
+		t.Errorf("received '%v' expected '%v'", tier, s.FeeTiers[0])
+	}
+}
+
+func TestExecuteOrderFeeTiers(t *testing.T) {
+	t.Parallel()
+	om, exch := setupOfflineOrderManager(t)
+	o, d := setupOfflineOrder(t, gctorder.Buy, decimal.NewFromInt(1), decimal.NewFromInt(100),
+		gctkline.Candle{Open: 100, Close: 100, High: 100, Low: 100, Volume: 100000})
+	cs := Settings{
+		Exchange:            exch,
+		Pair:                o.Pair(),
+		Asset:               o.GetAssetType(),
+		MinimumSlippageRate: decimal.NewFromInt(100),
+		MaximumSlippageRate: decimal.NewFromInt(100),
+		TakerFee:            decimal.NewFromFloat(0.001),
+		FeeTiers: []FeeTier{
+			{VolumeThreshold: decimal.NewFromInt(150), TakerFee: decimal.NewFromFloat(0.0005)},
+		},
+		SignalToFillConvention: SameCandleClose,
+	}
+	e := Exchange{CurrencySettings: []Settings{cs}}
+	// the first two fills trade 200 of volume at the base taker fee, after
+	// which the tier's threshold of 150 is crossed and its fee steps down
+	for i, expectedFee := range []float64{0.1, 0.1, 0.05} {
+		f, err := e.ExecuteOrder(context.Background(), o, d, om, &fakeFund{})
+		if !errors.Is(err, nil) {
+			t.Fatalf("received '%v' expected '%v'", err, nil)
+		}
+		if !f.GetExchangeFee().Equal(decimal.NewFromFloat(expectedFee)) {
+			t.Errorf("fill %v received '%v' expected '%v'", i, f.GetExchangeFee(), expectedFee)
+		}
+	}
+	if volume := e.getTradedVolume(testExchange, ""); !volume.Equal(decimal.NewFromInt(300)) {
+		t.Errorf("received '%v' expected '%v'", volume, decimal.NewFromInt(300))
+	}
+	// volume is tracked per account
+	o.Account = "variant a"
+	f, err := e.ExecuteOrder(context.Background(), o, d, om, &fakeFund{})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	if !f.GetExchangeFee().Equal(decimal.NewFromFloat(0.1)) {
+		t.Errorf("received '%v' expected '%v'", f.GetExchangeFee(), 0.1)
+	}
+}
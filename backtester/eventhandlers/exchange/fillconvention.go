@@ -0,0 +1,47 @@
+package exchange
+
+import (
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/fill"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/order"
+	gctorder "github.com/thrasher-corp/gocryptotrader/exchanges/order"
+)
+
+// FillsAtNextCandleOpen returns whether orders are deferred to fill at the open
+// of the next candle, being the NextCandleOpen convention and the default when
+// no convention is set
+func (s *Settings) FillsAtNextCandleOpen() bool {
+	return s.SignalToFillConvention == "" || s.SignalToFillConvention == NextCandleOpen
+}
+
+// deferToNextOpen defers the order to fill at the open of the next candle when
+// using the NextCandleOpen convention, which empty conventions default to, so
+// that orders never fill at the close the strategy has already seen. Orders
+// only fill at the signal candle's close when the SameCandleClose convention
+// is explicitly set. The order keeps its reserved funds
+// until released. Liquidations, stop-outs, real orders, resting limit orders,
+// dormant stop orders, pending take-profit orders, dormant trailing stop
+// orders, partial fill remainders and orders already deferred by latency or
+// to the next open are filled without deferral
+func (e *Exchange) deferToNextOpen(o order.Event, f *fill.Fill, cs *Settings) bool {
+	if !cs.FillsAtNextCandleOpen() ||
+		cs.UseRealOrders ||
+		o.IsLiquidating() ||
+		o.IsStopOut() ||
+		o.IsDeferredToNextOpen() ||
+		o.IsRestingLimit() ||
+		o.IsDormantStop() ||
+		o.IsPendingTakeProfit() ||
+		o.IsDormantTrailingStop() ||
+		o.IsPartialFill() ||
+		o.GetLatency() > 0 {
+		return false
+	}
+	ord := e.deferOrder(o)
+	if ord == nil {
+		return false
+	}
+	ord.DeferredToNextOpen = true
+	f.SetDirection(gctorder.DoNothing)
+	f.AppendReasonf("Order signalled at %v close, deferred to the next candle open", o.GetTime())
+	return true
+}
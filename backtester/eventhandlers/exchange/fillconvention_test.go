@@ -0,0 +1,125 @@
+package exchange
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/event"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/fill"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/kline"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/order"
+	gctkline "github.com/thrasher-corp/gocryptotrader/exchanges/kline"
+	gctorder "github.com/thrasher-corp/gocryptotrader/exchanges/order"
+)
+
+func TestExecuteOrderNextCandleOpen(t *testing.T) {
+	t.Parallel()
+	om, exch := setupOfflineOrderManager(t)
+	o, d := setupOfflineOrder(t, gctorder.Buy, decimal.NewFromInt(1), decimal.NewFromInt(100),
+		gctkline.Candle{Close: 100, High: 110, Low: 90, Volume: 1000})
+	cs := Settings{
+		Exchange:               exch,
+		Pair:                   o.Pair(),
+		Asset:                  o.GetAssetType(),
+		MinimumSlippageRate:    decimal.NewFromInt(100),
+		MaximumSlippageRate:    decimal.NewFromInt(100),
+		SignalToFillConvention: SameCandleClose,
+	}
+	e := Exchange{CurrencySettings: []Settings{cs}}
+	f, err := e.ExecuteOrder(context.Background(), o, d, om, &fakeFund{})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	if f.GetDirection() != gctorder.Buy {
+		t.Errorf("received '%v' expected '%v'", f.GetDirection(), gctorder.Buy)
+	}
+
+	e.CurrencySettings[0].SignalToFillConvention = NextCandleOpen
+	o, d = setupOfflineOrder(t, gctorder.Buy, decimal.NewFromInt(1), decimal.NewFromInt(100),
+		gctkline.Candle{Close: 100, High: 110, Low: 90, Volume: 1000})
+	f, err = e.ExecuteOrder(context.Background(), o, d, om, &fakeFund{})
+	if !errors.Is(err, ErrCannotTransact) {
+		t.Fatalf("received '%v' expected '%v'", err, ErrCannotTransact)
+	}
+	if f.GetDirection() != gctorder.DoNothing {
+		t.Errorf("received '%v' expected '%v'", f.GetDirection(), gctorder.DoNothing)
+	}
+	if len(e.deferredOrders) != 1 {
+		t.Fatalf("received '%v' expected '%v'", len(e.deferredOrders), 1)
+	}
+	if !e.deferredOrders[0].IsDeferredToNextOpen() {
+		t.Errorf("received '%v' expected '%v'", e.deferredOrders[0].IsDeferredToNextOpen(), true)
+	}
+
+	ev := &kline.Kline{
+		Base: &event.Base{
+			Offset:       o.GetOffset(),
+			Exchange:     o.GetExchange(),
+			Time:         o.GetTime(),
+			Interval:     o.GetInterval(),
+			CurrencyPair: o.Pair(),
+			AssetType:    o.GetAssetType(),
+		},
+		Open:  decimal.NewFromInt(95),
+		Close: decimal.NewFromInt(100),
+	}
+	released, err := e.ReleaseDeferredOrders(ev)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	if len(released) != 0 {
+		t.Errorf("received '%v' expected '%v'", len(released), 0)
+	}
+	ev.Offset++
+	ev.Time = ev.Time.Add(o.GetInterval().Duration())
+	released, err = e.ReleaseDeferredOrders(ev)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	if len(released) != 1 {
+		t.Fatalf("received '%v' expected '%v'", len(released), 1)
+	}
+	if !released[0].GetClosePrice().Equal(ev.Open) {
+		t.Errorf("received '%v' expected '%v'", released[0].GetClosePrice(), ev.Open)
+	}
+
+	// released orders are not deferred again
+	if e.deferToNextOpen(released[0], &fill.Fill{Base: &event.Base{}}, &e.CurrencySettings[0]) {
+		t.Error("expected released order to fill without deferral")
+	}
+}
+
+func TestDeferToNextOpen(t *testing.T) {
+	t.Parallel()
+	e := Exchange{}
+	cs := &Settings{SignalToFillConvention: NextCandleOpen}
+	o := &order.Order{
+		Base:                &event.Base{},
+		Direction:           gctorder.Sell,
+		LiquidatingPosition: true,
+	}
+	f := &fill.Fill{Base: &event.Base{}}
+	if e.deferToNextOpen(o, f, cs) {
+		t.Error("expected liquidation to fill without deferral")
+	}
+	o.LiquidatingPosition = false
+	cs.UseRealOrders = true
+	if e.deferToNextOpen(o, f, cs) {
+		t.Error("expected real order to fill without deferral")
+	}
+	cs.UseRealOrders = false
+	if !e.deferToNextOpen(o, f, cs) {
+		t.Error("expected order to be deferred")
+	}
+	// an empty convention defers to the next candle open
+	cs.SignalToFillConvention = ""
+	if !e.deferToNextOpen(o, f, cs) {
+		t.Error("expected order to be deferred by default")
+	}
+	cs.SignalToFillConvention = SameCandleClose
+	if e.deferToNextOpen(o, f, cs) {
+		t.Error("expected same candle close order to fill without deferral")
+	}
+}
@@ -0,0 +1,58 @@
+package exchange
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/thrasher-corp/gocryptotrader/backtester/data"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/fill"
+)
+
+// calculateFillTime returns when within its candle an order filled. The
+// candle's event time is its close, the open is one interval earlier and a
+// proportional fill is placed between them by where the fill price sat
+// between the candle's low and high
+func calculateFillTime(assignment string, candleTime time.Time, interval time.Duration, price, high, low decimal.Decimal) time.Time {
+	switch assignment {
+	case FillTimeOpen:
+		return candleTime.Add(-interval)
+	case FillTimeProportional:
+		fraction := decimal.NewFromFloat(0.5)
+		if high.GreaterThan(low) {
+			fraction = price.Sub(low).Div(high.Sub(low))
+		}
+		if fraction.IsNegative() {
+			fraction = decimal.Zero
+		}
+		if fraction.GreaterThan(decimal.NewFromInt(1)) {
+			fraction = decimal.NewFromInt(1)
+		}
+		elapsed := decimal.NewFromInt(int64(interval)).Mul(fraction).IntPart()
+		return candleTime.Add(-interval).Add(time.Duration(elapsed))
+	default:
+		return candleTime
+	}
+}
+
+// assignFillTime records when within its candle the order filled according
+// to the fill time assignment. The fill's event time remains the candle's
+// time so holdings and statistics stay aligned to each candle
+func assignFillTime(f *fill.Fill, d data.Handler, cs *Settings) {
+	f.FillTime = f.GetTime()
+	if cs.FillTimeAssignment == "" || cs.FillTimeAssignment == FillTimeClose || d == nil {
+		return
+	}
+	latest := d.Latest()
+	if latest == nil {
+		return
+	}
+	f.FillTime = calculateFillTime(cs.FillTimeAssignment,
+		f.GetTime(),
+		latest.GetInterval().Duration(),
+		f.GetPurchasePrice(),
+		latest.GetHighPrice(),
+		latest.GetLowPrice())
+	if !f.FillTime.Equal(f.GetTime()) {
+		f.AppendReasonf("Fill time assigned to %v within the candle by %v", f.FillTime, cs.FillTimeAssignment)
+	}
+}
@@ -0,0 +1,61 @@
+package exchange
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	gctkline "github.com/thrasher-corp/gocryptotrader/exchanges/kline"
+	gctorder "github.com/thrasher-corp/gocryptotrader/exchanges/order"
+)
+
+func TestCalculateFillTime(t *testing.T) {
+	t.Parallel()
+	tt := time.Date(2022, 1, 3, 1, 0, 0, 0, time.UTC)
+	high, low := decimal.NewFromInt(110), decimal.NewFromInt(90)
+	if ft := calculateFillTime(FillTimeClose, tt, time.Hour, decimal.NewFromInt(100), high, low); !ft.Equal(tt) {
+		t.Errorf("received '%v' expected '%v'", ft, tt)
+	}
+	if ft := calculateFillTime(FillTimeOpen, tt, time.Hour, decimal.NewFromInt(100), high, low); !ft.Equal(tt.Add(-time.Hour)) {
+		t.Errorf("received '%v' expected '%v'", ft, tt.Add(-time.Hour))
+	}
+	if ft := calculateFillTime(FillTimeProportional, tt, time.Hour, decimal.NewFromInt(105), high, low); !ft.Equal(tt.Add(-time.Minute * 15)) {
+		t.Errorf("received '%v' expected '%v'", ft, tt.Add(-time.Minute*15))
+	}
+	if ft := calculateFillTime(FillTimeProportional, tt, time.Hour, decimal.NewFromInt(200), high, low); !ft.Equal(tt) {
+		t.Errorf("received '%v' expected fill price above the range to fill at '%v'", ft, tt)
+	}
+	if ft := calculateFillTime(FillTimeProportional, tt, time.Hour, decimal.NewFromInt(100), high, high); !ft.Equal(tt.Add(-time.Minute * 30)) {
+		t.Errorf("received '%v' expected flat candle to fill at '%v'", ft, tt.Add(-time.Minute*30))
+	}
+}
+
+func TestExecuteOrderFillTimeAssignment(t *testing.T) {
+	t.Parallel()
+	om, exch := setupOfflineOrderManager(t)
+	o, d := setupOfflineOrder(t, gctorder.Buy, decimal.NewFromInt(1), decimal.NewFromInt(100),
+		gctkline.Candle{Close: 100, High: 110, Low: 90, Volume: 1000})
+	cs := Settings{
+		Exchange:               exch,
+		Pair:                   o.Pair(),
+		Asset:                  o.GetAssetType(),
+		MinimumSlippageRate:    decimal.NewFromInt(100),
+		MaximumSlippageRate:    decimal.NewFromInt(100),
+		FillTimeAssignment:     FillTimeOpen,
+		SignalToFillConvention: SameCandleClose,
+	}
+	e := Exchange{CurrencySettings: []Settings{cs}}
+	f, err := e.ExecuteOrder(context.Background(), o, d, om, &fakeFund{})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	interval := d.Latest().GetInterval().Duration()
+	if !f.GetFillTime().Equal(o.GetTime().Add(-interval)) {
+		t.Errorf("received '%v' expected '%v'", f.GetFillTime(), o.GetTime().Add(-interval))
+	}
+	if !f.GetTime().Equal(o.GetTime()) {
+		t.Errorf("received '%v' expected event time to remain '%v'", f.GetTime(), o.GetTime())
+	}
+}
@@ -0,0 +1,85 @@
+package exchange
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/thrasher-corp/gocryptotrader/backtester/common"
+	"github.com/thrasher-corp/gocryptotrader/backtester/funding"
+	gctorder "github.com/thrasher-corp/gocryptotrader/exchanges/order"
+)
+
+// ApplyFundingRate settles the funding payments which fall due within the
+// data event's candle against its open futures position. Payments are the
+// position's notional at the close price multiplied by the funding rate,
+// paid by longs and received by shorts when the rate is positive. A position
+// opened since the previous funding time only pays or receives for the
+// portion of the funding period it was held. Payments are charged to or
+// credited to the collateral and the net payment is returned, positive
+// when paid and negative when received
+func (e *Exchange) ApplyFundingRate(ev common.DataEventHandler, cr funding.ICollateralReleaser, position *gctorder.Position) (decimal.Decimal, error) {
+	if ev == nil {
+		return decimal.Zero, common.ErrNilEvent
+	}
+	if cr == nil {
+		return decimal.Zero, fmt.Errorf("%w missing collateral releaser", common.ErrNilArguments)
+	}
+	if !ev.GetAssetType().IsFutures() ||
+		position == nil ||
+		position.Status != gctorder.Open ||
+		position.LatestSize.IsZero() {
+		return decimal.Zero, nil
+	}
+	cs, err := e.GetCurrencySettings(ev.GetExchange(), ev.GetAssetType(), ev.Pair())
+	if err != nil {
+		return decimal.Zero, err
+	}
+	if cs.FundingRate == nil {
+		return decimal.Zero, nil
+	}
+	candleEnd := ev.GetTime().Add(ev.GetInterval().Duration())
+	notional := position.LatestSize.Abs().Mul(ev.GetClosePrice())
+	rates := cs.FundingRate.FundingRates
+	var payment decimal.Decimal
+	for i := range rates {
+		if rates[i].Time.Before(ev.GetTime()) ||
+			!rates[i].Time.Before(candleEnd) ||
+			!rates[i].Time.After(position.OpeningDate) {
+			continue
+		}
+		amount := notional.Mul(rates[i].Rate)
+		period := fundingPeriod(rates, i)
+		if held := rates[i].Time.Sub(position.OpeningDate); period > 0 && held < period {
+			amount = amount.Mul(decimal.NewFromInt(int64(held))).Div(decimal.NewFromInt(int64(period)))
+		}
+		if position.LatestDirection.IsShort() {
+			amount = amount.Neg()
+		}
+		payment = payment.Add(amount)
+	}
+	switch {
+	case payment.IsPositive():
+		err = cr.ChargeCost(payment)
+	case payment.IsNegative():
+		err = cr.ReceivePayment(payment.Neg())
+	}
+	if err != nil {
+		return decimal.Zero, err
+	}
+	return payment, nil
+}
+
+// fundingPeriod returns the duration between the funding rate and the one
+// before it. The first rate uses the duration until the next rate. Returns
+// zero when it cannot be determined
+func fundingPeriod(rates []gctorder.FundingRate, i int) time.Duration {
+	switch {
+	case i > 0:
+		return rates[i].Time.Sub(rates[i-1].Time)
+	case len(rates) > 1:
+		return rates[1].Time.Sub(rates[0].Time)
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,149 @@
+package exchange
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/thrasher-corp/gocryptotrader/backtester/common"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/event"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/kline"
+	"github.com/thrasher-corp/gocryptotrader/backtester/funding"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	gctkline "github.com/thrasher-corp/gocryptotrader/exchanges/kline"
+	gctorder "github.com/thrasher-corp/gocryptotrader/exchanges/order"
+)
+
+func TestApplyFundingRate(t *testing.T) {
+	t.Parallel()
+	e := Exchange{}
+	_, err := e.ApplyFundingRate(nil, nil, nil)
+	if !errors.Is(err, common.ErrNilEvent) {
+		t.Errorf("received '%v' expected '%v'", err, common.ErrNilEvent)
+	}
+
+	_, exch := setupOfflineOrderManager(t)
+	p := currency.NewPair(currency.BTC, currency.USDT)
+	tt := time.Date(2022, 1, 3, 0, 0, 0, 0, time.UTC)
+	newEvent := func(at time.Time) *kline.Kline {
+		t.Helper()
+		return &kline.Kline{
+			Base: &event.Base{
+				Exchange:     testExchange,
+				CurrencyPair: p,
+				AssetType:    asset.Futures,
+				Interval:     gctkline.OneHour,
+				Time:         at,
+			},
+			Close: decimal.NewFromInt(100),
+		}
+	}
+	_, err = e.ApplyFundingRate(newEvent(tt), nil, nil)
+	if !errors.Is(err, common.ErrNilArguments) {
+		t.Errorf("received '%v' expected '%v'", err, common.ErrNilArguments)
+	}
+
+	contract, err := funding.CreateItem(testExchange, asset.Futures, p.Base, decimal.Zero, decimal.Zero)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	collateral, err := funding.CreateItem(testExchange, asset.Futures, p.Quote, decimal.NewFromInt(1000), decimal.Zero)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	collat, err := funding.CreateCollateral(contract, collateral)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	position := &gctorder.Position{
+		Status:          gctorder.Open,
+		OpeningDate:     tt.Add(4 * time.Hour),
+		LatestDirection: gctorder.Long,
+		LatestSize:      decimal.NewFromInt(2),
+	}
+	_, err = e.ApplyFundingRate(newEvent(tt.Add(8*time.Hour)), collat, position)
+	if !errors.Is(err, errNoCurrencySettingsFound) {
+		t.Errorf("received '%v' expected '%v'", err, errNoCurrencySettingsFound)
+	}
+
+	e.SetExchangeAssetCurrencySettings(asset.Futures, p, &Settings{
+		Exchange: exch,
+		Pair:     p,
+		Asset:    asset.Futures,
+	})
+	payment, err := e.ApplyFundingRate(newEvent(tt.Add(8*time.Hour)), collat, position)
+	if !errors.Is(err, nil) {
+		t.Errorf("received '%v' expected '%v'", err, nil)
+	}
+	if !payment.IsZero() {
+		t.Errorf("received '%v' expected no payment without funding rates", payment)
+	}
+
+	e.CurrencySettings[0].FundingRate = &gctorder.FundingRates{
+		FundingRates: []gctorder.FundingRate{
+			{Time: tt, Rate: decimal.NewFromFloat(0.01)},
+			{Time: tt.Add(8 * time.Hour), Rate: decimal.NewFromFloat(0.01)},
+			{Time: tt.Add(16 * time.Hour), Rate: decimal.NewFromFloat(0.02)},
+		},
+	}
+	// the long was opened halfway through the funding period, so pays half
+	// of 1% of its notional of 200
+	payment, err = e.ApplyFundingRate(newEvent(tt.Add(8*time.Hour)), collat, position)
+	if !errors.Is(err, nil) {
+		t.Errorf("received '%v' expected '%v'", err, nil)
+	}
+	if !payment.Equal(decimal.NewFromInt(1)) {
+		t.Errorf("received '%v' expected '%v'", payment, decimal.NewFromInt(1))
+	}
+	if !collat.AvailableFunds().Equal(decimal.NewFromInt(999)) {
+		t.Errorf("received '%v' expected '%v'", collat.AvailableFunds(), decimal.NewFromInt(999))
+	}
+
+	payment, err = e.ApplyFundingRate(newEvent(tt.Add(9*time.Hour)), collat, position)
+	if !errors.Is(err, nil) {
+		t.Errorf("received '%v' expected '%v'", err, nil)
+	}
+	if !payment.IsZero() {
+		t.Errorf("received '%v' expected no payment between funding times", payment)
+	}
+
+	// a short held for the full funding period receives 2% of its notional
+	position.LatestDirection = gctorder.Short
+	payment, err = e.ApplyFundingRate(newEvent(tt.Add(16*time.Hour)), collat, position)
+	if !errors.Is(err, nil) {
+		t.Errorf("received '%v' expected '%v'", err, nil)
+	}
+	if !payment.Equal(decimal.NewFromInt(-4)) {
+		t.Errorf("received '%v' expected '%v'", payment, decimal.NewFromInt(-4))
+	}
+	if !collat.AvailableFunds().Equal(decimal.NewFromInt(1003)) {
+		t.Errorf("received '%v' expected '%v'", collat.AvailableFunds(), decimal.NewFromInt(1003))
+	}
+
+	position.Status = gctorder.Closed
+	payment, err = e.ApplyFundingRate(newEvent(tt.Add(16*time.Hour)), collat, position)
+	if !errors.Is(err, nil) {
+		t.Errorf("received '%v' expected '%v'", err, nil)
+	}
+	if !payment.IsZero() {
+		t.Errorf("received '%v' expected no payment for a closed position", payment)
+	}
+}
+
+func TestFundingPeriod(t *testing.T) {
+	t.Parallel()
+	tt := time.Date(2022, 1, 3, 0, 0, 0, 0, time.UTC)
+	rates := []gctorder.FundingRate{{Time: tt}}
+	if period := fundingPeriod(rates, 0); period != 0 {
+		t.Errorf("received '%v' expected '%v'", period, 0)
+	}
+	rates = append(rates, gctorder.FundingRate{Time: tt.Add(8 * time.Hour)})
+	if period := fundingPeriod(rates, 0); period != 8*time.Hour {
+		t.Errorf("received '%v' expected '%v'", period, 8*time.Hour)
+	}
+	if period := fundingPeriod(rates, 1); period != 8*time.Hour {
+		t.Errorf("received '%v' expected '%v'", period, 8*time.Hour)
+	}
+}
@@ -0,0 +1,32 @@
+package exchange
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/fill"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/order"
+	"github.com/thrasher-corp/gocryptotrader/backtester/funding"
+)
+
+// heldTooBriefly returns whether an exit order is placed before its position
+// has been held for the minimum holding period. Liquidations and stop-outs
+// are forced exits and are always allowed
+func (e *Exchange) heldTooBriefly(o order.Event, cs *Settings) (held time.Duration, tooBrief bool) {
+	if cs.MinimumHoldingPeriod <= 0 || o.IsLiquidating() || o.IsStopOut() || !isPositionExit(o) {
+		return 0, false
+	}
+	pe := e.getPositionEntry(o.GetExchange(), o.GetAssetType(), o.Pair())
+	if pe == nil {
+		return 0, false
+	}
+	held = o.GetTime().Sub(pe.time)
+	return held, held < cs.MinimumHoldingPeriod
+}
+
+// handleMinimumHoldingPeriod rejects an exit order placed before
+// its position has been held for the minimum holding period
+func handleMinimumHoldingPeriod(o order.Event, f *fill.Fill, funds funding.IFundReleaser, cs *Settings, held time.Duration) (fill.Event, error) {
+	f.AppendReasonf("Position held for %v, below minimum holding period of %v, rejected", held, cs.MinimumHoldingPeriod)
+	return f, allocateFundsPostOrder(f, funds, errMinimumHoldingPeriod, o.GetAmount(), o.GetAllocatedFunds(), decimal.Zero, decimal.Zero, decimal.Zero, cs)
+}
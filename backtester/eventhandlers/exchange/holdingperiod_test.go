@@ -0,0 +1,128 @@
+package exchange
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/event"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/fill"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/order"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	gctkline "github.com/thrasher-corp/gocryptotrader/exchanges/kline"
+	gctorder "github.com/thrasher-corp/gocryptotrader/exchanges/order"
+)
+
+func TestHeldTooBriefly(t *testing.T) {
+	t.Parallel()
+	e := Exchange{}
+	p := currency.NewPair(currency.BTC, currency.USDT)
+	cs := &Settings{MinimumHoldingPeriod: time.Hour}
+	tt := time.Date(2022, 1, 3, 0, 0, 0, 0, time.UTC)
+	o := &order.Order{
+		Base: &event.Base{
+			Exchange:     testExchange,
+			CurrencyPair: p,
+			AssetType:    asset.Spot,
+			Time:         tt,
+		},
+		Direction: gctorder.Buy,
+	}
+	f := &fill.Fill{
+		Base:   o.Base,
+		Amount: decimal.NewFromInt(2),
+	}
+	e.updatePositionEntry(o, f)
+	o.Base = &event.Base{
+		Exchange:     testExchange,
+		CurrencyPair: p,
+		AssetType:    asset.Spot,
+		Time:         tt.Add(time.Minute * 30),
+	}
+	f.Base = o.Base
+	e.updatePositionEntry(o, f)
+	pe := e.getPositionEntry(testExchange, asset.Spot, p)
+	if pe == nil {
+		t.Fatal("expected open position entry")
+	}
+	if !pe.time.Equal(tt) {
+		t.Errorf("received '%v' expected '%v'", pe.time, tt)
+	}
+	if !pe.amount.Equal(decimal.NewFromInt(4)) {
+		t.Errorf("received '%v' expected '%v'", pe.amount, 4)
+	}
+
+	o.Direction = gctorder.Sell
+	held, tooBrief := e.heldTooBriefly(o, cs)
+	if !tooBrief {
+		t.Error("expected exit to be rejected")
+	}
+	if held != time.Minute*30 {
+		t.Errorf("received '%v' expected '%v'", held, time.Minute*30)
+	}
+	o.LiquidatingPosition = true
+	if _, tooBrief = e.heldTooBriefly(o, cs); tooBrief {
+		t.Error("expected liquidation to bypass minimum holding period")
+	}
+	o.LiquidatingPosition = false
+
+	o.Time = tt.Add(time.Hour)
+	if _, tooBrief = e.heldTooBriefly(o, cs); tooBrief {
+		t.Error("expected exit to be allowed after minimum holding period")
+	}
+	e.updatePositionEntry(o, f)
+	if pe = e.getPositionEntry(testExchange, asset.Spot, p); pe == nil || !pe.amount.Equal(decimal.NewFromInt(2)) {
+		t.Errorf("received '%v' expected remaining position of '%v'", pe, 2)
+	}
+	e.updatePositionEntry(o, f)
+	if pe = e.getPositionEntry(testExchange, asset.Spot, p); pe != nil {
+		t.Errorf("received '%v' expected '%v'", pe, nil)
+	}
+}
+
+func TestExecuteOrderMinimumHoldingPeriod(t *testing.T) {
+	t.Parallel()
+	om, exch := setupOfflineOrderManager(t)
+	o, d := setupOfflineOrder(t, gctorder.Buy, decimal.NewFromInt(1), decimal.NewFromInt(100),
+		gctkline.Candle{Close: 100, High: 110, Low: 90, Volume: 1000})
+	entryTime := o.GetTime()
+	cs := Settings{
+		Exchange:               exch,
+		Pair:                   o.Pair(),
+		Asset:                  o.GetAssetType(),
+		MinimumSlippageRate:    decimal.NewFromInt(100),
+		MaximumSlippageRate:    decimal.NewFromInt(100),
+		MinimumHoldingPeriod:   time.Hour,
+		SignalToFillConvention: SameCandleClose,
+	}
+	e := Exchange{CurrencySettings: []Settings{cs}}
+	_, err := e.ExecuteOrder(context.Background(), o, d, om, &fakeFund{})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+
+	o, d = setupOfflineOrder(t, gctorder.Sell, decimal.NewFromInt(1), decimal.NewFromInt(100),
+		gctkline.Candle{Close: 100, High: 110, Low: 90, Volume: 1000})
+	o.Time = entryTime.Add(time.Minute)
+	f, err := e.ExecuteOrder(context.Background(), o, d, om, &fakeFund{})
+	if !errors.Is(err, errMinimumHoldingPeriod) {
+		t.Fatalf("received '%v' expected '%v'", err, errMinimumHoldingPeriod)
+	}
+	if f.GetDirection() != gctorder.CouldNotSell {
+		t.Errorf("received '%v' expected '%v'", f.GetDirection(), gctorder.CouldNotSell)
+	}
+
+	o, d = setupOfflineOrder(t, gctorder.Sell, decimal.NewFromInt(1), decimal.NewFromInt(100),
+		gctkline.Candle{Close: 100, High: 110, Low: 90, Volume: 1000})
+	o.Time = entryTime.Add(time.Hour)
+	f, err = e.ExecuteOrder(context.Background(), o, d, om, &fakeFund{})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	if f.GetDirection() != gctorder.Sell {
+		t.Errorf("received '%v' expected '%v'", f.GetDirection(), gctorder.Sell)
+	}
+}
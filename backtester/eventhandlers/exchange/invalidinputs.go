@@ -0,0 +1,34 @@
+package exchange
+
+import (
+	"fmt"
+
+	"github.com/shopspring/decimal"
+	"github.com/thrasher-corp/gocryptotrader/backtester/common"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/fill"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/order"
+	"github.com/thrasher-corp/gocryptotrader/backtester/funding"
+	"github.com/thrasher-corp/gocryptotrader/log"
+)
+
+// validateExecutionInputs ensures the price and amount an order will be
+// executed with are usable. Zero or negative prices from bad data would
+// otherwise fill orders for nothing and cascade into meaningless holdings
+// and statistics
+func validateExecutionInputs(price, amount decimal.Decimal) error {
+	if !price.IsPositive() {
+		return fmt.Errorf("%w price %v must be positive", errInvalidExecutionInput, price)
+	}
+	if amount.IsNegative() {
+		return fmt.Errorf("%w amount %v cannot be negative", errInvalidExecutionInput, amount)
+	}
+	return nil
+}
+
+// handleInvalidExecutionInputs rejects an order which cannot be
+// executed with its price or amount and warns of the bad data
+func handleInvalidExecutionInputs(o order.Event, f *fill.Fill, funds funding.IFundReleaser, cs *Settings, err error) (fill.Event, error) {
+	log.Warnf(common.Exchange, "%v %v %v order at %v rejected: %v", o.GetExchange(), o.GetAssetType(), o.Pair(), o.GetTime(), err)
+	f.AppendReasonf("Order rejected, %v", err)
+	return f, allocateFundsPostOrder(f, funds, err, o.GetAmount(), o.GetAllocatedFunds(), decimal.Zero, decimal.Zero, decimal.Zero, cs)
+}
@@ -0,0 +1,83 @@
+package exchange
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	gctkline "github.com/thrasher-corp/gocryptotrader/exchanges/kline"
+	gctorder "github.com/thrasher-corp/gocryptotrader/exchanges/order"
+)
+
+func TestValidateExecutionInputs(t *testing.T) {
+	t.Parallel()
+	err := validateExecutionInputs(decimal.NewFromInt(100), decimal.NewFromInt(1))
+	if !errors.Is(err, nil) {
+		t.Errorf("received '%v' expected '%v'", err, nil)
+	}
+	err = validateExecutionInputs(decimal.NewFromInt(100), decimal.Zero)
+	if !errors.Is(err, nil) {
+		t.Errorf("received '%v' expected '%v'", err, nil)
+	}
+	err = validateExecutionInputs(decimal.Zero, decimal.NewFromInt(1))
+	if !errors.Is(err, errInvalidExecutionInput) {
+		t.Errorf("received '%v' expected '%v'", err, errInvalidExecutionInput)
+	}
+	err = validateExecutionInputs(decimal.NewFromInt(-1), decimal.NewFromInt(1))
+	if !errors.Is(err, errInvalidExecutionInput) {
+		t.Errorf("received '%v' expected '%v'", err, errInvalidExecutionInput)
+	}
+	err = validateExecutionInputs(decimal.NewFromInt(100), decimal.NewFromInt(-1))
+	if !errors.Is(err, errInvalidExecutionInput) {
+		t.Errorf("received '%v' expected '%v'", err, errInvalidExecutionInput)
+	}
+}
+
+func TestExecuteOrderInvalidInputs(t *testing.T) {
+	t.Parallel()
+	om, exch := setupOfflineOrderManager(t)
+	for _, tc := range []struct {
+		name   string
+		price  decimal.Decimal
+		candle gctkline.Candle
+	}{
+		{name: "zero price", price: decimal.Zero, candle: gctkline.Candle{Volume: 1000}},
+		{name: "negative price", price: decimal.NewFromInt(-100), candle: gctkline.Candle{Close: -100, High: -90, Low: -110, Volume: 1000}},
+	} {
+		o, d := setupOfflineOrder(t, gctorder.Buy, decimal.NewFromInt(1), tc.price, tc.candle)
+		cs := Settings{
+			Exchange:               exch,
+			Pair:                   o.Pair(),
+			Asset:                  o.GetAssetType(),
+			MinimumSlippageRate:    decimal.NewFromInt(100),
+			MaximumSlippageRate:    decimal.NewFromInt(100),
+			SignalToFillConvention: SameCandleClose,
+		}
+		e := Exchange{CurrencySettings: []Settings{cs}}
+		f, err := e.ExecuteOrder(context.Background(), o, d, om, &fakeFund{})
+		if !errors.Is(err, errInvalidExecutionInput) {
+			t.Fatalf("%v received '%v' expected '%v'", tc.name, err, errInvalidExecutionInput)
+		}
+		if f.GetDirection() != gctorder.CouldNotBuy {
+			t.Errorf("%v received '%v' expected '%v'", tc.name, f.GetDirection(), gctorder.CouldNotBuy)
+		}
+	}
+
+	// zero volume candles do not fill, but must not break execution
+	o, d := setupOfflineOrder(t, gctorder.Buy, decimal.NewFromInt(1), decimal.NewFromInt(100),
+		gctkline.Candle{Close: 100, High: 110, Low: 90})
+	cs := Settings{
+		Exchange:               exch,
+		Pair:                   o.Pair(),
+		Asset:                  o.GetAssetType(),
+		MinimumSlippageRate:    decimal.NewFromInt(100),
+		MaximumSlippageRate:    decimal.NewFromInt(100),
+		SignalToFillConvention: SameCandleClose,
+	}
+	e := Exchange{CurrencySettings: []Settings{cs}}
+	_, err := e.ExecuteOrder(context.Background(), o, d, om, &fakeFund{})
+	if errors.Is(err, errInvalidExecutionInput) {
+		t.Errorf("received '%v' expected zero volume to not be an invalid input", err)
+	}
+}
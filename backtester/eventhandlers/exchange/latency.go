@@ -0,0 +1,123 @@
+package exchange
+
+import (
+	"math/rand"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/fill"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/order"
+	gctkline "github.com/thrasher-corp/gocryptotrader/exchanges/kline"
+	gctorder "github.com/thrasher-corp/gocryptotrader/exchanges/order"
+)
+
+// Sample returns the next latency from the distribution. A nil
+// distribution has no latency
+func (l *LatencyDistribution) Sample() time.Duration {
+	if l == nil {
+		return 0
+	}
+	if l.rng == nil {
+		l.rng = rand.New(rand.NewSource(l.Seed)) //nolint:gosec // reproducible number generation required, no need for crypto/rand
+	}
+	l.samples++
+	var latency time.Duration
+	if len(l.Histogram) > 0 {
+		var totalWeight int64
+		for i := range l.Histogram {
+			if l.Histogram[i].Weight > 0 {
+				totalWeight += l.Histogram[i].Weight
+			}
+		}
+		if totalWeight == 0 {
+			return 0
+		}
+		target := l.rng.Int63n(totalWeight)
+		for i := range l.Histogram {
+			if l.Histogram[i].Weight <= 0 {
+				continue
+			}
+			if target < l.Histogram[i].Weight {
+				latency = l.Histogram[i].Latency
+				break
+			}
+			target -= l.Histogram[i].Weight
+		}
+	} else {
+		latency = l.Mean + time.Duration(l.rng.NormFloat64()*float64(l.StandardDeviation))
+	}
+	if latency < 0 {
+		return 0
+	}
+	return latency
+}
+
+// restoreSamples reseeds the distribution and draws the number of samples
+// taken so that later samples continue the same reproducible sequence
+func (l *LatencyDistribution) restoreSamples(samples int64) {
+	if l == nil {
+		return
+	}
+	l.rng = nil
+	l.samples = 0
+	for l.samples < samples {
+		l.Sample()
+	}
+}
+
+// latencyCandleShift returns how far the fill is shifted by latency, being the
+// number of whole candle intervals elapsed. Latency shorter than the interval
+// still fills on the candle the order was placed
+func latencyCandleShift(latency time.Duration, interval gctkline.Interval) time.Duration {
+	if latency <= 0 || interval.Duration() <= 0 {
+		return 0
+	}
+	return latency - latency%interval.Duration()
+}
+
+// handleOrderLatency samples the order's latency. When the latency shifts the
+// fill past the current candle, the order is deferred until the candle it
+// reaches the exchange and keeps its reserved funds until released.
+// Orders which have already experienced latency, resting limit orders,
+// dormant stop orders, pending take-profit orders, dormant trailing stop
+// orders and partial fill remainders are not delayed again
+func (e *Exchange) handleOrderLatency(o order.Event, f *fill.Fill, cs *Settings) (deferred bool) {
+	if cs.Latency == nil || o.IsLiquidating() || o.IsStopOut() {
+		return false
+	}
+	if o.GetLatency() > 0 {
+		f.Latency = o.GetLatency()
+		return false
+	}
+	if o.IsRestingLimit() || o.IsDormantStop() || o.IsPendingTakeProfit() || o.IsDormantTrailingStop() || o.IsPartialFill() {
+		return false
+	}
+	f.Latency = cs.Latency.Sample()
+	if latencyCandleShift(f.Latency, o.GetInterval()) == 0 {
+		return false
+	}
+	ord := e.deferOrder(o)
+	if ord == nil {
+		return false
+	}
+	ord.Latency = f.Latency
+	f.SetDirection(gctorder.DoNothing)
+	f.AppendReasonf("Order delayed by %v latency, deferred until %v", f.Latency, o.GetTime().Add(latencyCandleShift(f.Latency, o.GetInterval())))
+	return true
+}
+
+// deferOrder stores a copy of the order to be returned by
+// ReleaseDeferredOrders, keeping when the order was decided upon.
+// Returns nil if the order cannot be deferred
+func (e *Exchange) deferOrder(o order.Event) *order.Order {
+	ord, ok := o.(*order.Order)
+	if !ok || ord.Base == nil {
+		return nil
+	}
+	cpy := *ord
+	cpy.DecisionTime = ord.GetDecisionTime()
+	b := *ord.Base
+	b.Reasons = append([]string(nil), ord.Reasons...)
+	cpy.Base = &b
+	e.deferredOrders = append(e.deferredOrders, &cpy)
+	return &cpy
+}
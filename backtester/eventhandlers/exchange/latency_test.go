@@ -0,0 +1,146 @@
+package exchange
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/event"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/kline"
+	gctkline "github.com/thrasher-corp/gocryptotrader/exchanges/kline"
+	gctorder "github.com/thrasher-corp/gocryptotrader/exchanges/order"
+)
+
+func TestLatencyDistributionSample(t *testing.T) {
+	t.Parallel()
+	var l *LatencyDistribution
+	if s := l.Sample(); s != 0 {
+		t.Errorf("received '%v' expected '%v'", s, 0)
+	}
+
+	l = &LatencyDistribution{
+		Histogram: []LatencyBucket{
+			{Latency: time.Millisecond, Weight: 1},
+			{Latency: time.Second, Weight: 0},
+			{Latency: time.Minute, Weight: 3},
+		},
+		Seed: 1337,
+	}
+	l2 := &LatencyDistribution{
+		Histogram: l.Histogram,
+		Seed:      1337,
+	}
+	for i := 0; i < 100; i++ {
+		s := l.Sample()
+		if s != time.Millisecond && s != time.Minute {
+			t.Fatalf("received '%v' expected a histogram bucket", s)
+		}
+		if s2 := l2.Sample(); s != s2 {
+			t.Fatalf("received '%v' expected '%v' from the same seed", s2, s)
+		}
+	}
+
+	l = &LatencyDistribution{Histogram: []LatencyBucket{{Latency: time.Second}}}
+	if s := l.Sample(); s != 0 {
+		t.Errorf("received '%v' expected '%v'", s, 0)
+	}
+
+	l = &LatencyDistribution{Mean: time.Second}
+	if s := l.Sample(); s != time.Second {
+		t.Errorf("received '%v' expected '%v'", s, time.Second)
+	}
+	l = &LatencyDistribution{Mean: -time.Second}
+	if s := l.Sample(); s != 0 {
+		t.Errorf("received '%v' expected '%v'", s, 0)
+	}
+}
+
+func TestLatencyCandleShift(t *testing.T) {
+	t.Parallel()
+	if s := latencyCandleShift(time.Minute, gctkline.FifteenMin); s != 0 {
+		t.Errorf("received '%v' expected '%v'", s, 0)
+	}
+	if s := latencyCandleShift(time.Minute*31, gctkline.FifteenMin); s != time.Minute*30 {
+		t.Errorf("received '%v' expected '%v'", s, time.Minute*30)
+	}
+	if s := latencyCandleShift(time.Minute*31, 0); s != 0 {
+		t.Errorf("received '%v' expected '%v'", s, 0)
+	}
+}
+
+func TestExecuteOrderLatency(t *testing.T) {
+	t.Parallel()
+	om, exch := setupOfflineOrderManager(t)
+	o, d := setupOfflineOrder(t, gctorder.Buy, decimal.NewFromInt(1), decimal.NewFromInt(100),
+		gctkline.Candle{Close: 100, High: 110, Low: 90, Volume: 1000})
+	cs := Settings{
+		Exchange:               exch,
+		Pair:                   o.Pair(),
+		Asset:                  o.GetAssetType(),
+		MinimumSlippageRate:    decimal.NewFromInt(100),
+		MaximumSlippageRate:    decimal.NewFromInt(100),
+		Latency:                &LatencyDistribution{Mean: time.Second},
+		SignalToFillConvention: SameCandleClose,
+	}
+	e := Exchange{CurrencySettings: []Settings{cs}}
+	f, err := e.ExecuteOrder(context.Background(), o, d, om, &fakeFund{})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	if f.GetLatency() != time.Second {
+		t.Errorf("received '%v' expected '%v'", f.GetLatency(), time.Second)
+	}
+	if f.GetDirection() != gctorder.Buy {
+		t.Errorf("received '%v' expected '%v'", f.GetDirection(), gctorder.Buy)
+	}
+
+	e.CurrencySettings[0].Latency = &LatencyDistribution{Mean: time.Minute * 20}
+	o, d = setupOfflineOrder(t, gctorder.Buy, decimal.NewFromInt(1), decimal.NewFromInt(100),
+		gctkline.Candle{Close: 100, High: 110, Low: 90, Volume: 1000})
+	f, err = e.ExecuteOrder(context.Background(), o, d, om, &fakeFund{})
+	if !errors.Is(err, ErrCannotTransact) {
+		t.Fatalf("received '%v' expected '%v'", err, ErrCannotTransact)
+	}
+	if f.GetDirection() != gctorder.DoNothing {
+		t.Errorf("received '%v' expected '%v'", f.GetDirection(), gctorder.DoNothing)
+	}
+	if len(e.deferredOrders) != 1 {
+		t.Fatalf("received '%v' expected '%v'", len(e.deferredOrders), 1)
+	}
+	if e.deferredOrders[0].Latency != time.Minute*20 {
+		t.Errorf("received '%v' expected '%v'", e.deferredOrders[0].Latency, time.Minute*20)
+	}
+
+	ev := &kline.Kline{
+		Base: &event.Base{
+			Offset:       o.GetOffset(),
+			Exchange:     o.GetExchange(),
+			Time:         o.GetTime(),
+			Interval:     o.GetInterval(),
+			CurrencyPair: o.Pair(),
+			AssetType:    o.GetAssetType(),
+		},
+		Close: decimal.NewFromInt(100),
+	}
+	released, err := e.ReleaseDeferredOrders(ev)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	if len(released) != 0 {
+		t.Errorf("received '%v' expected '%v'", len(released), 0)
+	}
+	ev.Offset++
+	ev.Time = ev.Time.Add(o.GetInterval().Duration())
+	released, err = e.ReleaseDeferredOrders(ev)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	if len(released) != 1 {
+		t.Fatalf("received '%v' expected '%v'", len(released), 1)
+	}
+	if released[0].GetLatency() != time.Minute*20 {
+		t.Errorf("received '%v' expected '%v'", released[0].GetLatency(), time.Minute*20)
+	}
+}
@@ -0,0 +1,93 @@
+package exchange
+
+import (
+	"github.com/shopspring/decimal"
+	"github.com/thrasher-corp/gocryptotrader/backtester/common"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/fill"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/order"
+	gctorder "github.com/thrasher-corp/gocryptotrader/exchanges/order"
+)
+
+// isLimitTouched returns whether a candle's range crosses the limit price.
+// Buys are touched when the low reaches down to the limit price and
+// sells when the high reaches up to it
+func isLimitTouched(direction gctorder.Side, limitPrice, high, low decimal.Decimal) bool {
+	switch direction {
+	case gctorder.Buy, gctorder.Bid, gctorder.Long:
+		return low.LessThanOrEqual(limitPrice)
+	case gctorder.Sell, gctorder.Ask, gctorder.Short, gctorder.ClosePosition:
+		return high.GreaterThanOrEqual(limitPrice)
+	}
+	return false
+}
+
+// limitFillPrice returns the price a touched limit order fills at, being the
+// price when it is at or better than the limit price, otherwise the limit price
+func limitFillPrice(direction gctorder.Side, limitPrice, price decimal.Decimal) decimal.Decimal {
+	switch direction {
+	case gctorder.Buy, gctorder.Bid, gctorder.Long:
+		return decimal.Min(price, limitPrice)
+	case gctorder.Sell, gctorder.Ask, gctorder.Short, gctorder.ClosePosition:
+		return decimal.Max(price, limitPrice)
+	}
+	return price
+}
+
+// isMakerFill returns whether the simulated order filled passively as a
+// maker, being a limit order which rested from a previous candle or which was
+// not marketable at the price it was placed at. Market orders, marketable
+// limit orders, liquidations and real orders are treated as takers
+func isMakerFill(o order.Event, cs *Settings) bool {
+	limitPrice := o.GetLimitPrice()
+	if cs.UseRealOrders || o.IsLiquidating() || !limitPrice.IsPositive() {
+		return false
+	}
+	if o.IsRestingLimit() {
+		return true
+	}
+	switch o.GetDirection() {
+	case gctorder.Buy, gctorder.Bid, gctorder.Long:
+		return limitPrice.LessThan(o.GetClosePrice())
+	case gctorder.Sell, gctorder.Ask, gctorder.Short, gctorder.ClosePosition:
+		return limitPrice.GreaterThan(o.GetClosePrice())
+	}
+	return false
+}
+
+// restUntouchedLimit leaves a limit order open until a candle's range crosses
+// its limit price, deferring it to be evaluated against the next candle. The
+// candle the order is placed on traded before the order existed, so its range
+// is never used to fill the order and only limit orders marketable at the
+// price they were placed at fill on it. The order keeps its reserved funds
+// until it fills. Market orders, marketable limit orders and resting limit
+// orders touched by the candle are filled without deferral
+func (e *Exchange) restUntouchedLimit(o order.Event, f *fill.Fill, candle common.DataEventHandler) bool {
+	limitPrice := o.GetLimitPrice()
+	if !limitPrice.IsPositive() || candle == nil {
+		return false
+	}
+	isPlacement := !o.IsRestingLimit()
+	switch {
+	case isPlacement && isLimitTouched(o.GetDirection(), limitPrice, o.GetClosePrice(), o.GetClosePrice()):
+		f.AppendReasonf("Limit price %v marketable at placement price %v", limitPrice, o.GetClosePrice())
+		return false
+	case !isPlacement && isLimitTouched(o.GetDirection(), limitPrice, candle.GetHighPrice(), candle.GetLowPrice()):
+		f.AppendReasonf("Limit price %v touched within candle low %v high %v", limitPrice, candle.GetLowPrice(), candle.GetHighPrice())
+		return false
+	}
+	ord := e.deferOrder(o)
+	if ord == nil {
+		return false
+	}
+	ord.RestingLimit = true
+	// the untouched candle's reasons are recorded against its fill
+	ord.Reasons = nil
+	f.RestingLimit = true
+	f.SetDirection(gctorder.DoNothing)
+	if isPlacement {
+		f.AppendReasonf("Limit price %v not marketable at placement price %v, order left open to be evaluated from the next candle", limitPrice, o.GetClosePrice())
+	} else {
+		f.AppendReasonf("Limit price %v not touched within candle low %v high %v, order left open", limitPrice, candle.GetLowPrice(), candle.GetHighPrice())
+	}
+	return true
+}
@@ -0,0 +1,213 @@
+package exchange
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/event"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/fill"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/kline"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/order"
+	gctkline "github.com/thrasher-corp/gocryptotrader/exchanges/kline"
+	gctorder "github.com/thrasher-corp/gocryptotrader/exchanges/order"
+)
+
+func TestIsLimitTouched(t *testing.T) {
+	t.Parallel()
+	high, low := decimal.NewFromInt(110), decimal.NewFromInt(90)
+	for _, tc := range []struct {
+		direction  gctorder.Side
+		limitPrice decimal.Decimal
+		isTouched  bool
+	}{
+		{gctorder.Buy, decimal.NewFromInt(95), true},
+		{gctorder.Buy, decimal.NewFromInt(90), true},
+		{gctorder.Long, decimal.NewFromInt(89), false},
+		{gctorder.Sell, decimal.NewFromInt(105), true},
+		{gctorder.Sell, decimal.NewFromInt(110), true},
+		{gctorder.Short, decimal.NewFromInt(111), false},
+		{gctorder.UnknownSide, decimal.NewFromInt(100), false},
+	} {
+		if isTouched := isLimitTouched(tc.direction, tc.limitPrice, high, low); isTouched != tc.isTouched {
+			t.Errorf("%v limit %v received '%v' expected '%v'", tc.direction, tc.limitPrice, isTouched, tc.isTouched)
+		}
+	}
+}
+
+func TestLimitFillPrice(t *testing.T) {
+	t.Parallel()
+	limit := decimal.NewFromInt(100)
+	for _, tc := range []struct {
+		direction gctorder.Side
+		price     decimal.Decimal
+		expected  decimal.Decimal
+	}{
+		{gctorder.Buy, decimal.NewFromInt(105), limit},
+		{gctorder.Buy, decimal.NewFromInt(95), decimal.NewFromInt(95)},
+		{gctorder.Sell, decimal.NewFromInt(95), limit},
+		{gctorder.Sell, decimal.NewFromInt(105), decimal.NewFromInt(105)},
+		{gctorder.UnknownSide, decimal.NewFromInt(105), decimal.NewFromInt(105)},
+	} {
+		if price := limitFillPrice(tc.direction, limit, tc.price); !price.Equal(tc.expected) {
+			t.Errorf("%v price %v received '%v' expected '%v'", tc.direction, tc.price, price, tc.expected)
+		}
+	}
+}
+
+func TestIsMakerFill(t *testing.T) {
+	t.Parallel()
+	cs := &Settings{}
+	o := &order.Order{
+		Base:       &event.Base{},
+		Direction:  gctorder.Buy,
+		ClosePrice: decimal.NewFromInt(100),
+	}
+	if isMakerFill(o, cs) {
+		t.Error("expected market order to be a taker")
+	}
+	o.LimitPrice = decimal.NewFromInt(105)
+	if isMakerFill(o, cs) {
+		t.Error("expected marketable limit order to be a taker")
+	}
+	o.LimitPrice = decimal.NewFromInt(95)
+	if !isMakerFill(o, cs) {
+		t.Error("expected limit order below the price to be a maker")
+	}
+	o.Direction = gctorder.Sell
+	if isMakerFill(o, cs) {
+		t.Error("expected marketable limit sell to be a taker")
+	}
+	o.LimitPrice = decimal.NewFromInt(105)
+	if !isMakerFill(o, cs) {
+		t.Error("expected limit sell above the price to be a maker")
+	}
+	o.LimitPrice = decimal.NewFromInt(90)
+	o.RestingLimit = true
+	if !isMakerFill(o, cs) {
+		t.Error("expected resting limit order to be a maker")
+	}
+	cs.UseRealOrders = true
+	if isMakerFill(o, cs) {
+		t.Error("expected real order to be a taker")
+	}
+}
+
+func TestExecuteOrderLimit(t *testing.T) {
+	t.Parallel()
+	om, exch := setupOfflineOrderManager(t)
+	first := gctkline.Candle{Open: 100, Close: 100, High: 110, Low: 96, Volume: 1000}
+	o, d := setupOfflineOrder(t, gctorder.Buy, decimal.NewFromInt(1), decimal.NewFromInt(100), first)
+	o.LimitPrice = decimal.NewFromInt(95)
+	cs := Settings{
+		Exchange:               exch,
+		Pair:                   o.Pair(),
+		Asset:                  o.GetAssetType(),
+		MinimumSlippageRate:    decimal.NewFromInt(99),
+		MaximumSlippageRate:    decimal.NewFromInt(99),
+		MakerFee:               decimal.NewFromFloat(0.001),
+		TakerFee:               decimal.NewFromFloat(0.002),
+		SignalToFillConvention: SameCandleClose,
+	}
+	e := Exchange{CurrencySettings: []Settings{cs}}
+	f, err := e.ExecuteOrder(context.Background(), o, d, om, &fakeFund{})
+	if !errors.Is(err, ErrCannotTransact) {
+		t.Fatalf("received '%v' expected '%v'", err, ErrCannotTransact)
+	}
+	if f.GetDirection() != gctorder.DoNothing || !f.IsRestingLimit() {
+		t.Errorf("received '%v' '%v' expected '%v' '%v'", f.GetDirection(), f.IsRestingLimit(), gctorder.DoNothing, true)
+	}
+	if len(e.deferredOrders) != 1 || !e.deferredOrders[0].IsRestingLimit() {
+		t.Fatalf("expected a single resting limit order, received '%v'", e.deferredOrders)
+	}
+
+	ev := &kline.Kline{
+		Base: &event.Base{
+			Offset:       o.GetOffset(),
+			Exchange:     o.GetExchange(),
+			Time:         o.GetTime(),
+			Interval:     o.GetInterval(),
+			CurrencyPair: o.Pair(),
+			AssetType:    o.GetAssetType(),
+		},
+		Open:  decimal.NewFromInt(97),
+		Close: decimal.NewFromInt(96),
+	}
+	released, err := e.ReleaseDeferredOrders(ev)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	if len(released) != 0 {
+		t.Errorf("received '%v' expected resting order to wait for the next candle", len(released))
+	}
+	ev.Offset++
+	ev.Time = ev.Time.Add(o.GetInterval().Duration())
+	released, err = e.ReleaseDeferredOrders(ev)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	if len(released) != 1 {
+		t.Fatalf("received '%v' expected '%v'", len(released), 1)
+	}
+	if !released[0].GetClosePrice().Equal(ev.Open) {
+		t.Errorf("received '%v' expected '%v'", released[0].GetClosePrice(), ev.Open)
+	}
+
+	// the candle opens above the limit and trades through it
+	_, d = setupOfflineOrder(t, gctorder.Buy, decimal.NewFromInt(1), decimal.NewFromInt(100),
+		first, gctkline.Candle{Open: 97, Close: 96, High: 98, Low: 94, Volume: 1000})
+	f, err = e.ExecuteOrder(context.Background(), released[0], d, om, &fakeFund{})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	if f.GetDirection() != gctorder.Buy || f.IsRestingLimit() {
+		t.Errorf("received '%v' '%v' expected '%v' '%v'", f.GetDirection(), f.IsRestingLimit(), gctorder.Buy, false)
+	}
+	if !f.GetPurchasePrice().Equal(decimal.NewFromInt(95)) {
+		t.Errorf("received '%v' expected '%v'", f.GetPurchasePrice(), 95)
+	}
+	if f.GetOrder().Type != gctorder.Limit {
+		t.Errorf("received '%v' expected '%v'", f.GetOrder().Type, gctorder.Limit)
+	}
+	// the resting order filled passively
+	if f.GetFeeType() != fill.FeeTypeMaker || !f.GetExchangeFee().Equal(decimal.NewFromFloat(0.095)) {
+		t.Errorf("received '%v' '%v' expected '%v' '%v'", f.GetFeeType(), f.GetExchangeFee(), fill.FeeTypeMaker, 0.095)
+	}
+	if len(e.deferredOrders) != 0 {
+		t.Errorf("received '%v' expected '%v'", len(e.deferredOrders), 0)
+	}
+
+	// the placement candle traded through the limit before the order existed
+	o, d = setupOfflineOrder(t, gctorder.Buy, decimal.NewFromInt(1), decimal.NewFromInt(100), first)
+	o.LimitPrice = decimal.NewFromInt(97)
+	f, err = e.ExecuteOrder(context.Background(), o, d, om, &fakeFund{})
+	if !errors.Is(err, ErrCannotTransact) {
+		t.Fatalf("received '%v' expected '%v'", err, ErrCannotTransact)
+	}
+	if f.GetDirection() != gctorder.DoNothing || !f.IsRestingLimit() {
+		t.Errorf("received '%v' '%v' expected '%v' '%v'", f.GetDirection(), f.IsRestingLimit(), gctorder.DoNothing, true)
+	}
+	e.deferredOrders = nil
+
+	// marketable limit orders fill on placement as takers
+	o, d = setupOfflineOrder(t, gctorder.Buy, decimal.NewFromInt(1), decimal.NewFromInt(100), first)
+	o.LimitPrice = decimal.NewFromInt(101)
+	f, err = e.ExecuteOrder(context.Background(), o, d, om, &fakeFund{})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	if f.GetDirection() != gctorder.Buy || f.IsRestingLimit() || f.GetFeeType() != fill.FeeTypeTaker {
+		t.Errorf("received '%v' '%v' '%v' expected '%v' '%v' '%v'", f.GetDirection(), f.IsRestingLimit(), f.GetFeeType(), gctorder.Buy, false, fill.FeeTypeTaker)
+	}
+	if len(e.deferredOrders) != 0 {
+		t.Errorf("received '%v' expected '%v'", len(e.deferredOrders), 0)
+	}
+
+	o, d = setupOfflineOrder(t, gctorder.Buy, decimal.NewFromInt(1), decimal.NewFromInt(100), first)
+	o.LimitPrice = decimal.NewFromInt(-1)
+	_, err = e.ExecuteOrder(context.Background(), o, d, om, &fakeFund{})
+	if !errors.Is(err, errInvalidLimitPrice) {
+		t.Errorf("received '%v' expected '%v'", err, errInvalidLimitPrice)
+	}
+}
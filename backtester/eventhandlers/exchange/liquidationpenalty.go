@@ -0,0 +1,25 @@
+package exchange
+
+import (
+	"github.com/shopspring/decimal"
+	gctorder "github.com/thrasher-corp/gocryptotrader/exchanges/order"
+)
+
+// liquidationPenaltyPrice moves a liquidation's price against it by the
+// penalty percent, modelling the severe slippage of being liquidated at
+// market. Closing a short buys higher and closing a long sells lower
+func liquidationPenaltyPrice(direction gctorder.Side, price, penaltyPercent decimal.Decimal) decimal.Decimal {
+	if !penaltyPercent.IsPositive() || !price.IsPositive() {
+		return price
+	}
+	movement := price.Mul(penaltyPercent).Div(decimal.NewFromInt(100))
+	switch direction {
+	case gctorder.Buy, gctorder.Bid, gctorder.Long:
+		return price.Add(movement)
+	case gctorder.Sell, gctorder.Ask, gctorder.Short, gctorder.ClosePosition:
+		if penalised := price.Sub(movement); penalised.IsPositive() {
+			return penalised
+		}
+	}
+	return price
+}
@@ -0,0 +1,66 @@
+package exchange
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	gctkline "github.com/thrasher-corp/gocryptotrader/exchanges/kline"
+	gctorder "github.com/thrasher-corp/gocryptotrader/exchanges/order"
+)
+
+func TestLiquidationPenaltyPrice(t *testing.T) {
+	t.Parallel()
+	price := decimal.NewFromInt(100)
+	for _, tt := range []struct {
+		direction gctorder.Side
+		penalty   decimal.Decimal
+		expected  decimal.Decimal
+	}{
+		{gctorder.Sell, decimal.Zero, price},
+		{gctorder.Sell, decimal.NewFromInt(5), decimal.NewFromInt(95)},
+		{gctorder.Short, decimal.NewFromInt(5), decimal.NewFromInt(95)},
+		{gctorder.Long, decimal.NewFromInt(5), decimal.NewFromInt(105)},
+		{gctorder.Buy, decimal.NewFromInt(5), decimal.NewFromInt(105)},
+		// a penalty cannot sell for nothing
+		{gctorder.Sell, decimal.NewFromInt(100), price},
+		{gctorder.DoNothing, decimal.NewFromInt(5), price},
+	} {
+		if p := liquidationPenaltyPrice(tt.direction, price, tt.penalty); !p.Equal(tt.expected) {
+			t.Errorf("received '%v' expected '%v' for %v at %v%%", p, tt.expected, tt.direction, tt.penalty)
+		}
+	}
+}
+
+func TestExecuteOrderLiquidationPenalty(t *testing.T) {
+	t.Parallel()
+	om, exch := setupOfflineOrderManager(t)
+	o, d := setupOfflineOrder(t, gctorder.Sell, decimal.NewFromInt(1), decimal.NewFromInt(100),
+		gctkline.Candle{Close: 100, High: 110, Low: 90, Volume: 1000})
+	o.LiquidatingPosition = true
+	cs := Settings{
+		Exchange:                   exch,
+		Pair:                       o.Pair(),
+		Asset:                      o.GetAssetType(),
+		MinimumSlippageRate:        decimal.NewFromInt(100),
+		MaximumSlippageRate:        decimal.NewFromInt(100),
+		LiquidationSlippagePercent: decimal.NewFromInt(5),
+		LiquidationFeeRate:         decimal.NewFromFloat(0.01),
+	}
+	e := Exchange{CurrencySettings: []Settings{cs}}
+	f, err := e.ExecuteOrder(context.Background(), o, d, om, &fakeFund{})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	if !f.GetPurchasePrice().Equal(decimal.NewFromInt(95)) {
+		t.Errorf("received '%v' expected '%v'", f.GetPurchasePrice(), 95)
+	}
+	// 5 lost to the penalty slippage and a penalty fee of 0.95
+	if !f.GetLiquidationCost().Equal(decimal.NewFromFloat(5.95)) {
+		t.Errorf("received '%v' expected '%v'", f.GetLiquidationCost(), 5.95)
+	}
+	if !f.GetExchangeFee().Equal(decimal.NewFromFloat(0.95)) {
+		t.Errorf("received '%v' expected '%v'", f.GetExchangeFee(), 0.95)
+	}
+}
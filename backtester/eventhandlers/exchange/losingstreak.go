@@ -0,0 +1,123 @@
+package exchange
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/fill"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/order"
+	"github.com/thrasher-corp/gocryptotrader/backtester/funding"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	gctorder "github.com/thrasher-corp/gocryptotrader/exchanges/order"
+)
+
+// getLosingStreak returns the exchange, asset and pair's
+// current round trip and losing streak
+func (e *Exchange) getLosingStreak(exch string, a asset.Item, p currency.Pair) *losingStreak {
+	if e.losingStreaks == nil {
+		e.losingStreaks = make(map[string]map[asset.Item]map[currency.Pair]*losingStreak)
+	}
+	if e.losingStreaks[exch] == nil {
+		e.losingStreaks[exch] = make(map[asset.Item]map[currency.Pair]*losingStreak)
+	}
+	if e.losingStreaks[exch][a] == nil {
+		e.losingStreaks[exch][a] = make(map[currency.Pair]*losingStreak)
+	}
+	ls, ok := e.losingStreaks[exch][a][p]
+	if !ok {
+		ls = &losingStreak{}
+		e.losingStreaks[exch][a][p] = ls
+	}
+	return ls
+}
+
+// isCircuitBroken returns whether an entry order is placed while the
+// losing streak circuit breaker has halted new entries
+func (e *Exchange) isCircuitBroken(o order.Event, cs *Settings) bool {
+	if cs.LosingStreakBreaker == nil || !isCapitalEntry(o) {
+		return false
+	}
+	ls := e.getLosingStreak(o.GetExchange(), o.GetAssetType(), o.Pair())
+	if !ls.halted {
+		return false
+	}
+	if !ls.haltedUntil.IsZero() && !o.GetTime().Before(ls.haltedUntil) {
+		ls.halted = false
+		return false
+	}
+	return true
+}
+
+// handleCircuitBroken rejects an entry order placed while
+// the losing streak circuit breaker is halting entries
+func (e *Exchange) handleCircuitBroken(o order.Event, f *fill.Fill, funds funding.IFundReleaser, cs *Settings) (fill.Event, error) {
+	f.CircuitBreakerBound = true
+	ls := e.getLosingStreak(o.GetExchange(), o.GetAssetType(), o.Pair())
+	if ls.haltedUntil.IsZero() {
+		f.AppendReasonf("Entries halted for the rest of the run after %v consecutive losing trades, rejected", cs.LosingStreakBreaker.ConsecutiveLosses)
+	} else {
+		f.AppendReasonf("Entries halted until %v after %v consecutive losing trades, rejected", ls.haltedUntil, cs.LosingStreakBreaker.ConsecutiveLosses)
+	}
+	return f, allocateFundsPostOrder(f, funds, errCircuitBreakerHalted, o.GetAmount(), o.GetAllocatedFunds(), decimal.Zero, decimal.Zero, decimal.Zero, cs)
+}
+
+// updateLosingStreak tracks the currency's round trip trades after a
+// successful fill. A round trip completes when the position returns to, or
+// flips through, zero. Its PNL is the net cash flow of its fills after fees.
+// Consecutive losing round trips reaching the threshold halt new entries for
+// the cooldown, or for the rest of the run when there is no cooldown
+func (e *Exchange) updateLosingStreak(o order.Event, f *fill.Fill, cs *Settings) {
+	if cs.LosingStreakBreaker == nil || f.GetAmount().IsZero() {
+		return
+	}
+	ls := e.getLosingStreak(o.GetExchange(), o.GetAssetType(), o.Pair())
+	isSpot := !o.GetAssetType().IsFutures()
+	if isSpot && !o.GetDirection().IsLong() && !ls.position.IsPositive() {
+		// spot sales of holdings not bought during the run are not round trips
+		return
+	}
+	amount := f.GetAmount()
+	value := f.GetPurchasePrice().Mul(amount)
+	switch {
+	case o.GetDirection() == gctorder.ClosePosition && ls.position.IsNegative():
+		// closing a short buys back the position
+	case o.GetDirection() == gctorder.ClosePosition,
+		!o.GetDirection().IsLong():
+		amount = amount.Neg()
+		value = value.Neg()
+	}
+	previous := ls.position
+	ls.position = ls.position.Add(amount)
+	ls.cashFlow = ls.cashFlow.Sub(value).Sub(f.GetExchangeFee())
+	if o.IsLiquidating() || (o.GetAssetType().IsFutures() && o.IsClosingPosition()) {
+		ls.position = decimal.Zero
+	}
+	if previous.IsZero() || (!ls.position.IsZero() && ls.position.Sign() == previous.Sign()) {
+		return
+	}
+	// attribute any position flipped through zero to the next round trip
+	flipValue := ls.position.Mul(f.GetPurchasePrice())
+	pnl := ls.cashFlow.Add(flipValue)
+	ls.cashFlow = flipValue.Neg()
+	if isSpot && ls.position.IsNegative() {
+		ls.position = decimal.Zero
+		ls.cashFlow = decimal.Zero
+	}
+	if !pnl.IsNegative() {
+		ls.consecutiveLosses = 0
+		return
+	}
+	ls.consecutiveLosses++
+	if ls.consecutiveLosses < cs.LosingStreakBreaker.ConsecutiveLosses {
+		return
+	}
+	ls.consecutiveLosses = 0
+	ls.halted = true
+	ls.haltedUntil = time.Time{}
+	if cs.LosingStreakBreaker.Cooldown > 0 {
+		ls.haltedUntil = f.GetTime().Add(cs.LosingStreakBreaker.Cooldown)
+	}
+	f.CircuitBreakerTriggered = true
+	f.AppendReasonf("%v consecutive losing trades, circuit breaker halting entries", cs.LosingStreakBreaker.ConsecutiveLosses)
+}
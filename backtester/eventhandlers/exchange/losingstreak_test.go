@@ -0,0 +1,118 @@
+package exchange
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/event"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/fill"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/order"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	gctkline "github.com/thrasher-corp/gocryptotrader/exchanges/kline"
+	gctorder "github.com/thrasher-corp/gocryptotrader/exchanges/order"
+)
+
+func TestUpdateLosingStreak(t *testing.T) {
+	t.Parallel()
+	e := Exchange{}
+	p := currency.NewPair(currency.BTC, currency.USDT)
+	cs := &Settings{LosingStreakBreaker: &LosingStreakBreaker{ConsecutiveLosses: 2, Cooldown: time.Hour}}
+	tt := time.Date(2022, 1, 3, 0, 0, 0, 0, time.UTC)
+	trade := func(side gctorder.Side, amount, price int64) (*order.Order, *fill.Fill) {
+		t.Helper()
+		b := &event.Base{
+			Exchange:     testExchange,
+			CurrencyPair: p,
+			AssetType:    asset.Spot,
+			Time:         tt,
+		}
+		o := &order.Order{Base: b, Direction: side}
+		f := &fill.Fill{
+			Base:          b,
+			Direction:     side,
+			Amount:        decimal.NewFromInt(amount),
+			PurchasePrice: decimal.NewFromInt(price),
+		}
+		e.updateLosingStreak(o, f, cs)
+		return o, f
+	}
+	// selling holdings which were not bought during the run is ignored
+	trade(gctorder.Sell, 1, 100)
+	ls := e.getLosingStreak(testExchange, asset.Spot, p)
+	if !ls.position.IsZero() || !ls.cashFlow.IsZero() {
+		t.Errorf("received '%v' '%v' expected no tracked round trip", ls.position, ls.cashFlow)
+	}
+
+	trade(gctorder.Buy, 2, 100)
+	trade(gctorder.Sell, 1, 90)
+	if ls.consecutiveLosses != 0 {
+		t.Errorf("received '%v' expected '%v'", ls.consecutiveLosses, 0)
+	}
+	_, f := trade(gctorder.Sell, 1, 90)
+	if ls.consecutiveLosses != 1 {
+		t.Errorf("received '%v' expected '%v'", ls.consecutiveLosses, 1)
+	}
+	if f.IsCircuitBreakerTriggered() {
+		t.Error("expected circuit breaker to not trigger on first loss")
+	}
+
+	trade(gctorder.Buy, 1, 100)
+	trade(gctorder.Sell, 1, 101)
+	if ls.consecutiveLosses != 0 {
+		t.Errorf("received '%v' expected winning trade to reset the streak", ls.consecutiveLosses)
+	}
+
+	trade(gctorder.Buy, 1, 100)
+	trade(gctorder.Sell, 1, 99)
+	trade(gctorder.Buy, 1, 100)
+	o, f := trade(gctorder.Sell, 1, 99)
+	if !f.IsCircuitBreakerTriggered() {
+		t.Error("expected circuit breaker to trigger")
+	}
+	if !ls.halted || !ls.haltedUntil.Equal(tt.Add(time.Hour)) {
+		t.Errorf("received '%v' '%v' expected entries halted until '%v'", ls.halted, ls.haltedUntil, tt.Add(time.Hour))
+	}
+	if e.isCircuitBroken(o, cs) {
+		t.Error("expected exits to always be allowed")
+	}
+	o.Direction = gctorder.Buy
+	if !e.isCircuitBroken(o, cs) {
+		t.Error("expected entries to be halted")
+	}
+	o.Time = tt.Add(time.Hour)
+	if e.isCircuitBroken(o, cs) {
+		t.Error("expected entries to resume after the cooldown")
+	}
+}
+
+func TestExecuteOrderCircuitBreaker(t *testing.T) {
+	t.Parallel()
+	om, exch := setupOfflineOrderManager(t)
+	o, d := setupOfflineOrder(t, gctorder.Buy, decimal.NewFromInt(1), decimal.NewFromInt(100),
+		gctkline.Candle{Close: 100, High: 110, Low: 90, Volume: 1000})
+	cs := Settings{
+		Exchange:               exch,
+		Pair:                   o.Pair(),
+		Asset:                  o.GetAssetType(),
+		MinimumSlippageRate:    decimal.NewFromInt(100),
+		MaximumSlippageRate:    decimal.NewFromInt(100),
+		LosingStreakBreaker:    &LosingStreakBreaker{ConsecutiveLosses: 1},
+		SignalToFillConvention: SameCandleClose,
+	}
+	e := Exchange{CurrencySettings: []Settings{cs}}
+	e.getLosingStreak(o.GetExchange(), o.GetAssetType(), o.Pair()).halted = true
+	f, err := e.ExecuteOrder(context.Background(), o, d, om, &fakeFund{})
+	if !errors.Is(err, errCircuitBreakerHalted) {
+		t.Fatalf("received '%v' expected '%v'", err, errCircuitBreakerHalted)
+	}
+	if f.GetDirection() != gctorder.CouldNotBuy {
+		t.Errorf("received '%v' expected '%v'", f.GetDirection(), gctorder.CouldNotBuy)
+	}
+	if !f.IsCircuitBreakerBound() {
+		t.Error("expected circuit breaker to bind")
+	}
+}
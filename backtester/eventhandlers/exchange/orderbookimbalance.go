@@ -0,0 +1,71 @@
+package exchange
+
+import (
+	"fmt"
+
+	"github.com/shopspring/decimal"
+	"github.com/thrasher-corp/gocryptotrader/backtester/common"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/orderbook"
+)
+
+// CalculateOrderbookImbalance returns the imbalance of bid and ask volume across
+// the top depth levels of the orderbook as (bids - asks) / (bids + asks). The
+// result ranges from -1 when only asks have volume to 1 when only bids do
+func CalculateOrderbookImbalance(ob *orderbook.Base, depth int) (decimal.Decimal, error) {
+	if ob == nil {
+		return decimal.Zero, fmt.Errorf("%w orderbook", common.ErrNilArguments)
+	}
+	if depth <= 0 {
+		return decimal.Zero, fmt.Errorf("%w %v", errInvalidOrderbookDepth, depth)
+	}
+	bidVolume := sumOrderbookVolume(ob.Bids, depth)
+	askVolume := sumOrderbookVolume(ob.Asks, depth)
+	totalVolume := bidVolume.Add(askVolume)
+	if totalVolume.IsZero() {
+		return decimal.Zero, fmt.Errorf("%w for %v %v %v", errNoOrderbookVolume, ob.Exchange, ob.Asset, ob.Pair)
+	}
+	return bidVolume.Sub(askVolume).Div(totalVolume), nil
+}
+
+func sumOrderbookVolume(items orderbook.Items, depth int) decimal.Decimal {
+	if depth > len(items) {
+		depth = len(items)
+	}
+	var volume decimal.Decimal
+	for i := 0; i < depth; i++ {
+		volume = volume.Add(decimal.NewFromFloat(items[i].Amount))
+	}
+	return volume
+}
+
+// AttachOrderbookImbalance calculates the orderbook imbalance for the data
+// event's exchange, asset and pair and attaches it to the event so strategies
+// can use it. It is only calculated when using real orders with an orderbook
+// imbalance depth set and an orderbook is available
+func (e *Exchange) AttachOrderbookImbalance(ev common.DataEventHandler) error {
+	if ev == nil {
+		return common.ErrNilEvent
+	}
+	cs, err := e.GetCurrencySettings(ev.GetExchange(), ev.GetAssetType(), ev.Pair())
+	if err != nil {
+		return err
+	}
+	if !cs.UseRealOrders || cs.OrderbookImbalanceDepth <= 0 {
+		return nil
+	}
+	setter, ok := ev.(OrderbookImbalanceSetter)
+	if !ok {
+		return nil
+	}
+	ob, err := orderbook.Get(ev.GetExchange(), ev.Pair(), ev.GetAssetType())
+	if err != nil {
+		// no book is available to calculate against
+		return nil
+	}
+	imbalance, err := CalculateOrderbookImbalance(ob, cs.OrderbookImbalanceDepth)
+	if err != nil {
+		return err
+	}
+	setter.SetOrderbookImbalance(imbalance)
+	return nil
+}
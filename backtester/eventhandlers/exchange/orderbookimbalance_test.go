@@ -0,0 +1,118 @@
+package exchange
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/thrasher-corp/gocryptotrader/backtester/common"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/event"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/kline"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/orderbook"
+)
+
+func TestCalculateOrderbookImbalance(t *testing.T) {
+	t.Parallel()
+	_, err := CalculateOrderbookImbalance(nil, 1)
+	if !errors.Is(err, common.ErrNilArguments) {
+		t.Errorf("received '%v' expected '%v'", err, common.ErrNilArguments)
+	}
+	ob := &orderbook.Base{}
+	_, err = CalculateOrderbookImbalance(ob, 0)
+	if !errors.Is(err, errInvalidOrderbookDepth) {
+		t.Errorf("received '%v' expected '%v'", err, errInvalidOrderbookDepth)
+	}
+	_, err = CalculateOrderbookImbalance(ob, 1)
+	if !errors.Is(err, errNoOrderbookVolume) {
+		t.Errorf("received '%v' expected '%v'", err, errNoOrderbookVolume)
+	}
+
+	ob.Bids = orderbook.Items{{Price: 100, Amount: 3}, {Price: 99, Amount: 10}}
+	ob.Asks = orderbook.Items{{Price: 101, Amount: 1}}
+	imbalance, err := CalculateOrderbookImbalance(ob, 1)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	if !imbalance.Equal(decimal.NewFromFloat(0.5)) {
+		t.Errorf("received '%v' expected '%v'", imbalance, 0.5)
+	}
+	imbalance, err = CalculateOrderbookImbalance(ob, 5)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	if !imbalance.Equal(decimal.NewFromInt(12).Div(decimal.NewFromInt(14))) {
+		t.Errorf("received '%v' expected '%v'", imbalance, decimal.NewFromInt(12).Div(decimal.NewFromInt(14)))
+	}
+}
+
+func TestAttachOrderbookImbalance(t *testing.T) {
+	t.Parallel()
+	e := Exchange{}
+	err := e.AttachOrderbookImbalance(nil)
+	if !errors.Is(err, common.ErrNilEvent) {
+		t.Errorf("received '%v' expected '%v'", err, common.ErrNilEvent)
+	}
+
+	_, exch := setupOfflineOrderManager(t)
+	p := currency.NewPair(currency.XRP, currency.DOGE)
+	ev := &kline.Kline{
+		Base: &event.Base{
+			Exchange:     testExchange,
+			CurrencyPair: p,
+			AssetType:    asset.Spot,
+		},
+	}
+	err = e.AttachOrderbookImbalance(ev)
+	if !errors.Is(err, errNoCurrencySettingsFound) {
+		t.Errorf("received '%v' expected '%v'", err, errNoCurrencySettingsFound)
+	}
+
+	e.SetExchangeAssetCurrencySettings(asset.Spot, p, &Settings{
+		Exchange:                exch,
+		Pair:                    p,
+		Asset:                   asset.Spot,
+		OrderbookImbalanceDepth: 1,
+	})
+	err = e.AttachOrderbookImbalance(ev)
+	if !errors.Is(err, nil) {
+		t.Errorf("received '%v' expected '%v'", err, nil)
+	}
+	if _, ok := ev.GetOrderbookImbalance(); ok {
+		t.Error("expected no imbalance without real orders")
+	}
+
+	e.CurrencySettings[0].UseRealOrders = true
+	err = e.AttachOrderbookImbalance(ev)
+	if !errors.Is(err, nil) {
+		t.Errorf("received '%v' expected '%v'", err, nil)
+	}
+	if _, ok := ev.GetOrderbookImbalance(); ok {
+		t.Error("expected no imbalance without an orderbook")
+	}
+
+	ob := &orderbook.Base{
+		Exchange:        exch.GetName(),
+		Pair:            p,
+		Asset:           asset.Spot,
+		Bids:            orderbook.Items{{Price: 100, Amount: 1}},
+		Asks:            orderbook.Items{{Price: 101, Amount: 3}},
+		VerifyOrderbook: true,
+	}
+	err = ob.Process()
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	err = e.AttachOrderbookImbalance(ev)
+	if !errors.Is(err, nil) {
+		t.Errorf("received '%v' expected '%v'", err, nil)
+	}
+	imbalance, ok := ev.GetOrderbookImbalance()
+	if !ok {
+		t.Fatal("expected imbalance to be attached")
+	}
+	if !imbalance.Equal(decimal.NewFromFloat(-0.5)) {
+		t.Errorf("received '%v' expected '%v'", imbalance, -0.5)
+	}
+}
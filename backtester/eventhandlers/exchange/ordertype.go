@@ -0,0 +1,44 @@
+package exchange
+
+import (
+	"fmt"
+
+	"github.com/shopspring/decimal"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/order"
+)
+
+// validateOrderType returns an error when the order's fee override or its
+// limit, stop, take-profit or trailing stop prices are invalid, or are
+// combined in a way the exchange cannot simulate. Triggered stop orders are
+// placed at market, so they cannot be left dormant on a real exchange
+func validateOrderType(o order.Event, cs *Settings) error {
+	if feeOverride := o.GetFeeOverride(); feeOverride != nil &&
+		(feeOverride.IsNegative() || feeOverride.GreaterThanOrEqual(decimal.NewFromInt(1))) {
+		return fmt.Errorf("%w %v must be at least 0 and below 1", errInvalidFeeOverride, feeOverride)
+	}
+	limitPrice := o.GetLimitPrice()
+	if limitPrice.IsNegative() {
+		return fmt.Errorf("%w %v must not be negative", errInvalidLimitPrice, limitPrice)
+	}
+	stopPrice := o.GetStopPrice()
+	if stopPrice.IsNegative() ||
+		(stopPrice.IsPositive() && (limitPrice.IsPositive() || cs.UseRealOrders)) {
+		return fmt.Errorf("%w %v must not be negative, combined with a limit price or used with real orders", errInvalidStopPrice, stopPrice)
+	}
+	target := o.GetTakeProfitPrice()
+	if target.IsNegative() ||
+		(target.IsPositive() && (limitPrice.IsPositive() || stopPrice.IsPositive() || cs.UseRealOrders)) {
+		return fmt.Errorf("%w %v must not be negative, combined with a limit or stop price or used with real orders", errInvalidTakeProfitPrice, target)
+	}
+	if distance, percent := o.GetTrailingStopDistance(), o.GetTrailingStopPercent(); distance.IsNegative() || percent.IsNegative() ||
+		percent.GreaterThanOrEqual(decimal.NewFromInt(100)) ||
+		(distance.IsPositive() && percent.IsPositive()) ||
+		(isTrailingStop(o) && (limitPrice.IsPositive() || stopPrice.IsPositive() || target.IsPositive() || cs.UseRealOrders)) {
+		return fmt.Errorf("%w distance %v and percent %v must not be negative or both set, the percent must be below 100 and neither can be combined with a limit, stop or take-profit price or used with real orders", errInvalidTrailingStop, distance, percent)
+	}
+	if percent := o.GetTakeProfitPercent(); percent.IsNegative() ||
+		(percent.IsPositive() && (target.IsPositive() || limitPrice.IsPositive() || stopPrice.IsPositive() || isTrailingStop(o) || cs.UseRealOrders)) {
+		return fmt.Errorf("%w %v must not be negative, combined with a take-profit, limit, stop or trailing stop price or used with real orders", errInvalidTakeProfitPercent, percent)
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+package exchange
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/order"
+)
+
+func TestValidateOrderType(t *testing.T) {
+	t.Parallel()
+	one, negative := decimal.NewFromInt(1), decimal.NewFromInt(-1)
+	for _, tc := range []struct {
+		name        string
+		order       *order.Order
+		realOrders  bool
+		expectedErr error
+	}{
+		{"market", &order.Order{}, false, nil},
+		{"fee override", &order.Order{FeeOverride: &one}, false, errInvalidFeeOverride},
+		{"negative fee override", &order.Order{FeeOverride: &negative}, false, errInvalidFeeOverride},
+		{"limit", &order.Order{LimitPrice: one}, true, nil},
+		{"negative limit", &order.Order{LimitPrice: negative}, false, errInvalidLimitPrice},
+		{"stop", &order.Order{StopPrice: one}, false, nil},
+		{"stop with limit", &order.Order{StopPrice: one, LimitPrice: one}, false, errInvalidStopPrice},
+		{"stop with real orders", &order.Order{StopPrice: one}, true, errInvalidStopPrice},
+		{"take-profit", &order.Order{TakeProfitPrice: one}, false, nil},
+		{"take-profit with stop", &order.Order{TakeProfitPrice: one, StopPrice: one}, false, errInvalidTakeProfitPrice},
+		{"take-profit with limit", &order.Order{TakeProfitPrice: one, LimitPrice: one}, false, errInvalidTakeProfitPrice},
+		{"trailing stop", &order.Order{TrailingStopPercent: one}, false, nil},
+		{"trailing stop distance and percent", &order.Order{TrailingStopPercent: one, TrailingStopDistance: one}, false, errInvalidTrailingStop},
+		{"trailing stop percent of 100", &order.Order{TrailingStopPercent: decimal.NewFromInt(100)}, false, errInvalidTrailingStop},
+		{"trailing stop with take-profit", &order.Order{TrailingStopDistance: one, TakeProfitPrice: one}, false, errInvalidTrailingStop},
+		{"take-profit percent", &order.Order{TakeProfitPercent: one}, false, nil},
+		{"negative take-profit percent", &order.Order{TakeProfitPercent: negative}, false, errInvalidTakeProfitPercent},
+		{"take-profit percent with trailing stop", &order.Order{TakeProfitPercent: one, TrailingStopPercent: one}, false, errInvalidTakeProfitPercent},
+		{"take-profit percent with real orders", &order.Order{TakeProfitPercent: one}, true, errInvalidTakeProfitPercent},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			err := validateOrderType(tc.order, &Settings{UseRealOrders: tc.realOrders})
+			if !errors.Is(err, tc.expectedErr) {
+				t.Errorf("received '%v' expected '%v'", err, tc.expectedErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package exchange
+
+import (
+	"github.com/shopspring/decimal"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/fill"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/order"
+	gctorder "github.com/thrasher-corp/gocryptotrader/exchanges/order"
+)
+
+// partialFillRemainder returns the amount an order shrunk to fit the candle's
+// volume left unfilled, along with the funds kept reserved to fill it on
+// subsequent candles. Buys keep their unspent quote funds and sells their
+// unsold base funds. Zero values are returned when nothing remains to fill
+func partialFillRemainder(o order.Event, filledAmount, price, fee decimal.Decimal, cs *Settings) (remaining, remainingFunds decimal.Decimal) {
+	if _, ok := o.(*order.Order); !ok {
+		return decimal.Zero, decimal.Zero
+	}
+	remaining = o.GetAmount().Sub(filledAmount)
+	if !remaining.IsPositive() {
+		return decimal.Zero, decimal.Zero
+	}
+	switch o.GetDirection() {
+	case gctorder.Buy, gctorder.Bid:
+		remainingFunds = o.GetAllocatedFunds().Sub(filledAmount.Mul(price)).Sub(fee).Sub(withheldRebate(fee, cs))
+	case gctorder.Sell, gctorder.Ask:
+		remainingFunds = o.GetAllocatedFunds().Sub(filledAmount)
+	}
+	if !remainingFunds.IsPositive() {
+		return decimal.Zero, decimal.Zero
+	}
+	return remaining, remainingFunds
+}
+
+// carryPartialFill defers the unfilled remainder of the order to continue
+// filling on the next candle with the funds kept reserved for it. The
+// remainder of a limit order continues to rest at its limit price, while the
+// remainder of a triggered stop, take-profit or trailing stop order continues
+// filling at market
+func (e *Exchange) carryPartialFill(o order.Event, f *fill.Fill, filledAmount, remaining, remainingFunds decimal.Decimal) {
+	ord := e.deferOrder(o)
+	if ord == nil {
+		return
+	}
+	ord.Amount = remaining
+	ord.AllocatedFunds = remainingFunds
+	ord.PartialFill = true
+	ord.RestingLimit = ord.LimitPrice.IsPositive()
+	ord.StopPrice = decimal.Zero
+	ord.DormantStop = false
+	ord.TakeProfitPrice = decimal.Zero
+	ord.TakeProfitPercent = decimal.Zero
+	ord.PendingTakeProfit = false
+	ord.TrailingStopDistance = decimal.Zero
+	ord.TrailingStopPercent = decimal.Zero
+	ord.TrailingStopHighWaterMark = decimal.Zero
+	ord.DormantTrailingStop = false
+	// the filled candle's reasons are recorded against its fill
+	ord.Reasons = nil
+	f.AppendReasonf("Partially filled %v of %v this candle, %v remaining carried to the next candle", filledAmount, o.GetAmount(), remaining)
+}
@@ -0,0 +1,134 @@
+package exchange
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/thrasher-corp/gocryptotrader/backtester/common"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/event"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/kline"
+	"github.com/thrasher-corp/gocryptotrader/backtester/funding"
+	gctkline "github.com/thrasher-corp/gocryptotrader/exchanges/kline"
+	gctorder "github.com/thrasher-corp/gocryptotrader/exchanges/order"
+)
+
+type fakeFundingReader struct{}
+
+func (f *fakeFundingReader) GetFundingForEvent(common.EventHandler) (funding.IFundingPair, error) {
+	return &fakeFundingPair{}, nil
+}
+
+func (f *fakeFundingReader) GetAllFunding() []funding.BasicItem {
+	return nil
+}
+
+type fakeFundingPair struct{}
+
+func (f *fakeFundingPair) FundReader() funding.IFundReader {
+	return nil
+}
+
+func (f *fakeFundingPair) FundReserver() funding.IFundReserver {
+	return nil
+}
+
+func (f *fakeFundingPair) FundReleaser() funding.IFundReleaser {
+	return &fakeFund{}
+}
+
+func TestExecuteOrderPartialFill(t *testing.T) {
+	t.Parallel()
+	om, exch := setupOfflineOrderManager(t)
+	thin := gctkline.Candle{Open: 100, Close: 100, High: 110, Low: 90, Volume: 400}
+	o, d := setupOfflineOrder(t, gctorder.Buy, decimal.NewFromInt(10), decimal.NewFromInt(100), thin)
+	o.AllocatedFunds = decimal.NewFromInt(1000)
+	cs := Settings{
+		Exchange:               exch,
+		Pair:                   o.Pair(),
+		Asset:                  o.GetAssetType(),
+		MinimumSlippageRate:    decimal.NewFromInt(100),
+		MaximumSlippageRate:    decimal.NewFromInt(100),
+		SignalToFillConvention: SameCandleClose,
+	}
+	e := Exchange{CurrencySettings: []Settings{cs}}
+	f, err := e.ExecuteOrder(context.Background(), o, d, om, &fakeFund{})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	if len(e.deferredOrders) != 0 {
+		t.Fatalf("received '%v' expected no remainder without partial fills", len(e.deferredOrders))
+	}
+
+	cs.AllowPartialFills = true
+	e = Exchange{CurrencySettings: []Settings{cs}}
+	f, err = e.ExecuteOrder(context.Background(), o, d, om, &fakeFund{})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	// the candle's volume of 400 fits just under 4 at a price of 100
+	filled := f.GetAmount()
+	if f.GetDirection() != gctorder.Buy || !filled.IsPositive() || filled.GreaterThanOrEqual(decimal.NewFromInt(4)) {
+		t.Errorf("received '%v' '%v' expected '%v' below '%v'", f.GetDirection(), filled, gctorder.Buy, 4)
+	}
+	if !strings.Contains(strings.Join(f.GetReasons(), " "), "Partially filled") {
+		t.Errorf("received '%v' expected partial fill reason", f.GetReasons())
+	}
+	if len(e.deferredOrders) != 1 {
+		t.Fatalf("received '%v' expected '%v'", len(e.deferredOrders), 1)
+	}
+	remainder := e.deferredOrders[0]
+	if !remainder.IsPartialFill() ||
+		!remainder.GetAmount().Equal(decimal.NewFromInt(10).Sub(filled)) ||
+		!remainder.GetAllocatedFunds().Equal(decimal.NewFromInt(1000).Sub(filled.Mul(f.GetPurchasePrice()))) {
+		t.Errorf("received '%v' '%v' '%v' expected remainder of '%v' with '%v' funds", remainder.IsPartialFill(), remainder.GetAmount(), remainder.GetAllocatedFunds(), decimal.NewFromInt(10).Sub(filled), decimal.NewFromInt(1000).Sub(filled.Mul(f.GetPurchasePrice())))
+	}
+
+	ev := &kline.Kline{
+		Base: &event.Base{
+			Offset:       o.GetOffset(),
+			Exchange:     o.GetExchange(),
+			Time:         o.GetTime(),
+			Interval:     o.GetInterval(),
+			CurrencyPair: o.Pair(),
+			AssetType:    o.GetAssetType(),
+		},
+		Open:  decimal.NewFromInt(101),
+		Close: decimal.NewFromInt(102),
+	}
+	released, err := e.ReleaseDeferredOrders(ev)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	if len(released) != 0 {
+		t.Errorf("received '%v' expected remainder to wait for the next candle", len(released))
+	}
+	ev.Offset++
+	ev.Time = ev.Time.Add(o.GetInterval().Duration())
+	released, err = e.ReleaseDeferredOrders(ev)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	if len(released) != 1 || !released[0].GetClosePrice().Equal(ev.Close) {
+		t.Fatalf("received '%v' expected remainder released at close price '%v'", released, ev.Close)
+	}
+
+	// the remainder is still unfilled when the run ends
+	e.deferredOrders = append(e.deferredOrders, remainder)
+	_, err = e.CancelDeferredOrders(nil)
+	if !errors.Is(err, common.ErrNilArguments) {
+		t.Errorf("received '%v' expected '%v'", err, common.ErrNilArguments)
+	}
+	cancelled, err := e.CancelDeferredOrders(&fakeFundingReader{})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	if len(cancelled) != 1 || cancelled[0].GetDirection() != gctorder.CouldNotBuy {
+		t.Fatalf("received '%v' expected a single cancelled buy", cancelled)
+	}
+	if len(e.deferredOrders) != 0 {
+		t.Errorf("received '%v' expected '%v'", len(e.deferredOrders), 0)
+	}
+}
@@ -0,0 +1,60 @@
+package exchange
+
+import (
+	"fmt"
+
+	"github.com/thrasher-corp/gocryptotrader/backtester/common"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/event"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/order"
+	gctorder "github.com/thrasher-corp/gocryptotrader/exchanges/order"
+)
+
+// CheckPositionAge returns a market order closing the data event's position
+// when it has been held for longer than the maximum position age. The order
+// is a forced exit and is returned without reserving funds. Returns nil when
+// there is no position or it is within the maximum age
+func (e *Exchange) CheckPositionAge(ev common.DataEventHandler) (*order.Order, error) {
+	if ev == nil {
+		return nil, common.ErrNilEvent
+	}
+	cs, err := e.GetCurrencySettings(ev.GetExchange(), ev.GetAssetType(), ev.Pair())
+	if err != nil {
+		return nil, err
+	}
+	if cs.MaxPositionAge <= 0 {
+		return nil, nil
+	}
+	pe := e.getPositionEntry(ev.GetExchange(), ev.GetAssetType(), ev.Pair())
+	if pe == nil || !pe.amount.IsPositive() {
+		return nil, nil
+	}
+	held := ev.GetTime().Sub(pe.time)
+	if held <= cs.MaxPositionAge {
+		return nil, nil
+	}
+	closingDirection := gctorder.Sell
+	switch pe.direction {
+	case gctorder.Long:
+		closingDirection = gctorder.Short
+	case gctorder.Short:
+		closingDirection = gctorder.Long
+	}
+	return &order.Order{
+		Base: &event.Base{
+			Offset:       ev.GetOffset(),
+			Exchange:     ev.GetExchange(),
+			Time:         ev.GetTime(),
+			Interval:     ev.GetInterval(),
+			CurrencyPair: ev.Pair(),
+			AssetType:    ev.GetAssetType(),
+			Reasons:      []string{fmt.Sprintf("TIME EXIT, position held for %v, exceeding maximum position age of %v", held, cs.MaxPositionAge)},
+		},
+		Direction:       closingDirection,
+		ClosePrice:      ev.GetClosePrice(),
+		Amount:          pe.amount,
+		AllocatedFunds:  pe.amount,
+		OrderType:       gctorder.Market,
+		ClosingPosition: true,
+		StopOut:         true,
+	}, nil
+}
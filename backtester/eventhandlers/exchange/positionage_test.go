@@ -0,0 +1,107 @@
+package exchange
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/thrasher-corp/gocryptotrader/backtester/common"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/event"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/fill"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/kline"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/order"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	gctorder "github.com/thrasher-corp/gocryptotrader/exchanges/order"
+)
+
+func TestCheckPositionAge(t *testing.T) {
+	t.Parallel()
+	e := Exchange{}
+	_, err := e.CheckPositionAge(nil)
+	if !errors.Is(err, common.ErrNilEvent) {
+		t.Errorf("received '%v' expected '%v'", err, common.ErrNilEvent)
+	}
+
+	_, exch := setupOfflineOrderManager(t)
+	p := currency.NewPair(currency.BTC, currency.USDT)
+	tt := time.Date(2022, 1, 3, 0, 0, 0, 0, time.UTC)
+	e.SetExchangeAssetCurrencySettings(asset.Spot, p, &Settings{
+		Exchange: exch,
+		Pair:     p,
+		Asset:    asset.Spot,
+	})
+	newEvent := func(at time.Time) *kline.Kline {
+		t.Helper()
+		return &kline.Kline{
+			Base: &event.Base{
+				Offset:       3,
+				Exchange:     testExchange,
+				CurrencyPair: p,
+				AssetType:    asset.Spot,
+				Time:         at,
+			},
+			Close: decimal.NewFromInt(100),
+		}
+	}
+	o, err := e.CheckPositionAge(newEvent(tt))
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	if o != nil {
+		t.Errorf("received '%v' expected no limit when disabled", o)
+	}
+
+	e.CurrencySettings[0].MaxPositionAge = time.Hour
+	entry := &order.Order{
+		Base: &event.Base{
+			Exchange:     testExchange,
+			CurrencyPair: p,
+			AssetType:    asset.Spot,
+			Time:         tt,
+		},
+		Direction: gctorder.Buy,
+	}
+	e.updatePositionEntry(entry, &fill.Fill{Base: entry.Base, Amount: decimal.NewFromInt(2)})
+	o, err = e.CheckPositionAge(newEvent(tt.Add(time.Hour)))
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	if o != nil {
+		t.Errorf("received '%v' expected position within its age to be held", o)
+	}
+
+	o, err = e.CheckPositionAge(newEvent(tt.Add(time.Hour * 2)))
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	if o == nil {
+		t.Fatal("expected closing order")
+	}
+	if o.GetDirection() != gctorder.Sell {
+		t.Errorf("received '%v' expected '%v'", o.GetDirection(), gctorder.Sell)
+	}
+	if !o.GetAmount().Equal(decimal.NewFromInt(2)) {
+		t.Errorf("received '%v' expected '%v'", o.GetAmount(), 2)
+	}
+	if !o.IsClosingPosition() || !o.IsStopOut() {
+		t.Error("expected a forced closing order")
+	}
+	if o.GetOffset() != 3 {
+		t.Errorf("received '%v' expected '%v'", o.GetOffset(), 3)
+	}
+	if len(o.GetReasons()) != 1 {
+		t.Errorf("received '%v' expected a time exit reason", o.GetReasons())
+	}
+
+	exitFill := &fill.Fill{Base: o.Base, Amount: decimal.NewFromInt(2)}
+	e.updatePositionEntry(o, exitFill)
+	o, err = e.CheckPositionAge(newEvent(tt.Add(time.Hour * 3)))
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	if o != nil {
+		t.Errorf("received '%v' expected closed position to not be exited again", o)
+	}
+}
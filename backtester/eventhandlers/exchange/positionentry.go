@@ -0,0 +1,112 @@
+package exchange
+
+import (
+	"github.com/shopspring/decimal"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/fill"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/order"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	gctorder "github.com/thrasher-corp/gocryptotrader/exchanges/order"
+)
+
+// isPositionExit returns whether the order reduces or closes an open position.
+// Spot sales reduce a held position while futures positions are exited by
+// closing orders
+func isPositionExit(o order.Event) bool {
+	if o.IsClosingPosition() || o.GetDirection() == gctorder.ClosePosition {
+		return true
+	}
+	if o.GetAssetType().IsFutures() {
+		return false
+	}
+	return o.GetDirection().IsShort()
+}
+
+// getPositionEntry returns when the exchange, asset and pair's open
+// position was entered. Returns nil when there is no open position
+func (e *Exchange) getPositionEntry(exch string, a asset.Item, p currency.Pair) *positionEntry {
+	if e.positionEntries == nil {
+		return nil
+	}
+	return e.positionEntries[exch][a][p]
+}
+
+// setPositionEntry stores when the exchange, asset and pair's position was
+// entered. A nil entry removes the position
+func (e *Exchange) setPositionEntry(exch string, a asset.Item, p currency.Pair, pe *positionEntry) {
+	if e.positionEntries == nil {
+		e.positionEntries = make(map[string]map[asset.Item]map[currency.Pair]*positionEntry)
+	}
+	if e.positionEntries[exch] == nil {
+		e.positionEntries[exch] = make(map[asset.Item]map[currency.Pair]*positionEntry)
+	}
+	if e.positionEntries[exch][a] == nil {
+		e.positionEntries[exch][a] = make(map[currency.Pair]*positionEntry)
+	}
+	if pe == nil {
+		delete(e.positionEntries[exch][a], p)
+		return
+	}
+	e.positionEntries[exch][a][p] = pe
+}
+
+// updatePositionEntry tracks when the currency's open position was entered
+// after a successful fill. Adding to an open position keeps its original
+// entry time and accumulates the cost of entering it. Spot sales reduce the
+// position and futures closing orders or liquidations remove it
+func (e *Exchange) updatePositionEntry(o order.Event, f fill.Event) {
+	pe := e.getPositionEntry(o.GetExchange(), o.GetAssetType(), o.Pair())
+	switch {
+	case o.IsLiquidating() || (isPositionExit(o) && o.GetAssetType().IsFutures()):
+		e.setPositionEntry(o.GetExchange(), o.GetAssetType(), o.Pair(), nil)
+	case isPositionExit(o):
+		if pe == nil {
+			return
+		}
+		pe.amount = pe.amount.Sub(f.GetAmount())
+		if !pe.amount.IsPositive() {
+			e.setPositionEntry(o.GetExchange(), o.GetAssetType(), o.Pair(), nil)
+		}
+	case pe == nil:
+		pe = &positionEntry{
+			time:      f.GetTime(),
+			direction: o.GetDirection(),
+		}
+		pe.addEntryFill(f)
+		e.setPositionEntry(o.GetExchange(), o.GetAssetType(), o.Pair(), pe)
+	default:
+		pe.addEntryFill(f)
+	}
+}
+
+// addEntryFill adds the fill's amount, notional, fee and spread to the
+// position entry
+func (pe *positionEntry) addEntryFill(f fill.Event) {
+	pe.amount = pe.amount.Add(f.GetAmount())
+	pe.enteredAmount = pe.enteredAmount.Add(f.GetAmount())
+	pe.notional = pe.notional.Add(f.GetPurchasePrice().Mul(f.GetAmount()))
+	pe.fee = pe.fee.Add(f.GetExchangeFee())
+	pe.spreadCost = pe.spreadCost.Add(entrySpreadCost(f).Mul(f.GetAmount()))
+}
+
+// entrySpreadCost returns the absolute spread paid per unit by the fill,
+// derived from its effective spread against the orderbook midpoint. Price
+// improvement is not assumed to repeat so is treated as no cost
+func entrySpreadCost(f fill.Event) decimal.Decimal {
+	spread, ok := f.GetEffectiveSpread()
+	if !ok || !spread.IsPositive() {
+		return decimal.Zero
+	}
+	rate := spread.Div(decimal.NewFromInt(100))
+	one := decimal.NewFromInt(1)
+	var midpoint decimal.Decimal
+	switch {
+	case f.GetDirection().IsLong():
+		midpoint = f.GetPurchasePrice().Div(one.Add(rate))
+	case f.GetDirection().IsShort() && rate.LessThan(one):
+		midpoint = f.GetPurchasePrice().Div(one.Sub(rate))
+	default:
+		return decimal.Zero
+	}
+	return midpoint.Mul(rate)
+}
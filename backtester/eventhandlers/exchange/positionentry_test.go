@@ -0,0 +1,135 @@
+package exchange
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/event"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/fill"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/order"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	gctorder "github.com/thrasher-corp/gocryptotrader/exchanges/order"
+)
+
+func TestIsPositionExit(t *testing.T) {
+	t.Parallel()
+	o := &order.Order{Base: &event.Base{AssetType: asset.Spot}, Direction: gctorder.Buy}
+	if isPositionExit(o) {
+		t.Error("expected spot buy to not be an exit")
+	}
+	o.Direction = gctorder.Sell
+	if !isPositionExit(o) {
+		t.Error("expected spot sell to be an exit")
+	}
+	o.AssetType = asset.Futures
+	o.Direction = gctorder.Short
+	if isPositionExit(o) {
+		t.Error("expected futures short to not be an exit")
+	}
+	o.ClosingPosition = true
+	if !isPositionExit(o) {
+		t.Error("expected closing order to be an exit")
+	}
+}
+
+func TestUpdatePositionEntry(t *testing.T) {
+	t.Parallel()
+	e := Exchange{}
+	p := currency.NewPair(currency.BTC, currency.USDT)
+	o := &order.Order{
+		Base: &event.Base{
+			Exchange:     testExchange,
+			CurrencyPair: p,
+			AssetType:    asset.Spot,
+		},
+		Direction: gctorder.Buy,
+	}
+	f := &fill.Fill{
+		Base:          o.Base,
+		Direction:     gctorder.Buy,
+		Amount:        decimal.NewFromInt(2),
+		PurchasePrice: decimal.NewFromInt(101),
+		ExchangeFee:   decimal.NewFromInt(2),
+	}
+	setEffectiveSpread(f, decimal.NewFromInt(100))
+	e.updatePositionEntry(o, f)
+	f = &fill.Fill{
+		Base:          o.Base,
+		Direction:     gctorder.Buy,
+		Amount:        decimal.NewFromInt(2),
+		PurchasePrice: decimal.NewFromInt(99),
+		ExchangeFee:   decimal.NewFromInt(2),
+	}
+	e.updatePositionEntry(o, f)
+	pe := e.getPositionEntry(testExchange, asset.Spot, p)
+	if pe == nil {
+		t.Fatal("expected open position entry")
+	}
+	if pe.direction != gctorder.Buy {
+		t.Errorf("received '%v' expected '%v'", pe.direction, gctorder.Buy)
+	}
+	if !pe.amount.Equal(decimal.NewFromInt(4)) {
+		t.Errorf("received '%v' expected '%v'", pe.amount, 4)
+	}
+	if !pe.notional.Equal(decimal.NewFromInt(400)) {
+		t.Errorf("received '%v' expected '%v'", pe.notional, 400)
+	}
+	if !pe.fee.Equal(decimal.NewFromInt(4)) {
+		t.Errorf("received '%v' expected '%v'", pe.fee, 4)
+	}
+	// only the first fill paid a spread
+	if !pe.spreadCost.Round(8).Equal(decimal.NewFromInt(2)) {
+		t.Errorf("received '%v' expected '%v'", pe.spreadCost, 2)
+	}
+
+	o.Direction = gctorder.Sell
+	e.updatePositionEntry(o, f)
+	if pe = e.getPositionEntry(testExchange, asset.Spot, p); pe == nil || !pe.amount.Equal(decimal.NewFromInt(2)) {
+		t.Errorf("received '%v' expected remaining position of '%v'", pe, 2)
+	}
+	if !pe.enteredAmount.Equal(decimal.NewFromInt(4)) {
+		t.Errorf("received '%v' expected '%v'", pe.enteredAmount, 4)
+	}
+	e.updatePositionEntry(o, f)
+	if pe = e.getPositionEntry(testExchange, asset.Spot, p); pe != nil {
+		t.Errorf("received '%v' expected '%v'", pe, nil)
+	}
+
+	o.AssetType = asset.Futures
+	o.Direction = gctorder.Short
+	e.updatePositionEntry(o, f)
+	if pe = e.getPositionEntry(testExchange, asset.Futures, p); pe == nil {
+		t.Fatal("expected open futures position entry")
+	}
+	o.LiquidatingPosition = true
+	e.updatePositionEntry(o, f)
+	if pe = e.getPositionEntry(testExchange, asset.Futures, p); pe != nil {
+		t.Errorf("received '%v' expected '%v'", pe, nil)
+	}
+}
+
+func TestEntrySpreadCost(t *testing.T) {
+	t.Parallel()
+	f := &fill.Fill{Direction: gctorder.Buy, PurchasePrice: decimal.NewFromInt(101)}
+	if c := entrySpreadCost(f); !c.IsZero() {
+		t.Errorf("received '%v' expected '%v'", c, 0)
+	}
+	// bought at 101 against a midpoint of 100
+	setEffectiveSpread(f, decimal.NewFromInt(100))
+	if c := entrySpreadCost(f); !c.Round(8).Equal(decimal.NewFromInt(1)) {
+		t.Errorf("received '%v' expected '%v'", c, 1)
+	}
+	// sold at 99 against a midpoint of 100
+	f = &fill.Fill{Direction: gctorder.Sell, PurchasePrice: decimal.NewFromInt(99)}
+	setEffectiveSpread(f, decimal.NewFromInt(100))
+	if c := entrySpreadCost(f); !c.Round(8).Equal(decimal.NewFromInt(1)) {
+		t.Errorf("received '%v' expected '%v'", c, 1)
+	}
+	// price improvement is not a cost
+	f = &fill.Fill{Direction: gctorder.Buy, PurchasePrice: decimal.NewFromInt(99)}
+	setEffectiveSpread(f, decimal.NewFromInt(100))
+	if c := entrySpreadCost(f); !c.IsZero() {
+		t.Errorf("received '%v' expected '%v'", c, 0)
+	}
+}
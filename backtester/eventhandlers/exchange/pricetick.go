@@ -0,0 +1,22 @@
+package exchange
+
+import (
+	"github.com/shopspring/decimal"
+)
+
+// conformToPriceTick rounds the price to the nearest multiple of the pair's
+// price tick size from its exchange limits. Pairs on the same exchange can
+// have different tick sizes, so the tick is sourced from each pair's limits.
+// Positive prices are never rounded below one tick. The price is returned
+// unchanged when exchange limits are not in use or no tick size is set
+func conformToPriceTick(price decimal.Decimal, cs *Settings) decimal.Decimal {
+	if cs == nil || !cs.CanUseExchangeLimits || cs.Limits.PriceStepIncrementSize <= 0 || !price.IsPositive() {
+		return price
+	}
+	tick := decimal.NewFromFloat(cs.Limits.PriceStepIncrementSize)
+	conformed := price.Div(tick).Round(0).Mul(tick)
+	if !conformed.IsPositive() {
+		return tick
+	}
+	return conformed
+}
@@ -0,0 +1,98 @@
+package exchange
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	gctkline "github.com/thrasher-corp/gocryptotrader/exchanges/kline"
+	gctorder "github.com/thrasher-corp/gocryptotrader/exchanges/order"
+)
+
+func TestConformToPriceTick(t *testing.T) {
+	t.Parallel()
+	price := decimal.NewFromFloat(100.37)
+	if p := conformToPriceTick(price, nil); !p.Equal(price) {
+		t.Errorf("received '%v' expected '%v'", p, price)
+	}
+	cs := &Settings{Limits: gctorder.MinMaxLevel{PriceStepIncrementSize: 0.5}}
+	if p := conformToPriceTick(price, cs); !p.Equal(price) {
+		t.Errorf("received '%v' expected '%v' when exchange limits are disabled", p, price)
+	}
+	cs.CanUseExchangeLimits = true
+	if p := conformToPriceTick(price, cs); !p.Equal(decimal.NewFromFloat(100.5)) {
+		t.Errorf("received '%v' expected '%v'", p, 100.5)
+	}
+	if p := conformToPriceTick(decimal.NewFromFloat(0.1), cs); !p.Equal(decimal.NewFromFloat(0.5)) {
+		t.Errorf("received '%v' expected '%v'", p, 0.5)
+	}
+	if p := conformToPriceTick(decimal.Zero, cs); !p.IsZero() {
+		t.Errorf("received '%v' expected '%v'", p, 0)
+	}
+}
+
+func TestConformToPriceTickPerPair(t *testing.T) {
+	t.Parallel()
+	_, exch := setupOfflineOrderManager(t)
+	btc := currency.NewPair(currency.BTC, currency.USDT)
+	eth := currency.NewPair(currency.ETH, currency.USDT)
+	e := Exchange{}
+	e.SetExchangeAssetCurrencySettings(asset.Spot, btc, &Settings{
+		Exchange:             exch,
+		Pair:                 btc,
+		Asset:                asset.Spot,
+		CanUseExchangeLimits: true,
+		Limits:               gctorder.MinMaxLevel{PriceStepIncrementSize: 0.5},
+	})
+	e.SetExchangeAssetCurrencySettings(asset.Spot, eth, &Settings{
+		Exchange:             exch,
+		Pair:                 eth,
+		Asset:                asset.Spot,
+		CanUseExchangeLimits: true,
+		Limits:               gctorder.MinMaxLevel{PriceStepIncrementSize: 0.01},
+	})
+	price := decimal.NewFromFloat(100.373)
+	for _, tt := range []struct {
+		pair     currency.Pair
+		expected decimal.Decimal
+	}{
+		{pair: btc, expected: decimal.NewFromFloat(100.5)},
+		{pair: eth, expected: decimal.NewFromFloat(100.37)},
+	} {
+		cs, err := e.GetCurrencySettings(testExchange, asset.Spot, tt.pair)
+		if !errors.Is(err, nil) {
+			t.Fatalf("received '%v' expected '%v'", err, nil)
+		}
+		if p := conformToPriceTick(price, &cs); !p.Equal(tt.expected) {
+			t.Errorf("received '%v' expected '%v' for %v", p, tt.expected, tt.pair)
+		}
+	}
+}
+
+func TestExecuteOrderPriceTick(t *testing.T) {
+	t.Parallel()
+	om, exch := setupOfflineOrderManager(t)
+	o, d := setupOfflineOrder(t, gctorder.Buy, decimal.NewFromInt(1), decimal.NewFromFloat(100.3),
+		gctkline.Candle{Close: 100.3, High: 110, Low: 90, Volume: 1000})
+	cs := Settings{
+		Exchange:               exch,
+		Pair:                   o.Pair(),
+		Asset:                  o.GetAssetType(),
+		MinimumSlippageRate:    decimal.NewFromInt(100),
+		MaximumSlippageRate:    decimal.NewFromInt(100),
+		CanUseExchangeLimits:   true,
+		Limits:                 gctorder.MinMaxLevel{PriceStepIncrementSize: 0.5},
+		SignalToFillConvention: SameCandleClose,
+	}
+	e := Exchange{CurrencySettings: []Settings{cs}}
+	f, err := e.ExecuteOrder(context.Background(), o, d, om, &fakeFund{})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	if !f.GetPurchasePrice().Equal(decimal.NewFromFloat(100.5)) {
+		t.Errorf("received '%v' expected '%v'", f.GetPurchasePrice(), 100.5)
+	}
+}
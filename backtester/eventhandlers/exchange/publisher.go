@@ -0,0 +1,78 @@
+package exchange
+
+import (
+	"fmt"
+	"sync/atomic"
+
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/fill"
+	gctorder "github.com/thrasher-corp/gocryptotrader/exchanges/order"
+)
+
+// NewChannelPublisher returns a FillPublisher which sends fills to a channel
+// buffered to the size provided
+func NewChannelPublisher(size int) (*ChannelPublisher, error) {
+	if size <= 0 {
+		return nil, fmt.Errorf("%w %v", errInvalidPublisherBufferSize, size)
+	}
+	return &ChannelPublisher{
+		fills: make(chan PublishedFill, size),
+	}, nil
+}
+
+// Publish sends the fill to the channel without blocking. Fills published
+// while the buffer is full are dropped so a slow consumer cannot stall the run
+func (c *ChannelPublisher) Publish(p PublishedFill) {
+	select {
+	case c.fills <- p:
+	default:
+		atomic.AddInt64(&c.dropped, 1)
+	}
+}
+
+// Fills returns the channel published fills are received on
+func (c *ChannelPublisher) Fills() <-chan PublishedFill {
+	return c.fills
+}
+
+// Dropped returns the number of fills dropped due to a full buffer
+func (c *ChannelPublisher) Dropped() int64 {
+	return atomic.LoadInt64(&c.dropped)
+}
+
+// publishFill sends the fill to the publisher when one is set. Orders which
+// did not reach the exchange, such as deferred orders, are not published
+func (e *Exchange) publishFill(f fill.Event) {
+	if e.Publisher == nil || f == nil || f.GetDirection() == gctorder.DoNothing {
+		return
+	}
+	e.Publisher.Publish(toPublishedFill(f))
+}
+
+// toPublishedFill converts a fill event to its serialisable representation
+func toPublishedFill(f fill.Event) PublishedFill {
+	p := PublishedFill{
+		Exchange:      f.GetExchange(),
+		Asset:         f.GetAssetType().String(),
+		Pair:          f.Pair().String(),
+		Interval:      f.GetInterval().Word(),
+		Offset:        f.GetOffset(),
+		Time:          f.GetFillTime(),
+		Direction:     f.GetDirection().String(),
+		Amount:        f.GetAmount(),
+		ClosePrice:    f.GetClosePrice(),
+		PurchasePrice: f.GetPurchasePrice(),
+		Total:         f.GetTotal(),
+		ExchangeFee:   f.GetExchangeFee(),
+		Slippage:      f.GetSlippageRate(),
+		Liquidated:    f.IsLiquidated(),
+		StopOut:       f.IsStopOut(),
+		Tag:           f.GetTag(),
+		Account:       f.GetAccount(),
+		FeeType:       f.GetFeeType(),
+		Reasons:       f.GetConcatReasons(),
+	}
+	if o := f.GetOrder(); o != nil {
+		p.OrderID = o.OrderID
+	}
+	return p
+}
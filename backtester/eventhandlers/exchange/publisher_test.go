@@ -0,0 +1,90 @@
+package exchange
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	gctkline "github.com/thrasher-corp/gocryptotrader/exchanges/kline"
+	gctorder "github.com/thrasher-corp/gocryptotrader/exchanges/order"
+)
+
+func TestNewChannelPublisher(t *testing.T) {
+	t.Parallel()
+	_, err := NewChannelPublisher(0)
+	if !errors.Is(err, errInvalidPublisherBufferSize) {
+		t.Errorf("received '%v' expected '%v'", err, errInvalidPublisherBufferSize)
+	}
+	c, err := NewChannelPublisher(1)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	c.Publish(PublishedFill{Tag: "1"})
+	c.Publish(PublishedFill{Tag: "2"})
+	if c.Dropped() != 1 {
+		t.Errorf("received '%v' expected '%v'", c.Dropped(), 1)
+	}
+	if p := <-c.Fills(); p.Tag != "1" {
+		t.Errorf("received '%v' expected '%v'", p.Tag, "1")
+	}
+}
+
+func TestExecuteOrderPublishesFill(t *testing.T) {
+	t.Parallel()
+	om, exch := setupOfflineOrderManager(t)
+	o, d := setupOfflineOrder(t, gctorder.Buy, decimal.NewFromInt(1), decimal.NewFromInt(100),
+		gctkline.Candle{Close: 100, High: 110, Low: 90, Volume: 1000})
+	o.Tag = "breakout"
+	cs := Settings{
+		Exchange:               exch,
+		Pair:                   o.Pair(),
+		Asset:                  o.GetAssetType(),
+		MinimumSlippageRate:    decimal.NewFromInt(100),
+		MaximumSlippageRate:    decimal.NewFromInt(100),
+		SignalToFillConvention: SameCandleClose,
+	}
+	c, err := NewChannelPublisher(10)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	e := Exchange{
+		CurrencySettings: []Settings{cs},
+		Publisher:        c,
+	}
+	f, err := e.ExecuteOrder(context.Background(), o, d, om, &fakeFund{})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	if len(c.Fills()) != 1 {
+		t.Fatalf("received '%v' expected '%v'", len(c.Fills()), 1)
+	}
+	p := <-c.Fills()
+	if p.Direction != gctorder.Buy.String() {
+		t.Errorf("received '%v' expected '%v'", p.Direction, gctorder.Buy)
+	}
+	if !p.PurchasePrice.Equal(f.GetPurchasePrice()) {
+		t.Errorf("received '%v' expected '%v'", p.PurchasePrice, f.GetPurchasePrice())
+	}
+	if p.Tag != "breakout" {
+		t.Errorf("received '%v' expected '%v'", p.Tag, "breakout")
+	}
+	if p.OrderID == "" {
+		t.Error("expected order id to be published")
+	}
+	if _, err = json.Marshal(p); !errors.Is(err, nil) {
+		t.Errorf("received '%v' expected '%v'", err, nil)
+	}
+
+	e.CurrencySettings[0].Latency = &LatencyDistribution{Mean: gctkline.OneHour.Duration()}
+	o, d = setupOfflineOrder(t, gctorder.Buy, decimal.NewFromInt(1), decimal.NewFromInt(100),
+		gctkline.Candle{Close: 100, High: 110, Low: 90, Volume: 1000})
+	_, err = e.ExecuteOrder(context.Background(), o, d, om, &fakeFund{})
+	if !errors.Is(err, ErrCannotTransact) {
+		t.Fatalf("received '%v' expected '%v'", err, ErrCannotTransact)
+	}
+	if len(c.Fills()) != 0 {
+		t.Errorf("received '%v' expected '%v'", len(c.Fills()), 0)
+	}
+}
@@ -0,0 +1,13 @@
+package exchange
+
+import "github.com/shopspring/decimal"
+
+// withheldRebate returns the rebate earned by a negative fee which is withheld
+// from funding when the rebate policy accumulates rebates separately. Rebates
+// are otherwise reinvested into available funds along with the order
+func withheldRebate(fee decimal.Decimal, cs *Settings) decimal.Decimal {
+	if cs == nil || cs.RebatePolicy != RebateAccumulate || !fee.IsNegative() {
+		return decimal.Zero
+	}
+	return fee.Neg()
+}
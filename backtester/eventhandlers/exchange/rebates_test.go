@@ -0,0 +1,146 @@
+package exchange
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/event"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/fill"
+	"github.com/thrasher-corp/gocryptotrader/backtester/funding"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	gctkline "github.com/thrasher-corp/gocryptotrader/exchanges/kline"
+	gctorder "github.com/thrasher-corp/gocryptotrader/exchanges/order"
+)
+
+func TestWithheldRebate(t *testing.T) {
+	t.Parallel()
+	rebate := decimal.NewFromInt(-2)
+	if r := withheldRebate(rebate, nil); !r.IsZero() {
+		t.Errorf("received '%v' expected '%v'", r, 0)
+	}
+	if r := withheldRebate(rebate, &Settings{RebatePolicy: RebateReinvest}); !r.IsZero() {
+		t.Errorf("received '%v' expected '%v'", r, 0)
+	}
+	if r := withheldRebate(decimal.NewFromInt(2), &Settings{RebatePolicy: RebateAccumulate}); !r.IsZero() {
+		t.Errorf("received '%v' expected '%v'", r, 0)
+	}
+	if r := withheldRebate(rebate, &Settings{RebatePolicy: RebateAccumulate}); !r.Equal(decimal.NewFromInt(2)) {
+		t.Errorf("received '%v' expected '%v'", r, 2)
+	}
+}
+
+func TestAllocateFundsPostOrderRebates(t *testing.T) {
+	t.Parallel()
+	initialFunds := decimal.NewFromInt(1337)
+	price := decimal.NewFromInt(100)
+	rebate := decimal.NewFromInt(-2)
+	for _, tc := range []struct {
+		policy         string
+		expectedQuote  decimal.Decimal
+		expectedRebate decimal.Decimal
+	}{
+		// buying 4 at 100 costs 400, receiving a rebate of 2 on top of the unspent 100
+		{policy: RebateReinvest, expectedQuote: initialFunds.Sub(decimal.NewFromInt(398))},
+		{policy: RebateAccumulate, expectedQuote: initialFunds.Sub(decimal.NewFromInt(400)), expectedRebate: decimal.NewFromInt(2)},
+	} {
+		btc, err := funding.CreateItem(testExchange, asset.Spot, currency.BTC, initialFunds, decimal.Zero)
+		if err != nil {
+			t.Fatal(err)
+		}
+		usd, err := funding.CreateItem(testExchange, asset.Spot, currency.USD, initialFunds, decimal.Zero)
+		if err != nil {
+			t.Fatal(err)
+		}
+		fundPair, err := funding.CreatePair(btc, usd)
+		if err != nil {
+			t.Fatal(err)
+		}
+		allocated := decimal.NewFromInt(500)
+		err = fundPair.Reserve(allocated, gctorder.Buy)
+		if err != nil {
+			t.Fatal(err)
+		}
+		f := &fill.Fill{
+			Base: &event.Base{
+				AssetType:    asset.Spot,
+				CurrencyPair: currency.NewPair(currency.BTC, currency.USD),
+			},
+			Direction: gctorder.Buy,
+			Order:     &gctorder.Detail{},
+		}
+		amount := decimal.NewFromInt(4)
+		err = allocateFundsPostOrder(f, fundPair, nil, amount, allocated, amount, price, rebate, &Settings{RebatePolicy: tc.policy})
+		if !errors.Is(err, nil) {
+			t.Fatalf("%v received '%v' expected '%v'", tc.policy, err, nil)
+		}
+		if !fundPair.QuoteAvailable().Equal(tc.expectedQuote) {
+			t.Errorf("%v received '%v' expected '%v'", tc.policy, fundPair.QuoteAvailable(), tc.expectedQuote)
+		}
+		if !f.GetAccumulatedRebate().Equal(tc.expectedRebate) {
+			t.Errorf("%v received '%v' expected '%v'", tc.policy, f.GetAccumulatedRebate(), tc.expectedRebate)
+		}
+	}
+}
+
+func TestExecuteOrderMakerRebate(t *testing.T) {
+	t.Parallel()
+	om, exch := setupOfflineOrderManager(t)
+	o, d := setupOfflineOrder(t, gctorder.Sell, decimal.NewFromInt(1), decimal.NewFromInt(100),
+		gctkline.Candle{Open: 100, Close: 100, High: 110, Low: 95, Volume: 100000})
+	o.LimitPrice = decimal.NewFromInt(105)
+	// the limit order rested from a previous candle
+	o.RestingLimit = true
+	o.AllocatedFunds = decimal.NewFromInt(1)
+	cs := Settings{
+		Exchange:            exch,
+		Pair:                o.Pair(),
+		Asset:               o.GetAssetType(),
+		MinimumSlippageRate: decimal.NewFromInt(100),
+		MaximumSlippageRate: decimal.NewFromInt(100),
+		MakerFee:            decimal.NewFromFloat(-0.0002),
+		TakerFee:            decimal.NewFromFloat(0.001),
+	}
+	e := Exchange{CurrencySettings: []Settings{cs}}
+	initialQuote := decimal.NewFromInt(1337)
+	btc, err := funding.CreateItem(testExchange, asset.Spot, o.Pair().Base, decimal.NewFromInt(1), decimal.Zero)
+	if err != nil {
+		t.Fatal(err)
+	}
+	usdt, err := funding.CreateItem(testExchange, asset.Spot, o.Pair().Quote, initialQuote, decimal.Zero)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fundPair, err := funding.CreatePair(btc, usdt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = fundPair.Reserve(o.AllocatedFunds, gctorder.Sell)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f, err := e.ExecuteOrder(context.Background(), o, d, om, fundPair)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	if f.GetFeeType() != fill.FeeTypeMaker {
+		t.Errorf("received '%v' expected '%v'", f.GetFeeType(), fill.FeeTypeMaker)
+	}
+	proceeds := f.GetAmount().Mul(f.GetPurchasePrice())
+	if !proceeds.Equal(decimal.NewFromInt(105)) {
+		t.Fatalf("received '%v' expected '%v'", proceeds, decimal.NewFromInt(105))
+	}
+	// the negative maker fee is a rebate paid on top of the raw proceeds
+	rebate := decimal.NewFromFloat(0.021)
+	if !f.GetExchangeFee().Equal(rebate.Neg()) {
+		t.Errorf("received '%v' expected '%v'", f.GetExchangeFee(), rebate.Neg())
+	}
+	if !fundPair.QuoteAvailable().Equal(initialQuote.Add(proceeds).Add(rebate)) {
+		t.Errorf("received '%v' expected '%v'", fundPair.QuoteAvailable(), initialQuote.Add(proceeds).Add(rebate))
+	}
+	if !fundPair.BaseAvailable().IsZero() {
+		t.Errorf("received '%v' expected '%v'", fundPair.BaseAvailable(), decimal.Zero)
+	}
+}
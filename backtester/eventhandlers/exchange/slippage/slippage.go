@@ -1,6 +1,7 @@
 package slippage
 
 import (
+	"math"
 	"math/rand"
 
 	"github.com/shopspring/decimal"
@@ -11,6 +12,13 @@ import (
 // EstimateSlippagePercentage takes in an int range of numbers
 // turns it into a percentage
 func EstimateSlippagePercentage(maximumSlippageRate, minimumSlippageRate decimal.Decimal) decimal.Decimal {
+	return EstimateSlippagePercentageFromRand(nil, maximumSlippageRate, minimumSlippageRate)
+}
+
+// EstimateSlippagePercentageFromRand is EstimateSlippagePercentage drawing the
+// percentage from r so that the percentages drawn can be reproduced. A nil r
+// draws from the default source
+func EstimateSlippagePercentageFromRand(r *rand.Rand, maximumSlippageRate, minimumSlippageRate decimal.Decimal) decimal.Decimal {
 	if minimumSlippageRate.LessThan(decimal.NewFromInt(1)) || minimumSlippageRate.GreaterThan(decimal.NewFromInt(100)) {
 		return decimal.NewFromInt(1)
 	}
@@ -22,19 +30,51 @@ func EstimateSlippagePercentage(maximumSlippageRate, minimumSlippageRate decimal
 	// eg 80 means for every dollar, keep 80%
 	randSeed := int(minimumSlippageRate.IntPart()) - int(maximumSlippageRate.IntPart())
 	if randSeed > 0 {
-		result := int64(rand.Intn(randSeed)) //nolint:gosec // basic number generation required, no need for crypto/rand
+		var result int64
+		if r != nil {
+			result = int64(r.Intn(randSeed))
+		} else {
+			result = int64(rand.Intn(randSeed)) //nolint:gosec // basic number generation required, no need for crypto/rand
+		}
 
 		return maximumSlippageRate.Add(decimal.NewFromInt(result)).Div(decimal.NewFromInt(100))
 	}
 	return decimal.NewFromInt(1)
 }
 
+// TieredSlippagePercentage returns the slippage rate of the largest tier whose
+// minimum notional the order's notional reaches, in the same format as
+// EstimateSlippagePercentage. Tiers must be sorted by ascending minimum notional.
+// Orders smaller than every tier experience no slippage
+func TieredSlippagePercentage(tiers []Tier, notional decimal.Decimal) decimal.Decimal {
+	rate := decimal.NewFromInt(1)
+	for i := range tiers {
+		if notional.LessThan(tiers[i].MinimumNotional) {
+			break
+		}
+		rate = decimal.NewFromInt(1).Sub(tiers[i].BasisPoints.Div(decimal.NewFromInt(10000)))
+	}
+	return rate
+}
+
 // CalculateSlippageByOrderbook will analyse a provided orderbook and return the result of attempting to
 // place the order on there
 func CalculateSlippageByOrderbook(ob *orderbook.Base, side gctorder.Side, allocatedFunds, feeRate decimal.Decimal) (price, amount decimal.Decimal) {
 	result := ob.SimulateOrder(allocatedFunds.InexactFloat64(), side == gctorder.Buy)
+	if result.MaximumPrice <= 0 {
+		// an empty or invalid book cannot fill the order
+		return decimal.Zero, decimal.Zero
+	}
 	rate := (result.MinimumPrice - result.MaximumPrice) / result.MaximumPrice
-	price = decimal.NewFromFloat(result.MinimumPrice * (rate + 1))
-	amount = decimal.NewFromFloat(result.Amount * (1 - feeRate.InexactFloat64()))
-	return
+	p := result.MinimumPrice * (rate + 1)
+	a := result.Amount * (1 - feeRate.InexactFloat64())
+	if !isFinite(p) || !isFinite(a) {
+		return decimal.Zero, decimal.Zero
+	}
+	return decimal.NewFromFloat(p), decimal.NewFromFloat(a)
+}
+
+// isFinite returns whether the float can be converted to a decimal
+func isFinite(f float64) bool {
+	return !math.IsNaN(f) && !math.IsInf(f, 0)
 }
@@ -2,6 +2,8 @@ package slippage
 
 import (
 	"context"
+	"math"
+	"math/rand"
 	"testing"
 
 	"github.com/shopspring/decimal"
@@ -9,6 +11,7 @@ import (
 	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/bitstamp"
 	gctorder "github.com/thrasher-corp/gocryptotrader/exchanges/order"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/orderbook"
 )
 
 func TestRandomSlippage(t *testing.T) {
@@ -19,6 +22,39 @@ func TestRandomSlippage(t *testing.T) {
 	}
 }
 
+func TestEstimateSlippagePercentageFromRand(t *testing.T) {
+	t.Parallel()
+	r := rand.New(rand.NewSource(1337)) //nolint:gosec // reproducible number generation required, no need for crypto/rand
+	expected := EstimateSlippagePercentageFromRand(r, decimal.NewFromInt(80), decimal.NewFromInt(100))
+	if expected.LessThan(decimal.NewFromFloat(0.8)) || expected.GreaterThan(decimal.NewFromInt(1)) {
+		t.Error("expected result > 0.8 and < 100")
+	}
+	r = rand.New(rand.NewSource(1337)) //nolint:gosec // reproducible number generation required, no need for crypto/rand
+	if resp := EstimateSlippagePercentageFromRand(r, decimal.NewFromInt(80), decimal.NewFromInt(100)); !resp.Equal(expected) {
+		t.Errorf("received '%v' expected '%v'", resp, expected)
+	}
+}
+
+func TestTieredSlippagePercentage(t *testing.T) {
+	t.Parallel()
+	tiers := []Tier{
+		{MinimumNotional: decimal.NewFromInt(100), BasisPoints: decimal.NewFromInt(2)},
+		{MinimumNotional: decimal.NewFromInt(10000), BasisPoints: decimal.NewFromInt(20)},
+	}
+	if resp := TieredSlippagePercentage(nil, decimal.NewFromInt(1337)); !resp.Equal(decimal.NewFromInt(1)) {
+		t.Errorf("received '%v' expected '%v'", resp, 1)
+	}
+	if resp := TieredSlippagePercentage(tiers, decimal.NewFromInt(50)); !resp.Equal(decimal.NewFromInt(1)) {
+		t.Errorf("received '%v' expected '%v'", resp, 1)
+	}
+	if resp := TieredSlippagePercentage(tiers, decimal.NewFromInt(100)); !resp.Equal(decimal.NewFromFloat(0.9998)) {
+		t.Errorf("received '%v' expected '%v'", resp, 0.9998)
+	}
+	if resp := TieredSlippagePercentage(tiers, decimal.NewFromInt(50000)); !resp.Equal(decimal.NewFromFloat(0.998)) {
+		t.Errorf("received '%v' expected '%v'", resp, 0.998)
+	}
+}
+
 func TestCalculateSlippageByOrderbook(t *testing.T) {
 	t.Parallel()
 	b := bitstamp.Bitstamp{}
@@ -35,3 +71,21 @@ func TestCalculateSlippageByOrderbook(t *testing.T) {
 		t.Error("order size must be less than funds")
 	}
 }
+
+func TestCalculateSlippageByOrderbookEmptyBook(t *testing.T) {
+	t.Parallel()
+	price, amount := CalculateSlippageByOrderbook(&orderbook.Base{}, gctorder.Buy, decimal.NewFromInt(1000), decimal.NewFromFloat(0.03))
+	if !price.IsZero() || !amount.IsZero() {
+		t.Errorf("received '%v' '%v' expected '%v' '%v'", price, amount, 0, 0)
+	}
+}
+
+func TestIsFinite(t *testing.T) {
+	t.Parallel()
+	if !isFinite(1337) {
+		t.Error("expected finite")
+	}
+	if isFinite(math.NaN()) || isFinite(math.Inf(1)) || isFinite(math.Inf(-1)) {
+		t.Error("expected non-finite values to be caught")
+	}
+}
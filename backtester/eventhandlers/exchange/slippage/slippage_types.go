@@ -8,3 +8,10 @@ var (
 	DefaultMaximumSlippagePercent = decimal.NewFromInt(100)
 	DefaultMinimumSlippagePercent = decimal.NewFromInt(100)
 )
+
+// Tier is a slippage rate applied to orders whose notional is at least
+// the minimum notional, expressed in basis points of the price
+type Tier struct {
+	MinimumNotional decimal.Decimal
+	BasisPoints     decimal.Decimal
+}
@@ -0,0 +1,49 @@
+package exchange
+
+import (
+	"github.com/shopspring/decimal"
+	gctorder "github.com/thrasher-corp/gocryptotrader/exchanges/order"
+)
+
+// candleDirection returns 1 for an up candle which closed above its open,
+// -1 for a down candle which closed below its open and 0 otherwise
+func candleDirection(open, closePrice decimal.Decimal) int {
+	if !open.IsPositive() || !closePrice.IsPositive() {
+		return 0
+	}
+	return closePrice.Cmp(open)
+}
+
+// applySlippageAsymmetry scales the slippage of an estimated slippage rate by
+// the candle's direction, modelling momentum driven execution costs. Orders
+// aligned with the candle, such as buying in an up candle, slip more by the
+// asymmetry factor and orders opposing the candle slip less by it. The
+// slippage rate is the multiplier applied to the price, where 1 is no slippage
+func applySlippageAsymmetry(direction gctorder.Side, open, closePrice, slippageRate, asymmetry decimal.Decimal) (decimal.Decimal, bool) {
+	if !asymmetry.IsPositive() {
+		return slippageRate, false
+	}
+	var side int
+	switch direction {
+	case gctorder.Buy, gctorder.Bid, gctorder.Long:
+		side = 1
+	case gctorder.Sell, gctorder.Ask, gctorder.Short:
+		side = -1
+	default:
+		return slippageRate, false
+	}
+	candle := candleDirection(open, closePrice)
+	if candle == 0 {
+		return slippageRate, false
+	}
+	one := decimal.NewFromInt(1)
+	scale := one.Add(asymmetry)
+	if side != candle {
+		scale = one.Sub(asymmetry)
+		if scale.IsNegative() {
+			scale = decimal.Zero
+		}
+	}
+	slipped := one.Sub(slippageRate).Mul(scale)
+	return one.Sub(slipped), true
+}
@@ -0,0 +1,88 @@
+package exchange
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventhandlers/exchange/slippage"
+	gctkline "github.com/thrasher-corp/gocryptotrader/exchanges/kline"
+	gctorder "github.com/thrasher-corp/gocryptotrader/exchanges/order"
+)
+
+func TestCandleDirection(t *testing.T) {
+	t.Parallel()
+	if d := candleDirection(decimal.NewFromInt(100), decimal.NewFromInt(110)); d != 1 {
+		t.Errorf("received '%v' expected '%v'", d, 1)
+	}
+	if d := candleDirection(decimal.NewFromInt(100), decimal.NewFromInt(90)); d != -1 {
+		t.Errorf("received '%v' expected '%v'", d, -1)
+	}
+	if d := candleDirection(decimal.NewFromInt(100), decimal.NewFromInt(100)); d != 0 {
+		t.Errorf("received '%v' expected '%v'", d, 0)
+	}
+	if d := candleDirection(decimal.Zero, decimal.NewFromInt(100)); d != 0 {
+		t.Errorf("received '%v' expected '%v'", d, 0)
+	}
+}
+
+func TestApplySlippageAsymmetry(t *testing.T) {
+	t.Parallel()
+	up := []decimal.Decimal{decimal.NewFromInt(100), decimal.NewFromInt(110)}
+	down := []decimal.Decimal{decimal.NewFromInt(110), decimal.NewFromInt(100)}
+	rate := decimal.NewFromFloat(0.98)
+	half := decimal.NewFromFloat(0.5)
+
+	resp, adjusted := applySlippageAsymmetry(gctorder.Buy, up[0], up[1], rate, decimal.Zero)
+	if adjusted || !resp.Equal(rate) {
+		t.Errorf("received '%v' '%v' expected '%v' '%v'", resp, adjusted, rate, false)
+	}
+	resp, adjusted = applySlippageAsymmetry(gctorder.Buy, up[0], up[1], rate, half)
+	if !adjusted || !resp.Equal(decimal.NewFromFloat(0.97)) {
+		t.Errorf("received '%v' '%v' expected '%v' '%v'", resp, adjusted, 0.97, true)
+	}
+	resp, _ = applySlippageAsymmetry(gctorder.Sell, up[0], up[1], rate, half)
+	if !resp.Equal(decimal.NewFromFloat(0.99)) {
+		t.Errorf("received '%v' expected '%v'", resp, 0.99)
+	}
+	resp, _ = applySlippageAsymmetry(gctorder.Sell, down[0], down[1], rate, half)
+	if !resp.Equal(decimal.NewFromFloat(0.97)) {
+		t.Errorf("received '%v' expected '%v'", resp, 0.97)
+	}
+	resp, _ = applySlippageAsymmetry(gctorder.Buy, down[0], down[1], rate, decimal.NewFromInt(1))
+	if !resp.Equal(decimal.NewFromInt(1)) {
+		t.Errorf("received '%v' expected '%v'", resp, 1)
+	}
+	resp, adjusted = applySlippageAsymmetry(gctorder.Buy, up[0], up[0], rate, half)
+	if adjusted || !resp.Equal(rate) {
+		t.Errorf("received '%v' '%v' expected flat candle to be unadjusted", resp, adjusted)
+	}
+	_, adjusted = applySlippageAsymmetry(gctorder.ClosePosition, up[0], up[1], rate, half)
+	if adjusted {
+		t.Error("expected unsupported direction to be unadjusted")
+	}
+}
+
+func TestExecuteOrderSlippageAsymmetry(t *testing.T) {
+	t.Parallel()
+	om, exch := setupOfflineOrderManager(t)
+	o, d := setupOfflineOrder(t, gctorder.Buy, decimal.NewFromInt(1), decimal.NewFromInt(100),
+		gctkline.Candle{Open: 90, Close: 100, High: 110, Low: 80, Volume: 1000})
+	cs := Settings{
+		Exchange:               exch,
+		Pair:                   o.Pair(),
+		Asset:                  o.GetAssetType(),
+		SlippageTiers:          []slippage.Tier{{BasisPoints: decimal.NewFromInt(200)}},
+		SlippageAsymmetry:      decimal.NewFromFloat(0.5),
+		SignalToFillConvention: SameCandleClose,
+	}
+	e := Exchange{CurrencySettings: []Settings{cs}}
+	f, err := e.ExecuteOrder(context.Background(), o, d, om, &fakeFund{})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	if !f.GetPurchasePrice().Equal(decimal.NewFromInt(103)) {
+		t.Errorf("received '%v' expected '%v'", f.GetPurchasePrice(), 103)
+	}
+}
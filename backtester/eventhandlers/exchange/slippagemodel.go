@@ -0,0 +1,102 @@
+package exchange
+
+import (
+	"math/rand"
+
+	"github.com/shopspring/decimal"
+	"github.com/thrasher-corp/gocryptotrader/backtester/common"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventhandlers/exchange/slippage"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/fill"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/order"
+	"github.com/thrasher-corp/gocryptotrader/backtester/funding"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/orderbook"
+)
+
+// estimateSlippage applies the built-in slippage estimate to the price. The
+// rate is drawn from r between the minimum and maximum slippage range or the
+// slippage tiers, then scaled by the candle's direction and amplified on
+// extreme volatility candles when configured
+func estimateSlippage(f *fill.Fill, cs *Settings, r *rand.Rand, latest common.DataEventHandler, price, amount, rangePercent decimal.Decimal, isExtreme bool) (decimal.Decimal, error) {
+	slippageRate := slippage.EstimateSlippagePercentageFromRand(r, cs.MinimumSlippageRate, cs.MaximumSlippageRate)
+	if len(cs.SlippageTiers) > 0 {
+		slippageRate = slippage.TieredSlippagePercentage(cs.SlippageTiers, amount.Mul(price))
+		f.AppendReasonf("Slippage rate %v selected from tier for order notional %v", slippageRate, amount.Mul(price))
+	}
+	if latest != nil {
+		asymmetricRate, isAdjusted := applySlippageAsymmetry(f.GetDirection(), latest.GetOpenPrice(), latest.GetClosePrice(), slippageRate, cs.SlippageAsymmetry)
+		if isAdjusted && !asymmetricRate.Equal(slippageRate) {
+			f.AppendReasonf("Slippage rate adjusted from %v to %v for candle direction", slippageRate, asymmetricRate)
+			slippageRate = asymmetricRate
+		}
+	}
+	if isExtreme && cs.ExtremeVolatilityBehaviour == ExtremeVolatilityAmplifySlippage {
+		amplifiedRate := amplifySlippage(slippageRate, cs.ExtremeVolatilitySlippageMultiplier)
+		if !amplifiedRate.Equal(slippageRate) {
+			f.AppendReasonf("Slippage rate amplified from %v to %v as candle range of %v%% reaches extreme volatility threshold of %v%%", slippageRate, amplifiedRate, rangePercent.Round(4), cs.ExtremeVolatilityThreshold)
+			slippageRate = amplifiedRate
+		}
+	}
+	adjustedPrice, err := applySlippageToPrice(f.GetDirection(), price, slippageRate)
+	if err != nil {
+		return decimal.Zero, err
+	}
+	f.Slippage = slippageRate.Mul(decimal.NewFromInt(100)).Sub(decimal.NewFromInt(100))
+	return adjustedPrice, nil
+}
+
+// applySlippageModel applies the custom slippage model to the
+// order's price and amount, noting when the amount was changed
+func applySlippageModel(f *fill.Fill, cs *Settings, price, amount decimal.Decimal, ob *orderbook.Base) (adjustedPrice, adjustedAmount decimal.Decimal, err error) {
+	adjustedPrice, adjustedAmount, err = cs.SlippageModel.Apply(f.GetDirection(), price, amount, ob)
+	if err != nil {
+		return decimal.Zero, decimal.Zero, err
+	}
+	if !adjustedAmount.Equal(amount) {
+		f.AppendReasonf("Order size adjusted from %v to %v by slippage model", amount, adjustedAmount)
+	}
+	return adjustedPrice, adjustedAmount, nil
+}
+
+// handleSlippageModelError rejects an order
+// which the custom slippage model failed to apply to
+func handleSlippageModelError(o order.Event, f *fill.Fill, funds funding.IFundReleaser, cs *Settings, modelErr error) (fill.Event, error) {
+	f.AppendReasonf("Order rejected as slippage model failed: %v", modelErr)
+	return f, allocateFundsPostOrder(f, funds, modelErr, o.GetAmount(), o.GetAllocatedFunds(), decimal.Zero, decimal.Zero, decimal.Zero, cs)
+}
+
+// slippageSeed seeds the exchange's random slippage source so that
+// runs draw the same sequence of random slippage rates
+const slippageSeed = 1
+
+// getSlippageRand returns the exchange's source of random slippage rates,
+// creating it on first use
+func (e *Exchange) getSlippageRand() *rand.Rand {
+	if e.slippageRand == nil {
+		e.slippageSource = &slippageSource{source: rand.NewSource(slippageSeed)}
+		e.slippageRand = rand.New(e.slippageSource) //nolint:gosec // reproducible number generation required, no need for crypto/rand
+	}
+	return e.slippageRand
+}
+
+// restoreSlippageDraws reseeds the exchange's random slippage source and
+// draws the number of values drawn so that later random slippage rates
+// continue the same reproducible sequence
+func (e *Exchange) restoreSlippageDraws(draws int64) {
+	e.slippageRand = nil
+	e.getSlippageRand()
+	for e.slippageSource.draws < draws {
+		e.slippageSource.Int63()
+	}
+}
+
+// Int63 draws the next value from the source, counting the draw
+func (s *slippageSource) Int63() int64 {
+	s.draws++
+	return s.source.Int63()
+}
+
+// Seed reseeds the source, resetting its count of draws
+func (s *slippageSource) Seed(seed int64) {
+	s.source.Seed(seed)
+	s.draws = 0
+}
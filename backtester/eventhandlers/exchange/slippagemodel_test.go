@@ -0,0 +1,101 @@
+package exchange
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	gctkline "github.com/thrasher-corp/gocryptotrader/exchanges/kline"
+	gctorder "github.com/thrasher-corp/gocryptotrader/exchanges/order"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/orderbook"
+)
+
+var errTestSlippageModel = errors.New("test slippage model error")
+
+// fixedSlippageModel moves the price against the order by a fixed percentage
+type fixedSlippageModel struct {
+	percent decimal.Decimal
+	err     error
+}
+
+func (m *fixedSlippageModel) Apply(direction gctorder.Side, price, amount decimal.Decimal, _ *orderbook.Base) (adjPrice, adjAmount decimal.Decimal, err error) {
+	if m.err != nil {
+		return decimal.Zero, decimal.Zero, m.err
+	}
+	move := price.Mul(m.percent).Div(decimal.NewFromInt(100))
+	if direction.IsLong() {
+		return price.Add(move), amount, nil
+	}
+	return price.Sub(move), amount, nil
+}
+
+func TestExecuteOrderSlippageModel(t *testing.T) {
+	t.Parallel()
+	om, exch := setupOfflineOrderManager(t)
+	candle := gctkline.Candle{Close: 100, High: 110, Low: 90, Volume: 1000}
+	for _, tc := range []struct {
+		direction gctorder.Side
+		expected  decimal.Decimal
+	}{
+		{gctorder.Buy, decimal.NewFromInt(102)},
+		{gctorder.Sell, decimal.NewFromInt(98)},
+	} {
+		o, d := setupOfflineOrder(t, tc.direction, decimal.NewFromInt(1), decimal.NewFromInt(100), candle)
+		cs := Settings{
+			Exchange: exch,
+			Pair:     o.Pair(),
+			Asset:    o.GetAssetType(),
+			// the built-in estimate would slip by half the price
+			MinimumSlippageRate:    decimal.NewFromInt(50),
+			MaximumSlippageRate:    decimal.NewFromInt(50),
+			SlippageModel:          &fixedSlippageModel{percent: decimal.NewFromInt(2)},
+			SignalToFillConvention: SameCandleClose,
+		}
+		e := Exchange{CurrencySettings: []Settings{cs}}
+		f, err := e.ExecuteOrder(context.Background(), o, d, om, &fakeFund{})
+		if !errors.Is(err, nil) {
+			t.Fatalf("received '%v' expected '%v'", err, nil)
+		}
+		if !f.GetPurchasePrice().Equal(tc.expected) {
+			t.Errorf("%v received '%v' expected '%v'", tc.direction, f.GetPurchasePrice(), tc.expected)
+		}
+		if !f.GetSlippageRate().Equal(decimal.NewFromInt(-2)) {
+			t.Errorf("%v received '%v' expected '%v'", tc.direction, f.GetSlippageRate(), -2)
+		}
+	}
+
+	o, d := setupOfflineOrder(t, gctorder.Buy, decimal.NewFromInt(1), decimal.NewFromInt(100), candle)
+	cs := Settings{
+		Exchange:               exch,
+		Pair:                   o.Pair(),
+		Asset:                  o.GetAssetType(),
+		SlippageModel:          &fixedSlippageModel{err: errTestSlippageModel},
+		SignalToFillConvention: SameCandleClose,
+	}
+	e := Exchange{CurrencySettings: []Settings{cs}}
+	f, err := e.ExecuteOrder(context.Background(), o, d, om, &fakeFund{})
+	if !errors.Is(err, errTestSlippageModel) {
+		t.Errorf("received '%v' expected '%v'", err, errTestSlippageModel)
+	}
+	if f.GetDirection() != gctorder.CouldNotBuy {
+		t.Errorf("received '%v' expected '%v'", f.GetDirection(), gctorder.CouldNotBuy)
+	}
+}
+
+func TestAdverseSlippagePercent(t *testing.T) {
+	t.Parallel()
+	reference := decimal.NewFromInt(100)
+	if resp := adverseSlippagePercent(gctorder.Buy, reference, decimal.NewFromInt(102)); !resp.Equal(decimal.NewFromInt(2)) {
+		t.Errorf("received '%v' expected '%v'", resp, 2)
+	}
+	if resp := adverseSlippagePercent(gctorder.Short, reference, decimal.NewFromInt(102)); !resp.Equal(decimal.NewFromInt(-2)) {
+		t.Errorf("received '%v' expected '%v'", resp, -2)
+	}
+	if resp := adverseSlippagePercent(gctorder.UnknownSide, reference, decimal.NewFromInt(102)); !resp.IsZero() {
+		t.Errorf("received '%v' expected '%v'", resp, 0)
+	}
+	if resp := adverseSlippagePercent(gctorder.Buy, decimal.Zero, decimal.NewFromInt(102)); !resp.IsZero() {
+		t.Errorf("received '%v' expected '%v'", resp, 0)
+	}
+}
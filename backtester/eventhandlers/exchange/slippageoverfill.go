@@ -0,0 +1,46 @@
+package exchange
+
+import (
+	"github.com/shopspring/decimal"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/fill"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/order"
+	"github.com/thrasher-corp/gocryptotrader/backtester/funding"
+	gctorder "github.com/thrasher-corp/gocryptotrader/exchanges/order"
+)
+
+// slippageOverfill returns how far a buy's notional at its executed price
+// exceeds its allocated funds. Orders are sized to fit funds before their
+// final price is known, so slippage can push a fully sized order over them
+func slippageOverfill(direction gctorder.Side, price, amount, allocatedFunds decimal.Decimal) (decimal.Decimal, bool) {
+	switch direction {
+	case gctorder.Buy, gctorder.Bid:
+	default:
+		return decimal.Zero, false
+	}
+	overfill := price.Mul(amount).Sub(allocatedFunds)
+	if !overfill.IsPositive() {
+		return decimal.Zero, false
+	}
+	return overfill, true
+}
+
+// shrinkAmountToFitFunds returns the largest amount whose notional at the
+// executed price fits within the allocated funds, conformed to the exchange's
+// step size when exchange limits are in use
+func shrinkAmountToFitFunds(price, allocatedFunds decimal.Decimal, amountPrecision int32, cs *Settings) decimal.Decimal {
+	if !price.IsPositive() || !allocatedFunds.IsPositive() {
+		return decimal.Zero
+	}
+	amount := allocatedFunds.Div(price).Truncate(amountPrecision)
+	if cs.CanUseExchangeLimits {
+		amount = cs.Limits.ConformToDecimalAmount(amount)
+	}
+	return amount
+}
+
+// handleSlippageOverfill rejects a buy whose notional
+// after slippage exceeds its allocated funds
+func handleSlippageOverfill(o order.Event, f *fill.Fill, funds funding.IFundReleaser, cs *Settings, overfill decimal.Decimal) (fill.Event, error) {
+	f.AppendReasonf("Order notional exceeds allocated funds %v by %v after slippage, rejected", o.GetAllocatedFunds(), overfill)
+	return f, allocateFundsPostOrder(f, funds, errSlippageOverfill, o.GetAmount(), o.GetAllocatedFunds(), decimal.Zero, decimal.Zero, decimal.Zero, cs)
+}
@@ -0,0 +1,90 @@
+package exchange
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/fill"
+	gctkline "github.com/thrasher-corp/gocryptotrader/exchanges/kline"
+	gctorder "github.com/thrasher-corp/gocryptotrader/exchanges/order"
+)
+
+func TestSlippageOverfill(t *testing.T) {
+	t.Parallel()
+	funds := decimal.NewFromInt(1000)
+	// sized to consume all funds at 100 before slipping to 101
+	amount := reduceAmountToFitPortfolioLimit(decimal.NewFromInt(100), decimal.NewFromInt(1337), funds, gctorder.Buy)
+	overfill, isOverfilled := slippageOverfill(gctorder.Buy, decimal.NewFromInt(101), amount, funds)
+	if !isOverfilled || !overfill.Equal(decimal.NewFromInt(10)) {
+		t.Errorf("received '%v' '%v' expected '%v' '%v'", overfill, isOverfilled, 10, true)
+	}
+	overfill, isOverfilled = slippageOverfill(gctorder.Buy, decimal.NewFromInt(100), amount, funds)
+	if isOverfilled || !overfill.IsZero() {
+		t.Errorf("received '%v' '%v' expected '%v' '%v'", overfill, isOverfilled, 0, false)
+	}
+	if _, isOverfilled = slippageOverfill(gctorder.Sell, decimal.NewFromInt(101), amount, funds); isOverfilled {
+		t.Error("expected sells to be ignored")
+	}
+}
+
+func TestShrinkAmountToFitFunds(t *testing.T) {
+	t.Parallel()
+	cs := &Settings{}
+	resp := shrinkAmountToFitFunds(decimal.NewFromInt(101), decimal.NewFromInt(1000), 2, cs)
+	if !resp.Equal(decimal.NewFromFloat(9.9)) {
+		t.Errorf("received '%v' expected '%v'", resp, 9.9)
+	}
+	if resp.Mul(decimal.NewFromInt(101)).GreaterThan(decimal.NewFromInt(1000)) {
+		t.Errorf("received notional '%v' expected it to fit within '%v'", resp.Mul(decimal.NewFromInt(101)), 1000)
+	}
+	cs.CanUseExchangeLimits = true
+	cs.Limits.AmountStepIncrementSize = 1
+	resp = shrinkAmountToFitFunds(decimal.NewFromInt(101), decimal.NewFromInt(1000), 2, cs)
+	if !resp.Equal(decimal.NewFromInt(9)) {
+		t.Errorf("received '%v' expected '%v'", resp, 9)
+	}
+	resp = shrinkAmountToFitFunds(decimal.Zero, decimal.NewFromInt(1000), 2, cs)
+	if !resp.IsZero() {
+		t.Errorf("received '%v' expected '%v'", resp, 0)
+	}
+}
+
+func TestHandleSlippageOverfill(t *testing.T) {
+	t.Parallel()
+	o, _ := setupOfflineOrder(t, gctorder.Buy, decimal.NewFromInt(1), decimal.NewFromInt(100),
+		gctkline.Candle{Close: 100, High: 110, Low: 90, Volume: 100000})
+	f := &fill.Fill{Base: o.GetBase(), Direction: o.GetDirection()}
+	_, err := handleSlippageOverfill(o, f, &fakeFund{}, &Settings{}, decimal.NewFromInt(10))
+	if !errors.Is(err, errSlippageOverfill) {
+		t.Errorf("received '%v' expected '%v'", err, errSlippageOverfill)
+	}
+	if f.GetDirection() != gctorder.CouldNotBuy {
+		t.Errorf("received '%v' expected '%v'", f.GetDirection(), gctorder.CouldNotBuy)
+	}
+}
+
+func TestExecuteOrderSlippageWithinFunds(t *testing.T) {
+	t.Parallel()
+	om, exch := setupOfflineOrderManager(t)
+	// the order consumes all allocated funds before slippage raises its price
+	o, d := setupOfflineOrder(t, gctorder.Buy, decimal.NewFromFloat(13.37), decimal.NewFromInt(100),
+		gctkline.Candle{Close: 100, High: 110, Low: 90, Volume: 100000})
+	cs := Settings{
+		Exchange:               exch,
+		Pair:                   o.Pair(),
+		Asset:                  o.GetAssetType(),
+		MinimumSlippageRate:    decimal.NewFromInt(100),
+		MaximumSlippageRate:    decimal.NewFromInt(99),
+		SignalToFillConvention: SameCandleClose,
+	}
+	e := Exchange{CurrencySettings: []Settings{cs}}
+	f, err := e.ExecuteOrder(context.Background(), o, d, om, &fakeFund{})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	if f.GetAmount().Mul(f.GetPurchasePrice()).GreaterThan(o.GetAllocatedFunds()) {
+		t.Errorf("received notional '%v' expected it to fit within '%v'", f.GetAmount().Mul(f.GetPurchasePrice()), o.GetAllocatedFunds())
+	}
+}
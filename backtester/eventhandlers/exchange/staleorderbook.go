@@ -0,0 +1,29 @@
+package exchange
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/fill"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/order"
+	"github.com/thrasher-corp/gocryptotrader/backtester/funding"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/orderbook"
+)
+
+// isStaleOrderbook returns whether the orderbook was last updated longer
+// than the maximum age before the order's time. Orderbooks without an update
+// time cannot be aged and are not considered stale
+func isStaleOrderbook(ob *orderbook.Base, orderTime time.Time, maximumAge time.Duration) (age time.Duration, isStale bool) {
+	if ob == nil || maximumAge <= 0 || ob.LastUpdated.IsZero() {
+		return 0, false
+	}
+	age = orderTime.Sub(ob.LastUpdated)
+	return age, age > maximumAge
+}
+
+// handleStaleOrderbook rejects an order against an orderbook
+// which has not been updated within the maximum orderbook age
+func handleStaleOrderbook(ob *orderbook.Base, o order.Event, f *fill.Fill, funds funding.IFundReleaser, cs *Settings, age time.Duration) (fill.Event, error) {
+	f.AppendReasonf("Orderbook last updated %v at %v, older than maximum orderbook age of %v, rejected", age, ob.LastUpdated, cs.MaximumOrderbookAge)
+	return f, allocateFundsPostOrder(f, funds, errStaleOrderbook, o.GetAmount(), o.GetAllocatedFunds(), decimal.Zero, decimal.Zero, decimal.Zero, cs)
+}
@@ -0,0 +1,86 @@
+package exchange
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	gctkline "github.com/thrasher-corp/gocryptotrader/exchanges/kline"
+	gctorder "github.com/thrasher-corp/gocryptotrader/exchanges/order"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/orderbook"
+)
+
+func TestIsStaleOrderbook(t *testing.T) {
+	t.Parallel()
+	tt := time.Date(2022, 1, 3, 0, 0, 0, 0, time.UTC)
+	if _, isStale := isStaleOrderbook(nil, tt, time.Minute); isStale {
+		t.Error("expected nil orderbook to not be stale")
+	}
+	ob := &orderbook.Base{}
+	if _, isStale := isStaleOrderbook(ob, tt, time.Minute); isStale {
+		t.Error("expected orderbook without an update time to not be stale")
+	}
+	ob.LastUpdated = tt.Add(-time.Hour)
+	if _, isStale := isStaleOrderbook(ob, tt, 0); isStale {
+		t.Error("expected disabled check to not be stale")
+	}
+	age, isStale := isStaleOrderbook(ob, tt, time.Minute)
+	if !isStale {
+		t.Error("expected orderbook to be stale")
+	}
+	if age != time.Hour {
+		t.Errorf("received '%v' expected '%v'", age, time.Hour)
+	}
+	ob.LastUpdated = tt.Add(-time.Minute)
+	if _, isStale = isStaleOrderbook(ob, tt, time.Minute); isStale {
+		t.Error("expected orderbook at the maximum age to not be stale")
+	}
+	ob.LastUpdated = tt.Add(time.Second)
+	if _, isStale = isStaleOrderbook(ob, tt, time.Minute); isStale {
+		t.Error("expected orderbook updated after the order to not be stale")
+	}
+}
+
+func TestExecuteOrderStaleOrderbook(t *testing.T) {
+	t.Parallel()
+	om, exch := setupOfflineOrderManager(t)
+	p := currency.NewPair(currency.BCH, currency.DOGE)
+	o, d := setupOfflineOrder(t, gctorder.Buy, decimal.NewFromInt(1), decimal.NewFromInt(100),
+		gctkline.Candle{Close: 100, High: 110, Low: 90, Volume: 1000})
+	o.CurrencyPair = p
+	ob := &orderbook.Base{
+		Exchange:        exch.GetName(),
+		Pair:            p,
+		Asset:           asset.Spot,
+		Bids:            orderbook.Items{{Price: 99, Amount: 10}},
+		Asks:            orderbook.Items{{Price: 100, Amount: 10}},
+		LastUpdated:     o.GetTime().Add(-time.Hour),
+		VerifyOrderbook: true,
+	}
+	err := ob.Process()
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	e := Exchange{}
+	e.SetExchangeAssetCurrencySettings(asset.Spot, p, &Settings{
+		Exchange:            exch,
+		Pair:                p,
+		Asset:               asset.Spot,
+		UseRealOrders:       true,
+		MaximumOrderbookAge: time.Minute,
+	})
+	f, err := e.ExecuteOrder(context.Background(), o, d, om, &fakeFund{})
+	if !errors.Is(err, errStaleOrderbook) {
+		t.Fatalf("received '%v' expected '%v'", err, errStaleOrderbook)
+	}
+	if f.GetDirection() != gctorder.CouldNotBuy {
+		t.Errorf("received '%v' expected '%v'", f.GetDirection(), gctorder.CouldNotBuy)
+	}
+	if len(f.GetReasons()) == 0 {
+		t.Error("expected a rejection reason")
+	}
+}
@@ -0,0 +1,349 @@
+package exchange
+
+import (
+	"bytes"
+	"encoding/gob"
+	"fmt"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/order"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	gctorder "github.com/thrasher-corp/gocryptotrader/exchanges/order"
+)
+
+// State is a copy of the exchange's tracking of deferred orders, capital
+// allocations, position entries, losing streaks, traded volumes, sampled
+// latencies and random slippage drawn which executed orders update. It can be
+// gob encoded to be restored in another run
+type State struct {
+	deferredOrders     []*order.Order
+	capitalAllocations map[string]map[asset.Item]map[currency.Pair]*capitalAllocation
+	positionEntries    map[string]map[asset.Item]map[currency.Pair]*positionEntry
+	losingStreaks      map[string]map[asset.Item]map[currency.Pair]*losingStreak
+	tradedVolumes      map[string]map[string]decimal.Decimal
+	latencySamples     []int64
+	slippageDraws      int64
+}
+
+// stateEncoding is the gob representation of State, flattening the
+// tracking stored per exchange, asset and pair
+type stateEncoding struct {
+	DeferredOrders     []*order.Order
+	CapitalAllocations []capitalAllocationEncoding
+	PositionEntries    []positionEntryEncoding
+	LosingStreaks      []losingStreakEncoding
+	TradedVolumes      map[string]map[string]decimal.Decimal
+	LatencySamples     []int64
+	SlippageDraws      int64
+}
+
+// capitalAllocationEncoding is the gob representation of a capitalAllocation
+type capitalAllocationEncoding struct {
+	Exchange string
+	Asset    asset.Item
+	Pair     currency.Pair
+	Capital  decimal.Decimal
+	Amount   decimal.Decimal
+}
+
+// positionEntryEncoding is the gob representation of a positionEntry
+type positionEntryEncoding struct {
+	Exchange      string
+	Asset         asset.Item
+	Pair          currency.Pair
+	Time          time.Time
+	Direction     gctorder.Side
+	Amount        decimal.Decimal
+	EnteredAmount decimal.Decimal
+	Notional      decimal.Decimal
+	Fee           decimal.Decimal
+	SpreadCost    decimal.Decimal
+}
+
+// losingStreakEncoding is the gob representation of a losingStreak
+type losingStreakEncoding struct {
+	Exchange          string
+	Asset             asset.Item
+	Pair              currency.Pair
+	Position          decimal.Decimal
+	CashFlow          decimal.Decimal
+	ConsecutiveLosses int64
+	Halted            bool
+	HaltedUntil       time.Time
+}
+
+// SaveState returns a copy of the exchange's tracking state so that orders
+// executed afterwards can be undone by RestoreState
+func (e *Exchange) SaveState() *State {
+	s := &State{
+		deferredOrders:     copyDeferredOrders(e.deferredOrders),
+		capitalAllocations: copyCapitalAllocations(e.capitalAllocations),
+		positionEntries:    copyPositionEntries(e.positionEntries),
+		losingStreaks:      copyLosingStreaks(e.losingStreaks),
+		tradedVolumes:      copyTradedVolumes(e.tradedVolumes),
+		latencySamples:     make([]int64, len(e.CurrencySettings)),
+	}
+	if e.slippageSource != nil {
+		s.slippageDraws = e.slippageSource.draws
+	}
+	for i := range e.CurrencySettings {
+		if e.CurrencySettings[i].Latency != nil {
+			s.latencySamples[i] = e.CurrencySettings[i].Latency.samples
+		}
+	}
+	return s
+}
+
+// RestoreState replaces the exchange's tracking state with a copy of the
+// state saved by SaveState, discarding any changes made since. Latency
+// distributions and the random slippage source are replayed to the number of
+// values drawn when saved
+func (e *Exchange) RestoreState(s *State) error {
+	if s == nil {
+		return errNilState
+	}
+	if len(s.latencySamples) != len(e.CurrencySettings) {
+		return fmt.Errorf("%w state has %v currency settings, exchange has %v", errStateMismatch, len(s.latencySamples), len(e.CurrencySettings))
+	}
+	e.deferredOrders = copyDeferredOrders(s.deferredOrders)
+	e.capitalAllocations = copyCapitalAllocations(s.capitalAllocations)
+	e.positionEntries = copyPositionEntries(s.positionEntries)
+	e.losingStreaks = copyLosingStreaks(s.losingStreaks)
+	e.tradedVolumes = copyTradedVolumes(s.tradedVolumes)
+	for i := range e.CurrencySettings {
+		e.CurrencySettings[i].Latency.restoreSamples(s.latencySamples[i])
+	}
+	e.restoreSlippageDraws(s.slippageDraws)
+	return nil
+}
+
+// GobEncode conforms type to the gob encoder interface
+func (s *State) GobEncode() ([]byte, error) {
+	enc := stateEncoding{
+		DeferredOrders: s.deferredOrders,
+		TradedVolumes:  s.tradedVolumes,
+		LatencySamples: s.latencySamples,
+		SlippageDraws:  s.slippageDraws,
+	}
+	for exch, assets := range s.capitalAllocations {
+		for a, pairs := range assets {
+			for p, v := range pairs {
+				enc.CapitalAllocations = append(enc.CapitalAllocations, capitalAllocationEncoding{
+					Exchange: exch,
+					Asset:    a,
+					Pair:     p,
+					Capital:  v.capital,
+					Amount:   v.amount,
+				})
+			}
+		}
+	}
+	for exch, assets := range s.positionEntries {
+		for a, pairs := range assets {
+			for p, v := range pairs {
+				enc.PositionEntries = append(enc.PositionEntries, positionEntryEncoding{
+					Exchange:      exch,
+					Asset:         a,
+					Pair:          p,
+					Time:          v.time,
+					Direction:     v.direction,
+					Amount:        v.amount,
+					EnteredAmount: v.enteredAmount,
+					Notional:      v.notional,
+					Fee:           v.fee,
+					SpreadCost:    v.spreadCost,
+				})
+			}
+		}
+	}
+	for exch, assets := range s.losingStreaks {
+		for a, pairs := range assets {
+			for p, v := range pairs {
+				enc.LosingStreaks = append(enc.LosingStreaks, losingStreakEncoding{
+					Exchange:          exch,
+					Asset:             a,
+					Pair:              p,
+					Position:          v.position,
+					CashFlow:          v.cashFlow,
+					ConsecutiveLosses: v.consecutiveLosses,
+					Halted:            v.halted,
+					HaltedUntil:       v.haltedUntil,
+				})
+			}
+		}
+	}
+	var buf bytes.Buffer
+	err := gob.NewEncoder(&buf).Encode(enc)
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// GobDecode conforms type to the gob decoder interface
+func (s *State) GobDecode(d []byte) error {
+	var enc stateEncoding
+	err := gob.NewDecoder(bytes.NewReader(d)).Decode(&enc)
+	if err != nil {
+		return err
+	}
+	*s = State{
+		deferredOrders:     enc.DeferredOrders,
+		capitalAllocations: make(map[string]map[asset.Item]map[currency.Pair]*capitalAllocation),
+		positionEntries:    make(map[string]map[asset.Item]map[currency.Pair]*positionEntry),
+		losingStreaks:      make(map[string]map[asset.Item]map[currency.Pair]*losingStreak),
+		tradedVolumes:      enc.TradedVolumes,
+		latencySamples:     enc.LatencySamples,
+		slippageDraws:      enc.SlippageDraws,
+	}
+	for i := range enc.CapitalAllocations {
+		v := &enc.CapitalAllocations[i]
+		if s.capitalAllocations[v.Exchange] == nil {
+			s.capitalAllocations[v.Exchange] = make(map[asset.Item]map[currency.Pair]*capitalAllocation)
+		}
+		if s.capitalAllocations[v.Exchange][v.Asset] == nil {
+			s.capitalAllocations[v.Exchange][v.Asset] = make(map[currency.Pair]*capitalAllocation)
+		}
+		s.capitalAllocations[v.Exchange][v.Asset][v.Pair] = &capitalAllocation{
+			capital: v.Capital,
+			amount:  v.Amount,
+		}
+	}
+	for i := range enc.PositionEntries {
+		v := &enc.PositionEntries[i]
+		if s.positionEntries[v.Exchange] == nil {
+			s.positionEntries[v.Exchange] = make(map[asset.Item]map[currency.Pair]*positionEntry)
+		}
+		if s.positionEntries[v.Exchange][v.Asset] == nil {
+			s.positionEntries[v.Exchange][v.Asset] = make(map[currency.Pair]*positionEntry)
+		}
+		s.positionEntries[v.Exchange][v.Asset][v.Pair] = &positionEntry{
+			time:          v.Time,
+			direction:     v.Direction,
+			amount:        v.Amount,
+			enteredAmount: v.EnteredAmount,
+			notional:      v.Notional,
+			fee:           v.Fee,
+			spreadCost:    v.SpreadCost,
+		}
+	}
+	for i := range enc.LosingStreaks {
+		v := &enc.LosingStreaks[i]
+		if s.losingStreaks[v.Exchange] == nil {
+			s.losingStreaks[v.Exchange] = make(map[asset.Item]map[currency.Pair]*losingStreak)
+		}
+		if s.losingStreaks[v.Exchange][v.Asset] == nil {
+			s.losingStreaks[v.Exchange][v.Asset] = make(map[currency.Pair]*losingStreak)
+		}
+		s.losingStreaks[v.Exchange][v.Asset][v.Pair] = &losingStreak{
+			position:          v.Position,
+			cashFlow:          v.CashFlow,
+			consecutiveLosses: v.ConsecutiveLosses,
+			halted:            v.Halted,
+			haltedUntil:       v.HaltedUntil,
+		}
+	}
+	return nil
+}
+
+// copyDeferredOrders copies deferred orders so that changes made to them
+// while deferred do not alter the copy
+func copyDeferredOrders(ords []*order.Order) []*order.Order {
+	if ords == nil {
+		return nil
+	}
+	resp := make([]*order.Order, len(ords))
+	for i := range ords {
+		cpy := *ords[i]
+		if ords[i].Base != nil {
+			b := *ords[i].Base
+			b.Reasons = append([]string(nil), ords[i].Reasons...)
+			cpy.Base = &b
+		}
+		resp[i] = &cpy
+	}
+	return resp
+}
+
+// copyCapitalAllocations copies the capital allocations stored per exchange, asset and pair
+func copyCapitalAllocations(m map[string]map[asset.Item]map[currency.Pair]*capitalAllocation) map[string]map[asset.Item]map[currency.Pair]*capitalAllocation {
+	if m == nil {
+		return nil
+	}
+	resp := make(map[string]map[asset.Item]map[currency.Pair]*capitalAllocation, len(m))
+	for exch, assets := range m {
+		resp[exch] = make(map[asset.Item]map[currency.Pair]*capitalAllocation, len(assets))
+		for a, pairs := range assets {
+			resp[exch][a] = make(map[currency.Pair]*capitalAllocation, len(pairs))
+			for p, v := range pairs {
+				if v == nil {
+					continue
+				}
+				cpy := *v
+				resp[exch][a][p] = &cpy
+			}
+		}
+	}
+	return resp
+}
+
+// copyPositionEntries copies the position entries stored per exchange, asset and pair
+func copyPositionEntries(m map[string]map[asset.Item]map[currency.Pair]*positionEntry) map[string]map[asset.Item]map[currency.Pair]*positionEntry {
+	if m == nil {
+		return nil
+	}
+	resp := make(map[string]map[asset.Item]map[currency.Pair]*positionEntry, len(m))
+	for exch, assets := range m {
+		resp[exch] = make(map[asset.Item]map[currency.Pair]*positionEntry, len(assets))
+		for a, pairs := range assets {
+			resp[exch][a] = make(map[currency.Pair]*positionEntry, len(pairs))
+			for p, v := range pairs {
+				if v == nil {
+					continue
+				}
+				cpy := *v
+				resp[exch][a][p] = &cpy
+			}
+		}
+	}
+	return resp
+}
+
+// copyLosingStreaks copies the losing streaks stored per exchange, asset and pair
+func copyLosingStreaks(m map[string]map[asset.Item]map[currency.Pair]*losingStreak) map[string]map[asset.Item]map[currency.Pair]*losingStreak {
+	if m == nil {
+		return nil
+	}
+	resp := make(map[string]map[asset.Item]map[currency.Pair]*losingStreak, len(m))
+	for exch, assets := range m {
+		resp[exch] = make(map[asset.Item]map[currency.Pair]*losingStreak, len(assets))
+		for a, pairs := range assets {
+			resp[exch][a] = make(map[currency.Pair]*losingStreak, len(pairs))
+			for p, v := range pairs {
+				if v == nil {
+					continue
+				}
+				cpy := *v
+				resp[exch][a][p] = &cpy
+			}
+		}
+	}
+	return resp
+}
+
+// copyTradedVolumes copies the traded volumes stored per exchange and account
+func copyTradedVolumes(m map[string]map[string]decimal.Decimal) map[string]map[string]decimal.Decimal {
+	if m == nil {
+		return nil
+	}
+	resp := make(map[string]map[string]decimal.Decimal, len(m))
+	for exch, accounts := range m {
+		resp[exch] = make(map[string]decimal.Decimal, len(accounts))
+		for account, volume := range accounts {
+			resp[exch][account] = volume
+		}
+	}
+	return resp
+}
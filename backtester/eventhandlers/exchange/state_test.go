@@ -0,0 +1,143 @@
+package exchange
+
+import (
+	"bytes"
+	"context"
+	"encoding/gob"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventhandlers/exchange/slippage"
+	gctkline "github.com/thrasher-corp/gocryptotrader/exchanges/kline"
+	gctorder "github.com/thrasher-corp/gocryptotrader/exchanges/order"
+)
+
+func TestSaveRestoreState(t *testing.T) {
+	t.Parallel()
+	om, exch := setupOfflineOrderManager(t)
+	o, d := setupOfflineOrder(t, gctorder.Buy, decimal.NewFromInt(1), decimal.NewFromInt(100),
+		gctkline.Candle{Open: 100, Close: 100, High: 100, Low: 100, Volume: 100000})
+	e := Exchange{CurrencySettings: []Settings{{
+		Exchange:               exch,
+		Pair:                   o.Pair(),
+		Asset:                  o.GetAssetType(),
+		MinimumSlippageRate:    decimal.NewFromInt(100),
+		MaximumSlippageRate:    decimal.NewFromInt(100),
+		SignalToFillConvention: SameCandleClose,
+	}}}
+	_, err := e.ExecuteOrder(context.Background(), o, d, om, &fakeFund{})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	s := e.SaveState()
+
+	_, err = e.ExecuteOrder(context.Background(), o, d, om, &fakeFund{})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	if volume := e.getTradedVolume(testExchange, ""); !volume.Equal(decimal.NewFromInt(200)) {
+		t.Errorf("received '%v' expected '%v'", volume, 200)
+	}
+	pe := e.getPositionEntry(testExchange, o.GetAssetType(), o.Pair())
+	if pe == nil || !pe.amount.Equal(decimal.NewFromInt(2)) {
+		t.Fatalf("received '%v' expected an entry of '%v'", pe, 2)
+	}
+
+	err = e.RestoreState(s)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	if volume := e.getTradedVolume(testExchange, ""); !volume.Equal(decimal.NewFromInt(100)) {
+		t.Errorf("received '%v' expected '%v'", volume, 100)
+	}
+	pe = e.getPositionEntry(testExchange, o.GetAssetType(), o.Pair())
+	if pe == nil || !pe.amount.Equal(decimal.NewFromInt(1)) {
+		t.Errorf("received '%v' expected an entry of '%v'", pe, 1)
+	}
+	// changes made after restoring do not alter the saved state
+	pe.amount = decimal.NewFromInt(1337)
+	err = e.RestoreState(s)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	if pe = e.getPositionEntry(testExchange, o.GetAssetType(), o.Pair()); !pe.amount.Equal(decimal.NewFromInt(1)) {
+		t.Errorf("received '%v' expected '%v'", pe.amount, 1)
+	}
+
+	// the state restores the same after being gob encoded
+	var buf bytes.Buffer
+	err = gob.NewEncoder(&buf).Encode(s)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	decoded := &State{}
+	err = gob.NewDecoder(&buf).Decode(decoded)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	restored := Exchange{CurrencySettings: e.CurrencySettings}
+	err = restored.RestoreState(decoded)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	if volume := restored.getTradedVolume(testExchange, ""); !volume.Equal(decimal.NewFromInt(100)) {
+		t.Errorf("received '%v' expected '%v'", volume, 100)
+	}
+	pe = restored.getPositionEntry(testExchange, o.GetAssetType(), o.Pair())
+	if pe == nil || !pe.amount.Equal(decimal.NewFromInt(1)) || !pe.time.Equal(e.getPositionEntry(testExchange, o.GetAssetType(), o.Pair()).time) {
+		t.Errorf("received '%v' expected an entry of '%v'", pe, 1)
+	}
+
+	err = e.RestoreState(nil)
+	if !errors.Is(err, errNilState) {
+		t.Errorf("received '%v' expected '%v'", err, errNilState)
+	}
+	err = e.RestoreState(&State{})
+	if !errors.Is(err, errStateMismatch) {
+		t.Errorf("received '%v' expected '%v'", err, errStateMismatch)
+	}
+}
+
+func TestRestoreStateLatency(t *testing.T) {
+	t.Parallel()
+	l := &LatencyDistribution{Mean: time.Second, StandardDeviation: time.Second, Seed: 1337}
+	e := Exchange{CurrencySettings: []Settings{{Latency: l}}}
+	l.Sample()
+	s := e.SaveState()
+	expected := []time.Duration{l.Sample(), l.Sample()}
+
+	err := e.RestoreState(s)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	for i := range expected {
+		if latency := l.Sample(); latency != expected[i] {
+			t.Errorf("received '%v' expected '%v'", latency, expected[i])
+		}
+	}
+}
+
+func TestRestoreStateSlippage(t *testing.T) {
+	t.Parallel()
+	e := Exchange{}
+	minimum, maximum := decimal.NewFromInt(80), decimal.NewFromInt(100)
+	slippage.EstimateSlippagePercentageFromRand(e.getSlippageRand(), minimum, maximum)
+	s := e.SaveState()
+	expected := []decimal.Decimal{
+		slippage.EstimateSlippagePercentageFromRand(e.getSlippageRand(), minimum, maximum),
+		slippage.EstimateSlippagePercentageFromRand(e.getSlippageRand(), minimum, maximum),
+	}
+
+	restored := Exchange{}
+	err := restored.RestoreState(s)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	for i := range expected {
+		if rate := slippage.EstimateSlippagePercentageFromRand(restored.getSlippageRand(), minimum, maximum); !rate.Equal(expected[i]) {
+			t.Errorf("received '%v' expected '%v'", rate, expected[i])
+		}
+	}
+}
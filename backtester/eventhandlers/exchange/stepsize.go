@@ -0,0 +1,30 @@
+package exchange
+
+import (
+	"errors"
+
+	"github.com/shopspring/decimal"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/fill"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/order"
+	"github.com/thrasher-corp/gocryptotrader/backtester/funding"
+	gctorder "github.com/thrasher-corp/gocryptotrader/exchanges/order"
+)
+
+// roundsToZero returns whether conforming a positive amount to an exchange
+// constraint leaves nothing to trade
+func roundsToZero(amount, conformedAmount decimal.Decimal) bool {
+	return amount.IsPositive() && !conformedAmount.IsPositive()
+}
+
+// handleAmountRoundedToZero abandons an order whose amount rounds down to
+// zero at the exchange's step size or amount precision, releasing its funds
+// and explaining the constraint rather than placing an empty order
+func handleAmountRoundedToZero(o order.Event, f *fill.Fill, funds funding.IFundReleaser, cs *Settings, amount decimal.Decimal, constraint string) (fill.Event, error) {
+	f.AppendReasonf("Order size %v rounds to zero at %v, order not placed", amount, constraint)
+	err := allocateFundsPostOrder(f, funds, errAmountBelowStepSize, o.GetAmount(), o.GetAllocatedFunds(), decimal.Zero, decimal.Zero, decimal.Zero, cs)
+	if err != nil && !errors.Is(err, errAmountBelowStepSize) {
+		return f, err
+	}
+	f.SetDirection(gctorder.DoNothing)
+	return f, nil
+}
@@ -0,0 +1,67 @@
+package exchange
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	gctkline "github.com/thrasher-corp/gocryptotrader/exchanges/kline"
+	gctorder "github.com/thrasher-corp/gocryptotrader/exchanges/order"
+)
+
+func TestRoundsToZero(t *testing.T) {
+	t.Parallel()
+	if !roundsToZero(decimal.NewFromFloat(0.5), decimal.Zero) {
+		t.Error("expected positive amount conformed to zero to round to zero")
+	}
+	if roundsToZero(decimal.NewFromFloat(1.5), decimal.NewFromInt(1)) {
+		t.Error("expected amount conformed to a positive step to be tradeable")
+	}
+	if roundsToZero(decimal.Zero, decimal.Zero) {
+		t.Error("expected an empty amount to not be treated as rounded")
+	}
+}
+
+func TestExecuteOrderAmountBelowStepSize(t *testing.T) {
+	t.Parallel()
+	om, exch := setupOfflineOrderManager(t)
+	cs := Settings{
+		Exchange:               exch,
+		MinimumSlippageRate:    decimal.NewFromInt(100),
+		MaximumSlippageRate:    decimal.NewFromInt(100),
+		CanUseExchangeLimits:   true,
+		Limits:                 gctorder.MinMaxLevel{AmountStepIncrementSize: 1},
+		SignalToFillConvention: SameCandleClose,
+	}
+	// a small allocation of a high priced asset is worth less than one contract
+	o, d := setupOfflineOrder(t, gctorder.Buy, decimal.NewFromFloat(0.99), decimal.NewFromInt(1000),
+		gctkline.Candle{Close: 1000, High: 1100, Low: 900, Volume: 100000})
+	cs.Pair = o.Pair()
+	cs.Asset = o.GetAssetType()
+	e := Exchange{CurrencySettings: []Settings{cs}}
+	f, err := e.ExecuteOrder(context.Background(), o, d, om, &fakeFund{})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	if f.GetDirection() != gctorder.DoNothing {
+		t.Errorf("received '%v' expected '%v'", f.GetDirection(), gctorder.DoNothing)
+	}
+	if reasons := f.GetReasons(); len(reasons) == 0 || reasons[len(reasons)-1] != "Order size 0.99 rounds to zero at exchange step size 1, order not placed" {
+		t.Errorf("received '%v' expected a step size reason", reasons)
+	}
+
+	// exactly one step remains tradeable
+	o, d = setupOfflineOrder(t, gctorder.Buy, decimal.NewFromInt(1), decimal.NewFromInt(1000),
+		gctkline.Candle{Close: 1000, High: 1100, Low: 900, Volume: 100000})
+	f, err = e.ExecuteOrder(context.Background(), o, d, om, &fakeFund{})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	if f.GetDirection() != gctorder.Buy {
+		t.Errorf("received '%v' expected '%v'", f.GetDirection(), gctorder.Buy)
+	}
+	if !f.GetAmount().Equal(decimal.NewFromInt(1)) {
+		t.Errorf("received '%v' expected '%v'", f.GetAmount(), 1)
+	}
+}
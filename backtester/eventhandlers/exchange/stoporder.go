@@ -0,0 +1,74 @@
+package exchange
+
+import (
+	"github.com/shopspring/decimal"
+	"github.com/thrasher-corp/gocryptotrader/backtester/common"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/fill"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/order"
+	gctorder "github.com/thrasher-corp/gocryptotrader/exchanges/order"
+)
+
+// isStopTriggered returns whether a candle's range breaches the stop price.
+// Sells, which stop-loss a long position, are triggered when the low falls to
+// the stop price and buys, which stop-loss a short position, when the high
+// rises to it
+func isStopTriggered(direction gctorder.Side, stopPrice, high, low decimal.Decimal) bool {
+	switch direction {
+	case gctorder.Sell, gctorder.Ask, gctorder.Short, gctorder.ClosePosition:
+		return low.LessThanOrEqual(stopPrice)
+	case gctorder.Buy, gctorder.Bid, gctorder.Long:
+		return high.GreaterThanOrEqual(stopPrice)
+	}
+	return false
+}
+
+// stopFillPrice returns the price a triggered stop order executes at before
+// slippage, being the stop price unless the price has already gapped past it,
+// in which case the worse price is used
+func stopFillPrice(direction gctorder.Side, stopPrice, price decimal.Decimal) decimal.Decimal {
+	switch direction {
+	case gctorder.Sell, gctorder.Ask, gctorder.Short, gctorder.ClosePosition:
+		return decimal.Min(price, stopPrice)
+	case gctorder.Buy, gctorder.Bid, gctorder.Long:
+		return decimal.Max(price, stopPrice)
+	}
+	return price
+}
+
+// holdDormantStop leaves a stop order dormant until a candle's range breaches
+// its stop price, deferring it to be evaluated against the next candle. The
+// candle the order is placed on traded before the order existed, so its range
+// is never used to trigger the order and only stop orders already breached at
+// the price they were placed at trigger on it. The order keeps its reserved
+// funds until it is triggered. Orders without a stop price and triggered stop
+// orders are filled without deferral
+func (e *Exchange) holdDormantStop(o order.Event, f *fill.Fill, candle common.DataEventHandler) bool {
+	stopPrice := o.GetStopPrice()
+	if !stopPrice.IsPositive() || candle == nil {
+		return false
+	}
+	isPlacement := !o.IsDormantStop()
+	switch {
+	case isPlacement && isStopTriggered(o.GetDirection(), stopPrice, o.GetClosePrice(), o.GetClosePrice()):
+		f.AppendReasonf("Stop price %v breached at placement price %v", stopPrice, o.GetClosePrice())
+		return false
+	case !isPlacement && isStopTriggered(o.GetDirection(), stopPrice, candle.GetHighPrice(), candle.GetLowPrice()):
+		f.AppendReasonf("Stop price %v triggered within candle low %v high %v", stopPrice, candle.GetLowPrice(), candle.GetHighPrice())
+		return false
+	}
+	ord := e.deferOrder(o)
+	if ord == nil {
+		return false
+	}
+	ord.DormantStop = true
+	// the untriggered candle's reasons are recorded against its fill
+	ord.Reasons = nil
+	f.DormantStop = true
+	f.SetDirection(gctorder.DoNothing)
+	if isPlacement {
+		f.AppendReasonf("Stop price %v not breached at placement price %v, order left dormant to be evaluated from the next candle", stopPrice, o.GetClosePrice())
+	} else {
+		f.AppendReasonf("Stop price %v not triggered within candle low %v high %v, order left dormant", stopPrice, candle.GetLowPrice(), candle.GetHighPrice())
+	}
+	return true
+}
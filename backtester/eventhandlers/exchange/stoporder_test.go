@@ -0,0 +1,231 @@
+package exchange
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/event"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/kline"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/order"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	gctkline "github.com/thrasher-corp/gocryptotrader/exchanges/kline"
+	gctorder "github.com/thrasher-corp/gocryptotrader/exchanges/order"
+)
+
+func TestIsStopTriggered(t *testing.T) {
+	t.Parallel()
+	high, low := decimal.NewFromInt(110), decimal.NewFromInt(90)
+	for _, tc := range []struct {
+		direction   gctorder.Side
+		stopPrice   decimal.Decimal
+		isTriggered bool
+	}{
+		{gctorder.Sell, decimal.NewFromInt(95), true},
+		{gctorder.Sell, decimal.NewFromInt(90), true},
+		{gctorder.Short, decimal.NewFromInt(89), false},
+		{gctorder.Buy, decimal.NewFromInt(105), true},
+		{gctorder.Buy, decimal.NewFromInt(110), true},
+		{gctorder.Long, decimal.NewFromInt(111), false},
+		{gctorder.UnknownSide, decimal.NewFromInt(100), false},
+	} {
+		if isTriggered := isStopTriggered(tc.direction, tc.stopPrice, high, low); isTriggered != tc.isTriggered {
+			t.Errorf("%v stop %v received '%v' expected '%v'", tc.direction, tc.stopPrice, isTriggered, tc.isTriggered)
+		}
+	}
+}
+
+func TestStopFillPrice(t *testing.T) {
+	t.Parallel()
+	stop := decimal.NewFromInt(100)
+	for _, tc := range []struct {
+		direction gctorder.Side
+		price     decimal.Decimal
+		expected  decimal.Decimal
+	}{
+		{gctorder.Sell, decimal.NewFromInt(105), stop},
+		{gctorder.Sell, decimal.NewFromInt(95), decimal.NewFromInt(95)},
+		{gctorder.Buy, decimal.NewFromInt(95), stop},
+		{gctorder.Buy, decimal.NewFromInt(105), decimal.NewFromInt(105)},
+		{gctorder.UnknownSide, decimal.NewFromInt(105), decimal.NewFromInt(105)},
+	} {
+		if price := stopFillPrice(tc.direction, stop, tc.price); !price.Equal(tc.expected) {
+			t.Errorf("%v price %v received '%v' expected '%v'", tc.direction, tc.price, price, tc.expected)
+		}
+	}
+}
+
+// releaseNextCandle releases the exchange's deferred orders against the candle
+// following the order's, opening at the provided price
+func releaseNextCandle(t *testing.T, e *Exchange, o *order.Order, open decimal.Decimal) []order.Event {
+	t.Helper()
+	ev := &kline.Kline{
+		Base: &event.Base{
+			Offset:       o.GetOffset() + 1,
+			Exchange:     o.GetExchange(),
+			Time:         o.GetTime().Add(o.GetInterval().Duration()),
+			Interval:     o.GetInterval(),
+			CurrencyPair: o.Pair(),
+			AssetType:    o.GetAssetType(),
+		},
+		Open:  open,
+		Close: open,
+	}
+	released, err := e.ReleaseDeferredOrders(ev)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	if len(released) != 1 {
+		t.Fatalf("received '%v' expected '%v'", len(released), 1)
+	}
+	if !released[0].GetClosePrice().Equal(open) {
+		t.Errorf("received '%v' expected '%v'", released[0].GetClosePrice(), open)
+	}
+	return released
+}
+
+func TestExecuteOrderStop(t *testing.T) {
+	t.Parallel()
+	om, exch := setupOfflineOrderManager(t)
+	first := gctkline.Candle{Open: 100, Close: 100, High: 104, Low: 96, Volume: 1000}
+	o, d := setupOfflineOrder(t, gctorder.Sell, decimal.NewFromInt(1), decimal.NewFromInt(100), first)
+	o.StopPrice = decimal.NewFromInt(95)
+	cs := Settings{
+		Exchange:               exch,
+		Pair:                   o.Pair(),
+		Asset:                  o.GetAssetType(),
+		MinimumSlippageRate:    decimal.NewFromInt(100),
+		MaximumSlippageRate:    decimal.NewFromInt(100),
+		SignalToFillConvention: SameCandleClose,
+	}
+	e := Exchange{CurrencySettings: []Settings{cs}}
+	f, err := e.ExecuteOrder(context.Background(), o, d, om, &fakeFund{})
+	if !errors.Is(err, ErrCannotTransact) {
+		t.Fatalf("received '%v' expected '%v'", err, ErrCannotTransact)
+	}
+	if f.GetDirection() != gctorder.DoNothing || !f.IsDormantStop() {
+		t.Errorf("received '%v' '%v' expected '%v' '%v'", f.GetDirection(), f.IsDormantStop(), gctorder.DoNothing, true)
+	}
+	if len(e.deferredOrders) != 1 || !e.deferredOrders[0].IsDormantStop() {
+		t.Fatalf("expected a single dormant stop order, received '%v'", e.deferredOrders)
+	}
+
+	// the next candle gaps down through the stop, executing at its open
+	released := releaseNextCandle(t, &e, o, decimal.NewFromInt(92))
+	_, d = setupOfflineOrder(t, gctorder.Sell, decimal.NewFromInt(1), decimal.NewFromInt(100),
+		first, gctkline.Candle{Open: 92, Close: 93, High: 94, Low: 90, Volume: 1000})
+	f, err = e.ExecuteOrder(context.Background(), released[0], d, om, &fakeFund{})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	if f.GetDirection() != gctorder.Sell || f.IsDormantStop() || !f.GetStopPrice().Equal(o.StopPrice) {
+		t.Errorf("received '%v' '%v' '%v' expected '%v' '%v' '%v'", f.GetDirection(), f.IsDormantStop(), f.GetStopPrice(), gctorder.Sell, false, o.StopPrice)
+	}
+	if !f.GetPurchasePrice().Equal(decimal.NewFromInt(92)) {
+		t.Errorf("received '%v' expected gap down to execute worse than the stop at '%v'", f.GetPurchasePrice(), 92)
+	}
+	if f.GetOrder().Type != gctorder.Market {
+		t.Errorf("received '%v' expected triggered stop placed at '%v'", f.GetOrder().Type, gctorder.Market)
+	}
+
+	// the placement candle traded through the stop before the order existed
+	o, d = setupOfflineOrder(t, gctorder.Sell, decimal.NewFromInt(1), decimal.NewFromInt(100),
+		gctkline.Candle{Open: 100, Close: 100, High: 104, Low: 94, Volume: 1000})
+	o.StopPrice = decimal.NewFromInt(95)
+	f, err = e.ExecuteOrder(context.Background(), o, d, om, &fakeFund{})
+	if !errors.Is(err, ErrCannotTransact) {
+		t.Fatalf("received '%v' expected '%v'", err, ErrCannotTransact)
+	}
+	if f.GetDirection() != gctorder.DoNothing || !f.IsDormantStop() {
+		t.Errorf("received '%v' '%v' expected '%v' '%v'", f.GetDirection(), f.IsDormantStop(), gctorder.DoNothing, true)
+	}
+	e.deferredOrders = nil
+
+	// a dormant stop triggered within the candle executes at the stop price
+	o.DormantStop = true
+	f, err = e.ExecuteOrder(context.Background(), o, d, om, &fakeFund{})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	if !f.GetPurchasePrice().Equal(o.StopPrice) {
+		t.Errorf("received '%v' expected '%v'", f.GetPurchasePrice(), o.StopPrice)
+	}
+
+	// a stop already breached at the placement price triggers immediately
+	o, d = setupOfflineOrder(t, gctorder.Sell, decimal.NewFromInt(1), decimal.NewFromInt(100),
+		gctkline.Candle{Open: 100, Close: 100, High: 104, Low: 96, Volume: 1000})
+	o.StopPrice = decimal.NewFromInt(101)
+	f, err = e.ExecuteOrder(context.Background(), o, d, om, &fakeFund{})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	if f.GetDirection() != gctorder.Sell || f.IsDormantStop() || !f.GetPurchasePrice().Equal(decimal.NewFromInt(100)) {
+		t.Errorf("received '%v' '%v' '%v' expected '%v' '%v' '%v'", f.GetDirection(), f.IsDormantStop(), f.GetPurchasePrice(), gctorder.Sell, false, 100)
+	}
+
+	o.StopPrice = decimal.NewFromInt(-1)
+	_, err = e.ExecuteOrder(context.Background(), o, d, om, &fakeFund{})
+	if !errors.Is(err, errInvalidStopPrice) {
+		t.Errorf("received '%v' expected '%v'", err, errInvalidStopPrice)
+	}
+	o.StopPrice = decimal.NewFromInt(95)
+	o.LimitPrice = decimal.NewFromInt(94)
+	_, err = e.ExecuteOrder(context.Background(), o, d, om, &fakeFund{})
+	if !errors.Is(err, errInvalidStopPrice) {
+		t.Errorf("received '%v' expected '%v'", err, errInvalidStopPrice)
+	}
+	o.LimitPrice = decimal.Zero
+	cs.UseRealOrders = true
+	e = Exchange{CurrencySettings: []Settings{cs}}
+	_, err = e.ExecuteOrder(context.Background(), o, d, om, &fakeFund{})
+	if !errors.Is(err, errInvalidStopPrice) {
+		t.Errorf("received '%v' expected '%v'", err, errInvalidStopPrice)
+	}
+}
+
+func TestExecuteOrderStopFutures(t *testing.T) {
+	t.Parallel()
+	om, exch := setupOfflineOrderManager(t)
+	first := gctkline.Candle{Open: 100, Close: 100, High: 104, Low: 96, Volume: 1000}
+	for _, tc := range []struct {
+		direction gctorder.Side
+		stopPrice decimal.Decimal
+		gap       gctkline.Candle
+	}{
+		// a long position's stop-loss gapping down
+		{gctorder.Short, decimal.NewFromInt(95), gctkline.Candle{Open: 92, Close: 93, High: 94, Low: 90, Volume: 1000}},
+		// a short position's stop-loss gapping up
+		{gctorder.Long, decimal.NewFromInt(105), gctkline.Candle{Open: 108, Close: 107, High: 110, Low: 106, Volume: 1000}},
+	} {
+		o, d := setupOfflineOrder(t, tc.direction, decimal.NewFromInt(1), decimal.NewFromInt(100), first)
+		o.AssetType = asset.Futures
+		o.StopPrice = tc.stopPrice
+		e := Exchange{CurrencySettings: []Settings{{
+			Exchange:               exch,
+			Pair:                   o.Pair(),
+			Asset:                  asset.Futures,
+			MinimumSlippageRate:    decimal.NewFromInt(100),
+			MaximumSlippageRate:    decimal.NewFromInt(100),
+			SignalToFillConvention: SameCandleClose,
+		}}}
+		f, err := e.ExecuteOrder(context.Background(), o, d, om, &fakeFund{})
+		if !errors.Is(err, ErrCannotTransact) {
+			t.Fatalf("%v received '%v' expected '%v'", tc.direction, err, ErrCannotTransact)
+		}
+		if !f.IsDormantStop() {
+			t.Errorf("%v received '%v' expected '%v'", tc.direction, f.IsDormantStop(), true)
+		}
+
+		open := decimal.NewFromFloat(tc.gap.Open)
+		released := releaseNextCandle(t, &e, o, open)
+		_, d = setupOfflineOrder(t, tc.direction, decimal.NewFromInt(1), decimal.NewFromInt(100), first, tc.gap)
+		f, err = e.ExecuteOrder(context.Background(), released[0], d, om, &fakeFund{})
+		if !errors.Is(err, nil) {
+			t.Fatalf("%v received '%v' expected '%v'", tc.direction, err, nil)
+		}
+		if f.GetDirection() != tc.direction || !f.GetPurchasePrice().Equal(open) {
+			t.Errorf("%v received '%v' '%v' expected gap to execute worse than the stop at '%v'", tc.direction, f.GetDirection(), f.GetPurchasePrice(), open)
+		}
+	}
+}
@@ -0,0 +1,139 @@
+package exchange
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/backtester/common"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/engine"
+	gctexchange "github.com/thrasher-corp/gocryptotrader/exchanges"
+	gctorder "github.com/thrasher-corp/gocryptotrader/exchanges/order"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/request"
+	"github.com/thrasher-corp/gocryptotrader/log"
+)
+
+// isRetryableSubmissionError returns whether an order submission error is
+// recoverable, being a timeout or an HTTP status code for rate limiting or an
+// unavailable service. Permanent rejections such as insufficient balance are
+// not retried as submitting the same order again cannot succeed
+func isRetryableSubmissionError(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+	var statusErr *request.HTTPStatusError
+	if !errors.As(err, &statusErr) {
+		return false
+	}
+	switch statusErr.StatusCode {
+	case http.StatusTooManyRequests,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// submitWithRetries submits an order, retrying recoverable errors up to the
+// retry limit with an exponential backoff. Before each retry, findSubmitted
+// checks whether the failed attempt reached the exchange regardless, so the
+// order is never submitted twice. The backoff is abandoned as soon as the
+// context is done, such as when the backtester is shut down, so that the
+// event loop is not held waiting on a retry which will never be made. The
+// failed attempt is still looked up before returning, using a fresh context
+// as the original is done, so an order which reached the exchange is tracked.
+// Returns the order's ID and how many retries were made
+func submitWithRetries(ctx context.Context, retries int, backoff time.Duration, submit func(context.Context) (string, error), findSubmitted func(context.Context) (string, bool)) (orderID string, retried int64, err error) {
+	wait := backoff
+	for {
+		orderID, err = submit(ctx)
+		if err == nil || int(retried) >= retries || !isRetryableSubmissionError(err) {
+			return orderID, retried, err
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			if id, ok := findSubmitted(context.Background()); ok {
+				return id, retried, nil
+			}
+			return orderID, retried, err
+		case <-timer.C:
+		}
+		if id, ok := findSubmitted(ctx); ok {
+			return id, retried, nil
+		}
+		retried++
+		wait *= 2
+	}
+}
+
+// submissionLookbackMargin is subtracted from the submission time when
+// searching the exchange's order history to allow for clock skew
+const submissionLookbackMargin = time.Minute
+
+// findSubmittedOrder returns the ID of an order with the submission's client
+// order ID, being one which reached the exchange despite its submission
+// returning an error. The order manager is checked first, then the exchange's
+// active orders and its order history since the submission, as an order whose
+// submission failed in transit is only known to the exchange. Orders found on
+// the exchange are added to the order manager so that their fill is tracked
+func findSubmittedOrder(ctx context.Context, exch gctexchange.IBotExchange, orderManager *engine.OrderManager, submit *gctorder.Submit, submittedAt time.Time) (string, bool) {
+	if orderManager == nil || submit == nil || submit.ClientOrderID == "" {
+		return "", false
+	}
+	ords, err := orderManager.GetOrdersFiltered(&gctorder.Filter{
+		Exchange:      submit.Exchange,
+		AssetType:     submit.AssetType,
+		Pair:          submit.Pair,
+		ClientOrderID: submit.ClientOrderID,
+	})
+	if err == nil && len(ords) > 0 {
+		return ords[0].OrderID, true
+	}
+	if exch == nil {
+		return "", false
+	}
+	req := &gctorder.GetOrdersRequest{
+		Type:      gctorder.AnyType,
+		Side:      gctorder.AnySide,
+		StartTime: submittedAt.Add(-submissionLookbackMargin),
+		EndTime:   time.Now(),
+		Pairs:     currency.Pairs{submit.Pair},
+		AssetType: submit.AssetType,
+	}
+	for _, getOrders := range []func(context.Context, *gctorder.GetOrdersRequest) ([]gctorder.Detail, error){
+		exch.GetActiveOrders,
+		exch.GetOrderHistory,
+	} {
+		ords, err = getOrders(ctx, req)
+		if err != nil {
+			log.Errorf(common.Backtester, "Unable to search %v orders for client order ID %v %v", submit.Exchange, submit.ClientOrderID, err)
+			continue
+		}
+		for i := range ords {
+			if ords[i].ClientOrderID != submit.ClientOrderID {
+				continue
+			}
+			if ords[i].Exchange == "" {
+				ords[i].Exchange = submit.Exchange
+			}
+			if _, err = orderManager.UpsertOrder(&ords[i]); err != nil {
+				log.Errorf(common.Backtester, "Unable to track %v order %v found by client order ID %v %v", submit.Exchange, ords[i].OrderID, submit.ClientOrderID, err)
+			}
+			return ords[i].OrderID, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,192 @@
+package exchange
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	gctexchange "github.com/thrasher-corp/gocryptotrader/exchanges"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	gctorder "github.com/thrasher-corp/gocryptotrader/exchanges/order"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/request"
+)
+
+var (
+	errTestRateLimited        error = &request.HTTPStatusError{Name: testExchange, StatusCode: http.StatusTooManyRequests}
+	errTestInsufficientFunds        = errors.New("insufficient balance")
+	errTestUnsuccessfulStatus error = &request.HTTPStatusError{Name: testExchange, StatusCode: http.StatusServiceUnavailable}
+)
+
+func TestIsRetryableSubmissionError(t *testing.T) {
+	t.Parallel()
+	for _, err := range []error{
+		errTestRateLimited,
+		errTestUnsuccessfulStatus,
+		fmt.Errorf("order manager: %w", errTestRateLimited),
+		context.DeadlineExceeded,
+		fmt.Errorf("order manager: %w", context.DeadlineExceeded),
+	} {
+		if !isRetryableSubmissionError(err) {
+			t.Errorf("expected '%v' to be retryable", err)
+		}
+	}
+	for _, err := range []error{
+		nil,
+		errTestInsufficientFunds,
+		context.Canceled,
+		// only the status code is trusted rather than the error's wording
+		errors.New("rate limit exceeded"),
+		&request.HTTPStatusError{Name: testExchange, StatusCode: http.StatusBadRequest, Body: "rate limit"},
+	} {
+		if isRetryableSubmissionError(err) {
+			t.Errorf("expected '%v' to not be retryable", err)
+		}
+	}
+}
+
+func TestSubmitWithRetries(t *testing.T) {
+	t.Parallel()
+	notFound := func(context.Context) (string, bool) { return "", false }
+	failThenSucceed := func(failures int, failure error) (func(context.Context) (string, error), *int) {
+		attempts := new(int)
+		return func(context.Context) (string, error) {
+			*attempts++
+			if *attempts <= failures {
+				return "", failure
+			}
+			return "1337", nil
+		}, attempts
+	}
+
+	submit, attempts := failThenSucceed(2, errTestRateLimited)
+	orderID, retried, err := submitWithRetries(context.Background(), 3, time.Millisecond, submit, notFound)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	if orderID != "1337" || retried != 2 || *attempts != 3 {
+		t.Errorf("received '%v' '%v' '%v' expected '%v' '%v' '%v'", orderID, retried, *attempts, "1337", 2, 3)
+	}
+
+	submit, attempts = failThenSucceed(5, errTestRateLimited)
+	_, retried, err = submitWithRetries(context.Background(), 2, time.Millisecond, submit, notFound)
+	if !errors.Is(err, errTestRateLimited) {
+		t.Errorf("received '%v' expected '%v'", err, errTestRateLimited)
+	}
+	if retried != 2 || *attempts != 3 {
+		t.Errorf("received '%v' '%v' expected '%v' '%v'", retried, *attempts, 2, 3)
+	}
+
+	submit, attempts = failThenSucceed(1, errTestInsufficientFunds)
+	_, retried, err = submitWithRetries(context.Background(), 3, time.Millisecond, submit, notFound)
+	if !errors.Is(err, errTestInsufficientFunds) {
+		t.Errorf("received '%v' expected '%v'", err, errTestInsufficientFunds)
+	}
+	if retried != 0 || *attempts != 1 {
+		t.Errorf("received '%v' '%v' expected permanent rejections to fail immediately", retried, *attempts)
+	}
+
+	// an order which reached the exchange despite erroring is not submitted again
+	submit, attempts = failThenSucceed(1, errTestRateLimited)
+	orderID, _, err = submitWithRetries(context.Background(), 3, time.Millisecond, submit, func(context.Context) (string, bool) { return "1338", true })
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	if orderID != "1338" || *attempts != 1 {
+		t.Errorf("received '%v' '%v' expected '%v' '%v'", orderID, *attempts, "1338", 1)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	submit, attempts = failThenSucceed(1, errTestRateLimited)
+	_, _, err = submitWithRetries(ctx, 3, time.Hour, submit, notFound)
+	if !errors.Is(err, errTestRateLimited) {
+		t.Errorf("received '%v' expected '%v'", err, errTestRateLimited)
+	}
+	if *attempts != 1 {
+		t.Errorf("received '%v' expected '%v'", *attempts, 1)
+	}
+
+	// an order which reached the exchange is still found when the backoff is abandoned
+	submit, attempts = failThenSucceed(1, errTestRateLimited)
+	orderID, _, err = submitWithRetries(ctx, 3, time.Hour, submit, func(lookupCtx context.Context) (string, bool) {
+		return "1339", lookupCtx.Err() == nil
+	})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	if orderID != "1339" || *attempts != 1 {
+		t.Errorf("received '%v' '%v' expected '%v' '%v'", orderID, *attempts, "1339", 1)
+	}
+}
+
+// fakeOrderExchange returns preset active orders and order history
+type fakeOrderExchange struct {
+	gctexchange.IBotExchange
+	active  []gctorder.Detail
+	history []gctorder.Detail
+	err     error
+}
+
+func (f *fakeOrderExchange) GetActiveOrders(context.Context, *gctorder.GetOrdersRequest) ([]gctorder.Detail, error) {
+	return f.active, f.err
+}
+
+func (f *fakeOrderExchange) GetOrderHistory(context.Context, *gctorder.GetOrdersRequest) ([]gctorder.Detail, error) {
+	return f.history, f.err
+}
+
+func TestFindSubmittedOrder(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	if _, ok := findSubmittedOrder(ctx, nil, nil, &gctorder.Submit{ClientOrderID: "1337"}, time.Now()); ok {
+		t.Error("expected no order without an order manager")
+	}
+	om, _ := setupOfflineOrderManager(t)
+	if _, ok := findSubmittedOrder(ctx, nil, om, &gctorder.Submit{}, time.Now()); ok {
+		t.Error("expected no order without a client order ID")
+	}
+	p := currency.NewPair(currency.BTC, currency.USDT)
+	submit := &gctorder.Submit{Exchange: testExchange, AssetType: asset.Spot, Pair: p, ClientOrderID: "1337"}
+	if _, ok := findSubmittedOrder(ctx, nil, om, submit, time.Now()); ok {
+		t.Error("expected no order which was never submitted")
+	}
+	exch := &fakeOrderExchange{err: errTestUnsuccessfulStatus}
+	if _, ok := findSubmittedOrder(ctx, exch, om, submit, time.Now()); ok {
+		t.Error("expected no order when the exchange cannot be searched")
+	}
+	exch = &fakeOrderExchange{active: []gctorder.Detail{
+		{Exchange: testExchange, AssetType: asset.Spot, Pair: p, OrderID: "1", ClientOrderID: "1"},
+	}}
+	if _, ok := findSubmittedOrder(ctx, exch, om, submit, time.Now()); ok {
+		t.Error("expected no order with a different client order ID")
+	}
+
+	// a market order which filled on the exchange is only in its history
+	exch.history = []gctorder.Detail{{
+		AssetType:     asset.Spot,
+		Pair:          p,
+		OrderID:       "1338",
+		ClientOrderID: "1337",
+		Side:          gctorder.Buy,
+		Type:          gctorder.Market,
+		Status:        gctorder.Filled,
+		Amount:        1,
+		Price:         1337,
+		Date:          time.Now(),
+		LastUpdated:   time.Now(),
+	}}
+	orderID, ok := findSubmittedOrder(ctx, exch, om, submit, time.Now())
+	if !ok || orderID != "1338" {
+		t.Fatalf("received '%v' '%v' expected '%v' '%v'", orderID, ok, "1338", true)
+	}
+	// the order found on the exchange is tracked by the order manager
+	exch.history = nil
+	orderID, ok = findSubmittedOrder(ctx, nil, om, submit, time.Now())
+	if !ok || orderID != "1338" {
+		t.Errorf("received '%v' '%v' expected '%v' '%v'", orderID, ok, "1338", true)
+	}
+}
@@ -0,0 +1,79 @@
+package exchange
+
+import (
+	"fmt"
+
+	"github.com/thrasher-corp/gocryptotrader/backtester/common"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/fill"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/order"
+	gctorder "github.com/thrasher-corp/gocryptotrader/exchanges/order"
+)
+
+// holdPendingTakeProfit leaves a take-profit order pending until a candle's
+// range reaches its target, deferring it to be evaluated against the next
+// candle. Sells, which take profit on a long position, are reached when the
+// high rises to the target and buys, which take profit on a short position,
+// when the low falls to it, the same as a limit order being touched. The
+// candle the order is placed on traded before the order existed, so its range
+// is never used to fill the order and only targets already reached at the
+// price the order was placed at fill on it. The order keeps its reserved funds
+// until it fills. Orders without a target and take-profit orders which reached
+// their target are filled without deferral
+func (e *Exchange) holdPendingTakeProfit(o order.Event, f *fill.Fill, candle common.DataEventHandler) bool {
+	target := o.GetTakeProfitPrice()
+	if !target.IsPositive() || candle == nil {
+		return false
+	}
+	isPlacement := !o.IsPendingTakeProfit()
+	switch {
+	case isPlacement && isLimitTouched(o.GetDirection(), target, o.GetClosePrice(), o.GetClosePrice()):
+		f.AppendReasonf("Take-profit target %v reached at placement price %v", target, o.GetClosePrice())
+		return false
+	case !isPlacement && isLimitTouched(o.GetDirection(), target, candle.GetHighPrice(), candle.GetLowPrice()):
+		f.AppendReasonf("Take-profit target %v reached within candle low %v high %v", target, candle.GetLowPrice(), candle.GetHighPrice())
+		return false
+	}
+	ord := e.deferOrder(o)
+	if ord == nil {
+		return false
+	}
+	ord.PendingTakeProfit = true
+	// the unreached candle's reasons are recorded against its fill
+	ord.Reasons = nil
+	f.PendingTakeProfit = true
+	f.SetDirection(gctorder.DoNothing)
+	if isPlacement {
+		f.AppendReasonf("Take-profit target %v not reached at placement price %v, order left pending to be evaluated from the next candle", target, o.GetClosePrice())
+	} else {
+		f.AppendReasonf("Take-profit target %v not reached within candle low %v high %v, order left pending", target, candle.GetLowPrice(), candle.GetHighPrice())
+	}
+	return true
+}
+
+// resolveTakeProfitPercent sets the take-profit order's target price from the
+// percentage move targeted from its open position's entry price. When
+// NetOfCostTargets is enabled the target also recovers the fee rate the entry
+// fills actually paid on both legs and the spread they paid again on exit.
+// The resolved price is carried by the order should it be left pending
+func (e *Exchange) resolveTakeProfitPercent(o order.Event, f *fill.Fill, cs *Settings) error {
+	pe := e.getPositionEntry(o.GetExchange(), o.GetAssetType(), o.Pair())
+	if pe == nil || !isPositionExit(o) || !pe.enteredAmount.IsPositive() || !pe.notional.IsPositive() {
+		return fmt.Errorf("%w to resolve take-profit percent %v for %v %v %v", errNoPositionEntry, o.GetTakeProfitPercent(), o.GetExchange(), o.GetAssetType(), o.Pair())
+	}
+	entryPrice, feeRate, spread := pe.entryCosts()
+	target, err := cs.calculateTargetPrice(pe.direction, entryPrice, o.GetTakeProfitPercent(), feeRate, spread)
+	if err != nil {
+		return err
+	}
+	if !target.IsPositive() {
+		return fmt.Errorf("%w resolved target %v", errInvalidTakeProfitPercent, target)
+	}
+	if cs.NetOfCostTargets {
+		f.AppendReasonf("Take-profit of %v%% resolved to %v from entry price %v net of entry fee rate %v and spread %v", o.GetTakeProfitPercent(), target, entryPrice, feeRate, spread)
+	} else {
+		f.AppendReasonf("Take-profit of %v%% resolved to %v from entry price %v", o.GetTakeProfitPercent(), target, entryPrice)
+	}
+	o.SetTakeProfitPrice(target)
+	f.TakeProfitPrice = target
+	return nil
+}
@@ -0,0 +1,243 @@
+package exchange
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	gctkline "github.com/thrasher-corp/gocryptotrader/exchanges/kline"
+	gctorder "github.com/thrasher-corp/gocryptotrader/exchanges/order"
+)
+
+func TestExecuteOrderTakeProfit(t *testing.T) {
+	t.Parallel()
+	om, exch := setupOfflineOrderManager(t)
+	// the placement candle traded through the target before the order existed
+	o, d := setupOfflineOrder(t, gctorder.Sell, decimal.NewFromInt(1), decimal.NewFromInt(100),
+		gctkline.Candle{Open: 100, Close: 100, High: 106, Low: 96, Volume: 100000})
+	o.TakeProfitPrice = decimal.NewFromInt(105)
+	cs := Settings{
+		Exchange:               exch,
+		Pair:                   o.Pair(),
+		Asset:                  o.GetAssetType(),
+		MinimumSlippageRate:    decimal.NewFromInt(100),
+		MaximumSlippageRate:    decimal.NewFromInt(100),
+		SignalToFillConvention: SameCandleClose,
+	}
+	e := Exchange{CurrencySettings: []Settings{cs}}
+	f, err := e.ExecuteOrder(context.Background(), o, d, om, &fakeFund{})
+	if !errors.Is(err, ErrCannotTransact) {
+		t.Fatalf("received '%v' expected '%v'", err, ErrCannotTransact)
+	}
+	if f.GetDirection() != gctorder.DoNothing || !f.IsPendingTakeProfit() {
+		t.Errorf("received '%v' '%v' expected '%v' '%v'", f.GetDirection(), f.IsPendingTakeProfit(), gctorder.DoNothing, true)
+	}
+	e.deferredOrders = nil
+
+	// the long position's pending target is reached mid-candle
+	o.PendingTakeProfit = true
+	f, err = e.ExecuteOrder(context.Background(), o, d, om, &fakeFund{})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	if f.GetDirection() != gctorder.Sell || f.IsPendingTakeProfit() || !f.GetTakeProfitPrice().Equal(o.TakeProfitPrice) {
+		t.Errorf("received '%v' '%v' '%v' expected '%v' '%v' '%v'", f.GetDirection(), f.IsPendingTakeProfit(), f.GetTakeProfitPrice(), gctorder.Sell, false, o.TakeProfitPrice)
+	}
+	if !f.GetPurchasePrice().Equal(o.TakeProfitPrice) {
+		t.Errorf("received '%v' expected '%v'", f.GetPurchasePrice(), o.TakeProfitPrice)
+	}
+
+	// the short position's target is reached mid-candle, sizing the amount
+	// against the target rather than the close price
+	o, d = setupOfflineOrder(t, gctorder.Buy, decimal.NewFromInt(10), decimal.NewFromInt(100),
+		gctkline.Candle{Open: 100, Close: 100, High: 104, Low: 94, Volume: 100000})
+	o.AllocatedFunds = decimal.NewFromInt(960)
+	o.TakeProfitPrice = decimal.NewFromInt(95)
+	o.PendingTakeProfit = true
+	cs.BuySide = MinMax{MinimumSize: decimal.NewFromInt(10)}
+	e = Exchange{CurrencySettings: []Settings{cs}}
+	f, err = e.ExecuteOrder(context.Background(), o, d, om, &fakeFund{})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	if !f.GetPurchasePrice().Equal(o.TakeProfitPrice) || !f.GetAmount().Equal(o.Amount) {
+		t.Errorf("received '%v' '%v' expected '%v' '%v'", f.GetPurchasePrice(), f.GetAmount(), o.TakeProfitPrice, o.Amount)
+	}
+
+	// a target already reached at the placement price fills immediately
+	o, d = setupOfflineOrder(t, gctorder.Sell, decimal.NewFromInt(1), decimal.NewFromInt(100),
+		gctkline.Candle{Open: 100, Close: 100, High: 104, Low: 96, Volume: 100000})
+	o.TakeProfitPrice = decimal.NewFromInt(99)
+	e = Exchange{CurrencySettings: []Settings{cs}}
+	f, err = e.ExecuteOrder(context.Background(), o, d, om, &fakeFund{})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	if f.GetDirection() != gctorder.Sell || f.IsPendingTakeProfit() || !f.GetPurchasePrice().Equal(decimal.NewFromInt(100)) {
+		t.Errorf("received '%v' '%v' '%v' expected '%v' '%v' '%v'", f.GetDirection(), f.IsPendingTakeProfit(), f.GetPurchasePrice(), gctorder.Sell, false, 100)
+	}
+
+	o.TakeProfitPrice = decimal.NewFromInt(-1)
+	_, err = e.ExecuteOrder(context.Background(), o, d, om, &fakeFund{})
+	if !errors.Is(err, errInvalidTakeProfitPrice) {
+		t.Errorf("received '%v' expected '%v'", err, errInvalidTakeProfitPrice)
+	}
+	o.TakeProfitPrice = decimal.NewFromInt(95)
+	o.StopPrice = decimal.NewFromInt(105)
+	_, err = e.ExecuteOrder(context.Background(), o, d, om, &fakeFund{})
+	if !errors.Is(err, errInvalidTakeProfitPrice) {
+		t.Errorf("received '%v' expected '%v'", err, errInvalidTakeProfitPrice)
+	}
+}
+
+func TestExecuteOrderTakeProfitNeverReached(t *testing.T) {
+	t.Parallel()
+	om, exch := setupOfflineOrderManager(t)
+	o, d := setupOfflineOrder(t, gctorder.Sell, decimal.NewFromInt(1), decimal.NewFromInt(100),
+		gctkline.Candle{Open: 100, Close: 100, High: 104, Low: 96, Volume: 100000})
+	o.TakeProfitPrice = decimal.NewFromInt(110)
+	e := Exchange{CurrencySettings: []Settings{{
+		Exchange:               exch,
+		Pair:                   o.Pair(),
+		Asset:                  o.GetAssetType(),
+		SignalToFillConvention: SameCandleClose,
+	}}}
+	f, err := e.ExecuteOrder(context.Background(), o, d, om, &fakeFund{})
+	if !errors.Is(err, ErrCannotTransact) {
+		t.Fatalf("received '%v' expected '%v'", err, ErrCannotTransact)
+	}
+	if f.GetDirection() != gctorder.DoNothing || !f.IsPendingTakeProfit() {
+		t.Errorf("received '%v' '%v' expected '%v' '%v'", f.GetDirection(), f.IsPendingTakeProfit(), gctorder.DoNothing, true)
+	}
+	if len(e.deferredOrders) != 1 || !e.deferredOrders[0].IsPendingTakeProfit() {
+		t.Fatalf("expected a single pending take-profit order, received '%v'", e.deferredOrders)
+	}
+
+	// the run ends before the target is reached, releasing the reserved funds
+	cancelled, err := e.CancelDeferredOrders(&fakeFundingReader{})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	if len(cancelled) != 1 || cancelled[0].GetDirection() != gctorder.CouldNotSell {
+		t.Fatalf("received '%v' expected a single cancelled sell", cancelled)
+	}
+	if !cancelled[0].GetTakeProfitPrice().Equal(o.TakeProfitPrice) {
+		t.Errorf("received '%v' expected '%v'", cancelled[0].GetTakeProfitPrice(), o.TakeProfitPrice)
+	}
+	if len(e.deferredOrders) != 0 {
+		t.Errorf("received '%v' expected '%v'", len(e.deferredOrders), 0)
+	}
+}
+
+func TestExecuteOrderTakeProfitPercent(t *testing.T) {
+	t.Parallel()
+	om, exch := setupOfflineOrderManager(t)
+	candle := gctkline.Candle{Open: 100, Close: 100, High: 101.1, Low: 99, Volume: 100000}
+	for _, tc := range []struct {
+		netOfCost bool
+		expected  decimal.Decimal
+	}{
+		// the target is not net of costs so is reached by a 1% move
+		{false, decimal.NewFromInt(101)},
+		// (target * (1 - 0.001)) - (100 + 0.1) = 1, being 1% of the entry
+		{true, decimal.NewFromFloat(1.011).Div(decimal.NewFromFloat(0.999)).Mul(decimal.NewFromInt(100))},
+	} {
+		e := Exchange{CurrencySettings: []Settings{{
+			Exchange:               exch,
+			Pair:                   currency.NewPair(currency.BTC, currency.USDT),
+			Asset:                  asset.Spot,
+			TakerFee:               decimal.NewFromFloat(0.001),
+			MinimumSlippageRate:    decimal.NewFromInt(100),
+			MaximumSlippageRate:    decimal.NewFromInt(100),
+			NetOfCostTargets:       tc.netOfCost,
+			SignalToFillConvention: SameCandleClose,
+		}}}
+		entry, d := setupOfflineOrder(t, gctorder.Buy, decimal.NewFromInt(1), decimal.NewFromInt(100), candle)
+		entryFill, err := e.ExecuteOrder(context.Background(), entry, d, om, &fakeFund{})
+		if !errors.Is(err, nil) {
+			t.Fatalf("received '%v' expected '%v'", err, nil)
+		}
+		if !entryFill.GetExchangeFee().Equal(decimal.NewFromFloat(0.1)) {
+			t.Fatalf("received '%v' expected '%v'", entryFill.GetExchangeFee(), 0.1)
+		}
+		// the target is resolved from the fee the entry actually paid
+		// rather than the configured taker fee
+		e.CurrencySettings[0].TakerFee = decimal.NewFromFloat(0.5)
+
+		o, d := setupOfflineOrder(t, gctorder.Sell, decimal.NewFromInt(1), decimal.NewFromInt(100), candle)
+		o.TakeProfitPercent = decimal.NewFromInt(1)
+		f, err := e.ExecuteOrder(context.Background(), o, d, om, &fakeFund{})
+		if !errors.Is(err, ErrCannotTransact) {
+			t.Fatalf("received '%v' expected '%v'", err, ErrCannotTransact)
+		}
+		if !f.IsPendingTakeProfit() || len(e.deferredOrders) != 1 {
+			t.Fatal("expected the target to be left pending on the candle it was placed on")
+		}
+		pending := e.deferredOrders[0]
+		target := pending.GetTakeProfitPrice()
+		if !pending.GetTakeProfitPercent().IsZero() {
+			t.Errorf("received '%v' expected the pending order to carry its resolved target", pending.GetTakeProfitPercent())
+		}
+		if !target.Round(8).Equal(tc.expected.Round(8)) || !f.GetTakeProfitPrice().Equal(target) {
+			t.Errorf("net of cost '%v' received '%v' '%v' expected '%v'", tc.netOfCost, target, f.GetTakeProfitPrice(), tc.expected)
+		}
+
+		e.deferredOrders = nil
+		f, err = e.ExecuteOrder(context.Background(), pending, d, om, &fakeFund{})
+		if tc.netOfCost {
+			if !errors.Is(err, ErrCannotTransact) {
+				t.Fatalf("received '%v' expected '%v'", err, ErrCannotTransact)
+			}
+			if !f.IsPendingTakeProfit() {
+				t.Fatalf("expected the net of cost target to be left pending above the candle high of %v", candle.High)
+			}
+			netProfit := target.Mul(decimal.NewFromFloat(0.999)).Sub(decimal.NewFromFloat(100.1))
+			if !netProfit.Round(8).Equal(decimal.NewFromInt(1)) {
+				t.Errorf("received net profit '%v' expected '%v'", netProfit, 1)
+			}
+			continue
+		}
+		if !errors.Is(err, nil) {
+			t.Fatalf("received '%v' expected '%v'", err, nil)
+		}
+		if !f.GetPurchasePrice().Equal(target) {
+			t.Errorf("received '%v' expected '%v'", f.GetPurchasePrice(), target)
+		}
+	}
+}
+
+func TestExecuteOrderTakeProfitPercentInvalid(t *testing.T) {
+	t.Parallel()
+	om, exch := setupOfflineOrderManager(t)
+	o, d := setupOfflineOrder(t, gctorder.Sell, decimal.NewFromInt(1), decimal.NewFromInt(100),
+		gctkline.Candle{Open: 100, Close: 100, High: 104, Low: 96, Volume: 100000})
+	e := Exchange{CurrencySettings: []Settings{{
+		Exchange:               exch,
+		Pair:                   o.Pair(),
+		Asset:                  o.GetAssetType(),
+		SignalToFillConvention: SameCandleClose,
+	}}}
+	// there is no open position to resolve the target from
+	o.TakeProfitPercent = decimal.NewFromInt(1)
+	f, err := e.ExecuteOrder(context.Background(), o, d, om, &fakeFund{})
+	if !errors.Is(err, errNoPositionEntry) {
+		t.Errorf("received '%v' expected '%v'", err, errNoPositionEntry)
+	}
+	if f.GetDirection() != gctorder.CouldNotSell {
+		t.Errorf("received '%v' expected '%v'", f.GetDirection(), gctorder.CouldNotSell)
+	}
+	o.TakeProfitPercent = decimal.NewFromInt(-1)
+	_, err = e.ExecuteOrder(context.Background(), o, d, om, &fakeFund{})
+	if !errors.Is(err, errInvalidTakeProfitPercent) {
+		t.Errorf("received '%v' expected '%v'", err, errInvalidTakeProfitPercent)
+	}
+	o.TakeProfitPercent = decimal.NewFromInt(1)
+	o.TakeProfitPrice = decimal.NewFromInt(105)
+	_, err = e.ExecuteOrder(context.Background(), o, d, om, &fakeFund{})
+	if !errors.Is(err, errInvalidTakeProfitPercent) {
+		t.Errorf("received '%v' expected '%v'", err, errInvalidTakeProfitPercent)
+	}
+}
@@ -0,0 +1,67 @@
+package exchange
+
+import (
+	"fmt"
+
+	"github.com/shopspring/decimal"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	gctorder "github.com/thrasher-corp/gocryptotrader/exchanges/order"
+)
+
+// CalculateTargetPrice returns the exit price required for the exchange, asset
+// and pair's open position to move by targetPercent from its entry price.
+// When NetOfCostTargets is enabled the target also recovers the fee rate the
+// entry fills actually paid on both legs and the spread they pay again on exit
+func (e *Exchange) CalculateTargetPrice(exch string, a asset.Item, cp currency.Pair, targetPercent decimal.Decimal) (decimal.Decimal, error) {
+	cs, err := e.GetCurrencySettings(exch, a, cp)
+	if err != nil {
+		return decimal.Zero, err
+	}
+	pe := e.getPositionEntry(exch, a, cp)
+	if pe == nil || !pe.enteredAmount.IsPositive() || !pe.notional.IsPositive() {
+		return decimal.Zero, fmt.Errorf("%w for %v %v %v", errNoPositionEntry, exch, a, cp)
+	}
+	entryPrice, feeRate, spread := pe.entryCosts()
+	return cs.calculateTargetPrice(pe.direction, entryPrice, targetPercent, feeRate, spread)
+}
+
+// entryCosts returns the position's average entry price along with the fee
+// rate and the spread per unit its entry fills paid
+func (pe *positionEntry) entryCosts() (entryPrice, feeRate, spread decimal.Decimal) {
+	return pe.notional.Div(pe.enteredAmount), pe.fee.Div(pe.notional), pe.spreadCost.Div(pe.enteredAmount)
+}
+
+// calculateTargetPrice returns the exit price required for a position entered
+// at entryPrice in the direction to move by targetPercent. When
+// NetOfCostTargets is enabled, the exit price also recovers the fee rate on
+// both legs and the absolute spread on exit, so that a 1% target nets 1%
+// after costs. Negative fee rates are maker rebates
+func (s *Settings) calculateTargetPrice(direction gctorder.Side, entryPrice, targetPercent, feeRate, spread decimal.Decimal) (decimal.Decimal, error) {
+	if entryPrice.LessThanOrEqual(decimal.Zero) {
+		return decimal.Zero, fmt.Errorf("%w entry price %v", errInvalidTargetSettings, entryPrice)
+	}
+	if spread.IsNegative() {
+		return decimal.Zero, fmt.Errorf("%w spread %v", errInvalidTargetSettings, spread)
+	}
+	one := decimal.NewFromInt(1)
+	target := targetPercent.Div(decimal.NewFromInt(100))
+	fee, spreadCost := decimal.Zero, decimal.Zero
+	if s.NetOfCostTargets {
+		fee = feeRate
+		spreadCost = spread
+	}
+	if fee.LessThanOrEqual(one.Neg()) || fee.GreaterThanOrEqual(one) {
+		return decimal.Zero, fmt.Errorf("%w fee rate %v", errInvalidTargetSettings, fee)
+	}
+	switch direction {
+	case gctorder.Buy, gctorder.Bid, gctorder.Long:
+		// (exit - spread) * (1 - fee) - entry * (1 + fee) = entry * target
+		return entryPrice.Mul(one.Add(fee).Add(target)).Div(one.Sub(fee)).Add(spreadCost), nil
+	case gctorder.Sell, gctorder.Ask, gctorder.Short:
+		// entry * (1 - fee) - (exit + spread) * (1 + fee) = entry * target
+		return entryPrice.Mul(one.Sub(fee).Sub(target)).Div(one.Add(fee)).Sub(spreadCost), nil
+	default:
+		return decimal.Zero, fmt.Errorf("%w %v", errInvalidDirection, direction)
+	}
+}
@@ -0,0 +1,111 @@
+package exchange
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/ftx"
+	gctorder "github.com/thrasher-corp/gocryptotrader/exchanges/order"
+)
+
+func TestExchangeCalculateTargetPrice(t *testing.T) {
+	t.Parallel()
+	p := currency.NewPair(currency.BTC, currency.USDT)
+	e := Exchange{}
+	_, err := e.CalculateTargetPrice(testExchange, asset.Spot, p, decimal.NewFromInt(1))
+	if !errors.Is(err, errNoCurrencySettingsFound) {
+		t.Errorf("received '%v' expected '%v'", err, errNoCurrencySettingsFound)
+	}
+	exch := &ftx.FTX{}
+	exch.Name = testExchange
+	e.CurrencySettings = []Settings{{
+		Exchange:         exch,
+		Pair:             p,
+		Asset:            asset.Spot,
+		NetOfCostTargets: true,
+	}}
+	_, err = e.CalculateTargetPrice(testExchange, asset.Spot, p, decimal.NewFromInt(1))
+	if !errors.Is(err, errNoPositionEntry) {
+		t.Errorf("received '%v' expected '%v'", err, errNoPositionEntry)
+	}
+	e.setPositionEntry(testExchange, asset.Spot, p, &positionEntry{
+		direction:     gctorder.Buy,
+		amount:        decimal.NewFromInt(2),
+		enteredAmount: decimal.NewFromInt(2),
+		notional:      decimal.NewFromInt(200),
+		fee:           decimal.NewFromInt(2),
+		spreadCost:    decimal.NewFromInt(1),
+	})
+	target, err := e.CalculateTargetPrice(testExchange, asset.Spot, p, decimal.NewFromInt(1))
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	// exiting at the target minus the entry's spread, after fees on both legs, nets 1%
+	feeRate := decimal.NewFromFloat(0.01)
+	netProfit := target.Sub(decimal.NewFromFloat(0.5)).Mul(decimal.NewFromInt(1).Sub(feeRate)).Sub(decimal.NewFromInt(100).Mul(decimal.NewFromInt(1).Add(feeRate)))
+	if !netProfit.Round(8).Equal(decimal.NewFromInt(1)) {
+		t.Errorf("received '%v' expected '%v'", netProfit, 1)
+	}
+}
+
+func TestCalculateTargetPrice(t *testing.T) {
+	t.Parallel()
+	cs := &Settings{}
+	feeRate := decimal.NewFromFloat(0.01)
+	_, err := cs.calculateTargetPrice(gctorder.Buy, decimal.Zero, decimal.NewFromInt(1), feeRate, decimal.Zero)
+	if !errors.Is(err, errInvalidTargetSettings) {
+		t.Errorf("received '%v' expected '%v'", err, errInvalidTargetSettings)
+	}
+	_, err = cs.calculateTargetPrice(gctorder.Buy, decimal.NewFromInt(100), decimal.NewFromInt(1), feeRate, decimal.NewFromInt(-1))
+	if !errors.Is(err, errInvalidTargetSettings) {
+		t.Errorf("received '%v' expected '%v'", err, errInvalidTargetSettings)
+	}
+	_, err = cs.calculateTargetPrice(gctorder.DoNothing, decimal.NewFromInt(100), decimal.NewFromInt(1), feeRate, decimal.Zero)
+	if !errors.Is(err, errInvalidDirection) {
+		t.Errorf("received '%v' expected '%v'", err, errInvalidDirection)
+	}
+
+	entry := decimal.NewFromInt(100)
+	target, err := cs.calculateTargetPrice(gctorder.Buy, entry, decimal.NewFromInt(1), feeRate, decimal.NewFromInt(1))
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	if !target.Equal(decimal.NewFromInt(101)) {
+		t.Errorf("received '%v' expected '%v'", target, 101)
+	}
+	target, err = cs.calculateTargetPrice(gctorder.Short, entry, decimal.NewFromInt(1), feeRate, decimal.NewFromInt(1))
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	if !target.Equal(decimal.NewFromInt(99)) {
+		t.Errorf("received '%v' expected '%v'", target, 99)
+	}
+
+	cs.NetOfCostTargets = true
+	spread := decimal.NewFromFloat(0.5)
+	target, err = cs.calculateTargetPrice(gctorder.Buy, entry, decimal.NewFromInt(1), feeRate, spread)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	// exiting at the target minus the spread, after fees on both legs, nets 1%
+	netProfit := target.Sub(spread).Mul(decimal.NewFromInt(1).Sub(feeRate)).Sub(entry.Mul(decimal.NewFromInt(1).Add(feeRate)))
+	if !netProfit.Round(8).Equal(decimal.NewFromInt(1)) {
+		t.Errorf("received '%v' expected '%v'", netProfit, 1)
+	}
+	target, err = cs.calculateTargetPrice(gctorder.Sell, entry, decimal.NewFromInt(1), feeRate, spread)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	netProfit = entry.Mul(decimal.NewFromInt(1).Sub(feeRate)).Sub(target.Add(spread).Mul(decimal.NewFromInt(1).Add(feeRate)))
+	if !netProfit.Round(8).Equal(decimal.NewFromInt(1)) {
+		t.Errorf("received '%v' expected '%v'", netProfit, 1)
+	}
+
+	_, err = cs.calculateTargetPrice(gctorder.Buy, entry, decimal.NewFromInt(1), decimal.NewFromInt(1), spread)
+	if !errors.Is(err, errInvalidTargetSettings) {
+		t.Errorf("received '%v' expected '%v'", err, errInvalidTargetSettings)
+	}
+}
@@ -0,0 +1,166 @@
+package exchange
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/thrasher-corp/gocryptotrader/backtester/common"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/fill"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/order"
+	"github.com/thrasher-corp/gocryptotrader/backtester/funding"
+	gctorder "github.com/thrasher-corp/gocryptotrader/exchanges/order"
+)
+
+// IsOpen returns whether the trading session is open at the provided time.
+// A nil session is always open
+func (t *TradingSession) IsOpen(tt time.Time) bool {
+	if t == nil {
+		return true
+	}
+	loc := t.Location
+	if loc == nil {
+		loc = time.UTC
+	}
+	tt = tt.In(loc)
+	midnight := time.Date(tt.Year(), tt.Month(), tt.Day(), 0, 0, 0, 0, loc)
+	timeOfDay := tt.Sub(midnight)
+	switch {
+	case t.Open == t.Close:
+		return t.tradesOn(tt.Weekday())
+	case t.Open < t.Close:
+		return timeOfDay >= t.Open && timeOfDay < t.Close && t.tradesOn(tt.Weekday())
+	default:
+		// overnight sessions belong to the day they open
+		if timeOfDay >= t.Open {
+			return t.tradesOn(tt.Weekday())
+		}
+		return timeOfDay < t.Close && t.tradesOn(midnight.AddDate(0, 0, -1).Weekday())
+	}
+}
+
+func (t *TradingSession) tradesOn(day time.Weekday) bool {
+	if len(t.Days) == 0 {
+		return true
+	}
+	for i := range t.Days {
+		if t.Days[i] == day {
+			return true
+		}
+	}
+	return false
+}
+
+// handleOffHoursOrder either defers or rejects an order placed outside of the
+// trading session. Deferred orders keep their reserved funds until released
+func (e *Exchange) handleOffHoursOrder(o order.Event, f *fill.Fill, funds funding.IFundReleaser, cs *Settings) (fill.Event, error) {
+	if cs.TradingSession.DeferOffHoursOrders && e.deferOrder(o) != nil {
+		f.SetDirection(gctorder.DoNothing)
+		f.AppendReasonf("Order placed outside trading session at %v, deferred until the next trading session", o.GetTime())
+		return f, fmt.Errorf("%w %v", ErrCannotTransact, errOutsideTradingSession)
+	}
+	f.AppendReasonf("Order placed outside trading session at %v, rejected", o.GetTime())
+	return f, allocateFundsPostOrder(f, funds, errOutsideTradingSession, o.GetAmount(), o.GetAllocatedFunds(), decimal.Zero, decimal.Zero, decimal.Zero, cs)
+}
+
+// ReleaseDeferredOrders returns deferred orders for the data event's exchange,
+// asset and pair once their latency has elapsed, the trading session is open
+// and the exchange is not down.
+// Released orders are updated to the data event so that they fill at the first
+// candle within the session. Orders deferred to the next candle open fill
+// at the data event's open price rather than its close. Resting limit
+// orders are released at each subsequent candle and are evaluated from
+// its open price, so a candle gapping through the limit fills at the open.
+// Dormant stop orders are likewise evaluated from the open, so a candle
+// gapping through the stop executes at the open rather than the stop price,
+// as are pending take-profit orders, filling at the open when it gaps past
+// the target, and dormant trailing stop orders, which carry their high-water
+// mark between candles.
+// Partial fill remainders are released at each subsequent candle
+func (e *Exchange) ReleaseDeferredOrders(ev common.DataEventHandler) ([]order.Event, error) {
+	if ev == nil {
+		return nil, common.ErrNilEvent
+	}
+	var released []order.Event
+	remaining := make([]*order.Order, 0, len(e.deferredOrders))
+	for i := range e.deferredOrders {
+		ord := e.deferredOrders[i]
+		if !strings.EqualFold(ord.Exchange, ev.GetExchange()) ||
+			ord.AssetType != ev.GetAssetType() ||
+			!ord.CurrencyPair.Equal(ev.Pair()) {
+			remaining = append(remaining, ord)
+			continue
+		}
+		cs, err := e.GetCurrencySettings(ev.GetExchange(), ev.GetAssetType(), ev.Pair())
+		if err != nil {
+			return nil, err
+		}
+		isPriceTriggered := ord.RestingLimit || ord.DormantStop || ord.PendingTakeProfit || ord.DormantTrailingStop
+		if (!isPriceTriggered && !ord.PartialFill && ev.GetTime().Before(ord.Time.Add(latencyCandleShift(ord.Latency, ord.Interval)))) ||
+			((ord.DeferredToNextOpen || isPriceTriggered || ord.PartialFill) && !ev.GetTime().After(ord.Time)) ||
+			!cs.TradingSession.IsOpen(ev.GetTime()) ||
+			cs.inDowntime(ev.GetTime()) {
+			remaining = append(remaining, ord)
+			continue
+		}
+		ord.AppendReasonf("Deferred order from %v released at %v", ord.Time, ev.GetTime())
+		ord.Offset = ev.GetOffset()
+		ord.Time = ev.GetTime()
+		ord.ClosePrice = ev.GetClosePrice()
+		if ord.DeferredToNextOpen || isPriceTriggered {
+			ord.ClosePrice = ev.GetOpenPrice()
+			ord.AppendReasonf("Filling at candle open price %v", ord.ClosePrice)
+		}
+		released = append(released, ord)
+	}
+	e.deferredOrders = remaining
+	return released, nil
+}
+
+// CancelDeferredOrders cancels every order still deferred at the end of the
+// run, such as untouched limit orders and the unfilled remainder of partial
+// fills, releasing their reserved funds. A fill is returned for each
+// cancelled order so that it is recorded against the final candle
+func (e *Exchange) CancelDeferredOrders(funds funding.IFundingReader) ([]fill.Event, error) {
+	if funds == nil {
+		return nil, fmt.Errorf("%w: funding", common.ErrNilArguments)
+	}
+	cancelled := make([]fill.Event, 0, len(e.deferredOrders))
+	for i := range e.deferredOrders {
+		ord := e.deferredOrders[i]
+		cs, err := e.GetCurrencySettings(ord.GetExchange(), ord.GetAssetType(), ord.Pair())
+		if err != nil {
+			return nil, err
+		}
+		pair, err := funds.GetFundingForEvent(ord)
+		if err != nil {
+			return nil, err
+		}
+		f := &fill.Fill{
+			Base:                      ord.GetBase(),
+			Direction:                 ord.GetDirection(),
+			Amount:                    ord.GetAmount(),
+			IntendedAmount:            ord.GetAmount(),
+			ClosePrice:                ord.GetClosePrice(),
+			VolumeAdjustedPrice:       ord.GetClosePrice(),
+			Tag:                       ord.GetTag(),
+			Account:                   ord.GetAccount(),
+			LimitPrice:                ord.GetLimitPrice(),
+			StopPrice:                 ord.GetStopPrice(),
+			TakeProfitPrice:           ord.GetTakeProfitPrice(),
+			TrailingStopHighWaterMark: ord.GetTrailingStopHighWaterMark(),
+			DecisionTime:              ord.GetDecisionTime(),
+		}
+		f.AppendReasonf("Deferred order of %v cancelled unfilled at the end of the run, releasing %v", ord.GetAmount(), ord.GetAllocatedFunds())
+		err = allocateFundsPostOrder(f, pair.FundReleaser(), errDeferredOrderCancelled, ord.GetAmount(), ord.GetAllocatedFunds(), decimal.Zero, decimal.Zero, decimal.Zero, &cs)
+		if err != nil && !errors.Is(err, errDeferredOrderCancelled) {
+			return nil, err
+		}
+		e.publishFill(f)
+		cancelled = append(cancelled, f)
+	}
+	e.deferredOrders = nil
+	return cancelled, nil
+}
@@ -0,0 +1,249 @@
+package exchange
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/thrasher-corp/gocryptotrader/backtester/common"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/event"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/kline"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/order"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	gctkline "github.com/thrasher-corp/gocryptotrader/exchanges/kline"
+	gctorder "github.com/thrasher-corp/gocryptotrader/exchanges/order"
+)
+
+func TestTradingSessionIsOpen(t *testing.T) {
+	t.Parallel()
+	var ts *TradingSession
+	if !ts.IsOpen(time.Now()) {
+		t.Error("expected nil trading session to always be open")
+	}
+
+	// Monday 2022-01-03
+	monday := time.Date(2022, 1, 3, 0, 0, 0, 0, time.UTC)
+	ts = &TradingSession{
+		Days:  []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday},
+		Open:  time.Hour * 9,
+		Close: time.Hour * 17,
+	}
+	if ts.IsOpen(monday.Add(time.Hour * 8)) {
+		t.Error("expected session to be closed before open")
+	}
+	if !ts.IsOpen(monday.Add(time.Hour * 9)) {
+		t.Error("expected session to be open at open")
+	}
+	if ts.IsOpen(monday.Add(time.Hour * 17)) {
+		t.Error("expected session to be closed at close")
+	}
+	if ts.IsOpen(monday.AddDate(0, 0, -1).Add(time.Hour * 10)) {
+		t.Error("expected session to be closed on Sunday")
+	}
+
+	loc := time.FixedZone("test", -5*60*60)
+	ts.Location = loc
+	if ts.IsOpen(monday.Add(time.Hour * 10)) {
+		t.Error("expected session to be closed at 05:00 in session location")
+	}
+	if !ts.IsOpen(monday.Add(time.Hour * 15)) {
+		t.Error("expected session to be open at 10:00 in session location")
+	}
+
+	overnight := &TradingSession{
+		Days:  []time.Weekday{time.Monday},
+		Open:  time.Hour * 22,
+		Close: time.Hour * 5,
+	}
+	if !overnight.IsOpen(monday.Add(time.Hour * 23)) {
+		t.Error("expected overnight session to be open after open")
+	}
+	if !overnight.IsOpen(monday.Add(time.Hour * 28)) {
+		t.Error("expected overnight session opened on Monday to be open on Tuesday morning")
+	}
+	if overnight.IsOpen(monday.Add(time.Hour * 2)) {
+		t.Error("expected overnight session opened on Sunday to be closed")
+	}
+
+	allDay := &TradingSession{Days: []time.Weekday{time.Monday}}
+	if !allDay.IsOpen(monday.Add(time.Hour * 3)) {
+		t.Error("expected all day session to be open")
+	}
+}
+
+func TestExecuteOrderOutsideTradingSession(t *testing.T) {
+	t.Parallel()
+	om, exch := setupOfflineOrderManager(t)
+	o, d := setupOfflineOrder(t, gctorder.Buy, decimal.NewFromInt(1), decimal.NewFromInt(100),
+		gctkline.Candle{Close: 100, High: 110, Low: 90, Volume: 1000})
+	o.Time = time.Date(2022, 1, 3, 3, 0, 0, 0, time.UTC)
+	cs := Settings{
+		Exchange:            exch,
+		Pair:                o.Pair(),
+		Asset:               o.GetAssetType(),
+		MinimumSlippageRate: decimal.NewFromInt(100),
+		MaximumSlippageRate: decimal.NewFromInt(100),
+		TradingSession: &TradingSession{
+			Open:  time.Hour * 9,
+			Close: time.Hour * 17,
+		},
+	}
+	e := Exchange{CurrencySettings: []Settings{cs}}
+	f, err := e.ExecuteOrder(context.Background(), o, d, om, &fakeFund{})
+	if !errors.Is(err, errOutsideTradingSession) {
+		t.Fatalf("received '%v' expected '%v'", err, errOutsideTradingSession)
+	}
+	if f.GetDirection() != gctorder.CouldNotBuy {
+		t.Errorf("received '%v' expected '%v'", f.GetDirection(), gctorder.CouldNotBuy)
+	}
+	if !strings.Contains(f.GetConcatReasons(), "rejected") {
+		t.Errorf("expected rejection reason, received '%v'", f.GetConcatReasons())
+	}
+	if len(e.deferredOrders) != 0 {
+		t.Errorf("received '%v' expected '%v'", len(e.deferredOrders), 0)
+	}
+
+	e.CurrencySettings[0].TradingSession.DeferOffHoursOrders = true
+	o, d = setupOfflineOrder(t, gctorder.Buy, decimal.NewFromInt(1), decimal.NewFromInt(100),
+		gctkline.Candle{Close: 100, High: 110, Low: 90, Volume: 1000})
+	o.Time = time.Date(2022, 1, 3, 3, 0, 0, 0, time.UTC)
+	f, err = e.ExecuteOrder(context.Background(), o, d, om, &fakeFund{})
+	if !errors.Is(err, ErrCannotTransact) {
+		t.Fatalf("received '%v' expected '%v'", err, ErrCannotTransact)
+	}
+	if f.GetDirection() != gctorder.DoNothing {
+		t.Errorf("received '%v' expected '%v'", f.GetDirection(), gctorder.DoNothing)
+	}
+	if len(e.deferredOrders) != 1 {
+		t.Fatalf("received '%v' expected '%v'", len(e.deferredOrders), 1)
+	}
+	if e.deferredOrders[0].Base == o.Base {
+		t.Error("expected deferred order to not share the original order's base")
+	}
+}
+
+func TestReleaseDeferredOrders(t *testing.T) {
+	t.Parallel()
+	e := Exchange{}
+	_, err := e.ReleaseDeferredOrders(nil)
+	if !errors.Is(err, common.ErrNilEvent) {
+		t.Errorf("received '%v' expected '%v'", err, common.ErrNilEvent)
+	}
+
+	_, exch := setupOfflineOrderManager(t)
+	p := currency.NewPair(currency.BTC, currency.USDT)
+	e.SetExchangeAssetCurrencySettings(asset.Spot, p, &Settings{
+		Exchange: exch,
+		Pair:     p,
+		Asset:    asset.Spot,
+		TradingSession: &TradingSession{
+			Open:  time.Hour * 9,
+			Close: time.Hour * 17,
+		},
+	})
+	tt := time.Date(2022, 1, 3, 3, 0, 0, 0, time.UTC)
+	e.deferredOrders = []*order.Order{
+		{
+			Base: &event.Base{
+				Offset:       1,
+				Exchange:     testExchange,
+				Time:         tt,
+				Interval:     gctkline.OneHour,
+				CurrencyPair: p,
+				AssetType:    asset.Spot,
+			},
+			Direction:  gctorder.Buy,
+			ClosePrice: decimal.NewFromInt(100),
+		},
+		{
+			Base: &event.Base{
+				Offset:       1,
+				Exchange:     testExchange,
+				Time:         tt,
+				Interval:     gctkline.OneHour,
+				CurrencyPair: currency.NewPair(currency.ETH, currency.USDT),
+				AssetType:    asset.Spot,
+			},
+			Direction: gctorder.Buy,
+		},
+	}
+	ev := &kline.Kline{
+		Base: &event.Base{
+			Offset:       2,
+			Exchange:     testExchange,
+			Time:         tt.Add(time.Hour),
+			Interval:     gctkline.OneHour,
+			CurrencyPair: p,
+			AssetType:    asset.Spot,
+		},
+		Close: decimal.NewFromInt(1337),
+	}
+	released, err := e.ReleaseDeferredOrders(ev)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	if len(released) != 0 {
+		t.Errorf("received '%v' expected '%v'", len(released), 0)
+	}
+
+	ev.Offset = 8
+	ev.Time = tt.Add(time.Hour * 7)
+	released, err = e.ReleaseDeferredOrders(ev)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	if len(released) != 1 {
+		t.Fatalf("received '%v' expected '%v'", len(released), 1)
+	}
+	if released[0].GetOffset() != 8 {
+		t.Errorf("received '%v' expected '%v'", released[0].GetOffset(), 8)
+	}
+	if !released[0].GetTime().Equal(ev.Time) {
+		t.Errorf("received '%v' expected '%v'", released[0].GetTime(), ev.Time)
+	}
+	if !released[0].GetClosePrice().Equal(ev.Close) {
+		t.Errorf("received '%v' expected '%v'", released[0].GetClosePrice(), ev.Close)
+	}
+	if len(e.deferredOrders) != 1 {
+		t.Errorf("received '%v' expected '%v'", len(e.deferredOrders), 1)
+	}
+}
+
+func TestExecuteOrderStopOutOutsideTradingSession(t *testing.T) {
+	t.Parallel()
+	om, exch := setupOfflineOrderManager(t)
+	o, d := setupOfflineOrder(t, gctorder.Sell, decimal.NewFromInt(1), decimal.NewFromInt(100),
+		gctkline.Candle{Close: 100, High: 110, Low: 90, Volume: 1000})
+	o.Time = time.Date(2022, 1, 3, 3, 0, 0, 0, time.UTC)
+	o.StopOut = true
+	cs := Settings{
+		Exchange:            exch,
+		Pair:                o.Pair(),
+		Asset:               o.GetAssetType(),
+		MinimumSlippageRate: decimal.NewFromInt(100),
+		MaximumSlippageRate: decimal.NewFromInt(100),
+		TradingSession: &TradingSession{
+			Open:  time.Hour * 9,
+			Close: time.Hour * 17,
+		},
+		Latency: &LatencyDistribution{Mean: time.Hour},
+	}
+	e := Exchange{CurrencySettings: []Settings{cs}}
+	f, err := e.ExecuteOrder(context.Background(), o, d, om, &fakeFund{})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	if f.GetDirection() != gctorder.Sell {
+		t.Errorf("received '%v' expected '%v'", f.GetDirection(), gctorder.Sell)
+	}
+	if !f.IsStopOut() || f.IsLiquidated() {
+		t.Error("expected stop-out fill which is not an exchange liquidation")
+	}
+	if len(e.deferredOrders) != 0 {
+		t.Errorf("received '%v' expected '%v'", len(e.deferredOrders), 0)
+	}
+}
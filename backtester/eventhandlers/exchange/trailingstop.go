@@ -0,0 +1,89 @@
+package exchange
+
+import (
+	"github.com/shopspring/decimal"
+	"github.com/thrasher-corp/gocryptotrader/backtester/common"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/fill"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/order"
+	gctorder "github.com/thrasher-corp/gocryptotrader/exchanges/order"
+)
+
+// isTrailingStop returns whether the order trails the best price reached
+func isTrailingStop(o order.Event) bool {
+	return o.GetTrailingStopDistance().IsPositive() || o.GetTrailingStopPercent().IsPositive()
+}
+
+// trailingStopTriggerPrice returns the price a trailing stop triggers at
+// by trailing the high-water mark by the distance, or by the percentage of
+// the high-water mark when a percentage is set. Sells, which protect a long
+// position, trail below the highest price reached and buys, which protect a
+// short position, trail above the lowest price reached
+func trailingStopTriggerPrice(direction gctorder.Side, highWaterMark, distance, percent decimal.Decimal) decimal.Decimal {
+	if percent.IsPositive() {
+		distance = highWaterMark.Mul(percent).Div(decimal.NewFromInt(100))
+	}
+	switch direction {
+	case gctorder.Sell, gctorder.Ask, gctorder.Short, gctorder.ClosePosition:
+		return highWaterMark.Sub(distance)
+	case gctorder.Buy, gctorder.Bid, gctorder.Long:
+		return highWaterMark.Add(distance)
+	}
+	return decimal.Zero
+}
+
+// trailHighWaterMark returns the best price reached after the candle, being
+// the highest price for sells and the lowest price for buys
+func trailHighWaterMark(direction gctorder.Side, highWaterMark, high, low decimal.Decimal) decimal.Decimal {
+	switch direction {
+	case gctorder.Sell, gctorder.Ask, gctorder.Short, gctorder.ClosePosition:
+		return decimal.Max(highWaterMark, high)
+	case gctorder.Buy, gctorder.Bid, gctorder.Long:
+		return decimal.Min(highWaterMark, low)
+	}
+	return highWaterMark
+}
+
+// holdDormantTrailingStop leaves a trailing stop order dormant while the
+// candle's range does not retrace from the high-water mark far enough to reach
+// its trigger price, carrying the high-water mark on the deferred order to be
+// evaluated against the next candle. The high-water mark starts from the price
+// the order was placed at, as the placement candle traded before the order
+// existed. As the order of the high and low within a candle is unknown, each
+// candle is checked against the previous high-water mark before its range
+// moves the mark. The order keeps its reserved funds until it is triggered.
+// Orders without a trailing stop and triggered trailing stops are filled
+// without deferral
+func (e *Exchange) holdDormantTrailingStop(o order.Event, f *fill.Fill, candle common.DataEventHandler) bool {
+	if !isTrailingStop(o) || candle == nil {
+		return false
+	}
+	direction := o.GetDirection()
+	distance, percent := o.GetTrailingStopDistance(), o.GetTrailingStopPercent()
+	highWaterMark := o.GetTrailingStopHighWaterMark()
+	if highWaterMark.IsZero() {
+		highWaterMark = o.GetClosePrice()
+	} else {
+		triggerPrice := trailingStopTriggerPrice(direction, highWaterMark, distance, percent)
+		if isStopTriggered(direction, triggerPrice, candle.GetHighPrice(), candle.GetLowPrice()) {
+			f.TrailingStopHighWaterMark = highWaterMark
+			f.TrailingStopTriggerPrice = triggerPrice
+			f.AppendReasonf("Trailing stop price %v trailing high-water mark %v triggered within candle low %v high %v", triggerPrice, highWaterMark, candle.GetLowPrice(), candle.GetHighPrice())
+			return false
+		}
+		highWaterMark = trailHighWaterMark(direction, highWaterMark, candle.GetHighPrice(), candle.GetLowPrice())
+	}
+	ord := e.deferOrder(o)
+	if ord == nil {
+		return false
+	}
+	ord.DormantTrailingStop = true
+	ord.TrailingStopHighWaterMark = highWaterMark
+	// the untriggered candle's reasons are recorded against its fill
+	ord.Reasons = nil
+	f.DormantTrailingStop = true
+	f.TrailingStopHighWaterMark = highWaterMark
+	f.TrailingStopTriggerPrice = trailingStopTriggerPrice(direction, highWaterMark, distance, percent)
+	f.SetDirection(gctorder.DoNothing)
+	f.AppendReasonf("Trailing stop not triggered within candle low %v high %v, high-water mark %v trails to trigger price %v, order left dormant", candle.GetLowPrice(), candle.GetHighPrice(), highWaterMark, f.TrailingStopTriggerPrice)
+	return true
+}
@@ -0,0 +1,202 @@
+package exchange
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/order"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	gctkline "github.com/thrasher-corp/gocryptotrader/exchanges/kline"
+	gctorder "github.com/thrasher-corp/gocryptotrader/exchanges/order"
+)
+
+func TestTrailingStopTriggerPrice(t *testing.T) {
+	t.Parallel()
+	mark := decimal.NewFromInt(200)
+	for _, tc := range []struct {
+		direction gctorder.Side
+		distance  decimal.Decimal
+		percent   decimal.Decimal
+		expected  decimal.Decimal
+	}{
+		{gctorder.Sell, decimal.NewFromInt(5), decimal.Zero, decimal.NewFromInt(195)},
+		{gctorder.Short, decimal.Zero, decimal.NewFromInt(10), decimal.NewFromInt(180)},
+		{gctorder.Buy, decimal.NewFromInt(5), decimal.Zero, decimal.NewFromInt(205)},
+		{gctorder.Long, decimal.Zero, decimal.NewFromInt(10), decimal.NewFromInt(220)},
+		{gctorder.UnknownSide, decimal.NewFromInt(5), decimal.Zero, decimal.Zero},
+	} {
+		if price := trailingStopTriggerPrice(tc.direction, mark, tc.distance, tc.percent); !price.Equal(tc.expected) {
+			t.Errorf("%v received '%v' expected '%v'", tc.direction, price, tc.expected)
+		}
+	}
+}
+
+func TestTrailHighWaterMark(t *testing.T) {
+	t.Parallel()
+	mark := decimal.NewFromInt(100)
+	for _, tc := range []struct {
+		direction gctorder.Side
+		high, low decimal.Decimal
+		expected  decimal.Decimal
+	}{
+		{gctorder.Sell, decimal.NewFromInt(110), decimal.NewFromInt(90), decimal.NewFromInt(110)},
+		{gctorder.Sell, decimal.NewFromInt(99), decimal.NewFromInt(90), mark},
+		{gctorder.Buy, decimal.NewFromInt(110), decimal.NewFromInt(90), decimal.NewFromInt(90)},
+		{gctorder.Buy, decimal.NewFromInt(110), decimal.NewFromInt(101), mark},
+		{gctorder.UnknownSide, decimal.NewFromInt(110), decimal.NewFromInt(90), mark},
+	} {
+		if trailed := trailHighWaterMark(tc.direction, mark, tc.high, tc.low); !trailed.Equal(tc.expected) {
+			t.Errorf("%v high %v low %v received '%v' expected '%v'", tc.direction, tc.high, tc.low, trailed, tc.expected)
+		}
+	}
+}
+
+func TestExecuteOrderTrailingStopLong(t *testing.T) {
+	t.Parallel()
+	om, exch := setupOfflineOrderManager(t)
+	first := gctkline.Candle{Open: 100, Close: 100, High: 104, Low: 96, Volume: 1000}
+	o, d := setupOfflineOrder(t, gctorder.Sell, decimal.NewFromInt(1), decimal.NewFromInt(100), first)
+	o.TrailingStopDistance = decimal.NewFromInt(5)
+	cs := Settings{
+		Exchange:               exch,
+		Pair:                   o.Pair(),
+		Asset:                  o.GetAssetType(),
+		MinimumSlippageRate:    decimal.NewFromInt(100),
+		MaximumSlippageRate:    decimal.NewFromInt(100),
+		SignalToFillConvention: SameCandleClose,
+	}
+	e := Exchange{CurrencySettings: []Settings{cs}}
+	// the trail starts from the price the order was placed at
+	f, err := e.ExecuteOrder(context.Background(), o, d, om, &fakeFund{})
+	if !errors.Is(err, ErrCannotTransact) {
+		t.Fatalf("received '%v' expected '%v'", err, ErrCannotTransact)
+	}
+	if f.GetDirection() != gctorder.DoNothing || !f.IsDormantTrailingStop() {
+		t.Errorf("received '%v' '%v' expected '%v' '%v'", f.GetDirection(), f.IsDormantTrailingStop(), gctorder.DoNothing, true)
+	}
+	if !f.GetTrailingStopHighWaterMark().Equal(decimal.NewFromInt(100)) || !f.GetTrailingStopTriggerPrice().Equal(decimal.NewFromInt(95)) {
+		t.Errorf("received '%v' '%v' expected '%v' '%v'", f.GetTrailingStopHighWaterMark(), f.GetTrailingStopTriggerPrice(), 100, 95)
+	}
+
+	// the next candle rallies without reaching the trigger, raising the high-water mark
+	second := gctkline.Candle{Open: 101, Close: 108, High: 110, Low: 97, Volume: 1000}
+	released := releaseNextCandle(t, &e, o, decimal.NewFromInt(101))
+	_, d = setupOfflineOrder(t, gctorder.Sell, decimal.NewFromInt(1), decimal.NewFromInt(100), first, second)
+	f, err = e.ExecuteOrder(context.Background(), released[0], d, om, &fakeFund{})
+	if !errors.Is(err, ErrCannotTransact) {
+		t.Fatalf("received '%v' expected '%v'", err, ErrCannotTransact)
+	}
+	if !f.GetTrailingStopHighWaterMark().Equal(decimal.NewFromInt(110)) || !f.GetTrailingStopTriggerPrice().Equal(decimal.NewFromInt(105)) {
+		t.Errorf("received '%v' '%v' expected '%v' '%v'", f.GetTrailingStopHighWaterMark(), f.GetTrailingStopTriggerPrice(), 110, 105)
+	}
+	if len(e.deferredOrders) != 1 || !e.deferredOrders[0].GetTrailingStopHighWaterMark().Equal(decimal.NewFromInt(110)) {
+		t.Fatalf("expected a single dormant trailing stop carrying its high-water mark, received '%v'", e.deferredOrders)
+	}
+
+	// the following candle retraces through the trigger price
+	released = releaseNextCandle(t, &e, released[0].(*order.Order), decimal.NewFromInt(108))
+	_, d = setupOfflineOrder(t, gctorder.Sell, decimal.NewFromInt(1), decimal.NewFromInt(100),
+		first, second, gctkline.Candle{Open: 108, Close: 104, High: 109, Low: 103, Volume: 1000})
+	f, err = e.ExecuteOrder(context.Background(), released[0], d, om, &fakeFund{})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	if f.GetDirection() != gctorder.Sell || f.IsDormantTrailingStop() {
+		t.Errorf("received '%v' '%v' expected '%v' '%v'", f.GetDirection(), f.IsDormantTrailingStop(), gctorder.Sell, false)
+	}
+	if !f.GetTrailingStopHighWaterMark().Equal(decimal.NewFromInt(110)) || !f.GetTrailingStopTriggerPrice().Equal(decimal.NewFromInt(105)) {
+		t.Errorf("received '%v' '%v' expected '%v' '%v'", f.GetTrailingStopHighWaterMark(), f.GetTrailingStopTriggerPrice(), 110, 105)
+	}
+	if !f.GetPurchasePrice().Equal(decimal.NewFromInt(105)) {
+		t.Errorf("received '%v' expected '%v'", f.GetPurchasePrice(), 105)
+	}
+	if len(e.deferredOrders) != 0 {
+		t.Errorf("received '%v' expected '%v'", len(e.deferredOrders), 0)
+	}
+}
+
+func TestExecuteOrderTrailingStopShort(t *testing.T) {
+	t.Parallel()
+	om, exch := setupOfflineOrderManager(t)
+	first := gctkline.Candle{Open: 100, Close: 100, High: 104, Low: 96, Volume: 1000}
+	o, d := setupOfflineOrder(t, gctorder.Long, decimal.NewFromInt(1), decimal.NewFromInt(100), first)
+	o.AssetType = asset.Futures
+	o.TrailingStopPercent = decimal.NewFromInt(10)
+	e := Exchange{CurrencySettings: []Settings{{
+		Exchange:               exch,
+		Pair:                   o.Pair(),
+		Asset:                  asset.Futures,
+		MinimumSlippageRate:    decimal.NewFromInt(100),
+		MaximumSlippageRate:    decimal.NewFromInt(100),
+		SignalToFillConvention: SameCandleClose,
+	}}}
+	f, err := e.ExecuteOrder(context.Background(), o, d, om, &fakeFund{})
+	if !errors.Is(err, ErrCannotTransact) {
+		t.Fatalf("received '%v' expected '%v'", err, ErrCannotTransact)
+	}
+	if !f.IsDormantTrailingStop() || !f.GetTrailingStopTriggerPrice().Equal(decimal.NewFromInt(110)) {
+		t.Errorf("received '%v' '%v' expected '%v' '%v'", f.IsDormantTrailingStop(), f.GetTrailingStopTriggerPrice(), true, 110)
+	}
+
+	// the next candle sells off, lowering the high-water mark the trigger trails
+	second := gctkline.Candle{Open: 95, Close: 82, High: 98, Low: 80, Volume: 1000}
+	released := releaseNextCandle(t, &e, o, decimal.NewFromInt(95))
+	_, d = setupOfflineOrder(t, gctorder.Long, decimal.NewFromInt(1), decimal.NewFromInt(100), first, second)
+	f, err = e.ExecuteOrder(context.Background(), released[0], d, om, &fakeFund{})
+	if !errors.Is(err, ErrCannotTransact) {
+		t.Fatalf("received '%v' expected '%v'", err, ErrCannotTransact)
+	}
+	if !f.GetTrailingStopHighWaterMark().Equal(decimal.NewFromInt(80)) || !f.GetTrailingStopTriggerPrice().Equal(decimal.NewFromInt(88)) {
+		t.Errorf("received '%v' '%v' expected '%v' '%v'", f.GetTrailingStopHighWaterMark(), f.GetTrailingStopTriggerPrice(), 80, 88)
+	}
+
+	// the following candle gaps up through the trigger, executing at its open
+	released = releaseNextCandle(t, &e, released[0].(*order.Order), decimal.NewFromInt(90))
+	_, d = setupOfflineOrder(t, gctorder.Long, decimal.NewFromInt(1), decimal.NewFromInt(100),
+		first, second, gctkline.Candle{Open: 90, Close: 91, High: 92, Low: 89, Volume: 1000})
+	f, err = e.ExecuteOrder(context.Background(), released[0], d, om, &fakeFund{})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	if f.GetDirection() != gctorder.Long || !f.GetPurchasePrice().Equal(decimal.NewFromInt(90)) {
+		t.Errorf("received '%v' '%v' expected gap to execute worse than the trigger at '%v'", f.GetDirection(), f.GetPurchasePrice(), 90)
+	}
+	if !f.GetTrailingStopHighWaterMark().Equal(decimal.NewFromInt(80)) || !f.GetTrailingStopTriggerPrice().Equal(decimal.NewFromInt(88)) {
+		t.Errorf("received '%v' '%v' expected '%v' '%v'", f.GetTrailingStopHighWaterMark(), f.GetTrailingStopTriggerPrice(), 80, 88)
+	}
+}
+
+func TestExecuteOrderInvalidTrailingStop(t *testing.T) {
+	t.Parallel()
+	om, exch := setupOfflineOrderManager(t)
+	o, d := setupOfflineOrder(t, gctorder.Sell, decimal.NewFromInt(1), decimal.NewFromInt(100),
+		gctkline.Candle{Open: 100, Close: 100, High: 104, Low: 96, Volume: 1000})
+	cs := Settings{
+		Exchange:               exch,
+		Pair:                   o.Pair(),
+		Asset:                  o.GetAssetType(),
+		MinimumSlippageRate:    decimal.NewFromInt(100),
+		MaximumSlippageRate:    decimal.NewFromInt(100),
+		SignalToFillConvention: SameCandleClose,
+	}
+	e := Exchange{CurrencySettings: []Settings{cs}}
+	for _, tc := range []struct {
+		distance, percent, stopPrice decimal.Decimal
+	}{
+		{decimal.NewFromInt(-1), decimal.Zero, decimal.Zero},
+		{decimal.Zero, decimal.NewFromInt(-1), decimal.Zero},
+		{decimal.Zero, decimal.NewFromInt(100), decimal.Zero},
+		{decimal.NewFromInt(5), decimal.NewFromInt(5), decimal.Zero},
+		{decimal.NewFromInt(5), decimal.Zero, decimal.NewFromInt(95)},
+	} {
+		o.TrailingStopDistance = tc.distance
+		o.TrailingStopPercent = tc.percent
+		o.StopPrice = tc.stopPrice
+		_, err := e.ExecuteOrder(context.Background(), o, d, om, &fakeFund{})
+		if !errors.Is(err, errInvalidTrailingStop) {
+			t.Errorf("distance %v percent %v stop %v received '%v' expected '%v'", tc.distance, tc.percent, tc.stopPrice, err, errInvalidTrailingStop)
+		}
+	}
+}
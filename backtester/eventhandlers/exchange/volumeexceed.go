@@ -0,0 +1,16 @@
+package exchange
+
+import (
+	"github.com/shopspring/decimal"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/fill"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/order"
+	"github.com/thrasher-corp/gocryptotrader/backtester/funding"
+)
+
+// handleCandleVolumeExceeded rejects an order which exceeds the volume of the
+// candle it is fitted against rather than shrinking it, for strategies where
+// a partially sized position is worse than none
+func handleCandleVolumeExceeded(o order.Event, f *fill.Fill, funds funding.IFundReleaser, cs *Settings, amount, fitAmount decimal.Decimal) (fill.Event, error) {
+	f.AppendReasonf("Order size %v exceeds the %v fitting the candle's volume, rejected", amount, fitAmount)
+	return f, allocateFundsPostOrder(f, funds, errCandleVolumeExceeded, o.GetAmount(), o.GetAllocatedFunds(), decimal.Zero, decimal.Zero, decimal.Zero, cs)
+}
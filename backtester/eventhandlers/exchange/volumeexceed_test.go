@@ -0,0 +1,63 @@
+package exchange
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	gctkline "github.com/thrasher-corp/gocryptotrader/exchanges/kline"
+	gctorder "github.com/thrasher-corp/gocryptotrader/exchanges/order"
+)
+
+func TestExecuteOrderVolumeExceedBehaviour(t *testing.T) {
+	t.Parallel()
+	om, exch := setupOfflineOrderManager(t)
+	amount := decimal.NewFromInt(10)
+	for _, tc := range []struct {
+		behaviour string
+		err       error
+		direction gctorder.Side
+		reason    string
+	}{
+		{"", nil, gctorder.Buy, "to fit candle"},
+		{VolumeExceedShrink, nil, gctorder.Buy, "to fit candle"},
+		{VolumeExceedReject, errCandleVolumeExceeded, gctorder.CouldNotBuy, "rejected"},
+		{VolumeExceedAllow, nil, gctorder.Buy, "filled in full"},
+	} {
+		// the candle's volume of 5 cannot fill the order of 10
+		o, d := setupOfflineOrder(t, gctorder.Buy, amount, decimal.NewFromInt(100),
+			gctkline.Candle{Open: 100, Close: 100, High: 100, Low: 100, Volume: 5})
+		e := Exchange{CurrencySettings: []Settings{{
+			Exchange:               exch,
+			Pair:                   o.Pair(),
+			Asset:                  o.GetAssetType(),
+			MinimumSlippageRate:    decimal.NewFromInt(100),
+			MaximumSlippageRate:    decimal.NewFromInt(100),
+			VolumeExceedBehaviour:  tc.behaviour,
+			SignalToFillConvention: SameCandleClose,
+		}}}
+		f, err := e.ExecuteOrder(context.Background(), o, d, om, &fakeFund{})
+		if !errors.Is(err, tc.err) {
+			t.Fatalf("'%v' received '%v' expected '%v'", tc.behaviour, err, tc.err)
+		}
+		if f.GetDirection() != tc.direction {
+			t.Errorf("'%v' received '%v' expected '%v'", tc.behaviour, f.GetDirection(), tc.direction)
+		}
+		if !strings.Contains(f.GetConcatReasons(), tc.reason) {
+			t.Errorf("'%v' received reasons '%v' expected to contain '%v'", tc.behaviour, f.GetConcatReasons(), tc.reason)
+		}
+		switch tc.behaviour {
+		case VolumeExceedAllow:
+			if !f.GetAmount().Equal(amount) || f.IsVolumeAdjusted() {
+				t.Errorf("'%v' received '%v' '%v' expected '%v' '%v'", tc.behaviour, f.GetAmount(), f.IsVolumeAdjusted(), amount, false)
+			}
+		case VolumeExceedReject:
+		default:
+			if !f.GetAmount().LessThan(amount) || !f.IsVolumeAdjusted() {
+				t.Errorf("'%v' received '%v' '%v' expected less than '%v' '%v'", tc.behaviour, f.GetAmount(), f.IsVolumeAdjusted(), amount, true)
+			}
+		}
+	}
+}
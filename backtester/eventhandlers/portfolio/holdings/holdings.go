@@ -41,7 +41,7 @@ func Create(ev ClosePriceReader, fundReader funding.IFundReader) (Holding, error
 			return Holding{}, ErrInitialFundsZero
 		}
 
-		return Holding{
+		h := Holding{
 			Offset:            ev.GetOffset(),
 			Pair:              ev.Pair(),
 			Asset:             ev.GetAssetType(),
@@ -52,11 +52,34 @@ func Create(ev ClosePriceReader, fundReader funding.IFundReader) (Holding, error
 			BaseInitialFunds:  funds.BaseInitialFunds(),
 			BaseSize:          funds.BaseInitialFunds(),
 			TotalInitialValue: funds.QuoteInitialFunds().Add(funds.BaseInitialFunds().Mul(ev.GetClosePrice())),
-		}, nil
+		}
+		if h.BaseInitialFunds.IsPositive() {
+			h.AverageEntryPrice = ev.GetClosePrice()
+		}
+		return h, nil
 	}
 	return Holding{}, fmt.Errorf("%v %w", ev.GetAssetType(), asset.ErrNotSupported)
 }
 
+// SetInitialEntryPrice sets the price the spot initial base funds were bought
+// at, so that the run starts with an open position. The initial value and
+// unrealised PNL of the position are measured from the entry price rather
+// than the price at creation
+func (h *Holding) SetInitialEntryPrice(price decimal.Decimal) error {
+	if !price.IsPositive() {
+		return fmt.Errorf("%w %v, must be greater than zero", errInvalidEntryPrice, price)
+	}
+	if h.Asset != asset.Spot || !h.BaseInitialFunds.IsPositive() {
+		return fmt.Errorf("%w, requires spot initial base funds", errInvalidEntryPrice)
+	}
+	h.AverageEntryPrice = price
+	h.TotalInitialValue = h.QuoteInitialFunds.Add(h.BaseInitialFunds.Mul(price))
+	if !h.BaseValue.IsZero() {
+		h.UnrealisedPNL = h.BaseValue.Sub(h.BaseSize.Mul(price))
+	}
+	return nil
+}
+
 // Update calculates holding statistics for the events time
 func (h *Holding) Update(e fill.Event, f funding.IFundReader) error {
 	h.Timestamp = e.GetTime()
@@ -89,6 +112,7 @@ func (h *Holding) update(e fill.Event, f funding.IFundReader) error {
 		if err != nil {
 			return err
 		}
+		h.updateAverageEntryPrice(direction, amount, price, spotR.BaseAvailable())
 		h.BaseSize = spotR.BaseAvailable()
 		h.QuoteSize = spotR.QuoteAvailable()
 	case a.IsFutures():
@@ -130,11 +154,31 @@ func (h *Holding) update(e fill.Event, f funding.IFundReader) error {
 	return nil
 }
 
+// updateAverageEntryPrice weights the average entry price of the spot base
+// held by any amount bought. Sales do not change the average entry price
+// of the remaining base, which is reset once no base is held
+func (h *Holding) updateAverageEntryPrice(direction order.Side, amount, price, baseSize decimal.Decimal) {
+	if !baseSize.IsPositive() {
+		h.AverageEntryPrice = decimal.Zero
+		return
+	}
+	if (direction != order.Buy && direction != order.Bid) || !amount.IsPositive() {
+		return
+	}
+	previousSize := decimal.Max(baseSize.Sub(amount), decimal.Zero)
+	h.AverageEntryPrice = h.AverageEntryPrice.Mul(previousSize).Add(price.Mul(amount)).Div(previousSize.Add(amount))
+}
+
 func (h *Holding) scaleValuesToCurrentPrice(currentPrice decimal.Decimal) {
 	origPosValue := h.BaseValue
 	origTotalValue := h.TotalValue
 	h.BaseValue = h.BaseSize.Mul(currentPrice)
 	h.TotalValue = h.BaseValue.Add(h.QuoteSize)
+	if h.AverageEntryPrice.IsPositive() {
+		h.UnrealisedPNL = h.BaseValue.Sub(h.BaseSize.Mul(h.AverageEntryPrice))
+	} else {
+		h.UnrealisedPNL = decimal.Zero
+	}
 
 	h.TotalValueDifference = h.TotalValue.Sub(origTotalValue)
 	h.PositionsValueDifference = h.BaseValue.Sub(origPosValue)
@@ -358,3 +358,80 @@ func TestUpdateSellStats(t *testing.T) {
 		t.Errorf("expected '%v' received '%v'", 2, h.TotalFees)
 	}
 }
+
+func TestSetInitialEntryPrice(t *testing.T) {
+	t.Parallel()
+	b, err := funding.CreateItem(testExchange, asset.Spot, currency.BTC, decimal.NewFromInt(2), decimal.Zero)
+	if err != nil {
+		t.Fatal(err)
+	}
+	q, err := funding.CreateItem(testExchange, asset.Spot, currency.USDT, decimal.NewFromInt(100), decimal.Zero)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p, err := funding.CreatePair(b, q)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ev := &kline.Kline{
+		Base:  &event.Base{AssetType: asset.Spot},
+		Close: decimal.NewFromInt(50),
+	}
+	h, err := Create(ev, p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !h.AverageEntryPrice.Equal(decimal.NewFromInt(50)) {
+		t.Errorf("received '%v' expected '%v'", h.AverageEntryPrice, 50)
+	}
+	err = h.SetInitialEntryPrice(decimal.Zero)
+	if !errors.Is(err, errInvalidEntryPrice) {
+		t.Errorf("received '%v' expected '%v'", err, errInvalidEntryPrice)
+	}
+	err = h.SetInitialEntryPrice(decimal.NewFromInt(40))
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	// 100 quote and 2 base bought at 40
+	if !h.TotalInitialValue.Equal(decimal.NewFromInt(180)) {
+		t.Errorf("received '%v' expected '%v'", h.TotalInitialValue, 180)
+	}
+	h.UpdateValue(ev)
+	if !h.UnrealisedPNL.Equal(decimal.NewFromInt(20)) {
+		t.Errorf("received '%v' expected '%v'", h.UnrealisedPNL, 20)
+	}
+
+	flat, err := Create(ev, pair(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !flat.AverageEntryPrice.IsZero() {
+		t.Errorf("received '%v' expected '%v'", flat.AverageEntryPrice, 0)
+	}
+	err = flat.SetInitialEntryPrice(decimal.NewFromInt(40))
+	if !errors.Is(err, errInvalidEntryPrice) {
+		t.Errorf("received '%v' expected '%v'", err, errInvalidEntryPrice)
+	}
+}
+
+func TestUpdateAverageEntryPrice(t *testing.T) {
+	t.Parallel()
+	h := Holding{AverageEntryPrice: decimal.NewFromInt(40)}
+	// buying 2 at 70 on top of 2 held at 40
+	h.updateAverageEntryPrice(order.Buy, decimal.NewFromInt(2), decimal.NewFromInt(70), decimal.NewFromInt(4))
+	if !h.AverageEntryPrice.Equal(decimal.NewFromInt(55)) {
+		t.Errorf("received '%v' expected '%v'", h.AverageEntryPrice, 55)
+	}
+	h.updateAverageEntryPrice(order.Sell, decimal.NewFromInt(1), decimal.NewFromInt(100), decimal.NewFromInt(3))
+	if !h.AverageEntryPrice.Equal(decimal.NewFromInt(55)) {
+		t.Errorf("received '%v' expected '%v'", h.AverageEntryPrice, 55)
+	}
+	h.updateAverageEntryPrice(order.Sell, decimal.NewFromInt(3), decimal.NewFromInt(100), decimal.Zero)
+	if !h.AverageEntryPrice.IsZero() {
+		t.Errorf("received '%v' expected '%v'", h.AverageEntryPrice, 0)
+	}
+	h.updateAverageEntryPrice(order.Buy, decimal.NewFromInt(1), decimal.NewFromInt(80), decimal.NewFromInt(1))
+	if !h.AverageEntryPrice.Equal(decimal.NewFromInt(80)) {
+		t.Errorf("received '%v' expected '%v'", h.AverageEntryPrice, 80)
+	}
+}
@@ -10,8 +10,12 @@ import (
 	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
 )
 
-// ErrInitialFundsZero is an error when initial funds are zero or less
-var ErrInitialFundsZero = errors.New("initial funds <= 0")
+var (
+	// ErrInitialFundsZero is an error when initial funds are zero or less
+	ErrInitialFundsZero = errors.New("initial funds <= 0")
+
+	errInvalidEntryPrice = errors.New("invalid entry price")
+)
 
 // Holding contains pricing statistics for a given time
 // for a given exchange asset pair
@@ -32,6 +36,10 @@ type Holding struct {
 	SoldValue         decimal.Decimal `json:"sold-value"`
 	BoughtAmount      decimal.Decimal `json:"bought-amount"`
 	CommittedFunds    decimal.Decimal `json:"committed-funds"`
+	// AverageEntryPrice is the volume weighted price of the spot
+	// base held, including any initial base funds
+	AverageEntryPrice decimal.Decimal `json:"average-entry-price"`
+	UnrealisedPNL     decimal.Decimal `json:"unrealised-pnl"`
 
 	IsLiquidated bool
 
@@ -42,11 +42,21 @@ func (p *Portfolio) OnSignal(ev signal.Event, cs *exchange.Settings, funds fundi
 	}
 
 	o := &order.Order{
-		Base:               ev.GetBase(),
-		Direction:          ev.GetDirection(),
-		FillDependentEvent: ev.GetFillDependentEvent(),
-		Amount:             ev.GetAmount(),
-		ClosePrice:         ev.GetClosePrice(),
+		Base:                 ev.GetBase(),
+		Direction:            ev.GetDirection(),
+		FillDependentEvent:   ev.GetFillDependentEvent(),
+		Amount:               ev.GetAmount(),
+		ClosePrice:           ev.GetClosePrice(),
+		MaxSlippageTolerance: ev.GetMaxSlippageTolerance(),
+		Tag:                  ev.GetTag(),
+		Account:              ev.GetAccount(),
+		FeeOverride:          ev.GetFeeOverride(),
+		LimitPrice:           ev.GetLimitPrice(),
+		StopPrice:            ev.GetStopPrice(),
+		TakeProfitPrice:      ev.GetTakeProfitPrice(),
+		TakeProfitPercent:    ev.GetTakeProfitPercent(),
+		TrailingStopDistance: ev.GetTrailingStopDistance(),
+		TrailingStopPercent:  ev.GetTrailingStopPercent(),
 	}
 	if ev.GetDirection() == gctorder.UnknownSide {
 		return o, errInvalidDirection
@@ -66,14 +76,27 @@ func (p *Portfolio) OnSignal(ev signal.Event, cs *exchange.Settings, funds fundi
 		ev.GetDirection() == gctorder.TransferredFunds {
 		return o, nil
 	}
+	if !ev.GetClosePrice().IsPositive() {
+		return rejectInvalidClosePrice(ev, o)
+	}
 	if !funds.CanPlaceOrder(ev.GetDirection()) {
 		return cannotPurchase(ev, o)
 	}
 
 	o.OrderType = gctorder.Market
+	switch {
+	case o.LimitPrice.IsPositive():
+		o.OrderType = gctorder.Limit
+	case o.StopPrice.IsPositive():
+		o.OrderType = gctorder.Stop
+	case o.TakeProfitPrice.IsPositive() || o.TakeProfitPercent.IsPositive():
+		o.OrderType = gctorder.TakeProfit
+	case o.TrailingStopDistance.IsPositive() || o.TrailingStopPercent.IsPositive():
+		o.OrderType = gctorder.TrailingStop
+	}
 	o.BuyLimit = ev.GetBuyLimit()
 	o.SellLimit = ev.GetSellLimit()
-	var sizingFunds decimal.Decimal
+	var sizingFunds, initialFunds decimal.Decimal
 	var side = ev.GetDirection()
 	if ev.GetAssetType() == asset.Spot {
 		if side == gctorder.ClosePosition {
@@ -83,12 +106,30 @@ func (p *Portfolio) OnSignal(ev signal.Event, cs *exchange.Settings, funds fundi
 		if err != nil {
 			return nil, err
 		}
+		initialFunds = pReader.QuoteInitialFunds()
 		switch side {
 		case gctorder.Buy, gctorder.Bid:
 			sizingFunds = pReader.QuoteAvailable()
 		case gctorder.Sell, gctorder.Ask:
 			sizingFunds = pReader.BaseAvailable()
 		}
+		if len(lookup.accounts) > 0 {
+			if _, ok := lookup.accounts[ev.GetAccount()]; !ok && ev.GetAccount() != "" {
+				return rejectUnknownAccount(ev, o)
+			}
+			lookup.seedAccountBalances(pReader)
+			pool, _ := lookup.accountPool(ev.GetAccount())
+			ab := lookup.accountBalances[pool]
+			available := ab.quote
+			if side == gctorder.Sell || side == gctorder.Ask {
+				available = ab.base
+			}
+			available = decimal.Max(available, decimal.Zero)
+			if available.LessThan(sizingFunds) {
+				o.AppendReasonf("Sizing funds limited from %v to %v available in account %q", sizingFunds, available, ev.GetAccount())
+				sizingFunds = available
+			}
+		}
 	} else if ev.GetAssetType().IsFutures() {
 		if ev.GetDirection() == gctorder.ClosePosition {
 			// lookup position
@@ -111,6 +152,14 @@ func (p *Portfolio) OnSignal(ev signal.Event, cs *exchange.Settings, funds fundi
 				return nil, err
 			}
 			sizingFunds = collateralFunds.AvailableFunds()
+			initialFunds = collateralFunds.InitialFunds()
+		}
+	}
+	if len(lookup.tagAllocations) > 0 && lookup.isTagEntry(ev.GetTag(), ev.GetDirection(), ev.GetAssetType()) {
+		available := lookup.availableTagCapital(ev.GetTag(), initialFunds)
+		if available.LessThan(sizingFunds) {
+			o.AppendReasonf("Sizing funds limited from %v to %v remaining in the allocation of tag %q", sizingFunds, available, ev.GetTag())
+			sizingFunds = available
 		}
 	}
 	if sizingFunds.LessThanOrEqual(decimal.Zero) {
@@ -154,6 +203,48 @@ func cannotPurchase(ev signal.Event, o *order.Order) (*order.Order, error) {
 	return o, nil
 }
 
+// rejectInvalidClosePrice rejects a signal whose close price is zero or
+// negative, as bad data would otherwise be fed into sizing and slippage
+func rejectInvalidClosePrice(ev signal.Event, o *order.Order) (*order.Order, error) {
+	if ev == nil {
+		return nil, common.ErrNilEvent
+	}
+	if o == nil {
+		return nil, fmt.Errorf("%w received nil order for %v %v %v", common.ErrNilArguments, ev.GetExchange(), ev.GetAssetType(), ev.Pair())
+	}
+	o.AppendReasonf("Order rejected, close price %v must be positive", ev.GetClosePrice())
+	switch ev.GetDirection() {
+	case gctorder.Buy, gctorder.Bid:
+		o.SetDirection(gctorder.CouldNotBuy)
+	case gctorder.Sell, gctorder.Ask:
+		o.SetDirection(gctorder.CouldNotSell)
+	case gctorder.Short:
+		o.SetDirection(gctorder.CouldNotShort)
+	case gctorder.Long:
+		o.SetDirection(gctorder.CouldNotLong)
+	default:
+		o.SetDirection(gctorder.DoNothing)
+	}
+	ev.SetDirection(o.Direction)
+	return o, nil
+}
+
+// rejectUnknownAccount rejects a signal funded by an account
+// which is not configured for its exchange, asset and pair
+func rejectUnknownAccount(ev signal.Event, o *order.Order) (*order.Order, error) {
+	o.AppendReasonf("Order rejected, account %q is not configured", ev.GetAccount())
+	switch ev.GetDirection() {
+	case gctorder.Buy, gctorder.Bid:
+		o.SetDirection(gctorder.CouldNotBuy)
+	case gctorder.Sell, gctorder.Ask, gctorder.ClosePosition:
+		o.SetDirection(gctorder.CouldNotSell)
+	default:
+		o.SetDirection(gctorder.DoNothing)
+	}
+	ev.SetDirection(o.Direction)
+	return o, nil
+}
+
 func (p *Portfolio) evaluateOrder(d common.Directioner, originalOrderSignal, ev *order.Order) (*order.Order, error) {
 	var evaluatedOrder *order.Order
 	cm, err := p.GetComplianceManager(originalOrderSignal.GetExchange(), originalOrderSignal.GetAssetType(), originalOrderSignal.Pair())
@@ -205,6 +296,30 @@ func (p *Portfolio) sizeOrder(d common.Directioner, cs *exchange.Settings, origi
 		}
 		originalOrderSignal.AppendReason("sized order to 0")
 	}
+	sizedAmount := sizedOrder.Amount
+	rejected, err := p.applyFlipPolicy(cs, sizedOrder)
+	if err != nil {
+		return nil, err
+	}
+	if rejected {
+		switch originalOrderSignal.Direction {
+		case gctorder.Buy, gctorder.Bid:
+			originalOrderSignal.Direction = gctorder.CouldNotBuy
+		case gctorder.Sell, gctorder.Ask:
+			originalOrderSignal.Direction = gctorder.CouldNotSell
+		case gctorder.Long:
+			originalOrderSignal.Direction = gctorder.CouldNotLong
+		case gctorder.Short:
+			originalOrderSignal.Direction = gctorder.CouldNotShort
+		default:
+			originalOrderSignal.Direction = gctorder.DoNothing
+		}
+		d.SetDirection(originalOrderSignal.Direction)
+		return originalOrderSignal, nil
+	}
+	if sizedAmount.IsPositive() && !sizedOrder.Amount.Equal(sizedAmount) {
+		estFee = estFee.Mul(sizedOrder.Amount).Div(sizedAmount)
+	}
 	switch d.GetDirection() {
 	case gctorder.Buy,
 		gctorder.Bid,
@@ -247,7 +362,7 @@ func (p *Portfolio) OnFill(ev fill.Event, funds funding.IFundReleaser) (fill.Eve
 	} else {
 		h = lookup.GetLatestHoldings()
 		if h.Timestamp.IsZero() {
-			h, err = holdings.Create(ev, funds)
+			h, err = lookup.createHoldings(ev, funds)
 			if err != nil {
 				return nil, err
 			}
@@ -265,6 +380,16 @@ func (p *Portfolio) OnFill(ev fill.Event, funds funding.IFundReleaser) (fill.Eve
 	if err != nil {
 		log.Error(common.Portfolio, err)
 	}
+	lookup.updateTagCapital(ev)
+	if len(lookup.accounts) > 0 {
+		var pReader funding.IPairReader
+		pReader, err = funds.GetPairReader()
+		if err != nil {
+			return nil, err
+		}
+		lookup.seedAccountBalances(pReader)
+		lookup.updateAccountBalances(ev)
+	}
 
 	err = p.addComplianceSnapshot(ev)
 	if err != nil {
@@ -385,7 +510,7 @@ func (p *Portfolio) UpdateHoldings(e common.DataEventHandler, funds funding.IFun
 	}
 	h := settings.GetLatestHoldings()
 	if h.Timestamp.IsZero() {
-		h, err = holdings.Create(e, funds)
+		h, err = settings.createHoldings(e, funds)
 		if err != nil {
 			return err
 		}
@@ -441,6 +566,22 @@ func (s *Settings) GetLatestHoldings() holdings.Holding {
 	return s.HoldingsSnapshots[len(s.HoldingsSnapshots)-1]
 }
 
+// createHoldings creates the first holdings for the currency, starting
+// with an open position at the initial entry price when one is set
+func (s *Settings) createHoldings(ev holdings.ClosePriceReader, funds funding.IFundReader) (holdings.Holding, error) {
+	h, err := holdings.Create(ev, funds)
+	if err != nil {
+		return h, err
+	}
+	if s.initialEntryPrice.IsPositive() {
+		err = h.SetInitialEntryPrice(s.initialEntryPrice)
+		if err != nil {
+			return h, err
+		}
+	}
+	return h, nil
+}
+
 // GetHoldingsForTime returns the holdings for a time period, or an empty holding if not found
 func (s *Settings) GetHoldingsForTime(t time.Time) holdings.Holding {
 	for i := len(s.HoldingsSnapshots) - 1; i >= 0; i-- {
@@ -451,6 +592,96 @@ func (s *Settings) GetHoldingsForTime(t time.Time) holdings.Holding {
 	return holdings.Holding{}
 }
 
+// tagPool returns the allocation pool a strategy tag's orders draw from and
+// its percentage of the initial funds. Tags without an allocation, including
+// untagged orders, share the unallocated remainder
+func (s *Settings) tagPool(tag string) (pool string, percent decimal.Decimal) {
+	if percent, ok := s.tagAllocations[tag]; ok && tag != "" {
+		return tag, percent
+	}
+	remainder := decimal.NewFromInt(100)
+	for _, allocation := range s.tagAllocations {
+		remainder = remainder.Sub(allocation)
+	}
+	return "", remainder
+}
+
+// isTagEntry returns whether an order in the direction commits more of its
+// tag's allocation. Spot buys are entries, as are futures orders when the
+// tag's pool is flat or positioned in the same direction
+func (s *Settings) isTagEntry(tag string, side gctorder.Side, item asset.Item) bool {
+	if side == gctorder.ClosePosition {
+		return false
+	}
+	if !item.IsFutures() {
+		return side.IsLong()
+	}
+	pool, _ := s.tagPool(tag)
+	tc := s.tagCapital[pool]
+	return tc == nil || !tc.amount.IsPositive() || tc.isLong == side.IsLong()
+}
+
+// availableTagCapital returns the capital remaining in a strategy tag's
+// allocation of the initial funds after its open entries
+func (s *Settings) availableTagCapital(tag string, initialFunds decimal.Decimal) decimal.Decimal {
+	pool, percent := s.tagPool(tag)
+	available := initialFunds.Mul(percent).Div(decimal.NewFromInt(100))
+	if tc := s.tagCapital[pool]; tc != nil {
+		available = available.Sub(tc.capital)
+	}
+	return decimal.Max(available, decimal.Zero)
+}
+
+// updateTagCapital tracks the capital committed by each allocation pool's
+// open entries after a fill. Entries add the filled value, exits release
+// capital in proportion to the amount closed and liquidations release all
+// capital. Futures exits larger than the pool's position open the remainder
+// in the opposite direction
+func (s *Settings) updateTagCapital(f fill.Event) {
+	if len(s.tagAllocations) == 0 || !common.CanTransact(f.GetDirection()) || !f.GetAmount().IsPositive() {
+		return
+	}
+	if f.IsLiquidated() {
+		s.tagCapital = nil
+		return
+	}
+	if s.tagCapital == nil {
+		s.tagCapital = make(map[string]*tagCapital)
+	}
+	pool, _ := s.tagPool(f.GetTag())
+	tc, ok := s.tagCapital[pool]
+	if !ok {
+		tc = &tagCapital{}
+		s.tagCapital[pool] = tc
+	}
+	isLong := f.GetDirection().IsLong()
+	isEntry := isLong
+	if f.GetAssetType().IsFutures() {
+		isEntry = !tc.amount.IsPositive() || tc.isLong == isLong
+	}
+	if isEntry {
+		tc.isLong = isLong
+		tc.capital = tc.capital.Add(f.GetPurchasePrice().Mul(f.GetAmount()).Add(f.GetExchangeFee()))
+		tc.amount = tc.amount.Add(f.GetAmount())
+		return
+	}
+	if !tc.amount.IsPositive() {
+		return
+	}
+	remaining := tc.amount.Sub(f.GetAmount())
+	if remaining.IsPositive() {
+		tc.capital = tc.capital.Mul(remaining).Div(tc.amount)
+		tc.amount = remaining
+		return
+	}
+	*tc = tagCapital{}
+	if flipped := remaining.Neg(); flipped.IsPositive() && f.GetAssetType().IsFutures() {
+		tc.isLong = isLong
+		tc.amount = flipped
+		tc.capital = flipped.Mul(f.GetPurchasePrice())
+	}
+}
+
 // GetPositions returns all futures positions for an event's exchange, asset, pair
 func (p *Portfolio) GetPositions(e common.EventHandler) ([]gctorder.Position, error) {
 	settings, err := p.getFuturesSettingsFromEvent(e)
@@ -488,6 +719,61 @@ func (p *Portfolio) UpdatePNL(e common.EventHandler, closePrice decimal.Decimal)
 	return nil
 }
 
+// AccrueBorrowCost charges an open short futures position the cost of
+// borrowing for the candle, being its notional at the close price multiplied
+// by the annual borrow rate pro-rated to the candle's interval. The cost is
+// removed from the collateral and accumulated separately from the position's PNL
+func (p *Portfolio) AccrueBorrowCost(ev common.DataEventHandler, cr funding.ICollateralReleaser, borrowRatePercent decimal.Decimal) (decimal.Decimal, error) {
+	if ev == nil {
+		return decimal.Zero, common.ErrNilEvent
+	}
+	if cr == nil {
+		return decimal.Zero, fmt.Errorf("%w missing collateral releaser", common.ErrNilArguments)
+	}
+	if !borrowRatePercent.IsPositive() {
+		return decimal.Zero, nil
+	}
+	settings, err := p.getFuturesSettingsFromEvent(ev)
+	if err != nil {
+		return decimal.Zero, err
+	}
+	side, size := settings.getOpenPosition(ev.GetAssetType())
+	if side != gctorder.Short || size.IsZero() {
+		return decimal.Zero, nil
+	}
+	cost := size.Abs().Mul(ev.GetClosePrice()).
+		Mul(borrowRatePercent.Div(decimal.NewFromInt(100))).
+		Mul(decimal.NewFromInt(int64(ev.GetInterval().Duration()))).
+		Div(decimal.NewFromInt(int64(borrowRateYear)))
+	if !cost.IsPositive() {
+		return decimal.Zero, nil
+	}
+	err = cr.ChargeCost(cost)
+	if err != nil {
+		return decimal.Zero, err
+	}
+	settings.borrowCost = settings.borrowCost.Add(cost)
+	return cost, nil
+}
+
+// RecordFundingPayment accumulates a funding rate payment settled against
+// an open futures position separately from the position's PNL. Payments
+// are positive when paid and negative when received
+func (p *Portfolio) RecordFundingPayment(e common.EventHandler, payment decimal.Decimal) error {
+	if e == nil {
+		return common.ErrNilEvent
+	}
+	if payment.IsZero() {
+		return nil
+	}
+	settings, err := p.getFuturesSettingsFromEvent(e)
+	if err != nil {
+		return err
+	}
+	settings.fundingCost = settings.fundingCost.Add(payment)
+	return nil
+}
+
 // TrackFuturesOrder updates the futures tracker with a new order
 // from a fill event
 func (p *Portfolio) TrackFuturesOrder(ev fill.Event, fund funding.IFundReleaser) (*PNLSummary, error) {
@@ -572,6 +858,12 @@ func (p *Portfolio) GetLatestPNLForEvent(e common.EventHandler) (*PNLSummary, er
 	}
 	response.Result = pnlHistory[len(pnlHistory)-1]
 	response.CollateralCurrency = position.CollateralCurrency
+	settings, err := p.getSettings(e.GetExchange(), e.GetAssetType(), e.Pair())
+	if err != nil {
+		return nil, err
+	}
+	response.BorrowCost = settings.borrowCost
+	response.FundingCost = settings.fundingCost
 	return response, nil
 }
 
@@ -685,6 +977,189 @@ func (p *Portfolio) CreateLiquidationOrdersForExchange(ev common.DataEventHandle
 	return closingOrders, nil
 }
 
+// CheckDrawdownStopOut tracks drawdown for the data event and returns orders
+// closing positions when the stop-out threshold is breached. Position scoped
+// stop-outs close the event's position, portfolio scoped stop-outs close every
+// open position. Closing orders are returned without reserving funds
+func (p *Portfolio) CheckDrawdownStopOut(ev common.DataEventHandler, stopOut *exchange.DrawdownStopOut) ([]*order.Order, error) {
+	if ev == nil {
+		return nil, common.ErrNilEvent
+	}
+	if stopOut == nil || !stopOut.MaximumDrawdownPercent.IsPositive() {
+		return nil, nil
+	}
+	settings, err := p.getSettings(ev.GetExchange(), ev.GetAssetType(), ev.Pair())
+	if err != nil {
+		return nil, fmt.Errorf("%v %v %v %w", ev.GetExchange(), ev.GetAssetType(), ev.Pair(), err)
+	}
+	oneHundred := decimal.NewFromInt(100)
+	switch stopOut.Scope {
+	case exchange.DrawdownScopePosition, "":
+		direction, size := settings.getOpenPosition(ev.GetAssetType())
+		if !size.IsPositive() {
+			settings.stopOutExtremePrice = decimal.Zero
+			return nil, nil
+		}
+		price := ev.GetClosePrice()
+		isLong := direction == gctorder.Buy || direction == gctorder.Long
+		if settings.stopOutExtremePrice.IsZero() ||
+			(isLong && price.GreaterThan(settings.stopOutExtremePrice)) ||
+			(!isLong && price.LessThan(settings.stopOutExtremePrice)) {
+			settings.stopOutExtremePrice = price
+		}
+		drawdown := settings.stopOutExtremePrice.Sub(price)
+		if !isLong {
+			drawdown = drawdown.Neg()
+		}
+		drawdown = drawdown.Div(settings.stopOutExtremePrice).Mul(oneHundred)
+		if drawdown.LessThan(stopOut.MaximumDrawdownPercent) {
+			return nil, nil
+		}
+		settings.stopOutExtremePrice = decimal.Zero
+		return []*order.Order{
+			createStopOutOrder(ev, ev.GetExchange(), ev.GetAssetType(), ev.Pair(), direction, size, drawdown, stopOut.Scope),
+		}, nil
+	case exchange.DrawdownScopePortfolio:
+		var totalValue decimal.Decimal
+		allHoldings := p.GetLatestHoldingsForAllCurrencies()
+		for i := range allHoldings {
+			totalValue = totalValue.Add(allHoldings[i].TotalValue)
+		}
+		if totalValue.GreaterThan(p.peakPortfolioValue) {
+			p.peakPortfolioValue = totalValue
+		}
+		if !p.peakPortfolioValue.IsPositive() {
+			return nil, nil
+		}
+		drawdown := p.peakPortfolioValue.Sub(totalValue).Div(p.peakPortfolioValue).Mul(oneHundred)
+		if drawdown.LessThan(stopOut.MaximumDrawdownPercent) {
+			return nil, nil
+		}
+		p.peakPortfolioValue = totalValue
+		var closingOrders []*order.Order
+		for exch, assetPairSettings := range p.exchangeAssetPairSettings {
+			for item, pairMap := range assetPairSettings {
+				for pair, pairSettings := range pairMap {
+					direction, size := pairSettings.getOpenPosition(item)
+					pairSettings.stopOutExtremePrice = decimal.Zero
+					if !size.IsPositive() {
+						continue
+					}
+					closingOrders = append(closingOrders, createStopOutOrder(ev, exch, item, pair, direction, size, drawdown, stopOut.Scope))
+				}
+			}
+		}
+		return closingOrders, nil
+	default:
+		return nil, fmt.Errorf("%w %v", errInvalidDrawdownScope, stopOut.Scope)
+	}
+}
+
+// getOpenPosition returns the direction and size of the open position.
+// Spot positions are always long
+func (s *Settings) getOpenPosition(item asset.Item) (gctorder.Side, decimal.Decimal) {
+	if item.IsFutures() {
+		if s.FuturesTracker == nil {
+			return gctorder.UnknownSide, decimal.Zero
+		}
+		positions := s.FuturesTracker.GetPositions()
+		if len(positions) == 0 || positions[len(positions)-1].Status != gctorder.Open {
+			return gctorder.UnknownSide, decimal.Zero
+		}
+		return positions[len(positions)-1].LatestDirection, positions[len(positions)-1].LatestSize
+	}
+	return gctorder.Buy, s.GetLatestHoldings().BaseSize
+}
+
+// applyFlipPolicy compares futures orders against the open position. Orders
+// exceeding a position in the opposite direction would flip it through flat,
+// so are clamped to close it exactly or rejected depending on the currency's
+// flip policy. Returns whether the order was rejected
+func (p *Portfolio) applyFlipPolicy(cs *exchange.Settings, o *order.Order) (bool, error) {
+	if cs == nil || o == nil {
+		return false, common.ErrNilArguments
+	}
+	if (cs.FlipPolicy != exchange.ClampToFlat && cs.FlipPolicy != exchange.RejectExcess) ||
+		!o.GetAssetType().IsFutures() ||
+		o.GetDirection() == gctorder.ClosePosition {
+		return false, nil
+	}
+	settings, err := p.getSettings(o.GetExchange(), o.GetAssetType(), o.Pair())
+	if err != nil {
+		return false, fmt.Errorf("%v %v %v %w", o.GetExchange(), o.GetAssetType(), o.Pair(), err)
+	}
+	direction, size := settings.getOpenPosition(o.GetAssetType())
+	if !size.IsPositive() ||
+		direction.IsLong() == o.GetDirection().IsLong() ||
+		o.Amount.LessThanOrEqual(size) {
+		return false, nil
+	}
+	if cs.FlipPolicy == exchange.RejectExcess {
+		o.AppendReason(fmt.Sprintf("order amount %v exceeds the open %v position of %v, rejected to prevent flipping", o.Amount, direction.Lower(), size))
+		return true, nil
+	}
+	o.AppendReason(fmt.Sprintf("clamped order amount %v to %v to close the open %v position without flipping", o.Amount, size, direction.Lower()))
+	o.Amount = size
+	return false, nil
+}
+
+// CreateClosingOrder returns a market order closing the data event's open
+// position at its close price with the reason provided. The order is a forced
+// exit and is returned without reserving funds. Returns nil when there is no
+// open position
+func (p *Portfolio) CreateClosingOrder(ev common.DataEventHandler, reason string) (*order.Order, error) {
+	if ev == nil {
+		return nil, common.ErrNilEvent
+	}
+	settings, err := p.getSettings(ev.GetExchange(), ev.GetAssetType(), ev.Pair())
+	if err != nil {
+		return nil, fmt.Errorf("%v %v %v %w", ev.GetExchange(), ev.GetAssetType(), ev.Pair(), err)
+	}
+	direction, size := settings.getOpenPosition(ev.GetAssetType())
+	if !size.IsPositive() {
+		return nil, nil
+	}
+	return createClosingOrder(ev, ev.GetExchange(), ev.GetAssetType(), ev.Pair(), direction, size, reason), nil
+}
+
+// createStopOutOrder creates a market order closing a position
+// which has breached its maximum drawdown
+func createStopOutOrder(ev common.DataEventHandler, exch string, item asset.Item, pair currency.Pair, direction gctorder.Side, size, drawdown decimal.Decimal, scope string) *order.Order {
+	if scope == "" {
+		scope = exchange.DrawdownScopePosition
+	}
+	return createClosingOrder(ev, exch, item, pair, direction, size, fmt.Sprintf("STOPPED OUT, %v drawdown of %v%%", scope, drawdown.Round(2)))
+}
+
+// createClosingOrder creates a forced market order closing a position
+func createClosingOrder(ev common.DataEventHandler, exch string, item asset.Item, pair currency.Pair, direction gctorder.Side, size decimal.Decimal, reason string) *order.Order {
+	closingDirection := gctorder.Sell
+	switch direction {
+	case gctorder.Long:
+		closingDirection = gctorder.Short
+	case gctorder.Short:
+		closingDirection = gctorder.Long
+	}
+	return &order.Order{
+		Base: &event.Base{
+			Offset:       ev.GetOffset(),
+			Exchange:     exch,
+			Time:         ev.GetTime(),
+			Interval:     ev.GetInterval(),
+			CurrencyPair: pair,
+			AssetType:    item,
+			Reasons:      []string{reason},
+		},
+		Direction:       closingDirection,
+		ClosePrice:      ev.GetClosePrice(),
+		Amount:          size,
+		AllocatedFunds:  size,
+		OrderType:       gctorder.Market,
+		ClosingPosition: true,
+		StopOut:         true,
+	}
+}
+
 func (p *Portfolio) getFuturesSettingsFromEvent(e common.EventHandler) (*Settings, error) {
 	if e == nil {
 		return nil, common.ErrNilEvent
@@ -737,9 +1212,11 @@ func (p *Portfolio) GetLatestPNLs() []PNLSummary {
 					continue
 				}
 				summary := PNLSummary{
-					Exchange: exch,
-					Item:     ai,
-					Pair:     cp,
+					Exchange:    exch,
+					Item:        ai,
+					Pair:        cp,
+					BorrowCost:  settings.borrowCost,
+					FundingCost: settings.fundingCost,
 				}
 				positions := settings.FuturesTracker.GetPositions()
 				if len(positions) > 0 {
@@ -794,3 +1271,92 @@ func (p *PNLSummary) GetDirection() gctorder.Side {
 func (p *PNLSummary) GetPositionStatus() gctorder.Status {
 	return p.Result.Status
 }
+
+// GetBorrowCost returns the total borrow cost
+// accrued against short positions
+func (p *PNLSummary) GetBorrowCost() decimal.Decimal {
+	return p.BorrowCost
+}
+
+// GetFundingCost returns the net funding rate payments
+// paid, with a negative cost when more was received
+func (p *PNLSummary) GetFundingCost() decimal.Decimal {
+	return p.FundingCost
+}
+
+// accountPool returns the account a signal's orders are funded by and its
+// percentage of the initial funds. Orders without an account are funded by
+// the unallocated remainder
+func (s *Settings) accountPool(account string) (pool string, percent decimal.Decimal) {
+	if percent, ok := s.accounts[account]; ok && account != "" {
+		return account, percent
+	}
+	remainder := decimal.NewFromInt(100)
+	for _, allocation := range s.accounts {
+		remainder = remainder.Sub(allocation)
+	}
+	return "", remainder
+}
+
+// seedAccountBalances segregates the initial quote and base funds
+// into each account's balance the first time they are needed
+func (s *Settings) seedAccountBalances(pReader funding.IPairReader) {
+	if s.accountBalances != nil {
+		return
+	}
+	s.accountBalances = make(map[string]*accountBalance, len(s.accounts)+1)
+	pools := make([]string, 0, len(s.accounts)+1)
+	pools = append(pools, "")
+	for account := range s.accounts {
+		pools = append(pools, account)
+	}
+	oneHundred := decimal.NewFromInt(100)
+	for i := range pools {
+		_, percent := s.accountPool(pools[i])
+		s.accountBalances[pools[i]] = &accountBalance{
+			quote: pReader.QuoteInitialFunds().Mul(percent).Div(oneHundred),
+			base:  pReader.BaseInitialFunds().Mul(percent).Div(oneHundred),
+		}
+	}
+}
+
+// updateAccountBalances applies a fill to the balances of the account which
+// funded it. Stop-outs and liquidations close the whole holding, so they
+// are shared across accounts in proportion to their base balance
+func (s *Settings) updateAccountBalances(f fill.Event) {
+	if !common.CanTransact(f.GetDirection()) || !f.GetAmount().IsPositive() {
+		return
+	}
+	if !f.IsStopOut() && !f.IsLiquidated() {
+		pool, _ := s.accountPool(f.GetAccount())
+		s.accountBalances[pool].apply(f, f.GetAmount(), f.GetExchangeFee())
+		return
+	}
+	var totalBase decimal.Decimal
+	for _, ab := range s.accountBalances {
+		if ab.base.IsPositive() {
+			totalBase = totalBase.Add(ab.base)
+		}
+	}
+	if !totalBase.IsPositive() {
+		return
+	}
+	closed := f.GetAmount().Div(totalBase)
+	for _, ab := range s.accountBalances {
+		if ab.base.IsPositive() {
+			ab.apply(f, ab.base.Mul(closed), f.GetExchangeFee().Mul(ab.base).Div(totalBase))
+		}
+	}
+}
+
+// apply updates the balance with an amount of a fill and its fee
+func (a *accountBalance) apply(f fill.Event, amount, fee decimal.Decimal) {
+	value := f.GetPurchasePrice().Mul(amount)
+	if f.GetDirection().IsLong() {
+		a.quote = a.quote.Sub(value).Sub(fee)
+		a.base = a.base.Add(amount)
+		return
+	}
+	a.quote = a.quote.Add(value).Sub(fee)
+	a.base = a.base.Sub(amount)
+}
@@ -498,11 +498,12 @@ func TestOnSignal(t *testing.T) {
 		Direction: gctorder.Buy,
 	}
 	var resp *order.Order
+	// signals without a close price are rejected before sizing
 	resp, err = p.OnSignal(s, &exchange.Settings{}, pair)
 	if err != nil {
 		t.Fatal(err)
 	}
-	if len(resp.Reasons) != 2 {
+	if len(resp.Reasons) != 1 || resp.Direction != gctorder.CouldNotBuy {
 		t.Error("expected issue")
 	}
 
@@ -511,7 +512,7 @@ func TestOnSignal(t *testing.T) {
 	if err != nil {
 		t.Error(err)
 	}
-	if len(resp.Reasons) != 4 {
+	if len(resp.Reasons) != 2 {
 		t.Error("expected issue")
 	}
 
@@ -547,6 +548,7 @@ func TestOnSignal(t *testing.T) {
 	s.ClosePrice = decimal.NewFromInt(10)
 	s.Direction = gctorder.Buy
 	s.Amount = decimal.NewFromInt(1)
+	s.LimitPrice = decimal.NewFromInt(9)
 	resp, err = p.OnSignal(s, &exchange.Settings{}, pair)
 	if err != nil {
 		t.Error(err)
@@ -554,6 +556,43 @@ func TestOnSignal(t *testing.T) {
 	if resp.Amount.IsZero() {
 		t.Error("expected an amount to be sized")
 	}
+	if resp.OrderType != gctorder.Limit || !resp.LimitPrice.Equal(s.LimitPrice) {
+		t.Errorf("received: %v %v, expected: %v %v", resp.OrderType, resp.LimitPrice, gctorder.Limit, s.LimitPrice)
+	}
+
+	s.Direction = gctorder.Buy
+	s.LimitPrice = decimal.Zero
+	s.StopPrice = decimal.NewFromInt(9)
+	resp, err = p.OnSignal(s, &exchange.Settings{}, pair)
+	if err != nil {
+		t.Error(err)
+	}
+	if resp.OrderType != gctorder.Stop || !resp.StopPrice.Equal(s.StopPrice) {
+		t.Errorf("received: %v %v, expected: %v %v", resp.OrderType, resp.StopPrice, gctorder.Stop, s.StopPrice)
+	}
+
+	s.Direction = gctorder.Buy
+	s.StopPrice = decimal.Zero
+	s.TakeProfitPrice = decimal.NewFromInt(11)
+	resp, err = p.OnSignal(s, &exchange.Settings{}, pair)
+	if err != nil {
+		t.Error(err)
+	}
+	if resp.OrderType != gctorder.TakeProfit || !resp.TakeProfitPrice.Equal(s.TakeProfitPrice) {
+		t.Errorf("received: %v %v, expected: %v %v", resp.OrderType, resp.TakeProfitPrice, gctorder.TakeProfit, s.TakeProfitPrice)
+	}
+
+	// a trailing stop sell protecting the long position
+	s.Direction = gctorder.Sell
+	s.TakeProfitPrice = decimal.Zero
+	s.TrailingStopDistance = decimal.NewFromInt(1)
+	resp, err = p.OnSignal(s, &exchange.Settings{}, pair)
+	if err != nil {
+		t.Error(err)
+	}
+	if resp.OrderType != gctorder.TrailingStop || !resp.TrailingStopDistance.Equal(s.TrailingStopDistance) {
+		t.Errorf("received: %v %v, expected: %v %v", resp.OrderType, resp.TrailingStopDistance, gctorder.TrailingStop, s.TrailingStopDistance)
+	}
 }
 
 func TestGetLatestHoldings(t *testing.T) {
@@ -1089,6 +1128,169 @@ func TestGetLatestPNLs(t *testing.T) {
 	}
 }
 
+func TestAccrueBorrowCost(t *testing.T) {
+	t.Parallel()
+	p := &Portfolio{}
+	rate := decimal.NewFromInt(365)
+	_, err := p.AccrueBorrowCost(nil, nil, rate)
+	if !errors.Is(err, common.ErrNilEvent) {
+		t.Errorf("received '%v' expected '%v'", err, common.ErrNilEvent)
+	}
+	pair := currency.NewPair(currency.BTC, currency.USD)
+	ev := &kline.Kline{
+		Base: &event.Base{
+			Exchange:     testExchange,
+			CurrencyPair: pair,
+			AssetType:    asset.Futures,
+			Interval:     gctkline.OneDay,
+			Time:         time.Now(),
+		},
+		Close: decimal.NewFromInt(100),
+	}
+	_, err = p.AccrueBorrowCost(ev, nil, rate)
+	if !errors.Is(err, common.ErrNilArguments) {
+		t.Errorf("received '%v' expected '%v'", err, common.ErrNilArguments)
+	}
+
+	contract, err := funding.CreateItem(testExchange, asset.Futures, pair.Base, decimal.Zero, decimal.Zero)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	collateral, err := funding.CreateItem(testExchange, asset.Futures, pair.Quote, decimal.NewFromInt(1000), decimal.Zero)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	collat, err := funding.CreateCollateral(contract, collateral)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	_, err = p.AccrueBorrowCost(ev, collat, rate)
+	if !errors.Is(err, errExchangeUnset) {
+		t.Errorf("received '%v' expected '%v'", err, errExchangeUnset)
+	}
+
+	ff := &ftx.FTX{}
+	ff.Name = testExchange
+	err = p.SetupCurrencySettingsMap(&exchange.Settings{Exchange: ff, Asset: asset.Futures, Pair: pair})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	settings, err := p.getSettings(testExchange, asset.Futures, pair)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	err = settings.FuturesTracker.TrackNewOrder(&gctorder.Detail{
+		Exchange:  testExchange,
+		AssetType: asset.Futures,
+		Pair:      pair,
+		Amount:    2,
+		Price:     100,
+		OrderID:   "one",
+		Date:      time.Now(),
+		Side:      gctorder.Long,
+	})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	cost, err := p.AccrueBorrowCost(ev, collat, rate)
+	if !errors.Is(err, nil) {
+		t.Errorf("received '%v' expected '%v'", err, nil)
+	}
+	if !cost.IsZero() {
+		t.Errorf("received '%v' expected '%v'", cost, decimal.Zero)
+	}
+
+	err = settings.FuturesTracker.TrackNewOrder(&gctorder.Detail{
+		Exchange:  testExchange,
+		AssetType: asset.Futures,
+		Pair:      pair,
+		Amount:    4,
+		Price:     100,
+		OrderID:   "two",
+		Date:      time.Now(),
+		Side:      gctorder.Short,
+	})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	cost, err = p.AccrueBorrowCost(ev, collat, decimal.Zero)
+	if !errors.Is(err, nil) {
+		t.Errorf("received '%v' expected '%v'", err, nil)
+	}
+	if !cost.IsZero() {
+		t.Errorf("received '%v' expected '%v'", cost, decimal.Zero)
+	}
+	// a short of 2 at 100 charged a year's 365% for one day
+	cost, err = p.AccrueBorrowCost(ev, collat, rate)
+	if !errors.Is(err, nil) {
+		t.Errorf("received '%v' expected '%v'", err, nil)
+	}
+	if !cost.Equal(decimal.NewFromInt(2)) {
+		t.Errorf("received '%v' expected '%v'", cost, decimal.NewFromInt(2))
+	}
+	if !collat.AvailableFunds().Equal(decimal.NewFromInt(998)) {
+		t.Errorf("received '%v' expected '%v'", collat.AvailableFunds(), decimal.NewFromInt(998))
+	}
+	_, err = p.AccrueBorrowCost(ev, collat, rate)
+	if !errors.Is(err, nil) {
+		t.Errorf("received '%v' expected '%v'", err, nil)
+	}
+	pnl, err := p.GetLatestPNLForEvent(ev)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	if !pnl.GetBorrowCost().Equal(decimal.NewFromInt(4)) {
+		t.Errorf("received '%v' expected '%v'", pnl.GetBorrowCost(), decimal.NewFromInt(4))
+	}
+}
+
+func TestRecordFundingPayment(t *testing.T) {
+	t.Parallel()
+	p := &Portfolio{}
+	err := p.RecordFundingPayment(nil, decimal.NewFromInt(1))
+	if !errors.Is(err, common.ErrNilEvent) {
+		t.Errorf("received '%v' expected '%v'", err, common.ErrNilEvent)
+	}
+	pair := currency.NewPair(currency.BTC, currency.USD)
+	ev := &kline.Kline{
+		Base: &event.Base{
+			Exchange:     testExchange,
+			CurrencyPair: pair,
+			AssetType:    asset.Futures,
+		},
+	}
+	err = p.RecordFundingPayment(ev, decimal.Zero)
+	if !errors.Is(err, nil) {
+		t.Errorf("received '%v' expected '%v'", err, nil)
+	}
+	err = p.RecordFundingPayment(ev, decimal.NewFromInt(1))
+	if !errors.Is(err, errExchangeUnset) {
+		t.Errorf("received '%v' expected '%v'", err, errExchangeUnset)
+	}
+
+	ff := &ftx.FTX{}
+	ff.Name = testExchange
+	err = p.SetupCurrencySettingsMap(&exchange.Settings{Exchange: ff, Asset: asset.Futures, Pair: pair})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	err = p.RecordFundingPayment(ev, decimal.NewFromInt(3))
+	if !errors.Is(err, nil) {
+		t.Errorf("received '%v' expected '%v'", err, nil)
+	}
+	err = p.RecordFundingPayment(ev, decimal.NewFromInt(-5))
+	if !errors.Is(err, nil) {
+		t.Errorf("received '%v' expected '%v'", err, nil)
+	}
+	latest := p.GetLatestPNLs()
+	if len(latest) != 1 {
+		t.Fatalf("received '%v' expected '%v'", len(latest), 1)
+	}
+	if !latest[0].GetFundingCost().Equal(decimal.NewFromInt(-2)) {
+		t.Errorf("received '%v' expected '%v'", latest[0].GetFundingCost(), decimal.NewFromInt(-2))
+	}
+}
+
 func TestGetUnrealisedPNL(t *testing.T) {
 	t.Parallel()
 	p := PNLSummary{
@@ -1488,3 +1690,654 @@ func TestCheckLiquidationStatus(t *testing.T) {
 		t.Errorf("received '%v', expected '%v'", err, expectedError)
 	}
 }
+
+func TestCheckDrawdownStopOut(t *testing.T) {
+	t.Parallel()
+	p := &Portfolio{}
+	_, err := p.CheckDrawdownStopOut(nil, nil)
+	if !errors.Is(err, common.ErrNilEvent) {
+		t.Fatalf("received '%v' expected '%v'", err, common.ErrNilEvent)
+	}
+
+	ff := &ftx.FTX{}
+	ff.Name = testExchange
+	cp := currency.NewPair(currency.BTC, currency.USD)
+	ev := &kline.Kline{
+		Base: &event.Base{
+			Exchange:     testExchange,
+			AssetType:    asset.Spot,
+			CurrencyPair: cp,
+			Time:         time.Now(),
+			Interval:     gctkline.OneDay,
+		},
+		Close: decimal.NewFromInt(100),
+	}
+	stopOut := &exchange.DrawdownStopOut{MaximumDrawdownPercent: decimal.NewFromInt(20)}
+	_, err = p.CheckDrawdownStopOut(ev, stopOut)
+	if !errors.Is(err, errExchangeUnset) {
+		t.Fatalf("received '%v' expected '%v'", err, errExchangeUnset)
+	}
+
+	err = p.SetupCurrencySettingsMap(&exchange.Settings{Exchange: ff, Asset: asset.Spot, Pair: cp})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	orders, err := p.CheckDrawdownStopOut(ev, stopOut)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	if len(orders) != 0 {
+		t.Fatalf("received '%v' expected '%v'", len(orders), 0)
+	}
+
+	settings, err := p.getSettings(testExchange, asset.Spot, cp)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	settings.HoldingsSnapshots = []holdings.Holding{{Timestamp: ev.Time, BaseSize: decimal.NewFromInt(2), TotalValue: decimal.NewFromInt(1000)}}
+	orders, err = p.CheckDrawdownStopOut(ev, stopOut)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	if len(orders) != 0 {
+		t.Fatalf("received '%v' expected '%v'", len(orders), 0)
+	}
+	if !settings.stopOutExtremePrice.Equal(decimal.NewFromInt(100)) {
+		t.Errorf("received '%v' expected '%v'", settings.stopOutExtremePrice, 100)
+	}
+
+	ev.Close = decimal.NewFromInt(80)
+	orders, err = p.CheckDrawdownStopOut(ev, stopOut)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	if len(orders) != 1 {
+		t.Fatalf("received '%v' expected '%v'", len(orders), 1)
+	}
+	if !orders[0].IsStopOut() || orders[0].IsLiquidating() {
+		t.Error("expected stop-out order which is not an exchange liquidation")
+	}
+	if orders[0].GetDirection() != gctorder.Sell {
+		t.Errorf("received '%v' expected '%v'", orders[0].GetDirection(), gctorder.Sell)
+	}
+	if !orders[0].GetAmount().Equal(decimal.NewFromInt(2)) {
+		t.Errorf("received '%v' expected '%v'", orders[0].GetAmount(), 2)
+	}
+	if !settings.stopOutExtremePrice.IsZero() {
+		t.Errorf("received '%v' expected '%v'", settings.stopOutExtremePrice, 0)
+	}
+
+	stopOut.Scope = exchange.DrawdownScopePortfolio
+	orders, err = p.CheckDrawdownStopOut(ev, stopOut)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	if len(orders) != 0 {
+		t.Fatalf("received '%v' expected '%v'", len(orders), 0)
+	}
+	settings.HoldingsSnapshots[0].TotalValue = decimal.NewFromInt(700)
+	orders, err = p.CheckDrawdownStopOut(ev, stopOut)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	if len(orders) != 1 {
+		t.Fatalf("received '%v' expected '%v'", len(orders), 1)
+	}
+	if !p.peakPortfolioValue.Equal(decimal.NewFromInt(700)) {
+		t.Errorf("received '%v' expected '%v'", p.peakPortfolioValue, 700)
+	}
+
+	stopOut.Scope = "everything"
+	_, err = p.CheckDrawdownStopOut(ev, stopOut)
+	if !errors.Is(err, errInvalidDrawdownScope) {
+		t.Fatalf("received '%v' expected '%v'", err, errInvalidDrawdownScope)
+	}
+}
+
+func TestCreateClosingOrder(t *testing.T) {
+	t.Parallel()
+	p := &Portfolio{}
+	_, err := p.CreateClosingOrder(nil, "")
+	if !errors.Is(err, common.ErrNilEvent) {
+		t.Fatalf("received '%v' expected '%v'", err, common.ErrNilEvent)
+	}
+
+	ff := &ftx.FTX{}
+	ff.Name = testExchange
+	cp := currency.NewPair(currency.BTC, currency.USD)
+	ev := &kline.Kline{
+		Base: &event.Base{
+			Exchange:     testExchange,
+			AssetType:    asset.Spot,
+			CurrencyPair: cp,
+			Time:         time.Now(),
+			Interval:     gctkline.OneDay,
+		},
+		Close: decimal.NewFromInt(100),
+	}
+	_, err = p.CreateClosingOrder(ev, "")
+	if !errors.Is(err, errExchangeUnset) {
+		t.Fatalf("received '%v' expected '%v'", err, errExchangeUnset)
+	}
+
+	err = p.SetupCurrencySettingsMap(&exchange.Settings{Exchange: ff, Asset: asset.Spot, Pair: cp})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	o, err := p.CreateClosingOrder(ev, "closing")
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	if o != nil {
+		t.Fatalf("received '%v' expected '%v'", o, nil)
+	}
+
+	settings, err := p.getSettings(testExchange, asset.Spot, cp)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	settings.HoldingsSnapshots = []holdings.Holding{{Timestamp: ev.Time, BaseSize: decimal.NewFromInt(2)}}
+	o, err = p.CreateClosingOrder(ev, "closing")
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	if o == nil {
+		t.Fatal("expected closing order")
+	}
+	if o.GetDirection() != gctorder.Sell || !o.IsClosingPosition() {
+		t.Errorf("received '%v' expected closing '%v'", o.GetDirection(), gctorder.Sell)
+	}
+	if !o.GetAmount().Equal(decimal.NewFromInt(2)) || !o.GetClosePrice().Equal(ev.Close) {
+		t.Errorf("received '%v' at '%v' expected '%v' at '%v'", o.GetAmount(), o.GetClosePrice(), 2, ev.Close)
+	}
+	if o.GetConcatReasons() != "closing" {
+		t.Errorf("received '%v' expected '%v'", o.GetConcatReasons(), "closing")
+	}
+}
+
+func TestApplyFlipPolicy(t *testing.T) {
+	t.Parallel()
+	p := &Portfolio{}
+	_, err := p.applyFlipPolicy(nil, nil)
+	if !errors.Is(err, common.ErrNilArguments) {
+		t.Fatalf("received '%v' expected '%v'", err, common.ErrNilArguments)
+	}
+
+	ff := &ftx.FTX{}
+	ff.Name = testExchange
+	cp := currency.NewPair(currency.BTC, currency.USD)
+	cs := &exchange.Settings{Exchange: ff, Asset: asset.Futures, Pair: cp, FlipPolicy: exchange.ClampToFlat}
+	newOrder := func(side gctorder.Side, amount int64) *order.Order {
+		return &order.Order{
+			Base: &event.Base{
+				Exchange:     testExchange,
+				AssetType:    asset.Futures,
+				CurrencyPair: cp,
+			},
+			Direction: side,
+			Amount:    decimal.NewFromInt(amount),
+		}
+	}
+	_, err = p.applyFlipPolicy(cs, newOrder(gctorder.Short, 5))
+	if !errors.Is(err, errExchangeUnset) {
+		t.Fatalf("received '%v' expected '%v'", err, errExchangeUnset)
+	}
+	err = p.SetupCurrencySettingsMap(cs)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	settings, err := p.getSettings(testExchange, asset.Futures, cp)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	err = settings.FuturesTracker.TrackNewOrder(&gctorder.Detail{
+		Exchange:  testExchange,
+		AssetType: asset.Futures,
+		Pair:      cp,
+		Side:      gctorder.Long,
+		OrderID:   "1337",
+		Date:      time.Now(),
+		Amount:    2,
+		Price:     1337,
+	})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+
+	// orders in the same direction or within the position never flip
+	for _, o := range []*order.Order{newOrder(gctorder.Long, 5), newOrder(gctorder.Short, 2)} {
+		amount := o.Amount
+		rejected, err := p.applyFlipPolicy(cs, o)
+		if !errors.Is(err, nil) {
+			t.Fatalf("received '%v' expected '%v'", err, nil)
+		}
+		if rejected || !o.Amount.Equal(amount) || len(o.Reasons) != 0 {
+			t.Errorf("received '%v' '%v' expected unchanged amount '%v'", rejected, o.Amount, amount)
+		}
+	}
+
+	o := newOrder(gctorder.Short, 5)
+	rejected, err := p.applyFlipPolicy(cs, o)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	if rejected || !o.Amount.Equal(decimal.NewFromInt(2)) {
+		t.Errorf("received '%v' '%v' expected clamped amount '%v'", rejected, o.Amount, 2)
+	}
+	if !strings.Contains(o.GetConcatReasons(), "clamped order amount 5 to 2") {
+		t.Errorf("received '%v' expected clamping reason", o.GetConcatReasons())
+	}
+
+	cs.FlipPolicy = exchange.RejectExcess
+	o = newOrder(gctorder.Short, 5)
+	rejected, err = p.applyFlipPolicy(cs, o)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	if !rejected || !o.Amount.Equal(decimal.NewFromInt(5)) {
+		t.Errorf("received '%v' '%v' expected rejected amount '%v'", rejected, o.Amount, 5)
+	}
+	if !strings.Contains(o.GetConcatReasons(), "rejected to prevent flipping") {
+		t.Errorf("received '%v' expected rejection reason", o.GetConcatReasons())
+	}
+
+	cs.FlipPolicy = exchange.AllowFlip
+	o = newOrder(gctorder.Short, 5)
+	rejected, err = p.applyFlipPolicy(cs, o)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	if rejected || !o.Amount.Equal(decimal.NewFromInt(5)) {
+		t.Errorf("received '%v' '%v' expected flipping amount '%v'", rejected, o.Amount, 5)
+	}
+}
+
+func TestUpdateHoldingsInitialEntryPrice(t *testing.T) {
+	t.Parallel()
+	bc, err := funding.CreateItem(testExchange, asset.Spot, currency.BTC, decimal.NewFromInt(2), decimal.Zero)
+	if err != nil {
+		t.Fatal(err)
+	}
+	qc, err := funding.CreateItem(testExchange, asset.Spot, currency.USDT, decimal.NewFromInt(100), decimal.Zero)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pair, err := funding.CreatePair(bc, qc)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	ff := &ftx.FTX{}
+	ff.Name = testExchange
+	cp := currency.NewPair(currency.BTC, currency.USDT)
+	p := Portfolio{}
+	err = p.SetupCurrencySettingsMap(&exchange.Settings{
+		Exchange:              ff,
+		Asset:                 asset.Spot,
+		Pair:                  cp,
+		InitialBaseEntryPrice: decimal.NewFromInt(40),
+	})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	err = p.UpdateHoldings(&kline.Kline{
+		Base: &event.Base{
+			Exchange:     testExchange,
+			AssetType:    asset.Spot,
+			CurrencyPair: cp,
+			Time:         time.Now(),
+		},
+		Close: decimal.NewFromInt(50),
+	}, pair)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	settings, err := p.getSettings(testExchange, asset.Spot, cp)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	h := settings.GetLatestHoldings()
+	if !h.AverageEntryPrice.Equal(decimal.NewFromInt(40)) {
+		t.Errorf("received '%v' expected '%v'", h.AverageEntryPrice, 40)
+	}
+	if !h.TotalInitialValue.Equal(decimal.NewFromInt(180)) {
+		t.Errorf("received '%v' expected '%v'", h.TotalInitialValue, 180)
+	}
+	if !h.UnrealisedPNL.Equal(decimal.NewFromInt(20)) {
+		t.Errorf("received '%v' expected '%v'", h.UnrealisedPNL, 20)
+	}
+}
+
+func TestTagAllocations(t *testing.T) {
+	t.Parallel()
+	s := &Settings{
+		tagAllocations: map[string]decimal.Decimal{
+			"trend":          decimal.NewFromInt(60),
+			"mean-reversion": decimal.NewFromInt(30),
+		},
+	}
+	pool, percent := s.tagPool("trend")
+	if pool != "trend" || !percent.Equal(decimal.NewFromInt(60)) {
+		t.Errorf("received '%v' '%v' expected '%v' '%v'", pool, percent, "trend", 60)
+	}
+	// tags without an allocation share the unallocated remainder
+	pool, percent = s.tagPool("breakout")
+	if pool != "" || !percent.Equal(decimal.NewFromInt(10)) {
+		t.Errorf("received '%v' '%v' expected '%v' '%v'", pool, percent, "", 10)
+	}
+	if !s.isTagEntry("trend", gctorder.Buy, asset.Spot) || s.isTagEntry("trend", gctorder.Sell, asset.Spot) {
+		t.Error("expected only spot buys to be entries")
+	}
+	if s.isTagEntry("trend", gctorder.ClosePosition, asset.Futures) {
+		t.Error("expected closing orders to not be entries")
+	}
+	initialFunds := decimal.NewFromInt(1000)
+	if available := s.availableTagCapital("trend", initialFunds); !available.Equal(decimal.NewFromInt(600)) {
+		t.Errorf("received '%v' expected '%v'", available, 600)
+	}
+
+	newFill := func(a asset.Item, side gctorder.Side, amount int64, tag string) *fill.Fill {
+		return &fill.Fill{
+			Base:          &event.Base{AssetType: a},
+			Direction:     side,
+			Amount:        decimal.NewFromInt(amount),
+			PurchasePrice: decimal.NewFromInt(100),
+			Tag:           tag,
+		}
+	}
+	s.updateTagCapital(newFill(asset.Spot, gctorder.Buy, 4, "trend"))
+	if available := s.availableTagCapital("trend", initialFunds); !available.Equal(decimal.NewFromInt(200)) {
+		t.Errorf("received '%v' expected '%v'", available, 200)
+	}
+	// other tags' allocations are independent
+	if available := s.availableTagCapital("mean-reversion", initialFunds); !available.Equal(decimal.NewFromInt(300)) {
+		t.Errorf("received '%v' expected '%v'", available, 300)
+	}
+	s.updateTagCapital(newFill(asset.Spot, gctorder.Sell, 1, "mean-reversion"))
+	s.updateTagCapital(newFill(asset.Spot, gctorder.CouldNotBuy, 1, "trend"))
+	s.updateTagCapital(newFill(asset.Spot, gctorder.Sell, 2, "trend"))
+	if available := s.availableTagCapital("trend", initialFunds); !available.Equal(decimal.NewFromInt(400)) {
+		t.Errorf("received '%v' expected '%v'", available, 400)
+	}
+	s.updateTagCapital(newFill(asset.Spot, gctorder.Buy, 10, "trend"))
+	if available := s.availableTagCapital("trend", initialFunds); !available.IsZero() {
+		t.Errorf("received '%v' expected '%v'", available, 0)
+	}
+	liquidation := newFill(asset.Spot, gctorder.Sell, 12, "")
+	liquidation.Liquidated = true
+	s.updateTagCapital(liquidation)
+	if available := s.availableTagCapital("trend", initialFunds); !available.Equal(decimal.NewFromInt(600)) {
+		t.Errorf("received '%v' expected '%v'", available, 600)
+	}
+
+	// futures exits larger than the pool's position flip it
+	s.updateTagCapital(newFill(asset.Futures, gctorder.Long, 2, "trend"))
+	if !s.isTagEntry("trend", gctorder.Long, asset.Futures) || s.isTagEntry("trend", gctorder.Short, asset.Futures) {
+		t.Error("expected only longs to be entries for a long pool")
+	}
+	s.updateTagCapital(newFill(asset.Futures, gctorder.Short, 3, "trend"))
+	if !s.isTagEntry("trend", gctorder.Short, asset.Futures) {
+		t.Error("expected shorts to be entries for a flipped pool")
+	}
+	if available := s.availableTagCapital("trend", initialFunds); !available.Equal(decimal.NewFromInt(500)) {
+		t.Errorf("received '%v' expected '%v'", available, 500)
+	}
+}
+
+func TestOnSignalTagAllocation(t *testing.T) {
+	t.Parallel()
+	p := &Portfolio{
+		sizeManager: &size.Size{},
+		riskManager: &risk.Risk{},
+	}
+	ff := &ftx.FTX{}
+	ff.Name = testExchange
+	cp := currency.NewPair(currency.BTC, currency.USD)
+	cs := &exchange.Settings{
+		Exchange:       ff,
+		Asset:          asset.Spot,
+		Pair:           cp,
+		TagAllocations: map[string]decimal.Decimal{"trend": decimal.NewFromInt(1)},
+	}
+	err := p.SetupCurrencySettingsMap(cs)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	bc, err := funding.CreateItem(testExchange, asset.Spot, currency.BTC, decimal.Zero, decimal.Zero)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	qc, err := funding.CreateItem(testExchange, asset.Spot, currency.USD, decimal.NewFromInt(1000), decimal.Zero)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	pair, err := funding.CreatePair(bc, qc)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	s := &signal.Signal{
+		Base: &event.Base{
+			Exchange:     testExchange,
+			AssetType:    asset.Spot,
+			CurrencyPair: cp,
+		},
+		Direction:  gctorder.Buy,
+		ClosePrice: decimal.NewFromInt(10),
+		Amount:     decimal.NewFromInt(5),
+		Tag:        "trend",
+	}
+	resp, err := p.OnSignal(s, cs, pair)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	var hasReason bool
+	for i := range resp.Reasons {
+		hasReason = hasReason || strings.Contains(resp.Reasons[i], `allocation of tag "trend"`)
+	}
+	if !hasReason {
+		t.Errorf("received '%v' expected sizing funds to be limited by the tag allocation", resp.Reasons)
+	}
+	// 1% of 1000 limits the order to 10 at a price of 10
+	if !resp.Amount.LessThanOrEqual(decimal.NewFromInt(1)) {
+		t.Errorf("received '%v' expected at most '%v'", resp.Amount, 1)
+	}
+}
+
+func TestAccountBalances(t *testing.T) {
+	t.Parallel()
+	s := &Settings{
+		accounts: map[string]decimal.Decimal{
+			"variant-a": decimal.NewFromInt(60),
+			"variant-b": decimal.NewFromInt(30),
+		},
+	}
+	pool, percent := s.accountPool("variant-a")
+	if pool != "variant-a" || !percent.Equal(decimal.NewFromInt(60)) {
+		t.Errorf("received '%v' '%v' expected '%v' '%v'", pool, percent, "variant-a", 60)
+	}
+	pool, percent = s.accountPool("")
+	if pool != "" || !percent.Equal(decimal.NewFromInt(10)) {
+		t.Errorf("received '%v' '%v' expected '%v' '%v'", pool, percent, "", 10)
+	}
+	bc, err := funding.CreateItem(testExchange, asset.Spot, currency.BTC, decimal.NewFromInt(10), decimal.Zero)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	qc, err := funding.CreateItem(testExchange, asset.Spot, currency.USD, decimal.NewFromInt(1000), decimal.Zero)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	pair, err := funding.CreatePair(bc, qc)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	s.seedAccountBalances(pair)
+	a := s.accountBalances["variant-a"]
+	if !a.quote.Equal(decimal.NewFromInt(600)) || !a.base.Equal(decimal.NewFromInt(6)) {
+		t.Errorf("received '%v' '%v' expected '%v' '%v'", a.quote, a.base, 600, 6)
+	}
+
+	newFill := func(side gctorder.Side, amount int64, account string) *fill.Fill {
+		return &fill.Fill{
+			Base:          &event.Base{AssetType: asset.Spot},
+			Direction:     side,
+			Amount:        decimal.NewFromInt(amount),
+			PurchasePrice: decimal.NewFromInt(100),
+			ExchangeFee:   decimal.NewFromInt(1),
+			Account:       account,
+		}
+	}
+	s.updateAccountBalances(newFill(gctorder.Buy, 2, "variant-a"))
+	if !a.quote.Equal(decimal.NewFromInt(399)) || !a.base.Equal(decimal.NewFromInt(8)) {
+		t.Errorf("received '%v' '%v' expected '%v' '%v'", a.quote, a.base, 399, 8)
+	}
+	// other accounts are isolated from the fill
+	b := s.accountBalances["variant-b"]
+	if !b.quote.Equal(decimal.NewFromInt(300)) || !b.base.Equal(decimal.NewFromInt(3)) {
+		t.Errorf("received '%v' '%v' expected '%v' '%v'", b.quote, b.base, 300, 3)
+	}
+	s.updateAccountBalances(newFill(gctorder.CouldNotSell, 1, "variant-b"))
+	s.updateAccountBalances(newFill(gctorder.Sell, 1, "variant-b"))
+	if !b.quote.Equal(decimal.NewFromInt(399)) || !b.base.Equal(decimal.NewFromInt(2)) {
+		t.Errorf("received '%v' '%v' expected '%v' '%v'", b.quote, b.base, 399, 2)
+	}
+
+	// stop-outs close each account's share of the holding
+	stopOut := newFill(gctorder.Sell, 11, "")
+	stopOut.StopOut = true
+	s.updateAccountBalances(stopOut)
+	for account, ab := range s.accountBalances {
+		if !ab.base.IsZero() {
+			t.Errorf("received '%v' expected account %q base to be closed", ab.base, account)
+		}
+	}
+}
+
+func TestOnSignalAccount(t *testing.T) {
+	t.Parallel()
+	p := &Portfolio{
+		sizeManager: &size.Size{},
+		riskManager: &risk.Risk{},
+	}
+	ff := &ftx.FTX{}
+	ff.Name = testExchange
+	cp := currency.NewPair(currency.BTC, currency.USD)
+	cs := &exchange.Settings{
+		Exchange: ff,
+		Asset:    asset.Spot,
+		Pair:     cp,
+		Accounts: map[string]decimal.Decimal{"variant-a": decimal.NewFromInt(1)},
+	}
+	err := p.SetupCurrencySettingsMap(cs)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	bc, err := funding.CreateItem(testExchange, asset.Spot, currency.BTC, decimal.Zero, decimal.Zero)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	qc, err := funding.CreateItem(testExchange, asset.Spot, currency.USD, decimal.NewFromInt(1000), decimal.Zero)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	pair, err := funding.CreatePair(bc, qc)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	s := &signal.Signal{
+		Base: &event.Base{
+			Exchange:     testExchange,
+			AssetType:    asset.Spot,
+			CurrencyPair: cp,
+		},
+		Direction:  gctorder.Buy,
+		ClosePrice: decimal.NewFromInt(10),
+		Amount:     decimal.NewFromInt(5),
+		Account:    "variant-a",
+	}
+	resp, err := p.OnSignal(s, cs, pair)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	var hasReason bool
+	for i := range resp.Reasons {
+		hasReason = hasReason || strings.Contains(resp.Reasons[i], `available in account "variant-a"`)
+	}
+	if !hasReason {
+		t.Errorf("received '%v' expected sizing funds to be limited by the account", resp.Reasons)
+	}
+	// 1% of 1000 limits the order to 10 at a price of 10
+	if !resp.Amount.LessThanOrEqual(decimal.NewFromInt(1)) {
+		t.Errorf("received '%v' expected at most '%v'", resp.Amount, 1)
+	}
+
+	s.Direction = gctorder.Buy
+	s.Account = "variant-z"
+	resp, err = p.OnSignal(s, cs, pair)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	if resp.Direction != gctorder.CouldNotBuy {
+		t.Errorf("received '%v' expected '%v'", resp.Direction, gctorder.CouldNotBuy)
+	}
+}
+
+func TestOnSignalInvalidClosePrice(t *testing.T) {
+	t.Parallel()
+	p := &Portfolio{
+		sizeManager: &size.Size{},
+		riskManager: &risk.Risk{},
+	}
+	ff := &ftx.FTX{}
+	ff.Name = testExchange
+	cp := currency.NewPair(currency.BTC, currency.USD)
+	cs := &exchange.Settings{Exchange: ff, Asset: asset.Spot, Pair: cp}
+	err := p.SetupCurrencySettingsMap(cs)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	bc, err := funding.CreateItem(testExchange, asset.Spot, currency.BTC, decimal.NewFromInt(10), decimal.Zero)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	qc, err := funding.CreateItem(testExchange, asset.Spot, currency.USD, decimal.NewFromInt(1000), decimal.Zero)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	pair, err := funding.CreatePair(bc, qc)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	for _, tc := range []struct {
+		side     gctorder.Side
+		price    decimal.Decimal
+		expected gctorder.Side
+	}{
+		{side: gctorder.Buy, price: decimal.Zero, expected: gctorder.CouldNotBuy},
+		{side: gctorder.Sell, price: decimal.NewFromInt(-10), expected: gctorder.CouldNotSell},
+	} {
+		s := &signal.Signal{
+			Base: &event.Base{
+				Exchange:     testExchange,
+				AssetType:    asset.Spot,
+				CurrencyPair: cp,
+			},
+			Direction:  tc.side,
+			ClosePrice: tc.price,
+			Amount:     decimal.NewFromInt(1),
+		}
+		resp, err := p.OnSignal(s, cs, pair)
+		if !errors.Is(err, nil) {
+			t.Fatalf("received '%v' expected '%v'", err, nil)
+		}
+		if resp.Direction != tc.expected {
+			t.Errorf("received '%v' expected '%v'", resp.Direction, tc.expected)
+		}
+		expectedReason := "Order rejected, close price " + tc.price.String() + " must be positive"
+		if len(resp.Reasons) != 1 || resp.Reasons[0] != expectedReason {
+			t.Errorf("received '%v' expected '%v'", resp.Reasons, expectedReason)
+		}
+		if !pair.QuoteAvailable().Equal(decimal.NewFromInt(1000)) || !pair.BaseAvailable().Equal(decimal.NewFromInt(10)) {
+			t.Errorf("received '%v' '%v' expected no funds to be reserved", pair.QuoteAvailable(), pair.BaseAvailable())
+		}
+	}
+}
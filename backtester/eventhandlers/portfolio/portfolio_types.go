@@ -22,6 +22,9 @@ import (
 
 const notEnoughFundsTo = "not enough funds to"
 
+// borrowRateYear is the period annual borrow rates are pro-rated over
+const borrowRateYear = time.Hour * 24 * 365
+
 var (
 	errInvalidDirection     = errors.New("invalid direction")
 	errRiskManagerUnset     = errors.New("risk manager unset")
@@ -35,6 +38,9 @@ var (
 	errHoldingsNoTimestamp  = errors.New("holding with unset timestamp received")
 	errHoldingsAlreadySet   = errors.New("holding already set")
 	errUnsetFuturesTracker  = errors.New("portfolio settings futures tracker unset")
+	errInvalidDrawdownScope = errors.New("invalid drawdown stop-out scope")
+	errNilState             = errors.New("received nil portfolio state")
+	errStateMismatch        = errors.New("portfolio state does not match settings")
 )
 
 // Portfolio stores all holdings and rules to assess orders, allowing the portfolio manager to
@@ -44,6 +50,9 @@ type Portfolio struct {
 	sizeManager               SizeHandler
 	riskManager               risk.Handler
 	exchangeAssetPairSettings map[string]map[asset.Item]map[currency.Pair]*Settings
+	// peakPortfolioValue is the highest total value of all holdings
+	// used to measure portfolio scoped drawdown stop-outs
+	peakPortfolioValue decimal.Decimal
 }
 
 // Handler contains all functions expected to operate a portfolio manager
@@ -59,10 +68,16 @@ type Handler interface {
 	GetPositions(common.EventHandler) ([]gctorder.Position, error)
 	TrackFuturesOrder(fill.Event, funding.IFundReleaser) (*PNLSummary, error)
 	UpdatePNL(common.EventHandler, decimal.Decimal) error
+	AccrueBorrowCost(common.DataEventHandler, funding.ICollateralReleaser, decimal.Decimal) (decimal.Decimal, error)
+	RecordFundingPayment(common.EventHandler, decimal.Decimal) error
 	GetLatestPNLForEvent(common.EventHandler) (*PNLSummary, error)
 	GetLatestPNLs() []PNLSummary
 	CheckLiquidationStatus(common.DataEventHandler, funding.ICollateralReader, *PNLSummary) error
 	CreateLiquidationOrdersForExchange(common.DataEventHandler, funding.IFundingManager) ([]order.Event, error)
+	CheckDrawdownStopOut(common.DataEventHandler, *exchange.DrawdownStopOut) ([]*order.Order, error)
+	CreateClosingOrder(common.DataEventHandler, string) (*order.Order, error)
+	SaveState() (*State, error)
+	RestoreState(*State) error
 	Reset()
 }
 
@@ -81,6 +96,80 @@ type Settings struct {
 	ComplianceManager compliance.Manager
 	Exchange          gctexchange.IBotExchange
 	FuturesTracker    *gctorder.MultiPositionTracker
+	// stopOutExtremePrice is the most favourable close price since the
+	// position was opened, used to measure position scoped drawdown
+	stopOutExtremePrice decimal.Decimal
+	// initialEntryPrice is the price the spot initial base funds were bought
+	// at, applied to the first holdings created
+	initialEntryPrice decimal.Decimal
+	// tagAllocations is the percentage of the initial funds each strategy
+	// tag's entries may commit, with tagCapital tracking the capital
+	// committed by each allocation pool's open entries
+	tagAllocations map[string]decimal.Decimal
+	tagCapital     map[string]*tagCapital
+	// accounts is the percentage of the initial funds segregated into each
+	// named account, with accountBalances tracking each account's funds
+	accounts        map[string]decimal.Decimal
+	accountBalances map[string]*accountBalance
+	// borrowCost is the total cost of borrowing
+	// accrued against open short positions
+	borrowCost decimal.Decimal
+	// fundingCost is the net of funding rate payments paid
+	// less those received by open futures positions
+	fundingCost decimal.Decimal
+}
+
+// tagCapital is the capital committed by the open
+// entries of a strategy tag's allocation pool
+type tagCapital struct {
+	capital decimal.Decimal
+	amount  decimal.Decimal
+	isLong  bool
+}
+
+// accountBalance is the quote and base funds held by a segregated account
+type accountBalance struct {
+	quote decimal.Decimal
+	base  decimal.Decimal
+}
+
+// State is a serialisable copy of the portfolio's holdings, orders,
+// positions and the capital committed per currency which can be restored
+// to a portfolio set up with the same currency settings
+type State struct {
+	PeakPortfolioValue decimal.Decimal
+	Settings           []SettingsState
+}
+
+// SettingsState is a copy of an exchange, asset and pair's portfolio settings
+// which are updated by handling events
+type SettingsState struct {
+	Exchange            string
+	Asset               asset.Item
+	Pair                currency.Pair
+	HoldingsSnapshots   []holdings.Holding
+	ComplianceSnapshots []compliance.Snapshot
+	FuturesTracker      *gctorder.MultiPositionTrackerState
+	StopOutExtremePrice decimal.Decimal
+	InitialEntryPrice   decimal.Decimal
+	TagCapital          map[string]TagCapitalState
+	AccountBalances     map[string]AccountBalanceState
+	BorrowCost          decimal.Decimal
+	FundingCost         decimal.Decimal
+}
+
+// TagCapitalState is a copy of the capital committed
+// by a strategy tag's allocation pool
+type TagCapitalState struct {
+	Capital decimal.Decimal
+	Amount  decimal.Decimal
+	IsLong  bool
+}
+
+// AccountBalanceState is a copy of a segregated account's funds
+type AccountBalanceState struct {
+	Quote decimal.Decimal
+	Base  decimal.Decimal
 }
 
 // PNLSummary holds a PNL result along with
@@ -92,6 +181,8 @@ type PNLSummary struct {
 	CollateralCurrency currency.Code
 	Offset             int64
 	Result             gctorder.PNLResult
+	BorrowCost         decimal.Decimal
+	FundingCost        decimal.Decimal
 }
 
 // IPNL defines an interface for an implementation
@@ -102,6 +193,8 @@ type IPNL interface {
 	GetCollateralCurrency() currency.Code
 	GetDirection() gctorder.Side
 	GetPositionStatus() gctorder.Status
+	GetBorrowCost() decimal.Decimal
+	GetFundingCost() decimal.Decimal
 }
 
 // BasicPNLResult holds the time and the pnl
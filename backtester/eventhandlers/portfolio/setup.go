@@ -34,6 +34,7 @@ func Setup(sh SizeHandler, r risk.Handler, riskFreeRate decimal.Decimal) (*Portf
 // Reset returns the portfolio manager to its default state
 func (p *Portfolio) Reset() {
 	p.exchangeAssetPairSettings = nil
+	p.peakPortfolioValue = decimal.Zero
 }
 
 // SetupCurrencySettingsMap ensures a map is created and no panics happen
@@ -73,6 +74,11 @@ func (p *Portfolio) SetupCurrencySettingsMap(setup *exchange.Settings) error {
 		Leverage:          setup.Leverage,
 		Exchange:          setup.Exchange,
 		ComplianceManager: compliance.Manager{},
+		tagAllocations:    setup.TagAllocations,
+		accounts:          setup.Accounts,
+	}
+	if setup.Asset == asset.Spot {
+		settings.initialEntryPrice = setup.InitialBaseEntryPrice
 	}
 	if setup.Asset.IsFutures() {
 		futureTrackerSetup := &gctorder.MultiPositionTrackerSetup{
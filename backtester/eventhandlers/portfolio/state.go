@@ -0,0 +1,136 @@
+package portfolio
+
+import (
+	"fmt"
+
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventhandlers/portfolio/compliance"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventhandlers/portfolio/holdings"
+)
+
+// SaveState returns a copy of the holdings, orders, positions and capital
+// committed for each currency along with the portfolio's peak value
+func (p *Portfolio) SaveState() (*State, error) {
+	s := &State{
+		PeakPortfolioValue: p.peakPortfolioValue,
+	}
+	for exch, assets := range p.exchangeAssetPairSettings {
+		for a, pairs := range assets {
+			for cp, settings := range pairs {
+				ss := SettingsState{
+					Exchange:            exch,
+					Asset:               a,
+					Pair:                cp,
+					HoldingsSnapshots:   append([]holdings.Holding(nil), settings.HoldingsSnapshots...),
+					ComplianceSnapshots: copyComplianceSnapshots(settings.ComplianceManager.Snapshots),
+					StopOutExtremePrice: settings.stopOutExtremePrice,
+					InitialEntryPrice:   settings.initialEntryPrice,
+					BorrowCost:          settings.borrowCost,
+					FundingCost:         settings.fundingCost,
+				}
+				if settings.FuturesTracker != nil {
+					var err error
+					ss.FuturesTracker, err = settings.FuturesTracker.GetState()
+					if err != nil {
+						return nil, err
+					}
+				}
+				if settings.tagCapital != nil {
+					ss.TagCapital = make(map[string]TagCapitalState, len(settings.tagCapital))
+					for tag, tc := range settings.tagCapital {
+						ss.TagCapital[tag] = TagCapitalState{
+							Capital: tc.capital,
+							Amount:  tc.amount,
+							IsLong:  tc.isLong,
+						}
+					}
+				}
+				if settings.accountBalances != nil {
+					ss.AccountBalances = make(map[string]AccountBalanceState, len(settings.accountBalances))
+					for account, balance := range settings.accountBalances {
+						ss.AccountBalances[account] = AccountBalanceState{
+							Quote: balance.quote,
+							Base:  balance.base,
+						}
+					}
+				}
+				s.Settings = append(s.Settings, ss)
+			}
+		}
+	}
+	return s, nil
+}
+
+// RestoreState replaces the holdings, orders, positions and capital committed
+// for each currency along with the portfolio's peak value with those of the
+// state. Every currency in the state must have portfolio settings
+func (p *Portfolio) RestoreState(s *State) error {
+	if s == nil {
+		return errNilState
+	}
+	settings := make([]*Settings, len(s.Settings))
+	for i := range s.Settings {
+		settings[i] = p.exchangeAssetPairSettings[s.Settings[i].Exchange][s.Settings[i].Asset][s.Settings[i].Pair]
+		if settings[i] == nil {
+			return fmt.Errorf("%w %v %v %v", errStateMismatch, s.Settings[i].Exchange, s.Settings[i].Asset, s.Settings[i].Pair)
+		}
+		if (settings[i].FuturesTracker == nil) != (s.Settings[i].FuturesTracker == nil) {
+			return fmt.Errorf("%w %v %v %v futures tracker", errStateMismatch, s.Settings[i].Exchange, s.Settings[i].Asset, s.Settings[i].Pair)
+		}
+	}
+	for i := range s.Settings {
+		if settings[i].FuturesTracker != nil {
+			err := settings[i].FuturesTracker.RestoreState(s.Settings[i].FuturesTracker)
+			if err != nil {
+				return err
+			}
+		}
+		settings[i].HoldingsSnapshots = append([]holdings.Holding(nil), s.Settings[i].HoldingsSnapshots...)
+		settings[i].ComplianceManager.Snapshots = copyComplianceSnapshots(s.Settings[i].ComplianceSnapshots)
+		settings[i].stopOutExtremePrice = s.Settings[i].StopOutExtremePrice
+		settings[i].initialEntryPrice = s.Settings[i].InitialEntryPrice
+		settings[i].borrowCost = s.Settings[i].BorrowCost
+		settings[i].fundingCost = s.Settings[i].FundingCost
+		settings[i].tagCapital = nil
+		if s.Settings[i].TagCapital != nil {
+			settings[i].tagCapital = make(map[string]*tagCapital, len(s.Settings[i].TagCapital))
+			for tag, tc := range s.Settings[i].TagCapital {
+				settings[i].tagCapital[tag] = &tagCapital{
+					capital: tc.Capital,
+					amount:  tc.Amount,
+					isLong:  tc.IsLong,
+				}
+			}
+		}
+		settings[i].accountBalances = nil
+		if s.Settings[i].AccountBalances != nil {
+			settings[i].accountBalances = make(map[string]*accountBalance, len(s.Settings[i].AccountBalances))
+			for account, balance := range s.Settings[i].AccountBalances {
+				settings[i].accountBalances[account] = &accountBalance{
+					quote: balance.Quote,
+					base:  balance.Base,
+				}
+			}
+		}
+	}
+	p.peakPortfolioValue = s.PeakPortfolioValue
+	return nil
+}
+
+// copyComplianceSnapshots copies compliance snapshots along with their orders
+func copyComplianceSnapshots(snapshots []compliance.Snapshot) []compliance.Snapshot {
+	if snapshots == nil {
+		return nil
+	}
+	resp := make([]compliance.Snapshot, len(snapshots))
+	for i := range snapshots {
+		resp[i] = snapshots[i]
+		resp[i].Orders = make([]compliance.SnapshotOrder, len(snapshots[i].Orders))
+		for j := range snapshots[i].Orders {
+			resp[i].Orders[j] = snapshots[i].Orders[j]
+			if snapshots[i].Orders[j].Order != nil {
+				resp[i].Orders[j].Order = snapshots[i].Orders[j].Order.CopyToPointer()
+			}
+		}
+	}
+	return resp
+}
@@ -0,0 +1,86 @@
+package portfolio
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventhandlers/exchange"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventhandlers/portfolio/compliance"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventhandlers/portfolio/holdings"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/ftx"
+	gctorder "github.com/thrasher-corp/gocryptotrader/exchanges/order"
+)
+
+func TestSaveRestoreState(t *testing.T) {
+	t.Parallel()
+	p := &Portfolio{}
+	ff := &ftx.FTX{}
+	ff.Name = testExchange
+	cp := currency.NewPair(currency.BTC, currency.USD)
+	err := p.SetupCurrencySettingsMap(&exchange.Settings{Exchange: ff, Asset: asset.Spot, Pair: cp})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	tt := time.Now()
+	err = p.setHoldingsForOffset(&holdings.Holding{Exchange: testExchange, Asset: asset.Spot, Pair: cp, Timestamp: tt, Offset: 1}, false)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	settings := p.exchangeAssetPairSettings[testExchange][asset.Spot][cp]
+	settings.ComplianceManager.Snapshots = []compliance.Snapshot{{Offset: 1, Orders: []compliance.SnapshotOrder{{Order: &gctorder.Detail{OrderID: "1337"}}}}}
+	settings.tagCapital = map[string]*tagCapital{"tag": {capital: decimal.NewFromInt(1337)}}
+	settings.accountBalances = map[string]*accountBalance{"account": {quote: decimal.NewFromInt(1337)}}
+	settings.borrowCost = decimal.NewFromInt(1)
+	p.peakPortfolioValue = decimal.NewFromInt(1337)
+	s, err := p.SaveState()
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+
+	err = p.setHoldingsForOffset(&holdings.Holding{Exchange: testExchange, Asset: asset.Spot, Pair: cp, Timestamp: tt.Add(time.Hour), Offset: 2}, false)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	settings.ComplianceManager.Snapshots[0].Orders[0].Order.OrderID = "changed"
+	settings.tagCapital = nil
+	settings.accountBalances["account"].quote = decimal.Zero
+	settings.borrowCost = decimal.NewFromInt(2)
+	p.peakPortfolioValue = decimal.Zero
+
+	err = p.RestoreState(s)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	if len(settings.HoldingsSnapshots) != 1 {
+		t.Errorf("received '%v' expected '%v'", len(settings.HoldingsSnapshots), 1)
+	}
+	if settings.ComplianceManager.Snapshots[0].Orders[0].Order.OrderID != "1337" {
+		t.Errorf("received '%v' expected '%v'", settings.ComplianceManager.Snapshots[0].Orders[0].Order.OrderID, "1337")
+	}
+	if tc := settings.tagCapital["tag"]; tc == nil || !tc.capital.Equal(decimal.NewFromInt(1337)) {
+		t.Errorf("received '%v' expected capital '%v'", tc, 1337)
+	}
+	if !settings.accountBalances["account"].quote.Equal(decimal.NewFromInt(1337)) {
+		t.Errorf("received '%v' expected '%v'", settings.accountBalances["account"].quote, 1337)
+	}
+	if !settings.borrowCost.Equal(decimal.NewFromInt(1)) {
+		t.Errorf("received '%v' expected '%v'", settings.borrowCost, 1)
+	}
+	if !p.peakPortfolioValue.Equal(decimal.NewFromInt(1337)) {
+		t.Errorf("received '%v' expected '%v'", p.peakPortfolioValue, 1337)
+	}
+
+	s.Settings[0].Pair = currency.NewPair(currency.ETH, currency.USD)
+	err = p.RestoreState(s)
+	if !errors.Is(err, errStateMismatch) {
+		t.Errorf("received '%v' expected '%v'", err, errStateMismatch)
+	}
+	err = p.RestoreState(nil)
+	if !errors.Is(err, errNilState) {
+		t.Errorf("received '%v' expected '%v'", err, errNilState)
+	}
+}
@@ -0,0 +1,177 @@
+package statistics
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/shopspring/decimal"
+	"github.com/thrasher-corp/gocryptotrader/backtester/common"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+)
+
+// getAccountAllocations returns the percentage of initial funds
+// segregated into each account for the exchange, asset and pair
+func (s *Statistic) getAccountAllocations(exch string, a asset.Item, p currency.Pair) map[string]decimal.Decimal {
+	var resp map[string]decimal.Decimal
+	for i := range s.AccountAllocations {
+		if !strings.EqualFold(s.AccountAllocations[i].Exchange, exch) ||
+			s.AccountAllocations[i].Asset != a ||
+			!s.AccountAllocations[i].Pair.Equal(p) {
+			continue
+		}
+		if resp == nil {
+			resp = make(map[string]decimal.Decimal)
+		}
+		resp[s.AccountAllocations[i].Account] = s.AccountAllocations[i].Percent
+	}
+	return resp
+}
+
+// calculateAccountStatistics replays filled orders against the balances of
+// the account which funded them, seeded from each account's share of the
+// initial quote and base funds. Orders without an account are funded by the
+// unallocated remainder. Stop-outs and liquidations close the whole holding
+// so are shared across accounts by their base balance. Each account's equity
+// is valued at every close price to build its equity curve, and the
+// aggregate of all accounts is calculated the same way
+func (c *CurrencyPairStatistic) calculateAccountStatistics() {
+	c.AccountStatistics = nil
+	c.AggregateAccount = nil
+	if len(c.accountAllocations) == 0 || len(c.Events) == 0 {
+		return
+	}
+	oneHundred := decimal.NewFromInt(100)
+	remainder := oneHundred
+	for _, percent := range c.accountAllocations {
+		remainder = remainder.Sub(percent)
+	}
+	names := make([]string, 0, len(c.accountAllocations)+1)
+	names = append(names, "")
+	for account := range c.accountAllocations {
+		names = append(names, account)
+	}
+	sort.Strings(names)
+	initial := c.Events[0].Holdings
+	replays := make(map[string]*accountReplay, len(names))
+	for i := range names {
+		percent := remainder
+		if names[i] != "" {
+			percent = c.accountAllocations[names[i]]
+		}
+		replays[names[i]] = &accountReplay{
+			AccountStatistic: AccountStatistic{
+				Account:           names[i],
+				AllocationPercent: percent,
+			},
+			quote: initial.QuoteInitialFunds.Mul(percent).Div(oneHundred),
+			base:  initial.BaseInitialFunds.Mul(percent).Div(oneHundred),
+		}
+	}
+	aggregate := &accountReplay{
+		AccountStatistic: AccountStatistic{AllocationPercent: oneHundred},
+	}
+	for i := range c.Events {
+		ev := &c.Events[i]
+		if f := ev.FillEvent; f != nil && common.CanTransact(f.GetDirection()) && f.GetAmount().IsPositive() {
+			if f.IsStopOut() || f.IsLiquidated() {
+				var totalBase decimal.Decimal
+				for _, r := range replays {
+					if r.base.IsPositive() {
+						totalBase = totalBase.Add(r.base)
+					}
+				}
+				if totalBase.IsPositive() {
+					closed := f.GetAmount().Div(totalBase)
+					for _, r := range replays {
+						if r.base.IsPositive() {
+							r.apply(f.GetDirection().IsLong(), f.GetPurchasePrice(), r.base.Mul(closed), f.GetExchangeFee().Mul(r.base).Div(totalBase))
+						}
+					}
+				}
+			} else {
+				r, ok := replays[f.GetAccount()]
+				if !ok {
+					r = replays[""]
+				}
+				r.apply(f.GetDirection().IsLong(), f.GetPurchasePrice(), f.GetAmount(), f.GetExchangeFee())
+			}
+		}
+		var quote, base decimal.Decimal
+		for _, r := range replays {
+			r.record(ev, i == 0)
+			quote = quote.Add(r.quote)
+			base = base.Add(r.base)
+		}
+		aggregate.quote = quote
+		aggregate.base = base
+		aggregate.record(ev, i == 0)
+	}
+	for i := range names {
+		r := replays[names[i]]
+		aggregate.Orders += r.Orders
+		aggregate.TotalFees = aggregate.TotalFees.Add(r.TotalFees)
+		// the unallocated remainder is only reported when it holds funds or orders
+		if names[i] == "" && !r.AllocationPercent.IsPositive() && r.Orders == 0 {
+			continue
+		}
+		c.AccountStatistics = append(c.AccountStatistics, r.finalise())
+	}
+	resp := aggregate.finalise()
+	c.AggregateAccount = &resp
+}
+
+// accountReplay tracks an account's balances while replaying its fills
+type accountReplay struct {
+	AccountStatistic
+	quote decimal.Decimal
+	base  decimal.Decimal
+	peak  decimal.Decimal
+}
+
+// apply updates the account's balances with an amount filled at a price
+func (r *accountReplay) apply(isLong bool, price, amount, fee decimal.Decimal) {
+	r.Orders++
+	r.TotalFees = r.TotalFees.Add(fee)
+	value := price.Mul(amount)
+	if isLong {
+		r.quote = r.quote.Sub(value).Sub(fee)
+		r.base = r.base.Add(amount)
+		return
+	}
+	r.quote = r.quote.Add(value).Sub(fee)
+	r.base = r.base.Sub(amount)
+}
+
+// record values the account's balances at the event's close price, adding
+// to its equity curve and tracking its peak to trough drawdown
+func (r *accountReplay) record(ev *DataAtOffset, isFirst bool) {
+	equity := r.quote.Add(r.base.Mul(ev.ClosePrice))
+	if isFirst {
+		r.InitialEquity = equity
+		r.peak = equity
+	}
+	r.EquityCurve = append(r.EquityCurve, ValueAtTime{Time: ev.Time, Value: equity, Set: true})
+	if equity.GreaterThan(r.peak) {
+		r.peak = equity
+	}
+	if r.peak.IsPositive() {
+		drawdown := r.peak.Sub(equity).Div(r.peak).Mul(decimal.NewFromInt(100))
+		if drawdown.GreaterThan(r.MaxDrawdownPercent) {
+			r.MaxDrawdownPercent = drawdown
+		}
+	}
+}
+
+// finalise sets the account's final balances, equity and return
+func (r *accountReplay) finalise() AccountStatistic {
+	r.QuoteBalance = r.quote
+	r.BaseBalance = r.base
+	if len(r.EquityCurve) > 0 {
+		r.FinalEquity = r.EquityCurve[len(r.EquityCurve)-1].Value
+	}
+	if r.InitialEquity.IsPositive() {
+		r.ReturnPercent = r.FinalEquity.Sub(r.InitialEquity).Div(r.InitialEquity).Mul(decimal.NewFromInt(100))
+	}
+	return r.AccountStatistic
+}
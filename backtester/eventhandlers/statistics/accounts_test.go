@@ -0,0 +1,108 @@
+package statistics
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventhandlers/portfolio/holdings"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/event"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/fill"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	gctorder "github.com/thrasher-corp/gocryptotrader/exchanges/order"
+)
+
+func TestGetAccountAllocations(t *testing.T) {
+	t.Parallel()
+	cp := currency.NewPair(currency.BTC, currency.USDT)
+	s := &Statistic{
+		AccountAllocations: []AccountAllocation{
+			{Exchange: testExchange, Asset: asset.Spot, Pair: cp, Account: "variant-a", Percent: decimal.NewFromInt(50)},
+			{Exchange: testExchange, Asset: asset.Futures, Pair: cp, Account: "variant-b", Percent: decimal.NewFromInt(50)},
+		},
+	}
+	resp := s.getAccountAllocations(testExchange, asset.Spot, cp)
+	if len(resp) != 1 || !resp["variant-a"].Equal(decimal.NewFromInt(50)) {
+		t.Errorf("received '%v' expected '%v'", resp, "variant-a 50")
+	}
+	if resp = s.getAccountAllocations(testExchange, asset.Margin, cp); resp != nil {
+		t.Errorf("received '%v' expected '%v'", resp, nil)
+	}
+}
+
+func TestCalculateAccountStatistics(t *testing.T) {
+	t.Parallel()
+	c := CurrencyPairStatistic{}
+	c.calculateAccountStatistics()
+	if c.AccountStatistics != nil || c.AggregateAccount != nil {
+		t.Errorf("received '%v' expected '%v'", c.AccountStatistics, nil)
+	}
+
+	c.accountAllocations = map[string]decimal.Decimal{
+		"variant-a": decimal.NewFromInt(50),
+		"variant-b": decimal.NewFromInt(50),
+	}
+	initial := holdings.Holding{QuoteInitialFunds: decimal.NewFromInt(2000)}
+	newFill := func(side gctorder.Side, price int64, account string) *fill.Fill {
+		return &fill.Fill{
+			Base:          &event.Base{},
+			Direction:     side,
+			Amount:        decimal.NewFromInt(1),
+			PurchasePrice: decimal.NewFromInt(price),
+			ExchangeFee:   decimal.NewFromInt(1),
+			Account:       account,
+		}
+	}
+	c.Events = []DataAtOffset{
+		{ClosePrice: decimal.NewFromInt(100), Holdings: initial, FillEvent: newFill(gctorder.Buy, 100, "variant-a")},
+		{ClosePrice: decimal.NewFromInt(50), Holdings: initial, FillEvent: newFill(gctorder.CouldNotBuy, 50, "variant-b")},
+		{ClosePrice: decimal.NewFromInt(200), Holdings: initial, FillEvent: newFill(gctorder.Buy, 200, "variant-b")},
+	}
+	c.calculateAccountStatistics()
+	// the empty unallocated remainder is not reported
+	if len(c.AccountStatistics) != 2 {
+		t.Fatalf("received '%v' expected '%v'", len(c.AccountStatistics), 2)
+	}
+	a := c.AccountStatistics[0]
+	if a.Account != "variant-a" || a.Orders != 1 || !a.BaseBalance.Equal(decimal.NewFromInt(1)) {
+		t.Errorf("received '%+v' expected variant-a with one order", a)
+	}
+	// 899 quote plus 1 base at 200
+	if !a.InitialEquity.Equal(decimal.NewFromInt(999)) || !a.FinalEquity.Equal(decimal.NewFromInt(1099)) {
+		t.Errorf("received '%v' '%v' expected '%v' '%v'", a.InitialEquity, a.FinalEquity, 999, 1099)
+	}
+	// 949 at a close of 50 from a peak of 999
+	if !a.MaxDrawdownPercent.Equal(decimal.NewFromInt(50).Div(decimal.NewFromInt(999)).Mul(decimal.NewFromInt(100))) {
+		t.Errorf("received '%v' expected drawdown of 50 from 999", a.MaxDrawdownPercent)
+	}
+	if len(a.EquityCurve) != 3 {
+		t.Errorf("received '%v' expected '%v'", len(a.EquityCurve), 3)
+	}
+	b := c.AccountStatistics[1]
+	if b.Account != "variant-b" || b.Orders != 1 || !b.QuoteBalance.Equal(decimal.NewFromInt(799)) {
+		t.Errorf("received '%+v' expected variant-b with one order", b)
+	}
+	if !b.InitialEquity.Equal(decimal.NewFromInt(1000)) || !b.FinalEquity.Equal(decimal.NewFromInt(999)) {
+		t.Errorf("received '%v' '%v' expected '%v' '%v'", b.InitialEquity, b.FinalEquity, 1000, 999)
+	}
+	agg := c.AggregateAccount
+	if agg == nil {
+		t.Fatal("expected aggregate account")
+	}
+	if agg.Orders != 2 || !agg.TotalFees.Equal(decimal.NewFromInt(2)) || !agg.FinalEquity.Equal(decimal.NewFromInt(2098)) {
+		t.Errorf("received '%+v' expected aggregate of both accounts", agg)
+	}
+
+	// stop-outs close each account's share of the holding
+	stopOut := newFill(gctorder.Sell, 200, "")
+	stopOut.Amount = decimal.NewFromInt(2)
+	stopOut.ExchangeFee = decimal.NewFromInt(2)
+	stopOut.StopOut = true
+	c.Events = append(c.Events, DataAtOffset{ClosePrice: decimal.NewFromInt(200), Holdings: initial, FillEvent: stopOut})
+	c.calculateAccountStatistics()
+	for i := range c.AccountStatistics {
+		if !c.AccountStatistics[i].BaseBalance.IsZero() || c.AccountStatistics[i].Orders != 2 {
+			t.Errorf("received '%+v' expected account base to be closed", c.AccountStatistics[i])
+		}
+	}
+}
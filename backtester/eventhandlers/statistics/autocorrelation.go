@@ -0,0 +1,67 @@
+package statistics
+
+import (
+	"math"
+
+	"github.com/shopspring/decimal"
+)
+
+// defaultAutocorrelationLags is the number of lags
+// calculated when no lags are configured
+const defaultAutocorrelationLags = 1
+
+// autocorrelationSignificanceZ is the z-score of the 95% confidence
+// interval used for the significance bound of each coefficient
+var autocorrelationSignificanceZ = decimal.NewFromFloat(1.96)
+
+// calculateAutocorrelation calculates the serial autocorrelation of the
+// returns at each lag up to maxLag, defaulting to lag 1. Each coefficient is
+// the covariance of the returns with themselves shifted by the lag divided by
+// their variance. Lags which leave no overlapping returns are skipped.
+// Coefficients beyond the significance bound of 1.96/sqrt(n) are flagged,
+// and a significant lag 1 coefficient marks the returns as highly
+// autocorrelated as risk ratios assume independent returns
+func calculateAutocorrelation(returns []decimal.Decimal, maxLag int64) (*Autocorrelation, error) {
+	if len(returns) == 0 {
+		return nil, errReceivedNoData
+	}
+	if maxLag <= 0 {
+		maxLag = defaultAutocorrelationLags
+	}
+	n := int64(len(returns))
+	var sum decimal.Decimal
+	for i := range returns {
+		sum = sum.Add(returns[i])
+	}
+	mean := sum.Div(decimal.NewFromInt(n))
+	deviations := make([]decimal.Decimal, len(returns))
+	var variance decimal.Decimal
+	for i := range returns {
+		deviations[i] = returns[i].Sub(mean)
+		variance = variance.Add(deviations[i].Mul(deviations[i]))
+	}
+	resp := &Autocorrelation{
+		Observations:      n,
+		SignificanceBound: autocorrelationSignificanceZ.Div(decimal.NewFromFloat(math.Sqrt(float64(n)))),
+	}
+	for lag := int64(1); lag <= maxLag && lag < n; lag++ {
+		var coefficient decimal.Decimal
+		if !variance.IsZero() {
+			var covariance decimal.Decimal
+			for i := lag; i < n; i++ {
+				covariance = covariance.Add(deviations[i].Mul(deviations[i-lag]))
+			}
+			coefficient = covariance.Div(variance)
+		}
+		significant := coefficient.Abs().GreaterThan(resp.SignificanceBound)
+		resp.Lags = append(resp.Lags, LagAutocorrelation{
+			Lag:         lag,
+			Coefficient: coefficient,
+			Significant: significant,
+		})
+		if lag == 1 {
+			resp.HighAutocorrelation = significant
+		}
+	}
+	return resp, nil
+}
@@ -0,0 +1,62 @@
+package statistics
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestCalculateAutocorrelation(t *testing.T) {
+	t.Parallel()
+	_, err := calculateAutocorrelation(nil, 1)
+	if !errors.Is(err, errReceivedNoData) {
+		t.Errorf("received '%v' expected '%v'", err, errReceivedNoData)
+	}
+
+	// alternating returns are perfectly negatively correlated at lag 1
+	// and positively correlated at lag 2
+	alternating := make([]decimal.Decimal, 100)
+	for i := range alternating {
+		alternating[i] = decimal.NewFromInt(1)
+		if i%2 == 1 {
+			alternating[i] = decimal.NewFromInt(-1)
+		}
+	}
+	resp, err := calculateAutocorrelation(alternating, 2)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	if len(resp.Lags) != 2 || resp.Observations != 100 {
+		t.Fatalf("received '%v' lags '%v' observations expected '%v' '%v'", len(resp.Lags), resp.Observations, 2, 100)
+	}
+	// the sum of 99 lagged products of -1 over a variance of 100
+	if !resp.Lags[0].Coefficient.Equal(decimal.NewFromFloat(-0.99)) || !resp.Lags[0].Significant {
+		t.Errorf("received '%+v' expected significant coefficient of '%v'", resp.Lags[0], -0.99)
+	}
+	if !resp.Lags[1].Coefficient.Equal(decimal.NewFromFloat(0.98)) || !resp.Lags[1].Significant {
+		t.Errorf("received '%+v' expected significant coefficient of '%v'", resp.Lags[1], 0.98)
+	}
+	if !resp.SignificanceBound.Equal(decimal.NewFromFloat(0.196)) || !resp.HighAutocorrelation {
+		t.Errorf("received '%v' '%v' expected '%v' '%v'", resp.SignificanceBound, resp.HighAutocorrelation, 0.196, true)
+	}
+
+	// lag 1 is calculated by default and constant returns have no correlation
+	constant := []decimal.Decimal{decimal.NewFromInt(1), decimal.NewFromInt(1), decimal.NewFromInt(1), decimal.NewFromInt(1)}
+	resp, err = calculateAutocorrelation(constant, 0)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	if len(resp.Lags) != 1 || !resp.Lags[0].Coefficient.IsZero() || resp.HighAutocorrelation {
+		t.Errorf("received '%+v' expected an uncorrelated lag 1", resp)
+	}
+
+	// lags without overlapping returns are skipped
+	resp, err = calculateAutocorrelation(constant[:2], 5)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	if len(resp.Lags) != 1 {
+		t.Errorf("received '%v' expected '%v'", len(resp.Lags), 1)
+	}
+}
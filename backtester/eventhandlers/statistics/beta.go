@@ -0,0 +1,147 @@
+package statistics
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/thrasher-corp/gocryptotrader/backtester/common"
+	"github.com/thrasher-corp/gocryptotrader/log"
+)
+
+// calculateStrategyBetas regresses the strategy's total USD returns against
+// each traded instrument's close price returns, revealing whether the strategy
+// is simply exposed to the instrument or is market neutral. Betas require USD
+// tracking as the strategy's returns are derived from its total USD holdings
+func (s *Statistic) calculateStrategyBetas() error {
+	s.StrategyBetas = nil
+	if s.FundingStatistics == nil ||
+		s.FundingStatistics.TotalUSDStatistics == nil ||
+		len(s.FundingStatistics.TotalUSDStatistics.HoldingValues) < 2 {
+		return nil
+	}
+	holdingValues := s.FundingStatistics.TotalUSDStatistics.HoldingValues
+	strategyReturns := make(map[time.Time]decimal.Decimal, len(holdingValues))
+	for i := 1; i < len(holdingValues); i++ {
+		if holdingValues[i-1].Value.IsZero() {
+			continue
+		}
+		strategyReturns[holdingValues[i].Time] = holdingValues[i].Value.Sub(holdingValues[i-1].Value).Div(holdingValues[i-1].Value)
+	}
+	for exch, assetMap := range s.ExchangeAssetPairStatistics {
+		for a, pairMap := range assetMap {
+			for p, stats := range pairMap {
+				var strategy, instrument []decimal.Decimal
+				var times []time.Time
+				for i := 1; i < len(stats.Events); i++ {
+					if stats.Events[i-1].ClosePrice.IsZero() {
+						continue
+					}
+					strategyReturn, ok := strategyReturns[stats.Events[i].Time]
+					if !ok {
+						continue
+					}
+					strategy = append(strategy, strategyReturn)
+					instrument = append(instrument, stats.Events[i].ClosePrice.Sub(stats.Events[i-1].ClosePrice).Div(stats.Events[i-1].ClosePrice))
+					times = append(times, stats.Events[i].Time)
+				}
+				beta, rSquared, err := calculateBeta(strategy, instrument)
+				if err != nil {
+					return fmt.Errorf("%v %v %v %w", exch, a, p, err)
+				}
+				var rollingBeta []ValueAtTime
+				if s.RollingBetaWindow > 0 {
+					rollingBeta, err = calculateRollingBeta(strategy, instrument, times, s.RollingBetaWindow)
+					if err != nil {
+						log.Errorf(common.Statistics, "%v %v %v %v", exch, a, p, err)
+					}
+				}
+				s.StrategyBetas = append(s.StrategyBetas, StrategyBeta{
+					Exchange:     exch,
+					Asset:        a,
+					Pair:         p,
+					Beta:         beta,
+					RSquared:     rSquared,
+					Observations: int64(len(instrument)),
+					RollingBeta:  rollingBeta,
+				})
+			}
+		}
+	}
+	sort.Slice(s.StrategyBetas, func(i, j int) bool {
+		if s.StrategyBetas[i].Exchange != s.StrategyBetas[j].Exchange {
+			return s.StrategyBetas[i].Exchange < s.StrategyBetas[j].Exchange
+		}
+		if s.StrategyBetas[i].Asset != s.StrategyBetas[j].Asset {
+			return s.StrategyBetas[i].Asset < s.StrategyBetas[j].Asset
+		}
+		return s.StrategyBetas[i].Pair.String() < s.StrategyBetas[j].Pair.String()
+	})
+	return nil
+}
+
+// calculateBeta performs an ordinary least squares regression of the strategy
+// returns against the instrument returns, returning the slope and the
+// coefficient of determination. Instruments whose price never moved have no
+// beta and return zero values
+func calculateBeta(strategyReturns, instrumentReturns []decimal.Decimal) (beta, rSquared decimal.Decimal, err error) {
+	if len(strategyReturns) != len(instrumentReturns) {
+		return decimal.Zero, decimal.Zero, fmt.Errorf("%w strategy returns %v instrument returns %v", errMismatchedReturnLengths, len(strategyReturns), len(instrumentReturns))
+	}
+	if len(instrumentReturns) < 2 {
+		return decimal.Zero, decimal.Zero, nil
+	}
+	n := decimal.NewFromInt(int64(len(instrumentReturns)))
+	var strategySum, instrumentSum decimal.Decimal
+	for i := range instrumentReturns {
+		strategySum = strategySum.Add(strategyReturns[i])
+		instrumentSum = instrumentSum.Add(instrumentReturns[i])
+	}
+	strategyMean := strategySum.Div(n)
+	instrumentMean := instrumentSum.Div(n)
+	var covariance, strategyVariance, instrumentVariance decimal.Decimal
+	for i := range instrumentReturns {
+		strategyDiff := strategyReturns[i].Sub(strategyMean)
+		instrumentDiff := instrumentReturns[i].Sub(instrumentMean)
+		covariance = covariance.Add(strategyDiff.Mul(instrumentDiff))
+		strategyVariance = strategyVariance.Add(strategyDiff.Mul(strategyDiff))
+		instrumentVariance = instrumentVariance.Add(instrumentDiff.Mul(instrumentDiff))
+	}
+	if instrumentVariance.IsZero() {
+		return decimal.Zero, decimal.Zero, nil
+	}
+	beta = covariance.Div(instrumentVariance)
+	if !strategyVariance.IsZero() {
+		rSquared = covariance.Mul(covariance).Div(strategyVariance.Mul(instrumentVariance))
+	}
+	return beta, rSquared, nil
+}
+
+// calculateRollingBeta regresses the strategy returns against the instrument
+// returns over a sliding window, producing a beta at the time of each
+// window's latest return to show how the strategy's exposure drifted
+func calculateRollingBeta(strategyReturns, instrumentReturns []decimal.Decimal, times []time.Time, window int64) ([]ValueAtTime, error) {
+	if window < 2 {
+		return nil, fmt.Errorf("%w %v, must be at least 2", errInvalidRollingBetaWindow, window)
+	}
+	if len(strategyReturns) != len(instrumentReturns) || len(instrumentReturns) != len(times) {
+		return nil, fmt.Errorf("%w strategy returns %v instrument returns %v times %v", errMismatchedReturnLengths, len(strategyReturns), len(instrumentReturns), len(times))
+	}
+	if int64(len(instrumentReturns)) < window {
+		return nil, fmt.Errorf("%w, %v returns for a window of %v", errNotEnoughEventsForRollingBeta, len(instrumentReturns), window)
+	}
+	series := make([]ValueAtTime, 0, int64(len(instrumentReturns))-window+1)
+	for i := window; i <= int64(len(instrumentReturns)); i++ {
+		beta, _, err := calculateBeta(strategyReturns[i-window:i], instrumentReturns[i-window:i])
+		if err != nil {
+			return nil, err
+		}
+		series = append(series, ValueAtTime{
+			Time:  times[i-1],
+			Value: beta,
+			Set:   true,
+		})
+	}
+	return series, nil
+}
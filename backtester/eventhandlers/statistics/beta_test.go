@@ -0,0 +1,176 @@
+package statistics
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+)
+
+func TestCalculateBeta(t *testing.T) {
+	t.Parallel()
+	_, _, err := calculateBeta([]decimal.Decimal{decimal.Zero}, nil)
+	if !errors.Is(err, errMismatchedReturnLengths) {
+		t.Errorf("received '%v' expected '%v'", err, errMismatchedReturnLengths)
+	}
+
+	instrument := []decimal.Decimal{
+		decimal.NewFromFloat(0.01),
+		decimal.NewFromFloat(-0.02),
+		decimal.NewFromFloat(0.03),
+		decimal.NewFromFloat(0.005),
+	}
+	strategy := make([]decimal.Decimal, len(instrument))
+	for i := range instrument {
+		strategy[i] = instrument[i].Mul(decimal.NewFromInt(2))
+	}
+	beta, rSquared, err := calculateBeta(strategy, instrument)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	if !beta.Equal(decimal.NewFromInt(2)) {
+		t.Errorf("received '%v' expected '%v'", beta, 2)
+	}
+	if !rSquared.Round(8).Equal(decimal.NewFromInt(1)) {
+		t.Errorf("received '%v' expected '%v'", rSquared, 1)
+	}
+
+	flat := make([]decimal.Decimal, len(instrument))
+	beta, rSquared, err = calculateBeta(flat, instrument)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	if !beta.IsZero() || !rSquared.IsZero() {
+		t.Errorf("received '%v' '%v' expected market neutral zero values", beta, rSquared)
+	}
+
+	beta, _, err = calculateBeta(strategy, flat)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	if !beta.IsZero() {
+		t.Errorf("received '%v' expected '%v'", beta, 0)
+	}
+}
+
+func TestCalculateStrategyBetas(t *testing.T) {
+	t.Parallel()
+	s := Statistic{}
+	err := s.calculateStrategyBetas()
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	if len(s.StrategyBetas) != 0 {
+		t.Errorf("received '%v' expected '%v'", len(s.StrategyBetas), 0)
+	}
+
+	tt := time.Now().Truncate(time.Hour)
+	closes := []int64{100, 110, 99, 120, 120}
+	holdings := []int64{1000, 1050, 997, 1097, 1097}
+	stats := &CurrencyPairStatistic{}
+	usd := &TotalFundingStatistics{}
+	for i := range closes {
+		stats.Events = append(stats.Events, DataAtOffset{
+			Time:       tt.Add(time.Hour * time.Duration(i)),
+			ClosePrice: decimal.NewFromInt(closes[i]),
+		})
+		usd.HoldingValues = append(usd.HoldingValues, ValueAtTime{
+			Time:  tt.Add(time.Hour * time.Duration(i)),
+			Value: decimal.NewFromInt(holdings[i]),
+		})
+	}
+	p := currency.NewPair(currency.BTC, currency.USDT)
+	s.ExchangeAssetPairStatistics = map[string]map[asset.Item]map[currency.Pair]*CurrencyPairStatistic{
+		testExchange: {asset.Spot: {p: stats}},
+	}
+	s.FundingStatistics = &FundingStatistics{TotalUSDStatistics: usd}
+	err = s.calculateStrategyBetas()
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	if len(s.StrategyBetas) != 1 {
+		t.Fatalf("received '%v' expected '%v'", len(s.StrategyBetas), 1)
+	}
+	b := s.StrategyBetas[0]
+	if b.Observations != 4 {
+		t.Errorf("received '%v' expected '%v'", b.Observations, 4)
+	}
+	if !b.Beta.IsPositive() || b.Beta.GreaterThan(decimal.NewFromInt(1)) {
+		t.Errorf("received '%v' expected a beta between 0 and 1", b.Beta)
+	}
+	if !b.RSquared.IsPositive() || b.RSquared.GreaterThan(decimal.NewFromInt(1)) {
+		t.Errorf("received '%v' expected an R² between 0 and 1", b.RSquared)
+	}
+	if !b.Pair.Equal(p) {
+		t.Errorf("received '%v' expected '%v'", b.Pair, p)
+	}
+	if b.RollingBeta != nil {
+		t.Errorf("received '%v' expected no rolling beta when disabled", b.RollingBeta)
+	}
+
+	s.RollingBetaWindow = 3
+	err = s.calculateStrategyBetas()
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	if len(s.StrategyBetas[0].RollingBeta) != 2 {
+		t.Errorf("received '%v' expected '%v'", len(s.StrategyBetas[0].RollingBeta), 2)
+	}
+}
+
+func TestCalculateRollingBeta(t *testing.T) {
+	t.Parallel()
+	tt := time.Now().Truncate(time.Hour)
+	instrument := []decimal.Decimal{
+		decimal.NewFromFloat(0.01),
+		decimal.NewFromFloat(-0.02),
+		decimal.NewFromFloat(0.03),
+		decimal.NewFromFloat(0.005),
+		decimal.NewFromFloat(-0.01),
+	}
+	// the strategy starts market neutral and becomes twice as directional
+	strategy := []decimal.Decimal{
+		decimal.Zero,
+		decimal.Zero,
+		instrument[2].Mul(decimal.NewFromInt(2)),
+		instrument[3].Mul(decimal.NewFromInt(2)),
+		instrument[4].Mul(decimal.NewFromInt(2)),
+	}
+	times := make([]time.Time, len(instrument))
+	for i := range times {
+		times[i] = tt.Add(time.Hour * time.Duration(i))
+	}
+
+	_, err := calculateRollingBeta(strategy, instrument, times, 1)
+	if !errors.Is(err, errInvalidRollingBetaWindow) {
+		t.Errorf("received '%v' expected '%v'", err, errInvalidRollingBetaWindow)
+	}
+	_, err = calculateRollingBeta(strategy, instrument, times[1:], 2)
+	if !errors.Is(err, errMismatchedReturnLengths) {
+		t.Errorf("received '%v' expected '%v'", err, errMismatchedReturnLengths)
+	}
+	_, err = calculateRollingBeta(strategy, instrument, times, 6)
+	if !errors.Is(err, errNotEnoughEventsForRollingBeta) {
+		t.Errorf("received '%v' expected '%v'", err, errNotEnoughEventsForRollingBeta)
+	}
+
+	series, err := calculateRollingBeta(strategy, instrument, times, 2)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	if len(series) != 4 {
+		t.Fatalf("received '%v' expected '%v'", len(series), 4)
+	}
+	if !series[0].Value.IsZero() {
+		t.Errorf("received '%v' expected '%v'", series[0].Value, 0)
+	}
+	if !series[3].Value.Equal(decimal.NewFromInt(2)) {
+		t.Errorf("received '%v' expected '%v'", series[3].Value, 2)
+	}
+	if !series[0].Time.Equal(times[1]) || !series[3].Time.Equal(times[4]) {
+		t.Errorf("received '%v' '%v' expected '%v' '%v'", series[0].Time, series[3].Time, times[1], times[4])
+	}
+}
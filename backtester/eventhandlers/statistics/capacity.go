@@ -0,0 +1,56 @@
+package statistics
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/shopspring/decimal"
+	"github.com/thrasher-corp/gocryptotrader/backtester/common"
+)
+
+// CalculateCapacity estimates how much capital the strategy could deploy on
+// the currency before its fills consistently exceed the target percentage of
+// candle volume. Fill sizes are assumed to scale with the starting capital,
+// so the capacity is the starting capital scaled by how far the median fill's
+// volume participation is from the target. The conservative capacity is where
+// the largest participating fill reaches the target. Fills on candles without
+// volume are ignored. Returns nil when there are no fills or starting capital
+func (c *CurrencyPairStatistic) CalculateCapacity(targetParticipationPercent decimal.Decimal) (*CapacityEstimate, error) {
+	if !targetParticipationPercent.IsPositive() || targetParticipationPercent.GreaterThan(decimal.NewFromInt(100)) {
+		return nil, fmt.Errorf("%w %v%%, must be between 0 and 100", errInvalidCapacityParticipation, targetParticipationPercent)
+	}
+	if len(c.Events) == 0 {
+		return nil, nil
+	}
+	initialCapital := c.Events[0].Holdings.TotalInitialValue
+	if !initialCapital.IsPositive() {
+		return nil, nil
+	}
+	oneHundred := decimal.NewFromInt(100)
+	var participation []decimal.Decimal
+	for i := range c.Events {
+		f := c.Events[i].FillEvent
+		d := c.Events[i].DataEvent
+		if f == nil || d == nil || !common.CanTransact(f.GetDirection()) || !f.GetAmount().IsPositive() || !d.GetVolume().IsPositive() {
+			continue
+		}
+		participation = append(participation, f.GetAmount().Div(d.GetVolume()).Mul(oneHundred))
+	}
+	if len(participation) == 0 {
+		return nil, nil
+	}
+	sort.Slice(participation, func(i, j int) bool {
+		return participation[i].LessThan(participation[j])
+	})
+	median := participation[percentileIndex(len(participation), decimal.NewFromInt(50))]
+	maximum := participation[len(participation)-1]
+	return &CapacityEstimate{
+		TargetParticipationPercent:  targetParticipationPercent,
+		InitialCapital:              initialCapital,
+		Fills:                       int64(len(participation)),
+		MedianParticipationPercent:  median,
+		MaximumParticipationPercent: maximum,
+		EstimatedCapacity:           initialCapital.Mul(targetParticipationPercent).Div(median),
+		ConservativeCapacity:        initialCapital.Mul(targetParticipationPercent).Div(maximum),
+	}, nil
+}
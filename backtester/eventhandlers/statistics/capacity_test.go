@@ -0,0 +1,83 @@
+package statistics
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventhandlers/portfolio/holdings"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/event"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/fill"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/kline"
+	gctorder "github.com/thrasher-corp/gocryptotrader/exchanges/order"
+)
+
+func TestCalculateCapacity(t *testing.T) {
+	t.Parallel()
+	c := CurrencyPairStatistic{}
+	_, err := c.CalculateCapacity(decimal.Zero)
+	if !errors.Is(err, errInvalidCapacityParticipation) {
+		t.Errorf("received '%v' expected '%v'", err, errInvalidCapacityParticipation)
+	}
+	_, err = c.CalculateCapacity(decimal.NewFromInt(101))
+	if !errors.Is(err, errInvalidCapacityParticipation) {
+		t.Errorf("received '%v' expected '%v'", err, errInvalidCapacityParticipation)
+	}
+	capacity, err := c.CalculateCapacity(decimal.NewFromInt(1))
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	if capacity != nil {
+		t.Errorf("received '%v' expected nil", capacity)
+	}
+
+	fills := []struct {
+		side   gctorder.Side
+		amount int64
+		volume int64
+	}{
+		{gctorder.Buy, 1, 1000},
+		{gctorder.DoNothing, 0, 1000},
+		{gctorder.Sell, 2, 1000},
+		{gctorder.Buy, 5, 1000},
+		// ignored without candle volume
+		{gctorder.Sell, 8, 0},
+	}
+	for i := range fills {
+		c.Events = append(c.Events, DataAtOffset{
+			Holdings: holdings.Holding{TotalInitialValue: decimal.NewFromInt(10000)},
+			DataEvent: &kline.Kline{
+				Base:   &event.Base{},
+				Volume: decimal.NewFromInt(fills[i].volume),
+			},
+			FillEvent: &fill.Fill{
+				Base:      &event.Base{},
+				Direction: fills[i].side,
+				Amount:    decimal.NewFromInt(fills[i].amount),
+			},
+		})
+	}
+	capacity, err = c.CalculateCapacity(decimal.NewFromInt(1))
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	if capacity == nil {
+		t.Fatal("expected capacity estimate")
+	}
+	if capacity.Fills != 3 {
+		t.Errorf("received '%v' expected '%v'", capacity.Fills, 3)
+	}
+	// the median fill trades 0.2% of volume, reaching 1% at five times the capital
+	if !capacity.MedianParticipationPercent.Equal(decimal.NewFromFloat(0.2)) {
+		t.Errorf("received '%v' expected '%v'", capacity.MedianParticipationPercent, 0.2)
+	}
+	if !capacity.EstimatedCapacity.Equal(decimal.NewFromInt(50000)) {
+		t.Errorf("received '%v' expected '%v'", capacity.EstimatedCapacity, 50000)
+	}
+	if !capacity.MaximumParticipationPercent.Equal(decimal.NewFromFloat(0.5)) {
+		t.Errorf("received '%v' expected '%v'", capacity.MaximumParticipationPercent, 0.5)
+	}
+	if !capacity.ConservativeCapacity.Equal(decimal.NewFromInt(20000)) {
+		t.Errorf("received '%v' expected '%v'", capacity.ConservativeCapacity, 20000)
+	}
+}
@@ -0,0 +1,35 @@
+package statistics
+
+import (
+	"github.com/shopspring/decimal"
+	"github.com/thrasher-corp/gocryptotrader/backtester/funding"
+)
+
+// flowAdjustedHoldingValues chains each candle's holding value return with
+// the USD value of deposits and withdrawals applied during the candle
+// removed, so that external flows are not mistaken for performance. Flows
+// applied at the first value are treated as part of the starting capital
+func flowAdjustedHoldingValues(values []ValueAtTime, flows []funding.CapitalFlow) []ValueAtTime {
+	if len(values) == 0 {
+		return nil
+	}
+	adjusted := make([]ValueAtTime, len(values))
+	adjusted[0] = values[0]
+	for i := 1; i < len(values); i++ {
+		adjusted[i] = ValueAtTime{
+			Time:  values[i].Time,
+			Value: adjusted[i-1].Value,
+		}
+		if values[i-1].Value.IsZero() {
+			continue
+		}
+		var netFlow decimal.Decimal
+		for j := range flows {
+			if flows[j].AppliedTime.After(values[i-1].Time) && !flows[j].AppliedTime.After(values[i].Time) {
+				netFlow = netFlow.Add(flows[j].USDValue)
+			}
+		}
+		adjusted[i].Value = adjusted[i-1].Value.Mul(values[i].Value.Sub(netFlow)).Div(values[i-1].Value)
+	}
+	return adjusted
+}
@@ -0,0 +1,46 @@
+package statistics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/thrasher-corp/gocryptotrader/backtester/funding"
+)
+
+func TestFlowAdjustedHoldingValues(t *testing.T) {
+	t.Parallel()
+	if v := flowAdjustedHoldingValues(nil, nil); v != nil {
+		t.Errorf("received '%v' expected '%v'", v, nil)
+	}
+	tt := time.Date(2022, 1, 3, 0, 0, 0, 0, time.UTC)
+	values := []ValueAtTime{
+		{Time: tt, Value: decimal.NewFromInt(1000)},
+		{Time: tt.Add(time.Hour), Value: decimal.NewFromInt(1100)},
+		{Time: tt.Add(time.Hour * 2), Value: decimal.NewFromInt(2200)},
+		{Time: tt.Add(time.Hour * 3), Value: decimal.NewFromInt(1650)},
+	}
+	flows := []funding.CapitalFlow{
+		// applied at the first value, part of the starting capital
+		{AppliedTime: tt, USDValue: decimal.NewFromInt(500)},
+		// a deposit of 1100 during the second candle is not a return
+		{AppliedTime: tt.Add(time.Hour * 2), USDValue: decimal.NewFromInt(1100)},
+		// a withdrawal of 660 during the third candle is not a loss
+		{AppliedTime: tt.Add(time.Hour * 3), USDValue: decimal.NewFromInt(-660)},
+	}
+	adjusted := flowAdjustedHoldingValues(values, flows)
+	expected := []decimal.Decimal{
+		decimal.NewFromInt(1000),
+		decimal.NewFromInt(1100),
+		decimal.NewFromInt(1100),
+		decimal.NewFromInt(1155),
+	}
+	for i := range expected {
+		if !adjusted[i].Time.Equal(values[i].Time) {
+			t.Errorf("received '%v' expected '%v'", adjusted[i].Time, values[i].Time)
+		}
+		if !adjusted[i].Value.Equal(expected[i]) {
+			t.Errorf("received '%v' expected '%v'", adjusted[i].Value, expected[i])
+		}
+	}
+}
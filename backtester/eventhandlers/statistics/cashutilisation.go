@@ -0,0 +1,51 @@
+package statistics
+
+import (
+	"github.com/shopspring/decimal"
+	"github.com/thrasher-corp/gocryptotrader/backtester/funding"
+	"github.com/thrasher-corp/gocryptotrader/backtester/funding/trackingcurrencies"
+)
+
+// calculateCashUtilisation splits each USD total snapshot into idle cash, being
+// USD equivalent currencies, and capital deployed into other currencies.
+// Utilisation is the percentage of the total USD value deployed. The average is
+// taken across every snapshot and the final breakdown is from the last snapshot
+func (t *TotalFundingStatistics) calculateCashUtilisation(usdTotals []funding.ItemSnapshot) {
+	if len(usdTotals) == 0 {
+		return
+	}
+	var utilisationSum decimal.Decimal
+	for i := range usdTotals {
+		cash, positions := cashAndPositionValues(usdTotals[i].Breakdown)
+		utilisation := cashUtilisation(cash, positions)
+		utilisationSum = utilisationSum.Add(utilisation)
+		if i == len(usdTotals)-1 {
+			t.FinalCashValue = cash
+			t.FinalPositionsValue = positions
+			t.FinalCashUtilisation = utilisation
+		}
+	}
+	t.AverageCashUtilisation = utilisationSum.Div(decimal.NewFromInt(int64(len(usdTotals))))
+}
+
+// cashAndPositionValues totals the USD contributions of USD equivalent
+// currencies as cash and all other currencies as deployed positions
+func cashAndPositionValues(breakdown []funding.CurrencyContribution) (cash, positions decimal.Decimal) {
+	for i := range breakdown {
+		if trackingcurrencies.CurrencyIsUSDTracked(breakdown[i].Currency) {
+			cash = cash.Add(breakdown[i].USDContribution)
+		} else {
+			positions = positions.Add(breakdown[i].USDContribution)
+		}
+	}
+	return cash, positions
+}
+
+// cashUtilisation returns the percentage of total value deployed
+func cashUtilisation(cash, positions decimal.Decimal) decimal.Decimal {
+	total := cash.Add(positions)
+	if total.IsZero() {
+		return decimal.Zero
+	}
+	return positions.Div(total).Mul(decimal.NewFromInt(100))
+}
@@ -0,0 +1,66 @@
+package statistics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/thrasher-corp/gocryptotrader/backtester/funding"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+)
+
+func TestCalculateCashUtilisation(t *testing.T) {
+	t.Parallel()
+	usd := &TotalFundingStatistics{}
+	usd.calculateCashUtilisation(nil)
+	if !usd.AverageCashUtilisation.IsZero() {
+		t.Errorf("received '%v' expected '%v'", usd.AverageCashUtilisation, 0)
+	}
+
+	tt := time.Now()
+	usd.calculateCashUtilisation([]funding.ItemSnapshot{
+		{
+			Time: tt,
+			Breakdown: []funding.CurrencyContribution{
+				{Currency: currency.USDT, USDContribution: decimal.NewFromInt(1000)},
+				{Currency: currency.BTC, USDContribution: decimal.Zero},
+			},
+		},
+		{
+			Time: tt.Add(time.Hour),
+			Breakdown: []funding.CurrencyContribution{
+				{Currency: currency.USDT, USDContribution: decimal.NewFromInt(250)},
+				{Currency: currency.BTC, USDContribution: decimal.NewFromInt(750)},
+			},
+		},
+		{
+			Time: tt.Add(time.Hour * 2),
+			Breakdown: []funding.CurrencyContribution{
+				{Currency: currency.USDT, USDContribution: decimal.NewFromInt(500)},
+				{Currency: currency.BTC, USDContribution: decimal.NewFromInt(500)},
+			},
+		},
+	})
+	if !usd.AverageCashUtilisation.Equal(decimal.NewFromInt(125).Div(decimal.NewFromInt(3))) {
+		t.Errorf("received '%v' expected '%v'", usd.AverageCashUtilisation, decimal.NewFromInt(125).Div(decimal.NewFromInt(3)))
+	}
+	if !usd.FinalCashUtilisation.Equal(decimal.NewFromInt(50)) {
+		t.Errorf("received '%v' expected '%v'", usd.FinalCashUtilisation, 50)
+	}
+	if !usd.FinalCashValue.Equal(decimal.NewFromInt(500)) {
+		t.Errorf("received '%v' expected '%v'", usd.FinalCashValue, 500)
+	}
+	if !usd.FinalPositionsValue.Equal(decimal.NewFromInt(500)) {
+		t.Errorf("received '%v' expected '%v'", usd.FinalPositionsValue, 500)
+	}
+}
+
+func TestCashUtilisation(t *testing.T) {
+	t.Parallel()
+	if u := cashUtilisation(decimal.Zero, decimal.Zero); !u.IsZero() {
+		t.Errorf("received '%v' expected '%v'", u, 0)
+	}
+	if u := cashUtilisation(decimal.NewFromInt(1), decimal.NewFromInt(3)); !u.Equal(decimal.NewFromInt(75)) {
+		t.Errorf("received '%v' expected '%v'", u, 75)
+	}
+}
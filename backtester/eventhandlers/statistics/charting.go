@@ -0,0 +1,104 @@
+package statistics
+
+import (
+	"sort"
+	"time"
+
+	"github.com/shopspring/decimal"
+	gctorder "github.com/thrasher-corp/gocryptotrader/exchanges/order"
+)
+
+// GetChartData returns charting ready series of each currency's equity,
+// drawdown, close prices and trades from a completed run, along with the
+// portfolio's USD equity curve when USD tracking is enabled. It complements
+// Serialise, consolidating the per offset data into parallel arrays
+func (s *Statistic) GetChartData() (*ChartData, error) {
+	if s.ExchangeAssetPairStatistics == nil {
+		return nil, errExchangeAssetPairStatsUnset
+	}
+	resp := &ChartData{}
+	for _, exchangeMap := range s.ExchangeAssetPairStatistics {
+		for _, assetMap := range exchangeMap {
+			for _, stats := range assetMap {
+				resp.Currencies = append(resp.Currencies, stats.chartSeries())
+			}
+		}
+	}
+	sort.Slice(resp.Currencies, func(i, j int) bool {
+		a, b := resp.Currencies[i], resp.Currencies[j]
+		if a.Exchange != b.Exchange {
+			return a.Exchange < b.Exchange
+		}
+		if a.Asset != b.Asset {
+			return a.Asset.String() < b.Asset.String()
+		}
+		return a.Pair.String() < b.Pair.String()
+	})
+	if s.FundingStatistics != nil &&
+		s.FundingStatistics.TotalUSDStatistics != nil &&
+		len(s.FundingStatistics.TotalUSDStatistics.HoldingValues) > 0 {
+		holdingValues := s.FundingStatistics.TotalUSDStatistics.HoldingValues
+		portfolio := &ChartSeries{
+			Timestamps: make([]time.Time, len(holdingValues)),
+			Equity:     make([]decimal.Decimal, len(holdingValues)),
+		}
+		for i := range holdingValues {
+			portfolio.Timestamps[i] = holdingValues[i].Time
+			portfolio.Equity[i] = holdingValues[i].Value
+		}
+		portfolio.Drawdown = drawdownSeries(portfolio.Equity)
+		resp.Portfolio = portfolio
+	}
+	return resp, nil
+}
+
+// chartSeries returns the currency's equity curve, drawdown,
+// close prices and markers for every filled order
+func (c *CurrencyPairStatistic) chartSeries() ChartSeries {
+	series := ChartSeries{
+		Exchange:   c.Exchange,
+		Asset:      c.Asset,
+		Pair:       c.Currency,
+		Timestamps: make([]time.Time, len(c.Events)),
+		Prices:     make([]decimal.Decimal, len(c.Events)),
+		Equity:     make([]decimal.Decimal, len(c.Events)),
+	}
+	for i := range c.Events {
+		series.Timestamps[i] = c.Events[i].Time
+		series.Prices[i] = c.Events[i].ClosePrice
+		series.Equity[i] = c.Events[i].Holdings.TotalValue
+		f := c.Events[i].FillEvent
+		if f == nil || !f.GetAmount().IsPositive() {
+			continue
+		}
+		switch f.GetDirection() {
+		case gctorder.Buy, gctorder.Bid, gctorder.Sell, gctorder.Ask,
+			gctorder.Long, gctorder.Short, gctorder.ClosePosition:
+			series.Markers = append(series.Markers, TradeMarker{
+				Time:      f.GetTime(),
+				Direction: f.GetDirection(),
+				Price:     f.GetPurchasePrice(),
+				Amount:    f.GetAmount(),
+			})
+		}
+	}
+	series.Drawdown = drawdownSeries(series.Equity)
+	return series
+}
+
+// drawdownSeries returns the percentage each equity value is below the highest
+// equity value before it. Values before any positive peak have no drawdown
+func drawdownSeries(equity []decimal.Decimal) []decimal.Decimal {
+	resp := make([]decimal.Decimal, len(equity))
+	peak := decimal.Zero
+	for i := range equity {
+		if equity[i].GreaterThan(peak) {
+			peak = equity[i]
+		}
+		if !peak.IsPositive() {
+			continue
+		}
+		resp[i] = peak.Sub(equity[i]).Div(peak).Mul(decimal.NewFromInt(100))
+	}
+	return resp
+}
@@ -0,0 +1,108 @@
+package statistics
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventhandlers/portfolio/holdings"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/event"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/fill"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	gctorder "github.com/thrasher-corp/gocryptotrader/exchanges/order"
+)
+
+func TestGetChartData(t *testing.T) {
+	t.Parallel()
+	s := &Statistic{}
+	_, err := s.GetChartData()
+	if !errors.Is(err, errExchangeAssetPairStatsUnset) {
+		t.Errorf("received '%v' expected '%v'", err, errExchangeAssetPairStatsUnset)
+	}
+
+	tt := time.Now()
+	btc := currency.NewPair(currency.BTC, currency.USDT)
+	newEvents := func(equity ...int64) []DataAtOffset {
+		events := make([]DataAtOffset, len(equity))
+		for i := range equity {
+			events[i] = DataAtOffset{
+				Time:       tt.Add(time.Hour * time.Duration(i)),
+				ClosePrice: decimal.NewFromInt(equity[i] * 10),
+				Holdings:   holdings.Holding{TotalValue: decimal.NewFromInt(equity[i])},
+			}
+		}
+		return events
+	}
+	btcEvents := newEvents(100, 120, 90, 130)
+	btcEvents[1].FillEvent = &fill.Fill{
+		Base:          &event.Base{Time: btcEvents[1].Time},
+		Direction:     gctorder.Buy,
+		Amount:        decimal.NewFromInt(1),
+		PurchasePrice: decimal.NewFromInt(1201),
+	}
+	btcEvents[2].FillEvent = &fill.Fill{
+		Base:      &event.Base{Time: btcEvents[2].Time},
+		Direction: gctorder.CouldNotSell,
+	}
+	s.ExchangeAssetPairStatistics = map[string]map[asset.Item]map[currency.Pair]*CurrencyPairStatistic{
+		testExchange: {
+			asset.Spot: {
+				currency.NewPair(currency.ETH, currency.USDT): {Exchange: testExchange, Asset: asset.Spot, Currency: currency.NewPair(currency.ETH, currency.USDT), Events: newEvents(50)},
+				btc: {Exchange: testExchange, Asset: asset.Spot, Currency: btc, Events: btcEvents},
+			},
+		},
+	}
+	s.FundingStatistics = &FundingStatistics{
+		TotalUSDStatistics: &TotalFundingStatistics{
+			HoldingValues: []ValueAtTime{
+				{Time: tt, Value: decimal.NewFromInt(200)},
+				{Time: tt.Add(time.Hour), Value: decimal.NewFromInt(150)},
+			},
+		},
+	}
+	resp, err := s.GetChartData()
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	if len(resp.Currencies) != 2 {
+		t.Fatalf("received '%v' expected '%v'", len(resp.Currencies), 2)
+	}
+	series := resp.Currencies[0]
+	if !series.Pair.Equal(btc) {
+		t.Fatalf("received '%v' expected '%v'", series.Pair, btc)
+	}
+	if len(series.Timestamps) != 4 || len(series.Prices) != 4 || len(series.Equity) != 4 || len(series.Drawdown) != 4 {
+		t.Fatalf("received '%v' '%v' '%v' '%v' expected parallel arrays of '%v'", len(series.Timestamps), len(series.Prices), len(series.Equity), len(series.Drawdown), 4)
+	}
+	if !series.Prices[1].Equal(decimal.NewFromInt(1200)) {
+		t.Errorf("received '%v' expected '%v'", series.Prices[1], 1200)
+	}
+	if !series.Drawdown[2].Equal(decimal.NewFromInt(25)) {
+		t.Errorf("received '%v' expected '%v'", series.Drawdown[2], 25)
+	}
+	if !series.Drawdown[3].IsZero() {
+		t.Errorf("received '%v' expected '%v'", series.Drawdown[3], 0)
+	}
+	if len(series.Markers) != 1 {
+		t.Fatalf("received '%v' expected '%v'", len(series.Markers), 1)
+	}
+	if series.Markers[0].Direction != gctorder.Buy || !series.Markers[0].Price.Equal(decimal.NewFromInt(1201)) {
+		t.Errorf("received '%v' '%v' expected '%v' '%v'", series.Markers[0].Direction, series.Markers[0].Price, gctorder.Buy, 1201)
+	}
+	if resp.Portfolio == nil {
+		t.Fatal("expected portfolio series")
+	}
+	if !resp.Portfolio.Drawdown[1].Equal(decimal.NewFromInt(25)) {
+		t.Errorf("received '%v' expected '%v'", resp.Portfolio.Drawdown[1], 25)
+	}
+}
+
+func TestDrawdownSeries(t *testing.T) {
+	t.Parallel()
+	resp := drawdownSeries([]decimal.Decimal{decimal.Zero, decimal.NewFromInt(10), decimal.NewFromInt(5)})
+	if !resp[0].IsZero() || !resp[1].IsZero() || !resp[2].Equal(decimal.NewFromInt(50)) {
+		t.Errorf("received '%v' expected '%v'", resp, "[0 0 50]")
+	}
+}
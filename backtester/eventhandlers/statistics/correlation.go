@@ -0,0 +1,111 @@
+package statistics
+
+import (
+	"math"
+	"sort"
+
+	"github.com/shopspring/decimal"
+)
+
+// calculateCorrelationMatrix correlates the per event holdings returns of every
+// traded currency with one another. Returns are aligned by data offset and only
+// offsets both currencies have data for are compared. Highly correlated
+// currencies offer less diversification than the number of positions suggests
+func (s *Statistic) calculateCorrelationMatrix() {
+	s.CorrelationMatrix = nil
+	var traded []*CurrencyPairStatistic
+	for _, assetMap := range s.ExchangeAssetPairStatistics {
+		for _, pairMap := range assetMap {
+			for _, stats := range pairMap {
+				if stats.TotalOrders > 0 && len(stats.Events) > 1 {
+					traded = append(traded, stats)
+				}
+			}
+		}
+	}
+	if len(traded) < 2 {
+		return
+	}
+	sort.Slice(traded, func(i, j int) bool {
+		if traded[i].Exchange != traded[j].Exchange {
+			return traded[i].Exchange < traded[j].Exchange
+		}
+		if traded[i].Asset != traded[j].Asset {
+			return traded[i].Asset < traded[j].Asset
+		}
+		return traded[i].Currency.String() < traded[j].Currency.String()
+	})
+	returns := make([]map[int64]decimal.Decimal, len(traded))
+	matrix := &CorrelationMatrix{
+		Currencies:   make([]CorrelatedCurrency, len(traded)),
+		Coefficients: make([][]decimal.Decimal, len(traded)),
+	}
+	for i := range traded {
+		returns[i] = make(map[int64]decimal.Decimal, len(traded[i].Events)-1)
+		for j := 1; j < len(traded[i].Events); j++ {
+			returns[i][traded[i].Events[j].Offset] = traded[i].Events[j].Holdings.ChangeInTotalValuePercent
+		}
+		matrix.Currencies[i] = CorrelatedCurrency{
+			Exchange: traded[i].Exchange,
+			Asset:    traded[i].Asset,
+			Pair:     traded[i].Currency,
+		}
+		matrix.Coefficients[i] = make([]decimal.Decimal, len(traded))
+	}
+	for i := range traded {
+		matrix.Coefficients[i][i] = decimal.NewFromInt(1)
+		for j := i + 1; j < len(traded); j++ {
+			var a, b []decimal.Decimal
+			for offset, r := range returns[i] {
+				if other, ok := returns[j][offset]; ok {
+					a = append(a, r)
+					b = append(b, other)
+				}
+			}
+			coefficient := calculateCorrelation(a, b)
+			matrix.Coefficients[i][j] = coefficient
+			matrix.Coefficients[j][i] = coefficient
+		}
+	}
+	s.CorrelationMatrix = matrix
+}
+
+// calculateCorrelation returns the Pearson correlation coefficient of two
+// equal length return series. Series which never moved do not correlate
+// and return zero
+func calculateCorrelation(a, b []decimal.Decimal) decimal.Decimal {
+	if len(a) != len(b) || len(a) < 2 {
+		return decimal.Zero
+	}
+	n := decimal.NewFromInt(int64(len(a)))
+	var aSum, bSum decimal.Decimal
+	for i := range a {
+		aSum = aSum.Add(a[i])
+		bSum = bSum.Add(b[i])
+	}
+	aMean := aSum.Div(n)
+	bMean := bSum.Div(n)
+	var covariance, aVariance, bVariance decimal.Decimal
+	for i := range a {
+		aDiff := a[i].Sub(aMean)
+		bDiff := b[i].Sub(bMean)
+		covariance = covariance.Add(aDiff.Mul(bDiff))
+		aVariance = aVariance.Add(aDiff.Mul(aDiff))
+		bVariance = bVariance.Add(bDiff.Mul(bDiff))
+	}
+	if aVariance.IsZero() || bVariance.IsZero() {
+		return decimal.Zero
+	}
+	// the coefficient's magnitude is derived from its exact square to
+	// keep perfectly correlated series from landing a hair out of range
+	coefficient := decimal.NewFromInt(1)
+	rSquared := covariance.Mul(covariance).Div(aVariance.Mul(bVariance))
+	if rSquared.LessThan(coefficient) {
+		f, _ := rSquared.Float64()
+		coefficient = decimal.NewFromFloat(math.Sqrt(f))
+	}
+	if covariance.IsNegative() {
+		return coefficient.Neg()
+	}
+	return coefficient
+}
@@ -0,0 +1,84 @@
+package statistics
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventhandlers/portfolio/holdings"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+)
+
+func TestCalculateCorrelation(t *testing.T) {
+	t.Parallel()
+	a := []decimal.Decimal{decimal.NewFromInt(1), decimal.NewFromInt(-2), decimal.NewFromInt(3)}
+	if c := calculateCorrelation(a, a[:2]); !c.IsZero() {
+		t.Errorf("received '%v' expected '%v'", c, 0)
+	}
+	if c := calculateCorrelation(a, a); !c.Equal(decimal.NewFromInt(1)) {
+		t.Errorf("received '%v' expected '%v'", c, 1)
+	}
+	b := []decimal.Decimal{decimal.NewFromInt(-2), decimal.NewFromInt(4), decimal.NewFromInt(-6)}
+	if c := calculateCorrelation(a, b); !c.Equal(decimal.NewFromInt(-1)) {
+		t.Errorf("received '%v' expected '%v'", c, -1)
+	}
+	flat := []decimal.Decimal{decimal.NewFromInt(1), decimal.NewFromInt(1), decimal.NewFromInt(1)}
+	if c := calculateCorrelation(a, flat); !c.IsZero() {
+		t.Errorf("received '%v' expected '%v'", c, 0)
+	}
+}
+
+func TestCalculateCorrelationMatrix(t *testing.T) {
+	t.Parallel()
+	s := Statistic{}
+	s.calculateCorrelationMatrix()
+	if s.CorrelationMatrix != nil {
+		t.Errorf("received '%v' expected '%v'", s.CorrelationMatrix, nil)
+	}
+
+	newStats := func(p currency.Pair, orders int64, returns ...int64) *CurrencyPairStatistic {
+		t.Helper()
+		stats := &CurrencyPairStatistic{
+			Exchange:    testExchange,
+			Asset:       asset.Spot,
+			Currency:    p,
+			TotalOrders: orders,
+		}
+		for i := range returns {
+			stats.Events = append(stats.Events, DataAtOffset{
+				Offset:   int64(i + 1),
+				Holdings: holdings.Holding{ChangeInTotalValuePercent: decimal.NewFromInt(returns[i])},
+			})
+		}
+		return stats
+	}
+	btc := currency.NewPair(currency.BTC, currency.USDT)
+	eth := currency.NewPair(currency.ETH, currency.USDT)
+	ltc := currency.NewPair(currency.LTC, currency.USDT)
+	s.ExchangeAssetPairStatistics = map[string]map[asset.Item]map[currency.Pair]*CurrencyPairStatistic{
+		testExchange: {asset.Spot: {
+			eth: newStats(eth, 1, 0, 2, 4, -2, 6),
+			btc: newStats(btc, 1, 0, 1, 2, -1, 3),
+			// untraded currencies are not part of the matrix
+			ltc: newStats(ltc, 0, 0, 1, -1, 1, -1),
+		}},
+	}
+	s.calculateCorrelationMatrix()
+	m := s.CorrelationMatrix
+	if m == nil {
+		t.Fatal("expected correlation matrix")
+	}
+	if len(m.Currencies) != 2 || len(m.Coefficients) != 2 {
+		t.Fatalf("received '%v' expected '%v'", len(m.Currencies), 2)
+	}
+	if !m.Currencies[0].Pair.Equal(btc) || !m.Currencies[1].Pair.Equal(eth) {
+		t.Errorf("received '%v' '%v' expected currencies sorted", m.Currencies[0].Pair, m.Currencies[1].Pair)
+	}
+	one := decimal.NewFromInt(1)
+	if !m.Coefficients[0][0].Equal(one) || !m.Coefficients[1][1].Equal(one) {
+		t.Errorf("received '%v' expected a diagonal of '%v'", m.Coefficients, one)
+	}
+	if !m.Coefficients[0][1].Equal(one) || !m.Coefficients[1][0].Equal(one) {
+		t.Errorf("received '%v' expected '%v'", m.Coefficients[0][1], one)
+	}
+}
@@ -0,0 +1,103 @@
+package statistics
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/thrasher-corp/gocryptotrader/backtester/common"
+)
+
+const (
+	// CostBasisFIFO matches disposals against the earliest acquisitions first
+	CostBasisFIFO = "fifo"
+	// CostBasisLIFO matches disposals against the latest acquisitions first
+	CostBasisLIFO = "lifo"
+	// CostBasisAverage values all held acquisitions at their average cost
+	CostBasisAverage = "average"
+)
+
+// taxLot is an open acquisition which has not yet been fully disposed of
+type taxLot struct {
+	time     time.Time
+	amount   decimal.Decimal
+	unitCost decimal.Decimal
+}
+
+// CalculateTaxLots matches each disposal against prior acquisitions using the
+// cost basis method to produce a lot-by-lot realised gain report. Acquisition
+// fees are added to the cost basis and disposal fees reduce the proceeds.
+// Any amount disposed of beyond what was acquired is not matched
+func (c *CurrencyPairStatistic) CalculateTaxLots(method string) ([]TaxLotDisposal, error) {
+	method = strings.ToLower(method)
+	switch method {
+	case CostBasisFIFO, CostBasisLIFO, CostBasisAverage:
+	default:
+		return nil, fmt.Errorf("%w '%v'", errInvalidCostBasisMethod, method)
+	}
+	var lots []taxLot
+	var disposals []TaxLotDisposal
+	for i := range c.Events {
+		f := c.Events[i].FillEvent
+		if f == nil || !common.CanTransact(f.GetDirection()) || !f.GetAmount().IsPositive() {
+			continue
+		}
+		amount := f.GetAmount()
+		value := f.GetPurchasePrice().Mul(amount)
+		switch {
+		case f.GetDirection().IsLong():
+			lots = append(lots, taxLot{
+				time:     f.GetTime(),
+				amount:   amount,
+				unitCost: value.Add(f.GetExchangeFee()).Div(amount),
+			})
+			if method == CostBasisAverage {
+				averageTaxLots(lots)
+			}
+		case f.GetDirection().IsShort():
+			unitProceeds := value.Sub(f.GetExchangeFee()).Div(amount)
+			remaining := amount
+			for remaining.IsPositive() && len(lots) > 0 {
+				idx := 0
+				if method == CostBasisLIFO {
+					idx = len(lots) - 1
+				}
+				matched := decimal.Min(remaining, lots[idx].amount)
+				costBasis := matched.Mul(lots[idx].unitCost)
+				proceeds := matched.Mul(unitProceeds)
+				disposals = append(disposals, TaxLotDisposal{
+					DisposalTime:    f.GetTime(),
+					AcquisitionTime: lots[idx].time,
+					Amount:          matched,
+					CostBasis:       costBasis,
+					Proceeds:        proceeds,
+					Gain:            proceeds.Sub(costBasis),
+				})
+				remaining = remaining.Sub(matched)
+				lots[idx].amount = lots[idx].amount.Sub(matched)
+				if lots[idx].amount.IsZero() {
+					lots = append(lots[:idx], lots[idx+1:]...)
+				}
+			}
+		}
+	}
+	return disposals, nil
+}
+
+// averageTaxLots sets every open lot to the average unit cost of all held
+// lots, retaining each lot's acquisition date
+func averageTaxLots(lots []taxLot) {
+	var totalAmount, totalCost decimal.Decimal
+	for i := range lots {
+		totalAmount = totalAmount.Add(lots[i].amount)
+		totalCost = totalCost.Add(lots[i].amount.Mul(lots[i].unitCost))
+	}
+	if totalAmount.IsZero() {
+		return
+	}
+	averageCost := totalCost.Div(totalAmount)
+	for i := range lots {
+		lots[i].unitCost = averageCost
+	}
+}
@@ -0,0 +1,88 @@
+package statistics
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/event"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/fill"
+	gctorder "github.com/thrasher-corp/gocryptotrader/exchanges/order"
+)
+
+func TestCalculateTaxLots(t *testing.T) {
+	t.Parallel()
+	c := CurrencyPairStatistic{}
+	_, err := c.CalculateTaxLots("hifo")
+	if !errors.Is(err, errInvalidCostBasisMethod) {
+		t.Errorf("received '%v' expected '%v'", err, errInvalidCostBasisMethod)
+	}
+
+	tt := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	fills := []struct {
+		side  gctorder.Side
+		price int64
+	}{
+		{gctorder.Buy, 100},
+		{gctorder.DoNothing, 1337},
+		{gctorder.Buy, 200},
+		{gctorder.Sell, 300},
+	}
+	for i := range fills {
+		c.Events = append(c.Events, DataAtOffset{
+			FillEvent: &fill.Fill{
+				Base:          &event.Base{Time: tt.AddDate(0, 0, i)},
+				Direction:     fills[i].side,
+				Amount:        decimal.NewFromInt(1),
+				PurchasePrice: decimal.NewFromInt(fills[i].price),
+				ExchangeFee:   decimal.NewFromInt(1),
+			},
+		})
+	}
+
+	disposals, err := c.CalculateTaxLots(CostBasisFIFO)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	if len(disposals) != 1 {
+		t.Fatalf("received '%v' expected '%v'", len(disposals), 1)
+	}
+	if !disposals[0].AcquisitionTime.Equal(tt) {
+		t.Errorf("received '%v' expected '%v'", disposals[0].AcquisitionTime, tt)
+	}
+	if !disposals[0].Gain.Equal(decimal.NewFromInt(198)) {
+		t.Errorf("received '%v' expected '%v'", disposals[0].Gain, 198)
+	}
+
+	disposals, err = c.CalculateTaxLots("LIFO")
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	if !disposals[0].AcquisitionTime.Equal(tt.AddDate(0, 0, 2)) {
+		t.Errorf("received '%v' expected '%v'", disposals[0].AcquisitionTime, tt.AddDate(0, 0, 2))
+	}
+	if !disposals[0].Gain.Equal(decimal.NewFromInt(98)) {
+		t.Errorf("received '%v' expected '%v'", disposals[0].Gain, 98)
+	}
+
+	disposals, err = c.CalculateTaxLots(CostBasisAverage)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	if !disposals[0].CostBasis.Equal(decimal.NewFromInt(151)) {
+		t.Errorf("received '%v' expected '%v'", disposals[0].CostBasis, 151)
+	}
+
+	c.Events[3].FillEvent.(*fill.Fill).Amount = decimal.NewFromInt(3)
+	disposals, err = c.CalculateTaxLots(CostBasisFIFO)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	if len(disposals) != 2 {
+		t.Fatalf("received '%v' expected '%v'", len(disposals), 2)
+	}
+	if !disposals[1].Amount.Equal(decimal.NewFromInt(1)) {
+		t.Errorf("received '%v' expected '%v'", disposals[1].Amount, 1)
+	}
+}
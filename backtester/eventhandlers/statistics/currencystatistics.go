@@ -2,6 +2,8 @@ package statistics
 
 import (
 	"fmt"
+	"sort"
+	"time"
 
 	"github.com/shopspring/decimal"
 	"github.com/thrasher-corp/gocryptotrader/backtester/common"
@@ -11,8 +13,9 @@ import (
 	gctorder "github.com/thrasher-corp/gocryptotrader/exchanges/order"
 )
 
-// CalculateResults calculates all statistics for the exchange, asset, currency pair
-func (c *CurrencyPairStatistic) CalculateResults(riskFreeRate decimal.Decimal) error {
+// CalculateResults calculates all statistics for the exchange, asset, currency pair.
+// Ratios and growth rates are annualised using the trading calendar
+func (c *CurrencyPairStatistic) CalculateResults(riskFreeRate decimal.Decimal, tradingCalendar string) error {
 	var errs gctcommon.Errors
 	var err error
 	first := c.Events[0]
@@ -34,6 +37,23 @@ func (c *CurrencyPairStatistic) CalculateResults(riskFreeRate decimal.Decimal) e
 		}
 	}
 	for i := range c.Events {
+		if c.Events[i].FillEvent != nil &&
+			c.Events[i].FillEvent.IsStopOut() &&
+			common.CanTransact(c.Events[i].FillEvent.GetDirection()) {
+			c.StopOutOrders++
+		}
+		if c.Events[i].FillEvent != nil && c.Events[i].FillEvent.IsCapitalCapBound() {
+			c.CapitalCapRejections++
+		}
+		if c.Events[i].FillEvent != nil && c.Events[i].FillEvent.IsCircuitBreakerTriggered() {
+			c.CircuitBreakerTriggers++
+		}
+		if c.Events[i].FillEvent != nil && c.Events[i].FillEvent.IsCircuitBreakerBound() {
+			c.CircuitBreakerRejections++
+		}
+		if c.Events[i].FillEvent != nil && c.Events[i].FillEvent.IsLiquidated() {
+			c.LiquidationCost = c.LiquidationCost.Add(c.Events[i].FillEvent.GetLiquidationCost())
+		}
 		price := c.Events[i].ClosePrice
 		if price.LessThan(c.LowestClosePrice.Value) || !c.LowestClosePrice.Set {
 			c.LowestClosePrice.Value = price
@@ -55,10 +75,22 @@ func (c *CurrencyPairStatistic) CalculateResults(riskFreeRate decimal.Decimal) e
 		c.StrategyMovement = last.Holdings.TotalValue.Sub(first.Holdings.TotalValue).Div(first.Holdings.TotalValue).Mul(oneHundred)
 	}
 	c.analysePNLGrowth()
+	c.calculateLatencyStatistics()
+	c.calculateEffectiveSpreadStatistics()
+	c.calculateFillRatioStatistics()
+	c.calculateEffectiveFeeRate()
+	c.calculateTimeInMarket()
+	c.calculateTagStatistics()
+	c.calculateAccountStatistics()
+	c.calculateGrossNetReturns()
 	err = c.calculateHighestCommittedFunds()
 	if err != nil {
 		return err
 	}
+	err = c.calculateKellyCriterion()
+	if err != nil {
+		return err
+	}
 	returnsPerCandle := make([]decimal.Decimal, len(c.Events))
 	benchmarkRates := make([]decimal.Decimal, len(c.Events))
 
@@ -72,8 +104,8 @@ func (c *CurrencyPairStatistic) CalculateResults(riskFreeRate decimal.Decimal) e
 		if c.Events[i].SignalEvent != nil && c.Events[i].SignalEvent.GetDirection() == gctorder.MissingData {
 			c.ShowMissingDataWarning = true
 		}
-		if c.Events[i].ClosePrice.IsZero() || c.Events[i-1].ClosePrice.IsZero() {
-			// closing price for the current candle or previous candle is zero, use the previous
+		if !c.Events[i].ClosePrice.IsPositive() || !c.Events[i-1].ClosePrice.IsPositive() {
+			// closing price for the current candle or previous candle is invalid, use the previous
 			// benchmark rate to allow some consistency
 			c.ShowMissingDataWarning = true
 			benchmarkRates[i] = benchmarkRates[i-1]
@@ -94,19 +126,27 @@ func (c *CurrencyPairStatistic) CalculateResults(riskFreeRate decimal.Decimal) e
 	}
 
 	interval := first.DataEvent.GetInterval()
-	intervalsPerYear := interval.IntervalsPerYear()
-	riskFreeRatePerCandle := riskFreeRate.Div(decimal.NewFromFloat(intervalsPerYear))
+	perYear := intervalsPerYear(interval, tradingCalendar)
+	riskFreeRatePerCandle := riskFreeRatePerCandle(riskFreeRate, interval, tradingCalendar)
 	c.ArithmeticRatios, c.GeometricRatios, err = CalculateRatios(benchmarkRates, returnsPerCandle, riskFreeRatePerCandle, &c.MaxDrawdown, sep)
 	if err != nil {
 		return err
 	}
+	c.TailRisk, err = calculateTailRisk(returnsPerCandle, c.valueAtRiskConfidenceLevels)
+	if err != nil {
+		errs = append(errs, err)
+	}
+	c.Autocorrelation, err = calculateAutocorrelation(returnsPerCandle, c.autocorrelationLags)
+	if err != nil {
+		errs = append(errs, err)
+	}
 
 	if !last.Holdings.QuoteInitialFunds.IsZero() {
 		var cagr decimal.Decimal
 		cagr, err = gctmath.DecimalCompoundAnnualGrowthRate(
 			last.Holdings.QuoteInitialFunds,
 			last.Holdings.TotalValue,
-			decimal.NewFromFloat(intervalsPerYear),
+			decimal.NewFromFloat(perYear),
 			decimal.NewFromInt(int64(len(c.Events))),
 		)
 		if err != nil {
@@ -117,6 +157,14 @@ func (c *CurrencyPairStatistic) CalculateResults(riskFreeRate decimal.Decimal) e
 	c.IsStrategyProfitable = last.Holdings.TotalValue.GreaterThan(first.Holdings.TotalValue)
 	c.DoesPerformanceBeatTheMarket = c.StrategyMovement.GreaterThan(c.MarketMovement)
 	c.TotalFees = last.Holdings.TotalFees.Round(8)
+	c.AccumulatedRebates = decimal.Zero
+	c.SubmissionRetries = 0
+	for i := range c.Events {
+		if c.Events[i].FillEvent != nil {
+			c.AccumulatedRebates = c.AccumulatedRebates.Add(c.Events[i].FillEvent.GetAccumulatedRebate())
+			c.SubmissionRetries += c.Events[i].FillEvent.GetSubmissionRetries()
+		}
+	}
 	c.TotalValueLostToVolumeSizing = last.Holdings.TotalValueLostToVolumeSizing.Round(2)
 	c.TotalValueLost = last.Holdings.TotalValueLost.Round(2)
 	c.TotalValueLostToSlippage = last.Holdings.TotalValueLostToSlippage.Round(2)
@@ -124,6 +172,8 @@ func (c *CurrencyPairStatistic) CalculateResults(riskFreeRate decimal.Decimal) e
 	if last.PNL != nil {
 		c.UnrealisedPNL = last.PNL.GetUnrealisedPNL().PNL
 		c.RealisedPNL = last.PNL.GetRealisedPNL().PNL
+		c.BorrowCost = last.PNL.GetBorrowCost()
+		c.FundingCost = last.PNL.GetFundingCost()
 	}
 	if len(errs) > 0 {
 		return errs
@@ -156,6 +206,153 @@ func (c *CurrencyPairStatistic) calculateHighestCommittedFunds() error {
 	return nil
 }
 
+// calculateLatencyStatistics summarises the latency experienced by filled
+// orders. Statistics are only set when at least one fill was delayed
+func (c *CurrencyPairStatistic) calculateLatencyStatistics() {
+	var latencies []time.Duration
+	var hasLatency bool
+	for i := range c.Events {
+		if c.Events[i].FillEvent == nil || !common.CanTransact(c.Events[i].FillEvent.GetDirection()) {
+			continue
+		}
+		latency := c.Events[i].FillEvent.GetLatency()
+		if latency > 0 {
+			hasLatency = true
+		}
+		latencies = append(latencies, latency)
+	}
+	if !hasLatency {
+		return
+	}
+	sort.Slice(latencies, func(i, j int) bool {
+		return latencies[i] < latencies[j]
+	})
+	var total time.Duration
+	for i := range latencies {
+		total += latencies[i]
+	}
+	c.Latency = &LatencyStatistics{
+		Orders:       int64(len(latencies)),
+		Minimum:      latencies[0],
+		Maximum:      latencies[len(latencies)-1],
+		Mean:         total / time.Duration(len(latencies)),
+		Median:       latencies[len(latencies)/2],
+		Percentile95: latencies[(len(latencies)*95-1)/100],
+	}
+	if len(latencies)%2 == 0 {
+		c.Latency.Median = (latencies[len(latencies)/2-1] + latencies[len(latencies)/2]) / 2
+	}
+}
+
+// calculateEffectiveSpreadStatistics summarises the effective spread paid
+// against the orderbook midpoint by filled orders. Statistics are only set
+// when at least one fill recorded an effective spread
+func (c *CurrencyPairStatistic) calculateEffectiveSpreadStatistics() {
+	var total decimal.Decimal
+	var worst ValueAtTime
+	var fills int64
+	for i := range c.Events {
+		if c.Events[i].FillEvent == nil || !common.CanTransact(c.Events[i].FillEvent.GetDirection()) {
+			continue
+		}
+		spread, ok := c.Events[i].FillEvent.GetEffectiveSpread()
+		if !ok {
+			continue
+		}
+		fills++
+		total = total.Add(spread)
+		if !worst.Set || spread.GreaterThan(worst.Value) {
+			worst = ValueAtTime{
+				Time:  c.Events[i].FillEvent.GetTime(),
+				Value: spread,
+				Set:   true,
+			}
+		}
+	}
+	if fills == 0 {
+		return
+	}
+	c.EffectiveSpread = &EffectiveSpreadStatistics{
+		Fills:   fills,
+		Average: total.Div(decimal.NewFromInt(fills)),
+		Worst:   worst,
+	}
+}
+
+// calculateEffectiveFeeRate calculates the fee rate blended across every
+// filled order, being the total fees paid as a proportion of the total
+// notional traded, reflecting maker, taker and volume tiered fees
+func (c *CurrencyPairStatistic) calculateEffectiveFeeRate() {
+	var fees, notional decimal.Decimal
+	for i := range c.Events {
+		if c.Events[i].FillEvent == nil || !common.CanTransact(c.Events[i].FillEvent.GetDirection()) {
+			continue
+		}
+		fees = fees.Add(c.Events[i].FillEvent.GetExchangeFee())
+		notional = notional.Add(c.Events[i].FillEvent.GetAmount().Mul(c.Events[i].FillEvent.GetPurchasePrice()))
+	}
+	c.EffectiveFeeRate = decimal.Zero
+	if notional.IsPositive() {
+		c.EffectiveFeeRate = fees.Div(notional)
+	}
+}
+
+// calculateFillRatioStatistics summarises how much of each filled order's
+// intended amount was filled after being shrunk to fit volume, portfolio or
+// exchange limits. Ratios are bucketed by quartile to show their distribution
+func (c *CurrencyPairStatistic) calculateFillRatioStatistics() {
+	var ratios []decimal.Decimal
+	for i := range c.Events {
+		if c.Events[i].FillEvent == nil || !common.CanTransact(c.Events[i].FillEvent.GetDirection()) {
+			continue
+		}
+		ratio, ok := c.Events[i].FillEvent.GetFillRatio()
+		if !ok {
+			continue
+		}
+		ratios = append(ratios, ratio)
+	}
+	if len(ratios) == 0 {
+		return
+	}
+	sort.Slice(ratios, func(i, j int) bool {
+		return ratios[i].LessThan(ratios[j])
+	})
+	quarter := decimal.NewFromFloat(0.25)
+	one := decimal.NewFromInt(1)
+	stats := &FillRatioStatistics{
+		Orders:  int64(len(ratios)),
+		Minimum: ratios[0],
+		Median:  ratios[len(ratios)/2],
+		Distribution: []FillRatioBucket{
+			{LowerBound: decimal.Zero, UpperBound: quarter},
+			{LowerBound: quarter, UpperBound: quarter.Mul(decimal.NewFromInt(2))},
+			{LowerBound: quarter.Mul(decimal.NewFromInt(2)), UpperBound: quarter.Mul(decimal.NewFromInt(3))},
+			{LowerBound: quarter.Mul(decimal.NewFromInt(3)), UpperBound: one},
+		},
+	}
+	if len(ratios)%2 == 0 {
+		stats.Median = ratios[len(ratios)/2-1].Add(ratios[len(ratios)/2]).Div(decimal.NewFromInt(2))
+	}
+	var total decimal.Decimal
+	for i := range ratios {
+		total = total.Add(ratios[i])
+		if ratios[i].GreaterThanOrEqual(one) {
+			stats.FullFills++
+			continue
+		}
+		stats.PartialFills++
+		for j := range stats.Distribution {
+			if ratios[i].LessThan(stats.Distribution[j].UpperBound) {
+				stats.Distribution[j].Orders++
+				break
+			}
+		}
+	}
+	stats.Average = total.Div(decimal.NewFromInt(stats.Orders))
+	c.FillRatios = stats
+}
+
 func (c *CurrencyPairStatistic) analysePNLGrowth() {
 	if !c.Asset.IsFutures() {
 		return
@@ -10,6 +10,7 @@ import (
 	"github.com/thrasher-corp/gocryptotrader/backtester/eventhandlers/portfolio/compliance"
 	"github.com/thrasher-corp/gocryptotrader/backtester/eventhandlers/portfolio/holdings"
 	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/event"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/fill"
 	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/kline"
 	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/signal"
 	"github.com/thrasher-corp/gocryptotrader/currency"
@@ -122,7 +123,7 @@ func TestCalculateResults(t *testing.T) {
 	}
 
 	cs.Events = append(cs.Events, ev, ev2)
-	err := cs.CalculateResults(decimal.NewFromFloat(0.03))
+	err := cs.CalculateResults(decimal.NewFromFloat(0.03), TradingCalendarContinuous)
 	if err != nil {
 		t.Error(err)
 	}
@@ -142,7 +143,7 @@ func TestCalculateResults(t *testing.T) {
 	cs.Events[0].DataEvent = &kline.Kline{
 		Base: even2,
 	}
-	err = cs.CalculateResults(decimal.NewFromFloat(0.03))
+	err = cs.CalculateResults(decimal.NewFromFloat(0.03), TradingCalendarContinuous)
 	if err != nil {
 		t.Error(err)
 	}
@@ -150,7 +151,7 @@ func TestCalculateResults(t *testing.T) {
 	cs.Events[1].DataEvent = &kline.Kline{
 		Base: even2,
 	}
-	err = cs.CalculateResults(decimal.NewFromFloat(0.03))
+	err = cs.CalculateResults(decimal.NewFromFloat(0.03), TradingCalendarContinuous)
 	if err != nil {
 		t.Error(err)
 	}
@@ -352,3 +353,151 @@ func TestAnalysePNLGrowth(t *testing.T) {
 		t.Errorf("received %v expected 0.5", c.LowestUnrealisedPNL.Value)
 	}
 }
+
+func TestCalculateLatencyStatistics(t *testing.T) {
+	t.Parallel()
+	c := CurrencyPairStatistic{
+		Events: []DataAtOffset{
+			{},
+			{FillEvent: &fill.Fill{Direction: order.DoNothing, Latency: time.Hour}},
+			{FillEvent: &fill.Fill{Direction: order.Buy}},
+		},
+	}
+	c.calculateLatencyStatistics()
+	if c.Latency != nil {
+		t.Errorf("received '%v' expected '%v'", c.Latency, nil)
+	}
+
+	for _, l := range []time.Duration{time.Second, time.Second * 3, time.Second * 4} {
+		c.Events = append(c.Events, DataAtOffset{FillEvent: &fill.Fill{Direction: order.Sell, Latency: l}})
+	}
+	c.calculateLatencyStatistics()
+	if c.Latency == nil {
+		t.Fatal("expected latency statistics")
+	}
+	if c.Latency.Orders != 4 {
+		t.Errorf("received '%v' expected '%v'", c.Latency.Orders, 4)
+	}
+	if c.Latency.Minimum != 0 {
+		t.Errorf("received '%v' expected '%v'", c.Latency.Minimum, 0)
+	}
+	if c.Latency.Maximum != time.Second*4 {
+		t.Errorf("received '%v' expected '%v'", c.Latency.Maximum, time.Second*4)
+	}
+	if c.Latency.Mean != time.Second*2 {
+		t.Errorf("received '%v' expected '%v'", c.Latency.Mean, time.Second*2)
+	}
+	if c.Latency.Median != time.Second*2 {
+		t.Errorf("received '%v' expected '%v'", c.Latency.Median, time.Second*2)
+	}
+	if c.Latency.Percentile95 != time.Second*4 {
+		t.Errorf("received '%v' expected '%v'", c.Latency.Percentile95, time.Second*4)
+	}
+}
+
+func TestCalculateEffectiveSpreadStatistics(t *testing.T) {
+	t.Parallel()
+	tt := time.Now()
+	c := CurrencyPairStatistic{
+		Events: []DataAtOffset{
+			{},
+			{FillEvent: &fill.Fill{Direction: order.DoNothing, EffectiveSpread: decimal.NewFromInt(10), HasEffectiveSpread: true}},
+			{FillEvent: &fill.Fill{Direction: order.Buy}},
+		},
+	}
+	c.calculateEffectiveSpreadStatistics()
+	if c.EffectiveSpread != nil {
+		t.Errorf("received '%v' expected '%v'", c.EffectiveSpread, nil)
+	}
+
+	for i, s := range []int64{1, 3, -1} {
+		c.Events = append(c.Events, DataAtOffset{FillEvent: &fill.Fill{
+			Base:               &event.Base{Time: tt.Add(time.Hour * time.Duration(i))},
+			Direction:          order.Sell,
+			EffectiveSpread:    decimal.NewFromInt(s),
+			HasEffectiveSpread: true,
+		}})
+	}
+	c.calculateEffectiveSpreadStatistics()
+	if c.EffectiveSpread == nil {
+		t.Fatal("expected effective spread statistics")
+	}
+	if c.EffectiveSpread.Fills != 3 {
+		t.Errorf("received '%v' expected '%v'", c.EffectiveSpread.Fills, 3)
+	}
+	if !c.EffectiveSpread.Average.Equal(decimal.NewFromInt(1)) {
+		t.Errorf("received '%v' expected '%v'", c.EffectiveSpread.Average, 1)
+	}
+	if !c.EffectiveSpread.Worst.Value.Equal(decimal.NewFromInt(3)) || !c.EffectiveSpread.Worst.Time.Equal(tt.Add(time.Hour)) {
+		t.Errorf("received '%v' expected '%v' at '%v'", c.EffectiveSpread.Worst.Value, 3, tt.Add(time.Hour))
+	}
+}
+
+func TestCalculateFillRatioStatistics(t *testing.T) {
+	t.Parallel()
+	c := CurrencyPairStatistic{
+		Events: []DataAtOffset{
+			{},
+			{FillEvent: &fill.Fill{Direction: order.CouldNotBuy, IntendedAmount: decimal.NewFromInt(1)}},
+			{FillEvent: &fill.Fill{Direction: order.Buy, Amount: decimal.NewFromInt(1)}},
+		},
+	}
+	c.calculateFillRatioStatistics()
+	if c.FillRatios != nil {
+		t.Errorf("received '%v' expected '%v'", c.FillRatios, nil)
+	}
+
+	for _, amount := range []int64{10, 10, 6, 1} {
+		c.Events = append(c.Events, DataAtOffset{FillEvent: &fill.Fill{
+			Direction:      order.Buy,
+			Amount:         decimal.NewFromInt(amount),
+			IntendedAmount: decimal.NewFromInt(10),
+		}})
+	}
+	c.calculateFillRatioStatistics()
+	if c.FillRatios == nil {
+		t.Fatal("expected fill ratio statistics")
+	}
+	if c.FillRatios.Orders != 4 {
+		t.Errorf("received '%v' expected '%v'", c.FillRatios.Orders, 4)
+	}
+	if c.FillRatios.FullFills != 2 || c.FillRatios.PartialFills != 2 {
+		t.Errorf("received '%v' '%v' expected '%v' '%v'", c.FillRatios.FullFills, c.FillRatios.PartialFills, 2, 2)
+	}
+	if !c.FillRatios.Average.Equal(decimal.NewFromFloat(0.675)) {
+		t.Errorf("received '%v' expected '%v'", c.FillRatios.Average, 0.675)
+	}
+	if !c.FillRatios.Median.Equal(decimal.NewFromFloat(0.8)) {
+		t.Errorf("received '%v' expected '%v'", c.FillRatios.Median, 0.8)
+	}
+	if !c.FillRatios.Minimum.Equal(decimal.NewFromFloat(0.1)) {
+		t.Errorf("received '%v' expected '%v'", c.FillRatios.Minimum, 0.1)
+	}
+	if c.FillRatios.Distribution[0].Orders != 1 || c.FillRatios.Distribution[2].Orders != 1 {
+		t.Errorf("received '%v' expected one partial fill in the first and third quartiles", c.FillRatios.Distribution)
+	}
+}
+
+func TestCalculateEffectiveFeeRate(t *testing.T) {
+	t.Parallel()
+	c := CurrencyPairStatistic{
+		Events: []DataAtOffset{
+			{},
+			{FillEvent: &fill.Fill{Direction: order.CouldNotBuy, Amount: decimal.NewFromInt(1), PurchasePrice: decimal.NewFromInt(100), ExchangeFee: decimal.NewFromInt(1)}},
+		},
+	}
+	c.calculateEffectiveFeeRate()
+	if !c.EffectiveFeeRate.IsZero() {
+		t.Errorf("received '%v' expected '%v'", c.EffectiveFeeRate, decimal.Zero)
+	}
+
+	// 1 in fees on 1000 traded at a taker rate and 0.5 on 1000 at a lower tier
+	c.Events = append(c.Events,
+		DataAtOffset{FillEvent: &fill.Fill{Direction: order.Buy, Amount: decimal.NewFromInt(10), PurchasePrice: decimal.NewFromInt(100), ExchangeFee: decimal.NewFromInt(1)}},
+		DataAtOffset{FillEvent: &fill.Fill{Direction: order.Sell, Amount: decimal.NewFromInt(10), PurchasePrice: decimal.NewFromInt(100), ExchangeFee: decimal.NewFromFloat(0.5)}},
+	)
+	c.calculateEffectiveFeeRate()
+	if !c.EffectiveFeeRate.Equal(decimal.NewFromFloat(0.00075)) {
+		t.Errorf("received '%v' expected '%v'", c.EffectiveFeeRate, decimal.NewFromFloat(0.00075))
+	}
+}
@@ -15,7 +15,7 @@ import (
 
 // CalculateFundingStatistics calculates funding statistics for total USD strategy results
 // along with individual funding item statistics
-func CalculateFundingStatistics(funds funding.IFundingManager, currStats map[string]map[asset.Item]map[currency.Pair]*CurrencyPairStatistic, riskFreeRate decimal.Decimal, interval gctkline.Interval) (*FundingStatistics, error) {
+func CalculateFundingStatistics(funds funding.IFundingManager, currStats map[string]map[asset.Item]map[currency.Pair]*CurrencyPairStatistic, riskFreeRate decimal.Decimal, interval gctkline.Interval, tradingCalendar string) (*FundingStatistics, error) {
 	if currStats == nil {
 		return nil, common.ErrNilArguments
 	}
@@ -79,31 +79,38 @@ func CalculateFundingStatistics(funds funding.IFundingManager, currStats map[str
 		return nil, fmt.Errorf("%w and holding values", errMissingSnapshots)
 	}
 
-	if !usdStats.HoldingValues[0].Value.IsZero() {
-		usdStats.StrategyMovement = usdStats.HoldingValues[len(usdStats.HoldingValues)-1].Value.Sub(
-			usdStats.HoldingValues[0].Value).Div(
-			usdStats.HoldingValues[0].Value).Mul(
+	// external deposits and withdrawals are removed from the values
+	// returns are measured against, leaving a time weighted return
+	performanceValues := usdStats.HoldingValues
+	if len(report.CapitalFlows) > 0 {
+		performanceValues = flowAdjustedHoldingValues(usdStats.HoldingValues, report.CapitalFlows)
+		usdStats.NetCapitalFlows = report.NetCapitalFlows
+	}
+	if !performanceValues[0].Value.IsZero() {
+		usdStats.StrategyMovement = performanceValues[len(performanceValues)-1].Value.Sub(
+			performanceValues[0].Value).Div(
+			performanceValues[0].Value).Mul(
 			decimal.NewFromInt(100))
 	}
-	usdStats.HoldingValueDifference = report.FinalFunds.Sub(report.InitialFunds).Div(report.InitialFunds).Mul(decimal.NewFromInt(100))
+	usdStats.HoldingValueDifference = report.FinalFunds.Sub(report.NetCapitalFlows).Sub(report.InitialFunds).Div(report.InitialFunds).Mul(decimal.NewFromInt(100))
 
-	riskFreeRatePerCandle := usdStats.RiskFreeRate.Div(decimal.NewFromFloat(interval.IntervalsPerYear()))
-	returnsPerCandle := make([]decimal.Decimal, len(usdStats.HoldingValues))
-	benchmarkRates := make([]decimal.Decimal, len(usdStats.HoldingValues))
-	benchmarkMovement := usdStats.HoldingValues[0].Value
-	benchmarkRates[0] = usdStats.HoldingValues[0].Value
-	for j := range usdStats.HoldingValues {
-		if j != 0 && !usdStats.HoldingValues[j-1].Value.IsZero() {
+	riskFreeRatePerCandle := riskFreeRatePerCandle(usdStats.RiskFreeRate, interval, tradingCalendar)
+	returnsPerCandle := make([]decimal.Decimal, len(performanceValues))
+	benchmarkRates := make([]decimal.Decimal, len(performanceValues))
+	benchmarkMovement := performanceValues[0].Value
+	benchmarkRates[0] = performanceValues[0].Value
+	for j := range performanceValues {
+		if j != 0 && !performanceValues[j-1].Value.IsZero() {
 			benchmarkMovement = benchmarkMovement.Add(benchmarkMovement.Mul(riskFreeRatePerCandle))
 			benchmarkRates[j] = riskFreeRatePerCandle
-			returnsPerCandle[j] = usdStats.HoldingValues[j].Value.Sub(usdStats.HoldingValues[j-1].Value).Div(usdStats.HoldingValues[j-1].Value)
+			returnsPerCandle[j] = performanceValues[j].Value.Sub(performanceValues[j-1].Value).Div(performanceValues[j-1].Value)
 		}
 	}
 	benchmarkRates = benchmarkRates[1:]
 	returnsPerCandle = returnsPerCandle[1:]
-	usdStats.BenchmarkMarketMovement = benchmarkMovement.Sub(usdStats.HoldingValues[0].Value).Div(usdStats.HoldingValues[0].Value).Mul(decimal.NewFromInt(100))
+	usdStats.BenchmarkMarketMovement = benchmarkMovement.Sub(performanceValues[0].Value).Div(performanceValues[0].Value).Mul(decimal.NewFromInt(100))
 	var err error
-	usdStats.MaxDrawdown, err = CalculateBiggestValueAtTimeDrawdown(usdStats.HoldingValues, interval)
+	usdStats.MaxDrawdown, err = CalculateBiggestValueAtTimeDrawdown(performanceValues, interval)
 	if err != nil {
 		return nil, err
 	}
@@ -116,13 +123,14 @@ func CalculateFundingStatistics(funds funding.IFundingManager, currStats map[str
 
 	for i := range response.Items {
 		var cagr decimal.Decimal
-		if response.Items[i].ReportItem.InitialFunds.IsZero() {
+		finalFunds := response.Items[i].ReportItem.FinalFunds.Sub(response.Items[i].ReportItem.NetCapitalFlow)
+		if response.Items[i].ReportItem.InitialFunds.IsZero() || !finalFunds.IsPositive() {
 			continue
 		}
 		cagr, err = gctmath.DecimalCompoundAnnualGrowthRate(
 			response.Items[i].ReportItem.InitialFunds,
-			response.Items[i].ReportItem.FinalFunds,
-			decimal.NewFromFloat(interval.IntervalsPerYear()),
+			finalFunds,
+			decimal.NewFromFloat(intervalsPerYear(interval, tradingCalendar)),
 			decimal.NewFromInt(int64(len(usdStats.HoldingValues))),
 		)
 		if err != nil {
@@ -130,12 +138,12 @@ func CalculateFundingStatistics(funds funding.IFundingManager, currStats map[str
 		}
 		response.Items[i].CompoundAnnualGrowthRate = cagr
 	}
-	if !usdStats.HoldingValues[0].Value.IsZero() {
+	if !performanceValues[0].Value.IsZero() {
 		var cagr decimal.Decimal
 		cagr, err = gctmath.DecimalCompoundAnnualGrowthRate(
-			usdStats.HoldingValues[0].Value,
-			usdStats.HoldingValues[len(usdStats.HoldingValues)-1].Value,
-			decimal.NewFromFloat(interval.IntervalsPerYear()),
+			performanceValues[0].Value,
+			performanceValues[len(performanceValues)-1].Value,
+			decimal.NewFromFloat(intervalsPerYear(interval, tradingCalendar)),
 			decimal.NewFromInt(int64(len(usdStats.HoldingValues))),
 		)
 		if err != nil {
@@ -143,8 +151,9 @@ func CalculateFundingStatistics(funds funding.IFundingManager, currStats map[str
 		}
 		usdStats.CompoundAnnualGrowthRate = cagr
 	}
-	usdStats.DidStrategyMakeProfit = usdStats.HoldingValues[len(usdStats.HoldingValues)-1].Value.GreaterThan(usdStats.HoldingValues[0].Value)
+	usdStats.DidStrategyMakeProfit = performanceValues[len(performanceValues)-1].Value.GreaterThan(performanceValues[0].Value)
 	usdStats.DidStrategyBeatTheMarket = usdStats.StrategyMovement.GreaterThan(usdStats.BenchmarkMarketMovement)
+	usdStats.calculateCashUtilisation(report.USDTotalsOverTime)
 	response.TotalUSDStatistics = usdStats
 
 	return response, nil
@@ -17,7 +17,7 @@ import (
 
 func TestCalculateFundingStatistics(t *testing.T) {
 	t.Parallel()
-	_, err := CalculateFundingStatistics(nil, nil, decimal.Zero, gctkline.OneHour)
+	_, err := CalculateFundingStatistics(nil, nil, decimal.Zero, gctkline.OneHour, TradingCalendarContinuous)
 	if !errors.Is(err, common.ErrNilArguments) {
 		t.Errorf("received %v expected %v", err, common.ErrNilArguments)
 	}
@@ -43,7 +43,7 @@ func TestCalculateFundingStatistics(t *testing.T) {
 		t.Errorf("received %v expected %v", err, nil)
 	}
 
-	_, err = CalculateFundingStatistics(f, nil, decimal.Zero, gctkline.OneHour)
+	_, err = CalculateFundingStatistics(f, nil, decimal.Zero, gctkline.OneHour, TradingCalendarContinuous)
 	if !errors.Is(err, common.ErrNilArguments) {
 		t.Errorf("received %v expected %v", err, common.ErrNilArguments)
 	}
@@ -75,7 +75,7 @@ func TestCalculateFundingStatistics(t *testing.T) {
 	}
 
 	cs := make(map[string]map[asset.Item]map[currency.Pair]*CurrencyPairStatistic)
-	_, err = CalculateFundingStatistics(f, cs, decimal.Zero, gctkline.OneHour)
+	_, err = CalculateFundingStatistics(f, cs, decimal.Zero, gctkline.OneHour, TradingCalendarContinuous)
 	if !errors.Is(err, errNoRelevantStatsFound) {
 		t.Errorf("received %v expected %v", err, errNoRelevantStatsFound)
 	}
@@ -99,7 +99,7 @@ func TestCalculateFundingStatistics(t *testing.T) {
 	cs["binance"] = make(map[asset.Item]map[currency.Pair]*CurrencyPairStatistic)
 	cs["binance"][asset.Spot] = make(map[currency.Pair]*CurrencyPairStatistic)
 	cs["binance"][asset.Spot][currency.NewPair(currency.LTC, currency.USD)] = &CurrencyPairStatistic{}
-	_, err = CalculateFundingStatistics(f, cs, decimal.Zero, gctkline.OneHour)
+	_, err = CalculateFundingStatistics(f, cs, decimal.Zero, gctkline.OneHour, TradingCalendarContinuous)
 	if !errors.Is(err, errMissingSnapshots) {
 		t.Errorf("received %v expected %v", err, errMissingSnapshots)
 	}
@@ -107,7 +107,7 @@ func TestCalculateFundingStatistics(t *testing.T) {
 	f.CreateSnapshot(usdKline.Candles[1].Time)
 	cs["binance"][asset.Spot][currency.NewPair(currency.BTC, currency.USDT)] = &CurrencyPairStatistic{}
 
-	_, err = CalculateFundingStatistics(f, cs, decimal.Zero, gctkline.OneHour)
+	_, err = CalculateFundingStatistics(f, cs, decimal.Zero, gctkline.OneHour, TradingCalendarContinuous)
 	if !errors.Is(err, nil) {
 		t.Errorf("received %v expected %v", err, nil)
 	}
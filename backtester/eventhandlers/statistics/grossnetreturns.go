@@ -0,0 +1,48 @@
+package statistics
+
+import (
+	"github.com/shopspring/decimal"
+	"github.com/thrasher-corp/gocryptotrader/backtester/common"
+)
+
+// calculateGrossNetReturns separates the currency's net return, after all
+// execution costs, from its gross return before fees and slippage so that the
+// quality of the strategy's signals can be assessed apart from its execution.
+// Slippage is the adverse difference between each fill's price and the close
+// price it was signalled at. Returns are a percentage of the initial total
+// value and are only set when the initial total value is positive
+func (c *CurrencyPairStatistic) calculateGrossNetReturns() {
+	c.GrossNetReturns = nil
+	if len(c.Events) == 0 {
+		return
+	}
+	initialValue := c.Events[0].Holdings.TotalValue
+	if !initialValue.IsPositive() {
+		return
+	}
+	resp := &GrossNetReturns{
+		NetPNL: c.Events[len(c.Events)-1].Holdings.TotalValue.Sub(initialValue),
+	}
+	for i := range c.Events {
+		f := c.Events[i].FillEvent
+		if f == nil || !common.CanTransact(f.GetDirection()) || !f.GetAmount().IsPositive() {
+			continue
+		}
+		resp.Fees = resp.Fees.Add(f.GetExchangeFee())
+		if !f.GetPurchasePrice().IsPositive() || !f.GetClosePrice().IsPositive() {
+			continue
+		}
+		slippage := f.GetPurchasePrice().Sub(f.GetClosePrice()).Mul(f.GetAmount())
+		if f.GetDirection().IsShort() {
+			slippage = slippage.Neg()
+		}
+		resp.Slippage = resp.Slippage.Add(slippage)
+	}
+	resp.GrossPNL = resp.NetPNL.Add(resp.Fees).Add(resp.Slippage)
+	oneHundred := decimal.NewFromInt(100)
+	resp.NetReturn = resp.NetPNL.Div(initialValue).Mul(oneHundred)
+	resp.GrossReturn = resp.GrossPNL.Div(initialValue).Mul(oneHundred)
+	resp.FeeDrag = resp.Fees.Div(initialValue).Mul(oneHundred)
+	resp.SlippageDrag = resp.Slippage.Div(initialValue).Mul(oneHundred)
+	c.GrossNetReturns = resp
+}
@@ -0,0 +1,68 @@
+package statistics
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventhandlers/portfolio/holdings"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/event"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/fill"
+	gctorder "github.com/thrasher-corp/gocryptotrader/exchanges/order"
+)
+
+func TestCalculateGrossNetReturns(t *testing.T) {
+	t.Parallel()
+	c := CurrencyPairStatistic{}
+	c.calculateGrossNetReturns()
+	if c.GrossNetReturns != nil {
+		t.Errorf("received '%v' expected '%v'", c.GrossNetReturns, nil)
+	}
+	c.Events = []DataAtOffset{{}}
+	c.calculateGrossNetReturns()
+	if c.GrossNetReturns != nil {
+		t.Errorf("received '%v' expected '%v'", c.GrossNetReturns, nil)
+	}
+
+	newFill := func(side gctorder.Side, closePrice, purchasePrice int64) *fill.Fill {
+		return &fill.Fill{
+			Base:          &event.Base{},
+			Direction:     side,
+			Amount:        decimal.NewFromInt(2),
+			ClosePrice:    decimal.NewFromInt(closePrice),
+			PurchasePrice: decimal.NewFromInt(purchasePrice),
+			ExchangeFee:   decimal.NewFromInt(5),
+		}
+	}
+	c.Events = []DataAtOffset{
+		{
+			Holdings:  holdings.Holding{TotalValue: decimal.NewFromInt(1000)},
+			FillEvent: newFill(gctorder.Buy, 100, 101),
+		},
+		{
+			Holdings:  holdings.Holding{TotalValue: decimal.NewFromInt(1000)},
+			FillEvent: newFill(gctorder.CouldNotSell, 100, 99),
+		},
+		{
+			Holdings:  holdings.Holding{TotalValue: decimal.NewFromInt(1030)},
+			FillEvent: newFill(gctorder.Sell, 120, 119),
+		},
+	}
+	c.calculateGrossNetReturns()
+	g := c.GrossNetReturns
+	if g == nil {
+		t.Fatal("expected gross and net returns to be set")
+	}
+	// the buy slipped 2 above its close and the sell 2 below
+	if !g.Fees.Equal(decimal.NewFromInt(10)) || !g.Slippage.Equal(decimal.NewFromInt(4)) {
+		t.Errorf("received fees '%v' slippage '%v' expected '%v' '%v'", g.Fees, g.Slippage, 10, 4)
+	}
+	if !g.NetPNL.Equal(decimal.NewFromInt(30)) || !g.GrossPNL.Equal(decimal.NewFromInt(44)) {
+		t.Errorf("received net '%v' gross '%v' expected '%v' '%v'", g.NetPNL, g.GrossPNL, 30, 44)
+	}
+	if !g.NetReturn.Equal(decimal.NewFromInt(3)) || !g.GrossReturn.Equal(decimal.NewFromFloat(4.4)) {
+		t.Errorf("received net '%v' gross '%v' expected '%v' '%v'", g.NetReturn, g.GrossReturn, 3, 4.4)
+	}
+	if !g.GrossReturn.Sub(g.FeeDrag).Sub(g.SlippageDrag).Equal(g.NetReturn) {
+		t.Errorf("received gross '%v' less drags '%v' '%v' expected net '%v'", g.GrossReturn, g.FeeDrag, g.SlippageDrag, g.NetReturn)
+	}
+}
@@ -0,0 +1,72 @@
+package statistics
+
+import (
+	"github.com/shopspring/decimal"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/fill"
+	"github.com/thrasher-corp/gocryptotrader/backtester/report/trades"
+)
+
+// calculateKellyCriterion derives the Kelly optimal fraction of capital to
+// risk per trade from the win rate and win/loss ratio of the currency's closed
+// round trip trades, being W - (1 - W) / R. Round trips which broke even count
+// towards the trades but are neither wins nor losses. A negative fraction
+// means the strategy had no edge and suggests it should not have been traded,
+// so its fractional Kelly sizes are left at zero. Without a winning trade the
+// fraction is -1. Statistics are only set when at least one round trip closed
+func (c *CurrencyPairStatistic) calculateKellyCriterion() error {
+	c.KellyCriterion = nil
+	var fills []fill.Event
+	for i := range c.Events {
+		if c.Events[i].FillEvent != nil {
+			fills = append(fills, c.Events[i].FillEvent)
+		}
+	}
+	roundTrips, err := trades.NewExporter().RoundTrips(fills, nil)
+	if err != nil {
+		return err
+	}
+	if len(roundTrips) == 0 {
+		return nil
+	}
+	k := &KellyCriterion{
+		RoundTrips: int64(len(roundTrips)),
+	}
+	var totalWin, totalLoss decimal.Decimal
+	for i := range roundTrips {
+		pnl := decimal.NewFromFloat(roundTrips[i].PNL)
+		switch {
+		case pnl.IsPositive():
+			k.Wins++
+			totalWin = totalWin.Add(pnl)
+		case pnl.IsNegative():
+			k.Losses++
+			totalLoss = totalLoss.Add(pnl.Abs())
+		}
+	}
+	k.WinRate = decimal.NewFromInt(k.Wins).Div(decimal.NewFromInt(k.RoundTrips))
+	if k.Wins > 0 {
+		k.AverageWin = totalWin.Div(decimal.NewFromInt(k.Wins))
+	}
+	if k.Losses > 0 {
+		k.AverageLoss = totalLoss.Div(decimal.NewFromInt(k.Losses))
+	}
+	switch {
+	case k.Wins == 0:
+		// without a win there is no payoff to size for, the
+		// strategy loses everything risked on it
+		k.FullKelly = decimal.NewFromInt(-1)
+	case k.Losses == 0:
+		// the payoff ratio is unbounded, leaving only the win rate
+		k.FullKelly = k.WinRate
+	default:
+		k.WinLossRatio = k.AverageWin.Div(k.AverageLoss)
+		k.FullKelly = k.WinRate.Sub(decimal.NewFromInt(1).Sub(k.WinRate).Div(k.WinLossRatio))
+	}
+	if k.FullKelly.IsPositive() {
+		k.HasEdge = true
+		k.HalfKelly = k.FullKelly.Div(decimal.NewFromInt(2))
+		k.QuarterKelly = k.FullKelly.Div(decimal.NewFromInt(4))
+	}
+	c.KellyCriterion = k
+	return nil
+}
@@ -0,0 +1,99 @@
+package statistics
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/event"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/fill"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	gctorder "github.com/thrasher-corp/gocryptotrader/exchanges/order"
+)
+
+// roundTripEvents returns fills buying and then selling one unit
+// at each pair of entry and exit prices without fees
+func roundTripEvents(prices ...float64) []DataAtOffset {
+	tt := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	var resp []DataAtOffset
+	for i := range prices {
+		side := gctorder.Buy
+		if i%2 == 1 {
+			side = gctorder.Sell
+		}
+		resp = append(resp, DataAtOffset{
+			FillEvent: &fill.Fill{
+				Base:          &event.Base{Time: tt.Add(time.Duration(i) * time.Hour), AssetType: asset.Spot},
+				Direction:     side,
+				Amount:        decimal.NewFromInt(1),
+				PurchasePrice: decimal.NewFromFloat(prices[i]),
+				Order:         &gctorder.Detail{Side: side},
+			},
+		})
+	}
+	return resp
+}
+
+func TestCalculateKellyCriterion(t *testing.T) {
+	t.Parallel()
+	c := CurrencyPairStatistic{}
+	err := c.calculateKellyCriterion()
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	if c.KellyCriterion != nil {
+		t.Fatalf("received '%v' expected '%v'", c.KellyCriterion, nil)
+	}
+
+	// two wins of 20, one loss of 10 and one break even
+	c.Events = roundTripEvents(100, 120, 100, 120, 100, 90, 100, 100)
+	err = c.calculateKellyCriterion()
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	k := c.KellyCriterion
+	if k == nil {
+		t.Fatal("expected Kelly criterion to be set")
+	}
+	if k.RoundTrips != 4 || k.Wins != 2 || k.Losses != 1 {
+		t.Errorf("received '%v' '%v' '%v' expected '4' '2' '1'", k.RoundTrips, k.Wins, k.Losses)
+	}
+	if !k.WinRate.Equal(decimal.NewFromFloat(0.5)) || !k.WinLossRatio.Equal(decimal.NewFromInt(2)) {
+		t.Errorf("received '%v' '%v' expected '0.5' '2'", k.WinRate, k.WinLossRatio)
+	}
+	// 0.5 - 0.5 / 2
+	if !k.FullKelly.Equal(decimal.NewFromFloat(0.25)) || !k.HalfKelly.Equal(decimal.NewFromFloat(0.125)) || !k.HasEdge {
+		t.Errorf("received '%v' '%v' '%v' expected '0.25' '0.125' 'true'", k.FullKelly, k.HalfKelly, k.HasEdge)
+	}
+
+	// one win of 10 and two losses of 20
+	c.Events = roundTripEvents(100, 110, 100, 80, 100, 80)
+	err = c.calculateKellyCriterion()
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	k = c.KellyCriterion
+	if !k.FullKelly.IsNegative() || k.HasEdge || !k.HalfKelly.IsZero() || !k.QuarterKelly.IsZero() {
+		t.Errorf("received '%v' '%v' '%v' expected negative Kelly without an edge", k.FullKelly, k.HasEdge, k.HalfKelly)
+	}
+
+	c.Events = roundTripEvents(100, 90)
+	err = c.calculateKellyCriterion()
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	if !c.KellyCriterion.FullKelly.Equal(decimal.NewFromInt(-1)) || c.KellyCriterion.HasEdge {
+		t.Errorf("received '%v' expected '%v'", c.KellyCriterion.FullKelly, -1)
+	}
+
+	c.Events = roundTripEvents(100, 110)
+	err = c.calculateKellyCriterion()
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	if !c.KellyCriterion.FullKelly.Equal(decimal.NewFromInt(1)) || !c.KellyCriterion.HasEdge {
+		t.Errorf("received '%v' expected '%v'", c.KellyCriterion.FullKelly, 1)
+	}
+
+}
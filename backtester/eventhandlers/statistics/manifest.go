@@ -0,0 +1,44 @@
+package statistics
+
+import (
+	"runtime"
+
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventhandlers/exchange"
+	"github.com/thrasher-corp/gocryptotrader/core"
+	gctkline "github.com/thrasher-corp/gocryptotrader/exchanges/kline"
+)
+
+// NewRunManifest records the inputs of a run so that two results can be
+// verified as coming from identical inputs. The config hash identifies the
+// strategy config and the settings are those each currency executed with.
+// Latency seeds are collected from the settings. The data range is set once
+// results are calculated
+func NewRunManifest(configHash string, interval gctkline.Interval, dataType string, settings []exchange.Settings) *RunManifest {
+	m := &RunManifest{
+		ConfigHash: configHash,
+		Version:    core.MajorVersion + "." + core.MinorVersion,
+		GoVersion:  runtime.Version(),
+		Interval:   interval,
+		DataType:   dataType,
+	}
+	for i := range settings {
+		var exchangeName string
+		if settings[i].Exchange != nil {
+			exchangeName = settings[i].Exchange.GetName()
+		}
+		m.CurrencySettings = append(m.CurrencySettings, ManifestCurrencySettings{
+			Exchange: exchangeName,
+			Settings: settings[i],
+		})
+		if settings[i].Latency != nil {
+			m.RandomSeeds = append(m.RandomSeeds, ManifestSeed{
+				Exchange: exchangeName,
+				Asset:    settings[i].Asset,
+				Pair:     settings[i].Pair,
+				Source:   "latency",
+				Seed:     settings[i].Latency.Seed,
+			})
+		}
+	}
+	return m
+}
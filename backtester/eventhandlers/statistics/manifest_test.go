@@ -0,0 +1,69 @@
+package statistics
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/thrasher-corp/gocryptotrader/backtester/common"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventhandlers/exchange"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	gctkline "github.com/thrasher-corp/gocryptotrader/exchanges/kline"
+)
+
+func TestNewRunManifest(t *testing.T) {
+	t.Parallel()
+	settings := []exchange.Settings{
+		{
+			Asset:    asset.Spot,
+			Pair:     currency.NewPair(currency.BTC, currency.USDT),
+			MakerFee: decimal.NewFromFloat(0.001),
+			Latency:  &exchange.LatencyDistribution{Seed: 1337},
+		},
+		{
+			Asset: asset.Spot,
+			Pair:  currency.NewPair(currency.ETH, currency.USDT),
+		},
+	}
+	m := NewRunManifest("hash", gctkline.OneHour, common.CandleStr, settings)
+	if m.ConfigHash != "hash" || m.Interval != gctkline.OneHour || m.DataType != common.CandleStr {
+		t.Errorf("received '%+v' expected config hash, interval and data type to be set", m)
+	}
+	if m.Version == "" || m.GoVersion == "" {
+		t.Errorf("received '%v' '%v' expected versions to be set", m.Version, m.GoVersion)
+	}
+	if len(m.CurrencySettings) != 2 {
+		t.Fatalf("received '%v' expected '%v'", len(m.CurrencySettings), 2)
+	}
+	if !m.CurrencySettings[0].Settings.MakerFee.Equal(decimal.NewFromFloat(0.001)) {
+		t.Errorf("received '%v' expected '%v'", m.CurrencySettings[0].Settings.MakerFee, 0.001)
+	}
+	if len(m.RandomSeeds) != 1 {
+		t.Fatalf("received '%v' expected '%v'", len(m.RandomSeeds), 1)
+	}
+	if m.RandomSeeds[0].Seed != 1337 || !m.RandomSeeds[0].Pair.Equal(settings[0].Pair) {
+		t.Errorf("received '%+v' expected seed 1337 for '%v'", m.RandomSeeds[0], settings[0].Pair)
+	}
+}
+
+func TestSerialiseManifest(t *testing.T) {
+	t.Parallel()
+	s := Statistic{
+		Manifest: NewRunManifest("hash", gctkline.OneHour, common.CandleStr, []exchange.Settings{{
+			Asset: asset.Spot,
+			Pair:  currency.NewPair(currency.BTC, currency.USDT),
+		}}),
+	}
+	resp, err := s.Serialise()
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	if !strings.HasPrefix(strings.TrimLeft(resp, "{\n "), `"manifest"`) {
+		t.Errorf("received '%v' expected the manifest first", resp[:50])
+	}
+	if !strings.Contains(resp, `"hash"`) {
+		t.Error("expected the config hash to be serialised")
+	}
+}
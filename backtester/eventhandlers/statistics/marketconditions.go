@@ -0,0 +1,33 @@
+package statistics
+
+import "github.com/shopspring/decimal"
+
+// calculateMarketConditions returns the range of each candle, being its high
+// less its low as a percentage of its close, along with its volume. Aligned
+// with each event's offset so performance can be compared against them
+func (c *CurrencyPairStatistic) calculateMarketConditions() []MarketCondition {
+	conditions := make([]MarketCondition, 0, len(c.Events))
+	oneHundred := decimal.NewFromInt(100)
+	for i := range c.Events {
+		d := c.Events[i].DataEvent
+		if d == nil {
+			continue
+		}
+		mc := MarketCondition{
+			Offset: c.Events[i].Offset,
+			Time:   c.Events[i].Time,
+			Volume: d.GetVolume(),
+		}
+		if !d.GetClosePrice().IsZero() {
+			mc.RangePercent = d.GetHighPrice().Sub(d.GetLowPrice()).Div(d.GetClosePrice()).Mul(oneHundred)
+		}
+		conditions = append(conditions, mc)
+	}
+	return conditions
+}
+
+// GetMarketConditions returns the range and volume of each candle. It is
+// only recorded when the market conditions statistic setting is enabled
+func (c *CurrencyPairStatistic) GetMarketConditions() []MarketCondition {
+	return c.MarketConditions
+}
@@ -0,0 +1,58 @@
+package statistics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/event"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/kline"
+)
+
+func TestCalculateMarketConditions(t *testing.T) {
+	t.Parallel()
+	c := CurrencyPairStatistic{}
+	if conditions := c.calculateMarketConditions(); len(conditions) != 0 {
+		t.Errorf("received '%v' expected '%v'", len(conditions), 0)
+	}
+	tt := time.Now()
+	c.Events = []DataAtOffset{
+		{
+			Offset: 1,
+			Time:   tt,
+			DataEvent: &kline.Kline{
+				Base:   &event.Base{},
+				High:   decimal.NewFromInt(110),
+				Low:    decimal.NewFromInt(90),
+				Close:  decimal.NewFromInt(100),
+				Volume: decimal.NewFromInt(1337),
+			},
+		},
+		{
+			Offset: 2,
+			Time:   tt.Add(time.Hour),
+		},
+		{
+			Offset:    3,
+			Time:      tt.Add(time.Hour * 2),
+			DataEvent: &kline.Kline{Base: &event.Base{}},
+		},
+	}
+	c.MarketConditions = c.calculateMarketConditions()
+	conditions := c.GetMarketConditions()
+	if len(conditions) != 2 {
+		t.Fatalf("received '%v' expected '%v'", len(conditions), 2)
+	}
+	if conditions[0].Offset != 1 || !conditions[0].Time.Equal(tt) {
+		t.Errorf("received '%v' '%v' expected '%v' '%v'", conditions[0].Offset, conditions[0].Time, 1, tt)
+	}
+	if !conditions[0].RangePercent.Equal(decimal.NewFromInt(20)) {
+		t.Errorf("received '%v' expected '%v'", conditions[0].RangePercent, 20)
+	}
+	if !conditions[0].Volume.Equal(decimal.NewFromInt(1337)) {
+		t.Errorf("received '%v' expected '%v'", conditions[0].Volume, 1337)
+	}
+	if conditions[1].Offset != 3 || !conditions[1].RangePercent.IsZero() {
+		t.Errorf("received '%v' '%v' expected '%v' '%v'", conditions[1].Offset, conditions[1].RangePercent, 3, 0)
+	}
+}
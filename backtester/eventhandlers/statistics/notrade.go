@@ -0,0 +1,60 @@
+package statistics
+
+import (
+	"github.com/thrasher-corp/gocryptotrader/backtester/common"
+)
+
+const (
+	noTradeStageData   = "data"
+	noTradeStageSignal = "signal"
+	noTradeStageOrder  = "order"
+	noTradeStageFill   = "fill"
+
+	noReasonGiven = "no reason given"
+)
+
+// calculateNoTradeReasons returns why each event did not result in a
+// successful fill. The reasons are taken from the furthest event reached
+// along the signal, order and fill chain as each shares its reasoning
+func (c *CurrencyPairStatistic) calculateNoTradeReasons() []NoTradeReason {
+	var reasons []NoTradeReason
+	for i := range c.Events {
+		var ev common.EventHandler
+		var stage string
+		switch {
+		case c.Events[i].FillEvent != nil:
+			if common.CanTransact(c.Events[i].FillEvent.GetDirection()) {
+				continue
+			}
+			ev, stage = c.Events[i].FillEvent, noTradeStageFill
+		case c.Events[i].OrderEvent != nil:
+			ev, stage = c.Events[i].OrderEvent, noTradeStageOrder
+		case c.Events[i].SignalEvent != nil:
+			ev, stage = c.Events[i].SignalEvent, noTradeStageSignal
+		case c.Events[i].DataEvent != nil:
+			ev, stage = c.Events[i].DataEvent, noTradeStageData
+		default:
+			continue
+		}
+		ntr := NoTradeReason{
+			Offset: c.Events[i].Offset,
+			Time:   c.Events[i].Time,
+			Stage:  stage,
+			Reason: ev.GetConcatReasons(),
+		}
+		if d, ok := ev.(common.Directioner); ok {
+			ntr.Direction = d.GetDirection()
+		}
+		if ntr.Reason == "" {
+			ntr.Reason = noReasonGiven
+		}
+		reasons = append(reasons, ntr)
+	}
+	return reasons
+}
+
+// GetNoTradeReasons returns why each event did not result in a trade.
+// It is only recorded when the no trade reasons statistic setting is enabled
+func (c *CurrencyPairStatistic) GetNoTradeReasons() []NoTradeReason {
+	return c.NoTradeReasons
+}
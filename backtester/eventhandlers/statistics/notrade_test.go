@@ -0,0 +1,79 @@
+package statistics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/event"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/fill"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/kline"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/order"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/signal"
+	gctorder "github.com/thrasher-corp/gocryptotrader/exchanges/order"
+)
+
+func TestCalculateNoTradeReasons(t *testing.T) {
+	t.Parallel()
+	c := CurrencyPairStatistic{}
+	if reasons := c.calculateNoTradeReasons(); reasons != nil {
+		t.Errorf("received '%v' expected '%v'", reasons, nil)
+	}
+	tt := time.Now()
+	newBase := func(offset int64, reasons ...string) *event.Base {
+		return &event.Base{Offset: offset, Time: tt.Add(time.Hour * time.Duration(offset)), Reasons: reasons}
+	}
+
+	noSignal := newBase(1)
+	doNothing := newBase(2, "waiting for RSI")
+	rejected := newBase(3, "not enough funds to buy")
+	couldNotBuy := newBase(4, "exceeded volume")
+	filled := newBase(5)
+	c.Events = []DataAtOffset{
+		{Offset: 1, Time: noSignal.Time, DataEvent: &kline.Kline{Base: noSignal}},
+		{
+			Offset:      2,
+			Time:        doNothing.Time,
+			DataEvent:   &kline.Kline{Base: doNothing},
+			SignalEvent: &signal.Signal{Base: doNothing, Direction: gctorder.DoNothing},
+		},
+		{
+			Offset:      3,
+			Time:        rejected.Time,
+			DataEvent:   &kline.Kline{Base: rejected},
+			SignalEvent: &signal.Signal{Base: rejected, Direction: gctorder.CouldNotBuy},
+			OrderEvent:  &order.Order{Base: rejected, Direction: gctorder.CouldNotBuy},
+		},
+		{
+			Offset:      4,
+			Time:        couldNotBuy.Time,
+			DataEvent:   &kline.Kline{Base: couldNotBuy},
+			SignalEvent: &signal.Signal{Base: couldNotBuy, Direction: gctorder.Buy},
+			OrderEvent:  &order.Order{Base: couldNotBuy, Direction: gctorder.Buy},
+			FillEvent:   &fill.Fill{Base: couldNotBuy, Direction: gctorder.CouldNotBuy},
+		},
+		{
+			Offset:      5,
+			Time:        filled.Time,
+			DataEvent:   &kline.Kline{Base: filled},
+			SignalEvent: &signal.Signal{Base: filled, Direction: gctorder.Buy},
+			OrderEvent:  &order.Order{Base: filled, Direction: gctorder.Buy},
+			FillEvent:   &fill.Fill{Base: filled, Direction: gctorder.Buy},
+		},
+	}
+	c.NoTradeReasons = c.calculateNoTradeReasons()
+	reasons := c.GetNoTradeReasons()
+	expected := []NoTradeReason{
+		{Offset: 1, Time: noSignal.Time, Stage: noTradeStageData, Reason: noReasonGiven},
+		{Offset: 2, Time: doNothing.Time, Stage: noTradeStageSignal, Direction: gctorder.DoNothing, Reason: "waiting for RSI"},
+		{Offset: 3, Time: rejected.Time, Stage: noTradeStageOrder, Direction: gctorder.CouldNotBuy, Reason: "not enough funds to buy"},
+		{Offset: 4, Time: couldNotBuy.Time, Stage: noTradeStageFill, Direction: gctorder.CouldNotBuy, Reason: "exceeded volume"},
+	}
+	if len(reasons) != len(expected) {
+		t.Fatalf("received '%v' expected '%v'", len(reasons), len(expected))
+	}
+	for i := range expected {
+		if reasons[i] != expected[i] {
+			t.Errorf("received '%+v' expected '%+v'", reasons[i], expected[i])
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package statistics
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/thrasher-corp/gocryptotrader/backtester/common"
+)
+
+// CalculatePerformanceFee charges a performance fee of the rate percentage
+// on any gain of the total USD holdings above their high-water mark. The fee
+// crystallises every interval from the first holding value and at the end of
+// the run, or only at the end of the run when the interval is zero. Fees
+// shrink all later holdings as if withdrawn, and the high-water mark is
+// raised to the holdings after the fee. Capital flows are excluded so that
+// deposits are not charged as gains
+func (f *FundingStatistics) CalculatePerformanceFee(ratePercent decimal.Decimal, interval time.Duration) error {
+	if f.Report == nil || f.TotalUSDStatistics == nil {
+		return fmt.Errorf("%w requires USD tracking statistics", common.ErrNilArguments)
+	}
+	values := f.TotalUSDStatistics.HoldingValues
+	if len(f.Report.CapitalFlows) > 0 {
+		values = flowAdjustedHoldingValues(values, f.Report.CapitalFlows)
+	}
+	fee, err := calculatePerformanceFee(values, ratePercent, interval)
+	if err != nil {
+		return err
+	}
+	f.TotalUSDStatistics.PerformanceFee = fee
+	return nil
+}
+
+// calculatePerformanceFee crystallises the high-water mark performance
+// fee against the holding values, returning holdings net of the fee
+func calculatePerformanceFee(values []ValueAtTime, ratePercent decimal.Decimal, interval time.Duration) (*PerformanceFee, error) {
+	if len(values) == 0 {
+		return nil, fmt.Errorf("%w holding values", errReceivedNoData)
+	}
+	if ratePercent.IsNegative() || ratePercent.GreaterThanOrEqual(decimal.NewFromInt(100)) {
+		return nil, fmt.Errorf("%w %v, must be between 0 and 100", errInvalidPerformanceFee, ratePercent)
+	}
+	if interval < 0 {
+		return nil, fmt.Errorf("%w interval %v cannot be negative", errInvalidPerformanceFee, interval)
+	}
+	rate := ratePercent.Div(decimal.NewFromInt(100))
+	resp := &PerformanceFee{
+		RatePercent:      ratePercent,
+		Interval:         interval,
+		NetHoldingValues: make([]ValueAtTime, len(values)),
+	}
+	// netFactor scales gross holdings down by every fee charged so far
+	netFactor := decimal.NewFromInt(1)
+	highWaterMark := values[0].Value
+	nextCrystallisation := values[0].Time.Add(interval)
+	for i := range values {
+		netValue := values[i].Value.Mul(netFactor)
+		isLast := i == len(values)-1
+		isDue := interval > 0 && !values[i].Time.Before(nextCrystallisation)
+		if (isDue || isLast) && netValue.GreaterThan(highWaterMark) {
+			fee := netValue.Sub(highWaterMark).Mul(rate)
+			resp.Charges = append(resp.Charges, PerformanceFeeCharge{
+				Time:          values[i].Time,
+				HoldingValue:  netValue,
+				HighWaterMark: highWaterMark,
+				Fee:           fee,
+			})
+			resp.TotalFees = resp.TotalFees.Add(fee)
+			netFactor = netFactor.Mul(netValue.Sub(fee)).Div(netValue)
+			netValue = netValue.Sub(fee)
+			highWaterMark = netValue
+		}
+		for isDue && !values[i].Time.Before(nextCrystallisation) {
+			nextCrystallisation = nextCrystallisation.Add(interval)
+		}
+		resp.NetHoldingValues[i] = ValueAtTime{Time: values[i].Time, Value: netValue, Set: true}
+	}
+	resp.FinalHighWaterMark = highWaterMark
+	if !values[0].Value.IsZero() {
+		oneHundred := decimal.NewFromInt(100)
+		resp.GrossStrategyMovement = values[len(values)-1].Value.Sub(values[0].Value).Div(values[0].Value).Mul(oneHundred)
+		resp.NetStrategyMovement = resp.NetHoldingValues[len(values)-1].Value.Sub(values[0].Value).Div(values[0].Value).Mul(oneHundred)
+	}
+	return resp, nil
+}
@@ -0,0 +1,109 @@
+package statistics
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/thrasher-corp/gocryptotrader/backtester/common"
+	"github.com/thrasher-corp/gocryptotrader/backtester/funding"
+)
+
+func performanceFeeValues(tt time.Time) []ValueAtTime {
+	values := make([]ValueAtTime, 4)
+	for i, v := range []int64{100, 120, 110, 130} {
+		values[i] = ValueAtTime{Time: tt.Add(time.Hour * 24 * time.Duration(i)), Value: decimal.NewFromInt(v), Set: true}
+	}
+	return values
+}
+
+func TestCalculatePerformanceFee(t *testing.T) {
+	t.Parallel()
+	_, err := calculatePerformanceFee(nil, decimal.NewFromInt(20), 0)
+	if !errors.Is(err, errReceivedNoData) {
+		t.Errorf("received '%v' expected '%v'", err, errReceivedNoData)
+	}
+	tt := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	values := performanceFeeValues(tt)
+	_, err = calculatePerformanceFee(values, decimal.NewFromInt(100), 0)
+	if !errors.Is(err, errInvalidPerformanceFee) {
+		t.Errorf("received '%v' expected '%v'", err, errInvalidPerformanceFee)
+	}
+	_, err = calculatePerformanceFee(values, decimal.NewFromInt(20), -time.Hour)
+	if !errors.Is(err, errInvalidPerformanceFee) {
+		t.Errorf("received '%v' expected '%v'", err, errInvalidPerformanceFee)
+	}
+
+	// charged at the end of the run only
+	fee, err := calculatePerformanceFee(values, decimal.NewFromInt(20), 0)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	if len(fee.Charges) != 1 || !fee.TotalFees.Equal(decimal.NewFromInt(6)) {
+		t.Errorf("received '%v' '%v' expected '%v' '%v'", len(fee.Charges), fee.TotalFees, 1, 6)
+	}
+	if !fee.GrossStrategyMovement.Equal(decimal.NewFromInt(30)) {
+		t.Errorf("received '%v' expected '%v'", fee.GrossStrategyMovement, 30)
+	}
+	if !fee.NetStrategyMovement.Equal(decimal.NewFromInt(24)) {
+		t.Errorf("received '%v' expected '%v'", fee.NetStrategyMovement, 24)
+	}
+	if !fee.FinalHighWaterMark.Equal(decimal.NewFromInt(124)) {
+		t.Errorf("received '%v' expected '%v'", fee.FinalHighWaterMark, 124)
+	}
+
+	// charged daily, the drop below the high-water mark is not charged
+	fee, err = calculatePerformanceFee(values, decimal.NewFromInt(20), time.Hour*24)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	if len(fee.Charges) != 2 {
+		t.Fatalf("received '%v' expected '%v'", len(fee.Charges), 2)
+	}
+	if !fee.Charges[0].Fee.Equal(decimal.NewFromInt(4)) || !fee.Charges[0].Time.Equal(values[1].Time) {
+		t.Errorf("received '%v' '%v' expected '%v' '%v'", fee.Charges[0].Fee, fee.Charges[0].Time, 4, values[1].Time)
+	}
+	if !fee.Charges[1].HighWaterMark.Equal(decimal.NewFromInt(116)) {
+		t.Errorf("received '%v' expected '%v'", fee.Charges[1].HighWaterMark, 116)
+	}
+	// 130 scaled by the first fee to 125.67, charged 20% above 116
+	if !fee.Charges[1].Fee.Round(4).Equal(decimal.NewFromFloat(1.9333)) {
+		t.Errorf("received '%v' expected '%v'", fee.Charges[1].Fee.Round(4), 1.9333)
+	}
+	if !fee.NetHoldingValues[2].Value.LessThan(values[2].Value) {
+		t.Errorf("expected net holdings '%v' to be below gross holdings '%v'", fee.NetHoldingValues[2].Value, values[2].Value)
+	}
+	if !fee.NetStrategyMovement.LessThan(fee.GrossStrategyMovement) {
+		t.Errorf("expected net movement '%v' to be below gross movement '%v'", fee.NetStrategyMovement, fee.GrossStrategyMovement)
+	}
+}
+
+func TestFundingStatisticsCalculatePerformanceFee(t *testing.T) {
+	t.Parallel()
+	f := FundingStatistics{}
+	err := f.CalculatePerformanceFee(decimal.NewFromInt(20), 0)
+	if !errors.Is(err, common.ErrNilArguments) {
+		t.Errorf("received '%v' expected '%v'", err, common.ErrNilArguments)
+	}
+	tt := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	f.Report = &funding.Report{}
+	f.TotalUSDStatistics = &TotalFundingStatistics{HoldingValues: performanceFeeValues(tt)}
+	err = f.CalculatePerformanceFee(decimal.NewFromInt(20), 0)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	if f.TotalUSDStatistics.PerformanceFee == nil || !f.TotalUSDStatistics.PerformanceFee.TotalFees.Equal(decimal.NewFromInt(6)) {
+		t.Errorf("expected a performance fee of '%v'", 6)
+	}
+
+	// a deposit is not charged as a gain
+	f.Report.CapitalFlows = []funding.CapitalFlow{{AppliedTime: tt.Add(time.Hour * 24 * 3), USDValue: decimal.NewFromInt(20)}}
+	err = f.CalculatePerformanceFee(decimal.NewFromInt(20), 0)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	if !f.TotalUSDStatistics.PerformanceFee.TotalFees.Round(8).Equal(decimal.NewFromInt(2)) {
+		t.Errorf("received '%v' expected '%v'", f.TotalUSDStatistics.PerformanceFee.TotalFees, 2)
+	}
+}
@@ -29,6 +29,15 @@ func addReason(reason, msg string) string {
 	return msg
 }
 
+// insufficientDataWarning flags a statistic calculated
+// from too few observations to be reliable
+func insufficientDataWarning(insufficient bool) string {
+	if !insufficient {
+		return ""
+	}
+	return " (insufficient data, unreliable)"
+}
+
 // PrintTotalResults outputs all results to the CMD
 func (s *Statistic) PrintTotalResults() {
 	log.Info(common.Statistics, common.CMDColours.H1+"------------------Strategy-----------------------------------"+common.CMDColours.Default)
@@ -44,6 +53,21 @@ func (s *Statistic) PrintTotalResults() {
 	log.Infof(common.Statistics, "Total short orders: %v", convert.IntToHumanFriendlyString(s.TotalShortOrders, ","))
 	log.Infof(common.Statistics, "Total orders: %v\n\n", convert.IntToHumanFriendlyString(s.TotalOrders, ","))
 
+	if s.TimeInMarket != nil {
+		log.Info(common.Statistics, common.CMDColours.H3+"------------------Time In Market-----------------------------"+common.CMDColours.Default)
+		log.Infof(common.Statistics, "In market: %s%% of candles for %v", convert.DecimalToHumanFriendlyString(s.TimeInMarket.InMarketPercent, 2, ".", ","), s.TimeInMarket.InMarketDuration)
+		log.Infof(common.Statistics, "In market candles: %v", convert.IntToHumanFriendlyString(s.TimeInMarket.InMarketCandles, ","))
+		log.Infof(common.Statistics, "Flat candles: %v\n\n", convert.IntToHumanFriendlyString(s.TimeInMarket.FlatCandles, ","))
+	}
+
+	if len(s.DowntimePeriods) > 0 {
+		log.Info(common.Statistics, common.CMDColours.H3+"------------------Exchange Downtime--------------------------"+common.CMDColours.Default)
+		for i := range s.DowntimePeriods {
+			d := s.DowntimePeriods[i]
+			log.Infof(common.Statistics, "%v %v %v down from %v to %v for %v", d.Exchange, d.Asset, d.Pair, d.Start, d.End, d.End.Sub(d.Start))
+		}
+	}
+
 	if s.BiggestDrawdown != nil {
 		log.Info(common.Statistics, common.CMDColours.H3+"------------------Biggest Drawdown-----------------------"+common.CMDColours.Default)
 		log.Infof(common.Statistics, "Exchange: %v Asset: %v Currency: %v", s.BiggestDrawdown.Exchange, s.BiggestDrawdown.Asset, s.BiggestDrawdown.Pair)
@@ -62,6 +86,69 @@ func (s *Statistic) PrintTotalResults() {
 	}
 }
 
+// PrintStrategyBetas outputs the strategy's beta to each traded instrument
+func (s *Statistic) PrintStrategyBetas() {
+	if len(s.StrategyBetas) == 0 {
+		return
+	}
+	log.Info(common.Statistics, common.CMDColours.H3+"------------------Strategy Beta------------------------------"+common.CMDColours.Default)
+	for i := range s.StrategyBetas {
+		b := s.StrategyBetas[i]
+		log.Infof(common.Statistics, "%v %v %v beta: %s R²: %s over %v candles", b.Exchange, b.Asset, b.Pair,
+			convert.DecimalToHumanFriendlyString(b.Beta, 4, ".", ","),
+			convert.DecimalToHumanFriendlyString(b.RSquared, 4, ".", ","),
+			convert.IntToHumanFriendlyString(b.Observations, ","))
+		if len(b.RollingBeta) == 0 {
+			continue
+		}
+		lowest, highest := b.RollingBeta[0], b.RollingBeta[0]
+		for j := range b.RollingBeta {
+			if b.RollingBeta[j].Value.LessThan(lowest.Value) {
+				lowest = b.RollingBeta[j]
+			}
+			if b.RollingBeta[j].Value.GreaterThan(highest.Value) {
+				highest = b.RollingBeta[j]
+			}
+		}
+		log.Infof(common.Statistics, "%v %v %v rolling beta first: %s final: %s lowest: %s at %v highest: %s at %v", b.Exchange, b.Asset, b.Pair,
+			convert.DecimalToHumanFriendlyString(b.RollingBeta[0].Value, 4, ".", ","),
+			convert.DecimalToHumanFriendlyString(b.RollingBeta[len(b.RollingBeta)-1].Value, 4, ".", ","),
+			convert.DecimalToHumanFriendlyString(lowest.Value, 4, ".", ","), lowest.Time,
+			convert.DecimalToHumanFriendlyString(highest.Value, 4, ".", ","), highest.Time)
+	}
+}
+
+// PrintCorrelationMatrix outputs the return correlation
+// of each pair of traded currencies
+func (s *Statistic) PrintCorrelationMatrix() {
+	if s.CorrelationMatrix == nil {
+		return
+	}
+	log.Info(common.Statistics, common.CMDColours.H3+"------------------Correlation Matrix-------------------------"+common.CMDColours.Default)
+	m := s.CorrelationMatrix
+	for i := range m.Currencies {
+		for j := i + 1; j < len(m.Currencies); j++ {
+			log.Infof(common.Statistics, "%v %v %v and %v %v %v correlation: %s",
+				m.Currencies[i].Exchange, m.Currencies[i].Asset, m.Currencies[i].Pair,
+				m.Currencies[j].Exchange, m.Currencies[j].Asset, m.Currencies[j].Pair,
+				convert.DecimalToHumanFriendlyString(m.Coefficients[i][j], 4, ".", ","))
+		}
+	}
+}
+
+// PrintReasonSummary outputs the most frequent
+// reasons appended to events across the run
+func (s *Statistic) PrintReasonSummary() {
+	reasons := topReasons(s.ReasonSummary(), limit10)
+	if len(reasons) == 0 {
+		return
+	}
+	log.Info(common.Statistics, common.CMDColours.H3+"------------------Top Reasons--------------------------------"+common.CMDColours.Default)
+	for i := range reasons {
+		log.Infof(common.Statistics, "%v times: %v", convert.IntToHumanFriendlyString(int64(reasons[i].Count), ","), reasons[i].Code)
+	}
+}
+
 // PrintAllEventsChronologically outputs all event details in the CMD
 // rather than separated by exchange, asset and currency pair, it's
 // grouped by time to allow a clearer picture of events
@@ -100,11 +187,12 @@ func (s *Statistic) PrintAllEventsChronologically() {
 						} else {
 							// successful order!
 							colour = common.CMDColours.Success
-							if currencyStatistic.Events[i].FillEvent.IsLiquidated() {
+							if currencyStatistic.Events[i].FillEvent.IsLiquidated() ||
+								currencyStatistic.Events[i].FillEvent.IsStopOut() {
 								colour = common.CMDColours.Error
 							}
 							msg := fmt.Sprintf(colour+
-								"%v %v%v%v| Price: %v\tDirection %v\tOrder placed: Amount: %v\tFee: %v\tTotal: %v",
+								"%v %v%v%v| Price: %v\tDirection %v\tOrder placed: Amount: %v\tFee: %v %v\tTotal: %v",
 								currencyStatistic.Events[i].FillEvent.GetTime().Format(gctcommon.SimpleTimeFormat),
 								fSIL(exch, limit12),
 								fSIL(a.String(), limit10),
@@ -113,6 +201,7 @@ func (s *Statistic) PrintAllEventsChronologically() {
 								currencyStatistic.Events[i].FillEvent.GetDirection(),
 								currencyStatistic.Events[i].FillEvent.GetAmount().Round(8),
 								currencyStatistic.Events[i].FillEvent.GetExchangeFee(),
+								currencyStatistic.Events[i].FillEvent.GetFeeType(),
 								currencyStatistic.Events[i].FillEvent.GetTotal().Round(8))
 							msg = addReason(currencyStatistic.Events[i].FillEvent.GetConcatReasons(), msg)
 							msg += common.CMDColours.Default
@@ -198,6 +287,22 @@ func (c *CurrencyPairStatistic) PrintResults(e string, a asset.Item, p currency.
 	}
 
 	log.Infof(common.CurrencyStatistics, "%s Total orders: %s", sep, convert.IntToHumanFriendlyString(c.TotalOrders, ","))
+	if c.StopOutOrders > 0 {
+		log.Infof(common.CurrencyStatistics, "%s Drawdown stop-out orders: %s", sep, convert.IntToHumanFriendlyString(c.StopOutOrders, ","))
+	}
+	if c.LiquidationCost.IsPositive() {
+		log.Infof(common.CurrencyStatistics, "%s Liquidation penalty cost: %s", sep, convert.DecimalToHumanFriendlyString(c.LiquidationCost, 8, ".", ","))
+	}
+	if c.CapitalCapRejections > 0 {
+		log.Infof(common.CurrencyStatistics, "%s Orders rejected by capital allocation cap: %s", sep, convert.IntToHumanFriendlyString(c.CapitalCapRejections, ","))
+	}
+	if c.CircuitBreakerTriggers > 0 {
+		log.Infof(common.CurrencyStatistics, "%s Losing streak circuit breaker triggers: %s", sep, convert.IntToHumanFriendlyString(c.CircuitBreakerTriggers, ","))
+	}
+	if c.CircuitBreakerRejections > 0 {
+		log.Infof(common.CurrencyStatistics, "%s Orders rejected by losing streak circuit breaker: %s", sep, convert.IntToHumanFriendlyString(c.CircuitBreakerRejections, ","))
+	}
+	log.Infof(common.CurrencyStatistics, "%s Time in market: %s%% of candles for %v", sep, convert.DecimalToHumanFriendlyString(c.TimeInMarket.InMarketPercent, 2, ".", ","), c.TimeInMarket.InMarketDuration)
 
 	log.Info(common.CurrencyStatistics, common.CMDColours.H2+"------------------Max Drawdown-------------------------------"+common.CMDColours.Default)
 	log.Infof(common.CurrencyStatistics, "%s Highest Price of drawdown: %s at %v", sep, convert.DecimalToHumanFriendlyString(c.MaxDrawdown.Highest.Value, 8, ".", ","), c.MaxDrawdown.Highest.Time)
@@ -228,6 +333,218 @@ func (c *CurrencyPairStatistic) PrintResults(e string, a asset.Item, p currency.
 		log.Infof(common.CurrencyStatistics, "%s Sortino ratio: %v", sep, c.GeometricRatios.SortinoRatio.Round(4))
 		log.Infof(common.CurrencyStatistics, "%s Information ratio: %v", sep, c.GeometricRatios.InformationRatio.Round(4))
 		log.Infof(common.CurrencyStatistics, "%s Calmar ratio: %v", sep, c.GeometricRatios.CalmarRatio.Round(4))
+
+		if c.TailRisk != nil {
+			log.Info(common.CurrencyStatistics, common.CMDColours.H4+"------------------Tail Risk--------------------------------------------"+common.CMDColours.Default)
+			for i := range c.TailRisk.ValueAtRisk {
+				v := c.TailRisk.ValueAtRisk[i]
+				log.Infof(common.CurrencyStatistics, "%s %v%% value at risk: %v%% conditional value at risk: %v%%%s", sep, v.ConfidenceLevel, v.ValueAtRisk.Round(4), v.ConditionalValueAtRisk.Round(4), insufficientDataWarning(v.InsufficientData))
+			}
+			log.Infof(common.CurrencyStatistics, "%s Tail ratio: %v%s", sep, c.TailRisk.TailRatio.Round(4), insufficientDataWarning(c.TailRisk.TailRatioInsufficientData))
+		}
+
+		if c.Autocorrelation != nil {
+			log.Info(common.CurrencyStatistics, common.CMDColours.H4+"------------------Autocorrelation--------------------------------------"+common.CMDColours.Default)
+			log.Infof(common.CurrencyStatistics, "%s Significance bound: +/-%v", sep, c.Autocorrelation.SignificanceBound.Round(4))
+			for i := range c.Autocorrelation.Lags {
+				l := c.Autocorrelation.Lags[i]
+				var caveat string
+				if l.Lag == 1 && c.Autocorrelation.HighAutocorrelation {
+					caveat = " (significant, returns may be smoothed or trending and the Sharpe ratio overstated)"
+				}
+				log.Infof(common.CurrencyStatistics, "%s Lag %v autocorrelation: %v%s", sep, l.Lag, l.Coefficient.Round(4), caveat)
+			}
+		}
+
+		if len(c.VolatilityRegimes) > 0 {
+			log.Info(common.CurrencyStatistics, common.CMDColours.H2+"------------------Volatility Regimes------------------------------------"+common.CMDColours.Default)
+			for i := range c.VolatilityRegimes {
+				r := c.VolatilityRegimes[i]
+				log.Infof(common.CurrencyStatistics, "%s Regime %v volatility: %v - %v", sep, r.Regime, r.LowerVolatility.Round(8), r.UpperVolatility.Round(8))
+				log.Infof(common.CurrencyStatistics, "%s Regime %v candles: %s", sep, r.Regime, convert.IntToHumanFriendlyString(r.Candles, ","))
+				log.Infof(common.CurrencyStatistics, "%s Regime %v strategy return: %s%%", sep, r.Regime, convert.DecimalToHumanFriendlyString(r.StrategyReturn, 2, ".", ","))
+				log.Infof(common.CurrencyStatistics, "%s Regime %v market return: %s%%", sep, r.Regime, convert.DecimalToHumanFriendlyString(r.MarketReturn, 2, ".", ","))
+				log.Infof(common.CurrencyStatistics, "%s Regime %v sharpe ratio: %v", sep, r.Regime, r.SharpeRatio.Round(4))
+			}
+		}
+	}
+
+	if c.Latency != nil {
+		log.Info(common.CurrencyStatistics, common.CMDColours.H2+"------------------Latency------------------------------------"+common.CMDColours.Default)
+		log.Infof(common.CurrencyStatistics, "%s Orders: %s", sep, convert.IntToHumanFriendlyString(c.Latency.Orders, ","))
+		log.Infof(common.CurrencyStatistics, "%s Minimum latency: %v", sep, c.Latency.Minimum)
+		log.Infof(common.CurrencyStatistics, "%s Maximum latency: %v", sep, c.Latency.Maximum)
+		log.Infof(common.CurrencyStatistics, "%s Mean latency: %v", sep, c.Latency.Mean)
+		log.Infof(common.CurrencyStatistics, "%s Median latency: %v", sep, c.Latency.Median)
+		log.Infof(common.CurrencyStatistics, "%s 95th percentile latency: %v", sep, c.Latency.Percentile95)
+	}
+
+	if c.EffectiveSpread != nil {
+		log.Info(common.CurrencyStatistics, common.CMDColours.H2+"------------------Effective Spread---------------------------"+common.CMDColours.Default)
+		log.Infof(common.CurrencyStatistics, "%s Fills: %s", sep, convert.IntToHumanFriendlyString(c.EffectiveSpread.Fills, ","))
+		log.Infof(common.CurrencyStatistics, "%s Average effective spread: %v%%", sep, c.EffectiveSpread.Average.Round(4))
+		log.Infof(common.CurrencyStatistics, "%s Worst effective spread: %v%% at %v", sep, c.EffectiveSpread.Worst.Value.Round(4), c.EffectiveSpread.Worst.Time)
+	}
+
+	if c.FillRatios != nil {
+		log.Info(common.CurrencyStatistics, common.CMDColours.H2+"------------------Fill Ratios--------------------------------"+common.CMDColours.Default)
+		log.Infof(common.CurrencyStatistics, "%s Orders: %s", sep, convert.IntToHumanFriendlyString(c.FillRatios.Orders, ","))
+		log.Infof(common.CurrencyStatistics, "%s Full fills: %s", sep, convert.IntToHumanFriendlyString(c.FillRatios.FullFills, ","))
+		log.Infof(common.CurrencyStatistics, "%s Partial fills: %s", sep, convert.IntToHumanFriendlyString(c.FillRatios.PartialFills, ","))
+		log.Infof(common.CurrencyStatistics, "%s Average fill ratio: %v", sep, c.FillRatios.Average.Round(4))
+		log.Infof(common.CurrencyStatistics, "%s Median fill ratio: %v", sep, c.FillRatios.Median.Round(4))
+		log.Infof(common.CurrencyStatistics, "%s Minimum fill ratio: %v", sep, c.FillRatios.Minimum.Round(4))
+		for i := range c.FillRatios.Distribution {
+			log.Infof(common.CurrencyStatistics, "%s Partial fills filling %v to %v: %s", sep, c.FillRatios.Distribution[i].LowerBound, c.FillRatios.Distribution[i].UpperBound, convert.IntToHumanFriendlyString(c.FillRatios.Distribution[i].Orders, ","))
+		}
+	}
+
+	if c.Funnel.Signals > 0 {
+		log.Info(common.CurrencyStatistics, common.CMDColours.H2+"------------------Signal Conversion------------------------------------"+common.CMDColours.Default)
+		log.Infof(common.CurrencyStatistics, "%s Signals: %s", sep, convert.IntToHumanFriendlyString(c.Funnel.Signals, ","))
+		log.Infof(common.CurrencyStatistics, "%s Orders: %s", sep, convert.IntToHumanFriendlyString(c.Funnel.Orders, ","))
+		log.Infof(common.CurrencyStatistics, "%s Fills: %s", sep, convert.IntToHumanFriendlyString(c.Funnel.Fills, ","))
+		log.Infof(common.CurrencyStatistics, "%s Rejected: %s", sep, convert.IntToHumanFriendlyString(c.Funnel.Rejected, ","))
+		conversion := decimal.NewFromInt(c.Funnel.Fills).Div(decimal.NewFromInt(c.Funnel.Signals)).Mul(decimal.NewFromInt(100))
+		log.Infof(common.CurrencyStatistics, "%s Signal to fill conversion: %s%%", sep, convert.DecimalToHumanFriendlyString(conversion, 2, ".", ","))
+	}
+
+	if len(c.NoTradeReasons) > 0 {
+		log.Info(common.CurrencyStatistics, common.CMDColours.H2+"------------------No Trade Reasons------------------------------------"+common.CMDColours.Default)
+		for _, stage := range []string{noTradeStageData, noTradeStageSignal, noTradeStageOrder, noTradeStageFill} {
+			var count int64
+			for i := range c.NoTradeReasons {
+				if c.NoTradeReasons[i].Stage == stage {
+					count++
+				}
+			}
+			if count > 0 {
+				log.Infof(common.CurrencyStatistics, "%s Candles without a trade at %v stage: %s", sep, stage, convert.IntToHumanFriendlyString(count, ","))
+			}
+		}
+	}
+
+	if len(c.TagStatistics) > 0 {
+		log.Info(common.CurrencyStatistics, common.CMDColours.H2+"------------------Tags------------------------------------"+common.CMDColours.Default)
+		for i := range c.TagStatistics {
+			ts := c.TagStatistics[i]
+			tag := ts.Tag
+			if tag == "" {
+				tag = "untagged"
+			}
+			log.Infof(common.CurrencyStatistics, "%s Tag %q orders: %s buy: %s sell: %s", sep, tag,
+				convert.IntToHumanFriendlyString(ts.Orders, ","),
+				convert.IntToHumanFriendlyString(ts.BuyOrders, ","),
+				convert.IntToHumanFriendlyString(ts.SellOrders, ","))
+			log.Infof(common.CurrencyStatistics, "%s Tag %q fees: %s position: %s PNL: %s", sep, tag,
+				convert.DecimalToHumanFriendlyString(ts.TotalFees, 8, ".", ","),
+				convert.DecimalToHumanFriendlyString(ts.Position, 8, ".", ","),
+				convert.DecimalToHumanFriendlyString(ts.PNL, 8, ".", ","))
+			if ts.AllocationPercent.IsPositive() {
+				log.Infof(common.CurrencyStatistics, "%s Tag %q allocation: %s%% capital: %s equity: %s return: %s%%", sep, tag,
+					convert.DecimalToHumanFriendlyString(ts.AllocationPercent, 2, ".", ","),
+					convert.DecimalToHumanFriendlyString(ts.AllocatedCapital, 8, ".", ","),
+					convert.DecimalToHumanFriendlyString(ts.Equity, 8, ".", ","),
+					convert.DecimalToHumanFriendlyString(ts.ReturnPercent, 2, ".", ","))
+			}
+		}
+	}
+
+	if len(c.AccountStatistics) > 0 {
+		log.Info(common.CurrencyStatistics, common.CMDColours.H2+"------------------Accounts--------------------------------"+common.CMDColours.Default)
+		accounts := make([]AccountStatistic, 0, len(c.AccountStatistics)+1)
+		accounts = append(accounts, c.AccountStatistics...)
+		accounts = append(accounts, *c.AggregateAccount)
+		for i := range accounts {
+			as := accounts[i]
+			account := as.Account
+			switch {
+			case i == len(accounts)-1:
+				account = "aggregate"
+			case account == "":
+				account = "unallocated"
+			}
+			log.Infof(common.CurrencyStatistics, "%s Account %q allocation: %s%% orders: %s fees: %s", sep, account,
+				convert.DecimalToHumanFriendlyString(as.AllocationPercent, 2, ".", ","),
+				convert.IntToHumanFriendlyString(as.Orders, ","),
+				convert.DecimalToHumanFriendlyString(as.TotalFees, 8, ".", ","))
+			log.Infof(common.CurrencyStatistics, "%s Account %q equity: %s to %s return: %s%% max drawdown: %s%%", sep, account,
+				convert.DecimalToHumanFriendlyString(as.InitialEquity, 8, ".", ","),
+				convert.DecimalToHumanFriendlyString(as.FinalEquity, 8, ".", ","),
+				convert.DecimalToHumanFriendlyString(as.ReturnPercent, 2, ".", ","),
+				convert.DecimalToHumanFriendlyString(as.MaxDrawdownPercent, 2, ".", ","))
+		}
+	}
+
+	if c.KellyCriterion != nil {
+		k := c.KellyCriterion
+		log.Info(common.CurrencyStatistics, common.CMDColours.H2+"------------------Kelly Criterion------------------------------------"+common.CMDColours.Default)
+		log.Infof(common.CurrencyStatistics, "%s Round trips: %s wins: %s losses: %s win rate: %s%%", sep,
+			convert.IntToHumanFriendlyString(k.RoundTrips, ","),
+			convert.IntToHumanFriendlyString(k.Wins, ","),
+			convert.IntToHumanFriendlyString(k.Losses, ","),
+			convert.DecimalToHumanFriendlyString(k.WinRate.Mul(decimal.NewFromInt(100)), 2, ".", ","))
+		log.Infof(common.CurrencyStatistics, "%s Average win: %s average loss: %s win/loss ratio: %s", sep,
+			convert.DecimalToHumanFriendlyString(k.AverageWin, 8, ".", ","),
+			convert.DecimalToHumanFriendlyString(k.AverageLoss, 8, ".", ","),
+			convert.DecimalToHumanFriendlyString(k.WinLossRatio.Round(4), 4, ".", ","))
+		if k.HasEdge {
+			log.Infof(common.CurrencyStatistics, "%s Full Kelly: %s%% half Kelly: %s%% quarter Kelly: %s%%", sep,
+				convert.DecimalToHumanFriendlyString(k.FullKelly.Mul(decimal.NewFromInt(100)), 2, ".", ","),
+				convert.DecimalToHumanFriendlyString(k.HalfKelly.Mul(decimal.NewFromInt(100)), 2, ".", ","),
+				convert.DecimalToHumanFriendlyString(k.QuarterKelly.Mul(decimal.NewFromInt(100)), 2, ".", ","))
+		} else {
+			log.Infof(common.CurrencyStatistics, "%s Full Kelly: %s%%, the strategy had no edge and should not be sized up", sep,
+				convert.DecimalToHumanFriendlyString(k.FullKelly.Mul(decimal.NewFromInt(100)), 2, ".", ","))
+		}
+		log.Infof(common.CurrencyStatistics, "%s Kelly fractions are derived in-sample from this run's trades and overstate the edge likely to persist, size below them", sep)
+	}
+
+	if c.Capacity != nil {
+		log.Info(common.CurrencyStatistics, common.CMDColours.H2+"------------------Capacity------------------------------------"+common.CMDColours.Default)
+		log.Infof(common.CurrencyStatistics, "%s Median volume participation: %s%% maximum: %s%% across %s fills", sep,
+			convert.DecimalToHumanFriendlyString(c.Capacity.MedianParticipationPercent.Round(4), 4, ".", ","),
+			convert.DecimalToHumanFriendlyString(c.Capacity.MaximumParticipationPercent.Round(4), 4, ".", ","),
+			convert.IntToHumanFriendlyString(c.Capacity.Fills, ","))
+		log.Infof(common.CurrencyStatistics, "%s Estimated capacity at %s%% participation: %s conservative: %s from starting capital of %s", sep,
+			convert.DecimalToHumanFriendlyString(c.Capacity.TargetParticipationPercent, 4, ".", ","),
+			convert.DecimalToHumanFriendlyString(c.Capacity.EstimatedCapacity, 2, ".", ","),
+			convert.DecimalToHumanFriendlyString(c.Capacity.ConservativeCapacity, 2, ".", ","),
+			convert.DecimalToHumanFriendlyString(c.Capacity.InitialCapital, 2, ".", ","))
+	}
+
+	if len(c.WashTrades) > 0 {
+		log.Info(common.CurrencyStatistics, common.CMDColours.H2+"------------------Wash Trades------------------------------------"+common.CMDColours.Default)
+		log.Infof(common.CurrencyStatistics, "%s Flagged wash trades: %s", sep, convert.IntToHumanFriendlyString(int64(len(c.WashTrades)), ","))
+		for i := range c.WashTrades {
+			w := c.WashTrades[i]
+			log.Infof(common.CurrencyStatistics, "%s %s %s opened at %v for %s and closed at %v for %s after %v, price difference: %s%%",
+				sep,
+				w.Side,
+				convert.DecimalToHumanFriendlyString(w.Size, 8, ".", ","),
+				w.EntryTime,
+				convert.DecimalToHumanFriendlyString(w.EntryPrice, 8, ".", ","),
+				w.ExitTime,
+				convert.DecimalToHumanFriendlyString(w.ExitPrice, 8, ".", ","),
+				w.HoldDuration,
+				convert.DecimalToHumanFriendlyString(w.PriceDifferencePercent.Round(4), 4, ".", ","))
+		}
+	}
+
+	if len(c.TaxLotDisposals) > 0 {
+		log.Info(common.CurrencyStatistics, common.CMDColours.H2+"------------------Tax Lot Disposals------------------------------------"+common.CMDColours.Default)
+		for i := range c.TaxLotDisposals {
+			d := c.TaxLotDisposals[i]
+			log.Infof(common.CurrencyStatistics, "%s Disposed %s at %v acquired at %v, cost basis: %s proceeds: %s gain: %s",
+				sep,
+				convert.DecimalToHumanFriendlyString(d.Amount, 8, ".", ","),
+				d.DisposalTime,
+				d.AcquisitionTime,
+				convert.DecimalToHumanFriendlyString(d.CostBasis, 8, ".", ","),
+				convert.DecimalToHumanFriendlyString(d.Proceeds, 8, ".", ","),
+				convert.DecimalToHumanFriendlyString(d.Gain, 8, ".", ","))
+		}
+		log.Infof(common.CurrencyStatistics, "%s Tax lot realised gain: %s", sep, convert.DecimalToHumanFriendlyString(c.TaxLotRealisedGain, 8, ".", ","))
 	}
 
 	log.Info(common.CurrencyStatistics, common.CMDColours.H2+"------------------Results------------------------------------"+common.CMDColours.Default)
@@ -240,12 +557,29 @@ func (c *CurrencyPairStatistic) PrintResults(e string, a asset.Item, p currency.
 	if !usingExchangeLevelFunding {
 		log.Infof(common.CurrencyStatistics, "%s Strategy movement: %s%%", sep, convert.DecimalToHumanFriendlyString(c.StrategyMovement, 2, ".", ","))
 		log.Infof(common.CurrencyStatistics, "%s Did it beat the market: %v", sep, c.StrategyMovement.GreaterThan(c.MarketMovement))
+		if c.GrossNetReturns != nil {
+			log.Infof(common.CurrencyStatistics, "%s Gross return before fees and slippage: %s%% net return: %s%%", sep,
+				convert.DecimalToHumanFriendlyString(c.GrossNetReturns.GrossReturn, 2, ".", ","),
+				convert.DecimalToHumanFriendlyString(c.GrossNetReturns.NetReturn, 2, ".", ","))
+			log.Infof(common.CurrencyStatistics, "%s Execution drag from fees: %s%% slippage: %s%%", sep,
+				convert.DecimalToHumanFriendlyString(c.GrossNetReturns.FeeDrag, 2, ".", ","),
+				convert.DecimalToHumanFriendlyString(c.GrossNetReturns.SlippageDrag, 2, ".", ","))
+		}
 	}
 
 	log.Infof(common.CurrencyStatistics, "%s Value lost to volume sizing: %s", sep, convert.DecimalToHumanFriendlyString(c.TotalValueLostToVolumeSizing, 2, ".", ","))
 	log.Infof(common.CurrencyStatistics, "%s Value lost to slippage: %s", sep, convert.DecimalToHumanFriendlyString(c.TotalValueLostToSlippage, 2, ".", ","))
 	log.Infof(common.CurrencyStatistics, "%s Total Value lost: %s", sep, convert.DecimalToHumanFriendlyString(c.TotalValueLost, 2, ".", ","))
 	log.Infof(common.CurrencyStatistics, "%s Total Fees: %s", sep, convert.DecimalToHumanFriendlyString(c.TotalFees, 8, ".", ","))
+	if !c.EffectiveFeeRate.IsZero() {
+		log.Infof(common.CurrencyStatistics, "%s Effective fee rate: %v", sep, c.EffectiveFeeRate.Round(8))
+	}
+	if c.AccumulatedRebates.IsPositive() {
+		log.Infof(common.CurrencyStatistics, "%s Accumulated rebates: %s", sep, convert.DecimalToHumanFriendlyString(c.AccumulatedRebates, 8, ".", ","))
+	}
+	if c.SubmissionRetries > 0 {
+		log.Infof(common.CurrencyStatistics, "%s Order submission retries: %v", sep, c.SubmissionRetries)
+	}
 	log.Infof(common.CurrencyStatistics, "%s Final holdings value: %s", sep, convert.DecimalToHumanFriendlyString(c.TotalAssetValue, 8, ".", ","))
 	if !usingExchangeLevelFunding {
 		// the following have no direct translation to individual exchange level funds as they
@@ -253,6 +587,10 @@ func (c *CurrencyPairStatistic) PrintResults(e string, a asset.Item, p currency.
 		log.Infof(common.CurrencyStatistics, "%s Final funds: %s", sep, convert.DecimalToHumanFriendlyString(last.Holdings.QuoteSize, 8, ".", ","))
 		log.Infof(common.CurrencyStatistics, "%s Final holdings: %s", sep, convert.DecimalToHumanFriendlyString(last.Holdings.BaseSize, 8, ".", ","))
 		log.Infof(common.CurrencyStatistics, "%s Final total value: %s", sep, convert.DecimalToHumanFriendlyString(last.Holdings.TotalValue, 8, ".", ","))
+		if last.Holdings.AverageEntryPrice.IsPositive() {
+			log.Infof(common.CurrencyStatistics, "%s Final average entry price: %s", sep, convert.DecimalToHumanFriendlyString(last.Holdings.AverageEntryPrice, 8, ".", ","))
+			log.Infof(common.CurrencyStatistics, "%s Final Unrealised PNL: %s", sep, convert.DecimalToHumanFriendlyString(last.Holdings.UnrealisedPNL, 8, ".", ","))
+		}
 	}
 
 	if last.PNL != nil {
@@ -261,6 +599,12 @@ func (c *CurrencyPairStatistic) PrintResults(e string, a asset.Item, p currency.
 		realised = last.PNL.GetRealisedPNL()
 		log.Infof(common.CurrencyStatistics, "%s Final Unrealised PNL: %s", sep, convert.DecimalToHumanFriendlyString(unrealised.PNL, 8, ".", ","))
 		log.Infof(common.CurrencyStatistics, "%s Final Realised PNL: %s", sep, convert.DecimalToHumanFriendlyString(realised.PNL, 8, ".", ","))
+		if borrowCost := last.PNL.GetBorrowCost(); borrowCost.IsPositive() {
+			log.Infof(common.CurrencyStatistics, "%s Accrued short borrow cost: %s", sep, convert.DecimalToHumanFriendlyString(borrowCost, 8, ".", ","))
+		}
+		if fundingCost := last.PNL.GetFundingCost(); !fundingCost.IsZero() {
+			log.Infof(common.CurrencyStatistics, "%s Net funding rate payments: %s", sep, convert.DecimalToHumanFriendlyString(fundingCost, 8, ".", ","))
+		}
 	}
 	if len(errs) > 0 {
 		log.Info(common.CurrencyStatistics, common.CMDColours.Error+"------------------Errors-------------------------------------"+common.CMDColours.Default)
@@ -295,6 +639,9 @@ func (f *FundingStatistics) PrintResults(wasAnyDataMissing bool) error {
 			}
 			log.Infof(common.FundingStatistics, "%s Initial funds: %s", sep, convert.DecimalToHumanFriendlyString(spotResults[i].ReportItem.InitialFunds, 8, ".", ","))
 			log.Infof(common.FundingStatistics, "%s Final funds: %s", sep, convert.DecimalToHumanFriendlyString(spotResults[i].ReportItem.FinalFunds, 8, ".", ","))
+			if !spotResults[i].ReportItem.NetCapitalFlow.IsZero() {
+				log.Infof(common.FundingStatistics, "%s Net capital flow: %s", sep, convert.DecimalToHumanFriendlyString(spotResults[i].ReportItem.NetCapitalFlow, 8, ".", ","))
+			}
 
 			if !f.Report.DisableUSDTracking && f.Report.UsingExchangeLevelFunding {
 				log.Infof(common.FundingStatistics, "%s Initial funds in USD: $%s", sep, convert.DecimalToHumanFriendlyString(spotResults[i].ReportItem.USDInitialFunds, 2, ".", ","))
@@ -345,12 +692,28 @@ func (f *FundingStatistics) PrintResults(wasAnyDataMissing bool) error {
 
 	log.Infof(common.FundingStatistics, "%s Initial value: $%s", sep, convert.DecimalToHumanFriendlyString(f.Report.InitialFunds, 8, ".", ","))
 	log.Infof(common.FundingStatistics, "%s Final value: $%s", sep, convert.DecimalToHumanFriendlyString(f.Report.FinalFunds, 8, ".", ","))
+	if len(f.Report.CapitalFlows) > 0 {
+		log.Infof(common.FundingStatistics, "%s Net capital flows: $%s", sep, convert.DecimalToHumanFriendlyString(f.TotalUSDStatistics.NetCapitalFlows, 8, ".", ","))
+		log.Infof(common.FundingStatistics, "%s Strategy movement is time weighted, excluding capital flows", sep)
+	}
 	log.Infof(common.FundingStatistics, "%s Benchmark Market Movement: %s%%", sep, convert.DecimalToHumanFriendlyString(f.TotalUSDStatistics.BenchmarkMarketMovement, 8, ".", ","))
 	log.Infof(common.FundingStatistics, "%s Strategy Movement: %s%%", sep, convert.DecimalToHumanFriendlyString(f.TotalUSDStatistics.StrategyMovement, 8, ".", ","))
 	log.Infof(common.FundingStatistics, "%s Did strategy make a profit: %v", sep, f.TotalUSDStatistics.DidStrategyMakeProfit)
 	log.Infof(common.FundingStatistics, "%s Did strategy beat the benchmark: %v", sep, f.TotalUSDStatistics.DidStrategyBeatTheMarket)
 	log.Infof(common.FundingStatistics, "%s Highest funds: $%s at %v", sep, convert.DecimalToHumanFriendlyString(f.TotalUSDStatistics.HighestHoldingValue.Value, 8, ".", ","), f.TotalUSDStatistics.HighestHoldingValue.Time)
 	log.Infof(common.FundingStatistics, "%s Lowest funds: $%s at %v", sep, convert.DecimalToHumanFriendlyString(f.TotalUSDStatistics.LowestHoldingValue.Value, 8, ".", ","), f.TotalUSDStatistics.LowestHoldingValue.Time)
+	log.Infof(common.FundingStatistics, "%s Average cash utilisation: %s%%", sep, convert.DecimalToHumanFriendlyString(f.TotalUSDStatistics.AverageCashUtilisation, 2, ".", ","))
+	log.Infof(common.FundingStatistics, "%s Final open positions: $%s", sep, convert.DecimalToHumanFriendlyString(f.TotalUSDStatistics.FinalPositionsValue, 8, ".", ","))
+	log.Infof(common.FundingStatistics, "%s Final idle cash: $%s", sep, convert.DecimalToHumanFriendlyString(f.TotalUSDStatistics.FinalCashValue, 8, ".", ","))
+	log.Infof(common.FundingStatistics, "%s Final cash utilisation: %s%%", sep, convert.DecimalToHumanFriendlyString(f.TotalUSDStatistics.FinalCashUtilisation, 2, ".", ","))
+	if pf := f.TotalUSDStatistics.PerformanceFee; pf != nil {
+		log.Info(common.FundingStatistics, common.CMDColours.H3+"------------------Performance Fee---------------------------------------"+common.CMDColours.Default)
+		log.Infof(common.FundingStatistics, "%s Performance fee rate: %s%%", sep, convert.DecimalToHumanFriendlyString(pf.RatePercent, 2, ".", ","))
+		log.Infof(common.FundingStatistics, "%s Performance fees charged: $%s over %v charges", sep, convert.DecimalToHumanFriendlyString(pf.TotalFees, 8, ".", ","), len(pf.Charges))
+		log.Infof(common.FundingStatistics, "%s Final high-water mark: $%s", sep, convert.DecimalToHumanFriendlyString(pf.FinalHighWaterMark, 8, ".", ","))
+		log.Infof(common.FundingStatistics, "%s Gross strategy movement: %s%%", sep, convert.DecimalToHumanFriendlyString(pf.GrossStrategyMovement, 8, ".", ","))
+		log.Infof(common.FundingStatistics, "%s Net of performance fee strategy movement: %s%%", sep, convert.DecimalToHumanFriendlyString(pf.NetStrategyMovement, 8, ".", ","))
+	}
 
 	log.Info(common.FundingStatistics, common.CMDColours.H3+"------------------Ratios------------------------------------------------"+common.CMDColours.Default)
 	log.Info(common.FundingStatistics, common.CMDColours.H4+"------------------Rates-------------------------------------------------"+common.CMDColours.Default)
@@ -0,0 +1,94 @@
+package statistics
+
+import (
+	"regexp"
+	"sort"
+
+	"github.com/thrasher-corp/gocryptotrader/backtester/common"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/event"
+)
+
+// reasonCodePlaceholder replaces the values formatted into a reason
+const reasonCodePlaceholder = "#"
+
+// reasonValues matches the numeric values formatted into a reason such as
+// amounts, prices and percentages so reasons can be grouped by their code
+var reasonValues = regexp.MustCompile(`-?\d+(\.\d+)?`)
+
+// ReasonCount is how often a reason code occurred across a run
+type ReasonCount struct {
+	Code  string `json:"code"`
+	Count int    `json:"count"`
+}
+
+// reasonCode returns the reason with its formatted values replaced, eg
+// "Order size shrunk from 2 to 1.5 to fit candle" is coded as
+// "Order size shrunk from # to # to fit candle"
+func reasonCode(reason string) string {
+	return reasonValues.ReplaceAllString(reason, reasonCodePlaceholder)
+}
+
+// ReasonSummary tallies how often each reason code was appended to events
+// across every exchange, asset and pair in the run. Events along the data,
+// signal, order and fill chain which share their reasoning are only counted once
+func (s *Statistic) ReasonSummary() map[string]int {
+	summary := make(map[string]int)
+	for _, exchangeMap := range s.ExchangeAssetPairStatistics {
+		for _, assetMap := range exchangeMap {
+			for _, stats := range assetMap {
+				for i := range stats.Events {
+					seen := make(map[*event.Base]bool)
+					for _, ev := range stats.Events[i].eventChain() {
+						b := ev.GetBase()
+						if b == nil || seen[b] {
+							continue
+						}
+						seen[b] = true
+						for _, reason := range b.Reasons {
+							summary[reasonCode(reason)]++
+						}
+					}
+				}
+			}
+		}
+	}
+	return summary
+}
+
+// topReasons returns the most frequent reason codes, ordered by
+// their count and then code, limited to the amount requested
+func topReasons(summary map[string]int, limit int) []ReasonCount {
+	counts := make([]ReasonCount, 0, len(summary))
+	for code, count := range summary {
+		counts = append(counts, ReasonCount{Code: code, Count: count})
+	}
+	sort.Slice(counts, func(i, j int) bool {
+		if counts[i].Count != counts[j].Count {
+			return counts[i].Count > counts[j].Count
+		}
+		return counts[i].Code < counts[j].Code
+	})
+	if limit > 0 && len(counts) > limit {
+		counts = counts[:limit]
+	}
+	return counts
+}
+
+// eventChain returns the events set at the offset
+// in the order they are processed
+func (d *DataAtOffset) eventChain() []common.EventHandler {
+	var chain []common.EventHandler
+	if d.DataEvent != nil {
+		chain = append(chain, d.DataEvent)
+	}
+	if d.SignalEvent != nil {
+		chain = append(chain, d.SignalEvent)
+	}
+	if d.OrderEvent != nil {
+		chain = append(chain, d.OrderEvent)
+	}
+	if d.FillEvent != nil {
+		chain = append(chain, d.FillEvent)
+	}
+	return chain
+}
@@ -0,0 +1,82 @@
+package statistics
+
+import (
+	"testing"
+
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/event"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/fill"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/kline"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/order"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/signal"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+)
+
+func TestReasonCode(t *testing.T) {
+	t.Parallel()
+	code := reasonCode("Order size shrunk from 2 to 1.5 to fit candle")
+	if code != "Order size shrunk from # to # to fit candle" {
+		t.Errorf("received '%v' expected '%v'", code, "Order size shrunk from # to # to fit candle")
+	}
+	code = reasonCode("Slippage capped at -5%")
+	if code != "Slippage capped at #%" {
+		t.Errorf("received '%v' expected '%v'", code, "Slippage capped at #%")
+	}
+}
+
+func TestReasonSummary(t *testing.T) {
+	t.Parallel()
+	s := Statistic{}
+	if summary := s.ReasonSummary(); len(summary) != 0 {
+		t.Errorf("received '%v' expected '%v'", len(summary), 0)
+	}
+	shared := &event.Base{Reasons: []string{"Price has slipped from 100 to 101", "Order size shrunk from 2 to 1 to fit candle"}}
+	slipped := &event.Base{Reasons: []string{"Price has slipped from 50 to 49"}}
+	p := currency.NewPair(currency.BTC, currency.USDT)
+	s.ExchangeAssetPairStatistics = map[string]map[asset.Item]map[currency.Pair]*CurrencyPairStatistic{
+		testExchange: {
+			asset.Spot: {
+				p: {
+					Events: []DataAtOffset{
+						{
+							DataEvent:   &kline.Kline{Base: shared},
+							SignalEvent: &signal.Signal{Base: shared},
+							OrderEvent:  &order.Order{Base: shared},
+							FillEvent:   &fill.Fill{Base: shared},
+						},
+						{
+							DataEvent: &kline.Kline{Base: slipped},
+						},
+					},
+				},
+			},
+		},
+	}
+	summary := s.ReasonSummary()
+	if len(summary) != 2 {
+		t.Fatalf("received '%v' expected '%v'", len(summary), 2)
+	}
+	if summary["Price has slipped from # to #"] != 2 {
+		t.Errorf("received '%v' expected '%v'", summary["Price has slipped from # to #"], 2)
+	}
+	if summary["Order size shrunk from # to # to fit candle"] != 1 {
+		t.Errorf("received '%v' expected '%v'", summary["Order size shrunk from # to # to fit candle"], 1)
+	}
+}
+
+func TestTopReasons(t *testing.T) {
+	t.Parallel()
+	reasons := topReasons(map[string]int{"b": 1, "a": 1, "c": 3}, 2)
+	expected := []ReasonCount{{Code: "c", Count: 3}, {Code: "a", Count: 1}}
+	if len(reasons) != len(expected) {
+		t.Fatalf("received '%v' expected '%v'", len(reasons), len(expected))
+	}
+	for i := range expected {
+		if reasons[i] != expected[i] {
+			t.Errorf("received '%+v' expected '%+v'", reasons[i], expected[i])
+		}
+	}
+	if reasons = topReasons(nil, limit10); len(reasons) != 0 {
+		t.Errorf("received '%v' expected '%v'", len(reasons), 0)
+	}
+}
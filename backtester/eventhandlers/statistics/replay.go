@@ -0,0 +1,189 @@
+package statistics
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/shopspring/decimal"
+	"github.com/thrasher-corp/gocryptotrader/backtester/common"
+	"github.com/thrasher-corp/gocryptotrader/backtester/data/kline"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventhandlers/exchange"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/order"
+	"github.com/thrasher-corp/gocryptotrader/backtester/funding"
+	"github.com/thrasher-corp/gocryptotrader/engine"
+	gctorder "github.com/thrasher-corp/gocryptotrader/exchanges/order"
+)
+
+// ReplayOrders re-executes every recorded order event through the provided
+// execution handler against the candle data recorded at the order's offset.
+// This allows fills and PNL to be recalculated under alternative exchange
+// settings, such as different slippage rates, without rerunning the strategy.
+// The context is passed to each order's execution
+func (s *Statistic) ReplayOrders(ctx context.Context, exch exchange.ExecutionHandler, orderManager *engine.OrderManager) ([]ReplayResult, error) {
+	if exch == nil {
+		return nil, fmt.Errorf("%w execution handler", common.ErrNilArguments)
+	}
+	if orderManager == nil {
+		return nil, fmt.Errorf("%w order manager", common.ErrNilArguments)
+	}
+	if s.ExchangeAssetPairStatistics == nil {
+		return nil, errExchangeAssetPairStatsUnset
+	}
+	currencies := s.sortedCurrencyStatistics()
+	results := make([]ReplayResult, 0, len(currencies))
+	for _, stats := range currencies {
+		result, err := stats.replayOrders(ctx, exch, orderManager)
+		if err != nil {
+			return nil, fmt.Errorf("%v %v %v %w", stats.Exchange, stats.Asset, stats.Currency, err)
+		}
+		results = append(results, *result)
+	}
+	return results, nil
+}
+
+// sortedCurrencyStatistics returns the currency statistics ordered by
+// exchange, asset and currency pair so that replays execute, and consume
+// funding, in the same order every time
+func (s *Statistic) sortedCurrencyStatistics() []*CurrencyPairStatistic {
+	var currencies []*CurrencyPairStatistic
+	for _, exchangeMap := range s.ExchangeAssetPairStatistics {
+		for _, assetMap := range exchangeMap {
+			for _, stats := range assetMap {
+				currencies = append(currencies, stats)
+			}
+		}
+	}
+	sort.Slice(currencies, func(i, j int) bool {
+		a, b := currencies[i], currencies[j]
+		if a.Exchange != b.Exchange {
+			return a.Exchange < b.Exchange
+		}
+		if a.Asset != b.Asset {
+			return a.Asset.String() < b.Asset.String()
+		}
+		return a.Currency.String() < b.Currency.String()
+	})
+	return currencies
+}
+
+// replayOrders replays all order events for a single exchange, asset and pair
+func (c *CurrencyPairStatistic) replayOrders(ctx context.Context, exch exchange.ExecutionHandler, orderManager *engine.OrderManager) (*ReplayResult, error) {
+	result := &ReplayResult{
+		Exchange: c.Exchange,
+		Asset:    c.Asset,
+		Pair:     c.Currency,
+	}
+	if len(c.Events) == 0 {
+		return result, nil
+	}
+	stream := make([]common.DataEventHandler, len(c.Events))
+	for i := range c.Events {
+		if c.Events[i].DataEvent == nil {
+			return nil, fmt.Errorf("%w at offset %v", errReceivedNoData, c.Events[i].Offset)
+		}
+		stream[i] = c.Events[i].DataEvent
+	}
+	d := &kline.DataFromKline{}
+	d.SetStream(stream)
+
+	var cash, holdings decimal.Decimal
+	for i := range c.Events {
+		d.Next()
+		if c.Events[i].OrderEvent == nil || !common.CanTransact(c.Events[i].OrderEvent.GetDirection()) {
+			continue
+		}
+		o := copyOrderEvent(c.Events[i].OrderEvent)
+		funds, err := createReplayFunding(o)
+		if err != nil {
+			return nil, err
+		}
+		f, err := exch.ExecuteOrder(ctx, o, d, orderManager, funds)
+		if f == nil {
+			if err != nil {
+				return nil, err
+			}
+			continue
+		}
+		result.Fills = append(result.Fills, f)
+		if err != nil || f.GetOrder() == nil {
+			continue
+		}
+		value := f.GetPurchasePrice().Mul(f.GetAmount())
+		result.TotalFees = result.TotalFees.Add(f.GetExchangeFee())
+		switch f.GetDirection() {
+		case gctorder.Buy, gctorder.Bid, gctorder.Long:
+			cash = cash.Sub(value).Sub(f.GetExchangeFee())
+			holdings = holdings.Add(f.GetAmount())
+		case gctorder.Sell, gctorder.Ask, gctorder.Short:
+			cash = cash.Add(value).Sub(f.GetExchangeFee())
+			holdings = holdings.Sub(f.GetAmount())
+		}
+	}
+	result.FinalHoldings = holdings
+	result.PNL = cash.Add(holdings.Mul(stream[len(stream)-1].GetClosePrice()))
+	return result, nil
+}
+
+// copyOrderEvent prevents a replay from modifying the recorded order event
+// as the fill event shares the order's base
+func copyOrderEvent(o order.Event) order.Event {
+	ord, ok := o.(*order.Order)
+	if !ok {
+		return o
+	}
+	cpy := *ord
+	if ord.Base != nil {
+		b := *ord.Base
+		b.Reasons = nil
+		cpy.Base = &b
+	}
+	return &cpy
+}
+
+// createReplayFunding creates isolated funding for a single replayed order
+// with the order's allocated funds already reserved
+func createReplayFunding(o order.Event) (funding.IFundReleaser, error) {
+	allocated := o.GetAllocatedFunds()
+	p := o.Pair()
+	if o.GetAssetType().IsFutures() {
+		contract, err := funding.CreateItem(o.GetExchange(), o.GetAssetType(), p.Base, o.GetAmount(), decimal.Zero)
+		if err != nil {
+			return nil, err
+		}
+		collateral, err := funding.CreateItem(o.GetExchange(), o.GetAssetType(), p.Quote, allocated, decimal.Zero)
+		if err != nil {
+			return nil, err
+		}
+		cp, err := funding.CreateCollateral(contract, collateral)
+		if err != nil {
+			return nil, err
+		}
+		if allocated.IsPositive() {
+			err = cp.Reserve(allocated, o.GetDirection())
+			if err != nil {
+				return nil, err
+			}
+		}
+		return cp, nil
+	}
+	base, err := funding.CreateItem(o.GetExchange(), o.GetAssetType(), p.Base, allocated, decimal.Zero)
+	if err != nil {
+		return nil, err
+	}
+	quote, err := funding.CreateItem(o.GetExchange(), o.GetAssetType(), p.Quote, allocated, decimal.Zero)
+	if err != nil {
+		return nil, err
+	}
+	sp, err := funding.CreatePair(base, quote)
+	if err != nil {
+		return nil, err
+	}
+	if allocated.IsPositive() {
+		err = sp.Reserve(allocated, o.GetDirection())
+		if err != nil {
+			return nil, err
+		}
+	}
+	return sp, nil
+}
@@ -0,0 +1,193 @@
+package statistics
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/thrasher-corp/gocryptotrader/backtester/common"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventhandlers/exchange"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/event"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/kline"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/order"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/engine"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	gctkline "github.com/thrasher-corp/gocryptotrader/exchanges/kline"
+	gctorder "github.com/thrasher-corp/gocryptotrader/exchanges/order"
+)
+
+func TestReplayOrders(t *testing.T) {
+	t.Parallel()
+	s := Statistic{}
+	_, err := s.ReplayOrders(context.Background(), nil, nil)
+	if !errors.Is(err, common.ErrNilArguments) {
+		t.Errorf("received '%v' expected '%v'", err, common.ErrNilArguments)
+	}
+	_, err = s.ReplayOrders(context.Background(), &exchange.Exchange{}, nil)
+	if !errors.Is(err, common.ErrNilArguments) {
+		t.Errorf("received '%v' expected '%v'", err, common.ErrNilArguments)
+	}
+
+	em := engine.SetupExchangeManager()
+	exch, err := em.NewExchangeByName(testExchange)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exch.SetDefaults()
+	em.Add(exch)
+	bot := &engine.Engine{}
+	om, err := engine.SetupOrderManager(em, &engine.CommunicationManager{}, &bot.ServicesWG, false, false, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = om.Start()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = s.ReplayOrders(context.Background(), &exchange.Exchange{}, om)
+	if !errors.Is(err, errExchangeAssetPairStatsUnset) {
+		t.Errorf("received '%v' expected '%v'", err, errExchangeAssetPairStatsUnset)
+	}
+
+	a := asset.Spot
+	p := currency.NewPair(currency.BTC, currency.USDT)
+	tt := time.Now()
+	for i := int64(1); i <= 2; i++ {
+		b := &event.Base{
+			Offset:       i,
+			Exchange:     testExchange,
+			Time:         tt.Add(time.Duration(i) * gctkline.OneDay.Duration()),
+			Interval:     gctkline.OneDay,
+			CurrencyPair: p,
+			AssetType:    a,
+		}
+		err = s.SetupEventForTime(&kline.Kline{
+			Base:   b,
+			Open:   eleet,
+			Close:  eleet,
+			Low:    eleeg,
+			High:   eleeb,
+			Volume: eleeet,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	err = s.SetEventForOffset(&order.Order{
+		Base: &event.Base{
+			Offset:       1,
+			Exchange:     testExchange,
+			Time:         tt,
+			Interval:     gctkline.OneDay,
+			CurrencyPair: p,
+			AssetType:    a,
+		},
+		Direction:      gctorder.Buy,
+		Amount:         decimal.NewFromInt(1),
+		ClosePrice:     eleet,
+		AllocatedFunds: eleeet,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cs := exchange.Settings{
+		Exchange:                exch,
+		Pair:                    p,
+		Asset:                   a,
+		MinimumSlippageRate:     decimal.NewFromInt(100),
+		MaximumSlippageRate:     decimal.NewFromInt(100),
+		SkipCandleVolumeFitting: true,
+		SignalToFillConvention:  exchange.SameCandleClose,
+	}
+	e := &exchange.Exchange{}
+	e.SetExchangeAssetCurrencySettings(a, p, &cs)
+	results, err := s.ReplayOrders(context.Background(), e, om)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	if len(results) != 1 {
+		t.Fatalf("received '%v' expected '%v'", len(results), 1)
+	}
+	if len(results[0].Fills) != 1 {
+		t.Fatalf("received '%v' expected '%v'", len(results[0].Fills), 1)
+	}
+	if !results[0].Fills[0].GetPurchasePrice().Equal(eleet) {
+		t.Errorf("received '%v' expected '%v'", results[0].Fills[0].GetPurchasePrice(), eleet)
+	}
+	if !results[0].FinalHoldings.Equal(decimal.NewFromInt(1)) {
+		t.Errorf("received '%v' expected '%v'", results[0].FinalHoldings, 1)
+	}
+	if !results[0].PNL.IsZero() {
+		t.Errorf("received '%v' expected '%v'", results[0].PNL, 0)
+	}
+	recorded := s.ExchangeAssetPairStatistics[testExchange][a][p].Events[0].OrderEvent
+	if len(recorded.GetReasons()) != 0 {
+		t.Errorf("received '%v' expected no reasons on recorded order", recorded.GetReasons())
+	}
+
+	cs.MinimumSlippageRate = decimal.NewFromInt(90)
+	cs.MaximumSlippageRate = decimal.NewFromInt(95)
+	e.SetExchangeAssetCurrencySettings(a, p, &cs)
+	results, err = s.ReplayOrders(context.Background(), e, om)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	if !results[0].PNL.IsNegative() {
+		t.Errorf("received '%v' expected negative PNL from slippage", results[0].PNL)
+	}
+}
+
+func TestSortedCurrencyStatistics(t *testing.T) {
+	t.Parallel()
+	s := Statistic{}
+	tt := time.Now()
+	for _, c := range []struct {
+		exch string
+		a    asset.Item
+		p    currency.Pair
+	}{
+		{"kraken", asset.Spot, currency.NewPair(currency.BTC, currency.USDT)},
+		{testExchange, asset.Spot, currency.NewPair(currency.LTC, currency.USDT)},
+		{testExchange, asset.Futures, currency.NewPair(currency.BTC, currency.USD)},
+		{testExchange, asset.Spot, currency.NewPair(currency.BTC, currency.USDT)},
+	} {
+		err := s.SetupEventForTime(&kline.Kline{
+			Base: &event.Base{
+				Offset:       1,
+				Exchange:     c.exch,
+				Time:         tt,
+				Interval:     gctkline.OneDay,
+				CurrencyPair: c.p,
+				AssetType:    c.a,
+			},
+			Close: eleet,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	expected := []string{
+		testExchange + " futures BTCUSD",
+		testExchange + " spot BTCUSDT",
+		testExchange + " spot LTCUSDT",
+		"kraken spot BTCUSDT",
+	}
+	// map iteration order is random, so repeat to catch an unstable order
+	for i := 0; i < 10; i++ {
+		currencies := s.sortedCurrencyStatistics()
+		if len(currencies) != len(expected) {
+			t.Fatalf("received '%v' expected '%v'", len(currencies), len(expected))
+		}
+		for j := range currencies {
+			received := currencies[j].Exchange + " " + currencies[j].Asset.String() + " " + currencies[j].Currency.String()
+			if received != expected[j] {
+				t.Fatalf("position %v received '%v' expected '%v'", j, received, expected[j])
+			}
+		}
+	}
+}
@@ -0,0 +1,26 @@
+package statistics
+
+// calculateReturnsSeries returns the change in total holdings value of each
+// event aligned with the event's timestamp. The first event is skipped as no
+// movement has been made, matching the returns used in ratio calculations
+func (c *CurrencyPairStatistic) calculateReturnsSeries() []ValueAtTime {
+	if len(c.Events) < 2 {
+		return nil
+	}
+	series := make([]ValueAtTime, 0, len(c.Events)-1)
+	for i := 1; i < len(c.Events); i++ {
+		series = append(series, ValueAtTime{
+			Time:  c.Events[i].Time,
+			Value: c.Events[i].Holdings.ChangeInTotalValuePercent,
+			Set:   true,
+		})
+	}
+	return series
+}
+
+// GetReturnsSeries returns the per event returns series used to calculate
+// the currency's ratios. It is only recorded when the returns series
+// statistic setting is enabled
+func (c *CurrencyPairStatistic) GetReturnsSeries() []ValueAtTime {
+	return c.ReturnsSeries
+}
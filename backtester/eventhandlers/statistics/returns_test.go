@@ -0,0 +1,35 @@
+package statistics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventhandlers/portfolio/holdings"
+)
+
+func TestCalculateReturnsSeries(t *testing.T) {
+	t.Parallel()
+	c := CurrencyPairStatistic{}
+	if series := c.calculateReturnsSeries(); series != nil {
+		t.Errorf("received '%v' expected '%v'", series, nil)
+	}
+	tt := time.Now()
+	for i, r := range []int64{0, 2, -1} {
+		c.Events = append(c.Events, DataAtOffset{
+			Time:     tt.Add(time.Hour * time.Duration(i)),
+			Holdings: holdings.Holding{ChangeInTotalValuePercent: decimal.NewFromInt(r)},
+		})
+	}
+	c.ReturnsSeries = c.calculateReturnsSeries()
+	series := c.GetReturnsSeries()
+	if len(series) != 2 {
+		t.Fatalf("received '%v' expected '%v'", len(series), 2)
+	}
+	if !series[0].Time.Equal(tt.Add(time.Hour)) || !series[0].Value.Equal(decimal.NewFromInt(2)) {
+		t.Errorf("received '%v' '%v' expected '%v' '%v'", series[0].Time, series[0].Value, tt.Add(time.Hour), 2)
+	}
+	if !series[1].Time.Equal(tt.Add(time.Hour*2)) || !series[1].Value.Equal(decimal.NewFromInt(-1)) {
+		t.Errorf("received '%v' '%v' expected '%v' '%v'", series[1].Time, series[1].Value, tt.Add(time.Hour*2), -1)
+	}
+}
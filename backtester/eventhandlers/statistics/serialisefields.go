@@ -0,0 +1,107 @@
+package statistics
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// currencyStatisticsField is the json name of the per currency statistics,
+// which prefixes the per currency fields selected by SerialiseFields
+const currencyStatisticsField = "currency-statistics"
+
+// currencyIdentifierFields are always output with each currency's selected
+// fields so that the values can be attributed to their currency
+var currencyIdentifierFields = []string{"Exchange", "Asset", "Currency"}
+
+// SerialiseFields outputs only the selected fields of the Statistic struct in
+// json. Top level metrics are selected by their json name, eg "total-orders",
+// while per currency fields are prefixed with "currency-statistics.", eg
+// "currency-statistics.sharpe-ratio", and are output for every currency
+// alongside its exchange, asset and currency. Unknown fields return an error
+func (s *Statistic) SerialiseFields(fields []string) (string, error) {
+	if len(fields) == 0 {
+		return "", errNoSerialiseFields
+	}
+	topLevelNames := jsonFieldNames(reflect.TypeOf(Statistic{}))
+	currencyNames := jsonFieldNames(reflect.TypeOf(CurrencyPairStatistic{}))
+	var topLevelFields []string
+	currencyFields := append([]string(nil), currencyIdentifierFields...)
+	var hasCurrencyFields bool
+	for _, field := range fields {
+		if strings.HasPrefix(field, currencyStatisticsField+".") {
+			currencyField := strings.TrimPrefix(field, currencyStatisticsField+".")
+			if !currencyNames[currencyField] {
+				return "", fmt.Errorf("%w '%v'", errInvalidSerialiseField, field)
+			}
+			currencyFields = append(currencyFields, currencyField)
+			hasCurrencyFields = true
+			continue
+		}
+		if !topLevelNames[field] {
+			return "", fmt.Errorf("%w '%v'", errInvalidSerialiseField, field)
+		}
+		topLevelFields = append(topLevelFields, field)
+	}
+
+	full, err := s.Serialise()
+	if err != nil {
+		return "", err
+	}
+	var topLevel map[string]json.RawMessage
+	err = json.Unmarshal([]byte(full), &topLevel)
+	if err != nil {
+		return "", err
+	}
+	selected := make(map[string]interface{})
+	for _, field := range topLevelFields {
+		if value, ok := topLevel[field]; ok {
+			selected[field] = value
+		}
+	}
+	if _, isWhole := selected[currencyStatisticsField]; hasCurrencyFields && !isWhole {
+		var currencies []map[string]json.RawMessage
+		err = json.Unmarshal(topLevel[currencyStatisticsField], &currencies)
+		if err != nil {
+			return "", err
+		}
+		selectedCurrencies := make([]map[string]json.RawMessage, len(currencies))
+		for i := range currencies {
+			selectedCurrencies[i] = make(map[string]json.RawMessage)
+			for _, field := range currencyFields {
+				if value, ok := currencies[i][field]; ok {
+					selectedCurrencies[i][field] = value
+				}
+			}
+		}
+		selected[currencyStatisticsField] = selectedCurrencies
+	}
+
+	resp, err := json.MarshalIndent(selected, "", " ")
+	if err != nil {
+		return "", err
+	}
+	return string(resp), nil
+}
+
+// jsonFieldNames returns the json names of the fields
+// a struct type outputs when serialised
+func jsonFieldNames(t reflect.Type) map[string]bool {
+	names := make(map[string]bool, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		switch name {
+		case "-":
+			continue
+		case "":
+			name = field.Name
+		}
+		names[name] = true
+	}
+	return names
+}
@@ -0,0 +1,87 @@
+package statistics
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+)
+
+func TestSerialiseFields(t *testing.T) {
+	t.Parallel()
+	p := currency.NewPair(currency.BTC, currency.USDT)
+	s := Statistic{
+		StrategyName: "test",
+		TotalOrders:  2,
+		ExchangeAssetPairStatistics: map[string]map[asset.Item]map[currency.Pair]*CurrencyPairStatistic{
+			testExchange: {
+				asset.Spot: {
+					p: {
+						Exchange:    testExchange,
+						Asset:       asset.Spot,
+						Currency:    p,
+						TotalOrders: 1,
+						BuyOrders:   1,
+					},
+				},
+			},
+		},
+	}
+	_, err := s.SerialiseFields(nil)
+	if !errors.Is(err, errNoSerialiseFields) {
+		t.Errorf("received '%v' expected '%v'", err, errNoSerialiseFields)
+	}
+	_, err = s.SerialiseFields([]string{"strategy-name", "sharpe-ratio"})
+	if !errors.Is(err, errInvalidSerialiseField) {
+		t.Errorf("received '%v' expected '%v'", err, errInvalidSerialiseField)
+	}
+	_, err = s.SerialiseFields([]string{"currency-statistics.strategy-name"})
+	if !errors.Is(err, errInvalidSerialiseField) {
+		t.Errorf("received '%v' expected '%v'", err, errInvalidSerialiseField)
+	}
+
+	resp, err := s.SerialiseFields([]string{"strategy-name", "total-orders", "currency-statistics.total-orders"})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	var result struct {
+		StrategyName       string                   `json:"strategy-name"`
+		TotalOrders        int64                    `json:"total-orders"`
+		CurrencyStatistics []map[string]interface{} `json:"currency-statistics"`
+	}
+	err = json.Unmarshal([]byte(resp), &result)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	if result.StrategyName != "test" || result.TotalOrders != 2 {
+		t.Errorf("received '%v' '%v' expected '%v' '%v'", result.StrategyName, result.TotalOrders, "test", 2)
+	}
+	if len(result.CurrencyStatistics) != 1 {
+		t.Fatalf("received '%v' expected '%v'", len(result.CurrencyStatistics), 1)
+	}
+	// only the selected field and the currency's identifiers are output
+	if len(result.CurrencyStatistics[0]) != 4 {
+		t.Errorf("received '%v' expected '%v'", result.CurrencyStatistics[0], "exchange, asset, currency and total orders")
+	}
+	if result.CurrencyStatistics[0]["total-orders"] != float64(1) {
+		t.Errorf("received '%v' expected '%v'", result.CurrencyStatistics[0]["total-orders"], 1)
+	}
+	if result.CurrencyStatistics[0]["Exchange"] != testExchange {
+		t.Errorf("received '%v' expected '%v'", result.CurrencyStatistics[0]["Exchange"], testExchange)
+	}
+
+	resp, err = s.SerialiseFields([]string{"total-orders"})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	var topLevel map[string]interface{}
+	err = json.Unmarshal([]byte(resp), &topLevel)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	if len(topLevel) != 1 {
+		t.Errorf("received '%v' expected only total orders", topLevel)
+	}
+}
@@ -0,0 +1,44 @@
+package statistics
+
+import (
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+)
+
+// SaveState returns a copy of the events and funnel counts
+// recorded for each currency
+func (s *Statistic) SaveState() *State {
+	resp := &State{}
+	for _, assets := range s.ExchangeAssetPairStatistics {
+		for _, pairs := range assets {
+			for _, stats := range pairs {
+				if stats == nil {
+					continue
+				}
+				cpy := *stats
+				cpy.Events = append([]DataAtOffset(nil), stats.Events...)
+				resp.Statistics = append(resp.Statistics, &cpy)
+			}
+		}
+	}
+	return resp
+}
+
+// RestoreState replaces the statistics recorded for each currency with
+// a copy of those of the state, discarding any recorded since
+func (s *Statistic) RestoreState(state *State) error {
+	if state == nil {
+		return errNilState
+	}
+	s.ExchangeAssetPairStatistics = make(map[string]map[asset.Item]map[currency.Pair]*CurrencyPairStatistic)
+	for i := range state.Statistics {
+		if state.Statistics[i] == nil {
+			continue
+		}
+		cpy := *state.Statistics[i]
+		cpy.Events = append([]DataAtOffset(nil), state.Statistics[i].Events...)
+		s.setupMap(cpy.Exchange, cpy.Asset)
+		s.ExchangeAssetPairStatistics[cpy.Exchange][cpy.Asset][cpy.Currency] = &cpy
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+package statistics
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/event"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/kline"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	gctkline "github.com/thrasher-corp/gocryptotrader/exchanges/kline"
+)
+
+func TestSaveRestoreState(t *testing.T) {
+	t.Parallel()
+	tt := time.Now()
+	exch := testExchange
+	a := asset.Spot
+	p := currency.NewPair(currency.BTC, currency.USDT)
+	s := Statistic{}
+	newKline := func(offset int64) *kline.Kline {
+		return &kline.Kline{
+			Base: &event.Base{
+				Offset:       offset,
+				Exchange:     exch,
+				Time:         tt.Add(time.Duration(offset) * gctkline.OneDay.Duration()),
+				Interval:     gctkline.OneDay,
+				CurrencyPair: p,
+				AssetType:    a,
+			},
+			Close: eleet,
+		}
+	}
+	err := s.SetupEventForTime(newKline(1))
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	state := s.SaveState()
+
+	err = s.SetupEventForTime(newKline(2))
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	s.ExchangeAssetPairStatistics[exch][a][p].Funnel.Signals++
+
+	err = s.RestoreState(state)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	lookup := s.ExchangeAssetPairStatistics[exch][a][p]
+	if len(lookup.Events) != 1 || lookup.Events[0].Offset != 1 {
+		t.Errorf("received '%v' expected one event at offset '%v'", lookup.Events, 1)
+	}
+	if lookup.Funnel.Signals != 0 {
+		t.Errorf("received '%v' expected '%v'", lookup.Funnel.Signals, 0)
+	}
+	err = s.SetupEventForTime(newKline(2))
+	if !errors.Is(err, nil) {
+		t.Errorf("received '%v' expected '%v'", err, nil)
+	}
+	if len(state.Statistics[0].Events) != 1 {
+		t.Errorf("received '%v' expected events recorded after restoring to not alter the state", len(state.Statistics[0].Events))
+	}
+
+	err = s.RestoreState(nil)
+	if !errors.Is(err, errNilState) {
+		t.Errorf("received '%v' expected '%v'", err, errNilState)
+	}
+}
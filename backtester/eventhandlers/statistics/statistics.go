@@ -14,6 +14,7 @@ import (
 	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/signal"
 	"github.com/thrasher-corp/gocryptotrader/currency"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	gctorder "github.com/thrasher-corp/gocryptotrader/exchanges/order"
 	"github.com/thrasher-corp/gocryptotrader/log"
 )
 
@@ -101,10 +102,22 @@ func applyEventAtOffset(ev common.EventHandler, lookup *CurrencyPairStatistic, i
 		lookup.Events[i].DataEvent = t
 	case signal.Event:
 		lookup.Events[i].SignalEvent = t
+		if common.CanTransact(t.GetDirection()) {
+			lookup.Funnel.Signals++
+		}
 	case order.Event:
 		lookup.Events[i].OrderEvent = t
+		if common.CanTransact(t.GetDirection()) {
+			lookup.Funnel.Orders++
+		}
 	case fill.Event:
 		lookup.Events[i].FillEvent = t
+		switch {
+		case common.CanTransact(t.GetDirection()):
+			lookup.Funnel.Fills++
+		case isRejectedDirection(t.GetDirection()):
+			lookup.Funnel.Rejected++
+		}
 	default:
 		return fmt.Errorf("unknown event type received: %v", ev)
 	}
@@ -115,6 +128,21 @@ func applyEventAtOffset(ev common.EventHandler, lookup *CurrencyPairStatistic, i
 	return nil
 }
 
+// isRejectedDirection returns whether the side
+// denotes an order which could not be completed
+func isRejectedDirection(side gctorder.Side) bool {
+	switch side {
+	case gctorder.CouldNotBuy,
+		gctorder.CouldNotSell,
+		gctorder.CouldNotShort,
+		gctorder.CouldNotLong,
+		gctorder.CouldNotCloseShort,
+		gctorder.CouldNotCloseLong:
+		return true
+	}
+	return false
+}
+
 // AddHoldingsForTime adds all holdings to the statistics at the time period
 func (s *Statistic) AddHoldingsForTime(h *holdings.Holding) error {
 	if s.ExchangeAssetPairStatistics == nil {
@@ -195,10 +223,52 @@ func (s *Statistic) CalculateAllResults() error {
 				if last.PNL != nil {
 					s.HasCollateral = true
 				}
-				err = stats.CalculateResults(s.RiskFreeRate)
+				stats.valueAtRiskConfidenceLevels = s.ValueAtRiskConfidenceLevels
+				stats.autocorrelationLags = s.AutocorrelationLags
+				stats.tagAllocations = s.getTagAllocations(exchangeName, assetItem, pair)
+				stats.accountAllocations = s.getAccountAllocations(exchangeName, assetItem, pair)
+				err = stats.CalculateResults(s.RiskFreeRate, s.TradingCalendar)
 				if err != nil {
 					log.Error(common.Statistics, err)
 				}
+				if s.VolatilityRegimeWindow > 0 && s.VolatilityRegimeCount > 0 {
+					interval := last.DataEvent.GetInterval()
+					riskFreeRatePerCandle := riskFreeRatePerCandle(s.RiskFreeRate, interval, s.TradingCalendar)
+					stats.VolatilityRegimes, err = stats.CalculateVolatilityRegimes(s.VolatilityRegimeWindow, s.VolatilityRegimeCount, riskFreeRatePerCandle)
+					if err != nil {
+						log.Error(common.Statistics, err)
+					}
+				}
+				if s.CostBasisMethod != "" && assetItem == asset.Spot {
+					stats.TaxLotDisposals, err = stats.CalculateTaxLots(s.CostBasisMethod)
+					if err != nil {
+						log.Error(common.Statistics, err)
+					}
+					for i := range stats.TaxLotDisposals {
+						stats.TaxLotRealisedGain = stats.TaxLotRealisedGain.Add(stats.TaxLotDisposals[i].Gain)
+					}
+				}
+				if s.WashTradeWindow > 0 {
+					stats.WashTrades, err = stats.CalculateWashTrades(s.WashTradeWindow, s.WashTradePriceTolerance)
+					if err != nil {
+						log.Error(common.Statistics, err)
+					}
+				}
+				if s.CapacityParticipation.IsPositive() {
+					stats.Capacity, err = stats.CalculateCapacity(s.CapacityParticipation)
+					if err != nil {
+						log.Error(common.Statistics, err)
+					}
+				}
+				if s.RecordReturnsSeries {
+					stats.ReturnsSeries = stats.calculateReturnsSeries()
+				}
+				if s.RecordNoTradeReasons {
+					stats.NoTradeReasons = stats.calculateNoTradeReasons()
+				}
+				if s.RecordMarketConditions {
+					stats.MarketConditions = stats.calculateMarketConditions()
+				}
 				stats.FinalHoldings = last.Holdings
 				stats.InitialHoldings = stats.Events[0].Holdings
 				stats.FinalOrders = last.Transactions
@@ -225,14 +295,33 @@ func (s *Statistic) CalculateAllResults() error {
 			}
 		}
 	}
-	s.FundingStatistics, err = CalculateFundingStatistics(s.FundManager, s.ExchangeAssetPairStatistics, s.RiskFreeRate, s.CandleInterval)
+	if s.Manifest != nil {
+		s.Manifest.StartDate = s.StartDate
+		s.Manifest.EndDate = s.EndDate
+	}
+	s.calculateTimeInMarket()
+	s.FundingStatistics, err = CalculateFundingStatistics(s.FundManager, s.ExchangeAssetPairStatistics, s.RiskFreeRate, s.CandleInterval, s.TradingCalendar)
 	if err != nil {
 		return err
 	}
+	if s.PerformanceFeeRate.IsPositive() && s.FundingStatistics.TotalUSDStatistics != nil {
+		err = s.FundingStatistics.CalculatePerformanceFee(s.PerformanceFeeRate, s.PerformanceFeeInterval)
+		if err != nil {
+			log.Error(common.Statistics, err)
+		}
+	}
 	err = s.FundingStatistics.PrintResults(s.WasAnyDataMissing)
 	if err != nil {
 		return err
 	}
+	err = s.calculateStrategyBetas()
+	if err != nil {
+		log.Error(common.Statistics, err)
+	}
+	s.PrintStrategyBetas()
+	s.calculateCorrelationMatrix()
+	s.PrintCorrelationMatrix()
+	s.PrintReasonSummary()
 	if currCount > 1 {
 		s.BiggestDrawdown = s.GetTheBiggestDrawdownAcrossCurrencies(finalResults)
 		s.BestMarketMovement = s.GetBestMarketPerformer(finalResults)
@@ -911,3 +911,38 @@ func TestCalculateBiggestValueAtTimeDrawdown(t *testing.T) {
 		t.Errorf("received %v expected %v", err, errReceivedNoData)
 	}
 }
+
+func TestConversionFunnel(t *testing.T) {
+	t.Parallel()
+	s := Statistic{}
+	p := currency.NewPair(currency.BTC, currency.USDT)
+	b := &event.Base{
+		Exchange:     testExchange,
+		Time:         time.Now(),
+		Interval:     gctkline.OneDay,
+		CurrencyPair: p,
+		AssetType:    asset.Spot,
+	}
+	err := s.SetupEventForTime(&kline.Kline{Base: b, Close: eleet})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: %v, expected: %v", err, nil)
+	}
+	events := []common.EventHandler{
+		&signal.Signal{Base: b, Direction: gctorder.Buy},
+		&signal.Signal{Base: b, Direction: gctorder.DoNothing},
+		&order.Order{Base: b, Direction: gctorder.Buy},
+		&fill.Fill{Base: b, Direction: gctorder.Buy},
+		&fill.Fill{Base: b, Direction: gctorder.CouldNotSell},
+		&fill.Fill{Base: b, Direction: gctorder.DoNothing},
+	}
+	for i := range events {
+		err = s.SetEventForOffset(events[i])
+		if !errors.Is(err, nil) {
+			t.Fatalf("received: %v, expected: %v", err, nil)
+		}
+	}
+	funnel := s.ExchangeAssetPairStatistics[testExchange][asset.Spot][p].Funnel
+	if funnel != (ConversionFunnel{Signals: 1, Orders: 1, Fills: 1, Rejected: 1}) {
+		t.Errorf("received: %+v, expected: %+v", funnel, ConversionFunnel{Signals: 1, Orders: 1, Fills: 1, Rejected: 1})
+	}
+}
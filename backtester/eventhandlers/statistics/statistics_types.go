@@ -6,6 +6,7 @@ import (
 
 	"github.com/shopspring/decimal"
 	"github.com/thrasher-corp/gocryptotrader/backtester/common"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventhandlers/exchange"
 	"github.com/thrasher-corp/gocryptotrader/backtester/eventhandlers/portfolio"
 	"github.com/thrasher-corp/gocryptotrader/backtester/eventhandlers/portfolio/compliance"
 	"github.com/thrasher-corp/gocryptotrader/backtester/eventhandlers/portfolio/holdings"
@@ -21,18 +22,32 @@ import (
 
 var (
 	// ErrAlreadyProcessed occurs when an event has already been processed
-	ErrAlreadyProcessed            = errors.New("this event has been processed already")
-	errExchangeAssetPairStatsUnset = errors.New("exchangeAssetPairStatistics not setup")
-	errCurrencyStatisticsUnset     = errors.New("no data")
-	errMissingSnapshots            = errors.New("funding report item missing USD snapshots")
-	errNoRelevantStatsFound        = errors.New("no relevant currency pair statistics found")
-	errReceivedNoData              = errors.New("received no data")
-	errNoDataAtOffset              = errors.New("no data found at offset")
+	ErrAlreadyProcessed              = errors.New("this event has been processed already")
+	errExchangeAssetPairStatsUnset   = errors.New("exchangeAssetPairStatistics not setup")
+	errCurrencyStatisticsUnset       = errors.New("no data")
+	errMissingSnapshots              = errors.New("funding report item missing USD snapshots")
+	errNoRelevantStatsFound          = errors.New("no relevant currency pair statistics found")
+	errReceivedNoData                = errors.New("received no data")
+	errNoDataAtOffset                = errors.New("no data found at offset")
+	errInvalidRegimeSettings         = errors.New("invalid volatility regime settings")
+	errNotEnoughEventsForRegimes     = errors.New("not enough events to calculate volatility regimes")
+	errInvalidCostBasisMethod        = errors.New("invalid cost basis method")
+	errMismatchedReturnLengths       = errors.New("mismatched return lengths")
+	errInvalidRollingBetaWindow      = errors.New("invalid rolling beta window")
+	errNotEnoughEventsForRollingBeta = errors.New("not enough events to calculate rolling beta")
+	errInvalidConfidenceLevel        = errors.New("invalid confidence level")
+	errInvalidPerformanceFee         = errors.New("invalid performance fee")
+	errInvalidWashTradeSettings      = errors.New("invalid wash trade settings")
+	errInvalidCapacityParticipation  = errors.New("invalid capacity participation")
+	errNoSerialiseFields             = errors.New("no fields selected to serialise")
+	errInvalidSerialiseField         = errors.New("invalid serialise field")
+	errNilState                      = errors.New("received nil statistics state")
 )
 
 // Statistic holds all statistical information for a backtester run, from drawdowns to ratios.
 // Any currency specific information is handled in currencystatistics
 type Statistic struct {
+	Manifest                    *RunManifest                                                       `json:"manifest,omitempty"`
 	StrategyName                string                                                             `json:"strategy-name"`
 	StrategyDescription         string                                                             `json:"strategy-description"`
 	StrategyNickname            string                                                             `json:"strategy-nickname"`
@@ -55,6 +70,27 @@ type Statistic struct {
 	FundingStatistics           *FundingStatistics                                                 `json:"funding-statistics"`
 	FundManager                 funding.IFundingManager                                            `json:"-"`
 	HasCollateral               bool                                                               `json:"has-collateral"`
+	VolatilityRegimeWindow      int64                                                              `json:"volatility-regime-window,omitempty"`
+	VolatilityRegimeCount       int64                                                              `json:"volatility-regime-count,omitempty"`
+	CostBasisMethod             string                                                             `json:"cost-basis-method,omitempty"`
+	TradingCalendar             string                                                             `json:"trading-calendar,omitempty"`
+	RecordReturnsSeries         bool                                                               `json:"record-returns-series,omitempty"`
+	RecordNoTradeReasons        bool                                                               `json:"record-no-trade-reasons,omitempty"`
+	RecordMarketConditions      bool                                                               `json:"record-market-conditions,omitempty"`
+	RollingBetaWindow           int64                                                              `json:"rolling-beta-window,omitempty"`
+	ValueAtRiskConfidenceLevels []decimal.Decimal                                                  `json:"value-at-risk-confidence-levels,omitempty"`
+	AutocorrelationLags         int64                                                              `json:"autocorrelation-lags,omitempty"`
+	PerformanceFeeRate          decimal.Decimal                                                    `json:"performance-fee-rate,omitempty"`
+	PerformanceFeeInterval      time.Duration                                                      `json:"performance-fee-interval,omitempty"`
+	WashTradeWindow             time.Duration                                                      `json:"wash-trade-window,omitempty"`
+	WashTradePriceTolerance     decimal.Decimal                                                    `json:"wash-trade-price-tolerance,omitempty"`
+	CapacityParticipation       decimal.Decimal                                                    `json:"capacity-participation,omitempty"`
+	TimeInMarket                *TimeInMarket                                                      `json:"time-in-market,omitempty"`
+	DowntimePeriods             []DowntimePeriod                                                   `json:"downtime-periods,omitempty"`
+	TagAllocations              []TagAllocation                                                    `json:"tag-allocations,omitempty"`
+	AccountAllocations          []AccountAllocation                                                `json:"account-allocations,omitempty"`
+	StrategyBetas               []StrategyBeta                                                     `json:"strategy-betas,omitempty"`
+	CorrelationMatrix           *CorrelationMatrix                                                 `json:"correlation-matrix,omitempty"`
 }
 
 // FinalResultsHolder holds important stats about a currency's performance
@@ -77,7 +113,16 @@ type Handler interface {
 	CalculateAllResults() error
 	Reset()
 	Serialise() (string, error)
+	SerialiseFields([]string) (string, error)
 	AddPNLForTime(*portfolio.PNLSummary) error
+	SaveState() *State
+	RestoreState(*State) error
+}
+
+// State is a serialisable copy of the events and funnel counts recorded for
+// each currency which can be restored to continue recording statistics
+type State struct {
+	Statistics []*CurrencyPairStatistic
 }
 
 // Results holds some statistics on results
@@ -150,6 +195,21 @@ type CurrencyPairStatistic struct {
 	ShortOrders int64 `json:"short-orders"`
 	SellOrders  int64 `json:"sell-orders"`
 	TotalOrders int64 `json:"total-orders"`
+	// StopOutOrders counts orders closing positions which breached
+	// their maximum drawdown, distinct from exchange liquidations
+	StopOutOrders int64 `json:"stop-out-orders"`
+	// CapitalCapRejections counts entries rejected for
+	// exceeding the maximum capital allocation
+	CapitalCapRejections int64 `json:"capital-cap-rejections"`
+	// CircuitBreakerTriggers counts how often consecutive losing
+	// trades halted new entries
+	CircuitBreakerTriggers int64 `json:"circuit-breaker-triggers"`
+	// CircuitBreakerRejections counts entries rejected
+	// while the losing streak circuit breaker was halted
+	CircuitBreakerRejections int64 `json:"circuit-breaker-rejections"`
+	// LiquidationCost is the cost of the liquidation
+	// penalty slippage and fees charged to liquidations
+	LiquidationCost decimal.Decimal `json:"liquidation-cost"`
 
 	StartingClosePrice   ValueAtTime `json:"starting-close-price"`
 	EndingClosePrice     ValueAtTime `json:"ending-close-price"`
@@ -164,22 +224,101 @@ type CurrencyPairStatistic struct {
 	StrategyMovement             decimal.Decimal `json:"strategy-movement"`
 	UnrealisedPNL                decimal.Decimal `json:"unrealised-pnl"`
 	RealisedPNL                  decimal.Decimal `json:"realised-pnl"`
+	BorrowCost                   decimal.Decimal `json:"borrow-cost"`
+	FundingCost                  decimal.Decimal `json:"funding-cost"`
 	CompoundAnnualGrowthRate     decimal.Decimal `json:"compound-annual-growth-rate"`
 	TotalAssetValue              decimal.Decimal `json:"total-asset-value"`
 	TotalFees                    decimal.Decimal `json:"total-fees"`
+	AccumulatedRebates           decimal.Decimal `json:"accumulated-rebates"`
+	EffectiveFeeRate             decimal.Decimal `json:"effective-fee-rate"`
+	SubmissionRetries            int64           `json:"submission-retries"`
 	TotalValueLostToVolumeSizing decimal.Decimal `json:"total-value-lost-to-volume-sizing"`
 	TotalValueLostToSlippage     decimal.Decimal `json:"total-value-lost-to-slippage"`
 	TotalValueLost               decimal.Decimal `json:"total-value-lost"`
 
 	Events []DataAtOffset `json:"-"`
 
-	MaxDrawdown           Swing               `json:"max-drawdown,omitempty"`
-	HighestCommittedFunds ValueAtTime         `json:"highest-committed-funds"`
-	GeometricRatios       *Ratios             `json:"geometric-ratios"`
-	ArithmeticRatios      *Ratios             `json:"arithmetic-ratios"`
-	InitialHoldings       holdings.Holding    `json:"initial-holdings-holdings"`
-	FinalHoldings         holdings.Holding    `json:"final-holdings"`
-	FinalOrders           compliance.Snapshot `json:"final-orders"`
+	MaxDrawdown           Swing                      `json:"max-drawdown,omitempty"`
+	HighestCommittedFunds ValueAtTime                `json:"highest-committed-funds"`
+	GeometricRatios       *Ratios                    `json:"geometric-ratios"`
+	ArithmeticRatios      *Ratios                    `json:"arithmetic-ratios"`
+	InitialHoldings       holdings.Holding           `json:"initial-holdings-holdings"`
+	FinalHoldings         holdings.Holding           `json:"final-holdings"`
+	FinalOrders           compliance.Snapshot        `json:"final-orders"`
+	VolatilityRegimes     []VolatilityRegime         `json:"volatility-regimes,omitempty"`
+	Latency               *LatencyStatistics         `json:"latency,omitempty"`
+	EffectiveSpread       *EffectiveSpreadStatistics `json:"effective-spread,omitempty"`
+	FillRatios            *FillRatioStatistics       `json:"fill-ratios,omitempty"`
+	TimeInMarket          TimeInMarket               `json:"time-in-market"`
+	TaxLotDisposals       []TaxLotDisposal           `json:"tax-lot-disposals,omitempty"`
+	TaxLotRealisedGain    decimal.Decimal            `json:"tax-lot-realised-gain"`
+	TagStatistics         []TagStatistic             `json:"tag-statistics,omitempty"`
+	AccountStatistics     []AccountStatistic         `json:"account-statistics,omitempty"`
+	AggregateAccount      *AccountStatistic          `json:"aggregate-account,omitempty"`
+	WashTrades            []WashTrade                `json:"wash-trades,omitempty"`
+	Capacity              *CapacityEstimate          `json:"capacity,omitempty"`
+	KellyCriterion        *KellyCriterion            `json:"kelly-criterion,omitempty"`
+	GrossNetReturns       *GrossNetReturns           `json:"gross-net-returns,omitempty"`
+	Funnel                ConversionFunnel           `json:"conversion-funnel"`
+	// ReturnsSeries is the per event returns series, only
+	// recorded when enabled as it adds to the output size
+	ReturnsSeries []ValueAtTime `json:"returns-series,omitempty"`
+	// NoTradeReasons records why each event did not trade, only
+	// recorded when enabled as it adds to the output size
+	NoTradeReasons []NoTradeReason `json:"no-trade-reasons,omitempty"`
+	// MarketConditions records the range and volume of each candle,
+	// only recorded when enabled as it adds to the output size
+	MarketConditions []MarketCondition `json:"market-conditions,omitempty"`
+	// TailRisk holds the value at risk and tail ratio of the returns
+	TailRisk *TailRisk `json:"tail-risk,omitempty"`
+	// Autocorrelation holds the serial autocorrelation of the returns
+	Autocorrelation *Autocorrelation `json:"autocorrelation,omitempty"`
+
+	valueAtRiskConfidenceLevels []decimal.Decimal
+	autocorrelationLags         int64
+	tagAllocations              map[string]decimal.Decimal
+	accountAllocations          map[string]decimal.Decimal
+}
+
+// Autocorrelation is the serial correlation of a returns series with
+// itself at each lag. Coefficients outside the significance bound are
+// unlikely to be chance, and a lag 1 coefficient outside it is flagged as
+// it indicates return smoothing or trending which inflates the Sharpe ratio
+type Autocorrelation struct {
+	Lags                []LagAutocorrelation `json:"lags"`
+	Observations        int64                `json:"observations"`
+	SignificanceBound   decimal.Decimal      `json:"significance-bound"`
+	HighAutocorrelation bool                 `json:"high-autocorrelation"`
+}
+
+// LagAutocorrelation is the autocorrelation coefficient of a returns series
+// against itself shifted by the lag
+type LagAutocorrelation struct {
+	Lag         int64           `json:"lag"`
+	Coefficient decimal.Decimal `json:"coefficient"`
+	Significant bool            `json:"significant"`
+}
+
+// TailRisk holds historical downside risk measures of a returns series
+type TailRisk struct {
+	Observations int64         `json:"observations"`
+	ValueAtRisk  []ValueAtRisk `json:"value-at-risk"`
+	// TailRatio is the 95th percentile return divided by the absolute 5th
+	// percentile return. Above one means gains in the right tail outsize
+	// losses in the left tail
+	TailRatio                 decimal.Decimal `json:"tail-ratio"`
+	TailRatioInsufficientData bool            `json:"tail-ratio-insufficient-data"`
+}
+
+// ValueAtRisk is the loss not expected to be exceeded at the confidence
+// level and the average loss when it is, as percentages of the total value
+type ValueAtRisk struct {
+	ConfidenceLevel        decimal.Decimal `json:"confidence-level"`
+	ValueAtRisk            decimal.Decimal `json:"value-at-risk"`
+	ConditionalValueAtRisk decimal.Decimal `json:"conditional-value-at-risk"`
+	// InsufficientData is set when too few returns fall beyond the
+	// confidence level for the measures to be reliable
+	InsufficientData bool `json:"insufficient-data"`
 }
 
 // Ratios stores all the ratios used for statistics
@@ -210,6 +349,338 @@ type relatedCurrencyPairStatistics struct {
 	stat           *CurrencyPairStatistic
 }
 
+// ReplayResult holds the fills and PNL generated by replaying
+// recorded order events for an exchange, asset and currency pair
+type ReplayResult struct {
+	Exchange      string          `json:"exchange"`
+	Asset         asset.Item      `json:"asset"`
+	Pair          currency.Pair   `json:"pair"`
+	Fills         []fill.Event    `json:"fills"`
+	TotalFees     decimal.Decimal `json:"total-fees"`
+	FinalHoldings decimal.Decimal `json:"final-holdings"`
+	PNL           decimal.Decimal `json:"pnl"`
+}
+
+// VolatilityRegime holds the boundaries and performance of a
+// segment of a run grouped by rolling close price volatility
+type VolatilityRegime struct {
+	Regime          int64           `json:"regime"`
+	LowerVolatility decimal.Decimal `json:"lower-volatility"`
+	UpperVolatility decimal.Decimal `json:"upper-volatility"`
+	Candles         int64           `json:"candles"`
+	StrategyReturn  decimal.Decimal `json:"strategy-return"`
+	MarketReturn    decimal.Decimal `json:"market-return"`
+	SharpeRatio     decimal.Decimal `json:"sharpe-ratio"`
+}
+
+// LatencyStatistics describes the realised latency
+// distribution experienced by filled orders
+type LatencyStatistics struct {
+	Orders       int64         `json:"orders"`
+	Minimum      time.Duration `json:"minimum"`
+	Maximum      time.Duration `json:"maximum"`
+	Mean         time.Duration `json:"mean"`
+	Median       time.Duration `json:"median"`
+	Percentile95 time.Duration `json:"95th-percentile"`
+}
+
+// EffectiveSpreadStatistics describes the effective spread paid against the
+// orderbook midpoint by filled orders as a percentage of the midpoint
+type EffectiveSpreadStatistics struct {
+	Fills   int64           `json:"fills"`
+	Average decimal.Decimal `json:"average"`
+	Worst   ValueAtTime     `json:"worst"`
+}
+
+// FillRatioStatistics describes the ratio of filled to intended amount of
+// filled orders, showing how often orders could not get their full size
+type FillRatioStatistics struct {
+	Orders       int64             `json:"orders"`
+	FullFills    int64             `json:"full-fills"`
+	PartialFills int64             `json:"partial-fills"`
+	Average      decimal.Decimal   `json:"average"`
+	Minimum      decimal.Decimal   `json:"minimum"`
+	Median       decimal.Decimal   `json:"median"`
+	Distribution []FillRatioBucket `json:"distribution"`
+}
+
+// FillRatioBucket counts partially filled orders whose fill
+// ratio is at least the lower bound and below the upper bound
+type FillRatioBucket struct {
+	LowerBound decimal.Decimal `json:"lower-bound"`
+	UpperBound decimal.Decimal `json:"upper-bound"`
+	Orders     int64           `json:"orders"`
+}
+
+// TimeInMarket describes how many candles ended holding
+// a position versus flat in cash
+type TimeInMarket struct {
+	InMarketCandles  int64           `json:"in-market-candles"`
+	FlatCandles      int64           `json:"flat-candles"`
+	InMarketPercent  decimal.Decimal `json:"in-market-percent"`
+	InMarketDuration time.Duration   `json:"in-market-duration"`
+}
+
+// ChartData holds charting ready series for a completed run. Each series
+// holds parallel arrays sharing an index per timestamp
+type ChartData struct {
+	Portfolio  *ChartSeries  `json:"portfolio,omitempty"`
+	Currencies []ChartSeries `json:"currencies"`
+}
+
+// ChartSeries holds the equity curve, drawdown and trades of an exchange,
+// asset and pair, or of the whole portfolio in USD. Drawdown is the
+// percentage the equity is below its running peak
+type ChartSeries struct {
+	Exchange   string            `json:"exchange,omitempty"`
+	Asset      asset.Item        `json:"asset,omitempty"`
+	Pair       currency.Pair     `json:"pair,omitempty"`
+	Timestamps []time.Time       `json:"timestamps"`
+	Prices     []decimal.Decimal `json:"prices,omitempty"`
+	Equity     []decimal.Decimal `json:"equity"`
+	Drawdown   []decimal.Decimal `json:"drawdown"`
+	Markers    []TradeMarker     `json:"markers,omitempty"`
+}
+
+// TradeMarker is a filled order for overlaying on a price chart
+type TradeMarker struct {
+	Time      time.Time       `json:"time"`
+	Direction gctorder.Side   `json:"direction"`
+	Price     decimal.Decimal `json:"price"`
+	Amount    decimal.Decimal `json:"amount"`
+}
+
+// DowntimePeriod is a gap in an exchange, asset and pair's
+// data feed which was treated as exchange downtime
+type DowntimePeriod struct {
+	Exchange string        `json:"exchange"`
+	Asset    asset.Item    `json:"asset"`
+	Pair     currency.Pair `json:"pair"`
+	Start    time.Time     `json:"start"`
+	End      time.Time     `json:"end"`
+}
+
+// GrossNetReturns separates a currency's return before fees and slippage
+// from its return after them. The gross return less the fee and slippage
+// drags is the net return. Returns and drags are percentages of the initial
+// total value, with negative slippage being price improvement
+type GrossNetReturns struct {
+	GrossPNL     decimal.Decimal `json:"gross-pnl"`
+	NetPNL       decimal.Decimal `json:"net-pnl"`
+	Fees         decimal.Decimal `json:"fees"`
+	Slippage     decimal.Decimal `json:"slippage"`
+	GrossReturn  decimal.Decimal `json:"gross-return"`
+	NetReturn    decimal.Decimal `json:"net-return"`
+	FeeDrag      decimal.Decimal `json:"fee-drag"`
+	SlippageDrag decimal.Decimal `json:"slippage-drag"`
+}
+
+// TagAllocation is the percentage of an exchange, asset and pair's
+// initial funds dedicated to a strategy tag's orders
+type TagAllocation struct {
+	Exchange string          `json:"exchange"`
+	Asset    asset.Item      `json:"asset"`
+	Pair     currency.Pair   `json:"pair"`
+	Tag      string          `json:"tag"`
+	Percent  decimal.Decimal `json:"percent"`
+}
+
+// AccountAllocation is the percentage of an exchange, asset and pair's
+// initial funds segregated into a named account
+type AccountAllocation struct {
+	Exchange string          `json:"exchange"`
+	Asset    asset.Item      `json:"asset"`
+	Pair     currency.Pair   `json:"pair"`
+	Account  string          `json:"account"`
+	Percent  decimal.Decimal `json:"percent"`
+}
+
+// StrategyBeta is the sensitivity of the strategy's total returns to an
+// instrument's returns, along with how much of the strategy's return
+// variance the instrument explains. RollingBeta is the beta over a sliding
+// window of returns, only calculated when a rolling beta window is set
+type StrategyBeta struct {
+	Exchange     string          `json:"exchange"`
+	Asset        asset.Item      `json:"asset"`
+	Pair         currency.Pair   `json:"pair"`
+	Beta         decimal.Decimal `json:"beta"`
+	RSquared     decimal.Decimal `json:"r-squared"`
+	Observations int64           `json:"observations"`
+	RollingBeta  []ValueAtTime   `json:"rolling-beta,omitempty"`
+}
+
+// CorrelationMatrix holds the return correlation coefficient of every
+// traded currency against every other. Coefficients are indexed in the
+// same order as the currencies
+type CorrelationMatrix struct {
+	Currencies   []CorrelatedCurrency `json:"currencies"`
+	Coefficients [][]decimal.Decimal  `json:"coefficients"`
+}
+
+// CorrelatedCurrency identifies a row and column of the correlation matrix
+type CorrelatedCurrency struct {
+	Exchange string        `json:"exchange"`
+	Asset    asset.Item    `json:"asset"`
+	Pair     currency.Pair `json:"pair"`
+}
+
+// ConversionFunnel tallies how many actionable signals progressed to
+// orders and fills, along with orders rejected along the way
+type ConversionFunnel struct {
+	Signals  int64 `json:"signals"`
+	Orders   int64 `json:"orders"`
+	Fills    int64 `json:"fills"`
+	Rejected int64 `json:"rejected"`
+}
+
+// NoTradeReason is why an event did not result in a successful fill.
+// Stage is the furthest point reached along the data, signal, order
+// and fill event chain before the trade was abandoned
+type NoTradeReason struct {
+	Offset    int64         `json:"offset"`
+	Time      time.Time     `json:"time"`
+	Stage     string        `json:"stage"`
+	Direction gctorder.Side `json:"direction"`
+	Reason    string        `json:"reason"`
+}
+
+// MarketCondition is the range and volume of a candle,
+// used to compare performance against market conditions
+type MarketCondition struct {
+	Offset       int64           `json:"offset"`
+	Time         time.Time       `json:"time"`
+	RangePercent decimal.Decimal `json:"range-percent"`
+	Volume       decimal.Decimal `json:"volume"`
+}
+
+// TagStatistic attributes the performance of filled orders
+// sharing a strategy tag. An empty Tag holds all untagged fills.
+// Tags with a capital allocation also hold the allocated capital, their
+// equity being the allocated capital plus PNL, and the return on it
+type TagStatistic struct {
+	Tag               string          `json:"tag"`
+	Orders            int64           `json:"orders"`
+	BuyOrders         int64           `json:"buy-orders"`
+	SellOrders        int64           `json:"sell-orders"`
+	TotalFees         decimal.Decimal `json:"total-fees"`
+	NetCashFlow       decimal.Decimal `json:"net-cash-flow"`
+	Position          decimal.Decimal `json:"position"`
+	PNL               decimal.Decimal `json:"pnl"`
+	AllocationPercent decimal.Decimal `json:"allocation-percent,omitempty"`
+	AllocatedCapital  decimal.Decimal `json:"allocated-capital,omitempty"`
+	Equity            decimal.Decimal `json:"equity,omitempty"`
+	ReturnPercent     decimal.Decimal `json:"return-percent,omitempty"`
+}
+
+// AccountStatistic is the performance of a segregated account, replayed
+// from the fills it funded. An empty Account holds orders funded by the
+// unallocated remainder. Equity is the quote balance plus the base balance
+// valued at the close price, recorded at every event in the equity curve
+type AccountStatistic struct {
+	Account            string          `json:"account"`
+	AllocationPercent  decimal.Decimal `json:"allocation-percent"`
+	Orders             int64           `json:"orders"`
+	TotalFees          decimal.Decimal `json:"total-fees"`
+	QuoteBalance       decimal.Decimal `json:"quote-balance"`
+	BaseBalance        decimal.Decimal `json:"base-balance"`
+	InitialEquity      decimal.Decimal `json:"initial-equity"`
+	FinalEquity        decimal.Decimal `json:"final-equity"`
+	ReturnPercent      decimal.Decimal `json:"return-percent"`
+	MaxDrawdownPercent decimal.Decimal `json:"max-drawdown-percent"`
+	EquityCurve        []ValueAtTime   `json:"equity-curve,omitempty"`
+}
+
+// TaxLotDisposal is the realised gain of a disposal matched against
+// a single acquisition lot by the configured cost basis method
+type TaxLotDisposal struct {
+	DisposalTime    time.Time       `json:"disposal-time"`
+	AcquisitionTime time.Time       `json:"acquisition-time"`
+	Amount          decimal.Decimal `json:"amount"`
+	CostBasis       decimal.Decimal `json:"cost-basis"`
+	Proceeds        decimal.Decimal `json:"proceeds"`
+	Gain            decimal.Decimal `json:"gain"`
+}
+
+// WashTrade is a round trip trade which opened and closed within the wash
+// trade window at prices within the wash trade price tolerance
+type WashTrade struct {
+	EntryTime              time.Time       `json:"entry-time"`
+	ExitTime               time.Time       `json:"exit-time"`
+	Side                   string          `json:"side"`
+	EntryPrice             decimal.Decimal `json:"entry-price"`
+	ExitPrice              decimal.Decimal `json:"exit-price"`
+	Size                   decimal.Decimal `json:"size"`
+	HoldDuration           time.Duration   `json:"hold-duration"`
+	PriceDifferencePercent decimal.Decimal `json:"price-difference-percent"`
+	Tag                    string          `json:"tag,omitempty"`
+}
+
+// CapacityEstimate is how much starting capital the strategy could deploy on
+// a currency before its fills exceed the target share of candle volume
+type CapacityEstimate struct {
+	TargetParticipationPercent  decimal.Decimal `json:"target-participation-percent"`
+	InitialCapital              decimal.Decimal `json:"initial-capital"`
+	Fills                       int64           `json:"fills"`
+	MedianParticipationPercent  decimal.Decimal `json:"median-participation-percent"`
+	MaximumParticipationPercent decimal.Decimal `json:"maximum-participation-percent"`
+	// EstimatedCapacity is the starting capital at which the median
+	// fill reaches the target participation
+	EstimatedCapacity decimal.Decimal `json:"estimated-capacity"`
+	// ConservativeCapacity is the starting capital at which
+	// the largest fill reaches the target participation
+	ConservativeCapacity decimal.Decimal `json:"conservative-capacity"`
+}
+
+// KellyCriterion is the historical Kelly optimal fraction of capital to risk
+// per trade, derived from the closed round trip trades of a currency. It is
+// calculated in-sample and overstates the edge likely to persist out of sample
+type KellyCriterion struct {
+	RoundTrips   int64           `json:"round-trips"`
+	Wins         int64           `json:"wins"`
+	Losses       int64           `json:"losses"`
+	WinRate      decimal.Decimal `json:"win-rate"`
+	AverageWin   decimal.Decimal `json:"average-win"`
+	AverageLoss  decimal.Decimal `json:"average-loss"`
+	WinLossRatio decimal.Decimal `json:"win-loss-ratio"`
+	FullKelly    decimal.Decimal `json:"full-kelly"`
+	HalfKelly    decimal.Decimal `json:"half-kelly"`
+	QuarterKelly decimal.Decimal `json:"quarter-kelly"`
+	// HasEdge is false when the Kelly fraction is not positive,
+	// suggesting the strategy should not have been traded
+	HasEdge bool `json:"has-edge"`
+}
+
+// RunManifest captures the inputs of a run for reproducibility. Runs with the
+// same config hash, settings, data range and seeds used identical inputs.
+// Slippage sampled between the minimum and maximum slippage rates is not
+// seeded, so only runs with equal minimum and maximum rates reproduce exactly
+type RunManifest struct {
+	ConfigHash       string                     `json:"config-hash"`
+	Version          string                     `json:"version"`
+	GoVersion        string                     `json:"go-version"`
+	StartDate        time.Time                  `json:"start-date"`
+	EndDate          time.Time                  `json:"end-date"`
+	Interval         gctkline.Interval          `json:"interval"`
+	DataType         string                     `json:"data-type"`
+	RandomSeeds      []ManifestSeed             `json:"random-seeds,omitempty"`
+	CurrencySettings []ManifestCurrencySettings `json:"currency-settings"`
+}
+
+// ManifestCurrencySettings are the exchange settings a currency executed with
+type ManifestCurrencySettings struct {
+	Exchange string            `json:"exchange"`
+	Settings exchange.Settings `json:"settings"`
+}
+
+// ManifestSeed is the seed of a random number generator used during the run
+type ManifestSeed struct {
+	Exchange string        `json:"exchange"`
+	Asset    asset.Item    `json:"asset"`
+	Pair     currency.Pair `json:"pair"`
+	Source   string        `json:"source"`
+	Seed     int64         `json:"seed"`
+}
+
 // FundingStatistics stores all funding related statistics
 type FundingStatistics struct {
 	Report             *funding.Report         `json:"-"`
@@ -263,4 +734,32 @@ type TotalFundingStatistics struct {
 	DidStrategyBeatTheMarket bool            `json:"did-strategy-beat-the-market"`
 	DidStrategyMakeProfit    bool            `json:"did-strategy-make-profit"`
 	HoldingValueDifference   decimal.Decimal `json:"holding-value-difference"`
+	AverageCashUtilisation   decimal.Decimal `json:"average-cash-utilisation"`
+	FinalCashUtilisation     decimal.Decimal `json:"final-cash-utilisation"`
+	FinalCashValue           decimal.Decimal `json:"final-cash-value"`
+	FinalPositionsValue      decimal.Decimal `json:"final-positions-value"`
+	NetCapitalFlows          decimal.Decimal `json:"net-capital-flows"`
+	PerformanceFee           *PerformanceFee `json:"performance-fee,omitempty"`
+}
+
+// PerformanceFee holds the high-water mark performance fee charged against
+// the total USD holdings along with their movement before and after it
+type PerformanceFee struct {
+	RatePercent           decimal.Decimal        `json:"rate-percent"`
+	Interval              time.Duration          `json:"interval"`
+	Charges               []PerformanceFeeCharge `json:"charges,omitempty"`
+	TotalFees             decimal.Decimal        `json:"total-fees"`
+	FinalHighWaterMark    decimal.Decimal        `json:"final-high-water-mark"`
+	GrossStrategyMovement decimal.Decimal        `json:"gross-strategy-movement"`
+	NetStrategyMovement   decimal.Decimal        `json:"net-strategy-movement"`
+	NetHoldingValues      []ValueAtTime          `json:"-"`
+}
+
+// PerformanceFeeCharge is a performance fee crystallised
+// when holdings closed above their high-water mark
+type PerformanceFeeCharge struct {
+	Time          time.Time       `json:"time"`
+	HoldingValue  decimal.Decimal `json:"holding-value"`
+	HighWaterMark decimal.Decimal `json:"high-water-mark"`
+	Fee           decimal.Decimal `json:"fee"`
 }
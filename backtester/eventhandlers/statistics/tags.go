@@ -0,0 +1,98 @@
+package statistics
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/shopspring/decimal"
+	"github.com/thrasher-corp/gocryptotrader/backtester/common"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+)
+
+// getTagAllocations returns the percentage of initial funds
+// allocated to each strategy tag for the exchange, asset and pair
+func (s *Statistic) getTagAllocations(exch string, a asset.Item, p currency.Pair) map[string]decimal.Decimal {
+	var resp map[string]decimal.Decimal
+	for i := range s.TagAllocations {
+		if !strings.EqualFold(s.TagAllocations[i].Exchange, exch) ||
+			s.TagAllocations[i].Asset != a ||
+			!s.TagAllocations[i].Pair.Equal(p) {
+			continue
+		}
+		if resp == nil {
+			resp = make(map[string]decimal.Decimal)
+		}
+		resp[s.TagAllocations[i].Tag] = s.TagAllocations[i].Percent
+	}
+	return resp
+}
+
+// calculateTagStatistics segments filled orders by their strategy tag so that
+// performance can be attributed to each order stream. A tag's PNL is the net
+// cash flow of its fills, after fees, plus its remaining position valued at
+// the final close price. Statistics are only set when at least one fill is
+// tagged or tags have capital allocations. Allocated tags are always included
+// and have their equity and return measured against their share of the
+// initial funds
+func (c *CurrencyPairStatistic) calculateTagStatistics() {
+	c.TagStatistics = nil
+	if len(c.Events) == 0 {
+		return
+	}
+	tags := make(map[string]*TagStatistic)
+	var hasTag bool
+	for i := range c.Events {
+		f := c.Events[i].FillEvent
+		if f == nil || !common.CanTransact(f.GetDirection()) {
+			continue
+		}
+		if f.GetTag() != "" {
+			hasTag = true
+		}
+		ts, ok := tags[f.GetTag()]
+		if !ok {
+			ts = &TagStatistic{Tag: f.GetTag()}
+			tags[f.GetTag()] = ts
+		}
+		value := f.GetPurchasePrice().Mul(f.GetAmount())
+		ts.Orders++
+		ts.TotalFees = ts.TotalFees.Add(f.GetExchangeFee())
+		ts.NetCashFlow = ts.NetCashFlow.Sub(f.GetExchangeFee())
+		switch {
+		case f.GetDirection().IsLong():
+			ts.BuyOrders++
+			ts.Position = ts.Position.Add(f.GetAmount())
+			ts.NetCashFlow = ts.NetCashFlow.Sub(value)
+		case f.GetDirection().IsShort():
+			ts.SellOrders++
+			ts.Position = ts.Position.Sub(f.GetAmount())
+			ts.NetCashFlow = ts.NetCashFlow.Add(value)
+		}
+	}
+	if !hasTag && len(c.tagAllocations) == 0 {
+		return
+	}
+	for tag := range c.tagAllocations {
+		if _, ok := tags[tag]; !ok {
+			tags[tag] = &TagStatistic{Tag: tag}
+		}
+	}
+	lastPrice := c.Events[len(c.Events)-1].ClosePrice
+	initialFunds := c.Events[0].Holdings.QuoteInitialFunds
+	for _, ts := range tags {
+		ts.PNL = ts.NetCashFlow.Add(ts.Position.Mul(lastPrice))
+		if percent, ok := c.tagAllocations[ts.Tag]; ok {
+			ts.AllocationPercent = percent
+			ts.AllocatedCapital = initialFunds.Mul(percent).Div(decimal.NewFromInt(100))
+			ts.Equity = ts.AllocatedCapital.Add(ts.PNL)
+			if ts.AllocatedCapital.IsPositive() {
+				ts.ReturnPercent = ts.PNL.Div(ts.AllocatedCapital).Mul(decimal.NewFromInt(100))
+			}
+		}
+		c.TagStatistics = append(c.TagStatistics, *ts)
+	}
+	sort.Slice(c.TagStatistics, func(i, j int) bool {
+		return c.TagStatistics[i].Tag < c.TagStatistics[j].Tag
+	})
+}
@@ -0,0 +1,134 @@
+package statistics
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventhandlers/portfolio/holdings"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/event"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/fill"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	gctorder "github.com/thrasher-corp/gocryptotrader/exchanges/order"
+)
+
+func TestCalculateTagStatistics(t *testing.T) {
+	t.Parallel()
+	c := CurrencyPairStatistic{}
+	c.calculateTagStatistics()
+	if c.TagStatistics != nil {
+		t.Errorf("received '%v' expected '%v'", c.TagStatistics, nil)
+	}
+
+	fills := []struct {
+		side  gctorder.Side
+		price int64
+		tag   string
+	}{
+		{gctorder.Buy, 100, "trend"},
+		{gctorder.Buy, 100, ""},
+		{gctorder.CouldNotBuy, 100, "trend"},
+		{gctorder.Sell, 150, "trend"},
+		{gctorder.Buy, 120, "mean-reversion"},
+	}
+	for i := range fills {
+		c.Events = append(c.Events, DataAtOffset{
+			ClosePrice: decimal.NewFromInt(130),
+			FillEvent: &fill.Fill{
+				Base:          &event.Base{},
+				Direction:     fills[i].side,
+				Amount:        decimal.NewFromInt(1),
+				PurchasePrice: decimal.NewFromInt(fills[i].price),
+				ExchangeFee:   decimal.NewFromInt(1),
+				Tag:           fills[i].tag,
+			},
+		})
+	}
+	c.calculateTagStatistics()
+	if len(c.TagStatistics) != 3 {
+		t.Fatalf("received '%v' expected '%v'", len(c.TagStatistics), 3)
+	}
+	// sorted by tag, untagged first
+	if c.TagStatistics[0].Tag != "" || !c.TagStatistics[0].PNL.Equal(decimal.NewFromInt(29)) {
+		t.Errorf("received '%v' expected '%v'", c.TagStatistics[0].PNL, 29)
+	}
+	if c.TagStatistics[1].Tag != "mean-reversion" || !c.TagStatistics[1].PNL.Equal(decimal.NewFromInt(9)) {
+		t.Errorf("received '%v' expected '%v'", c.TagStatistics[1].PNL, 9)
+	}
+	trend := c.TagStatistics[2]
+	if trend.Orders != 2 || trend.BuyOrders != 1 || trend.SellOrders != 1 {
+		t.Errorf("received '%v' expected '%v'", trend.Orders, 2)
+	}
+	if !trend.Position.IsZero() {
+		t.Errorf("received '%v' expected '%v'", trend.Position, 0)
+	}
+	if !trend.PNL.Equal(decimal.NewFromInt(48)) {
+		t.Errorf("received '%v' expected '%v'", trend.PNL, 48)
+	}
+}
+
+func TestCalculateTagStatisticsAllocations(t *testing.T) {
+	t.Parallel()
+	c := CurrencyPairStatistic{
+		Events: []DataAtOffset{
+			{
+				ClosePrice: decimal.NewFromInt(130),
+				Holdings:   holdings.Holding{QuoteInitialFunds: decimal.NewFromInt(1000)},
+				FillEvent: &fill.Fill{
+					Base:          &event.Base{},
+					Direction:     gctorder.Buy,
+					Amount:        decimal.NewFromInt(1),
+					PurchasePrice: decimal.NewFromInt(100),
+					ExchangeFee:   decimal.NewFromInt(1),
+					Tag:           "trend",
+				},
+			},
+		},
+		tagAllocations: map[string]decimal.Decimal{
+			"trend":          decimal.NewFromInt(60),
+			"mean-reversion": decimal.NewFromInt(40),
+		},
+	}
+	c.calculateTagStatistics()
+	if len(c.TagStatistics) != 2 {
+		t.Fatalf("received '%v' expected '%v'", len(c.TagStatistics), 2)
+	}
+	// allocated tags without fills are still reported
+	meanReversion := c.TagStatistics[0]
+	if meanReversion.Tag != "mean-reversion" || meanReversion.Orders != 0 {
+		t.Errorf("received '%+v' expected mean-reversion without orders", meanReversion)
+	}
+	if !meanReversion.AllocatedCapital.Equal(decimal.NewFromInt(400)) || !meanReversion.Equity.Equal(decimal.NewFromInt(400)) {
+		t.Errorf("received '%v' '%v' expected '%v'", meanReversion.AllocatedCapital, meanReversion.Equity, 400)
+	}
+	trend := c.TagStatistics[1]
+	if !trend.AllocatedCapital.Equal(decimal.NewFromInt(600)) {
+		t.Errorf("received '%v' expected '%v'", trend.AllocatedCapital, 600)
+	}
+	// 29 PNL on 600 capital
+	if !trend.Equity.Equal(decimal.NewFromInt(629)) {
+		t.Errorf("received '%v' expected '%v'", trend.Equity, 629)
+	}
+	expectedReturn := decimal.NewFromInt(29).Div(decimal.NewFromInt(600)).Mul(decimal.NewFromInt(100))
+	if !trend.ReturnPercent.Equal(expectedReturn) {
+		t.Errorf("received '%v' expected '%v'", trend.ReturnPercent, expectedReturn)
+	}
+}
+
+func TestGetTagAllocations(t *testing.T) {
+	t.Parallel()
+	cp := currency.NewPair(currency.BTC, currency.USDT)
+	s := Statistic{
+		TagAllocations: []TagAllocation{
+			{Exchange: "Binance", Asset: asset.Spot, Pair: cp, Tag: "trend", Percent: decimal.NewFromInt(60)},
+			{Exchange: "Binance", Asset: asset.Futures, Pair: cp, Tag: "carry", Percent: decimal.NewFromInt(50)},
+		},
+	}
+	if resp := s.getTagAllocations("binance", asset.Margin, cp); resp != nil {
+		t.Errorf("received '%v' expected '%v'", resp, nil)
+	}
+	resp := s.getTagAllocations("binance", asset.Spot, cp)
+	if len(resp) != 1 || !resp["trend"].Equal(decimal.NewFromInt(60)) {
+		t.Errorf("received '%v' expected trend allocation of '%v'", resp, 60)
+	}
+}
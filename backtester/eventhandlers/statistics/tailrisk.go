@@ -0,0 +1,89 @@
+package statistics
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/shopspring/decimal"
+)
+
+// minimumTailObservations is the number of returns required beyond a
+// percentile for it to be considered reliable. Fewer returns than this in a
+// tail flags the measure as having insufficient data
+const minimumTailObservations = 5
+
+var (
+	// defaultValueAtRiskConfidenceLevels are used when
+	// no confidence levels are configured
+	defaultValueAtRiskConfidenceLevels = []decimal.Decimal{decimal.NewFromInt(95), decimal.NewFromInt(99)}
+	// tailRatioPercentile is the percentage of returns in
+	// each tail compared when calculating the tail ratio
+	tailRatioPercentile = decimal.NewFromInt(5)
+)
+
+// calculateTailRisk calculates the historical value at risk and conditional
+// value at risk of the returns at each confidence level, along with the tail
+// ratio of the 95th percentile return against the 5th percentile return.
+// Confidence levels are percentages, eg 95 measures the worst 5% of returns.
+// Losses are reported as positive percentages. Measures whose tails hold
+// fewer than minimumTailObservations returns are flagged as unreliable
+func calculateTailRisk(returns, confidenceLevels []decimal.Decimal) (*TailRisk, error) {
+	if len(returns) == 0 {
+		return nil, errReceivedNoData
+	}
+	if len(confidenceLevels) == 0 {
+		confidenceLevels = defaultValueAtRiskConfidenceLevels
+	}
+	oneHundred := decimal.NewFromInt(100)
+	sorted := make([]decimal.Decimal, len(returns))
+	copy(sorted, returns)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].LessThan(sorted[j])
+	})
+	resp := &TailRisk{
+		Observations: int64(len(sorted)),
+	}
+	for i := range confidenceLevels {
+		if !confidenceLevels[i].IsPositive() || confidenceLevels[i].GreaterThanOrEqual(oneHundred) {
+			return nil, fmt.Errorf("%w %v, must be between 0 and 100", errInvalidConfidenceLevel, confidenceLevels[i])
+		}
+		tailPercent := oneHundred.Sub(confidenceLevels[i])
+		index := percentileIndex(len(sorted), tailPercent)
+		var tailSum decimal.Decimal
+		for j := 0; j <= index; j++ {
+			tailSum = tailSum.Add(sorted[j])
+		}
+		resp.ValueAtRisk = append(resp.ValueAtRisk, ValueAtRisk{
+			ConfidenceLevel:        confidenceLevels[i],
+			ValueAtRisk:            sorted[index].Neg().Mul(oneHundred),
+			ConditionalValueAtRisk: tailSum.Div(decimal.NewFromInt(int64(index + 1))).Neg().Mul(oneHundred),
+			InsufficientData:       !hasEnoughTailObservations(len(sorted), tailPercent),
+		})
+	}
+	leftTail := sorted[percentileIndex(len(sorted), tailRatioPercentile)]
+	rightTail := sorted[percentileIndex(len(sorted), oneHundred.Sub(tailRatioPercentile))]
+	if !leftTail.IsZero() {
+		resp.TailRatio = rightTail.Abs().Div(leftTail.Abs())
+	}
+	resp.TailRatioInsufficientData = !hasEnoughTailObservations(len(sorted), tailRatioPercentile)
+	return resp, nil
+}
+
+// percentileIndex returns the nearest rank index of the
+// percentile within a sorted series of the length provided
+func percentileIndex(length int, percentile decimal.Decimal) int {
+	index := int(decimal.NewFromInt(int64(length)).Mul(percentile).Div(decimal.NewFromInt(100)).Ceil().IntPart()) - 1
+	if index < 0 {
+		return 0
+	}
+	if index >= length {
+		return length - 1
+	}
+	return index
+}
+
+// hasEnoughTailObservations returns whether a tail holding the percentage
+// of a series of the length provided has enough returns to be reliable
+func hasEnoughTailObservations(length int, tailPercent decimal.Decimal) bool {
+	return decimal.NewFromInt(int64(length)).Mul(tailPercent).Div(decimal.NewFromInt(100)).GreaterThanOrEqual(decimal.NewFromInt(minimumTailObservations))
+}
@@ -0,0 +1,81 @@
+package statistics
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestCalculateTailRisk(t *testing.T) {
+	t.Parallel()
+	_, err := calculateTailRisk(nil, nil)
+	if !errors.Is(err, errReceivedNoData) {
+		t.Errorf("received '%v' expected '%v'", err, errReceivedNoData)
+	}
+
+	// returns of -10% to 9.9% in 0.1% steps
+	returns := make([]decimal.Decimal, 200)
+	for i := range returns {
+		returns[len(returns)-1-i] = decimal.NewFromInt(int64(i - 100)).Div(decimal.NewFromInt(1000))
+	}
+	_, err = calculateTailRisk(returns, []decimal.Decimal{decimal.NewFromInt(100)})
+	if !errors.Is(err, errInvalidConfidenceLevel) {
+		t.Errorf("received '%v' expected '%v'", err, errInvalidConfidenceLevel)
+	}
+
+	tr, err := calculateTailRisk(returns, nil)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	if tr.Observations != 200 {
+		t.Errorf("received '%v' expected '%v'", tr.Observations, 200)
+	}
+	if len(tr.ValueAtRisk) != 2 {
+		t.Fatalf("received '%v' expected '%v'", len(tr.ValueAtRisk), 2)
+	}
+	// the 10th worst return of 200 is -9.1%, averaging -9.55% with those below it
+	v := tr.ValueAtRisk[0]
+	if !v.ConfidenceLevel.Equal(decimal.NewFromInt(95)) || !v.ValueAtRisk.Equal(decimal.NewFromFloat(9.1)) || !v.ConditionalValueAtRisk.Equal(decimal.NewFromFloat(9.55)) {
+		t.Errorf("received '%+v' expected 95%% value at risk of 9.1 and conditional value at risk of 9.55", v)
+	}
+	if v.InsufficientData {
+		t.Error("expected 10 returns in the tail to be sufficient")
+	}
+	// only 2 returns of 200 fall beyond 99%
+	v = tr.ValueAtRisk[1]
+	if !v.ValueAtRisk.Equal(decimal.NewFromFloat(9.9)) || !v.ConditionalValueAtRisk.Equal(decimal.NewFromFloat(9.95)) {
+		t.Errorf("received '%+v' expected 99%% value at risk of 9.9 and conditional value at risk of 9.95", v)
+	}
+	if !v.InsufficientData {
+		t.Error("expected 2 returns in the tail to be insufficient")
+	}
+	// 95th percentile return of 8.9% against the 5th of -9.1%
+	if !tr.TailRatio.Equal(decimal.NewFromFloat(0.089).Div(decimal.NewFromFloat(0.091))) {
+		t.Errorf("received '%v' expected '%v'", tr.TailRatio, decimal.NewFromFloat(0.089).Div(decimal.NewFromFloat(0.091)))
+	}
+	if tr.TailRatioInsufficientData {
+		t.Error("expected tail ratio to have sufficient data")
+	}
+
+	tr, err = calculateTailRisk(returns[:20], []decimal.Decimal{decimal.NewFromInt(90)})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	if !tr.ValueAtRisk[0].InsufficientData || !tr.TailRatioInsufficientData {
+		t.Errorf("received '%+v' expected small sample to be flagged as insufficient data", tr)
+	}
+}
+
+func TestPercentileIndex(t *testing.T) {
+	t.Parallel()
+	if idx := percentileIndex(200, decimal.NewFromInt(5)); idx != 9 {
+		t.Errorf("received '%v' expected '%v'", idx, 9)
+	}
+	if idx := percentileIndex(10, decimal.NewFromInt(1)); idx != 0 {
+		t.Errorf("received '%v' expected '%v'", idx, 0)
+	}
+	if idx := percentileIndex(10, decimal.NewFromInt(100)); idx != 9 {
+		t.Errorf("received '%v' expected '%v'", idx, 9)
+	}
+}
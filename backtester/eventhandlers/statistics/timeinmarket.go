@@ -0,0 +1,72 @@
+package statistics
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// newTimeInMarket summarises in market and flat candles
+// into the percentage and duration spent holding a position
+func newTimeInMarket(inMarket, flat int64, interval time.Duration) TimeInMarket {
+	tim := TimeInMarket{
+		InMarketCandles:  inMarket,
+		FlatCandles:      flat,
+		InMarketDuration: interval * time.Duration(inMarket),
+	}
+	if total := inMarket + flat; total > 0 {
+		tim.InMarketPercent = decimal.NewFromInt(inMarket).Div(decimal.NewFromInt(total)).Mul(decimal.NewFromInt(100))
+	}
+	return tim
+}
+
+// calculateTimeInMarket determines how many of the currency's candles ended
+// holding a position versus flat in cash
+func (c *CurrencyPairStatistic) calculateTimeInMarket() {
+	if len(c.Events) == 0 {
+		return
+	}
+	var inMarket, flat int64
+	for i := range c.Events {
+		if c.Events[i].Holdings.BaseSize.IsZero() {
+			flat++
+			continue
+		}
+		inMarket++
+	}
+	var interval time.Duration
+	if c.Events[0].DataEvent != nil {
+		interval = c.Events[0].DataEvent.GetInterval().Duration()
+	}
+	c.TimeInMarket = newTimeInMarket(inMarket, flat, interval)
+}
+
+// calculateTimeInMarket determines how many candles across the whole run
+// ended with any currency holding a position versus entirely flat in cash
+func (s *Statistic) calculateTimeInMarket() {
+	heldAtTime := make(map[time.Time]bool)
+	for _, assetMap := range s.ExchangeAssetPairStatistics {
+		for _, pairMap := range assetMap {
+			for _, stats := range pairMap {
+				for i := range stats.Events {
+					t := stats.Events[i].Time
+					heldAtTime[t] = heldAtTime[t] || !stats.Events[i].Holdings.BaseSize.IsZero()
+				}
+			}
+		}
+	}
+	if len(heldAtTime) == 0 {
+		s.TimeInMarket = nil
+		return
+	}
+	var inMarket, flat int64
+	for _, held := range heldAtTime {
+		if held {
+			inMarket++
+			continue
+		}
+		flat++
+	}
+	tim := newTimeInMarket(inMarket, flat, s.CandleInterval.Duration())
+	s.TimeInMarket = &tim
+}
@@ -0,0 +1,66 @@
+package statistics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventhandlers/portfolio/holdings"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/event"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/kline"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	gctkline "github.com/thrasher-corp/gocryptotrader/exchanges/kline"
+)
+
+func TestCalculateTimeInMarket(t *testing.T) {
+	t.Parallel()
+	tt := time.Now()
+	newEvents := func(sizes ...int64) []DataAtOffset {
+		events := make([]DataAtOffset, len(sizes))
+		for i := range sizes {
+			events[i] = DataAtOffset{
+				Time:      tt.Add(time.Hour * time.Duration(i)),
+				DataEvent: &kline.Kline{Base: &event.Base{Interval: gctkline.OneHour}},
+				Holdings:  holdings.Holding{BaseSize: decimal.NewFromInt(sizes[i])},
+			}
+		}
+		return events
+	}
+	btc := &CurrencyPairStatistic{Events: newEvents(0, 1, 1, 0)}
+	btc.calculateTimeInMarket()
+	if btc.TimeInMarket.InMarketCandles != 2 || btc.TimeInMarket.FlatCandles != 2 {
+		t.Errorf("received '%v' '%v' expected '%v' '%v'", btc.TimeInMarket.InMarketCandles, btc.TimeInMarket.FlatCandles, 2, 2)
+	}
+	if !btc.TimeInMarket.InMarketPercent.Equal(decimal.NewFromInt(50)) {
+		t.Errorf("received '%v' expected '%v'", btc.TimeInMarket.InMarketPercent, 50)
+	}
+	if btc.TimeInMarket.InMarketDuration != time.Hour*2 {
+		t.Errorf("received '%v' expected '%v'", btc.TimeInMarket.InMarketDuration, time.Hour*2)
+	}
+
+	s := &Statistic{
+		CandleInterval: gctkline.OneHour,
+		ExchangeAssetPairStatistics: map[string]map[asset.Item]map[currency.Pair]*CurrencyPairStatistic{
+			testExchange: {
+				asset.Spot: {
+					currency.NewPair(currency.BTC, currency.USDT): btc,
+					currency.NewPair(currency.ETH, currency.USDT): {Events: newEvents(0, 0, -1, -1)},
+				},
+			},
+		},
+	}
+	s.calculateTimeInMarket()
+	if s.TimeInMarket == nil {
+		t.Fatal("expected time in market")
+	}
+	if s.TimeInMarket.InMarketCandles != 3 || s.TimeInMarket.FlatCandles != 1 {
+		t.Errorf("received '%v' '%v' expected '%v' '%v'", s.TimeInMarket.InMarketCandles, s.TimeInMarket.FlatCandles, 3, 1)
+	}
+	if !s.TimeInMarket.InMarketPercent.Equal(decimal.NewFromInt(75)) {
+		t.Errorf("received '%v' expected '%v'", s.TimeInMarket.InMarketPercent, 75)
+	}
+	if s.TimeInMarket.InMarketDuration != time.Hour*3 {
+		t.Errorf("received '%v' expected '%v'", s.TimeInMarket.InMarketDuration, time.Hour*3)
+	}
+}
@@ -0,0 +1,40 @@
+package statistics
+
+import (
+	"github.com/shopspring/decimal"
+	gctkline "github.com/thrasher-corp/gocryptotrader/exchanges/kline"
+)
+
+const (
+	// TradingCalendarContinuous annualises returns assuming the instrument
+	// trades every day of the year, as crypto markets do
+	TradingCalendarContinuous = "continuous"
+	// TradingCalendarBusinessDays annualises returns assuming the instrument
+	// only trades on 252 business days a year, as traditional markets do
+	TradingCalendarBusinessDays = "business-days"
+
+	calendarDaysPerYear = 365
+	businessDaysPerYear = 252
+)
+
+// intervalsPerYear returns the number of candle intervals in a trading year
+// for the calendar. Business day calendars exclude weekend and holiday closures
+// so that candles which only exist on trading days are not over annualised
+func intervalsPerYear(interval gctkline.Interval, calendar string) float64 {
+	perYear := interval.IntervalsPerYear()
+	if calendar == TradingCalendarBusinessDays {
+		perYear = perYear * businessDaysPerYear / calendarDaysPerYear
+	}
+	return perYear
+}
+
+// riskFreeRatePerCandle returns the annual risk free rate spread across each
+// candle of the trading year. An unknown interval has no candles to spread
+// the rate across and returns zero rather than dividing by zero
+func riskFreeRatePerCandle(riskFreeRate decimal.Decimal, interval gctkline.Interval, calendar string) decimal.Decimal {
+	perYear := intervalsPerYear(interval, calendar)
+	if perYear <= 0 {
+		return decimal.Zero
+	}
+	return riskFreeRate.Div(decimal.NewFromFloat(perYear))
+}
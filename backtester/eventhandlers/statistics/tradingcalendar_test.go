@@ -0,0 +1,35 @@
+package statistics
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+
+	gctkline "github.com/thrasher-corp/gocryptotrader/exchanges/kline"
+)
+
+func TestIntervalsPerYear(t *testing.T) {
+	t.Parallel()
+	if p := intervalsPerYear(gctkline.OneDay, TradingCalendarContinuous); p != 365 {
+		t.Errorf("received '%v' expected '%v'", p, 365)
+	}
+	if p := intervalsPerYear(gctkline.OneDay, ""); p != 365 {
+		t.Errorf("received '%v' expected '%v'", p, 365)
+	}
+	if p := intervalsPerYear(gctkline.OneDay, TradingCalendarBusinessDays); p != 252 {
+		t.Errorf("received '%v' expected '%v'", p, 252)
+	}
+	if p := intervalsPerYear(gctkline.OneHour, TradingCalendarBusinessDays); p != 252*24 {
+		t.Errorf("received '%v' expected '%v'", p, 252*24)
+	}
+}
+
+func TestRiskFreeRatePerCandle(t *testing.T) {
+	t.Parallel()
+	if r := riskFreeRatePerCandle(decimal.NewFromInt(365), gctkline.OneDay, TradingCalendarContinuous); !r.Equal(decimal.NewFromInt(1)) {
+		t.Errorf("received '%v' expected '%v'", r, 1)
+	}
+	if r := riskFreeRatePerCandle(decimal.NewFromInt(365), 0, TradingCalendarContinuous); !r.IsZero() {
+		t.Errorf("received '%v' expected '%v'", r, 0)
+	}
+}
@@ -0,0 +1,113 @@
+package statistics
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/shopspring/decimal"
+	gctmath "github.com/thrasher-corp/gocryptotrader/common/math"
+)
+
+// CalculateVolatilityRegimes segments the run into volatility regimes using
+// quantiles of the rolling volatility of the close price series. Each candle
+// after the initial window is assigned to a regime and performance metrics are
+// calculated separately per regime. Regime zero is the calmest
+func (c *CurrencyPairStatistic) CalculateVolatilityRegimes(window, regimeCount int64, riskFreeRatePerCandle decimal.Decimal) ([]VolatilityRegime, error) {
+	if window < 2 {
+		return nil, fmt.Errorf("%w window %v, must be at least 2", errInvalidRegimeSettings, window)
+	}
+	if regimeCount < 1 {
+		return nil, fmt.Errorf("%w regime count %v, must be at least 1", errInvalidRegimeSettings, regimeCount)
+	}
+	if int64(len(c.Events)) <= window {
+		return nil, fmt.Errorf("%w, %v events for a window of %v", errNotEnoughEventsForRegimes, len(c.Events), window)
+	}
+
+	marketReturns := make([]decimal.Decimal, len(c.Events))
+	for i := 1; i < len(c.Events); i++ {
+		if c.Events[i-1].ClosePrice.IsZero() {
+			continue
+		}
+		marketReturns[i] = c.Events[i].ClosePrice.Sub(c.Events[i-1].ClosePrice).Div(c.Events[i-1].ClosePrice)
+	}
+
+	// volatilities[j] relates to event index j+window
+	volatilities := make([]decimal.Decimal, int64(len(c.Events))-window)
+	for i := range volatilities {
+		end := int64(i) + window + 1
+		vol, err := gctmath.DecimalPopulationStandardDeviation(marketReturns[end-window : end])
+		if err != nil && !errors.Is(err, gctmath.ErrInexactConversion) {
+			return nil, err
+		}
+		volatilities[i] = vol
+	}
+
+	sorted := make([]decimal.Decimal, len(volatilities))
+	copy(sorted, volatilities)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].LessThan(sorted[j])
+	})
+	regimes := make([]VolatilityRegime, regimeCount)
+	for i := range regimes {
+		lowerIndex := int64(i) * int64(len(sorted)) / regimeCount
+		upperIndex := (int64(i)+1)*int64(len(sorted))/regimeCount - 1
+		if upperIndex < lowerIndex {
+			upperIndex = lowerIndex
+		}
+		regimes[i] = VolatilityRegime{
+			Regime:          int64(i),
+			LowerVolatility: sorted[lowerIndex],
+			UpperVolatility: sorted[upperIndex],
+		}
+	}
+
+	strategyReturns := make([][]decimal.Decimal, regimeCount)
+	regimeMarketReturns := make([][]decimal.Decimal, regimeCount)
+	for i := range volatilities {
+		r := regimeForVolatility(regimes, volatilities[i])
+		ev := c.Events[int64(i)+window]
+		strategyReturns[r] = append(strategyReturns[r], ev.Holdings.ChangeInTotalValuePercent)
+		regimeMarketReturns[r] = append(regimeMarketReturns[r], marketReturns[int64(i)+window])
+	}
+
+	oneHundred := decimal.NewFromInt(100)
+	for i := range regimes {
+		regimes[i].Candles = int64(len(strategyReturns[i]))
+		if regimes[i].Candles == 0 {
+			continue
+		}
+		regimes[i].StrategyReturn = compoundReturns(strategyReturns[i]).Mul(oneHundred)
+		regimes[i].MarketReturn = compoundReturns(regimeMarketReturns[i]).Mul(oneHundred)
+		mean, err := gctmath.DecimalArithmeticMean(strategyReturns[i])
+		if err != nil {
+			return nil, err
+		}
+		regimes[i].SharpeRatio, err = gctmath.DecimalSharpeRatio(strategyReturns[i], riskFreeRatePerCandle, mean)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return regimes, nil
+}
+
+// regimeForVolatility returns the calmest regime whose upper boundary
+// contains the volatility, where regimes are sorted by ascending volatility
+func regimeForVolatility(regimes []VolatilityRegime, volatility decimal.Decimal) int {
+	for i := range regimes {
+		if volatility.LessThanOrEqual(regimes[i].UpperVolatility) {
+			return i
+		}
+	}
+	return len(regimes) - 1
+}
+
+// compoundReturns compounds a series of per candle returns
+// into a total return for the series
+func compoundReturns(returns []decimal.Decimal) decimal.Decimal {
+	total := decimal.NewFromInt(1)
+	for i := range returns {
+		total = total.Mul(decimal.NewFromInt(1).Add(returns[i]))
+	}
+	return total.Sub(decimal.NewFromInt(1))
+}
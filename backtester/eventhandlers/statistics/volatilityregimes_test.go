@@ -0,0 +1,73 @@
+package statistics
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventhandlers/portfolio/holdings"
+)
+
+func TestCalculateVolatilityRegimes(t *testing.T) {
+	t.Parallel()
+	c := CurrencyPairStatistic{}
+	_, err := c.CalculateVolatilityRegimes(1, 2, decimal.Zero)
+	if !errors.Is(err, errInvalidRegimeSettings) {
+		t.Errorf("received '%v' expected '%v'", err, errInvalidRegimeSettings)
+	}
+	_, err = c.CalculateVolatilityRegimes(2, 0, decimal.Zero)
+	if !errors.Is(err, errInvalidRegimeSettings) {
+		t.Errorf("received '%v' expected '%v'", err, errInvalidRegimeSettings)
+	}
+	_, err = c.CalculateVolatilityRegimes(2, 2, decimal.Zero)
+	if !errors.Is(err, errNotEnoughEventsForRegimes) {
+		t.Errorf("received '%v' expected '%v'", err, errNotEnoughEventsForRegimes)
+	}
+
+	// calm prices followed by wild swings
+	prices := []int64{100, 101, 100, 101, 100, 101, 150, 90, 160, 80}
+	for i := range prices {
+		c.Events = append(c.Events, DataAtOffset{
+			ClosePrice: decimal.NewFromInt(prices[i]),
+			Holdings: holdings.Holding{
+				ChangeInTotalValuePercent: decimal.NewFromFloat(0.01),
+			},
+		})
+	}
+	regimes, err := c.CalculateVolatilityRegimes(2, 2, decimal.Zero)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	if len(regimes) != 2 {
+		t.Fatalf("received '%v' expected '%v'", len(regimes), 2)
+	}
+	if !regimes[0].UpperVolatility.LessThan(regimes[1].LowerVolatility) {
+		t.Errorf("received '%v' expected less than '%v'", regimes[0].UpperVolatility, regimes[1].LowerVolatility)
+	}
+	if regimes[0].Candles+regimes[1].Candles != int64(len(prices))-2 {
+		t.Errorf("received '%v' expected '%v'", regimes[0].Candles+regimes[1].Candles, len(prices)-2)
+	}
+	if regimes[0].Candles != 4 {
+		t.Errorf("received '%v' expected '%v'", regimes[0].Candles, 4)
+	}
+	if !regimes[0].StrategyReturn.IsPositive() {
+		t.Errorf("received '%v' expected positive strategy return", regimes[0].StrategyReturn)
+	}
+}
+
+func TestRegimeForVolatility(t *testing.T) {
+	t.Parallel()
+	regimes := []VolatilityRegime{
+		{UpperVolatility: decimal.NewFromInt(1)},
+		{UpperVolatility: decimal.NewFromInt(2)},
+	}
+	if r := regimeForVolatility(regimes, decimal.NewFromInt(1)); r != 0 {
+		t.Errorf("received '%v' expected '%v'", r, 0)
+	}
+	if r := regimeForVolatility(regimes, decimal.NewFromFloat(1.5)); r != 1 {
+		t.Errorf("received '%v' expected '%v'", r, 1)
+	}
+	if r := regimeForVolatility(regimes, decimal.NewFromInt(3)); r != 1 {
+		t.Errorf("received '%v' expected '%v'", r, 1)
+	}
+}
@@ -0,0 +1,61 @@
+package statistics
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/fill"
+	"github.com/thrasher-corp/gocryptotrader/backtester/report/trades"
+)
+
+// CalculateWashTrades flags round trip trades which opened and closed within
+// the window at prices within the tolerance percentage of each other. Such
+// trades have little economic purpose and resemble wash trades which a real
+// venue may penalise. Round trips are rebuilt from the currency's fills and
+// trades still open are never flagged
+func (c *CurrencyPairStatistic) CalculateWashTrades(window time.Duration, priceTolerancePercent decimal.Decimal) ([]WashTrade, error) {
+	if window <= 0 || priceTolerancePercent.IsNegative() {
+		return nil, fmt.Errorf("%w window %v price tolerance %v%%", errInvalidWashTradeSettings, window, priceTolerancePercent)
+	}
+	var fills []fill.Event
+	for i := range c.Events {
+		if c.Events[i].FillEvent != nil {
+			fills = append(fills, c.Events[i].FillEvent)
+		}
+	}
+	roundTrips, err := trades.NewExporter().RoundTrips(fills, nil)
+	if err != nil {
+		return nil, err
+	}
+	var resp []WashTrade
+	oneHundred := decimal.NewFromInt(100)
+	for i := range roundTrips {
+		rt := roundTrips[i]
+		if rt.IsOpen || rt.ExitTime == nil || rt.EntryPrice == 0 {
+			continue
+		}
+		held := rt.ExitTime.Sub(rt.EntryTime)
+		if held > window {
+			continue
+		}
+		entryPrice := decimal.NewFromFloat(rt.EntryPrice)
+		exitPrice := decimal.NewFromFloat(rt.ExitPrice)
+		difference := exitPrice.Sub(entryPrice).Abs().Div(entryPrice).Mul(oneHundred)
+		if difference.GreaterThan(priceTolerancePercent) {
+			continue
+		}
+		resp = append(resp, WashTrade{
+			EntryTime:              rt.EntryTime,
+			ExitTime:               *rt.ExitTime,
+			Side:                   rt.Side,
+			EntryPrice:             entryPrice,
+			ExitPrice:              exitPrice,
+			Size:                   decimal.NewFromFloat(rt.Size),
+			HoldDuration:           held,
+			PriceDifferencePercent: difference,
+			Tag:                    rt.Tag,
+		})
+	}
+	return resp, nil
+}
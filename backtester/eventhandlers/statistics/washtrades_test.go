@@ -0,0 +1,78 @@
+package statistics
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/event"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/fill"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	gctorder "github.com/thrasher-corp/gocryptotrader/exchanges/order"
+)
+
+func TestCalculateWashTrades(t *testing.T) {
+	t.Parallel()
+	c := CurrencyPairStatistic{}
+	_, err := c.CalculateWashTrades(0, decimal.Zero)
+	if !errors.Is(err, errInvalidWashTradeSettings) {
+		t.Errorf("received '%v' expected '%v'", err, errInvalidWashTradeSettings)
+	}
+	_, err = c.CalculateWashTrades(time.Minute, decimal.NewFromInt(-1))
+	if !errors.Is(err, errInvalidWashTradeSettings) {
+		t.Errorf("received '%v' expected '%v'", err, errInvalidWashTradeSettings)
+	}
+
+	tt := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	fills := []struct {
+		side    gctorder.Side
+		price   float64
+		elapsed time.Duration
+	}{
+		// closed within a minute at a near identical price
+		{gctorder.Buy, 100, 0},
+		{gctorder.Sell, 100.05, time.Minute},
+		// closed within a minute at a very different price
+		{gctorder.Buy, 100, time.Hour},
+		{gctorder.Sell, 110, time.Hour + time.Minute},
+		// closed at a near identical price after a day
+		{gctorder.Buy, 100, time.Hour * 2},
+		{gctorder.Sell, 100, time.Hour * 26},
+		// still open
+		{gctorder.Buy, 100, time.Hour * 27},
+	}
+	for i := range fills {
+		c.Events = append(c.Events, DataAtOffset{
+			FillEvent: &fill.Fill{
+				Base:          &event.Base{Time: tt.Add(fills[i].elapsed), AssetType: asset.Spot},
+				Direction:     fills[i].side,
+				Amount:        decimal.NewFromInt(1),
+				PurchasePrice: decimal.NewFromFloat(fills[i].price),
+				Order:         &gctorder.Detail{Side: fills[i].side},
+			},
+		}, DataAtOffset{})
+	}
+
+	washTrades, err := c.CalculateWashTrades(time.Minute*5, decimal.NewFromFloat(0.1))
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	if len(washTrades) != 1 {
+		t.Fatalf("received '%v' expected '%v'", len(washTrades), 1)
+	}
+	if !washTrades[0].EntryTime.Equal(tt) || washTrades[0].HoldDuration != time.Minute {
+		t.Errorf("received '%v' '%v' expected '%v' '%v'", washTrades[0].EntryTime, washTrades[0].HoldDuration, tt, time.Minute)
+	}
+	if !washTrades[0].PriceDifferencePercent.Equal(decimal.NewFromFloat(0.05)) {
+		t.Errorf("received '%v' expected '%v'", washTrades[0].PriceDifferencePercent, 0.05)
+	}
+
+	washTrades, err = c.CalculateWashTrades(time.Hour*25, decimal.NewFromInt(10))
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	if len(washTrades) != 3 {
+		t.Errorf("received '%v' expected '%v'", len(washTrades), 3)
+	}
+}
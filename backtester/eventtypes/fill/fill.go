@@ -1,6 +1,8 @@
 package fill
 
 import (
+	"time"
+
 	"github.com/shopspring/decimal"
 	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/signal"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
@@ -36,6 +38,12 @@ func (f *Fill) GetVolumeAdjustedPrice() decimal.Decimal {
 	return f.VolumeAdjustedPrice
 }
 
+// IsVolumeAdjusted highlights whether the order price or amount
+// was adjusted to fit within the candle's high, low and volume
+func (f *Fill) IsVolumeAdjusted() bool {
+	return f.WasVolumeAdjusted
+}
+
 // GetPurchasePrice returns the purchase price
 func (f *Fill) GetPurchasePrice() decimal.Decimal {
 	return f.PurchasePrice
@@ -77,3 +85,183 @@ func (f *Fill) GetFillDependentEvent() signal.Event {
 func (f *Fill) IsLiquidated() bool {
 	return f.Liquidated
 }
+
+// GetFundingLedger returns the funding operations recorded for the order
+func (f *Fill) GetFundingLedger() []LedgerEntry {
+	return f.FundingLedger
+}
+
+// GetLatency returns the delay the order experienced before filling
+func (f *Fill) GetLatency() time.Duration {
+	return f.Latency
+}
+
+// IsStopOut returns whether the fill closed a position
+// that breached its maximum drawdown
+func (f *Fill) IsStopOut() bool {
+	return f.StopOut
+}
+
+// GetTag returns the strategy's label for the filled order's stream
+func (f *Fill) GetTag() string {
+	return f.Tag
+}
+
+// GetAccount returns the name of the account which funded the filled order
+func (f *Fill) GetAccount() string {
+	return f.Account
+}
+
+// IsCapitalCapBound returns whether the order was rejected
+// for exceeding its maximum capital allocation
+func (f *Fill) IsCapitalCapBound() bool {
+	return f.CapitalCapBound
+}
+
+// IsCircuitBreakerTriggered returns whether the fill completed
+// the losing streak which triggered the circuit breaker
+func (f *Fill) IsCircuitBreakerTriggered() bool {
+	return f.CircuitBreakerTriggered
+}
+
+// IsCircuitBreakerBound returns whether the order was rejected
+// while the circuit breaker halted entries
+func (f *Fill) IsCircuitBreakerBound() bool {
+	return f.CircuitBreakerBound
+}
+
+// GetEffectiveSpread returns the effective spread paid against the
+// orderbook midpoint and whether it was recorded for the fill
+func (f *Fill) GetEffectiveSpread() (decimal.Decimal, bool) {
+	return f.EffectiveSpread, f.HasEffectiveSpread
+}
+
+// GetAccumulatedRebate returns the rebate withheld
+// from available funds to be accumulated separately
+func (f *Fill) GetAccumulatedRebate() decimal.Decimal {
+	return f.AccumulatedRebate
+}
+
+// GetLiquidationCost returns the cost of the liquidation
+// penalty slippage and fee charged to the fill
+func (f *Fill) GetLiquidationCost() decimal.Decimal {
+	return f.LiquidationCost
+}
+
+// GetLimitPrice returns the price of the limit order
+// which was filled, or zero for a market order
+func (f *Fill) GetLimitPrice() decimal.Decimal {
+	return f.LimitPrice
+}
+
+// IsRestingLimit returns whether the limit order was not touched
+// by the candle and was left open rather than filled
+func (f *Fill) IsRestingLimit() bool {
+	return f.RestingLimit
+}
+
+// GetFeeType returns whether the maker or taker fee was applied to the order
+func (f *Fill) GetFeeType() string {
+	return f.FeeType
+}
+
+// GetStopPrice returns the trigger price of the stop order
+// which was filled, or zero for an order without a stop
+func (f *Fill) GetStopPrice() decimal.Decimal {
+	return f.StopPrice
+}
+
+// IsDormantStop returns whether the stop order was not triggered
+// by the candle and was left dormant rather than filled
+func (f *Fill) IsDormantStop() bool {
+	return f.DormantStop
+}
+
+// GetTakeProfitPrice returns the target price of the take-profit
+// order which was filled, or zero for an order without a take-profit
+func (f *Fill) GetTakeProfitPrice() decimal.Decimal {
+	return f.TakeProfitPrice
+}
+
+// IsPendingTakeProfit returns whether the take-profit order's target was
+// not reached by the candle and was left pending rather than filled
+func (f *Fill) IsPendingTakeProfit() bool {
+	return f.PendingTakeProfit
+}
+
+// GetTrailingStopHighWaterMark returns the best price reached by the
+// trailing stop order, or zero for an order without a trailing stop
+func (f *Fill) GetTrailingStopHighWaterMark() decimal.Decimal {
+	return f.TrailingStopHighWaterMark
+}
+
+// GetTrailingStopTriggerPrice returns the price the trailing stop order
+// triggers at, or zero for an order without a trailing stop
+func (f *Fill) GetTrailingStopTriggerPrice() decimal.Decimal {
+	return f.TrailingStopTriggerPrice
+}
+
+// IsDormantTrailingStop returns whether the trailing stop order was not
+// triggered by the candle and was left dormant rather than filled
+func (f *Fill) IsDormantTrailingStop() bool {
+	return f.DormantTrailingStop
+}
+
+// GetSubmissionRetries returns how many times submitting
+// the real order was retried after a recoverable error
+func (f *Fill) GetSubmissionRetries() int64 {
+	return f.SubmissionRetries
+}
+
+// RollBack marks the fill as undone, removing its order so that it is not
+// recorded as a trade. Funding changes must be reverted separately
+func (f *Fill) RollBack() {
+	switch f.Direction {
+	case order.Buy, order.Bid:
+		f.Direction = order.CouldNotBuy
+	case order.Sell, order.Ask, order.ClosePosition:
+		f.Direction = order.CouldNotSell
+	}
+	f.Order = nil
+	f.RolledBack = true
+}
+
+// IsRolledBack returns whether the fill was undone
+// because the other leg of its rebalance could not fill
+func (f *Fill) IsRolledBack() bool {
+	return f.RolledBack
+}
+
+// GetIntendedAmount returns the amount the order requested
+// before it was shrunk to fit volume, portfolio or exchange limits
+func (f *Fill) GetIntendedAmount() decimal.Decimal {
+	return f.IntendedAmount
+}
+
+// GetFillRatio returns the filled amount as a ratio of the intended amount
+// and whether it could be calculated
+func (f *Fill) GetFillRatio() (decimal.Decimal, bool) {
+	if !f.IntendedAmount.IsPositive() {
+		return decimal.Zero, false
+	}
+	return f.Amount.Div(f.IntendedAmount), true
+}
+
+// GetFillTime returns when within its candle the order filled,
+// defaulting to the event time when no fill time was assigned
+func (f *Fill) GetFillTime() time.Time {
+	if f.FillTime.IsZero() {
+		return f.GetTime()
+	}
+	return f.FillTime
+}
+
+// GetDecisionTime returns when the filled order was decided upon, which
+// precedes the fill time when the order was deferred to a later candle.
+// Defaults to the event time when no decision time was assigned
+func (f *Fill) GetDecisionTime() time.Time {
+	if f.DecisionTime.IsZero() {
+		return f.GetTime()
+	}
+	return f.DecisionTime
+}
@@ -2,8 +2,10 @@ package fill
 
 import (
 	"testing"
+	"time"
 
 	"github.com/shopspring/decimal"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/event"
 	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/signal"
 	gctorder "github.com/thrasher-corp/gocryptotrader/exchanges/order"
 )
@@ -50,6 +52,18 @@ func TestGetVolumeAdjustedPrice(t *testing.T) {
 	}
 }
 
+func TestIsVolumeAdjusted(t *testing.T) {
+	t.Parallel()
+	f := Fill{}
+	if f.IsVolumeAdjusted() {
+		t.Error("expected false")
+	}
+	f.WasVolumeAdjusted = true
+	if !f.IsVolumeAdjusted() {
+		t.Error("expected true")
+	}
+}
+
 func TestGetPurchasePrice(t *testing.T) {
 	t.Parallel()
 	f := Fill{
@@ -127,3 +141,241 @@ func TestIsLiquidated(t *testing.T) {
 		t.Error("expected true")
 	}
 }
+
+func TestGetFundingLedger(t *testing.T) {
+	t.Parallel()
+	f := &Fill{}
+	if len(f.GetFundingLedger()) != 0 {
+		t.Errorf("received '%v' expected '%v'", len(f.GetFundingLedger()), 0)
+	}
+	f.FundingLedger = []LedgerEntry{{Operation: LedgerReserve}}
+	if len(f.GetFundingLedger()) != 1 {
+		t.Errorf("received '%v' expected '%v'", len(f.GetFundingLedger()), 1)
+	}
+}
+
+func TestGetLatency(t *testing.T) {
+	t.Parallel()
+	f := &Fill{Latency: time.Second}
+	if f.GetLatency() != time.Second {
+		t.Errorf("received '%v' expected '%v'", f.GetLatency(), time.Second)
+	}
+}
+
+func TestGetSubmissionRetries(t *testing.T) {
+	t.Parallel()
+	f := &Fill{SubmissionRetries: 2}
+	if f.GetSubmissionRetries() != 2 {
+		t.Errorf("received '%v' expected '%v'", f.GetSubmissionRetries(), 2)
+	}
+}
+
+func TestGetLiquidationCost(t *testing.T) {
+	t.Parallel()
+	f := &Fill{LiquidationCost: decimal.NewFromInt(1337)}
+	if !f.GetLiquidationCost().Equal(decimal.NewFromInt(1337)) {
+		t.Errorf("received '%v' expected '%v'", f.GetLiquidationCost(), 1337)
+	}
+}
+
+func TestGetLimitPrice(t *testing.T) {
+	t.Parallel()
+	f := &Fill{LimitPrice: decimal.NewFromInt(1337)}
+	if !f.GetLimitPrice().Equal(decimal.NewFromInt(1337)) {
+		t.Errorf("received '%v' expected '%v'", f.GetLimitPrice(), 1337)
+	}
+}
+
+func TestIsRestingLimit(t *testing.T) {
+	t.Parallel()
+	f := &Fill{RestingLimit: true}
+	if !f.IsRestingLimit() {
+		t.Errorf("received '%v' expected '%v'", f.IsRestingLimit(), true)
+	}
+}
+
+func TestGetStopPrice(t *testing.T) {
+	t.Parallel()
+	f := &Fill{StopPrice: decimal.NewFromInt(1337)}
+	if !f.GetStopPrice().Equal(decimal.NewFromInt(1337)) {
+		t.Errorf("received '%v' expected '%v'", f.GetStopPrice(), 1337)
+	}
+}
+
+func TestIsDormantStop(t *testing.T) {
+	t.Parallel()
+	f := &Fill{DormantStop: true}
+	if !f.IsDormantStop() {
+		t.Errorf("received '%v' expected '%v'", f.IsDormantStop(), true)
+	}
+}
+
+func TestGetTakeProfitPrice(t *testing.T) {
+	t.Parallel()
+	f := &Fill{TakeProfitPrice: decimal.NewFromInt(1337)}
+	if !f.GetTakeProfitPrice().Equal(decimal.NewFromInt(1337)) {
+		t.Errorf("received '%v' expected '%v'", f.GetTakeProfitPrice(), 1337)
+	}
+}
+
+func TestIsPendingTakeProfit(t *testing.T) {
+	t.Parallel()
+	f := &Fill{PendingTakeProfit: true}
+	if !f.IsPendingTakeProfit() {
+		t.Errorf("received '%v' expected '%v'", f.IsPendingTakeProfit(), true)
+	}
+}
+
+func TestGetTrailingStopHighWaterMark(t *testing.T) {
+	t.Parallel()
+	f := &Fill{TrailingStopHighWaterMark: decimal.NewFromInt(1337)}
+	if !f.GetTrailingStopHighWaterMark().Equal(decimal.NewFromInt(1337)) {
+		t.Errorf("received '%v' expected '%v'", f.GetTrailingStopHighWaterMark(), 1337)
+	}
+}
+
+func TestGetTrailingStopTriggerPrice(t *testing.T) {
+	t.Parallel()
+	f := &Fill{TrailingStopTriggerPrice: decimal.NewFromInt(1337)}
+	if !f.GetTrailingStopTriggerPrice().Equal(decimal.NewFromInt(1337)) {
+		t.Errorf("received '%v' expected '%v'", f.GetTrailingStopTriggerPrice(), 1337)
+	}
+}
+
+func TestIsDormantTrailingStop(t *testing.T) {
+	t.Parallel()
+	f := &Fill{DormantTrailingStop: true}
+	if !f.IsDormantTrailingStop() {
+		t.Errorf("received '%v' expected '%v'", f.IsDormantTrailingStop(), true)
+	}
+}
+
+func TestGetFeeType(t *testing.T) {
+	t.Parallel()
+	f := &Fill{FeeType: FeeTypeMaker}
+	if f.GetFeeType() != FeeTypeMaker {
+		t.Errorf("received '%v' expected '%v'", f.GetFeeType(), FeeTypeMaker)
+	}
+}
+
+func TestRollBack(t *testing.T) {
+	t.Parallel()
+	f := &Fill{Direction: gctorder.Sell, Order: &gctorder.Detail{}}
+	f.RollBack()
+	if !f.IsRolledBack() {
+		t.Errorf("received '%v' expected '%v'", f.IsRolledBack(), true)
+	}
+	if f.GetDirection() != gctorder.CouldNotSell {
+		t.Errorf("received '%v' expected '%v'", f.GetDirection(), gctorder.CouldNotSell)
+	}
+	if f.GetOrder() != nil {
+		t.Errorf("received '%v' expected '%v'", f.GetOrder(), nil)
+	}
+	f = &Fill{Direction: gctorder.Buy}
+	f.RollBack()
+	if f.GetDirection() != gctorder.CouldNotBuy {
+		t.Errorf("received '%v' expected '%v'", f.GetDirection(), gctorder.CouldNotBuy)
+	}
+}
+
+func TestIsStopOut(t *testing.T) {
+	t.Parallel()
+	f := &Fill{StopOut: true}
+	if !f.IsStopOut() {
+		t.Errorf("received '%v' expected '%v'", f.IsStopOut(), true)
+	}
+}
+
+func TestGetTag(t *testing.T) {
+	t.Parallel()
+	f := &Fill{Tag: "trend entry"}
+	if f.GetTag() != "trend entry" {
+		t.Errorf("received '%v' expected '%v'", f.GetTag(), "trend entry")
+	}
+}
+
+func TestGetAccount(t *testing.T) {
+	t.Parallel()
+	f := &Fill{Account: "variant a"}
+	if f.GetAccount() != "variant a" {
+		t.Errorf("received '%v' expected '%v'", f.GetAccount(), "variant a")
+	}
+}
+
+func TestIsCapitalCapBound(t *testing.T) {
+	t.Parallel()
+	f := &Fill{CapitalCapBound: true}
+	if !f.IsCapitalCapBound() {
+		t.Errorf("received '%v' expected '%v'", f.IsCapitalCapBound(), true)
+	}
+}
+
+func TestIsCircuitBreakerTriggered(t *testing.T) {
+	t.Parallel()
+	f := &Fill{CircuitBreakerTriggered: true}
+	if !f.IsCircuitBreakerTriggered() {
+		t.Errorf("received '%v' expected '%v'", f.IsCircuitBreakerTriggered(), true)
+	}
+}
+
+func TestIsCircuitBreakerBound(t *testing.T) {
+	t.Parallel()
+	f := &Fill{CircuitBreakerBound: true}
+	if !f.IsCircuitBreakerBound() {
+		t.Errorf("received '%v' expected '%v'", f.IsCircuitBreakerBound(), true)
+	}
+}
+
+func TestGetEffectiveSpread(t *testing.T) {
+	t.Parallel()
+	f := &Fill{}
+	if _, ok := f.GetEffectiveSpread(); ok {
+		t.Error("expected no effective spread")
+	}
+	f.EffectiveSpread = decimal.NewFromInt(1)
+	f.HasEffectiveSpread = true
+	if spread, ok := f.GetEffectiveSpread(); !ok || !spread.Equal(decimal.NewFromInt(1)) {
+		t.Errorf("received '%v' expected '%v'", spread, 1)
+	}
+}
+
+func TestGetFillRatio(t *testing.T) {
+	t.Parallel()
+	f := &Fill{Amount: decimal.NewFromInt(1)}
+	if _, ok := f.GetFillRatio(); ok {
+		t.Error("expected no fill ratio without an intended amount")
+	}
+	f.IntendedAmount = decimal.NewFromInt(4)
+	if !f.GetIntendedAmount().Equal(decimal.NewFromInt(4)) {
+		t.Errorf("received '%v' expected '%v'", f.GetIntendedAmount(), 4)
+	}
+	if ratio, ok := f.GetFillRatio(); !ok || !ratio.Equal(decimal.NewFromFloat(0.25)) {
+		t.Errorf("received '%v' expected '%v'", ratio, 0.25)
+	}
+}
+
+func TestGetFillTime(t *testing.T) {
+	t.Parallel()
+	tt := time.Now()
+	f := &Fill{Base: &event.Base{Time: tt}}
+	if !f.GetFillTime().Equal(tt) {
+		t.Errorf("received '%v' expected '%v'", f.GetFillTime(), tt)
+	}
+	f.FillTime = tt.Add(-time.Minute)
+	if !f.GetFillTime().Equal(tt.Add(-time.Minute)) {
+		t.Errorf("received '%v' expected '%v'", f.GetFillTime(), tt.Add(-time.Minute))
+	}
+}
+
+func TestGetDecisionTime(t *testing.T) {
+	t.Parallel()
+	tt := time.Now()
+	f := &Fill{Base: &event.Base{Time: tt}}
+	if !f.GetDecisionTime().Equal(tt) {
+		t.Errorf("received '%v' expected '%v'", f.GetDecisionTime(), tt)
+	}
+	f.DecisionTime = tt.Add(-time.Hour)
+	if !f.GetDecisionTime().Equal(tt.Add(-time.Hour)) {
+		t.Errorf("received '%v' expected '%v'", f.GetDecisionTime(), tt.Add(-time.Hour))
+	}
+}
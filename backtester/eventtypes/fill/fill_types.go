@@ -1,10 +1,13 @@
 package fill
 
 import (
+	"time"
+
 	"github.com/shopspring/decimal"
 	"github.com/thrasher-corp/gocryptotrader/backtester/common"
 	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/event"
 	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/signal"
+	"github.com/thrasher-corp/gocryptotrader/currency"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
 )
 
@@ -13,15 +16,102 @@ type Fill struct {
 	*event.Base
 	Direction           order.Side      `json:"side"`
 	Amount              decimal.Decimal `json:"amount"`
+	IntendedAmount      decimal.Decimal `json:"intended-amount"`
 	ClosePrice          decimal.Decimal `json:"close-price"`
 	VolumeAdjustedPrice decimal.Decimal `json:"volume-adjusted-price"`
+	WasVolumeAdjusted   bool            `json:"was-volume-adjusted"`
 	PurchasePrice       decimal.Decimal `json:"purchase-price"`
 	Total               decimal.Decimal `json:"total"`
 	ExchangeFee         decimal.Decimal `json:"exchange-fee"`
 	Slippage            decimal.Decimal `json:"slippage"`
 	Order               *order.Detail   `json:"-"`
+	FundingLedger       []LedgerEntry   `json:"funding-ledger,omitempty"`
+	Latency             time.Duration   `json:"latency,omitempty"`
+	FillTime            time.Time       `json:"fill-time"`
+	DecisionTime        time.Time       `json:"decision-time"`
 	FillDependentEvent  signal.Event
 	Liquidated          bool
+	StopOut             bool
+	Tag                 string `json:"tag,omitempty"`
+	Account             string `json:"account,omitempty"`
+	// CapitalCapBound is set when the order was rejected
+	// for exceeding its maximum capital allocation
+	CapitalCapBound bool
+	// CircuitBreakerTriggered is set when the fill completed the losing
+	// streak which triggered the circuit breaker
+	CircuitBreakerTriggered bool
+	// CircuitBreakerBound is set when the order was rejected
+	// while the circuit breaker halted entries
+	CircuitBreakerBound bool
+	// EffectiveSpread is the difference between the fill price and the
+	// orderbook midpoint as a percentage. It is only set for real orders
+	EffectiveSpread    decimal.Decimal `json:"effective-spread"`
+	HasEffectiveSpread bool            `json:"-"`
+	// AccumulatedRebate is the rebate earned from a negative fee which was
+	// withheld from available funds to be accumulated separately
+	AccumulatedRebate decimal.Decimal `json:"accumulated-rebate"`
+	// SubmissionRetries is how many times submitting
+	// the real order was retried after a recoverable error
+	SubmissionRetries int64 `json:"submission-retries,omitempty"`
+	// RolledBack is set when the fill was undone because
+	// the other leg of its rebalance could not fill
+	RolledBack bool `json:"rolled-back,omitempty"`
+	// LiquidationCost is the cost of the liquidation penalty slippage
+	// and fee charged to a liquidation fill
+	LiquidationCost decimal.Decimal `json:"liquidation-cost,omitempty"`
+	// LimitPrice is the price of a limit order. It is zero for market orders
+	LimitPrice decimal.Decimal `json:"limit-price,omitempty"`
+	// RestingLimit is set when the limit order was not touched by the
+	// candle and was left open to be evaluated against later candles
+	RestingLimit bool `json:"resting-limit,omitempty"`
+	// FeeType is whether the maker or taker fee was applied to the order
+	FeeType string `json:"fee-type,omitempty"`
+	// StopPrice is the trigger price of a stop order. It is zero for orders
+	// without a stop. The price executed at is the purchase price
+	StopPrice decimal.Decimal `json:"stop-price,omitempty"`
+	// DormantStop is set when the stop order was not triggered by the
+	// candle and was left dormant to be evaluated against later candles
+	DormantStop bool `json:"dormant-stop,omitempty"`
+	// TakeProfitPrice is the target price of a take-profit order. It is zero
+	// for orders without a take-profit
+	TakeProfitPrice decimal.Decimal `json:"take-profit-price,omitempty"`
+	// PendingTakeProfit is set when the take-profit order's target was not
+	// reached by the candle and was left pending to be evaluated against later candles
+	PendingTakeProfit bool `json:"pending-take-profit,omitempty"`
+	// TrailingStopHighWaterMark is the best price reached by a trailing stop
+	// order which the trigger price trails. It is zero for orders without a
+	// trailing stop
+	TrailingStopHighWaterMark decimal.Decimal `json:"trailing-stop-high-water-mark,omitempty"`
+	// TrailingStopTriggerPrice is the price the trailing stop order triggers
+	// at, trailing the high-water mark. The price executed at is the purchase price
+	TrailingStopTriggerPrice decimal.Decimal `json:"trailing-stop-trigger-price,omitempty"`
+	// DormantTrailingStop is set when the trailing stop order was not triggered
+	// by the candle and was left dormant to be evaluated against later candles
+	DormantTrailingStop bool `json:"dormant-trailing-stop,omitempty"`
+}
+
+// Fee types applied to a filled order
+const (
+	FeeTypeMaker = "maker"
+	FeeTypeTaker = "taker"
+)
+
+// Funding ledger operations
+const (
+	LedgerReserve           = "reserve"
+	LedgerRelease           = "release"
+	LedgerIncreaseAvailable = "increase-available"
+	LedgerReleaseContracts  = "release-contracts"
+)
+
+// LedgerEntry records a single change to funding made on behalf of an order.
+// The sum of available changes for a currency across all fills reconciles
+// initial funds to ending available funds
+type LedgerEntry struct {
+	Operation       string          `json:"operation"`
+	Currency        currency.Code   `json:"currency"`
+	ReservedChange  decimal.Decimal `json:"reserved-change"`
+	AvailableChange decimal.Decimal `json:"available-change"`
 }
 
 // Event holds all functions required to handle a fill event
@@ -31,8 +121,11 @@ type Event interface {
 
 	SetAmount(decimal.Decimal)
 	GetAmount() decimal.Decimal
+	GetIntendedAmount() decimal.Decimal
+	GetFillRatio() (decimal.Decimal, bool)
 	GetClosePrice() decimal.Decimal
 	GetVolumeAdjustedPrice() decimal.Decimal
+	IsVolumeAdjusted() bool
 	GetSlippageRate() decimal.Decimal
 	GetPurchasePrice() decimal.Decimal
 	GetTotal() decimal.Decimal
@@ -41,4 +134,30 @@ type Event interface {
 	GetOrder() *order.Detail
 	GetFillDependentEvent() signal.Event
 	IsLiquidated() bool
+	GetFundingLedger() []LedgerEntry
+	GetLatency() time.Duration
+	GetFillTime() time.Time
+	GetDecisionTime() time.Time
+	IsStopOut() bool
+	GetTag() string
+	GetAccount() string
+	IsCapitalCapBound() bool
+	IsCircuitBreakerTriggered() bool
+	IsCircuitBreakerBound() bool
+	GetEffectiveSpread() (decimal.Decimal, bool)
+	GetAccumulatedRebate() decimal.Decimal
+	GetSubmissionRetries() int64
+	RollBack()
+	IsRolledBack() bool
+	GetLiquidationCost() decimal.Decimal
+	GetLimitPrice() decimal.Decimal
+	IsRestingLimit() bool
+	GetFeeType() string
+	GetStopPrice() decimal.Decimal
+	IsDormantStop() bool
+	GetTakeProfitPrice() decimal.Decimal
+	IsPendingTakeProfit() bool
+	GetTrailingStopHighWaterMark() decimal.Decimal
+	GetTrailingStopTriggerPrice() decimal.Decimal
+	IsDormantTrailingStop() bool
 }
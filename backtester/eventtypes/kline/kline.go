@@ -25,7 +25,24 @@ func (k *Kline) GetOpenPrice() decimal.Decimal {
 	return k.Open
 }
 
+// GetVolume returns the volume of a kline
+func (k *Kline) GetVolume() decimal.Decimal {
+	return k.Volume
+}
+
 // GetUnderlyingPair returns the open price of a kline
 func (k *Kline) GetUnderlyingPair() currency.Pair {
 	return k.UnderlyingPair
 }
+
+// SetOrderbookImbalance attaches the orderbook imbalance to the kline
+func (k *Kline) SetOrderbookImbalance(imbalance decimal.Decimal) {
+	k.OrderbookImbalance = imbalance
+	k.HasOrderbookImbalance = true
+}
+
+// GetOrderbookImbalance returns the orderbook imbalance and whether
+// it was calculated for the kline
+func (k *Kline) GetOrderbookImbalance() (decimal.Decimal, bool) {
+	return k.OrderbookImbalance, k.HasOrderbookImbalance
+}
@@ -28,6 +28,16 @@ func TestHigh(t *testing.T) {
 	}
 }
 
+func TestVolume(t *testing.T) {
+	t.Parallel()
+	k := Kline{
+		Volume: decimal.NewFromInt(1337),
+	}
+	if !k.GetVolume().Equal(decimal.NewFromInt(1337)) {
+		t.Error("expected decimal.NewFromInt(1337)")
+	}
+}
+
 func TestLow(t *testing.T) {
 	t.Parallel()
 	k := Kline{
@@ -59,3 +69,19 @@ func TestGetUnderlyingPair(t *testing.T) {
 		t.Errorf("expected '%v'", k.Base.UnderlyingPair)
 	}
 }
+
+func TestOrderbookImbalance(t *testing.T) {
+	t.Parallel()
+	k := Kline{}
+	if _, ok := k.GetOrderbookImbalance(); ok {
+		t.Error("expected no imbalance")
+	}
+	k.SetOrderbookImbalance(decimal.NewFromFloat(0.5))
+	imbalance, ok := k.GetOrderbookImbalance()
+	if !ok {
+		t.Error("expected imbalance")
+	}
+	if !imbalance.Equal(decimal.NewFromFloat(0.5)) {
+		t.Errorf("received '%v' expected '%v'", imbalance, 0.5)
+	}
+}
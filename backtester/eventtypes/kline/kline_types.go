@@ -15,4 +15,8 @@ type Kline struct {
 	High             decimal.Decimal
 	Volume           decimal.Decimal
 	ValidationIssues string
+	// OrderbookImbalance is the bid versus ask volume imbalance of the
+	// live orderbook, only set when a book was available for the event
+	OrderbookImbalance    decimal.Decimal
+	HasOrderbookImbalance bool
 }
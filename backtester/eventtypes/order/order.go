@@ -1,6 +1,8 @@
 package order
 
 import (
+	"time"
+
 	"github.com/shopspring/decimal"
 	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/signal"
 	"github.com/thrasher-corp/gocryptotrader/currency"
@@ -103,3 +105,127 @@ func (o *Order) IsLiquidating() bool {
 func (o *Order) GetClosePrice() decimal.Decimal {
 	return o.ClosePrice
 }
+
+// GetMaxSlippageTolerance returns the maximum percentage
+// of adverse slippage accepted before cancelling the order
+func (o *Order) GetMaxSlippageTolerance() decimal.Decimal {
+	return o.MaxSlippageTolerance
+}
+
+// GetLatency returns the sampled delay the order experiences before filling
+func (o *Order) GetLatency() time.Duration {
+	return o.Latency
+}
+
+// GetDecisionTime returns when the order was decided upon,
+// defaulting to the event time when it was never deferred
+func (o *Order) GetDecisionTime() time.Time {
+	if o.DecisionTime.IsZero() {
+		return o.GetTime()
+	}
+	return o.DecisionTime
+}
+
+// IsDeferredToNextOpen returns whether the order was deferred
+// to fill at the open of the candle after its signal
+func (o *Order) IsDeferredToNextOpen() bool {
+	return o.DeferredToNextOpen
+}
+
+// IsStopOut returns whether the order closes a position
+// that breached its maximum drawdown
+func (o *Order) IsStopOut() bool {
+	return o.StopOut
+}
+
+// GetTag returns the strategy's label for the order's stream
+func (o *Order) GetTag() string {
+	return o.Tag
+}
+
+// GetAccount returns the name of the account funding the order
+func (o *Order) GetAccount() string {
+	return o.Account
+}
+
+// GetFeeOverride returns the optional fee rate
+// which replaces the exchange's taker fee
+func (o *Order) GetFeeOverride() *decimal.Decimal {
+	return o.FeeOverride
+}
+
+// GetLimitPrice returns the price the limit order fills at or better
+func (o *Order) GetLimitPrice() decimal.Decimal {
+	return o.LimitPrice
+}
+
+// IsRestingLimit returns whether the limit order was left open
+// after the candle it was placed on did not cross its limit price
+func (o *Order) IsRestingLimit() bool {
+	return o.RestingLimit
+}
+
+// IsPartialFill returns whether the order is the unfilled remainder
+// of an order partially filled on a previous candle
+func (o *Order) IsPartialFill() bool {
+	return o.PartialFill
+}
+
+// GetStopPrice returns the price which triggers the stop order
+func (o *Order) GetStopPrice() decimal.Decimal {
+	return o.StopPrice
+}
+
+// IsDormantStop returns whether the stop order was left dormant
+// after the candle it was placed on did not breach its stop price
+func (o *Order) IsDormantStop() bool {
+	return o.DormantStop
+}
+
+// GetTakeProfitPrice returns the target price of the take-profit order
+func (o *Order) GetTakeProfitPrice() decimal.Decimal {
+	return o.TakeProfitPrice
+}
+
+// SetTakeProfitPrice sets the target price of the take-profit order,
+// replacing any percentage target it was resolved from
+func (o *Order) SetTakeProfitPrice(target decimal.Decimal) {
+	o.TakeProfitPrice = target
+	o.TakeProfitPercent = decimal.Zero
+}
+
+// GetTakeProfitPercent returns the percentage move from the position's entry
+// price targeted by the take-profit order
+func (o *Order) GetTakeProfitPercent() decimal.Decimal {
+	return o.TakeProfitPercent
+}
+
+// IsPendingTakeProfit returns whether the take-profit order was left pending
+// after the candle it was placed on did not reach its target
+func (o *Order) IsPendingTakeProfit() bool {
+	return o.PendingTakeProfit
+}
+
+// GetTrailingStopDistance returns the price distance
+// the trailing stop order trails the best price by
+func (o *Order) GetTrailingStopDistance() decimal.Decimal {
+	return o.TrailingStopDistance
+}
+
+// GetTrailingStopPercent returns the percentage of the best
+// price the trailing stop order trails it by
+func (o *Order) GetTrailingStopPercent() decimal.Decimal {
+	return o.TrailingStopPercent
+}
+
+// GetTrailingStopHighWaterMark returns the best price
+// reached while the trailing stop order was dormant
+func (o *Order) GetTrailingStopHighWaterMark() decimal.Decimal {
+	return o.TrailingStopHighWaterMark
+}
+
+// IsDormantTrailingStop returns whether the trailing stop order was left
+// dormant after a candle did not retrace far enough to trigger it
+func (o *Order) IsDormantTrailingStop() bool {
+	return o.DormantTrailingStop
+}
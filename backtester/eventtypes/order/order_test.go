@@ -2,6 +2,7 @@ package order
 
 import (
 	"testing"
+	"time"
 
 	"github.com/shopspring/decimal"
 	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/event"
@@ -169,3 +170,221 @@ func TestIsClosingPosition(t *testing.T) {
 		t.Errorf("received '%v' expected '%v'", k.IsClosingPosition(), true)
 	}
 }
+
+func TestGetMaxSlippageTolerance(t *testing.T) {
+	t.Parallel()
+	k := Order{
+		MaxSlippageTolerance: decimal.NewFromInt(1),
+	}
+	if !k.GetMaxSlippageTolerance().Equal(decimal.NewFromInt(1)) {
+		t.Errorf("received '%v' expected '%v'", k.GetMaxSlippageTolerance(), 1)
+	}
+}
+
+func TestGetLatency(t *testing.T) {
+	t.Parallel()
+	k := Order{
+		Latency: time.Second,
+	}
+	if k.GetLatency() != time.Second {
+		t.Errorf("received '%v' expected '%v'", k.GetLatency(), time.Second)
+	}
+}
+
+func TestGetDecisionTime(t *testing.T) {
+	t.Parallel()
+	tt := time.Now()
+	k := Order{Base: &event.Base{Time: tt}}
+	if !k.GetDecisionTime().Equal(tt) {
+		t.Errorf("received '%v' expected '%v'", k.GetDecisionTime(), tt)
+	}
+	k.DecisionTime = tt.Add(-time.Hour)
+	if !k.GetDecisionTime().Equal(tt.Add(-time.Hour)) {
+		t.Errorf("received '%v' expected '%v'", k.GetDecisionTime(), tt.Add(-time.Hour))
+	}
+}
+
+func TestIsDeferredToNextOpen(t *testing.T) {
+	t.Parallel()
+	k := Order{
+		DeferredToNextOpen: true,
+	}
+	if !k.IsDeferredToNextOpen() {
+		t.Errorf("received '%v' expected '%v'", k.IsDeferredToNextOpen(), true)
+	}
+}
+
+func TestIsStopOut(t *testing.T) {
+	t.Parallel()
+	k := Order{
+		StopOut: true,
+	}
+	if !k.IsStopOut() {
+		t.Errorf("received '%v' expected '%v'", k.IsStopOut(), true)
+	}
+}
+
+func TestGetTag(t *testing.T) {
+	t.Parallel()
+	k := Order{
+		Tag: "trend entry",
+	}
+	if k.GetTag() != "trend entry" {
+		t.Errorf("received '%v' expected '%v'", k.GetTag(), "trend entry")
+	}
+}
+
+func TestGetAccount(t *testing.T) {
+	t.Parallel()
+	k := Order{
+		Account: "variant a",
+	}
+	if k.GetAccount() != "variant a" {
+		t.Errorf("received '%v' expected '%v'", k.GetAccount(), "variant a")
+	}
+}
+
+func TestGetFeeOverride(t *testing.T) {
+	t.Parallel()
+	k := Order{}
+	if k.GetFeeOverride() != nil {
+		t.Errorf("received '%v' expected '%v'", k.GetFeeOverride(), nil)
+	}
+	fee := decimal.Zero
+	k.FeeOverride = &fee
+	if k.GetFeeOverride() == nil || !k.GetFeeOverride().IsZero() {
+		t.Errorf("received '%v' expected '%v'", k.GetFeeOverride(), fee)
+	}
+}
+
+func TestGetLimitPrice(t *testing.T) {
+	t.Parallel()
+	k := Order{
+		LimitPrice: decimal.NewFromInt(1337),
+	}
+	if !k.GetLimitPrice().Equal(decimal.NewFromInt(1337)) {
+		t.Errorf("received '%v' expected '%v'", k.GetLimitPrice(), decimal.NewFromInt(1337))
+	}
+}
+
+func TestIsRestingLimit(t *testing.T) {
+	t.Parallel()
+	k := Order{
+		RestingLimit: true,
+	}
+	if !k.IsRestingLimit() {
+		t.Errorf("received '%v' expected '%v'", k.IsRestingLimit(), true)
+	}
+}
+
+func TestIsPartialFill(t *testing.T) {
+	t.Parallel()
+	k := Order{
+		PartialFill: true,
+	}
+	if !k.IsPartialFill() {
+		t.Errorf("received '%v' expected '%v'", k.IsPartialFill(), true)
+	}
+}
+
+func TestGetStopPrice(t *testing.T) {
+	t.Parallel()
+	k := Order{
+		StopPrice: decimal.NewFromInt(1337),
+	}
+	if !k.GetStopPrice().Equal(decimal.NewFromInt(1337)) {
+		t.Errorf("received '%v' expected '%v'", k.GetStopPrice(), decimal.NewFromInt(1337))
+	}
+}
+
+func TestIsDormantStop(t *testing.T) {
+	t.Parallel()
+	k := Order{
+		DormantStop: true,
+	}
+	if !k.IsDormantStop() {
+		t.Errorf("received '%v' expected '%v'", k.IsDormantStop(), true)
+	}
+}
+
+func TestGetTakeProfitPrice(t *testing.T) {
+	t.Parallel()
+	k := Order{
+		TakeProfitPrice: decimal.NewFromInt(1337),
+	}
+	if !k.GetTakeProfitPrice().Equal(decimal.NewFromInt(1337)) {
+		t.Errorf("received '%v' expected '%v'", k.GetTakeProfitPrice(), decimal.NewFromInt(1337))
+	}
+}
+
+func TestSetTakeProfitPrice(t *testing.T) {
+	t.Parallel()
+	k := Order{TakeProfitPercent: decimal.NewFromInt(1)}
+	k.SetTakeProfitPrice(decimal.NewFromInt(1337))
+	if !k.GetTakeProfitPrice().Equal(decimal.NewFromInt(1337)) {
+		t.Errorf("received '%v' expected '%v'", k.GetTakeProfitPrice(), decimal.NewFromInt(1337))
+	}
+	if !k.GetTakeProfitPercent().IsZero() {
+		t.Errorf("received '%v' expected '%v'", k.GetTakeProfitPercent(), 0)
+	}
+}
+
+func TestGetTakeProfitPercent(t *testing.T) {
+	t.Parallel()
+	k := Order{
+		TakeProfitPercent: decimal.NewFromInt(1),
+	}
+	if !k.GetTakeProfitPercent().Equal(decimal.NewFromInt(1)) {
+		t.Errorf("received '%v' expected '%v'", k.GetTakeProfitPercent(), decimal.NewFromInt(1))
+	}
+}
+
+func TestIsPendingTakeProfit(t *testing.T) {
+	t.Parallel()
+	k := Order{
+		PendingTakeProfit: true,
+	}
+	if !k.IsPendingTakeProfit() {
+		t.Errorf("received '%v' expected '%v'", k.IsPendingTakeProfit(), true)
+	}
+}
+
+func TestGetTrailingStopDistance(t *testing.T) {
+	t.Parallel()
+	k := Order{
+		TrailingStopDistance: decimal.NewFromInt(1337),
+	}
+	if !k.GetTrailingStopDistance().Equal(decimal.NewFromInt(1337)) {
+		t.Errorf("received '%v' expected '%v'", k.GetTrailingStopDistance(), decimal.NewFromInt(1337))
+	}
+}
+
+func TestGetTrailingStopPercent(t *testing.T) {
+	t.Parallel()
+	k := Order{
+		TrailingStopPercent: decimal.NewFromInt(5),
+	}
+	if !k.GetTrailingStopPercent().Equal(decimal.NewFromInt(5)) {
+		t.Errorf("received '%v' expected '%v'", k.GetTrailingStopPercent(), decimal.NewFromInt(5))
+	}
+}
+
+func TestGetTrailingStopHighWaterMark(t *testing.T) {
+	t.Parallel()
+	k := Order{
+		TrailingStopHighWaterMark: decimal.NewFromInt(1337),
+	}
+	if !k.GetTrailingStopHighWaterMark().Equal(decimal.NewFromInt(1337)) {
+		t.Errorf("received '%v' expected '%v'", k.GetTrailingStopHighWaterMark(), decimal.NewFromInt(1337))
+	}
+}
+
+func TestIsDormantTrailingStop(t *testing.T) {
+	t.Parallel()
+	k := Order{
+		DormantTrailingStop: true,
+	}
+	if !k.IsDormantTrailingStop() {
+		t.Errorf("received '%v' expected '%v'", k.IsDormantTrailingStop(), true)
+	}
+}
@@ -1,6 +1,8 @@
 package order
 
 import (
+	"time"
+
 	"github.com/shopspring/decimal"
 	"github.com/thrasher-corp/gocryptotrader/backtester/common"
 	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/event"
@@ -24,6 +26,65 @@ type Order struct {
 	FillDependentEvent  signal.Event
 	ClosingPosition     bool
 	LiquidatingPosition bool
+	// MaxSlippageTolerance is the percentage of adverse slippage
+	// accepted before the order is cancelled. Zero disables the check
+	MaxSlippageTolerance decimal.Decimal
+	// Latency is the sampled delay the order experiences before filling
+	Latency time.Duration
+	// DecisionTime is when the order was decided upon, set when the order
+	// is deferred to fill on a later candle
+	DecisionTime time.Time
+	// DeferredToNextOpen is set when the order was deferred from the candle
+	// which generated its signal to fill at the open of the next candle
+	DeferredToNextOpen bool
+	// StopOut is set when the order closes a position
+	// that breached its maximum drawdown
+	StopOut bool
+	// Tag is the strategy's label for the order stream the order belongs to
+	Tag string
+	// Account is the name of the segregated account funding the order
+	Account string
+	// FeeOverride is an optional fee rate which replaces the exchange's taker fee
+	FeeOverride *decimal.Decimal
+	// LimitPrice is the price the order fills at or better once a candle's
+	// range crosses it. Zero places a market order
+	LimitPrice decimal.Decimal
+	// RestingLimit is set when the limit order was not touched by the
+	// candle it was placed on and rests until a later candle crosses it
+	RestingLimit bool
+	// PartialFill is set when the order is the unfilled remainder of an
+	// order which was shrunk to fit a previous candle's volume
+	PartialFill bool
+	// StopPrice is the trigger price of a stop order. The order executes at
+	// market once a candle's range breaches it. Zero places a market order
+	StopPrice decimal.Decimal
+	// DormantStop is set when the stop order was not triggered by the
+	// candle it was placed on and remains dormant until a later candle does
+	DormantStop bool
+	// TakeProfitPrice is the target price of a take-profit order. The order
+	// fills at the target or better once a candle's range reaches it
+	TakeProfitPrice decimal.Decimal
+	// TakeProfitPercent is the take-profit target as a percentage move from
+	// the open position's entry price. It is resolved to TakeProfitPrice
+	// from the entry fill when the order is executed
+	TakeProfitPercent decimal.Decimal
+	// PendingTakeProfit is set when the take-profit order's target was not
+	// reached by the candle it was placed on and waits for a later candle
+	PendingTakeProfit bool
+	// TrailingStopDistance is the price distance a trailing stop order trails
+	// the best price reached by. The order executes at market once the
+	// price retraces by the distance
+	TrailingStopDistance decimal.Decimal
+	// TrailingStopPercent is the trailing stop distance as a
+	// percentage of the best price reached
+	TrailingStopPercent decimal.Decimal
+	// TrailingStopHighWaterMark is the best price reached while the trailing
+	// stop order was dormant, being the highest for sells and lowest for buys.
+	// It is carried between candles until the order is triggered
+	TrailingStopHighWaterMark decimal.Decimal
+	// DormantTrailingStop is set when the trailing stop order was not
+	// triggered by the candle and remains dormant until a later candle does
+	DormantTrailingStop bool
 }
 
 // Event inherits common event interfaces along with extra functions related to handling orders
@@ -44,4 +105,25 @@ type Event interface {
 	GetFillDependentEvent() signal.Event
 	IsClosingPosition() bool
 	IsLiquidating() bool
+	GetMaxSlippageTolerance() decimal.Decimal
+	GetLatency() time.Duration
+	GetDecisionTime() time.Time
+	IsDeferredToNextOpen() bool
+	IsStopOut() bool
+	GetTag() string
+	GetAccount() string
+	GetFeeOverride() *decimal.Decimal
+	GetLimitPrice() decimal.Decimal
+	IsRestingLimit() bool
+	IsPartialFill() bool
+	GetStopPrice() decimal.Decimal
+	IsDormantStop() bool
+	GetTakeProfitPrice() decimal.Decimal
+	SetTakeProfitPrice(decimal.Decimal)
+	GetTakeProfitPercent() decimal.Decimal
+	IsPendingTakeProfit() bool
+	GetTrailingStopDistance() decimal.Decimal
+	GetTrailingStopPercent() decimal.Decimal
+	GetTrailingStopHighWaterMark() decimal.Decimal
+	IsDormantTrailingStop() bool
 }
@@ -77,6 +77,12 @@ func (s *Signal) GetFillDependentEvent() Event {
 	return s.FillDependentEvent
 }
 
+// GetRebalanceEvent returns the signal funded
+// by the proceeds of this signal's fill
+func (s *Signal) GetRebalanceEvent() Event {
+	return s.RebalanceEvent
+}
+
 // GetCollateralCurrency returns the collateral currency
 func (s *Signal) GetCollateralCurrency() currency.Code {
 	return s.CollateralCurrency
@@ -92,3 +98,63 @@ func (s *Signal) IsNil() bool {
 func (s *Signal) MatchOrderAmount() bool {
 	return s.MatchesOrderAmount
 }
+
+// GetMaxSlippageTolerance returns the maximum percentage
+// of adverse slippage accepted before cancelling the order
+func (s *Signal) GetMaxSlippageTolerance() decimal.Decimal {
+	return s.MaxSlippageTolerance
+}
+
+// GetTag returns the strategy's label for the signal's order stream
+func (s *Signal) GetTag() string {
+	return s.Tag
+}
+
+// GetAccount returns the name of the account funding the signal's order
+func (s *Signal) GetAccount() string {
+	return s.Account
+}
+
+// GetStrength returns the strategy's conviction in the signal
+func (s *Signal) GetStrength() decimal.Decimal {
+	return s.Strength
+}
+
+// GetFeeOverride returns the optional fee rate which replaces
+// the exchange's taker fee for the signal's order
+func (s *Signal) GetFeeOverride() *decimal.Decimal {
+	return s.FeeOverride
+}
+
+// GetLimitPrice returns the price to place the signal's limit order at
+func (s *Signal) GetLimitPrice() decimal.Decimal {
+	return s.LimitPrice
+}
+
+// GetStopPrice returns the price which triggers the signal's stop order
+func (s *Signal) GetStopPrice() decimal.Decimal {
+	return s.StopPrice
+}
+
+// GetTakeProfitPrice returns the target price of the signal's take-profit order
+func (s *Signal) GetTakeProfitPrice() decimal.Decimal {
+	return s.TakeProfitPrice
+}
+
+// GetTakeProfitPercent returns the percentage move from the position's entry
+// price targeted by the signal's take-profit order
+func (s *Signal) GetTakeProfitPercent() decimal.Decimal {
+	return s.TakeProfitPercent
+}
+
+// GetTrailingStopDistance returns the price distance the signal's trailing
+// stop order trails the best price by
+func (s *Signal) GetTrailingStopDistance() decimal.Decimal {
+	return s.TrailingStopDistance
+}
+
+// GetTrailingStopPercent returns the percentage of the best price
+// the signal's trailing stop order trails it by
+func (s *Signal) GetTrailingStopPercent() decimal.Decimal {
+	return s.TrailingStopPercent
+}
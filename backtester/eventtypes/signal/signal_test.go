@@ -117,6 +117,21 @@ func TestGetFillDependentEvent(t *testing.T) {
 	}
 }
 
+func TestGetRebalanceEvent(t *testing.T) {
+	t.Parallel()
+	s := Signal{}
+	if a := s.GetRebalanceEvent(); a != nil {
+		t.Error("expected nil")
+	}
+	s.RebalanceEvent = &Signal{
+		Amount: decimal.NewFromInt(1337),
+	}
+	e := s.GetRebalanceEvent()
+	if !e.GetAmount().Equal(decimal.NewFromInt(1337)) {
+		t.Error("expected 1337")
+	}
+}
+
 func TestGetCollateralCurrency(t *testing.T) {
 	t.Parallel()
 	s := Signal{}
@@ -154,3 +169,108 @@ func TestMatchOrderAmount(t *testing.T) {
 		t.Error("expected true")
 	}
 }
+
+func TestGetMaxSlippageTolerance(t *testing.T) {
+	t.Parallel()
+	s := &Signal{MaxSlippageTolerance: decimal.NewFromInt(1)}
+	if !s.GetMaxSlippageTolerance().Equal(decimal.NewFromInt(1)) {
+		t.Errorf("received '%v' expected '%v'", s.GetMaxSlippageTolerance(), 1)
+	}
+}
+
+func TestGetTag(t *testing.T) {
+	t.Parallel()
+	s := &Signal{Tag: "trend entry"}
+	if s.GetTag() != "trend entry" {
+		t.Errorf("received '%v' expected '%v'", s.GetTag(), "trend entry")
+	}
+}
+
+func TestGetAccount(t *testing.T) {
+	t.Parallel()
+	s := &Signal{Account: "variant a"}
+	if s.GetAccount() != "variant a" {
+		t.Errorf("received '%v' expected '%v'", s.GetAccount(), "variant a")
+	}
+}
+
+func TestGetStrength(t *testing.T) {
+	t.Parallel()
+	s := &Signal{Strength: decimal.NewFromInt(3)}
+	if !s.GetStrength().Equal(decimal.NewFromInt(3)) {
+		t.Errorf("received '%v' expected '%v'", s.GetStrength(), 3)
+	}
+}
+
+func TestGetLimitPrice(t *testing.T) {
+	t.Parallel()
+	s := &Signal{
+		LimitPrice: decimal.NewFromInt(1337),
+	}
+	if !s.GetLimitPrice().Equal(decimal.NewFromInt(1337)) {
+		t.Errorf("received '%v' expected '%v'", s.GetLimitPrice(), decimal.NewFromInt(1337))
+	}
+}
+
+func TestGetStopPrice(t *testing.T) {
+	t.Parallel()
+	s := &Signal{
+		StopPrice: decimal.NewFromInt(1337),
+	}
+	if !s.GetStopPrice().Equal(decimal.NewFromInt(1337)) {
+		t.Errorf("received '%v' expected '%v'", s.GetStopPrice(), decimal.NewFromInt(1337))
+	}
+}
+
+func TestGetTakeProfitPrice(t *testing.T) {
+	t.Parallel()
+	s := &Signal{
+		TakeProfitPrice: decimal.NewFromInt(1337),
+	}
+	if !s.GetTakeProfitPrice().Equal(decimal.NewFromInt(1337)) {
+		t.Errorf("received '%v' expected '%v'", s.GetTakeProfitPrice(), decimal.NewFromInt(1337))
+	}
+}
+
+func TestGetTakeProfitPercent(t *testing.T) {
+	t.Parallel()
+	s := Signal{
+		TakeProfitPercent: decimal.NewFromInt(1),
+	}
+	if !s.GetTakeProfitPercent().Equal(decimal.NewFromInt(1)) {
+		t.Errorf("received '%v' expected '%v'", s.GetTakeProfitPercent(), decimal.NewFromInt(1))
+	}
+}
+
+func TestGetTrailingStopDistance(t *testing.T) {
+	t.Parallel()
+	s := &Signal{
+		TrailingStopDistance: decimal.NewFromInt(1337),
+	}
+	if !s.GetTrailingStopDistance().Equal(decimal.NewFromInt(1337)) {
+		t.Errorf("received '%v' expected '%v'", s.GetTrailingStopDistance(), decimal.NewFromInt(1337))
+	}
+}
+
+func TestGetTrailingStopPercent(t *testing.T) {
+	t.Parallel()
+	s := &Signal{
+		TrailingStopPercent: decimal.NewFromInt(5),
+	}
+	if !s.GetTrailingStopPercent().Equal(decimal.NewFromInt(5)) {
+		t.Errorf("received '%v' expected '%v'", s.GetTrailingStopPercent(), decimal.NewFromInt(5))
+	}
+}
+
+func TestGetFeeOverride(t *testing.T) {
+	t.Parallel()
+	s := &Signal{}
+	if s.GetFeeOverride() != nil {
+		t.Errorf("received '%v' expected '%v'", s.GetFeeOverride(), nil)
+	}
+	fee := decimal.Zero
+	s.FeeOverride = &fee
+	if s.GetFeeOverride() == nil || !s.GetFeeOverride().IsZero() {
+		t.Errorf("received '%v' expected '%v'", s.GetFeeOverride(), fee)
+	}
+}
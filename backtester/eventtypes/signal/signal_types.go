@@ -20,9 +20,22 @@ type Event interface {
 	GetBuyLimit() decimal.Decimal
 	GetAmount() decimal.Decimal
 	GetFillDependentEvent() Event
+	GetRebalanceEvent() Event
 	GetCollateralCurrency() currency.Code
 	SetAmount(decimal.Decimal)
+	SetPrice(decimal.Decimal)
 	MatchOrderAmount() bool
+	GetMaxSlippageTolerance() decimal.Decimal
+	GetTag() string
+	GetAccount() string
+	GetStrength() decimal.Decimal
+	GetFeeOverride() *decimal.Decimal
+	GetLimitPrice() decimal.Decimal
+	GetStopPrice() decimal.Decimal
+	GetTakeProfitPrice() decimal.Decimal
+	GetTakeProfitPercent() decimal.Decimal
+	GetTrailingStopDistance() decimal.Decimal
+	GetTrailingStopPercent() decimal.Decimal
 	IsNil() bool
 }
 
@@ -61,4 +74,50 @@ type Signal struct {
 	// MatchOrderAmount flags to other event handlers
 	// that the order amount must match the set Amount property
 	MatchesOrderAmount bool
+	// MaxSlippageTolerance is an optional percentage of adverse
+	// slippage the strategy will accept. If exceeded, the order is
+	// cancelled rather than filled. Zero means any slippage is accepted
+	MaxSlippageTolerance decimal.Decimal
+	// Tag is an optional label grouping orders into a logical
+	// stream, eg "trend entry", for per-tag performance statistics
+	Tag string
+	// Account is the optional name of the segregated account the order
+	// is funded by. Orders without an account use the default account
+	Account string
+	// Strength is an optional measure of the strategy's conviction in
+	// the signal, used to prioritise simultaneous signals competing for
+	// shared funding. Larger values are processed first
+	Strength decimal.Decimal
+	// FeeOverride is an optional fee rate used for the order instead of the
+	// exchange's taker fee, eg a promotional zero fee trade or a known maker fill
+	FeeOverride *decimal.Decimal
+	// LimitPrice is an optional price to place a limit order at. The order
+	// only fills once a candle's range crosses the limit price and rests
+	// across subsequent candles until then. Zero places a market order
+	LimitPrice decimal.Decimal
+	// StopPrice is an optional trigger price to place a stop order at, eg a
+	// stop-loss protecting an open position. The order remains dormant until
+	// a candle's range breaches the stop price. Zero places a market order
+	StopPrice decimal.Decimal
+	// TakeProfitPrice is an optional target price to place a take-profit
+	// order at, closing an open position in profit. The order remains pending
+	// until a candle's range reaches the target. Zero places a market order
+	TakeProfitPrice decimal.Decimal
+	// TakeProfitPercent is an optional take-profit target expressed as a
+	// percentage move from the open position's entry price, eg 1 for 1%. The
+	// target price is resolved from the entry fill when the order is
+	// executed. It cannot be combined with TakeProfitPrice
+	TakeProfitPercent decimal.Decimal
+	// TrailingStopDistance is an optional price distance to place a trailing
+	// stop at, which trails the best price reached by the distance and
+	// triggers once the price retraces by it. Zero places a market order
+	TrailingStopDistance decimal.Decimal
+	// TrailingStopPercent is an optional trailing stop distance expressed as
+	// a percentage of the best price reached, eg 5 for 5%. It cannot be
+	// combined with TrailingStopDistance. Zero places a market order
+	TrailingStopPercent decimal.Decimal
+	// RebalanceEvent is an optional buy signal funded by the proceeds of
+	// this sell signal. Both legs are processed within the same offset
+	// and neither is kept unless both fill
+	RebalanceEvent Event
 }
@@ -0,0 +1,91 @@
+package funding
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	gctcommon "github.com/thrasher-corp/gocryptotrader/common"
+)
+
+var (
+	errZeroCapitalFlow      = errors.New("capital flow amount cannot be zero")
+	errCapitalFlowNotSpot   = errors.New("capital flows can only be applied to spot funding")
+	errCapitalFlowTimeUnset = errors.New("capital flow time unset")
+)
+
+// SetCapitalFlows schedules external deposits and withdrawals to be applied
+// to matching funding items as the run reaches their time
+func (f *FundManager) SetCapitalFlows(flows []CapitalFlow) error {
+	scheduled := make([]CapitalFlow, len(flows))
+	for i := range flows {
+		flow := flows[i]
+		flow.Exchange = strings.ToLower(flow.Exchange)
+		if flow.Time.IsZero() {
+			return fmt.Errorf("%v %v %v %w", flow.Exchange, flow.Asset, flow.Currency, errCapitalFlowTimeUnset)
+		}
+		if flow.Amount.IsZero() {
+			return fmt.Errorf("%v %v %v %w", flow.Exchange, flow.Asset, flow.Currency, errZeroCapitalFlow)
+		}
+		if flow.Asset.IsFutures() {
+			return fmt.Errorf("%v %v %v %w", flow.Exchange, flow.Asset, flow.Currency, errCapitalFlowNotSpot)
+		}
+		if _, err := f.getFundingForCapitalFlow(&flow); err != nil {
+			return err
+		}
+		scheduled[i] = flow
+	}
+	sort.SliceStable(scheduled, func(i, j int) bool {
+		return scheduled[i].Time.Before(scheduled[j].Time)
+	})
+	f.capitalFlows = scheduled
+	f.appliedCapitalFlows = nil
+	return nil
+}
+
+// ApplyCapitalFlows applies all scheduled deposits and withdrawals due at
+// or before the time. Flows are only applied once. A withdrawal larger than
+// the funds available is skipped and returned as an error
+func (f *FundManager) ApplyCapitalFlows(t time.Time) error {
+	var errs gctcommon.Errors
+	for len(f.capitalFlows) > 0 && !f.capitalFlows[0].Time.After(t) {
+		flow := f.capitalFlows[0]
+		f.capitalFlows = f.capitalFlows[1:]
+		item, err := f.getFundingForCapitalFlow(&flow)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if flow.Amount.IsNegative() && flow.Amount.Abs().GreaterThan(item.available) {
+			errs = append(errs, fmt.Errorf("%w for %v %v %v withdrawal at %v. Requested %v Available: %v",
+				errNotEnoughFunds,
+				item.exchange,
+				item.asset,
+				item.currency,
+				flow.Time,
+				flow.Amount.Abs(),
+				item.available))
+			continue
+		}
+		item.available = item.available.Add(flow.Amount)
+		flow.AppliedTime = t
+		f.appliedCapitalFlows = append(f.appliedCapitalFlows, flow)
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// getFundingForCapitalFlow returns the funding item a capital flow
+// deposits into or withdraws from
+func (f *FundManager) getFundingForCapitalFlow(flow *CapitalFlow) (*Item, error) {
+	for i := range f.items {
+		if f.items[i].BasicEqual(flow.Exchange, flow.Asset, flow.Currency, flow.PairedWith) {
+			return f.items[i], nil
+		}
+	}
+	return nil, fmt.Errorf("capital flow %v %v %v %w", flow.Exchange, flow.Asset, flow.Currency, ErrFundsNotFound)
+}
@@ -0,0 +1,133 @@
+package funding
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+)
+
+func TestSetCapitalFlows(t *testing.T) {
+	t.Parallel()
+	f := FundManager{}
+	item, err := CreateItem(exchName, a, currency.USDT, elite, decimal.Zero)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	err = f.AddItem(item)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	tt := time.Date(2022, 1, 3, 0, 0, 0, 0, time.UTC)
+	flow := CapitalFlow{Exchange: exchName, Asset: a, Currency: currency.USDT, Amount: one}
+	err = f.SetCapitalFlows([]CapitalFlow{flow})
+	if !errors.Is(err, errCapitalFlowTimeUnset) {
+		t.Errorf("received '%v' expected '%v'", err, errCapitalFlowTimeUnset)
+	}
+	flow.Time = tt
+	flow.Amount = decimal.Zero
+	err = f.SetCapitalFlows([]CapitalFlow{flow})
+	if !errors.Is(err, errZeroCapitalFlow) {
+		t.Errorf("received '%v' expected '%v'", err, errZeroCapitalFlow)
+	}
+	flow.Amount = one
+	flow.Asset = asset.Futures
+	err = f.SetCapitalFlows([]CapitalFlow{flow})
+	if !errors.Is(err, errCapitalFlowNotSpot) {
+		t.Errorf("received '%v' expected '%v'", err, errCapitalFlowNotSpot)
+	}
+	flow.Asset = a
+	flow.Currency = currency.BTC
+	err = f.SetCapitalFlows([]CapitalFlow{flow})
+	if !errors.Is(err, ErrFundsNotFound) {
+		t.Errorf("received '%v' expected '%v'", err, ErrFundsNotFound)
+	}
+	flow.Currency = currency.USDT
+	later := flow
+	later.Time = tt.Add(time.Hour)
+	err = f.SetCapitalFlows([]CapitalFlow{later, flow})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	if len(f.capitalFlows) != 2 || !f.capitalFlows[0].Time.Equal(tt) {
+		t.Errorf("received '%v' expected flows sorted by time", f.capitalFlows)
+	}
+}
+
+func TestApplyCapitalFlows(t *testing.T) {
+	t.Parallel()
+	f := FundManager{}
+	item, err := CreateItem(exchName, a, currency.USDT, decimal.NewFromInt(100), decimal.Zero)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	err = f.AddItem(item)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	tt := time.Date(2022, 1, 3, 0, 0, 0, 0, time.UTC)
+	err = f.SetCapitalFlows([]CapitalFlow{
+		{Time: tt, Exchange: exchName, Asset: a, Currency: currency.USDT, Amount: decimal.NewFromInt(50)},
+		{Time: tt.Add(time.Hour), Exchange: exchName, Asset: a, Currency: currency.USDT, Amount: decimal.NewFromInt(-1000)},
+		{Time: tt.Add(time.Hour), Exchange: exchName, Asset: a, Currency: currency.USDT, Amount: decimal.NewFromInt(-30)},
+	})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+
+	err = f.ApplyCapitalFlows(tt.Add(-time.Minute))
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	if !item.available.Equal(decimal.NewFromInt(100)) {
+		t.Errorf("received '%v' expected '%v'", item.available, 100)
+	}
+
+	err = f.ApplyCapitalFlows(tt.Add(time.Minute))
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	if !item.available.Equal(decimal.NewFromInt(150)) {
+		t.Errorf("received '%v' expected '%v'", item.available, 150)
+	}
+	err = f.ApplyCapitalFlows(tt.Add(time.Minute))
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	if !item.available.Equal(decimal.NewFromInt(150)) {
+		t.Errorf("received '%v' expected flows to only apply once", item.available)
+	}
+
+	err = f.ApplyCapitalFlows(tt.Add(time.Hour))
+	if !errors.Is(err, errNotEnoughFunds) {
+		t.Fatalf("received '%v' expected '%v'", err, errNotEnoughFunds)
+	}
+	if !item.available.Equal(decimal.NewFromInt(120)) {
+		t.Errorf("received '%v' expected '%v'", item.available, 120)
+	}
+	if len(f.appliedCapitalFlows) != 2 {
+		t.Fatalf("received '%v' expected '%v'", len(f.appliedCapitalFlows), 2)
+	}
+	if !f.appliedCapitalFlows[1].AppliedTime.Equal(tt.Add(time.Hour)) {
+		t.Errorf("received '%v' expected '%v'", f.appliedCapitalFlows[1].AppliedTime, tt.Add(time.Hour))
+	}
+
+	item.snapshot[tt.Add(time.Minute).UnixNano()] = ItemSnapshot{USDClosePrice: one}
+	item.snapshot[tt.Add(time.Hour).UnixNano()] = ItemSnapshot{USDClosePrice: one}
+	report := f.GenerateReport()
+	if len(report.CapitalFlows) != 2 {
+		t.Fatalf("received '%v' expected '%v'", len(report.CapitalFlows), 2)
+	}
+	if !report.NetCapitalFlows.Equal(decimal.NewFromInt(20)) {
+		t.Errorf("received '%v' expected '%v'", report.NetCapitalFlows, 20)
+	}
+	if !report.Items[0].NetCapitalFlow.Equal(decimal.NewFromInt(20)) {
+		t.Errorf("received '%v' expected '%v'", report.Items[0].NetCapitalFlow, 20)
+	}
+	if !report.Items[0].Difference.IsZero() {
+		t.Errorf("received '%v' expected capital flows to be excluded from the difference", report.Items[0].Difference)
+	}
+}
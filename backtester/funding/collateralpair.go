@@ -80,6 +80,31 @@ func (c *CollateralPair) ReleaseContracts(amount decimal.Decimal) error {
 	return nil
 }
 
+// ChargeCost removes a cost accrued against the position, such as
+// the cost of borrowing to hold a short, from the available collateral.
+// A cost the available collateral cannot cover is not charged and returns
+// gctorder.ErrPositionLiquidated so that the position can be liquidated
+func (c *CollateralPair) ChargeCost(amount decimal.Decimal) error {
+	if amount.LessThanOrEqual(decimal.Zero) {
+		return fmt.Errorf("charge cost %w", errPositiveOnly)
+	}
+	if amount.GreaterThan(c.collateral.available) {
+		return fmt.Errorf("%w cost '%v' exceeds available collateral '%v'", gctorder.ErrPositionLiquidated, amount, c.collateral.available)
+	}
+	c.collateral.available = c.collateral.available.Sub(amount)
+	return nil
+}
+
+// ReceivePayment adds a payment received against the position, such as
+// a funding rate payment to a short, to the available collateral
+func (c *CollateralPair) ReceivePayment(amount decimal.Decimal) error {
+	if amount.LessThanOrEqual(decimal.Zero) {
+		return fmt.Errorf("receive payment %w", errPositiveOnly)
+	}
+	c.collateral.available = c.collateral.available.Add(amount)
+	return nil
+}
+
 // Reserve reserves or releases collateral based on order side
 func (c *CollateralPair) Reserve(amount decimal.Decimal, side gctorder.Side) error {
 	switch side {
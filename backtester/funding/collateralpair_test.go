@@ -175,6 +175,70 @@ func TestCollateralReleaseContracts(t *testing.T) {
 	}
 }
 
+func TestCollateralChargeCost(t *testing.T) {
+	t.Parallel()
+	c := &CollateralPair{
+		collateral: &Item{
+			asset:        asset.Futures,
+			isCollateral: true,
+			available:    decimal.NewFromInt(1337),
+		},
+		contract: &Item{asset: asset.Futures},
+	}
+
+	expectedError := errPositiveOnly
+	err := c.ChargeCost(decimal.Zero)
+	if !errors.Is(err, expectedError) {
+		t.Errorf("recevied '%v' expected '%v'", err, expectedError)
+	}
+
+	expectedError = nil
+	err = c.ChargeCost(decimal.NewFromInt(37))
+	if !errors.Is(err, expectedError) {
+		t.Errorf("recevied '%v' expected '%v'", err, expectedError)
+	}
+	if !c.collateral.available.Equal(decimal.NewFromInt(1300)) {
+		t.Errorf("recevied '%v' expected '%v'", c.collateral.available, decimal.NewFromInt(1300))
+	}
+
+	// a cost exceeding the available collateral is not charged
+	expectedError = gctorder.ErrPositionLiquidated
+	err = c.ChargeCost(decimal.NewFromInt(1301))
+	if !errors.Is(err, expectedError) {
+		t.Errorf("recevied '%v' expected '%v'", err, expectedError)
+	}
+	if !c.collateral.available.Equal(decimal.NewFromInt(1300)) {
+		t.Errorf("recevied '%v' expected '%v'", c.collateral.available, decimal.NewFromInt(1300))
+	}
+}
+
+func TestCollateralReceivePayment(t *testing.T) {
+	t.Parallel()
+	c := &CollateralPair{
+		collateral: &Item{
+			asset:        asset.Futures,
+			isCollateral: true,
+			available:    decimal.NewFromInt(1300),
+		},
+		contract: &Item{asset: asset.Futures},
+	}
+
+	expectedError := errPositiveOnly
+	err := c.ReceivePayment(decimal.NewFromInt(-1))
+	if !errors.Is(err, expectedError) {
+		t.Errorf("recevied '%v' expected '%v'", err, expectedError)
+	}
+
+	expectedError = nil
+	err = c.ReceivePayment(decimal.NewFromInt(37))
+	if !errors.Is(err, expectedError) {
+		t.Errorf("recevied '%v' expected '%v'", err, expectedError)
+	}
+	if !c.collateral.available.Equal(decimal.NewFromInt(1337)) {
+		t.Errorf("recevied '%v' expected '%v'", c.collateral.available, decimal.NewFromInt(1337))
+	}
+}
+
 func TestCollateralFundReader(t *testing.T) {
 	t.Parallel()
 	c := &CollateralPair{
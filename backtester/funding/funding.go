@@ -357,10 +357,24 @@ func (f *FundManager) GenerateReport() *Report {
 		})
 		item.Snapshots = pricingOverTime
 
+		for y := range f.appliedCapitalFlows {
+			if !f.items[x].BasicEqual(f.appliedCapitalFlows[y].Exchange, f.appliedCapitalFlows[y].Asset, f.appliedCapitalFlows[y].Currency, f.appliedCapitalFlows[y].PairedWith) {
+				continue
+			}
+			flow := f.appliedCapitalFlows[y]
+			item.NetCapitalFlow = item.NetCapitalFlow.Add(flow.Amount)
+			if !f.disableUSDTracking {
+				flow.USDValue = flow.Amount.Mul(f.items[x].snapshot[flow.AppliedTime.UnixNano()].USDClosePrice)
+			}
+			report.CapitalFlows = append(report.CapitalFlows, flow)
+			report.NetCapitalFlows = report.NetCapitalFlows.Add(flow.USDValue)
+		}
+
 		if f.items[x].initialFunds.IsZero() {
 			item.ShowInfinite = true
 		} else {
-			item.Difference = f.items[x].available.Sub(f.items[x].initialFunds).Div(f.items[x].initialFunds).Mul(decimal.NewFromInt(100))
+			// external deposits and withdrawals are not returns
+			item.Difference = f.items[x].available.Sub(item.NetCapitalFlow).Sub(f.items[x].initialFunds).Div(f.items[x].initialFunds).Mul(decimal.NewFromInt(100))
 		}
 		if f.items[x].pairedWith != nil {
 			item.PairedWith = f.items[x].pairedWith.currency
@@ -377,6 +391,9 @@ func (f *FundManager) GenerateReport() *Report {
 		report.FinalFunds = report.USDTotalsOverTime[len(report.USDTotalsOverTime)-1].USDValue
 	}
 
+	sort.SliceStable(report.CapitalFlows, func(i, j int) bool {
+		return report.CapitalFlows[i].AppliedTime.Before(report.CapitalFlows[j].AppliedTime)
+	})
 	report.Items = items
 	return &report
 }
@@ -496,6 +513,8 @@ func (f *FundManager) getFundingForEAP(exch string, a asset.Item, p currency.Pai
 		if resp.quote == nil {
 			return nil, fmt.Errorf("quote %v %w", p.Quote, ErrFundsNotFound)
 		}
+		resp.quoteBasket = f.getQuoteBasket(resp.quote)
+		resp.quotePriority = f.quoteFundingPriority
 		return &resp, nil
 	}
 
@@ -27,7 +27,10 @@ type IFundingManager interface {
 	UpdateCollateral(common.EventHandler) error
 	HasFutures() bool
 	HasExchangeBeenLiquidated(handler common.EventHandler) bool
+	SaveState() *State
+	RestoreState(*State) error
 	RealisePNL(receivingExchange string, receivingAsset asset.Item, receivingCurrency currency.Code, realisedPNL decimal.Decimal) error
+	ApplyCapitalFlows(time.Time) error
 }
 
 // IFundingTransferer allows for funding amounts to be transferred
@@ -101,6 +104,7 @@ type IPairReleaser interface {
 	IPairReader
 	IncreaseAvailable(decimal.Decimal, order.Side) error
 	Release(decimal.Decimal, decimal.Decimal, order.Side) error
+	RevertFill(decimal.Decimal, decimal.Decimal, order.Side) error
 	Liquidate()
 }
 
@@ -110,6 +114,8 @@ type ICollateralReleaser interface {
 	UpdateContracts(order.Side, decimal.Decimal) error
 	TakeProfit(contracts, positionReturns decimal.Decimal) error
 	ReleaseContracts(decimal.Decimal) error
+	ChargeCost(decimal.Decimal) error
+	ReceivePayment(decimal.Decimal) error
 	Liquidate()
 }
 
@@ -120,6 +126,10 @@ type FundManager struct {
 	disableUSDTracking        bool
 	items                     []*Item
 	exchangeManager           *engine.ExchangeManager
+	capitalFlows              []CapitalFlow
+	appliedCapitalFlows       []CapitalFlow
+	useQuoteFundingBasket     bool
+	quoteFundingPriority      []currency.Code
 }
 
 // Item holds funding data per currency item
@@ -137,12 +147,63 @@ type Item struct {
 	isCollateral      bool
 	isLiquidated      bool
 	collateralCandles map[currency.Code]kline.DataFromKline
+	quoteReservations []quoteReservation
 }
 
 // SpotPair holds two currencies that are associated with each other
 type SpotPair struct {
-	base  *Item
-	quote *Item
+	base          *Item
+	quote         *Item
+	quoteBasket   []*Item
+	quotePriority []currency.Code
+}
+
+// quoteReservation is a reservation made from a quote funding basket
+type quoteReservation struct {
+	amount decimal.Decimal
+	draws  []quoteDraw
+}
+
+// quoteDraw is the amount a reservation drew from a basket item
+type quoteDraw struct {
+	item   *Item
+	amount decimal.Decimal
+}
+
+// State is a serialisable copy of the fund manager's funding items and
+// capital flows which can be restored to a fund manager set up with the
+// same funding items
+type State struct {
+	Items               []ItemState
+	CapitalFlows        []CapitalFlow
+	AppliedCapitalFlows []CapitalFlow
+}
+
+// ItemState is a copy of a funding item's funds, snapshots
+// and reservations from a quote funding basket
+type ItemState struct {
+	Exchange          string
+	Asset             asset.Item
+	Currency          currency.Code
+	InitialFunds      decimal.Decimal
+	Available         decimal.Decimal
+	Reserved          decimal.Decimal
+	Snapshot          map[int64]ItemSnapshot
+	IsLiquidated      bool
+	QuoteReservations []QuoteReservationState
+}
+
+// QuoteReservationState is a copy of a reservation made from a quote funding
+// basket, referencing the items it drew from by their index
+type QuoteReservationState struct {
+	Amount decimal.Decimal
+	Draws  []QuoteDrawState
+}
+
+// QuoteDrawState is the amount a reservation drew from the item at the index
+type QuoteDrawState struct {
+	Item   int
+	Amount decimal.Decimal
 }
 
 // CollateralPair consists of a currency pair for a futures contract
@@ -172,6 +233,8 @@ type Report struct {
 	USDTotalsOverTime         []ItemSnapshot
 	InitialFunds              decimal.Decimal
 	FinalFunds                decimal.Decimal
+	CapitalFlows              []CapitalFlow
+	NetCapitalFlows           decimal.Decimal
 }
 
 // ReportItem holds reporting fields
@@ -192,6 +255,7 @@ type ReportItem struct {
 	ShowInfinite         bool
 	PairedWith           currency.Code
 	IsCollateral         bool
+	NetCapitalFlow       decimal.Decimal
 }
 
 // ItemSnapshot holds USD values to allow for tracking
@@ -204,6 +268,20 @@ type ItemSnapshot struct {
 	Breakdown     []CurrencyContribution
 }
 
+// CapitalFlow is an external deposit or withdrawal of funds scheduled
+// for a point in time during a run. A negative amount is a withdrawal.
+// AppliedTime and USDValue are populated once the flow has been applied
+type CapitalFlow struct {
+	Time        time.Time
+	Exchange    string
+	Asset       asset.Item
+	Currency    currency.Code
+	PairedWith  currency.Code
+	Amount      decimal.Decimal
+	AppliedTime time.Time
+	USDValue    decimal.Decimal
+}
+
 // CurrencyContribution helps breakdown how a USD value
 // determines its number
 type CurrencyContribution struct {
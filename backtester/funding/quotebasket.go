@@ -0,0 +1,141 @@
+package funding
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/shopspring/decimal"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+)
+
+var (
+	errQuoteBasketRequiresExchangeLevelFunding = errors.New("quote funding basket requires exchange level funding")
+	errQuoteBasketCurrencyNotStable            = errors.New("quote funding basket currencies must be stablecoins")
+)
+
+// SetQuoteFundingBasket allows spot buys quoted in a stablecoin to draw from
+// every stablecoin held at the same exchange and asset, treating them as
+// interchangeable at par. Currencies in the priority are drawn down first in
+// the order provided, followed by any remaining stablecoins from the largest
+// available balance to the smallest
+func (f *FundManager) SetQuoteFundingBasket(priority []currency.Code) error {
+	if !f.usingExchangeLevelFunding {
+		return errQuoteBasketRequiresExchangeLevelFunding
+	}
+	for i := range priority {
+		if !priority[i].IsStableCurrency() {
+			return fmt.Errorf("%w, received %v", errQuoteBasketCurrencyNotStable, priority[i])
+		}
+	}
+	f.useQuoteFundingBasket = true
+	f.quoteFundingPriority = priority
+	return nil
+}
+
+// getQuoteBasket returns the other stablecoin funding items
+// which can fund buys made with the quote item
+func (f *FundManager) getQuoteBasket(quote *Item) []*Item {
+	if !f.useQuoteFundingBasket || !quote.currency.IsStableCurrency() {
+		return nil
+	}
+	var basket []*Item
+	for i := range f.items {
+		if f.items[i] == quote ||
+			f.items[i].isCollateral ||
+			!f.items[i].currency.IsStableCurrency() ||
+			!f.items[i].BasicEqual(quote.exchange, quote.asset, f.items[i].currency, currency.EMPTYCODE) {
+			continue
+		}
+		basket = append(basket, f.items[i])
+	}
+	return basket
+}
+
+// quoteDrawOrder returns the quote item and its basket in the order they
+// are drawn from. Prioritised currencies come first, then the largest
+// available balance. The quote item wins any tie so that it is preferred
+func (p *SpotPair) quoteDrawOrder() []*Item {
+	items := append([]*Item{p.quote}, p.quoteBasket...)
+	rank := func(item *Item) int {
+		for i := range p.quotePriority {
+			if item.currency.Equal(p.quotePriority[i]) {
+				return i
+			}
+		}
+		return len(p.quotePriority)
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		iRank, jRank := rank(items[i]), rank(items[j])
+		if iRank != jRank {
+			return iRank < jRank
+		}
+		return items[i].available.GreaterThan(items[j].available)
+	})
+	return items
+}
+
+// reserveFromQuoteBasket moves funds from the basket into the quote item in
+// draw order before reserving them, recording where they came from so that
+// any funds later released can be refunded to their original currency
+func (p *SpotPair) reserveFromQuoteBasket(amount decimal.Decimal) error {
+	if amount.LessThanOrEqual(decimal.Zero) {
+		return errZeroAmountReceived
+	}
+	available := p.QuoteAvailable()
+	if amount.GreaterThan(available) {
+		return fmt.Errorf("%w for %v %v %v quote funding basket. Requested %v Available: %v",
+			errCannotAllocate,
+			p.quote.exchange,
+			p.quote.asset,
+			p.quote.currency,
+			amount,
+			available)
+	}
+	reservation := quoteReservation{amount: amount}
+	remaining := amount
+	for _, item := range p.quoteDrawOrder() {
+		draw := decimal.Min(item.available, remaining)
+		if !draw.IsPositive() {
+			continue
+		}
+		if item != p.quote {
+			item.available = item.available.Sub(draw)
+			p.quote.available = p.quote.available.Add(draw)
+		}
+		reservation.draws = append(reservation.draws, quoteDraw{item: item, amount: draw})
+		remaining = remaining.Sub(draw)
+		if remaining.IsZero() {
+			break
+		}
+	}
+	err := p.quote.Reserve(amount)
+	if err != nil {
+		return err
+	}
+	p.quote.quoteReservations = append(p.quote.quoteReservations, reservation)
+	return nil
+}
+
+// refundQuoteBasket returns funds released from a basket reservation to
+// the currencies they were drawn from. The last currency drawn from is
+// refunded first, as the funds spent are those drawn first
+func (p *SpotPair) refundQuoteBasket(amount, diff decimal.Decimal) {
+	for i := range p.quote.quoteReservations {
+		if !p.quote.quoteReservations[i].amount.Equal(amount) {
+			continue
+		}
+		reservation := p.quote.quoteReservations[i]
+		p.quote.quoteReservations = append(p.quote.quoteReservations[:i], p.quote.quoteReservations[i+1:]...)
+		remaining := diff
+		for j := len(reservation.draws) - 1; j >= 0 && remaining.IsPositive(); j-- {
+			refund := decimal.Min(reservation.draws[j].amount, remaining)
+			if reservation.draws[j].item != p.quote {
+				p.quote.available = p.quote.available.Sub(refund)
+				reservation.draws[j].item.available = reservation.draws[j].item.available.Add(refund)
+			}
+			remaining = remaining.Sub(refund)
+		}
+		return
+	}
+}
@@ -0,0 +1,180 @@
+package funding
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/engine"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	gctorder "github.com/thrasher-corp/gocryptotrader/exchanges/order"
+)
+
+func setupQuoteBasket(t *testing.T, priority []currency.Code) *FundManager {
+	t.Helper()
+	f, err := SetupFundingManager(&engine.ExchangeManager{}, true, true)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	for code, funds := range map[currency.Code]int64{
+		currency.BTC:  0,
+		currency.USDT: 100,
+		currency.USDC: 300,
+		currency.BUSD: 200,
+	} {
+		var item *Item
+		item, err = CreateItem(exchName, asset.Spot, code, decimal.NewFromInt(funds), decimal.Zero)
+		if !errors.Is(err, nil) {
+			t.Fatalf("received '%v' expected '%v'", err, nil)
+		}
+		err = f.AddItem(item)
+		if !errors.Is(err, nil) {
+			t.Fatalf("received '%v' expected '%v'", err, nil)
+		}
+	}
+	err = f.SetQuoteFundingBasket(priority)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	return f
+}
+
+func quoteBasketAvailable(t *testing.T, f *FundManager, code currency.Code) decimal.Decimal {
+	t.Helper()
+	item, err := f.getFundingForEAC(exchName, asset.Spot, code)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	return item.available
+}
+
+func TestSetQuoteFundingBasket(t *testing.T) {
+	t.Parallel()
+	f := FundManager{}
+	err := f.SetQuoteFundingBasket(nil)
+	if !errors.Is(err, errQuoteBasketRequiresExchangeLevelFunding) {
+		t.Errorf("received '%v' expected '%v'", err, errQuoteBasketRequiresExchangeLevelFunding)
+	}
+	f.usingExchangeLevelFunding = true
+	err = f.SetQuoteFundingBasket([]currency.Code{currency.BTC})
+	if !errors.Is(err, errQuoteBasketCurrencyNotStable) {
+		t.Errorf("received '%v' expected '%v'", err, errQuoteBasketCurrencyNotStable)
+	}
+	err = f.SetQuoteFundingBasket([]currency.Code{currency.USDC})
+	if !errors.Is(err, nil) {
+		t.Errorf("received '%v' expected '%v'", err, nil)
+	}
+	if !f.useQuoteFundingBasket {
+		t.Error("expected quote funding basket to be used")
+	}
+}
+
+func TestReserveFromQuoteBasketLargestFirst(t *testing.T) {
+	t.Parallel()
+	f := setupQuoteBasket(t, nil)
+	funds, err := f.getFundingForEAP(exchName, asset.Spot, currency.NewPair(currency.BTC, currency.USDT))
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	sp, ok := funds.(*SpotPair)
+	if !ok {
+		t.Fatal("expected spot pair")
+	}
+	if !sp.QuoteAvailable().Equal(decimal.NewFromInt(600)) {
+		t.Errorf("received '%v' expected '%v'", sp.QuoteAvailable(), 600)
+	}
+	err = sp.Reserve(decimal.NewFromInt(400), gctorder.Buy)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	// USDC is drawn down first, then BUSD
+	if available := quoteBasketAvailable(t, f, currency.USDC); !available.IsZero() {
+		t.Errorf("received '%v' expected '%v'", available, 0)
+	}
+	if available := quoteBasketAvailable(t, f, currency.BUSD); !available.Equal(decimal.NewFromInt(100)) {
+		t.Errorf("received '%v' expected '%v'", available, 100)
+	}
+	if available := quoteBasketAvailable(t, f, currency.USDT); !available.Equal(decimal.NewFromInt(100)) {
+		t.Errorf("received '%v' expected '%v'", available, 100)
+	}
+	if !sp.quote.reserved.Equal(decimal.NewFromInt(400)) {
+		t.Errorf("received '%v' expected '%v'", sp.quote.reserved, 400)
+	}
+
+	// 150 is unspent, refunding BUSD before USDC
+	err = sp.Release(decimal.NewFromInt(400), decimal.NewFromInt(150), gctorder.Buy)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	if available := quoteBasketAvailable(t, f, currency.BUSD); !available.Equal(decimal.NewFromInt(200)) {
+		t.Errorf("received '%v' expected '%v'", available, 200)
+	}
+	if available := quoteBasketAvailable(t, f, currency.USDC); !available.Equal(decimal.NewFromInt(50)) {
+		t.Errorf("received '%v' expected '%v'", available, 50)
+	}
+	if available := quoteBasketAvailable(t, f, currency.USDT); !available.Equal(decimal.NewFromInt(100)) {
+		t.Errorf("received '%v' expected '%v'", available, 100)
+	}
+	if len(sp.quote.quoteReservations) != 0 {
+		t.Errorf("received '%v' expected '%v'", len(sp.quote.quoteReservations), 0)
+	}
+}
+
+func TestReserveFromQuoteBasketPriority(t *testing.T) {
+	t.Parallel()
+	f := setupQuoteBasket(t, []currency.Code{currency.USDT, currency.BUSD})
+	funds, err := f.getFundingForEAP(exchName, asset.Spot, currency.NewPair(currency.BTC, currency.USDT))
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	err = funds.FundReserver().Reserve(decimal.NewFromInt(250), gctorder.Buy)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	if available := quoteBasketAvailable(t, f, currency.USDT); !available.IsZero() {
+		t.Errorf("received '%v' expected '%v'", available, 0)
+	}
+	if available := quoteBasketAvailable(t, f, currency.BUSD); !available.Equal(decimal.NewFromInt(50)) {
+		t.Errorf("received '%v' expected '%v'", available, 50)
+	}
+	if available := quoteBasketAvailable(t, f, currency.USDC); !available.Equal(decimal.NewFromInt(300)) {
+		t.Errorf("received '%v' expected '%v'", available, 300)
+	}
+
+	err = funds.FundReserver().Reserve(decimal.NewFromInt(1337), gctorder.Buy)
+	if !errors.Is(err, errCannotAllocate) {
+		t.Errorf("received '%v' expected '%v'", err, errCannotAllocate)
+	}
+	err = funds.FundReserver().Reserve(decimal.Zero, gctorder.Buy)
+	if !errors.Is(err, errZeroAmountReceived) {
+		t.Errorf("received '%v' expected '%v'", err, errZeroAmountReceived)
+	}
+}
+
+func TestQuoteBasketIgnoresNonStableQuotes(t *testing.T) {
+	t.Parallel()
+	f := setupQuoteBasket(t, nil)
+	item, err := CreateItem(exchName, asset.Spot, currency.ETH, elite, decimal.Zero)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	err = f.AddItem(item)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	quote, err := f.getFundingForEAC(exchName, asset.Spot, currency.ETH)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	if basket := f.getQuoteBasket(quote); len(basket) != 0 {
+		t.Errorf("received '%v' expected '%v'", len(basket), 0)
+	}
+	quote, err = f.getFundingForEAC(exchName, asset.Spot, currency.USDT)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	if basket := f.getQuoteBasket(quote); len(basket) != 2 {
+		t.Errorf("received '%v' expected '%v'", len(basket), 2)
+	}
+}
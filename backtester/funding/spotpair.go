@@ -32,9 +32,14 @@ func (p *SpotPair) BaseAvailable() decimal.Decimal {
 }
 
 // QuoteAvailable returns the available funds
-// from the quote in a currency pair
+// from the quote in a currency pair, including
+// any quote funding basket
 func (p *SpotPair) QuoteAvailable() decimal.Decimal {
-	return p.quote.available
+	available := p.quote.available
+	for i := range p.quoteBasket {
+		available = available.Add(p.quoteBasket[i].available)
+	}
+	return available
 }
 
 // Reserve allocates an amount of funds to be used at a later time
@@ -43,6 +48,9 @@ func (p *SpotPair) QuoteAvailable() decimal.Decimal {
 func (p *SpotPair) Reserve(amount decimal.Decimal, side order.Side) error {
 	switch side {
 	case order.Buy, order.Bid:
+		if len(p.quoteBasket) > 0 {
+			return p.reserveFromQuoteBasket(amount)
+		}
 		return p.quote.Reserve(amount)
 	case order.Sell, order.Ask, order.ClosePosition:
 		return p.base.Reserve(amount)
@@ -62,7 +70,12 @@ func (p *SpotPair) Reserve(amount decimal.Decimal, side order.Side) error {
 func (p *SpotPair) Release(amount, diff decimal.Decimal, side order.Side) error {
 	switch side {
 	case order.Buy, order.Bid:
-		return p.quote.Release(amount, diff)
+		err := p.quote.Release(amount, diff)
+		if err != nil {
+			return err
+		}
+		p.refundQuoteBasket(amount, diff)
+		return nil
 	case order.Sell, order.Ask:
 		return p.base.Release(amount, diff)
 	}
@@ -91,13 +104,49 @@ func (p *SpotPair) IncreaseAvailable(amount decimal.Decimal, side order.Side) er
 		side)
 }
 
+// RevertFill undoes a filled order's changes to available funds by
+// returning the funds spent to the currency they were reserved from and
+// removing the funds acquired. Funds spent on buys drawn from a quote
+// funding basket are returned to the quote currency
+func (p *SpotPair) RevertFill(spent, acquired decimal.Decimal, side order.Side) error {
+	var spentItem, acquiredItem *Item
+	switch side {
+	case order.Buy, order.Bid:
+		spentItem, acquiredItem = p.quote, p.base
+	case order.Sell, order.Ask, order.ClosePosition:
+		spentItem, acquiredItem = p.base, p.quote
+	default:
+		return fmt.Errorf("%w for %v %v %v. Unknown side %v",
+			errCannotAllocate,
+			p.base.exchange,
+			p.base.asset,
+			p.base.currency,
+			side)
+	}
+	if spent.IsNegative() || acquired.IsNegative() {
+		return fmt.Errorf("%w spent %v acquired %v", errNegativeAmountReceived, spent, acquired)
+	}
+	if acquired.GreaterThan(acquiredItem.available) {
+		return fmt.Errorf("%w for %v %v %v. Requested %v Available: %v",
+			errCannotAllocate,
+			acquiredItem.exchange,
+			acquiredItem.asset,
+			acquiredItem.currency,
+			acquired,
+			acquiredItem.available)
+	}
+	acquiredItem.available = acquiredItem.available.Sub(acquired)
+	spentItem.available = spentItem.available.Add(spent)
+	return nil
+}
+
 // CanPlaceOrder does a > 0 check to see if there are any funds
 // to place an order with
 // changes which currency to affect based on the order side
 func (p *SpotPair) CanPlaceOrder(side order.Side) bool {
 	switch side {
 	case order.Buy, order.Bid:
-		return p.quote.CanPlaceOrder()
+		return p.QuoteAvailable().GreaterThan(decimal.Zero)
 	case order.Sell, order.Ask, order.ClosePosition:
 		return p.base.CanPlaceOrder()
 	}
@@ -229,6 +229,53 @@ func TestIncreaseAvailablePair(t *testing.T) {
 	}
 }
 
+func TestRevertFill(t *testing.T) {
+	t.Parallel()
+	baseItem, err := CreateItem(exchName, a, pair.Base, decimal.NewFromInt(1), decimal.Zero)
+	if !errors.Is(err, nil) {
+		t.Errorf("received '%v' expected '%v'", err, nil)
+	}
+	quoteItem, err := CreateItem(exchName, a, pair.Quote, elite, decimal.Zero)
+	if !errors.Is(err, nil) {
+		t.Errorf("received '%v' expected '%v'", err, nil)
+	}
+	pairItems := SpotPair{base: baseItem, quote: quoteItem}
+	err = pairItems.RevertFill(decimal.NewFromInt(2), elite.Add(decimal.NewFromInt(1)), gctorder.Sell)
+	if !errors.Is(err, errCannotAllocate) {
+		t.Errorf("received '%v' expected '%v'", err, errCannotAllocate)
+	}
+	err = pairItems.RevertFill(decimal.NewFromInt(-2), elite, gctorder.Sell)
+	if !errors.Is(err, errNegativeAmountReceived) {
+		t.Errorf("received '%v' expected '%v'", err, errNegativeAmountReceived)
+	}
+	err = pairItems.RevertFill(decimal.NewFromInt(2), elite, gctorder.UnknownSide)
+	if !errors.Is(err, errCannotAllocate) {
+		t.Errorf("received '%v' expected '%v'", err, errCannotAllocate)
+	}
+
+	err = pairItems.RevertFill(decimal.NewFromInt(2), elite, gctorder.Sell)
+	if !errors.Is(err, nil) {
+		t.Errorf("received '%v' expected '%v'", err, nil)
+	}
+	if !pairItems.base.available.Equal(decimal.NewFromInt(3)) {
+		t.Errorf("received '%v' expected '%v'", pairItems.base.available, 3)
+	}
+	if !pairItems.quote.available.IsZero() {
+		t.Errorf("received '%v' expected '%v'", pairItems.quote.available, 0)
+	}
+
+	err = pairItems.RevertFill(elite, decimal.NewFromInt(3), gctorder.Buy)
+	if !errors.Is(err, nil) {
+		t.Errorf("received '%v' expected '%v'", err, nil)
+	}
+	if !pairItems.base.available.IsZero() {
+		t.Errorf("received '%v' expected '%v'", pairItems.base.available, 0)
+	}
+	if !pairItems.quote.available.Equal(elite) {
+		t.Errorf("received '%v' expected '%v'", pairItems.quote.available, elite)
+	}
+}
+
 func TestCanPlaceOrderPair(t *testing.T) {
 	t.Parallel()
 	p := SpotPair{
@@ -0,0 +1,119 @@
+package funding
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	errNilState      = errors.New("received nil funding state")
+	errStateMismatch = errors.New("funding state does not match funding items")
+)
+
+// SaveState returns a copy of the funding items' funds, snapshots and quote
+// reservations along with the capital flows yet to be applied and applied
+func (f *FundManager) SaveState() *State {
+	s := &State{
+		Items:               make([]ItemState, len(f.items)),
+		CapitalFlows:        append([]CapitalFlow(nil), f.capitalFlows...),
+		AppliedCapitalFlows: append([]CapitalFlow(nil), f.appliedCapitalFlows...),
+	}
+	for i := range f.items {
+		s.Items[i] = ItemState{
+			Exchange:     f.items[i].exchange,
+			Asset:        f.items[i].asset,
+			Currency:     f.items[i].currency,
+			InitialFunds: f.items[i].initialFunds,
+			Available:    f.items[i].available,
+			Reserved:     f.items[i].reserved,
+			IsLiquidated: f.items[i].isLiquidated,
+		}
+		if f.items[i].snapshot != nil {
+			s.Items[i].Snapshot = make(map[int64]ItemSnapshot, len(f.items[i].snapshot))
+			for t, snap := range f.items[i].snapshot {
+				s.Items[i].Snapshot[t] = snap
+			}
+		}
+		for j := range f.items[i].quoteReservations {
+			reservation := QuoteReservationState{
+				Amount: f.items[i].quoteReservations[j].amount,
+			}
+			for k := range f.items[i].quoteReservations[j].draws {
+				reservation.Draws = append(reservation.Draws, QuoteDrawState{
+					Item:   f.itemIndex(f.items[i].quoteReservations[j].draws[k].item),
+					Amount: f.items[i].quoteReservations[j].draws[k].amount,
+				})
+			}
+			s.Items[i].QuoteReservations = append(s.Items[i].QuoteReservations, reservation)
+		}
+	}
+	return s
+}
+
+// RestoreState replaces the funding items' funds, snapshots and quote
+// reservations along with the capital flows with those of the state. The
+// state's items must match the fund manager's items in order
+func (f *FundManager) RestoreState(s *State) error {
+	if s == nil {
+		return errNilState
+	}
+	if len(s.Items) != len(f.items) {
+		return fmt.Errorf("%w state has %v items, fund manager has %v", errStateMismatch, len(s.Items), len(f.items))
+	}
+	for i := range s.Items {
+		if s.Items[i].Exchange != f.items[i].exchange ||
+			s.Items[i].Asset != f.items[i].asset ||
+			!s.Items[i].Currency.Equal(f.items[i].currency) {
+			return fmt.Errorf("%w state item %v %v %v, fund manager item %v %v %v",
+				errStateMismatch,
+				s.Items[i].Exchange, s.Items[i].Asset, s.Items[i].Currency,
+				f.items[i].exchange, f.items[i].asset, f.items[i].currency)
+		}
+		for j := range s.Items[i].QuoteReservations {
+			for k := range s.Items[i].QuoteReservations[j].Draws {
+				if idx := s.Items[i].QuoteReservations[j].Draws[k].Item; idx < 0 || idx >= len(f.items) {
+					return fmt.Errorf("%w quote reservation draws from item %v", errStateMismatch, idx)
+				}
+			}
+		}
+	}
+	for i := range s.Items {
+		f.items[i].initialFunds = s.Items[i].InitialFunds
+		f.items[i].available = s.Items[i].Available
+		f.items[i].reserved = s.Items[i].Reserved
+		f.items[i].isLiquidated = s.Items[i].IsLiquidated
+		f.items[i].snapshot = nil
+		if s.Items[i].Snapshot != nil {
+			f.items[i].snapshot = make(map[int64]ItemSnapshot, len(s.Items[i].Snapshot))
+			for t, snap := range s.Items[i].Snapshot {
+				f.items[i].snapshot[t] = snap
+			}
+		}
+		f.items[i].quoteReservations = nil
+		for j := range s.Items[i].QuoteReservations {
+			reservation := quoteReservation{
+				amount: s.Items[i].QuoteReservations[j].Amount,
+			}
+			for k := range s.Items[i].QuoteReservations[j].Draws {
+				reservation.draws = append(reservation.draws, quoteDraw{
+					item:   f.items[s.Items[i].QuoteReservations[j].Draws[k].Item],
+					amount: s.Items[i].QuoteReservations[j].Draws[k].Amount,
+				})
+			}
+			f.items[i].quoteReservations = append(f.items[i].quoteReservations, reservation)
+		}
+	}
+	f.capitalFlows = append([]CapitalFlow(nil), s.CapitalFlows...)
+	f.appliedCapitalFlows = append([]CapitalFlow(nil), s.AppliedCapitalFlows...)
+	return nil
+}
+
+// itemIndex returns the index of the funding item
+func (f *FundManager) itemIndex(item *Item) int {
+	for i := range f.items {
+		if f.items[i] == item {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,82 @@
+package funding
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+)
+
+func TestSaveRestoreState(t *testing.T) {
+	t.Parallel()
+	f := FundManager{disableUSDTracking: true}
+	item, err := CreateItem(exchName, a, currency.USDT, elite, decimal.Zero)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	err = f.AddItem(item)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	tt := time.Date(2022, 1, 3, 0, 0, 0, 0, time.UTC)
+	err = f.SetCapitalFlows([]CapitalFlow{{Time: tt, Exchange: exchName, Asset: a, Currency: currency.USDT, Amount: one}})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	err = item.Reserve(one)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	item.quoteReservations = []quoteReservation{{amount: one, draws: []quoteDraw{{item: item, amount: one}}}}
+	f.CreateSnapshot(tt)
+	s := f.SaveState()
+
+	err = f.ApplyCapitalFlows(tt)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	err = item.Release(one, decimal.Zero)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	item.quoteReservations = nil
+	f.CreateSnapshot(tt.Add(time.Hour))
+
+	err = f.RestoreState(s)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	if !item.available.Equal(elite.Sub(one)) || !item.reserved.Equal(one) {
+		t.Errorf("received available '%v' reserved '%v' expected '%v' '%v'", item.available, item.reserved, elite.Sub(one), one)
+	}
+	if len(f.capitalFlows) != 1 || len(f.appliedCapitalFlows) != 0 {
+		t.Errorf("received '%v' '%v' expected one scheduled flow", f.capitalFlows, f.appliedCapitalFlows)
+	}
+	if len(item.snapshot) != 1 {
+		t.Errorf("received '%v' expected '%v'", len(item.snapshot), 1)
+	}
+	if len(item.quoteReservations) != 1 || item.quoteReservations[0].draws[0].item != item {
+		t.Errorf("received '%v' expected a reservation drawing from the item", item.quoteReservations)
+	}
+
+	s.Items[0].QuoteReservations[0].Draws[0].Item = 1
+	err = f.RestoreState(s)
+	if !errors.Is(err, errStateMismatch) {
+		t.Errorf("received '%v' expected '%v'", err, errStateMismatch)
+	}
+	s.Items[0].Currency = currency.BTC
+	err = f.RestoreState(s)
+	if !errors.Is(err, errStateMismatch) {
+		t.Errorf("received '%v' expected '%v'", err, errStateMismatch)
+	}
+	err = f.RestoreState(&State{})
+	if !errors.Is(err, errStateMismatch) {
+		t.Errorf("received '%v' expected '%v'", err, errStateMismatch)
+	}
+	err = f.RestoreState(nil)
+	if !errors.Is(err, errNilState) {
+		t.Errorf("received '%v' expected '%v'", err, errNilState)
+	}
+}
@@ -17,7 +17,8 @@ import (
 	"github.com/thrasher-corp/gocryptotrader/signaler"
 )
 
-var singleRunStrategyPath, templatePath, outputPath, btConfigDir, strategyPluginPath string
+var singleRunStrategyPath, templatePath, outputPath, btConfigDir, strategyPluginPath, checkpointPath, resumeCheckpointPath string
+var checkpointInterval int64
 var printLogo, generateReport, darkReport, colourOutput, logSubHeader bool
 
 func main() {
@@ -104,6 +105,13 @@ func main() {
 		os.Exit(1)
 	}
 
+	if flags["checkpointinterval"] {
+		btCfg.Checkpoint.Interval = checkpointInterval
+	}
+	if flags["checkpointpath"] {
+		btCfg.Checkpoint.Path = checkpointPath
+	}
+
 	if colourOutput {
 		common.SetColours(&btCfg.Colours)
 	} else {
@@ -160,11 +168,19 @@ func main() {
 				OutputPath:     btCfg.Report.OutputPath,
 				DarkMode:       darkReport,
 			},
+			Checkpoint: btCfg.Checkpoint,
 		})
 		if err != nil {
 			fmt.Printf("Could not execute strategy. Error: %v.\n", err)
 			os.Exit(1)
 		}
+		if resumeCheckpointPath != "" {
+			err = bt.LoadCheckpoint(resumeCheckpointPath)
+			if err != nil {
+				fmt.Printf("Could not resume from checkpoint. Error: %v.\n", err)
+				os.Exit(1)
+			}
+		}
 		if bt.MetaData.LiveTesting {
 			err = bt.ExecuteStrategy(false)
 			if err != nil {
@@ -271,6 +287,21 @@ func parseFlags(wd string) map[string]bool {
 		"strategypluginpath",
 		"",
 		"example path: "+filepath.Join(wd, "plugins", "strategies", "example", "example.so"))
+	flag.Int64Var(
+		&checkpointInterval,
+		"checkpointinterval",
+		0,
+		"when running a single strategy, saves a checkpoint each time this many candles have been processed. Zero disables checkpoints")
+	flag.StringVar(
+		&checkpointPath,
+		"checkpointpath",
+		"",
+		"the path where checkpoints are saved to")
+	flag.StringVar(
+		&resumeCheckpointPath,
+		"resumecheckpointpath",
+		"",
+		"when running a single strategy, resumes the run from the checkpoint saved at this path. The strategy must be the same as the run which saved it")
 	flag.Parse()
 	// collect flags
 	flags := make(map[string]bool)
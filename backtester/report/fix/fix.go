@@ -0,0 +1,201 @@
+package fix
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/shopspring/decimal"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/fill"
+	gctorder "github.com/thrasher-corp/gocryptotrader/exchanges/order"
+)
+
+// NewExporter returns an exporter rendering messages
+// between the sender and target comp IDs
+func NewExporter(senderCompID, targetCompID string) *Exporter {
+	return &Exporter{
+		SenderCompID: senderCompID,
+		TargetCompID: targetCompID,
+		Delimiter:    SOH,
+	}
+}
+
+// ExecutionReport renders the fill as a FIX execution report. Successful
+// fills are rendered as trades and rejected orders as rejections. Fills
+// which did not execute an order, such as those which did nothing, return
+// errNotExecution
+func (e *Exporter) ExecutionReport(f fill.Event) ([]byte, error) {
+	if f == nil {
+		return nil, errNilFill
+	}
+	side, rejected, err := mapSide(f)
+	if err != nil {
+		return nil, err
+	}
+	e.seqNum++
+	seqNum := strconv.FormatInt(e.seqNum, 10)
+	transactTime := f.GetFillTime().UTC().Format(timestampFormat)
+	orderQty := f.GetIntendedAmount()
+	if orderQty.IsZero() {
+		orderQty = f.GetAmount()
+	}
+
+	fields := []field{
+		{tagMsgType, msgTypeExecutionReport},
+		{tagSenderCompID, e.SenderCompID},
+		{tagTargetCompID, e.TargetCompID},
+		{tagMsgSeqNum, seqNum},
+		{tagSendingTime, transactTime},
+	}
+	ordType := ordTypeMarket
+	if o := f.GetOrder(); o != nil {
+		if o.OrderID != "" {
+			fields = append(fields, field{tagOrderID, o.OrderID})
+		}
+		if o.ClientOrderID != "" {
+			fields = append(fields, field{tagClOrdID, o.ClientOrderID})
+		}
+		if o.Type == gctorder.Limit {
+			ordType = ordTypeLimit
+		}
+	}
+	fields = append(fields,
+		field{tagExecID, seqNum},
+		field{tagSymbol, f.Pair().String()},
+		field{tagSecurityExchange, f.GetExchange()},
+		field{tagSide, side},
+		field{tagOrderQty, orderQty.String()},
+		field{tagOrdType, ordType},
+		field{tagPrice, f.GetClosePrice().String()},
+	)
+	if rejected {
+		fields = append(fields,
+			field{tagExecType, execTypeRejected},
+			field{tagOrdStatus, ordStatusRejected},
+			field{tagLastPx, "0"},
+			field{tagLastQty, "0"},
+			field{tagLeavesQty, "0"},
+			field{tagCumQty, "0"},
+			field{tagAvgPx, "0"},
+		)
+	} else {
+		ordStatus := ordStatusFilled
+		leavesQty := orderQty.Sub(f.GetAmount())
+		if leavesQty.IsPositive() {
+			ordStatus = ordStatusPartiallyFilled
+		} else {
+			leavesQty = decimal.Zero
+		}
+		fields = append(fields,
+			field{tagExecType, execTypeTrade},
+			field{tagOrdStatus, ordStatus},
+			field{tagLastPx, f.GetPurchasePrice().String()},
+			field{tagLastQty, f.GetAmount().String()},
+			field{tagLeavesQty, leavesQty.String()},
+			field{tagCumQty, f.GetAmount().String()},
+			field{tagAvgPx, f.GetPurchasePrice().String()},
+			field{tagCommission, f.GetExchangeFee().String()},
+			field{tagCommType, commTypeAbsolute},
+		)
+	}
+	fields = append(fields,
+		field{tagCurrency, f.Pair().Quote.String()},
+		field{tagTransactTime, transactTime},
+	)
+	if reasons := f.GetConcatReasons(); reasons != "" {
+		fields = append(fields, field{tagText, reasons})
+	}
+	return e.render(fields), nil
+}
+
+// Write renders each fill which executed an order as an execution
+// report, writing one message per line
+func (e *Exporter) Write(w io.Writer, fills []fill.Event) error {
+	for i := range fills {
+		msg, err := e.ExecutionReport(fills[i])
+		if err != nil {
+			if errors.Is(err, errNotExecution) {
+				continue
+			}
+			return err
+		}
+		_, err = w.Write(append(msg, '\n'))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// render wraps the fields with the standard header and trailer,
+// calculating the body length and checksum
+func (e *Exporter) render(fields []field) []byte {
+	delimiter := e.Delimiter
+	if delimiter == 0 {
+		delimiter = SOH
+	}
+	var body bytes.Buffer
+	for i := range fields {
+		writeField(&body, fields[i], delimiter)
+	}
+	var msg bytes.Buffer
+	writeField(&msg, field{tagBeginString, BeginString}, delimiter)
+	writeField(&msg, field{tagBodyLength, strconv.Itoa(body.Len())}, delimiter)
+	msg.Write(body.Bytes())
+	writeField(&msg, field{tagCheckSum, checksum(msg.Bytes())}, delimiter)
+	return msg.Bytes()
+}
+
+// writeField writes a tag=value pair, removing any delimiters
+// from the value so it cannot split the field
+func writeField(buf *bytes.Buffer, f field, delimiter byte) {
+	buf.WriteString(strconv.Itoa(f.tag))
+	buf.WriteByte('=')
+	buf.WriteString(strings.ReplaceAll(f.value, string(delimiter), " "))
+	buf.WriteByte(delimiter)
+}
+
+// checksum returns the FIX checksum, the sum of every
+// byte modulo 256, formatted as three digits
+func checksum(b []byte) string {
+	var sum int
+	for i := range b {
+		sum += int(b[i])
+	}
+	return fmt.Sprintf("%03d", sum%256)
+}
+
+// mapSide returns the FIX side of the fill and whether its order was rejected
+func mapSide(f fill.Event) (side string, rejected bool, err error) {
+	switch f.GetDirection() {
+	case gctorder.Buy, gctorder.Bid, gctorder.Long:
+		return sideBuy, false, nil
+	case gctorder.Sell, gctorder.Ask:
+		return sideSell, false, nil
+	case gctorder.Short:
+		return sideSellShort, false, nil
+	case gctorder.CouldNotBuy, gctorder.CouldNotLong, gctorder.CouldNotCloseShort:
+		return sideBuy, true, nil
+	case gctorder.CouldNotSell, gctorder.CouldNotCloseLong:
+		return sideSell, true, nil
+	case gctorder.CouldNotShort:
+		return sideSellShort, true, nil
+	case gctorder.ClosePosition:
+		if o := f.GetOrder(); o != nil {
+			switch {
+			case o.Side.IsLong():
+				return sideBuy, false, nil
+			case o.Side.IsShort():
+				return sideSell, false, nil
+			}
+		}
+		return "", false, fmt.Errorf("%w %v without order details", errUnknownSide, f.GetDirection())
+	case gctorder.DoNothing, gctorder.TransferredFunds, gctorder.MissingData:
+		return "", false, fmt.Errorf("%w %v", errNotExecution, f.GetDirection())
+	default:
+		return "", false, fmt.Errorf("%w %v", errUnknownSide, f.GetDirection())
+	}
+}
@@ -0,0 +1,150 @@
+package fix
+
+import (
+	"bytes"
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/event"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/fill"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	gctorder "github.com/thrasher-corp/gocryptotrader/exchanges/order"
+)
+
+const testExchange = "binance"
+
+func newFill(side gctorder.Side, amount, intended int64) *fill.Fill {
+	return &fill.Fill{
+		Base: &event.Base{
+			Exchange:     testExchange,
+			Time:         time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC),
+			CurrencyPair: currency.NewPair(currency.BTC, currency.USDT),
+			AssetType:    asset.Spot,
+		},
+		Direction:      side,
+		Amount:         decimal.NewFromInt(amount),
+		IntendedAmount: decimal.NewFromInt(intended),
+		ClosePrice:     decimal.NewFromInt(100),
+		PurchasePrice:  decimal.NewFromInt(101),
+		ExchangeFee:    decimal.NewFromFloat(0.5),
+		Order: &gctorder.Detail{
+			OrderID: "1337",
+			Type:    gctorder.Market,
+			Side:    side,
+		},
+	}
+}
+
+func TestExecutionReport(t *testing.T) {
+	t.Parallel()
+	e := NewExporter("BACKTESTER", "VENUE")
+	_, err := e.ExecutionReport(nil)
+	if !errors.Is(err, errNilFill) {
+		t.Errorf("received '%v' expected '%v'", err, errNilFill)
+	}
+
+	e.Delimiter = '|'
+	msg, err := e.ExecutionReport(newFill(gctorder.Buy, 2, 2))
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	body := "35=8|49=BACKTESTER|56=VENUE|34=1|52=20220101-00:00:00.000|37=1337|17=1|55=BTCUSDT|207=binance|54=1|38=2|40=1|44=100|150=F|39=2|31=101|32=2|151=0|14=2|6=101|12=0.5|13=3|15=USDT|60=20220101-00:00:00.000|"
+	header := "8=FIX.4.4|9=" + strconv.Itoa(len(body)) + "|"
+	if !strings.HasPrefix(string(msg), header+body+"10=") {
+		t.Errorf("received '%s' expected prefix '%v'", msg, header+body)
+	}
+	if !strings.HasSuffix(string(msg), "10="+checksum([]byte(header+body))+"|") {
+		t.Errorf("received '%s' expected checksum '%v'", msg, checksum([]byte(header+body)))
+	}
+
+	msg, err = e.ExecutionReport(newFill(gctorder.Sell, 1, 4))
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	for _, expected := range []string{"|34=2|", "|54=2|", "|150=F|", "|39=1|", "|151=3|", "|14=1|"} {
+		if !strings.Contains(string(msg), expected) {
+			t.Errorf("received '%s' expected to contain '%v'", msg, expected)
+		}
+	}
+
+	f := newFill(gctorder.CouldNotBuy, 0, 1)
+	f.AppendReason("insufficient funds")
+	msg, err = e.ExecutionReport(f)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	for _, expected := range []string{"|54=1|", "|150=8|", "|39=8|", "|32=0|", "|58=insufficient funds|"} {
+		if !strings.Contains(string(msg), expected) {
+			t.Errorf("received '%s' expected to contain '%v'", msg, expected)
+		}
+	}
+
+	_, err = e.ExecutionReport(newFill(gctorder.DoNothing, 0, 0))
+	if !errors.Is(err, errNotExecution) {
+		t.Errorf("received '%v' expected '%v'", err, errNotExecution)
+	}
+	f = newFill(gctorder.ClosePosition, 1, 1)
+	f.Order = nil
+	_, err = e.ExecutionReport(f)
+	if !errors.Is(err, errUnknownSide) {
+		t.Errorf("received '%v' expected '%v'", err, errUnknownSide)
+	}
+}
+
+func TestExecutionReportDelimiter(t *testing.T) {
+	t.Parallel()
+	e := &Exporter{}
+	f := newFill(gctorder.Buy, 1, 1)
+	f.AppendReason("a\x01b")
+	msg, err := e.ExecutionReport(f)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	if !bytes.HasPrefix(msg, []byte("8=FIX.4.4\x01")) {
+		t.Errorf("received '%q' expected SOH delimited message", msg)
+	}
+	if !bytes.Contains(msg, []byte("\x0158=a b\x01")) {
+		t.Errorf("received '%q' expected delimiters removed from values", msg)
+	}
+}
+
+func TestWrite(t *testing.T) {
+	t.Parallel()
+	e := NewExporter("BACKTESTER", "VENUE")
+	var buf bytes.Buffer
+	err := e.Write(&buf, []fill.Event{
+		newFill(gctorder.Buy, 1, 1),
+		newFill(gctorder.DoNothing, 0, 0),
+		newFill(gctorder.Sell, 1, 1),
+	})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("received '%v' expected '%v'", len(lines), 2)
+	}
+	if !strings.Contains(lines[1], "\x0134=2\x01") {
+		t.Errorf("received '%q' expected second sequence number", lines[1])
+	}
+
+	err = e.Write(&buf, []fill.Event{newFill(gctorder.UnknownSide, 1, 1)})
+	if !errors.Is(err, errUnknownSide) {
+		t.Errorf("received '%v' expected '%v'", err, errUnknownSide)
+	}
+}
+
+func TestChecksum(t *testing.T) {
+	t.Parallel()
+	if c := checksum(nil); c != "000" {
+		t.Errorf("received '%v' expected '%v'", c, "000")
+	}
+	if c := checksum([]byte{255, 2}); c != "001" {
+		t.Errorf("received '%v' expected '%v'", c, "001")
+	}
+}
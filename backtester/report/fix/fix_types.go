@@ -0,0 +1,86 @@
+package fix
+
+import "errors"
+
+// BeginString is the FIX protocol version rendered by the exporter
+const BeginString = "FIX.4.4"
+
+// SOH is the standard FIX field delimiter
+const SOH = '\x01'
+
+const (
+	msgTypeExecutionReport = "8"
+	timestampFormat        = "20060102-15:04:05.000"
+
+	execTypeTrade    = "F"
+	execTypeRejected = "8"
+
+	ordStatusPartiallyFilled = "1"
+	ordStatusFilled          = "2"
+	ordStatusRejected        = "8"
+
+	sideBuy       = "1"
+	sideSell      = "2"
+	sideSellShort = "5"
+
+	ordTypeMarket = "1"
+	ordTypeLimit  = "2"
+
+	commTypeAbsolute = "3"
+)
+
+// FIX tags rendered in execution reports
+const (
+	tagAvgPx            = 6
+	tagBeginString      = 8
+	tagBodyLength       = 9
+	tagCheckSum         = 10
+	tagClOrdID          = 11
+	tagCommission       = 12
+	tagCommType         = 13
+	tagCumQty           = 14
+	tagCurrency         = 15
+	tagExecID           = 17
+	tagLastPx           = 31
+	tagLastQty          = 32
+	tagMsgSeqNum        = 34
+	tagMsgType          = 35
+	tagOrderID          = 37
+	tagOrderQty         = 38
+	tagOrdStatus        = 39
+	tagOrdType          = 40
+	tagPrice            = 44
+	tagSenderCompID     = 49
+	tagSendingTime      = 52
+	tagSide             = 54
+	tagSymbol           = 55
+	tagTargetCompID     = 56
+	tagText             = 58
+	tagTransactTime     = 60
+	tagExecType         = 150
+	tagLeavesQty        = 151
+	tagSecurityExchange = 207
+)
+
+var (
+	errNilFill      = errors.New("nil fill event")
+	errNotExecution = errors.New("fill is not an order execution")
+	errUnknownSide  = errors.New("unknown order side")
+)
+
+// Exporter renders backtester fills as FIX execution report messages.
+// Each exported message is given the next sequence number
+type Exporter struct {
+	SenderCompID string
+	TargetCompID string
+	// Delimiter separates each field and defaults to SOH. A readable
+	// delimiter such as '|' may be set for logs and inspection
+	Delimiter byte
+	seqNum    int64
+}
+
+// field is a single FIX tag=value pair
+type field struct {
+	tag   int
+	value string
+}
@@ -0,0 +1,230 @@
+package trades
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/shopspring/decimal"
+	"github.com/thrasher-corp/gocryptotrader/backtester/common"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/fill"
+	gctorder "github.com/thrasher-corp/gocryptotrader/exchanges/order"
+)
+
+// NewExporter returns an exporter of closed round trip trades,
+// IncludeOpenTrades can be set to also export trades still open
+func NewExporter() *Exporter {
+	return &Exporter{}
+}
+
+// WriteJSONLines rebuilds the round trip trades made by the fills and writes
+// each as a JSON object on its own line. Fills must be in the order they were
+// executed. Candles are used to calculate MAE and MFE and may be nil
+func (e *Exporter) WriteJSONLines(w io.Writer, fills []fill.Event, candles []common.DataEventHandler) error {
+	trades, err := e.RoundTrips(fills, candles)
+	if err != nil {
+		return err
+	}
+	for i := range trades {
+		line, err := json.Marshal(trades[i])
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(append(line, '\n'))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RoundTrips rebuilds the round trip trades made by the fills in the order
+// they closed, followed by any open trades when enabled. Fills which did not
+// execute an order are ignored, as are spot sales of holdings not bought
+// during the run. Liquidations close the whole position. MAE and MFE are
+// calculated from the trade currency's candles between its entry and exit,
+// and are left unset when there are no candles
+func (e *Exporter) RoundTrips(fills []fill.Event, candles []common.DataEventHandler) ([]Trade, error) {
+	var resp []Trade
+	open := make(map[string]*roundTrip)
+	var keys []string
+	for i := range fills {
+		f := fills[i]
+		if f == nil {
+			return nil, errNilFill
+		}
+		amount := f.GetAmount()
+		if !common.CanTransact(f.GetDirection()) || f.GetOrder() == nil || !amount.IsPositive() {
+			continue
+		}
+		key := f.GetExchange() + f.GetAssetType().String() + f.Pair().String()
+		rt := open[key]
+		isLong := f.GetDirection().IsLong()
+		if f.GetDirection() == gctorder.ClosePosition || f.IsLiquidated() {
+			if rt == nil {
+				continue
+			}
+			isLong = !rt.isLong
+			if f.IsLiquidated() {
+				amount = rt.position.Abs()
+			}
+		}
+		if rt == nil {
+			if !isLong && !f.GetAssetType().IsFutures() {
+				// spot sales of holdings not bought during the run are not round trips
+				continue
+			}
+			open[key] = newRoundTrip(f, isLong, amount, f.GetExchangeFee())
+			keys = append(keys, key)
+			continue
+		}
+		if isLong == rt.isLong {
+			rt.addEntry(f, amount, f.GetExchangeFee())
+			continue
+		}
+		closing := decimal.Min(amount, rt.position.Abs())
+		closingFee := f.GetExchangeFee().Mul(closing).Div(amount)
+		rt.addExit(f, closing, closingFee)
+		if !rt.position.IsZero() {
+			continue
+		}
+		resp = append(resp, rt.trade(candles, false))
+		delete(open, key)
+		remaining := amount.Sub(closing)
+		if remaining.IsPositive() && (isLong || f.GetAssetType().IsFutures()) {
+			// attribute any position flipped through zero to the next round trip
+			open[key] = newRoundTrip(f, isLong, remaining, f.GetExchangeFee().Sub(closingFee))
+		}
+	}
+	if e.IncludeOpenTrades {
+		for i := range keys {
+			if rt, ok := open[keys[i]]; ok {
+				resp = append(resp, rt.trade(candles, true))
+				delete(open, keys[i])
+			}
+		}
+	}
+	return resp, nil
+}
+
+// newRoundTrip opens a round trip with the fill's entry
+func newRoundTrip(f fill.Event, isLong bool, amount, fee decimal.Decimal) *roundTrip {
+	rt := &roundTrip{
+		exchange:  f.GetExchange(),
+		asset:     f.GetAssetType().String(),
+		pair:      f.Pair().String(),
+		isLong:    isLong,
+		entryTime: f.GetFillTime(),
+		tag:       f.GetTag(),
+	}
+	rt.addEntry(f, amount, fee)
+	return rt
+}
+
+// addEntry adds to the round trip's position
+func (rt *roundTrip) addEntry(f fill.Event, amount, fee decimal.Decimal) {
+	rt.entrySize = rt.entrySize.Add(amount)
+	rt.entryValue = rt.entryValue.Add(amount.Mul(f.GetPurchasePrice()))
+	rt.fees = rt.fees.Add(fee)
+	rt.entryFills++
+	if rt.isLong {
+		rt.position = rt.position.Add(amount)
+	} else {
+		rt.position = rt.position.Sub(amount)
+	}
+}
+
+// addExit reduces the round trip's position
+func (rt *roundTrip) addExit(f fill.Event, amount, fee decimal.Decimal) {
+	rt.exitSize = rt.exitSize.Add(amount)
+	rt.exitValue = rt.exitValue.Add(amount.Mul(f.GetPurchasePrice()))
+	rt.fees = rt.fees.Add(fee)
+	rt.exitFills++
+	rt.exitTime = f.GetFillTime()
+	if rt.isLong {
+		rt.position = rt.position.Sub(amount)
+	} else {
+		rt.position = rt.position.Add(amount)
+	}
+}
+
+// trade summarises the round trip. The PNL of open round trips
+// is that realised by any exits made so far, after all fees
+func (rt *roundTrip) trade(candles []common.DataEventHandler, isOpen bool) Trade {
+	entryPrice := rt.entryValue.Div(rt.entrySize)
+	var exitPrice decimal.Decimal
+	if rt.exitSize.IsPositive() {
+		exitPrice = rt.exitValue.Div(rt.exitSize)
+	}
+	costBasis := entryPrice.Mul(rt.exitSize)
+	pnl := rt.exitValue.Sub(costBasis)
+	side := sideLong
+	if !rt.isLong {
+		pnl = pnl.Neg()
+		side = sideShort
+	}
+	pnl = pnl.Sub(rt.fees)
+	t := Trade{
+		Exchange:   rt.exchange,
+		Asset:      rt.asset,
+		Pair:       rt.pair,
+		Side:       side,
+		EntryTime:  rt.entryTime,
+		EntryPrice: entryPrice.InexactFloat64(),
+		ExitPrice:  exitPrice.InexactFloat64(),
+		Size:       rt.entrySize.InexactFloat64(),
+		Fees:       rt.fees.InexactFloat64(),
+		PNL:        pnl.InexactFloat64(),
+		PNLPercent: pnl.Div(rt.entryValue).Mul(decimal.NewFromInt(100)).InexactFloat64(),
+		EntryFills: rt.entryFills,
+		ExitFills:  rt.exitFills,
+		Tag:        rt.tag,
+		IsOpen:     isOpen,
+	}
+	if !isOpen {
+		exitTime := rt.exitTime
+		t.ExitTime = &exitTime
+		t.HoldDuration = exitTime.Sub(rt.entryTime).Seconds()
+	}
+	t.MAEPercent, t.MFEPercent = rt.excursions(entryPrice, candles, isOpen)
+	return t
+}
+
+// excursions returns the maximum adverse and favourable excursions of the
+// round trip's candles from the entry price as positive percentages
+func (rt *roundTrip) excursions(entryPrice decimal.Decimal, candles []common.DataEventHandler, isOpen bool) (mae, mfe *float64) {
+	if entryPrice.IsZero() {
+		return nil, nil
+	}
+	var low, high decimal.Decimal
+	var found bool
+	for i := range candles {
+		c := candles[i]
+		if c == nil ||
+			c.GetExchange() != rt.exchange ||
+			c.GetAssetType().String() != rt.asset ||
+			c.Pair().String() != rt.pair ||
+			c.GetTime().Before(rt.entryTime.Truncate(c.GetInterval().Duration())) ||
+			(!isOpen && c.GetTime().After(rt.exitTime)) {
+			continue
+		}
+		if !found || c.GetLowPrice().LessThan(low) {
+			low = c.GetLowPrice()
+		}
+		if !found || c.GetHighPrice().GreaterThan(high) {
+			high = c.GetHighPrice()
+		}
+		found = true
+	}
+	if !found {
+		return nil, nil
+	}
+	oneHundred := decimal.NewFromInt(100)
+	adverse := entryPrice.Sub(low)
+	favourable := high.Sub(entryPrice)
+	if !rt.isLong {
+		adverse, favourable = high.Sub(entryPrice), entryPrice.Sub(low)
+	}
+	maePercent := decimal.Max(adverse, decimal.Zero).Div(entryPrice).Mul(oneHundred).InexactFloat64()
+	mfePercent := decimal.Max(favourable, decimal.Zero).Div(entryPrice).Mul(oneHundred).InexactFloat64()
+	return &maePercent, &mfePercent
+}
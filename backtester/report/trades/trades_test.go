@@ -0,0 +1,196 @@
+package trades
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/thrasher-corp/gocryptotrader/backtester/common"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/event"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/fill"
+	evkline "github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/kline"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	gctkline "github.com/thrasher-corp/gocryptotrader/exchanges/kline"
+	gctorder "github.com/thrasher-corp/gocryptotrader/exchanges/order"
+)
+
+const testExchange = "binance"
+
+var startTime = time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func newBase(a asset.Item, offset int64) *event.Base {
+	return &event.Base{
+		Exchange:     testExchange,
+		Time:         startTime.Add(time.Duration(offset) * time.Hour),
+		Interval:     gctkline.OneHour,
+		CurrencyPair: currency.NewPair(currency.BTC, currency.USDT),
+		AssetType:    a,
+		Offset:       offset,
+	}
+}
+
+func newFill(a asset.Item, offset int64, side gctorder.Side, amount, price int64) *fill.Fill {
+	return &fill.Fill{
+		Base:          newBase(a, offset),
+		Direction:     side,
+		Amount:        decimal.NewFromInt(amount),
+		PurchasePrice: decimal.NewFromInt(price),
+		ExchangeFee:   decimal.NewFromInt(1),
+		Order:         &gctorder.Detail{Side: side},
+	}
+}
+
+func newCandle(offset, low, high int64) common.DataEventHandler {
+	return &evkline.Kline{
+		Base:  newBase(asset.Spot, offset),
+		Low:   decimal.NewFromInt(low),
+		High:  decimal.NewFromInt(high),
+		Close: decimal.NewFromInt(high),
+	}
+}
+
+func TestRoundTrips(t *testing.T) {
+	t.Parallel()
+	e := NewExporter()
+	_, err := e.RoundTrips([]fill.Event{nil}, nil)
+	if !errors.Is(err, errNilFill) {
+		t.Errorf("received '%v' expected '%v'", err, errNilFill)
+	}
+
+	entry := newFill(asset.Spot, 0, gctorder.Buy, 2, 100)
+	entry.Tag = "trend entry"
+	fills := []fill.Event{
+		// spot sales of holdings not bought during the run are ignored
+		newFill(asset.Spot, 0, gctorder.Sell, 1, 100),
+		newFill(asset.Spot, 0, gctorder.CouldNotBuy, 0, 100),
+		entry,
+		newFill(asset.Spot, 1, gctorder.Buy, 2, 110),
+		newFill(asset.Spot, 2, gctorder.Sell, 1, 120),
+		newFill(asset.Spot, 3, gctorder.Sell, 3, 130),
+		newFill(asset.Spot, 4, gctorder.Buy, 1, 100),
+	}
+	candles := []common.DataEventHandler{
+		newCandle(0, 95, 105),
+		newCandle(1, 90, 112),
+		newCandle(2, 115, 125),
+		newCandle(3, 125, 140),
+		newCandle(4, 50, 200),
+	}
+	trades, err := e.RoundTrips(fills, candles)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	if len(trades) != 1 {
+		t.Fatalf("received '%v' expected '%v'", len(trades), 1)
+	}
+	tr := trades[0]
+	if tr.Side != sideLong || tr.Tag != "trend entry" || tr.IsOpen {
+		t.Errorf("received '%+v' expected closed long trade tagged 'trend entry'", tr)
+	}
+	if tr.EntryPrice != 105 || tr.ExitPrice != 127.5 || tr.Size != 4 {
+		t.Errorf("received entry '%v' exit '%v' size '%v' expected '105' '127.5' '4'", tr.EntryPrice, tr.ExitPrice, tr.Size)
+	}
+	// 510 exit value less 420 entry value less 4 in fees
+	if tr.Fees != 4 || tr.PNL != 86 {
+		t.Errorf("received fees '%v' pnl '%v' expected '4' '86'", tr.Fees, tr.PNL)
+	}
+	if tr.EntryFills != 2 || tr.ExitFills != 2 {
+		t.Errorf("received '%v' '%v' expected '2' '2'", tr.EntryFills, tr.ExitFills)
+	}
+	if tr.ExitTime == nil || !tr.ExitTime.Equal(startTime.Add(3*time.Hour)) || tr.HoldDuration != 3*60*60 {
+		t.Errorf("received exit '%v' hold '%v' expected '%v' '%v'", tr.ExitTime, tr.HoldDuration, startTime.Add(3*time.Hour), 3*60*60)
+	}
+	// lowest low of 90 and highest high of 140 against an entry of 105
+	if tr.MAEPercent == nil || tr.MFEPercent == nil {
+		t.Fatal("expected MAE and MFE to be set")
+	}
+	if *tr.MAEPercent != decimal.NewFromInt(15).Div(decimal.NewFromInt(105)).Mul(decimal.NewFromInt(100)).InexactFloat64() {
+		t.Errorf("received '%v' expected MAE of 15 from 105", *tr.MAEPercent)
+	}
+	if *tr.MFEPercent != decimal.NewFromInt(35).Div(decimal.NewFromInt(105)).Mul(decimal.NewFromInt(100)).InexactFloat64() {
+		t.Errorf("received '%v' expected MFE of 35 from 105", *tr.MFEPercent)
+	}
+
+	e.IncludeOpenTrades = true
+	trades, err = e.RoundTrips(fills, nil)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	if len(trades) != 2 {
+		t.Fatalf("received '%v' expected '%v'", len(trades), 2)
+	}
+	if !trades[1].IsOpen || trades[1].ExitTime != nil || trades[1].MAEPercent != nil || trades[1].PNL != -1 {
+		t.Errorf("received '%+v' expected open trade with no exit", trades[1])
+	}
+}
+
+func TestRoundTripsFlipAndLiquidate(t *testing.T) {
+	t.Parallel()
+	e := NewExporter()
+	short := newFill(asset.Futures, 0, gctorder.Short, 2, 100)
+	flip := newFill(asset.Futures, 1, gctorder.Long, 4, 90)
+	flip.ExchangeFee = decimal.NewFromInt(2)
+	liquidation := newFill(asset.Futures, 2, gctorder.Short, 1, 80)
+	liquidation.Liquidated = true
+	trades, err := e.RoundTrips([]fill.Event{short, flip, liquidation}, nil)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	if len(trades) != 2 {
+		t.Fatalf("received '%v' expected '%v'", len(trades), 2)
+	}
+	// the short covers 2 at 90 with half of the flip's fee
+	if trades[0].Side != sideShort || trades[0].PNL != 18 || trades[0].Fees != 2 {
+		t.Errorf("received '%+v' expected short with pnl 18 and fees 2", trades[0])
+	}
+	// the remaining 2 long is liquidated at 80
+	if trades[1].Side != sideLong || trades[1].Size != 2 || trades[1].PNL != -22 {
+		t.Errorf("received '%+v' expected long of 2 with pnl -22", trades[1])
+	}
+}
+
+func TestWriteJSONLines(t *testing.T) {
+	t.Parallel()
+	e := NewExporter()
+	var buf bytes.Buffer
+	err := e.WriteJSONLines(&buf, []fill.Event{
+		newFill(asset.Spot, 0, gctorder.Buy, 1, 100),
+		newFill(asset.Spot, 1, gctorder.Sell, 1, 110),
+		newFill(asset.Spot, 2, gctorder.Buy, 1, 100),
+		newFill(asset.Spot, 3, gctorder.Sell, 1, 90),
+	}, nil)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	scanner := bufio.NewScanner(&buf)
+	var lines int
+	for scanner.Scan() {
+		lines++
+		var row map[string]interface{}
+		err = json.Unmarshal(scanner.Bytes(), &row)
+		if err != nil {
+			t.Fatalf("received '%v' expected '%v'", err, nil)
+		}
+		for _, key := range []string{"entry_time", "exit_time", "entry_price", "exit_price", "size", "pnl", "fees", "mae_percent", "mfe_percent", "tag"} {
+			if _, ok := row[key]; !ok {
+				t.Errorf("expected key '%v' in '%s'", key, scanner.Bytes())
+			}
+		}
+		if _, ok := row["pnl"].(float64); !ok {
+			t.Errorf("received '%T' expected pnl to be a JSON number", row["pnl"])
+		}
+	}
+	if lines != 2 {
+		t.Errorf("received '%v' expected '%v'", lines, 2)
+	}
+
+	err = e.WriteJSONLines(&buf, []fill.Event{nil}, nil)
+	if !errors.Is(err, errNilFill) {
+		t.Errorf("received '%v' expected '%v'", err, errNilFill)
+	}
+}
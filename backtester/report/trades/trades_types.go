@@ -0,0 +1,72 @@
+package trades
+
+import (
+	"errors"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+const (
+	sideLong  = "long"
+	sideShort = "short"
+)
+
+var errNilFill = errors.New("nil fill event")
+
+// Exporter writes round trip trades rebuilt from fills as JSON Lines, one
+// trade object per line, which can be loaded with pandas' read_json using
+// lines=True. Keys are snake case so that pandas parses the entry and exit
+// times as dates and numbers are written as JSON numbers
+type Exporter struct {
+	// IncludeOpenTrades also exports round trips still open after the
+	// last fill with no exit time. Their exit price and PNL only
+	// reflect any partial exits made, after all fees paid
+	IncludeOpenTrades bool
+}
+
+// Trade is a round trip trade. It opens when a currency's position moves away
+// from zero and closes when the position returns to, or flips through, zero.
+// Prices are the volume weighted average of the entry and exit fills. MAE and
+// MFE are the maximum adverse and favourable excursions of the candles held
+// through as a percentage of the entry price
+type Trade struct {
+	Exchange     string     `json:"exchange"`
+	Asset        string     `json:"asset"`
+	Pair         string     `json:"pair"`
+	Side         string     `json:"side"`
+	EntryTime    time.Time  `json:"entry_time"`
+	ExitTime     *time.Time `json:"exit_time"`
+	EntryPrice   float64    `json:"entry_price"`
+	ExitPrice    float64    `json:"exit_price"`
+	Size         float64    `json:"size"`
+	Fees         float64    `json:"fees"`
+	PNL          float64    `json:"pnl"`
+	PNLPercent   float64    `json:"pnl_percent"`
+	MAEPercent   *float64   `json:"mae_percent"`
+	MFEPercent   *float64   `json:"mfe_percent"`
+	HoldDuration float64    `json:"hold_duration_seconds"`
+	EntryFills   int64      `json:"entry_fills"`
+	ExitFills    int64      `json:"exit_fills"`
+	Tag          string     `json:"tag"`
+	IsOpen       bool       `json:"is_open"`
+}
+
+// roundTrip accumulates the fills of a currency's open round trip
+type roundTrip struct {
+	exchange   string
+	asset      string
+	pair       string
+	isLong     bool
+	entryTime  time.Time
+	exitTime   time.Time
+	position   decimal.Decimal
+	entrySize  decimal.Decimal
+	entryValue decimal.Decimal
+	exitSize   decimal.Decimal
+	exitValue  decimal.Decimal
+	fees       decimal.Decimal
+	entryFills int64
+	exitFills  int64
+	tag        string
+}
@@ -1188,6 +1188,14 @@ func (c *Config) CheckLoggerConfig() error {
 			log.Warnf(log.ConfigMgr, "Logger rotation size invalid, defaulting to %v", log.DefaultMaxFileSize)
 			c.Logging.LoggerFileConfig.MaxSize = log.DefaultMaxFileSize
 		}
+		if c.Logging.LoggerFileConfig.MaxAgeDays < 0 {
+			log.Warnln(log.ConfigMgr, "Logger max age days invalid, disabling max age")
+			c.Logging.LoggerFileConfig.MaxAgeDays = 0
+		}
+		if c.Logging.LoggerFileConfig.MaxBackups < 0 {
+			log.Warnln(log.ConfigMgr, "Logger max backups invalid, disabling max backups")
+			c.Logging.LoggerFileConfig.MaxBackups = 0
+		}
 		log.FileLoggingConfiguredCorrectly = true
 	}
 	log.RWM.Lock()
@@ -1928,6 +1928,8 @@ func TestCheckLoggerConfig(t *testing.T) {
 	c.Logging.LoggerFileConfig.FileName = ""
 	c.Logging.LoggerFileConfig.Rotate = nil
 	c.Logging.LoggerFileConfig.MaxSize = -1
+	c.Logging.LoggerFileConfig.MaxAgeDays = -1
+	c.Logging.LoggerFileConfig.MaxBackups = -1
 	c.Logging.AdvancedSettings.ShowLogSystemName = nil
 
 	err = c.CheckLoggerConfig()
@@ -1938,6 +1940,8 @@ func TestCheckLoggerConfig(t *testing.T) {
 	if c.Logging.LoggerFileConfig.FileName != "log.txt" ||
 		c.Logging.LoggerFileConfig.Rotate == nil ||
 		c.Logging.LoggerFileConfig.MaxSize != 100 ||
+		c.Logging.LoggerFileConfig.MaxAgeDays != 0 ||
+		c.Logging.LoggerFileConfig.MaxBackups != 0 ||
 		c.Logging.AdvancedSettings.ShowLogSystemName == nil ||
 		*c.Logging.AdvancedSettings.ShowLogSystemName {
 		t.Error("unexpected result")
@@ -310,6 +310,21 @@ func (c Code) MarshalJSON() ([]byte, error) {
 	return json.Marshal(c.String())
 }
 
+// GobDecode conforms type to the gob decoder interface, matching the decoded
+// symbol against stored currency items so decoded codes compare as equal
+func (c *Code) GobDecode(d []byte) error {
+	*c = NewCode(string(d))
+	return nil
+}
+
+// GobEncode conforms type to the gob encoder interface
+func (c Code) GobEncode() ([]byte, error) {
+	if c.Item == nil {
+		return nil, nil
+	}
+	return []byte(c.String()), nil
+}
+
 // IsEmpty returns true if the code is empty
 func (c Code) IsEmpty() bool {
 	return c.Item == nil || c.Item.Symbol == ""
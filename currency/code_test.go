@@ -1,6 +1,8 @@
 package currency
 
 import (
+	"bytes"
+	"encoding/gob"
 	"encoding/json"
 	"errors"
 	"testing"
@@ -551,6 +553,43 @@ func TestCodeMarshalJSON(t *testing.T) {
 	}
 }
 
+func TestCodeGob(t *testing.T) {
+	t.Parallel()
+	quickstruct := struct {
+		Codey   Code
+		Lower   Code
+		Empty   Code
+		Mapping map[Pair]bool
+	}{
+		Codey:   BTC,
+		Lower:   ETH.Lower(),
+		Mapping: map[Pair]bool{NewPair(BTC, USDT): true},
+	}
+	var buf bytes.Buffer
+	err := gob.NewEncoder(&buf).Encode(quickstruct)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded := quickstruct
+	decoded.Codey, decoded.Lower, decoded.Mapping = EMPTYCODE, EMPTYCODE, nil
+	err = gob.NewDecoder(&buf).Decode(&decoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !decoded.Codey.Equal(BTC) || decoded.Codey.String() != "BTC" {
+		t.Errorf("received '%v' expected '%v'", decoded.Codey, BTC)
+	}
+	if !decoded.Lower.Equal(ETH) || decoded.Lower.String() != "eth" {
+		t.Errorf("received '%v' expected '%v'", decoded.Lower, "eth")
+	}
+	if !decoded.Empty.IsEmpty() {
+		t.Errorf("received '%v' expected empty code", decoded.Empty)
+	}
+	if !decoded.Mapping[NewPair(BTC, USDT)] {
+		t.Error("expected decoded pair to match map key")
+	}
+}
+
 func TestIsFiatCurrency(t *testing.T) {
 	if EMPTYCODE.IsFiatCurrency() {
 		t.Errorf("TestIsFiatCurrency cannot match currency, %s.", EMPTYCODE)
@@ -514,6 +514,149 @@ func (m *MultiPositionTracker) Liquidate(price decimal.Decimal, t time.Time) err
 	return m.positions[len(m.positions)-1].Liquidate(price, t)
 }
 
+// GetState returns a copy of the tracker's positions and the
+// orders associated with them
+func (m *MultiPositionTracker) GetState() (*MultiPositionTrackerState, error) {
+	if m == nil {
+		return nil, fmt.Errorf("multi-position tracker %w", common.ErrNilPointer)
+	}
+	m.m.Lock()
+	defer m.m.Unlock()
+	resp := &MultiPositionTrackerState{
+		Positions:      make([]PositionTrackerState, len(m.positions)),
+		OrderPositions: make(map[string]int, len(m.orderPositions)),
+	}
+	for i := range m.positions {
+		resp.Positions[i] = m.positions[i].getState()
+		for id, tracker := range m.orderPositions {
+			if tracker == m.positions[i] {
+				resp.OrderPositions[id] = i
+			}
+		}
+	}
+	return resp, nil
+}
+
+// RestoreState replaces the tracker's positions with those of the state,
+// calculating PNL as the tracker was set up to
+func (m *MultiPositionTracker) RestoreState(s *MultiPositionTrackerState) error {
+	if m == nil {
+		return fmt.Errorf("multi-position tracker %w", common.ErrNilPointer)
+	}
+	if s == nil {
+		return errNilTrackerState
+	}
+	m.m.Lock()
+	defer m.m.Unlock()
+	positions := make([]*PositionTracker, len(s.Positions))
+	for i := range s.Positions {
+		if s.Positions[i].Exchange != m.exchange || s.Positions[i].Asset != m.asset {
+			return fmt.Errorf("%w position %v %v does not match tracker %v %v",
+				errInvalidTrackerState, s.Positions[i].Exchange, s.Positions[i].Asset, m.exchange, m.asset)
+		}
+		positions[i] = &PositionTracker{
+			useExchangePNLCalculation: m.useExchangePNLCalculations,
+			offlinePNLCalculation:     m.offlinePNLCalculation,
+		}
+		if m.useExchangePNLCalculations {
+			positions[i].PNLCalculation = m.exchangePNLCalculation
+		} else {
+			positions[i].PNLCalculation = &PNLCalculator{}
+		}
+		positions[i].setState(&s.Positions[i])
+	}
+	orderPositions := make(map[string]*PositionTracker, len(s.OrderPositions))
+	for id, i := range s.OrderPositions {
+		if i < 0 || i >= len(positions) {
+			return fmt.Errorf("%w order %v position %v out of range", errInvalidTrackerState, id, i)
+		}
+		orderPositions[id] = positions[i]
+	}
+	m.positions = positions
+	m.orderPositions = orderPositions
+	return nil
+}
+
+// getState returns a copy of the position tracker's
+// tracking of orders, exposure and PNL
+func (p *PositionTracker) getState() PositionTrackerState {
+	p.m.Lock()
+	defer p.m.Unlock()
+	return PositionTrackerState{
+		Exchange:           p.exchange,
+		Asset:              p.asset,
+		ContractPair:       p.contractPair,
+		Underlying:         p.underlying,
+		CollateralCurrency: p.collateralCurrency,
+		Exposure:           p.exposure,
+		OpeningDirection:   p.openingDirection,
+		OpeningPrice:       p.openingPrice,
+		OpeningSize:        p.openingSize,
+		OpeningDate:        p.openingDate,
+		LatestDirection:    p.latestDirection,
+		LatestPrice:        p.latestPrice,
+		LastUpdated:        p.lastUpdated,
+		UnrealisedPNL:      p.unrealisedPNL,
+		RealisedPNL:        p.realisedPNL,
+		Status:             p.status,
+		ClosingPrice:       p.closingPrice,
+		ClosingDate:        p.closingDate,
+		ShortPositions:     copyDetails(p.shortPositions),
+		LongPositions:      copyDetails(p.longPositions),
+		PNLHistory:         append([]PNLResult(nil), p.pnlHistory...),
+		FundingRateDetails: copyFundingRates(p.fundingRateDetails),
+	}
+}
+
+// setState sets the position tracker's tracking
+// of orders, exposure and PNL from a copy of the state
+func (p *PositionTracker) setState(s *PositionTrackerState) {
+	p.exchange = s.Exchange
+	p.asset = s.Asset
+	p.contractPair = s.ContractPair
+	p.underlying = s.Underlying
+	p.collateralCurrency = s.CollateralCurrency
+	p.exposure = s.Exposure
+	p.openingDirection = s.OpeningDirection
+	p.openingPrice = s.OpeningPrice
+	p.openingSize = s.OpeningSize
+	p.openingDate = s.OpeningDate
+	p.latestDirection = s.LatestDirection
+	p.latestPrice = s.LatestPrice
+	p.lastUpdated = s.LastUpdated
+	p.unrealisedPNL = s.UnrealisedPNL
+	p.realisedPNL = s.RealisedPNL
+	p.status = s.Status
+	p.closingPrice = s.ClosingPrice
+	p.closingDate = s.ClosingDate
+	p.shortPositions = copyDetails(s.ShortPositions)
+	p.longPositions = copyDetails(s.LongPositions)
+	p.pnlHistory = append([]PNLResult(nil), s.PNLHistory...)
+	p.fundingRateDetails = copyFundingRates(s.FundingRateDetails)
+}
+
+// copyDetails returns a copy of the order details
+func copyDetails(d []Detail) []Detail {
+	if d == nil {
+		return nil
+	}
+	resp := make([]Detail, len(d))
+	for i := range d {
+		resp[i] = d[i].Copy()
+	}
+	return resp
+}
+
+// copyFundingRates returns a copy of the funding rate details
+func copyFundingRates(f *FundingRates) *FundingRates {
+	if f == nil {
+		return nil
+	}
+	cpy := *f
+	cpy.FundingRates = append([]FundingRate(nil), f.FundingRates...)
+	return &cpy
+}
+
 // GetStats returns a summary of a future position
 func (p *PositionTracker) GetStats() *Position {
 	if p == nil {
@@ -1135,6 +1135,91 @@ func TestMPTLiquidate(t *testing.T) {
 	}
 }
 
+func TestMPTGetRestoreState(t *testing.T) {
+	t.Parallel()
+	item := asset.Futures
+	pair, err := currency.NewPairFromStrings("BTC", "1231")
+	if !errors.Is(err, nil) {
+		t.Error(err)
+	}
+	e := &MultiPositionTracker{
+		exchange:       testExchange,
+		asset:          item,
+		orderPositions: make(map[string]*PositionTracker),
+	}
+	tt := time.Now()
+	err = e.TrackNewOrder(&Detail{
+		Date:      tt,
+		Exchange:  testExchange,
+		Pair:      pair,
+		AssetType: item,
+		Side:      Long,
+		OrderID:   "lol",
+		Price:     1,
+		Amount:    1,
+	})
+	if !errors.Is(err, nil) {
+		t.Fatal(err)
+	}
+	s, err := e.GetState()
+	if !errors.Is(err, nil) {
+		t.Fatal(err)
+	}
+	if len(s.Positions) != 1 || s.OrderPositions["lol"] != 0 {
+		t.Fatalf("received '%+v' expected one position holding order 'lol'", s)
+	}
+
+	err = e.TrackNewOrder(&Detail{
+		Date:      tt.Add(time.Minute),
+		Exchange:  testExchange,
+		Pair:      pair,
+		AssetType: item,
+		Side:      Long,
+		OrderID:   "lol2",
+		Price:     1,
+		Amount:    1,
+	})
+	if !errors.Is(err, nil) {
+		t.Fatal(err)
+	}
+
+	restored := &MultiPositionTracker{
+		exchange:       testExchange,
+		asset:          item,
+		orderPositions: make(map[string]*PositionTracker),
+	}
+	err = restored.RestoreState(s)
+	if !errors.Is(err, nil) {
+		t.Fatal(err)
+	}
+	if len(restored.positions) != 1 || restored.orderPositions["lol"] != restored.positions[0] {
+		t.Fatalf("received '%v' expected one position holding order 'lol'", restored.positions)
+	}
+	if !restored.positions[0].exposure.Equal(decimal.NewFromInt(1)) {
+		t.Errorf("received '%v' expected '%v'", restored.positions[0].exposure, 1)
+	}
+	if _, ok := restored.positions[0].PNLCalculation.(*PNLCalculator); !ok {
+		t.Errorf("received '%T' expected '%T'", restored.positions[0].PNLCalculation, &PNLCalculator{})
+	}
+
+	s.OrderPositions["lol"] = 1
+	err = restored.RestoreState(s)
+	if !errors.Is(err, errInvalidTrackerState) {
+		t.Errorf("received '%v' expected '%v'", err, errInvalidTrackerState)
+	}
+
+	err = restored.RestoreState(nil)
+	if !errors.Is(err, errNilTrackerState) {
+		t.Errorf("received '%v' expected '%v'", err, errNilTrackerState)
+	}
+
+	e = nil
+	_, err = e.GetState()
+	if !errors.Is(err, common.ErrNilPointer) {
+		t.Errorf("received '%v' expected '%v'", err, common.ErrNilPointer)
+	}
+}
+
 func TestPositionLiquidate(t *testing.T) {
 	t.Parallel()
 	item := asset.Futures
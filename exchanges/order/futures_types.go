@@ -49,6 +49,8 @@ var (
 	errCannotCalculateUnrealisedPNL   = errors.New("cannot calculate unrealised PNL")
 	errDoesntMatch                    = errors.New("doesn't match")
 	errCannotTrackInvalidParams       = errors.New("parameters set incorrectly, cannot track")
+	errNilTrackerState                = errors.New("nil tracker state received")
+	errInvalidTrackerState            = errors.New("invalid tracker state")
 )
 
 // PNLCalculation is an interface to allow multiple
@@ -212,6 +214,42 @@ type PositionTrackerSetup struct {
 	PNLCalculator             PNLCalculation
 }
 
+// MultiPositionTrackerState is a copy of a multi position tracker's positions
+// which can be serialised and restored to a tracker set up for the same
+// exchange, asset and pair
+type MultiPositionTrackerState struct {
+	Positions []PositionTrackerState
+	// OrderPositions maps order IDs to the index of their position
+	OrderPositions map[string]int
+}
+
+// PositionTrackerState is a copy of a position tracker's tracking of orders,
+// exposure and PNL
+type PositionTrackerState struct {
+	Exchange           string
+	Asset              asset.Item
+	ContractPair       currency.Pair
+	Underlying         currency.Code
+	CollateralCurrency currency.Code
+	Exposure           decimal.Decimal
+	OpeningDirection   Side
+	OpeningPrice       decimal.Decimal
+	OpeningSize        decimal.Decimal
+	OpeningDate        time.Time
+	LatestDirection    Side
+	LatestPrice        decimal.Decimal
+	LastUpdated        time.Time
+	UnrealisedPNL      decimal.Decimal
+	RealisedPNL        decimal.Decimal
+	Status             Status
+	ClosingPrice       decimal.Decimal
+	ClosingDate        time.Time
+	ShortPositions     []Detail
+	LongPositions      []Detail
+	PNLHistory         []PNLResult
+	FundingRateDetails *FundingRates
+}
+
 // TotalCollateralCalculator holds many collateral calculators
 // to calculate total collateral standing with one struct
 type TotalCollateralCalculator struct {
@@ -237,10 +237,11 @@ func (r *Requester) doRequest(ctx context.Context, endpoint EndpointLimit, newRe
 
 		if resp.StatusCode < http.StatusOK ||
 			resp.StatusCode > http.StatusAccepted {
-			return fmt.Errorf("%s unsuccessful HTTP status code: %d raw response: %s",
-				r.name,
-				resp.StatusCode,
-				string(contents))
+			return &HTTPStatusError{
+				Name:       r.name,
+				StatusCode: resp.StatusCode,
+				Body:       string(contents),
+			}
 		}
 
 		if p.HTTPDebugging {
@@ -292,6 +292,17 @@ func TestDoRequest(t *testing.T) {
 		t.Fatalf("received: %v but expected: %v", err, nil)
 	}
 
+	err = r.SendPayload(ctx, UnAuth, func() (*Item, error) {
+		return &Item{Path: testURL + "/error"}, nil
+	})
+	var statusErr *HTTPStatusError
+	if !errors.As(err, &statusErr) {
+		t.Fatalf("received: %v but expected: %T", err, statusErr)
+	}
+	if statusErr.StatusCode != http.StatusBadRequest {
+		t.Fatalf("received: %v but expected: %v", statusErr.StatusCode, http.StatusBadRequest)
+	}
+
 	// Fail new request call
 	newError := errors.New("request item failure")
 	err = r.SendPayload(ctx, UnAuth, func() (*Item, error) {
@@ -1,6 +1,7 @@
 package request
 
 import (
+	"fmt"
 	"io"
 	"net/http"
 	"time"
@@ -75,4 +76,20 @@ type RequesterOption func(*Requester)
 // requests.
 type Generate func() (*Item, error)
 
+// HTTPStatusError is returned when a request receives an unsuccessful HTTP
+// status code so that callers can act on the status code itself
+type HTTPStatusError struct {
+	Name       string
+	StatusCode int
+	Body       string
+}
+
+// Error implements the error interface
+func (e *HTTPStatusError) Error() string {
+	return fmt.Sprintf("%s unsuccessful HTTP status code: %d raw response: %s",
+		e.Name,
+		e.StatusCode,
+		e.Body)
+}
+
 type verbosity string
@@ -0,0 +1,82 @@
+package log
+
+import (
+	"errors"
+	"fmt"
+	"sync/atomic"
+)
+
+var (
+	errInvalidChannelCapacity   = errors.New("channel capacity must be greater than zero")
+	errInvalidChannelFullPolicy = errors.New("invalid channel full policy")
+	errChannelWriterClosed      = errors.New("channel writer closed")
+)
+
+// NewChannelWriter returns a writer publishing log lines to a channel buffering
+// up to capacity lines, handling a full channel according to the policy
+func NewChannelWriter(capacity int, policy ChannelFullPolicy) (*ChannelWriter, error) {
+	if capacity <= 0 {
+		return nil, fmt.Errorf("%w, received %v", errInvalidChannelCapacity, capacity)
+	}
+	if policy != DropWhenFull && policy != BlockWhenFull {
+		return nil, fmt.Errorf("%w %v", errInvalidChannelFullPolicy, policy)
+	}
+	return &ChannelWriter{
+		ch:     make(chan string, capacity),
+		policy: policy,
+		done:   make(chan struct{}),
+	}, nil
+}
+
+// Channel returns the channel log lines are published to. It is
+// closed once the writer is closed and its remaining lines consumed
+func (c *ChannelWriter) Channel() <-chan string {
+	return c.ch
+}
+
+// Write implementation to satisfy io.Writer. The line is copied as the
+// logger reuses its buffer. Lines dropped on a full channel are not
+// treated as errors so that other writers are unaffected
+func (c *ChannelWriter) Write(p []byte) (int, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.closed {
+		return 0, errChannelWriterClosed
+	}
+	line := string(p)
+	if c.policy == BlockWhenFull {
+		select {
+		case c.ch <- line:
+			return len(p), nil
+		case <-c.done:
+			return 0, errChannelWriterClosed
+		}
+	}
+	select {
+	case c.ch <- line:
+	default:
+		atomic.AddUint64(&c.dropped, 1)
+	}
+	return len(p), nil
+}
+
+// Dropped returns how many lines were discarded while the channel was full
+func (c *ChannelWriter) Dropped() uint64 {
+	return atomic.LoadUint64(&c.dropped)
+}
+
+// Close stops publishing log lines, releasing any writes blocked on a full
+// channel, and closes the channel. The writer should be removed from any
+// multiwriter it was added to as subsequent writes return an error
+func (c *ChannelWriter) Close() error {
+	err := errChannelWriterClosed
+	c.once.Do(func() {
+		close(c.done)
+		c.mu.Lock()
+		c.closed = true
+		close(c.ch)
+		c.mu.Unlock()
+		err = nil
+	})
+	return err
+}
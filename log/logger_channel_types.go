@@ -0,0 +1,31 @@
+package log
+
+import (
+	"sync"
+)
+
+// ChannelFullPolicy determines how a ChannelWriter handles
+// log lines written while its channel is full
+type ChannelFullPolicy uint8
+
+const (
+	// DropWhenFull discards log lines written while the channel is full,
+	// counting them so that the consumer can detect lost output
+	DropWhenFull ChannelFullPolicy = iota
+	// BlockWhenFull waits for the consumer to make room in the channel.
+	// Logging is held up until it does, so the channel must be consumed
+	BlockWhenFull
+)
+
+// ChannelWriter satisfies io.Writer by publishing each log line to a buffered
+// channel, allowing an embedding application to consume log output in-process.
+// It is safe for concurrent use, so it can be added to a multiwriter directly
+type ChannelWriter struct {
+	ch      chan string
+	policy  ChannelFullPolicy
+	dropped uint64
+	closed  bool
+	done    chan struct{}
+	once    sync.Once
+	mu      sync.RWMutex
+}
@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/thrasher-corp/gocryptotrader/common/file"
@@ -34,8 +36,8 @@ func (r *Rotate) Write(output []byte) (n int, err error) {
 		}
 	}
 
-	if *r.Rotate {
-		if r.size+outputLen > r.maxSize() {
+	if r.rotationEnabled() {
+		if r.size+outputLen > r.maxSize() || r.exceedsMaxAge() {
 			err = r.rotateFile()
 			if err != nil {
 				return 0, err
@@ -58,8 +60,9 @@ func (r *Rotate) openOrCreateFile(n int64) error {
 		return fmt.Errorf("error opening log file info: %s", err)
 	}
 
-	if *r.Rotate {
-		if info.Size()+n >= r.maxSize() {
+	if r.rotationEnabled() {
+		if info.Size()+n >= r.maxSize() ||
+			(r.maxAge() > 0 && time.Since(info.ModTime()) > r.maxAge()) {
 			return r.rotateFile()
 		}
 	}
@@ -71,6 +74,9 @@ func (r *Rotate) openOrCreateFile(n int64) error {
 
 	r.output = file
 	r.size = info.Size()
+	// creation time is not available across platforms, so the last
+	// modification time is used to age an existing file
+	r.opened = info.ModTime()
 
 	return nil
 }
@@ -83,13 +89,17 @@ func (r *Rotate) openNew() error {
 	_, err := os.Stat(name)
 
 	if err == nil {
-		timestamp := time.Now().Format("2006-01-02T15-04-05")
+		timestamp := time.Now().Format(backupTimestampFormat)
 		newName := filepath.Join(LogPath, timestamp+"-"+r.FileName)
 
 		err = file.Move(name, newName)
 		if err != nil {
 			return fmt.Errorf("can't rename log file: %s", err)
 		}
+		err = r.removeOldBackups()
+		if err != nil {
+			return err
+		}
 	}
 
 	file, err := os.OpenFile(name, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
@@ -99,6 +109,7 @@ func (r *Rotate) openNew() error {
 
 	r.output = file
 	r.size = 0
+	r.opened = time.Now()
 	return nil
 }
 
@@ -126,6 +137,58 @@ func (r *Rotate) rotateFile() (err error) {
 	return r.openNew()
 }
 
+// removeOldBackups removes rotated log files which exceed MaxBackups or are
+// older than MaxAgeDays, keeping the most recent backups
+func (r *Rotate) removeOldBackups() error {
+	if r.MaxBackups <= 0 && r.maxAge() <= 0 {
+		return nil
+	}
+	entries, err := os.ReadDir(LogPath)
+	if err != nil {
+		return fmt.Errorf("can't read log directory: %s", err)
+	}
+	suffix := "-" + r.FileName
+	var backups []logBackup
+	for i := range entries {
+		if entries[i].IsDir() || !strings.HasSuffix(entries[i].Name(), suffix) {
+			continue
+		}
+		timestamp, err := time.ParseInLocation(backupTimestampFormat, strings.TrimSuffix(entries[i].Name(), suffix), time.Local)
+		if err != nil {
+			// not a backup created by this rotator
+			continue
+		}
+		backups = append(backups, logBackup{name: entries[i].Name(), timestamp: timestamp})
+	}
+	sort.Slice(backups, func(i, j int) bool {
+		return backups[i].timestamp.After(backups[j].timestamp)
+	})
+	cutoff := time.Now().Add(-r.maxAge())
+	for i := range backups {
+		if (r.MaxBackups <= 0 || i < r.MaxBackups) &&
+			(r.maxAge() <= 0 || !backups[i].timestamp.Before(cutoff)) {
+			continue
+		}
+		err = os.Remove(filepath.Join(LogPath, backups[i].name))
+		if err != nil {
+			return fmt.Errorf("can't remove log backup: %s", err)
+		}
+	}
+	return nil
+}
+
+func (r *Rotate) rotationEnabled() bool {
+	return r.Rotate != nil && *r.Rotate
+}
+
+func (r *Rotate) exceedsMaxAge() bool {
+	return r.maxAge() > 0 && !r.opened.IsZero() && time.Since(r.opened) > r.maxAge()
+}
+
+func (r *Rotate) maxAge() time.Duration {
+	return time.Duration(r.MaxAgeDays) * day
+}
+
 func (r *Rotate) maxSize() int64 {
 	if r.MaxSize == 0 {
 		return defaultMaxSize * megabyte
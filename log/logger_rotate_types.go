@@ -3,20 +3,37 @@ package log
 import (
 	"os"
 	"sync"
+	"time"
 )
 
 const (
 	defaultMaxSize int64 = 250
 	megabyte       int64 = 1024 * 1024
+	day                  = 24 * time.Hour
+
+	backupTimestampFormat = "2006-01-02T15-04-05"
 )
 
-// Rotate struct for each instance of Rotate
+// Rotate struct for each instance of Rotate. It satisfies io.Writer and is
+// safe for concurrent use, so it can be added to a multiwriter directly.
+// MaxAgeDays rotates the active file once it is older than the number of days
+// and removes backups older than it. MaxBackups limits how many rotated files
+// are kept. Zero values disable each setting
 type Rotate struct {
-	FileName string
-	Rotate   *bool
-	MaxSize  int64
+	FileName   string
+	Rotate     *bool
+	MaxSize    int64
+	MaxAgeDays int
+	MaxBackups int
 
 	size   int64
+	opened time.Time
 	output *os.File
 	mu     sync.Mutex
 }
+
+// logBackup is a rotated log file and the time it was rotated
+type logBackup struct {
+	name      string
+	timestamp time.Time
+}
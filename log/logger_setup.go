@@ -106,9 +106,11 @@ func SetupGlobalLogger() error {
 
 	if FileLoggingConfiguredCorrectly {
 		GlobalLogFile = &Rotate{
-			FileName: GlobalLogConfig.LoggerFileConfig.FileName,
-			MaxSize:  GlobalLogConfig.LoggerFileConfig.MaxSize,
-			Rotate:   GlobalLogConfig.LoggerFileConfig.Rotate,
+			FileName:   GlobalLogConfig.LoggerFileConfig.FileName,
+			MaxSize:    GlobalLogConfig.LoggerFileConfig.MaxSize,
+			Rotate:     GlobalLogConfig.LoggerFileConfig.Rotate,
+			MaxAgeDays: GlobalLogConfig.LoggerFileConfig.MaxAgeDays,
+			MaxBackups: GlobalLogConfig.LoggerFileConfig.MaxBackups,
 		}
 	}
 
@@ -6,8 +6,10 @@ import (
 	"io"
 	"log"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/thrasher-corp/gocryptotrader/common/convert"
 )
@@ -638,3 +640,223 @@ func TestOpenNew(t *testing.T) {
 		t.Fatalf("received: %v but expected: %v", err, nil)
 	}
 }
+
+func TestRemoveOldBackups(t *testing.T) {
+	t.Parallel()
+	r := Rotate{FileName: "backups.txt"}
+	err := r.removeOldBackups()
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: %v but expected: %v", err, nil)
+	}
+
+	tn := time.Now()
+	var names []string
+	for i := 0; i < 4; i++ {
+		name := tn.Add(-time.Duration(i)*time.Hour*20).Format(backupTimestampFormat) + "-" + r.FileName
+		err = os.WriteFile(filepath.Join(LogPath, name), []byte("test"), 0o600)
+		if err != nil {
+			t.Fatal(err)
+		}
+		names = append(names, name)
+	}
+
+	r.MaxBackups = 3
+	err = r.removeOldBackups()
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: %v but expected: %v", err, nil)
+	}
+	if _, err = os.Stat(filepath.Join(LogPath, names[3])); !os.IsNotExist(err) {
+		t.Errorf("expected oldest backup %v to be removed", names[3])
+	}
+
+	r.MaxBackups = 0
+	r.MaxAgeDays = 1
+	err = r.removeOldBackups()
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: %v but expected: %v", err, nil)
+	}
+	if _, err = os.Stat(filepath.Join(LogPath, names[2])); !os.IsNotExist(err) {
+		t.Errorf("expected expired backup %v to be removed", names[2])
+	}
+	for i := 0; i < 2; i++ {
+		if _, err = os.Stat(filepath.Join(LogPath, names[i])); err != nil {
+			t.Errorf("expected backup %v to be kept, received: %v", names[i], err)
+		}
+	}
+}
+
+func TestRotateMaxAge(t *testing.T) {
+	t.Parallel()
+	r := Rotate{Rotate: convert.BoolPtr(true), FileName: "maxage.txt", MaxAgeDays: 1}
+	_, err := r.Write([]byte("test"))
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: %v but expected: %v", err, nil)
+	}
+	if r.exceedsMaxAge() {
+		t.Error("expected new file to be within max age")
+	}
+
+	r.opened = time.Now().Add(-day - time.Hour)
+	if !r.exceedsMaxAge() {
+		t.Error("expected file to exceed max age")
+	}
+	_, err = r.Write([]byte("test"))
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: %v but expected: %v", err, nil)
+	}
+	if r.size != 4 {
+		t.Errorf("received: %v but expected: %v", r.size, 4)
+	}
+	if time.Since(r.opened) > time.Minute {
+		t.Error("expected file to be rotated")
+	}
+	err = r.Close()
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: %v but expected: %v", err, nil)
+	}
+}
+
+func TestNewChannelWriter(t *testing.T) {
+	t.Parallel()
+	_, err := NewChannelWriter(0, DropWhenFull)
+	if !errors.Is(err, errInvalidChannelCapacity) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errInvalidChannelCapacity)
+	}
+	_, err = NewChannelWriter(1, 2)
+	if !errors.Is(err, errInvalidChannelFullPolicy) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errInvalidChannelFullPolicy)
+	}
+	cw, err := NewChannelWriter(1, BlockWhenFull)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if cap(cw.Channel()) != 1 {
+		t.Errorf("received: '%v' but expected: '%v'", cap(cw.Channel()), 1)
+	}
+}
+
+func TestChannelWriterDropWhenFull(t *testing.T) {
+	t.Parallel()
+	cw, err := NewChannelWriter(1, DropWhenFull)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	payload := []byte("first")
+	n, err := cw.Write(payload)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if n != len(payload) {
+		t.Errorf("received: '%v' but expected: '%v'", n, len(payload))
+	}
+	// the logger reuses its buffer so published lines must be copies
+	payload[0] = 'F'
+	_, err = cw.Write([]byte("second"))
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if cw.Dropped() != 1 {
+		t.Errorf("received: '%v' but expected: '%v'", cw.Dropped(), 1)
+	}
+	if line := <-cw.Channel(); line != "first" {
+		t.Errorf("received: '%v' but expected: '%v'", line, "first")
+	}
+
+	err = cw.Close()
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if _, ok := <-cw.Channel(); ok {
+		t.Error("expected channel to be closed")
+	}
+	_, err = cw.Write(payload)
+	if !errors.Is(err, errChannelWriterClosed) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errChannelWriterClosed)
+	}
+	err = cw.Close()
+	if !errors.Is(err, errChannelWriterClosed) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errChannelWriterClosed)
+	}
+}
+
+func TestChannelWriterBlockWhenFull(t *testing.T) {
+	t.Parallel()
+	cw, err := NewChannelWriter(1, BlockWhenFull)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	_, err = cw.Write([]byte("first"))
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	written := make(chan error)
+	go func() {
+		_, err := cw.Write([]byte("second"))
+		written <- err
+	}()
+	select {
+	case err = <-written:
+		t.Fatalf("expected write to block on a full channel, received: '%v'", err)
+	case <-time.After(time.Millisecond * 50):
+	}
+	if line := <-cw.Channel(); line != "first" {
+		t.Errorf("received: '%v' but expected: '%v'", line, "first")
+	}
+	if err = <-written; !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if line := <-cw.Channel(); line != "second" {
+		t.Errorf("received: '%v' but expected: '%v'", line, "second")
+	}
+
+	_, err = cw.Write([]byte("third"))
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	go func() {
+		_, err := cw.Write([]byte("fourth"))
+		written <- err
+	}()
+	time.Sleep(time.Millisecond * 50)
+	err = cw.Close()
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if err = <-written; !errors.Is(err, errChannelWriterClosed) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errChannelWriterClosed)
+	}
+	if cw.Dropped() != 0 {
+		t.Errorf("received: '%v' but expected: '%v'", cw.Dropped(), 0)
+	}
+}
+
+func TestSubLoggerAddOutput(t *testing.T) {
+	t.Parallel()
+	sl := &SubLogger{name: "CHANNELTEST", output: io.Discard, levels: splitLevel("INFO")}
+	cw, err := NewChannelWriter(10, DropWhenFull)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	err = sl.AddOutput(cw)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	mw, ok := sl.output.(*multiWriterHolder)
+	if !ok {
+		t.Fatalf("received: '%T' but expected: '%T'", sl.output, &multiWriterHolder{})
+	}
+	if len(mw.writers) != 2 {
+		t.Errorf("received: '%v' but expected: '%v'", len(mw.writers), 2)
+	}
+	err = sl.AddOutput(cw)
+	if !errors.Is(err, errWriterAlreadyLoaded) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errWriterAlreadyLoaded)
+	}
+	_, err = mw.Write([]byte("hello"))
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if line := <-cw.Channel(); line != "hello" {
+		t.Errorf("received: '%v' but expected: '%v'", line, "hello")
+	}
+}
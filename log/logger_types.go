@@ -68,9 +68,11 @@ type SubLoggerConfig struct {
 }
 
 type loggerFileConfig struct {
-	FileName string `json:"filename,omitempty"`
-	Rotate   *bool  `json:"rotate,omitempty"`
-	MaxSize  int64  `json:"maxsize,omitempty"`
+	FileName   string `json:"filename,omitempty"`
+	Rotate     *bool  `json:"rotate,omitempty"`
+	MaxSize    int64  `json:"maxsize,omitempty"`
+	MaxAgeDays int    `json:"maxagedays,omitempty"`
+	MaxBackups int    `json:"maxbackups,omitempty"`
 }
 
 // Logger each instance of logger settings
@@ -28,6 +28,26 @@ func (sl *SubLogger) SetOutput(o io.Writer) {
 	sl.mtx.Unlock()
 }
 
+// AddOutput adds a writer alongside the sub logger's existing output
+// via its multiwriter, creating one when the output is a single writer
+func (sl *SubLogger) AddOutput(o io.Writer) error {
+	sl.mtx.Lock()
+	defer sl.mtx.Unlock()
+	if mw, ok := sl.output.(*multiWriterHolder); ok {
+		return mw.Add(o)
+	}
+	var writers []io.Writer
+	if sl.output != nil {
+		writers = append(writers, sl.output)
+	}
+	mw, err := multiWriter(append(writers, o)...)
+	if err != nil {
+		return err
+	}
+	sl.output = mw
+	return nil
+}
+
 // SetLevels overrides the default levels with new levels; levelception
 func (sl *SubLogger) SetLevels(newLevels Levels) {
 	sl.mtx.Lock()